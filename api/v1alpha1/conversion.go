@@ -0,0 +1,338 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+// ConvertTo converts the IonosCloudCluster to the hub version (v1beta1).
+func (src *IonosCloudCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudCluster)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	restored := &infrav1.IonosCloudCluster{}
+	if ok, err := utilconversion.UnmarshalData(dst, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Labels = restored.Spec.Labels
+	dst.Spec.BootstrapStorage = restored.Spec.BootstrapStorage
+	dst.Spec.ApplicationLoadBalancer = restored.Spec.ApplicationLoadBalancer
+	dst.Spec.Networks = restored.Spec.Networks
+	dst.Spec.SpreadStrategy = restored.Spec.SpreadStrategy
+	dst.Spec.IdentityRef = restored.Spec.IdentityRef
+	dst.Spec.IPBlocks = restored.Spec.IPBlocks
+	dst.Spec.Timeouts = restored.Spec.Timeouts
+	dst.Spec.MachineDefaults = restored.Spec.MachineDefaults
+	dst.Spec.DNS = restored.Spec.DNS
+	if restored.Spec.CredentialsRef == nil {
+		dst.Spec.CredentialsRef = nil
+	}
+	dst.Status.ApplicationLoadBalancerID = restored.Status.ApplicationLoadBalancerID
+	dst.Status.ApplicationLoadBalancerIPBlockID = restored.Status.ApplicationLoadBalancerIPBlockID
+	dst.Status.ApplicationLoadBalancerIP = restored.Status.ApplicationLoadBalancerIP
+	dst.Status.NetworkDatacenterIDs = restored.Status.NetworkDatacenterIDs
+	dst.Status.IPBlocks = restored.Status.IPBlocks
+	dst.Status.RetainedPrivateIPs = restored.Status.RetainedPrivateIPs
+	dst.Status.PinnedImages = restored.Status.PinnedImages
+	dst.Status.IPv6Prefixes = restored.Status.IPv6Prefixes
+	dst.Status.IPv6CIDRBlocks = restored.Status.IPv6CIDRBlocks
+	dst.Status.Networks = restored.Status.Networks
+	dst.Status.ControlPlaneEndpointDNS = restored.Status.ControlPlaneEndpointDNS
+	restoreRequestTargets(restored.Status.CurrentClusterRequest, dst.Status.CurrentClusterRequest)
+	for datacenterID, req := range dst.Status.CurrentRequestByDatacenter {
+		if restoredReq, ok := restored.Status.CurrentRequestByDatacenter[datacenterID]; ok {
+			restoreRequestTargets(&restoredReq, &req)
+			dst.Status.CurrentRequestByDatacenter[datacenterID] = req
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudCluster.
+func (dst *IonosCloudCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudCluster)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts the IonosCloudClusterList to the hub version (v1beta1).
+func (src *IonosCloudClusterList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudClusterList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudClusterList.
+func (dst *IonosCloudClusterList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudClusterList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertTo converts the IonosCloudMachine to the hub version (v1beta1).
+func (src *IonosCloudMachine) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachine)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	restored := &infrav1.IonosCloudMachine{}
+	if ok, err := utilconversion.UnmarshalData(dst, restored); err != nil || !ok {
+		return err
+	}
+
+	restoreMachineSpec(&restored.Spec, &dst.Spec)
+	restoreMachineStatus(&restored.Status, &dst.Status)
+	restoreRequestTargets(restored.Status.CurrentRequest, dst.Status.CurrentRequest)
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachine.
+func (dst *IonosCloudMachine) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachine)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts the IonosCloudMachineList to the hub version (v1beta1).
+func (src *IonosCloudMachineList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachineList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachineList.
+func (dst *IonosCloudMachineList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachineList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertTo converts the IonosCloudMachineTemplate to the hub version (v1beta1).
+func (src *IonosCloudMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachineTemplate)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	restored := &infrav1.IonosCloudMachineTemplate{}
+	if ok, err := utilconversion.UnmarshalData(dst, restored); err != nil || !ok {
+		return err
+	}
+
+	restoreMachineSpec(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	// The status doesn't exist in v1alpha1.
+	dst.Status = restored.Status
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachineTemplate.
+func (dst *IonosCloudMachineTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachineTemplate)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts the IonosCloudMachineTemplateList to the hub version (v1beta1).
+func (src *IonosCloudMachineTemplateList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachineTemplateList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachineTemplateList.
+func (dst *IonosCloudMachineTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachineTemplateList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertTo converts the IonosCloudMachinePool to the hub version (v1beta1).
+func (src *IonosCloudMachinePool) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachinePool)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+
+	restored := &infrav1.IonosCloudMachinePool{}
+	if ok, err := utilconversion.UnmarshalData(dst, restored); err != nil || !ok {
+		return err
+	}
+
+	restoreMachineSpec(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+	dst.Status.ReadyReplicas = restored.Status.ReadyReplicas
+	dst.Status.PendingMachineName = restored.Status.PendingMachineName
+	dst.Status.Capacity = restored.Status.Capacity
+	dst.Status.NodeInfo = restored.Status.NodeInfo
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachinePool.
+func (dst *IonosCloudMachinePool) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachinePool)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	if err := convertInto(src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+	if err := convertInto(src.Status, &dst.Status); err != nil {
+		return err
+	}
+	return utilconversion.MarshalData(src, dst)
+}
+
+// ConvertTo converts the IonosCloudMachinePoolList to the hub version (v1beta1).
+func (src *IonosCloudMachinePoolList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*infrav1.IonosCloudMachinePoolList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// ConvertFrom converts the hub version (v1beta1) to an IonosCloudMachinePoolList.
+func (dst *IonosCloudMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*infrav1.IonosCloudMachinePoolList)
+	dst.ListMeta = src.ListMeta
+	return convertInto(src.Items, &dst.Items)
+}
+
+// restoreMachineSpec restores the fields of a machine spec, which don't exist in v1alpha1.
+func restoreMachineSpec(restored, dst *infrav1.IonosCloudMachineSpec) {
+	dst.AdditionalUserData = restored.AdditionalUserData
+	dst.VolumeDeletionPolicy = restored.VolumeDeletionPolicy
+	dst.Labels = restored.Labels
+	dst.IPAMConfig = restored.IPAMConfig
+	dst.SpreadStrategy = restored.SpreadStrategy
+	dst.CDROM = restored.CDROM
+	dst.Boot = restored.Boot
+	dst.HostnameFormat = restored.HostnameFormat
+	dst.IPBlock = restored.IPBlock
+	dst.PrivateIP = restored.PrivateIP
+	dst.FailoverGroups = restored.FailoverGroups
+	dst.SSHKeys = restored.SSHKeys
+	dst.ReadinessStrategy = restored.ReadinessStrategy
+	dst.DriftPolicy = restored.DriftPolicy
+	dst.UserDataCompression = restored.UserDataCompression
+	dst.ImportExisting = restored.ImportExisting
+	if dst.Disk != nil && restored.Disk != nil {
+		dst.Disk.Bus = restored.Disk.Bus
+		dst.Disk.BackupUnitID = restored.Disk.BackupUnitID
+		dst.Disk.ImageUpgradePolicy = restored.Disk.ImageUpgradePolicy
+		if dst.Disk.Image != nil && restored.Disk.Image != nil {
+			dst.Disk.Image.Snapshot = restored.Disk.Image.Snapshot
+			dst.Disk.Image.Private = restored.Disk.Image.Private
+		}
+	}
+	for i := range dst.AdditionalVolumes {
+		if i < len(restored.AdditionalVolumes) {
+			dst.AdditionalVolumes[i].Bus = restored.AdditionalVolumes[i].Bus
+			dst.AdditionalVolumes[i].Retain = restored.AdditionalVolumes[i].Retain
+		}
+	}
+	for i := range dst.AdditionalNetworks {
+		if i < len(restored.AdditionalNetworks) {
+			dst.AdditionalNetworks[i].IPAMConfig = restored.AdditionalNetworks[i].IPAMConfig
+			dst.AdditionalNetworks[i].Name = restored.AdditionalNetworks[i].Name
+		}
+	}
+}
+
+// restoreMachineStatus restores the status fields of an IonosCloudMachine, which don't exist in v1alpha1.
+func restoreMachineStatus(restored, dst *infrav1.IonosCloudMachineStatus) {
+	dst.Addresses = restored.Addresses
+	dst.Hostname = restored.Hostname
+	dst.RecentRequests = restored.RecentRequests
+	dst.ProvisioningFailures = restored.ProvisioningFailures
+	dst.ProvisioningRetryTime = restored.ProvisioningRetryTime
+	dst.ResolvedImageID = restored.ResolvedImageID
+	dst.ProvisioningTimestamps = restored.ProvisioningTimestamps
+	dst.BootstrapRedelivery = restored.BootstrapRedelivery
+	dst.ShutdownRequestedAt = restored.ShutdownRequestedAt
+	dst.RemoteConsole = restored.RemoteConsole
+	if dst.MachineNetworkInfo == nil || restored.MachineNetworkInfo == nil {
+		return
+	}
+	for i := range dst.MachineNetworkInfo.NICInfo {
+		if i < len(restored.MachineNetworkInfo.NICInfo) {
+			nic := &dst.MachineNetworkInfo.NICInfo[i]
+			nic.ID = restored.MachineNetworkInfo.NICInfo[i].ID
+			nic.Name = restored.MachineNetworkInfo.NICInfo[i].Name
+			nic.MACAddress = restored.MachineNetworkInfo.NICInfo[i].MACAddress
+			nic.FirewallActive = restored.MachineNetworkInfo.NICInfo[i].FirewallActive
+			nic.FirewallType = restored.MachineNetworkInfo.NICInfo[i].FirewallType
+		}
+	}
+}
+
+// restoreRequestTargets restores the targets of a provisioning request, which don't exist in v1alpha1.
+func restoreRequestTargets(restored, dst *infrav1.ProvisioningRequest) {
+	if restored == nil || dst == nil || restored.RequestPath != dst.RequestPath {
+		return
+	}
+	dst.Targets = restored.Targets
+}
+
+// convertInto converts src into dst by round-tripping it through JSON.
+// Fields which only exist in v1beta1 are dropped and need to be restored from the
+// conversion data annotation.
+func convertInto[T any](src any, dst *T) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+	*dst = out
+	return nil
+}
@@ -34,14 +34,19 @@ const (
 	IonosCloudClusterKind = "IonosCloudCluster"
 )
 
+//+kubebuilder:validation:XValidation:rule="has(self.loadBalancer) == has(oldSelf.loadBalancer)",message="loadBalancer cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.natGateway) == has(oldSelf.natGateway)",message="natGateway cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.datacenter) == has(oldSelf.datacenter)",message="datacenter cannot be added or removed"
+
 // IonosCloudClusterSpec defines the desired state of IonosCloudCluster.
 type IonosCloudClusterSpec struct {
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	//
+	// If the host is not set, the provider reserves an IP block and uses its IP as the host.
+	// The IP block is deleted together with the cluster.
+	// If the port is not set, it defaults to 6443.
 	//+kubebuilder:validation:XValidation:rule="self.host == oldSelf.host || oldSelf.host == ''",message="control plane endpoint host cannot be updated"
 	//+kubebuilder:validation:XValidation:rule="self.port == oldSelf.port || oldSelf.port == 0",message="control plane endpoint port cannot be updated"
-	//
-	// TODO(gfariasalves): as of now, IP must be provided by the user as we still don't insert the
-	// provider-provided block IP into the kube-vip manifest.
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
 
 	// Location is the location where the data centers should be located.
@@ -51,8 +56,104 @@ type IonosCloudClusterSpec struct {
 	Location string `json:"location"`
 
 	// CredentialsRef is a reference to the secret containing the credentials to access the IONOS Cloud API.
+	// The secret needs to contain either a token or a username and password.
 	//+kubebuilder:validation:XValidation:rule="has(self.name) && self.name != ''",message="credentialsRef.name must be provided"
 	CredentialsRef corev1.LocalObjectReference `json:"credentialsRef"`
+
+	// Datacenter configures a data center, which is created and owned by the cluster.
+	// The data center is created in the location of the cluster and labeled with the cluster name.
+	// Machines without a data center ID are placed in this data center. It is deleted together with
+	// the cluster, if it does not contain any servers or LANs anymore.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenter is immutable"
+	//+optional
+	Datacenter *DatacenterSpec `json:"datacenter,omitempty"`
+
+	// LoadBalancer configures a Network Load Balancer in front of the control plane machines.
+	// If set, the control plane endpoint IP is assigned to the load balancer, which forwards
+	// the traffic to all control plane machines. A manually managed endpoint, e.g. via kube-vip,
+	// is not required in this case.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="loadBalancer is immutable"
+	//+optional
+	LoadBalancer *LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
+	// NATGateway configures a NAT Gateway, which provides outbound internet access for machines
+	// without a public IP address. If set, the cluster LAN in the data center of the NAT Gateway
+	// is created as a private LAN and its traffic is translated to a reserved public IP address.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="natGateway is immutable"
+	//+optional
+	NATGateway *NATGatewaySpec `json:"natGateway,omitempty"`
+
+	// FailureDomains is a list of failure domains, which machines can be distributed across.
+	// A failure domain is either a data center, an availability zone or an availability zone
+	// in a specific data center. Machines select a failure domain by its name via
+	// Machine.Spec.FailureDomain.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	FailureDomains []FailureDomainSpec `json:"failureDomains,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.datacenterID) || has(self.availabilityZone)",message="either datacenterID or availabilityZone must be set"
+
+// FailureDomainSpec defines a failure domain, which machines can be placed in.
+type FailureDomainSpec struct {
+	// Name is the name of the failure domain, which is referenced by machines.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DatacenterID is the ID of the data center, in which machines of this failure domain are created.
+	// If not set, the data center of the IonosCloudMachine is used.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
+
+	// AvailabilityZone is the availability zone, in which servers and volumes of this failure domain are created.
+	// If not set, the availability zones of the IonosCloudMachine are used.
+	//+kubebuilder:validation:Enum=ZONE_1;ZONE_2
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// ControlPlane determines if this failure domain is suitable for control plane machines.
+	//+kubebuilder:default=true
+	//+optional
+	ControlPlane *bool `json:"controlPlane,omitempty"`
+}
+
+// DatacenterSpec defines the data center, which is created and owned by the cluster.
+type DatacenterSpec struct {
+	// Name is the name of the data center.
+	// If not set, the name is derived from the namespace and name of the cluster.
+	//+kubebuilder:validation:MaxLength=255
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the data center.
+	//+optional
+	Description string `json:"description,omitempty"`
+}
+
+// LoadBalancerSpec defines the Network Load Balancer, which serves the control plane endpoint.
+type LoadBalancerSpec struct {
+	// DatacenterID is the ID of the data center where the load balancer should be created.
+	// Control plane machines are only registered as targets, if they are located in the same data center.
+	//+kubebuilder:validation:Format=uuid
+	DatacenterID string `json:"datacenterID"`
+}
+
+// NATGatewaySpec defines the NAT Gateway, which provides outbound internet access for the cluster LAN.
+type NATGatewaySpec struct {
+	// DatacenterID is the ID of the data center where the NAT Gateway should be created.
+	// Only machines in this data center are connected to the private cluster LAN behind the NAT Gateway.
+	//+kubebuilder:validation:Format=uuid
+	DatacenterID string `json:"datacenterID"`
+
+	// SourceSubnet is the subnet of the cluster LAN, whose outbound traffic is translated by the NAT Gateway.
+	//+kubebuilder:validation:Format=cidr
+	//+kubebuilder:default="10.0.0.0/8"
+	//+kubebuilder:example="10.0.0.0/24"
+	//+optional
+	SourceSubnet string `json:"sourceSubnet,omitempty"`
 }
 
 // IonosCloudClusterStatus defines the observed state of IonosCloudCluster.
@@ -76,6 +177,27 @@ type IonosCloudClusterStatus struct {
 	// ControlPlaneEndpointIPBlockID is the IONOS Cloud UUID for the control plane endpoint IP block.
 	//+optional
 	ControlPlaneEndpointIPBlockID string `json:"controlPlaneEndpointIPBlockID,omitempty"`
+
+	// DatacenterID is the IONOS Cloud UUID of the data center, which is owned by the cluster.
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
+
+	// LoadBalancerID is the IONOS Cloud UUID of the control plane Network Load Balancer.
+	//+optional
+	LoadBalancerID string `json:"loadBalancerID,omitempty"`
+
+	// NATGatewayID is the IONOS Cloud UUID of the NAT Gateway.
+	//+optional
+	NATGatewayID string `json:"natGatewayID,omitempty"`
+
+	// NATGatewayIPBlockID is the IONOS Cloud UUID of the IP block, which provides the public IP of the NAT Gateway.
+	//+optional
+	NATGatewayIPBlockID string `json:"natGatewayIPBlockID,omitempty"`
+
+	// FailureDomains contains the failure domains, which are declared in the spec.
+	// They are picked up by Cluster API to distribute machines across them.
+	//+optional
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 }
 
 //+kubebuilder:object:root=true
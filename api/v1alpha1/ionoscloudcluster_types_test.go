@@ -27,6 +27,8 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -83,6 +85,37 @@ var _ = Describe("IonosCloudCluster", func() {
 			Expect(k8sClient.Create(context.Background(), cluster)).
 				Should(MatchError(ContainSubstring("credentialsRef.name must be provided")))
 		})
+
+		Context("Failure domains", func() {
+			It("should allow creating clusters with failure domains", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{
+					{Name: "dc", DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"},
+					{Name: "zone", AvailabilityZone: AvailabilityZoneOne},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(ptr.Deref(cluster.Spec.FailureDomains[0].ControlPlane, false)).To(BeTrue())
+			})
+			It("should fail if neither data center nor zone are set", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{{Name: "empty"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("either datacenterID or availabilityZone must be set")))
+			})
+			It("should fail if the names are not unique", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{
+					{Name: "fd", AvailabilityZone: AvailabilityZoneOne},
+					{Name: "fd", AvailabilityZone: AvailabilityZoneTwo},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should fail if the zone is AUTO", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{{Name: "fd", AvailabilityZone: AvailabilityZoneAuto}}
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+		})
 	})
 
 	Context("Update", func() {
@@ -126,6 +159,102 @@ var _ = Describe("IonosCloudCluster", func() {
 				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
 			})
 		})
+
+		When("trying to update the load balancer", func() {
+			It("should not allow adding a load balancer", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer cannot be added or removed")))
+			})
+			It("should not allow removing the load balancer", func() {
+				cluster := defaultCluster()
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer cannot be added or removed")))
+			})
+			It("should not allow changing the data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer is immutable")))
+			})
+		})
+		When("trying to update the NAT gateway", func() {
+			It("should default the source subnet", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.NATGateway.SourceSubnet).To(Equal("10.0.0.0/8"))
+			})
+			It("should not allow adding a NAT gateway", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway cannot be added or removed")))
+			})
+			It("should not allow removing the NAT gateway", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway cannot be added or removed")))
+			})
+			It("should not allow changing the source subnet", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway.SourceSubnet = "10.1.0.0/16"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway is immutable")))
+			})
+		})
+		When("trying to update the data center", func() {
+			It("should allow creating a cluster with a data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow adding a data center", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter cannot be added or removed")))
+			})
+			It("should not allow removing the data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter cannot be added or removed")))
+			})
+			It("should not allow changing the name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{Name: "dc"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter.Name = "other"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter is immutable")))
+			})
+		})
 	})
 	Context("Status", func() {
 		It("should correctly get and set the status", func() {
@@ -48,6 +48,22 @@ const (
 	// creating the bootstrap data secret and store it in the Cluster API Machine.
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
 
+	// ShuttingDownReason (Severity=Info) indicates that the VM was requested to shut down
+	// and the controller waits for it to power off before deleting it.
+	ShuttingDownReason = "ShuttingDown"
+
+	// ServerResourcesUpdatedCondition indicates whether the number of cores and the memory size of the VM
+	// match the spec of the IonosCloudMachine.
+	ServerResourcesUpdatedCondition clusterv1.ConditionType = "ServerResourcesUpdated"
+
+	// ResizingReason (Severity=Info) indicates that the number of cores or the memory size of the VM
+	// is being updated in place.
+	ResizingReason = "Resizing"
+
+	// RebootRequiredReason (Severity=Warning) indicates that the number of cores or the memory size of the VM
+	// was updated, but could not be hot-plugged. The changes take effect after the VM has been restarted.
+	RebootRequiredReason = "RebootRequired"
+
 	// CloudResourceConfigAuto is a constant to indicate that the cloud resource should be managed by the
 	// Cluster API provider implementation.
 	CloudResourceConfigAuto = "AUTO"
@@ -90,7 +106,7 @@ func (a AvailabilityZone) String() string {
 	return string(a)
 }
 
-// ServerType is the type of server which is created (ENTERPRISE or VCPU).
+// ServerType is the type of server which is created (ENTERPRISE, VCPU or CUBE).
 type ServerType string
 
 const (
@@ -98,6 +114,8 @@ const (
 	ServerTypeEnterprise ServerType = "ENTERPRISE"
 	// ServerTypeVCPU server of type VCPU.
 	ServerTypeVCPU ServerType = "VCPU"
+	// ServerTypeCube server of type CUBE.
+	ServerTypeCube ServerType = "CUBE"
 )
 
 // String returns the string representation of the ServerType.
@@ -105,6 +123,23 @@ func (a ServerType) String() string {
 	return string(a)
 }
 
+// PowerState is the power state of a VM.
+type PowerState string
+
+const (
+	// PowerStateRunning means that the VM is powered on.
+	PowerStateRunning PowerState = "Running"
+	// PowerStateStopped means that the VM is powered off.
+	PowerStateStopped PowerState = "Stopped"
+)
+
+// String returns the string representation of the PowerState.
+func (p PowerState) String() string {
+	return string(p)
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(oldSelf.datacenterID) || has(self.datacenterID)",message="datacenterID cannot be removed"
+
 // IonosCloudMachineSpec defines the desired state of IonosCloudMachine.
 type IonosCloudMachineSpec struct {
 	// ProviderID is the IONOS Cloud provider ID
@@ -113,11 +148,15 @@ type IonosCloudMachineSpec struct {
 	ProviderID *string `json:"providerID,omitempty"`
 
 	// DatacenterID is the ID of the data center where the VM should be created in.
+	// It can be omitted, if the machine is placed in a failure domain, which defines the data center.
+	// In this case, the data center ID is set by the controller.
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenterID is immutable"
 	//+kubebuilder:validation:Format=uuid
-	DatacenterID string `json:"datacenterID"`
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
 
 	// NumCores defines the number of cores for the VM.
+	// Changing the number of cores of an existing VM updates it in place.
 	//+kubebuilder:validation:Minimum=1
 	//+kubebuilder:default=1
 	//+optional
@@ -132,6 +171,7 @@ type IonosCloudMachineSpec struct {
 	// MemoryMB is the memory size for the VM in MB.
 	// Size must be specified in multiples of 256 MB with a minimum of 1024 MB
 	// which is required as we are using hot-pluggable RAM by default.
+	// Changing the memory size of an existing VM updates it in place.
 	//+kubebuilder:validation:MultipleOf=1024
 	//+kubebuilder:validation:Minimum=2048
 	//+kubebuilder:default=3072
@@ -150,11 +190,41 @@ type IonosCloudMachineSpec struct {
 	// Disk defines the boot volume of the VM.
 	Disk *Volume `json:"disk"`
 
+	// AdditionalVolumes defines data volumes, which will be created and attached to the VM
+	// in addition to the boot volume.
+	//
+	// These volumes belong to the machine and are removed together with the VM.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	AdditionalVolumes []VolumeSpec `json:"additionalVolumes,omitempty"`
+
 	// AdditionalNetworks defines the additional network configurations for the VM.
+	// For each network, a secondary NIC is attached to the given LAN. The NICs are removed together with the VM,
+	// while the LANs themselves are not managed by the provider.
+	// Changing the networks of an existing VM is not supported.
 	// NOTE(lubedacht): We currently only support networks with DHCP enabled.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="additionalNetworks is immutable"
 	//+optional
 	AdditionalNetworks Networks `json:"additionalNetworks,omitempty"`
 
+	// IPv6 configures IPv6 on the primary NIC of the VM, which is attached to the cluster LAN.
+	// If set, IPv6 will be enabled on the cluster LAN, if it is not enabled already.
+	// This is needed for dual-stack clusters.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipv6 is immutable"
+	//+optional
+	IPv6 *IPv6Config `json:"ipv6,omitempty"`
+
+	// FirewallRules defines the firewall rules of the primary NIC of the VM.
+	// If rules are set, the firewall of the NIC is activated and only traffic, which matches one of the rules,
+	// is allowed in the directions the rules are defined for. The rules are reconciled by the controller,
+	// which means that changes made outside of the controller are reverted.
+	// Removing all rules deactivates the firewall of the NIC.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	FirewallRules []FirewallRule `json:"firewallRules,omitempty"`
+
 	// FailoverIP can be set to enable failover for VMs in the same MachineDeployment.
 	// It can be either set to an already reserved IPv4 address, or it can be set to "AUTO"
 	// which will automatically reserve an IPv4 address for the Failover Group.
@@ -165,14 +235,55 @@ type IonosCloudMachineSpec struct {
 	//+optional
 	FailoverIP *string `json:"failoverIP,omitempty"`
 
-	// Type is the server type of the VM. Can be either ENTERPRISE or VCPU.
+	// Type is the server type of the VM. Can be either ENTERPRISE, VCPU or CUBE.
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
-	//+kubebuilder:validation:Enum=ENTERPRISE;VCPU
+	//+kubebuilder:validation:Enum=ENTERPRISE;VCPU;CUBE
 	//+kubebuilder:default=ENTERPRISE
 	//+optional
 	Type ServerType `json:"type,omitempty"`
+
+	// Template is the template of a CUBE server, which defines the number of cores, the memory size
+	// and the size of the boot volume. It is required for and only allowed with the server type CUBE.
+	// For CUBE servers, numCores, memoryMB as well as the size and the type of the boot volume are ignored.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="template is immutable"
+	//+optional
+	Template *ServerTemplate `json:"template,omitempty"`
+
+	// ShutdownTimeout is the time to wait for the VM to shut down gracefully, before it is deleted
+	// during machine deletion. After the timeout, the VM is deleted regardless of its state.
+	// A timeout of 0 deletes the VM without shutting it down first.
+	//+kubebuilder:default="2m"
+	//+optional
+	ShutdownTimeout *metav1.Duration `json:"shutdownTimeout,omitempty"`
+
+	// DesiredPowerState is the power state, which the VM should be in.
+	// If set to Stopped, the VM is shut down, but its volumes and NICs are kept, which allows
+	// saving costs for machines that are not needed temporarily. Setting it back to Running
+	// starts the VM again.
+	//+kubebuilder:validation:Enum=Running;Stopped
+	//+kubebuilder:default=Running
+	//+optional
+	DesiredPowerState PowerState `json:"desiredPowerState,omitempty"`
 }
 
+//+kubebuilder:validation:XValidation:rule="has(self.id) != has(self.name)",message="exactly one of id or name must be set"
+
+// ServerTemplate references a template of a CUBE server either by its ID or by its name.
+type ServerTemplate struct {
+	// ID is the UUID of the template.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the template, e.g. "Basic Cube XS".
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+}
+
+//+listType=map
+//+listMapKey=networkID
+
 // Networks contains a list of additional LAN IDs
 // that should be attached to the VM.
 type Networks []Network
@@ -185,6 +296,89 @@ type Network struct {
 	NetworkID int32 `json:"networkID"`
 }
 
+// IPv6Config contains the IPv6 configuration of a NIC.
+type IPv6Config struct {
+	// DHCP indicates whether the NIC will receive its IPv6 address via DHCPv6.
+	//+kubebuilder:default=true
+	//+optional
+	DHCP *bool `json:"dhcp,omitempty"`
+}
+
+// FirewallRuleProtocol is the protocol of a firewall rule.
+type FirewallRuleProtocol string
+
+const (
+	// FirewallRuleProtocolTCP matches TCP traffic.
+	FirewallRuleProtocolTCP FirewallRuleProtocol = "TCP"
+	// FirewallRuleProtocolUDP matches UDP traffic.
+	FirewallRuleProtocolUDP FirewallRuleProtocol = "UDP"
+	// FirewallRuleProtocolICMP matches ICMP traffic.
+	FirewallRuleProtocolICMP FirewallRuleProtocol = "ICMP"
+	// FirewallRuleProtocolAny matches traffic of any protocol.
+	FirewallRuleProtocolAny FirewallRuleProtocol = "ANY"
+)
+
+// String returns the string representation of the FirewallRuleProtocol.
+func (p FirewallRuleProtocol) String() string {
+	return string(p)
+}
+
+// FirewallRuleDirection is the direction of the traffic, which a firewall rule applies to.
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIngress applies the rule to incoming traffic.
+	FirewallRuleDirectionIngress FirewallRuleDirection = "INGRESS"
+	// FirewallRuleDirectionEgress applies the rule to outgoing traffic.
+	FirewallRuleDirectionEgress FirewallRuleDirection = "EGRESS"
+)
+
+// String returns the string representation of the FirewallRuleDirection.
+func (d FirewallRuleDirection) String() string {
+	return string(d)
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.portRangeStart) == has(self.portRangeEnd)",message="portRangeStart and portRangeEnd must be set together"
+//+kubebuilder:validation:XValidation:rule="!has(self.portRangeStart) || self.portRangeStart <= self.portRangeEnd",message="portRangeStart must not be greater than portRangeEnd"
+//+kubebuilder:validation:XValidation:rule="!has(self.portRangeStart) || self.protocol in ['TCP', 'UDP']",message="port ranges are only supported for TCP and UDP"
+
+// FirewallRule defines a firewall rule, which allows traffic on the primary NIC of the VM.
+type FirewallRule struct {
+	// Name is the name of the firewall rule. It must be unique within the firewall rules of a machine.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Protocol is the protocol of the traffic, which is allowed by this rule.
+	//+kubebuilder:validation:Enum=TCP;UDP;ICMP;ANY
+	Protocol FirewallRuleProtocol `json:"protocol"`
+
+	// Direction is the direction of the traffic, which is allowed by this rule.
+	//+kubebuilder:validation:Enum=INGRESS;EGRESS
+	//+kubebuilder:default=INGRESS
+	//+optional
+	Direction FirewallRuleDirection `json:"direction,omitempty"`
+
+	// SourceCIDR restricts the rule to traffic originating from the given IP address or CIDR block.
+	// If not set, traffic from any source is allowed.
+	//+kubebuilder:example="198.51.100.0/24"
+	//+optional
+	SourceCIDR *string `json:"sourceCIDR,omitempty"`
+
+	// PortRangeStart is the first port of the port range, which is allowed by this rule.
+	// If no port range is set, all ports are allowed.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65534
+	//+optional
+	PortRangeStart *int32 `json:"portRangeStart,omitempty"`
+
+	// PortRangeEnd is the last port of the port range, which is allowed by this rule.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65534
+	//+optional
+	PortRangeEnd *int32 `json:"portRangeEnd,omitempty"`
+}
+
 // Volume is the physical storage on the VM.
 type Volume struct {
 	// Name is the name of the volume
@@ -214,6 +408,33 @@ type Volume struct {
 	Image *ImageSpec `json:"image"`
 }
 
+// VolumeSpec defines a data volume, which is attached to the VM.
+type VolumeSpec struct {
+	// Name is the name of the volume. It must be unique within the additional volumes
+	// of a machine and is used to derive the name of the volume in the cloud.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DiskType defines the type of the hard drive.
+	//+kubebuilder:validation:Enum=HDD;SSD Standard;SSD Premium
+	//+kubebuilder:default=HDD
+	//+optional
+	DiskType VolumeDiskType `json:"diskType,omitempty"`
+
+	// SizeGB defines the size of the volume in GB
+	//+kubebuilder:validation:Minimum=10
+	//+kubebuilder:default=20
+	//+optional
+	SizeGB int `json:"sizeGB,omitempty"`
+
+	// AvailabilityZone is the availability zone where the volume will be created.
+	//+kubebuilder:validation:Enum=AUTO;ZONE_1;ZONE_2;ZONE_3
+	//+kubebuilder:default=AUTO
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+}
+
 // ImageSpec defines the image to use for the VM.
 type ImageSpec struct {
 	// ID is the ID of the image to use for the VM.
@@ -231,6 +452,15 @@ type IonosCloudMachineStatus struct {
 	// This information is only available after the VM has been provisioned.
 	MachineNetworkInfo *MachineNetworkInfo `json:"machineNetworkInfo,omitempty"`
 
+	// Volumes contains information about the volumes, which are attached to the VM.
+	// This information is only available after the VM has been provisioned.
+	//+optional
+	Volumes []VolumeInfo `json:"volumes,omitempty"`
+
+	// InstanceState is the state of the VM as reported by IONOS Cloud, e.g. RUNNING or SHUTOFF.
+	//+optional
+	InstanceState string `json:"instanceState,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -301,11 +531,24 @@ type NICInfo struct {
 	Primary bool `json:"primary"`
 }
 
+// VolumeInfo provides information about a volume attached to the VM.
+type VolumeInfo struct {
+	// ID is the ID of the volume in the cloud.
+	ID string `json:"id"`
+
+	// Name is the name of the volume in the cloud.
+	Name string `json:"name"`
+
+	// Boot indicates whether the volume is the boot volume of the VM.
+	Boot bool `json:"boot"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=ionoscloudmachines,scope=Namespaced,categories=cluster-api;ionoscloud,shortName=icm
 //+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
 //+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine is ready"
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.instanceState",description="State of the VM"
 //+kubebuilder:printcolumn:name="IPv4 Addresses",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].ipv4Addresses"
 //+kubebuilder:printcolumn:name="Machine Connected Networks",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].networkID"
 //+kubebuilder:printcolumn:name="IPv6 Addresses",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].ipv6Addresses",priority=1
@@ -316,6 +559,9 @@ type IonosCloudMachine struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	//+kubebuilder:validation:XValidation:rule="self.type != 'VCPU' || !has(self.cpuFamily)",message="cpuFamily must not be specified when using VCPU"
+	//+kubebuilder:validation:XValidation:rule="self.type != 'CUBE' || !has(self.cpuFamily)",message="cpuFamily must not be specified when using CUBE"
+	//+kubebuilder:validation:XValidation:rule="self.type != 'CUBE' || has(self.template)",message="template must be specified when using CUBE"
+	//+kubebuilder:validation:XValidation:rule="self.type == 'CUBE' || !has(self.template)",message="template can only be specified when using CUBE"
 	Spec   IonosCloudMachineSpec   `json:"spec,omitempty"`
 	Status IonosCloudMachineStatus `json:"status,omitempty"`
 }
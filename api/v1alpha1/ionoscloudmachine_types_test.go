@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	sdk "github.com/ionos-cloud/sdk-go/v6"
@@ -104,10 +105,24 @@ var _ = Describe("IonosCloudMachine Tests", func() {
 		})
 
 		Context("Data center ID", func() {
-			It("should fail if not set", func() {
+			It("should allow an empty data center ID", func() {
 				m := defaultMachine()
 				m.Spec.DatacenterID = ""
-				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+			It("should allow setting the data center ID, if it is not set", func() {
+				m := defaultMachine()
+				m.Spec.DatacenterID = ""
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.DatacenterID = "6ded8c5f-8df2-46ef-b4ce-61833daf0961"
+				Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+			})
+			It("should not allow removing the data center ID", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.DatacenterID = ""
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("datacenterID cannot be removed")))
 			})
 
 			It("should fail if not a UUID", func() {
@@ -145,6 +160,14 @@ var _ = Describe("IonosCloudMachine Tests", func() {
 				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
 				Expect(m.Spec.NumCores).To(Equal(int32(1)))
 			})
+			It("should be mutable", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+				m.Spec.NumCores = 4
+				m.Spec.MemoryMB = 8192
+				Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+			})
 		})
 
 		Context("Availability zone", func() {
@@ -337,6 +360,126 @@ var _ = Describe("IonosCloudMachine Tests", func() {
 				m.Spec.AdditionalNetworks[0].NetworkID = -1
 				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
 			})
+			It("network IDs must be unique", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = Networks{{NetworkID: 1}, {NetworkID: 1}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should be immutable", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.AdditionalNetworks = append(m.Spec.AdditionalNetworks, Network{NetworkID: 10})
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("additionalNetworks is immutable")))
+			})
+		})
+		Context("Additional Volumes", func() {
+			It("should be optional", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AdditionalVolumes).To(BeNil())
+			})
+			It("should apply the defaults", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data"}}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AdditionalVolumes[0].SizeGB).To(Equal(20))
+				Expect(m.Spec.AdditionalVolumes[0].DiskType).To(Equal(VolumeDiskTypeHDD))
+				Expect(m.Spec.AdditionalVolumes[0].AvailabilityZone).To(Equal(AvailabilityZoneAuto))
+			})
+			It("should fail if the name is not set", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{SizeGB: 10}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if names are not unique", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data"}, {Name: "data"}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if the size is less than 10", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data", SizeGB: 9}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if the disk type is not part of the enum", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data", DiskType: "tape"}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+		})
+	})
+	Context("IPv6", func() {
+		It("should be optional", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.IPv6).To(BeNil())
+		})
+		It("should enable DHCP by default", func() {
+			m := defaultMachine()
+			m.Spec.IPv6 = &IPv6Config{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.IPv6.DHCP).To(Equal(ptr.To(true)))
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.IPv6 = &IPv6Config{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.IPv6.DHCP = ptr.To(false)
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipv6 is immutable")))
+		})
+	})
+	Context("Firewall rules", func() {
+		sshRule := func() FirewallRule {
+			return FirewallRule{
+				Name:           "ssh",
+				Protocol:       FirewallRuleProtocolTCP,
+				PortRangeStart: ptr.To[int32](22),
+				PortRangeEnd:   ptr.To[int32](22),
+			}
+		}
+		It("should default the direction to INGRESS", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FirewallRules[0].Direction).To(Equal(FirewallRuleDirectionIngress))
+		})
+		It("should allow changing the rules", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.FirewallRules[0].SourceCIDR = ptr.To("198.51.100.0/24")
+			Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+		})
+		It("should fail if the names are not unique", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule(), sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should fail if only one end of the port range is set", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.PortRangeEnd = nil
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("portRangeStart and portRangeEnd must be set together")))
+		})
+		It("should fail if the port range is inverted", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.PortRangeStart = ptr.To[int32](23)
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("portRangeStart must not be greater than portRangeEnd")))
+		})
+		It("should fail if a port range is set for ICMP", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.Protocol = FirewallRuleProtocolICMP
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("port ranges are only supported for TCP and UDP")))
 		})
 	})
 	Context("FailoverIP", func() {
@@ -414,6 +557,73 @@ var _ = Describe("IonosCloudMachine Tests", func() {
 			Entry("ENTERPRISE", ServerTypeEnterprise),
 			Entry("VCPU", ServerTypeVCPU),
 		)
+		It("should work for CUBE with a template", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+		It("should fail if type is CUBE and no template is set", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("template must be specified when using CUBE")))
+		})
+		It("should fail if cpuFamily is set and type is CUBE", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = ptr.To("some-cpu-family")
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should fail if a template is set and type is not CUBE", func() {
+			m := defaultMachine()
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("template can only be specified when using CUBE")))
+		})
+		It("should fail if both template ID and name are set", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			m.Spec.Template = &ServerTemplate{ID: "15c6dd2f-02d2-4987-b439-9a58dd59ecc3", Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("exactly one of id or name must be set")))
+		})
+	})
+	Context("ShutdownTimeout", func() {
+		It("should default to 2 minutes", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.ShutdownTimeout).To(Equal(&metav1.Duration{Duration: 2 * time.Minute}))
+		})
+		It("should allow disabling the shutdown", func() {
+			m := defaultMachine()
+			m.Spec.ShutdownTimeout = &metav1.Duration{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.ShutdownTimeout.Duration).To(BeZero())
+		})
+	})
+	Context("DesiredPowerState", func() {
+		It("should default to Running", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.DesiredPowerState).To(Equal(PowerStateRunning))
+		})
+		It("should allow stopping the machine", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.DesiredPowerState = PowerStateStopped
+			Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+		})
+		It("should not allow an unknown power state", func() {
+			m := defaultMachine()
+			m.Spec.DesiredPowerState = "Paused"
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
 	})
 	Context("Conditions", func() {
 		It("should correctly set and get the conditions", func() {
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Hub marks IonosCloudCluster as a conversion hub.
+func (*IonosCloudCluster) Hub() {}
+
+// Hub marks IonosCloudClusterList as a conversion hub.
+func (*IonosCloudClusterList) Hub() {}
+
+// Hub marks IonosCloudMachine as a conversion hub.
+func (*IonosCloudMachine) Hub() {}
+
+// Hub marks IonosCloudMachineList as a conversion hub.
+func (*IonosCloudMachineList) Hub() {}
+
+// Hub marks IonosCloudMachineTemplate as a conversion hub.
+func (*IonosCloudMachineTemplate) Hub() {}
+
+// Hub marks IonosCloudMachineTemplateList as a conversion hub.
+func (*IonosCloudMachineTemplateList) Hub() {}
+
+// Hub marks IonosCloudMachinePool as a conversion hub.
+func (*IonosCloudMachinePool) Hub() {}
+
+// Hub marks IonosCloudMachinePoolList as a conversion hub.
+func (*IonosCloudMachinePoolList) Hub() {}
@@ -0,0 +1,839 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ClusterFinalizer allows cleanup of resources, which are
+	// associated with the IonosCloudCluster before removing it from the API server.
+	ClusterFinalizer = "ionoscloudcluster.infrastructure.cluster.x-k8s.io"
+
+	// IonosCloudClusterReady is the condition for the IonosCloudCluster, which indicates that the cluster is ready.
+	IonosCloudClusterReady clusterv1.ConditionType = "ClusterReady"
+
+	// IonosCloudClusterKind is the string resource kind of the IonosCloudCluster resource.
+	IonosCloudClusterKind = "IonosCloudCluster"
+
+	// DryRunAnnotation enables the dry-run mode for the IonosCloudCluster and its machines, if set to "true".
+	// In dry-run mode, no mutating requests are sent to the Cloud API.
+	DryRunAnnotation = "infrastructure.cluster.x-k8s.io/dry-run"
+
+	// DeletionProtectionAnnotation prevents the deletion of the IONOS Cloud resources of an IonosCloudCluster,
+	// if set to "true". The deletion of the IonosCloudCluster is blocked until the annotation is removed.
+	DeletionProtectionAnnotation = "infrastructure.cluster.x-k8s.io/deletion-protection"
+
+	// ResourcesDeletedCondition documents the progress of the deletion of the IONOS Cloud resources
+	// of an IonosCloudCluster.
+	ResourcesDeletedCondition clusterv1.ConditionType = "ResourcesDeleted"
+
+	// DeletionProtectedReason (Severity=Warning) indicates that the resources of the IonosCloudCluster,
+	// or the server of one of its machines, are not deleted, because the IonosCloudCluster is annotated
+	// with DeletionProtectionAnnotation.
+	DeletionProtectedReason = "DeletionProtected"
+
+	// DryRunInSyncCondition reports the outcome of the last reconciliation in dry-run mode. It is true, if no
+	// mutation was necessary, and false, if a mutating request to the Cloud API was skipped.
+	// The condition is only set in dry-run mode.
+	DryRunInSyncCondition clusterv1.ConditionType = "DryRunInSync"
+
+	// MutationSkippedReason (Severity=Info) indicates that a mutating request to the Cloud API was skipped,
+	// because the dry-run mode is enabled.
+	MutationSkippedReason = "MutationSkipped"
+
+	// ControlPlaneEndpointReachableCondition reports whether the API server responds on the control plane endpoint.
+	// It is only set if probing the endpoint is enabled.
+	ControlPlaneEndpointReachableCondition clusterv1.ConditionType = "ControlPlaneEndpointReachable"
+
+	// WaitingForControlPlaneReason (Severity=Info) indicates that the control plane endpoint is not reachable,
+	// because the control plane has not been initialized yet.
+	WaitingForControlPlaneReason = "WaitingForControlPlane"
+
+	// ControlPlaneEndpointUnreachableReason (Severity=Warning) indicates that the control plane endpoint
+	// is not reachable, although the control plane has been initialized.
+	ControlPlaneEndpointUnreachableReason = "ControlPlaneEndpointUnreachable"
+
+	// CredentialsValidCondition reports whether a client for the Cloud API could be created from the credentials
+	// of the IonosCloudCluster. It is updated whenever the credentials secret changes.
+	CredentialsValidCondition clusterv1.ConditionType = "CredentialsValid"
+
+	// CredentialsNotFoundReason (Severity=Error) indicates that the credentials secret of the IonosCloudCluster
+	// does not exist.
+	CredentialsNotFoundReason = "CredentialsNotFound"
+
+	// InvalidCredentialsReason (Severity=Error) indicates that no client for the Cloud API could be created from
+	// the credentials secret, e.g. because it contains neither a token nor a username and password.
+	InvalidCredentialsReason = "InvalidCredentials"
+
+	// FeatureGateDisabledReason (Severity=Error) indicates that the object uses an experimental feature,
+	// whose feature gate is disabled in the manager.
+	FeatureGateDisabledReason = "FeatureGateDisabled"
+)
+
+//+kubebuilder:validation:XValidation:rule="has(self.loadBalancer) == has(oldSelf.loadBalancer)",message="loadBalancer cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.natGateway) == has(oldSelf.natGateway)",message="natGateway cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.applicationLoadBalancer) == has(oldSelf.applicationLoadBalancer)",message="applicationLoadBalancer cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.datacenter) == has(oldSelf.datacenter)",message="datacenter cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.networks) == has(oldSelf.networks)",message="networks cannot be added to or removed from an existing cluster"
+//+kubebuilder:validation:XValidation:rule="!has(self.natGateway) || !has(self.networks) || !self.networks[0].public",message="the first network must be private, if a NAT gateway is used"
+//+kubebuilder:validation:XValidation:rule="has(self.credentialsRef) != has(self.identityRef)",message="exactly one of credentialsRef or identityRef must be set"
+//+kubebuilder:validation:XValidation:rule="!has(oldSelf.dns) || has(self.dns)",message="dns cannot be removed"
+
+// IonosCloudClusterSpec defines the desired state of IonosCloudCluster.
+type IonosCloudClusterSpec struct {
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	//
+	// If the host is not set, the provider reserves an IP block and uses its IP as the host.
+	// If DNS is configured, the name of the managed DNS record is used as the host instead.
+	// The IP block is deleted together with the cluster.
+	// If the port is not set, it defaults to 6443.
+	//+kubebuilder:validation:XValidation:rule="self.host == oldSelf.host || oldSelf.host == ''",message="control plane endpoint host cannot be updated"
+	//+kubebuilder:validation:XValidation:rule="self.port == oldSelf.port || oldSelf.port == 0",message="control plane endpoint port cannot be updated"
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint,omitempty"`
+
+	// Location is the location where the data centers should be located.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="location is immutable"
+	//+kubebuilder:example=de/txl
+	//+kubebuilder:validation:MinLength=1
+	Location string `json:"location"`
+
+	// CredentialsRef is a reference to the secret containing the credentials to access the IONOS Cloud API.
+	// The secret needs to contain either a token or a username and password.
+	// Either credentialsRef or identityRef must be set.
+	//+kubebuilder:validation:XValidation:rule="has(self.name) && self.name != ''",message="credentialsRef.name must be provided"
+	//+optional
+	CredentialsRef *corev1.LocalObjectReference `json:"credentialsRef,omitempty"`
+
+	// IdentityRef is a reference to an IonosCloudClusterIdentity, which provides the credentials to access
+	// the IONOS Cloud API. The identity must allow the namespace of the cluster.
+	// Either credentialsRef or identityRef must be set.
+	//+optional
+	IdentityRef *IonosCloudClusterIdentityReference `json:"identityRef,omitempty"`
+
+	// Datacenter configures a data center, which is created and owned by the cluster.
+	// The data center is created in the location of the cluster and labeled with the cluster name and labels.
+	// Machines without a data center ID are placed in this data center. It is deleted together with
+	// the cluster, if it does not contain any servers or LANs anymore.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenter is immutable"
+	//+optional
+	Datacenter *DatacenterSpec `json:"datacenter,omitempty"`
+
+	// LoadBalancer configures a Network Load Balancer in front of the control plane machines.
+	// If set, the control plane endpoint IP is assigned to the load balancer, which forwards
+	// the traffic to all control plane machines. A manually managed endpoint, e.g. via kube-vip,
+	// is not required in this case.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="loadBalancer is immutable"
+	//+optional
+	LoadBalancer *LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
+	// NATGateway configures a NAT Gateway, which provides outbound internet access for machines
+	// without a public IP address. If set, the cluster LAN in the data center of the NAT Gateway
+	// is created as a private LAN and its traffic is translated to a reserved public IP address.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="natGateway is immutable"
+	//+optional
+	NATGateway *NATGatewaySpec `json:"natGateway,omitempty"`
+
+	// ApplicationLoadBalancer configures an Application Load Balancer, which routes HTTP traffic to the
+	// worker machines. It allows exposing workloads, e.g. an ingress controller listening on a node port,
+	// without running a cloud controller manager in the workload cluster.
+	//+optional
+	ApplicationLoadBalancer *ApplicationLoadBalancerSpec `json:"applicationLoadBalancer,omitempty"`
+
+	// Networks defines the LANs of the cluster, which are created in every data center that contains
+	// machines of the cluster, and are deleted together with the cluster.
+	// The primary NIC of each machine is attached to the first network, while additional NICs
+	// can be attached to the other networks by referencing them by name.
+	// If no networks are defined, a single public cluster LAN is created for the primary NICs.
+	// Networks can be appended, but existing networks cannot be changed or removed.
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MaxItems=16
+	//+kubebuilder:validation:XValidation:rule="oldSelf.all(n, n in self)",message="networks cannot be changed or removed"
+	//+optional
+	Networks []NetworkSpec `json:"networks,omitempty"`
+
+	// FailureDomains is a list of failure domains, which machines can be distributed across.
+	// A failure domain is either a data center, an availability zone or an availability zone
+	// in a specific data center. Machines select a failure domain by its name via
+	// Machine.Spec.FailureDomain.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	FailureDomains []FailureDomainSpec `json:"failureDomains,omitempty"`
+
+	// SpreadStrategy defines how machines without an explicit availability zone are distributed across
+	// availability zones. It applies to all machines of the cluster, which don't define their own spread strategy.
+	// Machines in failure domains with an availability zone are not affected.
+	//+kubebuilder:validation:Enum=None;ZoneRoundRobin
+	//+optional
+	SpreadStrategy SpreadStrategy `json:"spreadStrategy,omitempty"`
+
+	// Labels are added as IONOS Cloud labels to the servers and volumes of all machines of the cluster
+	// and to the data center owned by the cluster. The labels cluster-name, cluster-namespace and machine-name
+	// are added automatically and must not be set.
+	//+kubebuilder:validation:XValidation:rule="!('cluster-name' in self) && !('cluster-namespace' in self) && !('machine-name' in self)",message="cluster-name, cluster-namespace and machine-name are reserved labels"
+	//+optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// BootstrapStorage configures an IONOS Object Storage bucket, to which the bootstrap data of the machines
+	// is uploaded. The user data of the servers only contains a pre-signed URL of the bootstrap data, which
+	// allows bootstrap data exceeding the user data size limit of IONOS Cloud.
+	//+optional
+	BootstrapStorage *BootstrapStorageSpec `json:"bootstrapStorage,omitempty"`
+
+	// DNS configures a record in an existing IONOS Cloud DNS zone, which points to the IP of the control plane
+	// endpoint. The record is created and kept up to date by the provider and deleted together with the cluster.
+	//+optional
+	DNS *DNSSpec `json:"dns,omitempty"`
+
+	// IPBlocks defines IP blocks, which are reserved for the cluster and deleted together with it.
+	// Their IPs are assigned to the machines and the Application Load Balancer, which reference the
+	// IP block by its name, and stay stable for their lifetime, e.g. to be allowed by firewalls of
+	// other networks. The assignments are reported in the status.
+	// IP blocks can be appended, but existing IP blocks cannot be changed or removed.
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MaxItems=16
+	//+kubebuilder:validation:XValidation:rule="oldSelf.all(b, b in self)",message="ipBlocks cannot be changed or removed"
+	//+optional
+	IPBlocks []IPBlockSpec `json:"ipBlocks,omitempty"`
+
+	// Timeouts overrides the request poll interval and the machine timeouts of the controller manager
+	// for the cluster, e.g. if the Cloud API processes the requests of its contract slower than usual.
+	//+optional
+	Timeouts *TimeoutsSpec `json:"timeouts,omitempty"`
+
+	// MachineDefaults are inherited by the machines of the cluster, which don't set them.
+	// They are applied once, when a machine is created, so changing them doesn't affect existing machines.
+	//+optional
+	MachineDefaults *MachineDefaults `json:"machineDefaults,omitempty"`
+}
+
+// MachineDefaults defines settings, which are inherited by the machines of a cluster.
+type MachineDefaults struct {
+	// CPUFamily is the CPU family of machines without one. It is not applied to VCPU and CUBE servers.
+	//+kubebuilder:example=INTEL_SKYLAKE
+	//+optional
+	CPUFamily *string `json:"cpuFamily,omitempty"`
+
+	// DiskType is the disk type of boot volumes without one.
+	//+kubebuilder:validation:Enum=HDD;SSD Standard;SSD Premium
+	//+optional
+	DiskType VolumeDiskType `json:"diskType,omitempty"`
+
+	// AvailabilityZone is the availability zone of machines in the AUTO availability zone, which is applied
+	// after the machine has been placed in its failure domain. Machines, which receive it, are not
+	// distributed by the spread strategy.
+	//+kubebuilder:validation:Enum=ZONE_1;ZONE_2
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// SSHKeys are the public SSH keys of machines without SSH keys.
+	//+kubebuilder:validation:MaxItems=16
+	//+kubebuilder:validation:items:MinLength=1
+	//+listType=atomic
+	//+optional
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// Labels are added to the labels of machines, which don't set a label with the same key.
+	// Unlike the labels of the cluster, they are copied into the spec of the machines and are not
+	// added to the data center.
+	//+kubebuilder:validation:XValidation:rule="!('cluster-name' in self) && !('cluster-namespace' in self) && !('machine-name' in self)",message="cluster-name, cluster-namespace and machine-name are reserved labels"
+	//+optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// TimeoutsSpec defines the intervals and timeouts of the reconciliation of a cluster and its machines.
+// Fields, which are not set, default to the flags of the controller manager.
+type TimeoutsSpec struct {
+	// RequestPollInterval is the interval, in which the state of pending requests to the Cloud API is checked.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('1s')",message="requestPollInterval must be at least 1s"
+	//+optional
+	RequestPollInterval *metav1.Duration `json:"requestPollInterval,omitempty"`
+
+	// MachineProvisioning is the time, within which a machine must be provisioned after its creation.
+	// Machines, which exceed it, are marked as failed, so that they can be remediated by a MachineHealthCheck.
+	// A timeout of 0 disables it.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="machineProvisioning must not be negative"
+	//+optional
+	MachineProvisioning *metav1.Duration `json:"machineProvisioning,omitempty"`
+
+	// MachineDeletion is the time, within which the deletion of a machine must complete. Machines, which exceed it,
+	// are reported with reason DeletionTimedOut in the ServerDeleted condition, while the deletion is still retried.
+	// A timeout of 0 disables it.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="machineDeletion must not be negative"
+	//+optional
+	MachineDeletion *metav1.Duration `json:"machineDeletion,omitempty"`
+
+	// BootstrapExecution is the time, within which the Node of a machine must register in the workload cluster
+	// after its VM became available. Machines, which exceed it, are reported with reason BootstrapExecutionTimedOut
+	// in the BootstrapExecutedSuccessfully condition. A timeout of 0 disables it.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="bootstrapExecution must not be negative"
+	//+optional
+	BootstrapExecution *metav1.Duration `json:"bootstrapExecution,omitempty"`
+
+	// StuckRequest is the time, after which a request of a machine, which is still queued or running, is considered
+	// to be stuck. Stuck PATCH and DELETE requests are issued again, if they have not taken effect yet. Machines with
+	// other stuck requests are marked as failed, unless they are being deleted. A timeout of 0 disables it.
+	//+kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="stuckRequest must not be negative"
+	//+optional
+	StuckRequest *metav1.Duration `json:"stuckRequest,omitempty"`
+}
+
+// IPBlockSpec defines an IP block, which is reserved and owned by the cluster.
+type IPBlockSpec struct {
+	// Name is the name of the IP block, which is referenced by machines and load balancers.
+	// The IP block in IONOS Cloud is named after the cluster and the IP block.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Size is the number of IPs of the IP block.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	//+optional
+	Size int32 `json:"size,omitempty"`
+
+	// Location is the location, in which the IP block is reserved. IPs can only be assigned to resources
+	// in the same location. If not set, the location of the cluster is used.
+	//+kubebuilder:example=de/txl
+	//+optional
+	Location string `json:"location,omitempty"`
+}
+
+// BootstrapStorageSpec defines the Object Storage bucket, which stores the bootstrap data of the machines.
+type BootstrapStorageSpec struct {
+	// Bucket is the name of an existing bucket, to which the bootstrap data is uploaded.
+	// The bootstrap data is stored with the prefix bootstrap/ and expires according to a lifecycle rule,
+	// which is added to the bucket.
+	//+kubebuilder:validation:MinLength=3
+	//+kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// Region is the region of the bucket.
+	//+kubebuilder:example=eu-central-3
+	//+kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// Endpoint is the URL of the Object Storage API. If not set, it is derived from the region.
+	//+kubebuilder:example="https://s3.eu-central-3.ionoscloud.com"
+	//+kubebuilder:validation:Pattern=`^https?://`
+	//+optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsRef is a reference to the secret containing the access key and the secret key
+	// of the Object Storage, stored as accessKey and secretKey.
+	//+kubebuilder:validation:XValidation:rule="has(self.name) && self.name != ''",message="bootstrapStorage.credentialsRef.name must be provided"
+	CredentialsRef corev1.LocalObjectReference `json:"credentialsRef"`
+
+	// URLExpiry is the validity period of the pre-signed URLs, with which the servers fetch their bootstrap data.
+	// It must not exceed 7 days (168h).
+	//+kubebuilder:validation:XValidation:rule="duration(self) > duration('0s') && duration(self) <= duration('168h')",message="urlExpiry must be between 0s and 168h"
+	//+kubebuilder:default="24h"
+	//+optional
+	URLExpiry *metav1.Duration `json:"urlExpiry,omitempty"`
+
+	// ExpirationDays is the number of days, after which the uploaded bootstrap data is deleted
+	// by the lifecycle rule of the bucket.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	//+optional
+	ExpirationDays int32 `json:"expirationDays,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="self.zoneName == oldSelf.zoneName",message="dns.zoneName is immutable"
+//+kubebuilder:validation:XValidation:rule="has(self.recordName) == has(oldSelf.recordName) && (!has(self.recordName) || self.recordName == oldSelf.recordName)",message="dns.recordName is immutable"
+
+// DNSSpec defines the DNS records of the control plane endpoint in IONOS Cloud DNS.
+type DNSSpec struct {
+	// ZoneName is the name of an existing zone in IONOS Cloud DNS, in which the records are created.
+	//+kubebuilder:example=example.com
+	//+kubebuilder:validation:MinLength=1
+	ZoneName string `json:"zoneName"`
+
+	// RecordName is the name of the record relative to the zone. If not set, the name and the namespace
+	// of the cluster are used as <name>.<namespace>, so that clusters with the same name don't share a record.
+	// The host of the control plane endpoint is <recordName>.<zoneName>, if it is set by the provider.
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`
+	//+kubebuilder:validation:MaxLength=253
+	//+optional
+	RecordName string `json:"recordName,omitempty"`
+
+	// Wildcard additionally creates the record *.<recordName>, which points to the same IP, e.g. for
+	// ingress controllers, which are exposed on the control plane endpoint.
+	//+optional
+	Wildcard bool `json:"wildcard,omitempty"`
+
+	// TTL is the time to live of the records in seconds.
+	//+kubebuilder:validation:Minimum=60
+	//+kubebuilder:validation:Maximum=86400
+	//+kubebuilder:default=300
+	//+optional
+	TTL int32 `json:"ttl,omitempty"`
+
+	// Endpoint is the URL of the IONOS Cloud DNS API. The credentials of the cluster are used for it.
+	//+kubebuilder:default="https://dns.de-fra.ionos.com"
+	//+kubebuilder:validation:Pattern=`^https?://`
+	//+optional
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(self.crossConnect) || !has(self.public) || !self.public",message="only private networks can be connected via a Cross Connect"
+
+//+kubebuilder:validation:XValidation:rule="!has(self.lan) || !(has(self.crossConnect) || (has(self.public) && self.public) || (has(self.ipv6) && self.ipv6))",message="public, ipv6 and crossConnect cannot be set for an existing LAN"
+
+// NetworkSpec defines a LAN, which is created and owned by the cluster, or an existing LAN.
+type NetworkSpec struct {
+	// Name is the name of the network, which is referenced by machines.
+	// The LAN in IONOS Cloud is named after the cluster and the network, unless an existing LAN is referenced.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Public determines if the LAN is connected to the internet.
+	//+optional
+	Public bool `json:"public,omitempty"`
+
+	// IPv6 enables IPv6 on the LAN. The IPv6 CIDR block is assigned automatically.
+	//+optional
+	IPv6 bool `json:"ipv6,omitempty"`
+
+	// DHCP determines if the NICs attached to the LAN receive their IPv4 address from the
+	// DHCP server of IONOS Cloud.
+	//+kubebuilder:default=true
+	//+optional
+	DHCP *bool `json:"dhcp,omitempty"`
+
+	// Nameservers are the IP addresses of the DNS servers, which machines attached to the network use instead of
+	// the ones handed out by the DHCP server of IONOS Cloud, e.g. in private networks, which can't reach them.
+	// The DHCP server can't be configured, so they are set via the bootstrap data of the machines, which configures
+	// systemd-resolved. The nameservers of the primary network take precedence over those of additional networks.
+	//+kubebuilder:validation:XValidation:rule=`self.all(ip, ip.matches("^((25[0-5]|(2[0-4]|1\\d|[1-9]|)\\d)\\.?\\b){4}$") || ip.matches("^[0-9a-fA-F]*:[0-9a-fA-F:.]*$"))`,message="nameservers must be IP addresses"
+	//+kubebuilder:validation:MaxItems=3
+	//+kubebuilder:validation:items:MaxLength=45
+	//+listType=atomic
+	//+optional
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// NTPServers are the host names or IP addresses of the NTP servers, which machines attached to the network
+	// synchronize their clocks with. They are set via the bootstrap data of the machines, which configures
+	// systemd-timesyncd.
+	//+kubebuilder:validation:XValidation:rule=`self.all(server, server.matches("^[0-9a-zA-Z:.-]+$"))`,message="ntpServers must be host names or IP addresses"
+	//+kubebuilder:validation:MaxItems=5
+	//+kubebuilder:validation:items:MaxLength=253
+	//+listType=atomic
+	//+optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+
+	// CrossConnect connects the LANs of the network in all data centers of the cluster via a Cross Connect.
+	// This allows machines in other data centers, e.g. of a MachineDeployment, whose template sets its own
+	// data center ID, to reach the rest of the cluster privately. Only private networks can be connected.
+	//+optional
+	CrossConnect *CrossConnectSpec `json:"crossConnect,omitempty"`
+
+	// LAN references an existing LAN, which is used instead of creating one. The LAN must exist in every
+	// data center, in which machines are attached to the network. It is neither updated nor deleted
+	// by the cluster, which allows running clusters alongside existing workloads.
+	//+optional
+	LAN *LANReference `json:"lan,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.id) != has(self.name)",message="either id or name must be set"
+
+// LANReference references an existing LAN in IONOS Cloud by its ID or its name.
+type LANReference struct {
+	// ID is the ID of the LAN. As LAN IDs are only unique within a data center, networks used in multiple
+	// data centers should reference their LANs by name.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	ID int32 `json:"id,omitempty"`
+
+	// Name is the name of the LAN. It must be unique within each data center.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+}
+
+// CrossConnectSpec defines the Cross Connect, which connects the LANs of a cluster network.
+type CrossConnectSpec struct {
+	// ID is the ID of an existing Cross Connect, to which the LANs are connected.
+	// If not set, a Cross Connect is created and deleted together with the cluster.
+	// A Cross Connect referenced by ID is never deleted by the cluster.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	ID string `json:"id,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.datacenterID) || has(self.availabilityZone)",message="either datacenterID or availabilityZone must be set"
+
+// FailureDomainSpec defines a failure domain, which machines can be placed in.
+type FailureDomainSpec struct {
+	// Name is the name of the failure domain, which is referenced by machines.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DatacenterID is the ID of the data center, in which machines of this failure domain are created.
+	// If not set, the data center of the IonosCloudMachine is used.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
+
+	// AvailabilityZone is the availability zone, in which servers and volumes of this failure domain are created.
+	// If not set, the availability zones of the IonosCloudMachine are used.
+	//+kubebuilder:validation:Enum=ZONE_1;ZONE_2
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// ControlPlane determines if this failure domain is suitable for control plane machines.
+	//+kubebuilder:default=true
+	//+optional
+	ControlPlane *bool `json:"controlPlane,omitempty"`
+}
+
+// DatacenterSpec defines the data center, which is created and owned by the cluster.
+type DatacenterSpec struct {
+	// Name is the name of the data center.
+	// If not set, the name is derived from the namespace and name of the cluster.
+	//+kubebuilder:validation:MaxLength=255
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// Description is the description of the data center.
+	//+optional
+	Description string `json:"description,omitempty"`
+}
+
+// LoadBalancerSpec defines the Network Load Balancer, which serves the control plane endpoint.
+type LoadBalancerSpec struct {
+	// DatacenterID is the ID of the data center where the load balancer should be created.
+	// Control plane machines are only registered as targets, if they are located in the same data center.
+	//+kubebuilder:validation:Format=uuid
+	DatacenterID string `json:"datacenterID"`
+}
+
+// NATGatewaySpec defines the NAT Gateway, which provides outbound internet access for the cluster LAN.
+type NATGatewaySpec struct {
+	// DatacenterID is the ID of the data center where the NAT Gateway should be created.
+	// Only machines in this data center are connected to the private cluster LAN behind the NAT Gateway.
+	//+kubebuilder:validation:Format=uuid
+	DatacenterID string `json:"datacenterID"`
+
+	// SourceSubnet is the subnet of the cluster LAN, whose outbound traffic is translated by the NAT Gateway.
+	//+kubebuilder:validation:Format=cidr
+	//+kubebuilder:default="10.0.0.0/8"
+	//+kubebuilder:example="10.0.0.0/24"
+	//+optional
+	SourceSubnet string `json:"sourceSubnet,omitempty"`
+}
+
+// ApplicationLoadBalancerSpec defines the Application Load Balancer, which routes HTTP traffic to the worker machines.
+type ApplicationLoadBalancerSpec struct {
+	// DatacenterID is the ID of the data center where the load balancer should be created.
+	// Worker machines are only registered as targets, if they are located in the same data center.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenterID is immutable"
+	//+kubebuilder:validation:Format=uuid
+	DatacenterID string `json:"datacenterID"`
+
+	// Port is the port, on which the load balancer accepts HTTP traffic.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	//+kubebuilder:default=80
+	//+optional
+	Port int32 `json:"port,omitempty"`
+
+	// Rules are the HTTP rules of the load balancer. They are evaluated in the given order and
+	// the traffic is forwarded according to the first matching rule.
+	//+listType=map
+	//+listMapKey=name
+	//+kubebuilder:validation:MinItems=1
+	Rules []ApplicationLoadBalancerRule `json:"rules"`
+
+	// IPBlock is the name of an IP block of the cluster, from which the public IP of the load balancer
+	// is assigned. If not set, a dedicated IP block is reserved for the load balancer.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipBlock is immutable"
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	IPBlock string `json:"ipBlock,omitempty"`
+}
+
+// ApplicationLoadBalancerRule defines an HTTP rule, which forwards matching requests to the worker machines.
+type ApplicationLoadBalancerRule struct {
+	// Name is the name of the rule. It is also used to name the target group of the rule.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Host matches the host header of the request. If not set, requests for all hosts match.
+	//+kubebuilder:example=app.example.com
+	//+optional
+	Host string `json:"host,omitempty"`
+
+	// PathPrefix matches the beginning of the request path. If not set, requests for all paths match.
+	//+kubebuilder:validation:Pattern=`^/`
+	//+kubebuilder:example=/api
+	//+optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// TargetPort is the port at the worker machines, to which matching requests are forwarded,
+	// e.g. the node port of an ingress controller.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65535
+	TargetPort int32 `json:"targetPort"`
+}
+
+// IonosCloudClusterStatus defines the observed state of IonosCloudCluster.
+type IonosCloudClusterStatus struct {
+	// Ready indicates that the cluster is ready.
+	//+optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Conditions defines current service state of the IonosCloudCluster.
+	//+optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// CurrentRequestByDatacenter maps data center IDs to a pending provisioning request made during reconciliation.
+	//+optional
+	CurrentRequestByDatacenter map[string]ProvisioningRequest `json:"currentRequest,omitempty"`
+
+	// CurrentClusterRequest is the current pending request made during reconciliation for the whole cluster.
+	//+optional
+	CurrentClusterRequest *ProvisioningRequest `json:"currentClusterRequest,omitempty"`
+
+	// ControlPlaneEndpointIPBlockID is the IONOS Cloud UUID for the control plane endpoint IP block.
+	//+optional
+	ControlPlaneEndpointIPBlockID string `json:"controlPlaneEndpointIPBlockID,omitempty"`
+
+	// ControlPlaneEndpointDNS contains the DNS records of the control plane endpoint, which have been created.
+	//+optional
+	ControlPlaneEndpointDNS *DNSStatus `json:"controlPlaneEndpointDNS,omitempty"`
+
+	// DatacenterID is the IONOS Cloud UUID of the data center, which is owned by the cluster.
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
+
+	// NetworkDatacenterIDs contains the IDs of the data centers, in which the LANs of the cluster
+	// networks have been created. The LANs are deleted from these data centers together with the cluster.
+	//+optional
+	NetworkDatacenterIDs []string `json:"networkDatacenterIDs,omitempty"`
+
+	// Networks contains the LANs of the cluster networks in the data centers of the cluster.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	Networks []NetworkStatus `json:"networks,omitempty"`
+
+	// LoadBalancerID is the IONOS Cloud UUID of the control plane Network Load Balancer.
+	//+optional
+	LoadBalancerID string `json:"loadBalancerID,omitempty"`
+
+	// NATGatewayID is the IONOS Cloud UUID of the NAT Gateway.
+	//+optional
+	NATGatewayID string `json:"natGatewayID,omitempty"`
+
+	// NATGatewayIPBlockID is the IONOS Cloud UUID of the IP block, which provides the public IP of the NAT Gateway.
+	//+optional
+	NATGatewayIPBlockID string `json:"natGatewayIPBlockID,omitempty"`
+
+	// ApplicationLoadBalancerID is the IONOS Cloud UUID of the Application Load Balancer.
+	//+optional
+	ApplicationLoadBalancerID string `json:"applicationLoadBalancerID,omitempty"`
+
+	// ApplicationLoadBalancerIPBlockID is the IONOS Cloud UUID of the IP block, which provides the public IP
+	// of the Application Load Balancer.
+	//+optional
+	ApplicationLoadBalancerIPBlockID string `json:"applicationLoadBalancerIPBlockID,omitempty"`
+
+	// ApplicationLoadBalancerIP is the public IP address, on which the Application Load Balancer accepts traffic.
+	// DNS records of the exposed workloads should point to this address.
+	//+optional
+	ApplicationLoadBalancerIP string `json:"applicationLoadBalancerIP,omitempty"`
+
+	// IPBlocks contains the IP blocks of the cluster, which have been reserved, and the assignments of their IPs.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	IPBlocks []IPBlockStatus `json:"ipBlocks,omitempty"`
+
+	// RetainedPrivateIPs maps the names of the machines, which retain the private IPv4 address of their
+	// primary NIC, to the address. The addresses are kept after the machines have been deleted, so that
+	// machines with the same name get the same address.
+	//+optional
+	RetainedPrivateIPs map[string]string `json:"retainedPrivateIPs,omitempty"`
+
+	// PinnedImages maps the name of an IonosCloudMachineTemplate and the ID of a data center, separated by a slash,
+	// to the ID of the image, which is used for the boot volumes of all machines of the template, whose image
+	// upgrade policy is Pin. The image is pinned once the first machine has been created and is kept
+	// as long as the template exists.
+	//+optional
+	PinnedImages map[string]string `json:"pinnedImages,omitempty"`
+
+	// IPv6Prefixes maps the IDs of the data centers, in which dual-stack machines are placed, to the IPv6
+	// CIDR block (/64), which IONOS Cloud delegated to the primary LAN of the cluster in that data center.
+	//+optional
+	IPv6Prefixes map[string]string `json:"ipv6Prefixes,omitempty"`
+
+	// IPv6CIDRBlocks maps the names of the dual-stack machines to the IPv6 CIDR block (/80), which is assigned
+	// to their primary NIC. The blocks are sliced from the prefix of the data center of the machine in ascending
+	// order, so that the IPv6 addresses of the nodes, e.g. the pod CIDRs, can be planned ahead.
+	//+optional
+	IPv6CIDRBlocks map[string]string `json:"ipv6CIDRBlocks,omitempty"`
+
+	// FailureDomains contains the failure domains, which are declared in the spec.
+	// They are picked up by Cluster API to distribute machines across them.
+	//+optional
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+}
+
+// NetworkStatus is the observed state of a cluster network.
+type NetworkStatus struct {
+	// Name is the name of the network in the spec.
+	Name string `json:"name"`
+
+	// Unmanaged indicates that the network uses an existing LAN, which is not created or deleted by the cluster.
+	//+optional
+	Unmanaged bool `json:"unmanaged,omitempty"`
+
+	// LANIDs maps the IDs of the data centers to the IDs of the LANs of the network.
+	//+optional
+	LANIDs map[string]string `json:"lanIDs,omitempty"`
+}
+
+// DNSStatus is the observed state of the DNS records of the control plane endpoint.
+type DNSStatus struct {
+	// ZoneID is the IONOS Cloud UUID of the zone, which contains the records.
+	//+optional
+	ZoneID string `json:"zoneID,omitempty"`
+
+	// Address is the IP address, to which the records point. If the host of the control plane endpoint
+	// is the name of the record, it is used as the IP of the control plane endpoint.
+	//+optional
+	Address string `json:"address,omitempty"`
+
+	// RecordIDs maps the names of the records to their IONOS Cloud UUIDs. Only these records are updated
+	// and deleted by the provider.
+	//+optional
+	RecordIDs map[string]string `json:"recordIDs,omitempty"`
+
+	// OwnershipRecordIDs maps the names of the records to the IONOS Cloud UUIDs of the TXT records, which mark
+	// them as owned by the cluster.
+	//+optional
+	OwnershipRecordIDs map[string]string `json:"ownershipRecordIDs,omitempty"`
+}
+
+// IPBlockStatus is the observed state of an IP block of the cluster.
+type IPBlockStatus struct {
+	// Name is the name of the IP block in the spec.
+	Name string `json:"name"`
+
+	// ID is the IONOS Cloud UUID of the IP block.
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// IPs are the IPs of the IP block.
+	//+optional
+	IPs []string `json:"ips,omitempty"`
+
+	// Assignments maps the resources, which use IPs of the IP block, to their IP. Machines are
+	// identified by IonosCloudMachine/<name>, the Application Load Balancer by ApplicationLoadBalancer.
+	//+optional
+	Assignments map[string]string `json:"assignments,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=ionoscloudclusters,scope=Namespaced,categories=cluster-api;ionoscloud,shortName=icc
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Cluster infrastructure is ready"
+//+kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint",description="API Endpoint"
+
+// IonosCloudCluster is the Schema for the ionoscloudclusters API.
+type IonosCloudCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IonosCloudClusterSpec   `json:"spec,omitempty"`
+	Status IonosCloudClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IonosCloudClusterList contains a list of IonosCloudCluster.
+type IonosCloudClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IonosCloudCluster `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IonosCloudCluster{}, &IonosCloudClusterList{})
+}
+
+// GetConditions returns the conditions from the status.
+func (i *IonosCloudCluster) GetConditions() clusterv1.Conditions {
+	return i.Status.Conditions
+}
+
+// SetConditions sets the conditions in the status.
+func (i *IonosCloudCluster) SetConditions(conditions clusterv1.Conditions) {
+	i.Status.Conditions = conditions
+}
+
+// SetCurrentRequestByDatacenter sets the current provisioning request for the given data center.
+// This function makes sure that the map is initialized before setting the request.
+func (i *IonosCloudCluster) SetCurrentRequestByDatacenter(datacenterID, method, status, requestPath string) {
+	if i.Status.CurrentRequestByDatacenter == nil {
+		i.Status.CurrentRequestByDatacenter = map[string]ProvisioningRequest{}
+	}
+	i.Status.CurrentRequestByDatacenter[datacenterID] = ProvisioningRequest{
+		Method:      method,
+		RequestPath: requestPath,
+		State:       status,
+	}
+}
+
+// DeleteCurrentRequestByDatacenter deletes the current provisioning request for the given data center.
+func (i *IonosCloudCluster) DeleteCurrentRequestByDatacenter(datacenterID string) {
+	delete(i.Status.CurrentRequestByDatacenter, datacenterID)
+}
+
+// SetCurrentClusterRequest sets the current provisioning request for the cluster.
+func (i *IonosCloudCluster) SetCurrentClusterRequest(method, status, requestPath string) {
+	i.Status.CurrentClusterRequest = &ProvisioningRequest{
+		Method:      method,
+		RequestPath: requestPath,
+		State:       status,
+	}
+}
+
+// DeleteCurrentClusterRequest deletes the current provisioning request for the cluster.
+func (i *IonosCloudCluster) DeleteCurrentClusterRequest() {
+	i.Status.CurrentClusterRequest = nil
+}
+
+// HasPendingRequests returns true if the cluster tracks a provisioning request, which has not completed yet.
+func (i *IonosCloudCluster) HasPendingRequests() bool {
+	return i.Status.CurrentClusterRequest != nil || len(i.Status.CurrentRequestByDatacenter) > 0
+}
@@ -0,0 +1,645 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// newValueStr is a string that represents a changed value.
+	newValueStr = "changed"
+	exampleIP   = "198.51.100.1"
+)
+
+func TestIonosCloudCluster_Conditions(t *testing.T) {
+	conds := clusterv1.Conditions{{Type: "type"}}
+	cluster := &IonosCloudCluster{}
+
+	cluster.SetConditions(conds)
+	require.Equal(t, conds, cluster.GetConditions())
+}
+
+func defaultCluster() *IonosCloudCluster {
+	return &IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: IonosCloudClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{
+				Host: exampleIP,
+				Port: 5678,
+			},
+			Location:       "de/txl",
+			CredentialsRef: &corev1.LocalObjectReference{Name: "secret-name"},
+		},
+	}
+}
+
+var _ = Describe("IonosCloudCluster", func() {
+	AfterEach(func() {
+		err := k8sClient.Delete(context.Background(), defaultCluster())
+		Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+	})
+
+	Context("Create", func() {
+		It("should allow creating valid clusters", func() {
+			Expect(k8sClient.Create(context.Background(), defaultCluster())).To(Succeed())
+		})
+		It("should work with a FQDN controlplane endpoint", func() {
+			cluster := defaultCluster()
+			cluster.Spec.ControlPlaneEndpoint.Host = "example.org"
+			Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+		})
+		It("should not allow creating clusters with empty credential secret", func() {
+			cluster := defaultCluster()
+			cluster.Spec.CredentialsRef.Name = ""
+			Expect(k8sClient.Create(context.Background(), cluster)).
+				Should(MatchError(ContainSubstring("credentialsRef.name must be provided")))
+		})
+		It("should allow creating clusters with an identity", func() {
+			cluster := defaultCluster()
+			cluster.Spec.CredentialsRef = nil
+			cluster.Spec.IdentityRef = &IonosCloudClusterIdentityReference{Name: "identity"}
+			Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+		})
+		It("should not allow creating clusters with credentials and an identity", func() {
+			cluster := defaultCluster()
+			cluster.Spec.IdentityRef = &IonosCloudClusterIdentityReference{Name: "identity"}
+			Expect(k8sClient.Create(context.Background(), cluster)).
+				Should(MatchError(ContainSubstring("exactly one of credentialsRef or identityRef must be set")))
+		})
+		It("should not allow creating clusters without credentials", func() {
+			cluster := defaultCluster()
+			cluster.Spec.CredentialsRef = nil
+			Expect(k8sClient.Create(context.Background(), cluster)).
+				Should(MatchError(ContainSubstring("exactly one of credentialsRef or identityRef must be set")))
+		})
+
+		Context("Failure domains", func() {
+			It("should allow creating clusters with failure domains", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{
+					{Name: "dc", DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"},
+					{Name: "zone", AvailabilityZone: AvailabilityZoneOne},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(ptr.Deref(cluster.Spec.FailureDomains[0].ControlPlane, false)).To(BeTrue())
+			})
+			It("should fail if neither data center nor zone are set", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{{Name: "empty"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("either datacenterID or availabilityZone must be set")))
+			})
+			It("should fail if the names are not unique", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{
+					{Name: "fd", AvailabilityZone: AvailabilityZoneOne},
+					{Name: "fd", AvailabilityZone: AvailabilityZoneTwo},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should fail if the zone is AUTO", func() {
+				cluster := defaultCluster()
+				cluster.Spec.FailureDomains = []FailureDomainSpec{{Name: "fd", AvailabilityZone: AvailabilityZoneAuto}}
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+		})
+
+		Context("Labels", func() {
+			It("should allow setting labels", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Labels = map[string]string{"team": "platform"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow setting reserved labels", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Labels = map[string]string{"cluster-name": "other"}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("cluster-namespace and machine-name are reserved labels")))
+			})
+		})
+	})
+
+	Context("Update", func() {
+		It("should not allow changing the location", func() {
+			cluster := defaultCluster()
+			Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+			cluster.Spec.Location = newValueStr
+			Expect(k8sClient.Update(context.Background(), cluster)).ToNot(Succeed())
+		})
+
+		When("trying to update the control plane endpoint", func() {
+			It("should fail if the host is already set", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ControlPlaneEndpoint.Host = newValueStr
+				Expect(k8sClient.Update(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should work if the endpoint host is not set", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ControlPlaneEndpoint.Host = ""
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ControlPlaneEndpoint.Host = newValueStr
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+			It("should fail if the port is already set", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ControlPlaneEndpoint.Port = 1234
+				Expect(k8sClient.Update(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should work if the endpoint port is not set", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ControlPlaneEndpoint.Port = 0
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ControlPlaneEndpoint.Port = 4657
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+		})
+
+		When("trying to update the load balancer", func() {
+			It("should not allow adding a load balancer", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer cannot be added or removed")))
+			})
+			It("should not allow removing the load balancer", func() {
+				cluster := defaultCluster()
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer cannot be added or removed")))
+			})
+			It("should not allow changing the data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.LoadBalancer = &LoadBalancerSpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.LoadBalancer.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("loadBalancer is immutable")))
+			})
+		})
+		When("trying to update the NAT gateway", func() {
+			It("should default the source subnet", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.NATGateway.SourceSubnet).To(Equal("10.0.0.0/8"))
+			})
+			It("should not allow adding a NAT gateway", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway cannot be added or removed")))
+			})
+			It("should not allow removing the NAT gateway", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway cannot be added or removed")))
+			})
+			It("should not allow changing the source subnet", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.NATGateway.SourceSubnet = "10.1.0.0/16"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("natGateway is immutable")))
+			})
+		})
+		When("trying to update the application load balancer", func() {
+			alb := func() *ApplicationLoadBalancerSpec {
+				return &ApplicationLoadBalancerSpec{
+					DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a",
+					Rules:        []ApplicationLoadBalancerRule{{Name: "ingress", TargetPort: 30080}},
+				}
+			}
+			It("should default the port", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.ApplicationLoadBalancer.Port).To(Equal(int32(80)))
+			})
+			It("should require at least one rule", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				cluster.Spec.ApplicationLoadBalancer.Rules = nil
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should not allow a path prefix without leading slash", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				cluster.Spec.ApplicationLoadBalancer.Rules[0].PathPrefix = "api"
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should not allow adding an application load balancer", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("applicationLoadBalancer cannot be added or removed")))
+			})
+			It("should not allow changing the data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ApplicationLoadBalancer.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenterID is immutable")))
+			})
+			It("should allow changing the rules", func() {
+				cluster := defaultCluster()
+				cluster.Spec.ApplicationLoadBalancer = alb()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.ApplicationLoadBalancer.Rules = append(cluster.Spec.ApplicationLoadBalancer.Rules,
+					ApplicationLoadBalancerRule{Name: "api", Host: "api.example.com", TargetPort: 30081})
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+		})
+		When("trying to update the data center", func() {
+			It("should allow creating a cluster with a data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow adding a data center", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter cannot be added or removed")))
+			})
+			It("should not allow removing the data center", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter cannot be added or removed")))
+			})
+			It("should not allow changing the name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Datacenter = &DatacenterSpec{Name: "dc"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Datacenter.Name = "other"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("datacenter is immutable")))
+			})
+		})
+		When("trying to update the networks", func() {
+			It("should default DHCP", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.Networks[0].DHCP).To(HaveValue(BeTrue()))
+			})
+			It("should allow appending networks", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Networks = append(cluster.Spec.Networks, NetworkSpec{Name: "storage", DHCP: ptr.To(false)})
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow changing a network", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Networks[0].Public = true
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("networks cannot be changed or removed")))
+			})
+			It("should not allow adding networks to an existing cluster", func() {
+				cluster := defaultCluster()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary"}}
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("networks cannot be added to or removed from an existing cluster")))
+			})
+			It("should require a private first network for the NAT gateway", func() {
+				cluster := defaultCluster()
+				cluster.Spec.NATGateway = &NATGatewaySpec{DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"}
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", Public: true}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("the first network must be private")))
+			})
+			It("should only allow connecting private networks via a Cross Connect", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", Public: true, CrossConnect: &CrossConnectSpec{}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("only private networks can be connected via a Cross Connect")))
+
+				cluster.Spec.Networks[0].Public = false
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should require the ID of an existing Cross Connect to be a UUID", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", CrossConnect: &CrossConnectSpec{ID: "pcc"}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("spec.networks[0].crossConnect.id")))
+			})
+			It("should allow referencing an existing LAN by ID or name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{
+					{Name: "primary", LAN: &LANReference{ID: 1}},
+					{Name: "storage", LAN: &LANReference{Name: "storage"}},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should require either the ID or the name of an existing LAN", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", LAN: &LANReference{}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("either id or name must be set")))
+
+				cluster.Spec.Networks[0].LAN = &LANReference{ID: 1, Name: "primary"}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("either id or name must be set")))
+			})
+			It("should allow setting nameservers and NTP servers", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{
+					Name:        "primary",
+					Nameservers: []string{"10.0.0.53", "2001:db8::53"},
+					NTPServers:  []string{"ntp.example.com", "10.0.0.123"},
+				}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should require nameservers to be IP addresses", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", Nameservers: []string{"dns.example.com"}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("nameservers must be IP addresses")))
+			})
+			It("should not allow invalid NTP servers", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", NTPServers: []string{"ntp.example.com; reboot"}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("ntpServers must be host names or IP addresses")))
+			})
+			It("should not allow configuring an existing LAN", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Networks = []NetworkSpec{{Name: "primary", IPv6: true, LAN: &LANReference{ID: 1}}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("public, ipv6 and crossConnect cannot be set for an existing LAN")))
+
+				cluster.Spec.Networks[0].IPv6 = false
+				cluster.Spec.Networks[0].CrossConnect = &CrossConnectSpec{}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("public, ipv6 and crossConnect cannot be set for an existing LAN")))
+			})
+		})
+		When("trying to update the IP blocks", func() {
+			It("should default the size", func() {
+				cluster := defaultCluster()
+				cluster.Spec.IPBlocks = []IPBlockSpec{{Name: "egress"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.IPBlocks[0].Size).To(Equal(int32(1)))
+			})
+			It("should allow appending IP blocks", func() {
+				cluster := defaultCluster()
+				cluster.Spec.IPBlocks = []IPBlockSpec{{Name: "egress"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.IPBlocks = append(cluster.Spec.IPBlocks, IPBlockSpec{Name: "ingress", Size: 2})
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow changing an IP block", func() {
+				cluster := defaultCluster()
+				cluster.Spec.IPBlocks = []IPBlockSpec{{Name: "egress"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.IPBlocks[0].Size = 4
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("ipBlocks cannot be changed or removed")))
+			})
+			It("should require a DNS label as name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.IPBlocks = []IPBlockSpec{{Name: "Egress_IPs"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("spec.ipBlocks[0].name")))
+			})
+		})
+		When("configuring the bootstrap storage", func() {
+			storage := func() *BootstrapStorageSpec {
+				return &BootstrapStorageSpec{
+					Bucket:         "bootstrap-data",
+					Region:         "eu-central-3",
+					CredentialsRef: corev1.LocalObjectReference{Name: "object-storage"},
+				}
+			}
+			It("should default the URL expiry and the expiration days", func() {
+				cluster := defaultCluster()
+				cluster.Spec.BootstrapStorage = storage()
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.BootstrapStorage.URLExpiry).To(Equal(&metav1.Duration{Duration: 24 * time.Hour}))
+				Expect(cluster.Spec.BootstrapStorage.ExpirationDays).To(Equal(int32(1)))
+			})
+			It("should not allow a URL expiry of more than 7 days", func() {
+				cluster := defaultCluster()
+				cluster.Spec.BootstrapStorage = storage()
+				cluster.Spec.BootstrapStorage.URLExpiry = &metav1.Duration{Duration: 8 * 24 * time.Hour}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("urlExpiry must be between 0s and 168h")))
+			})
+			It("should require the name of the credentials secret", func() {
+				cluster := defaultCluster()
+				cluster.Spec.BootstrapStorage = storage()
+				cluster.Spec.BootstrapStorage.CredentialsRef.Name = ""
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("bootstrapStorage.credentialsRef.name must be provided")))
+			})
+		})
+		When("configuring the DNS record of the control plane endpoint", func() {
+			It("should default the TTL and the endpoint", func() {
+				cluster := defaultCluster()
+				cluster.Spec.DNS = &DNSSpec{ZoneName: "example.com"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+				Expect(cluster.Spec.DNS.TTL).To(Equal(int32(300)))
+				Expect(cluster.Spec.DNS.Endpoint).To(Equal("https://dns.de-fra.ionos.com"))
+			})
+			It("should require a DNS name as record name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.DNS = &DNSSpec{ZoneName: "example.com", RecordName: "*.api"}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("spec.dns.recordName")))
+			})
+			It("should allow enabling the wildcard record", func() {
+				cluster := defaultCluster()
+				cluster.Spec.DNS = &DNSSpec{ZoneName: "example.com"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.DNS.Wildcard = true
+				Expect(k8sClient.Update(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow changing the record name", func() {
+				cluster := defaultCluster()
+				cluster.Spec.DNS = &DNSSpec{ZoneName: "example.com"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.DNS.RecordName = "api"
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("dns.recordName is immutable")))
+			})
+			It("should not allow removing the DNS record", func() {
+				cluster := defaultCluster()
+				cluster.Spec.DNS = &DNSSpec{ZoneName: "example.com"}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+				cluster.Spec.DNS = nil
+				Expect(k8sClient.Update(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("dns cannot be removed")))
+			})
+		})
+		When("overriding the timeouts", func() {
+			It("should allow disabling the machine timeouts", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Timeouts = &TimeoutsSpec{
+					RequestPollInterval: &metav1.Duration{Duration: time.Minute},
+					MachineProvisioning: &metav1.Duration{},
+					MachineDeletion:     &metav1.Duration{},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow a request poll interval of less than 1s", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Timeouts = &TimeoutsSpec{RequestPollInterval: &metav1.Duration{Duration: time.Millisecond}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("requestPollInterval must be at least 1s")))
+			})
+			It("should not allow negative machine timeouts", func() {
+				cluster := defaultCluster()
+				cluster.Spec.Timeouts = &TimeoutsSpec{
+					MachineProvisioning: &metav1.Duration{Duration: -time.Minute},
+					MachineDeletion:     &metav1.Duration{Duration: -time.Minute},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).Should(And(
+					MatchError(ContainSubstring("machineProvisioning must not be negative")),
+					MatchError(ContainSubstring("machineDeletion must not be negative")),
+				))
+			})
+		})
+		When("setting machine defaults", func() {
+			It("should allow setting machine defaults", func() {
+				cluster := defaultCluster()
+				cluster.Spec.MachineDefaults = &MachineDefaults{
+					CPUFamily:        ptr.To("INTEL_SKYLAKE"),
+					DiskType:         VolumeDiskTypeSSDPremium,
+					AvailabilityZone: AvailabilityZoneOne,
+					Labels:           map[string]string{"team": "platform"},
+				}
+				Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+			})
+			It("should not allow the AUTO availability zone", func() {
+				cluster := defaultCluster()
+				cluster.Spec.MachineDefaults = &MachineDefaults{AvailabilityZone: AvailabilityZoneAuto}
+				Expect(k8sClient.Create(context.Background(), cluster)).ToNot(Succeed())
+			})
+			It("should not allow setting reserved labels", func() {
+				cluster := defaultCluster()
+				cluster.Spec.MachineDefaults = &MachineDefaults{Labels: map[string]string{"machine-name": "test"}}
+				Expect(k8sClient.Create(context.Background(), cluster)).
+					Should(MatchError(ContainSubstring("cluster-namespace and machine-name are reserved labels")))
+			})
+		})
+	})
+	Context("Status", func() {
+		It("should correctly get and set the status", func() {
+			By("initially having an empty status")
+
+			cluster := defaultCluster()
+			Expect(k8sClient.Create(context.Background(), cluster)).To(Succeed())
+
+			key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}
+			fetched := &IonosCloudCluster{}
+			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+			Expect(fetched.Status.Ready).To(BeFalse())
+			Expect(fetched.Status.CurrentRequestByDatacenter).To(BeEmpty())
+			Expect(fetched.Status.Conditions).To(BeEmpty())
+
+			By("retrieving the cluster and setting the status")
+			fetched.Status.Ready = true
+			wantProvisionRequest := ProvisioningRequest{
+				Method:      "POST",
+				RequestPath: "/path/to/resource",
+				State:       "QUEUED",
+			}
+			fetched.SetCurrentRequestByDatacenter("123",
+				wantProvisionRequest.Method, wantProvisionRequest.State, wantProvisionRequest.RequestPath)
+			conditions.MarkTrue(fetched, clusterv1.ReadyCondition)
+
+			By("updating the cluster status")
+			Expect(k8sClient.Status().Update(context.Background(), fetched)).To(Succeed())
+
+			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+			Expect(fetched.Status.Ready).To(BeTrue())
+			Expect(fetched.Status.CurrentRequestByDatacenter).To(HaveLen(1))
+			Expect(fetched.Status.CurrentRequestByDatacenter["123"]).To(Equal(wantProvisionRequest))
+			Expect(fetched.Status.Conditions).To(HaveLen(1))
+			Expect(conditions.IsTrue(fetched, clusterv1.ReadyCondition)).To(BeTrue())
+
+			By("Removing the entry from the status again")
+			fetched.DeleteCurrentRequestByDatacenter("123")
+			Expect(k8sClient.Status().Update(context.Background(), fetched)).To(Succeed())
+
+			Expect(k8sClient.Get(context.Background(), key, fetched)).To(Succeed())
+			Expect(fetched.Status.CurrentRequestByDatacenter).To(BeEmpty())
+		})
+	})
+})
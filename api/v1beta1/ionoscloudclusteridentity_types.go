@@ -0,0 +1,105 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IonosCloudClusterIdentityKind is the string resource kind of the IonosCloudClusterIdentity resource.
+const IonosCloudClusterIdentityKind = "IonosCloudClusterIdentity"
+
+// IonosCloudClusterIdentitySpec defines the desired state of IonosCloudClusterIdentity.
+type IonosCloudClusterIdentitySpec struct {
+	// SecretRef is a reference to the secret containing the credentials to access the IONOS Cloud API.
+	// The secret needs to contain either a token or a username and password.
+	// It is not owned by the clusters using the identity and is never deleted by the controller.
+	SecretRef IdentitySecretReference `json:"secretRef"`
+
+	// ContractNumber selects the contract, in which the IonosCloudClusters using the identity manage their
+	// resources, if the user of the credentials has access to multiple contracts. It takes precedence over the
+	// contractNumber of the secret, so that identities for different contracts can share the same secret.
+	// If neither is set, the default contract of the user is used.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	ContractNumber *int32 `json:"contractNumber,omitempty"`
+
+	// AllowedNamespaces restricts the namespaces, from which IonosCloudClusters can use the identity.
+	// A namespace is allowed, if it is part of the list or matches the selector.
+	// An empty object allows all namespaces. If it is not set, no namespace is allowed.
+	//+optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// IdentitySecretReference references a secret in a specific namespace.
+type IdentitySecretReference struct {
+	// Name is the name of the secret.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the secret.
+	//+kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// AllowedNamespaces selects the namespaces, which are allowed to use an identity.
+type AllowedNamespaces struct {
+	// NamespaceList is a list of namespaces, which are allowed to use the identity.
+	//+listType=set
+	//+optional
+	NamespaceList []string `json:"list,omitempty"`
+
+	// Selector selects the namespaces, which are allowed to use the identity, by their labels.
+	// An empty selector matches all namespaces.
+	//+optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// IonosCloudClusterIdentityReference is a reference to an IonosCloudClusterIdentity.
+type IonosCloudClusterIdentityReference struct {
+	// Name is the name of the IonosCloudClusterIdentity.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:resource:path=ionoscloudclusteridentities,scope=Cluster,categories=cluster-api;ionoscloud,shortName=icci
+//+kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretRef.name",description="Credentials secret"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of the identity"
+
+// IonosCloudClusterIdentity is the Schema for the ionoscloudclusteridentities API.
+// It allows sharing the credentials of a single secret between the IonosCloudClusters of multiple namespaces.
+type IonosCloudClusterIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IonosCloudClusterIdentitySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IonosCloudClusterIdentityList contains a list of IonosCloudClusterIdentity.
+type IonosCloudClusterIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IonosCloudClusterIdentity `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IonosCloudClusterIdentity{}, &IonosCloudClusterIdentityList{})
+}
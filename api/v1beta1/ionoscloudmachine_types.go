@@ -0,0 +1,1523 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	// IonosCloudMachineType is the named type for the API object.
+	IonosCloudMachineType = "IonosCloudMachine"
+
+	// MachineFinalizer is the finalizer for the IonosCloudMachine resources.
+	// It will prevent the deletion of the resource until it was removed by the controller
+	// to ensure that related cloud resources will be deleted before the IonosCloudMachine resource
+	// will be removed from the API server.
+	MachineFinalizer = "ionoscloudmachine.infrastructure.cluster.x-k8s.io"
+
+	// RebootAnnotation requests a reboot of the server of an IonosCloudMachine, if set to "true".
+	// The annotation is removed once the reboot has been requested.
+	RebootAnnotation = "infrastructure.cluster.x-k8s.io/reboot"
+
+	// RedeliverBootstrapDataAnnotation requests the re-delivery of the bootstrap data to the server of an
+	// IonosCloudMachine, if set to "true", e.g. after the bootstrap data secret was rotated. The bootstrap data is
+	// rendered again and delivered with a new boot volume, which replaces the boot volume of the server.
+	// The annotation is removed once the re-delivery has been started.
+	RedeliverBootstrapDataAnnotation = "infrastructure.cluster.x-k8s.io/redeliver-bootstrap-data"
+
+	// RemoteConsoleAnnotation requests the URL of the remote console of the server of an IonosCloudMachine,
+	// if set to "true". The URL is stored in a secret, which is referenced in the status of the IonosCloudMachine.
+	// The annotation is removed once the URL has been stored.
+	RemoteConsoleAnnotation = "infrastructure.cluster.x-k8s.io/remote-console"
+
+	// RemoteConsoleURLKey is the key of the remote console URL in the secret referenced by the
+	// remote console status of an IonosCloudMachine.
+	RemoteConsoleURLKey = "url"
+
+	// MaxRecentRequests is the number of provisioning requests, which are kept in the recent requests
+	// of an IonosCloudMachine.
+	MaxRecentRequests = 5
+
+	// MachineProvisionedCondition documents the status of the provisioning of a IonosCloudMachine and
+	// the underlying VM.
+	//
+	// Deprecated: The condition is no longer set and is removed from existing machines. The progress of the
+	// provisioning is reported by the ServerCreated, VolumeReady, NICConfigured, BootstrapDelivered
+	// and ProviderIDSet conditions.
+	MachineProvisionedCondition clusterv1.ConditionType = "MachineProvisioned"
+
+	// ServerCreatedCondition reports whether the VM of an IonosCloudMachine has been created and is available.
+	ServerCreatedCondition clusterv1.ConditionType = "ServerCreated"
+
+	// CreatingServerReason (Severity=Info) indicates that the VM is being created.
+	CreatingServerReason = "CreatingServer"
+
+	// ServerCreationRejectedReason (Severity=Error) indicates that the Cloud API rejected the creation of the VM
+	// as specified. The IonosCloudMachine has failed and needs to be replaced.
+	ServerCreationRejectedReason = "ServerCreationRejected"
+
+	// QuotaExceededReason (Severity=Warning) indicates that the VM would exceed the resource limits of the
+	// contract. The creation of the VM is retried until enough resources are available.
+	QuotaExceededReason = "QuotaExceeded"
+
+	// ServerImportRejectedReason (Severity=Warning) indicates that the existing VM, which should be imported,
+	// doesn't exist or doesn't match the spec of the IonosCloudMachine. The import is retried until the VM matches.
+	ServerImportRejectedReason = "ServerImportRejected"
+
+	// VolumeReadyCondition reports whether the boot volume and the additional volumes of the VM are available.
+	VolumeReadyCondition clusterv1.ConditionType = "VolumeReady"
+
+	// WaitingForVolumesReason (Severity=Info) indicates that at least one volume of the VM is not available yet.
+	WaitingForVolumesReason = "WaitingForVolumes"
+
+	// NICConfiguredCondition reports whether all NICs of the VM have been assigned their IP addresses.
+	NICConfiguredCondition clusterv1.ConditionType = "NICConfigured"
+
+	// WaitingForNICAddressesReason (Severity=Info) indicates that at least one NIC of the VM has
+	// no IP address yet.
+	WaitingForNICAddressesReason = "WaitingForNICAddresses"
+
+	// BootstrapDeliveredCondition reports whether the bootstrap data has been delivered to the VM,
+	// either as user data of the boot volume or as a CD-ROM image.
+	BootstrapDeliveredCondition clusterv1.ConditionType = "BootstrapDelivered"
+
+	// ProviderIDSetCondition reports whether the provider ID of the IonosCloudMachine has been set.
+	ProviderIDSetCondition clusterv1.ConditionType = "ProviderIDSet"
+
+	// WaitingForClusterInfrastructureReason (Severity=Info) indicates that the IonosCloudMachine is currently
+	// waiting for the cluster infrastructure to become ready.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+
+	// WaitingForBootstrapDataReason (Severity=Info) indicates that the bootstrap provider has not yet finished
+	// creating the bootstrap data secret and store it in the Cluster API Machine.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+
+	// ShuttingDownReason (Severity=Info) indicates that the VM was requested to shut down
+	// and the controller waits for it to power off before deleting it.
+	ShuttingDownReason = "ShuttingDown"
+
+	// ServerResourcesUpdatedCondition indicates whether the number of cores and the memory size of the VM
+	// match the spec of the IonosCloudMachine.
+	ServerResourcesUpdatedCondition clusterv1.ConditionType = "ServerResourcesUpdated"
+
+	// ResizingReason (Severity=Info) indicates that the number of cores or the memory size of the VM
+	// is being updated in place.
+	ResizingReason = "Resizing"
+
+	// RebootRequiredReason (Severity=Warning) indicates that the number of cores or the memory size of the VM
+	// was updated, but could not be hot-plugged. The changes take effect after the VM has been restarted.
+	RebootRequiredReason = "RebootRequired"
+
+	// ServerInSyncCondition indicates whether the VM of a provisioned IonosCloudMachine matches its spec.
+	// It is only set if the drift policy of the IonosCloudMachine is Report.
+	ServerInSyncCondition clusterv1.ConditionType = "ServerInSync"
+
+	// DriftDetectedReason (Severity=Warning) indicates that the VM differs from the spec of the IonosCloudMachine.
+	// The differences are listed in the message of the condition.
+	DriftDetectedReason = "DriftDetected"
+
+	// ServerDeletedCondition documents the progress of the deletion of the VM of an IonosCloudMachine.
+	// It is only set while the IonosCloudMachine is being deleted.
+	ServerDeletedCondition clusterv1.ConditionType = "ServerDeleted"
+
+	// WaitingForNodeDrainReason (Severity=Info) indicates that the deletion of the VM is on hold until
+	// Cluster API has drained the node of the machine.
+	WaitingForNodeDrainReason = "WaitingForNodeDrain"
+
+	// DeletionTimedOutReason (Severity=Error) indicates that the deletion of the machine exceeded the machine
+	// deletion timeout. The deletion is still retried, but it might require manual intervention.
+	DeletionTimedOutReason = "DeletionTimedOut"
+
+	// WaitingForVolumeDetachReason (Severity=Info) indicates that the deletion of the VM is on hold until
+	// all volumes, which are managed by the cluster, have been detached from the node of the machine.
+	WaitingForVolumeDetachReason = "WaitingForVolumeDetach"
+
+	// LastControlPlaneMachineReason (Severity=Warning) indicates that the deletion of the VM is blocked,
+	// because it hosts the last healthy control plane machine of a cluster, which is not being deleted.
+	LastControlPlaneMachineReason = "LastControlPlaneMachine"
+
+	// DeletingVolumesReason (Severity=Info) indicates that the volumes, which belong to the machine,
+	// are being deleted.
+	DeletingVolumesReason = "DeletingVolumes"
+
+	// DetachingVolumesReason (Severity=Info) indicates that the remaining volumes are being detached
+	// from the VM, so that they are kept once the VM is deleted.
+	DetachingVolumesReason = "DetachingVolumes"
+
+	// RetainingVolumesReason (Severity=Info) indicates that the volumes, which belong to the machine,
+	// are being detached from the VM, so that they are retained according to the VolumeDeletionPolicy.
+	RetainingVolumesReason = "RetainingVolumes"
+
+	// IPAddressClaimedCondition reports whether the IP addresses of all NICs, which reference an IPAM pool,
+	// have been claimed, and whether an IP of the referenced IP block of the cluster has been assigned.
+	IPAddressClaimedCondition clusterv1.ConditionType = "IPAddressClaimed"
+
+	// WaitingForIPAddressReason (Severity=Info) indicates that the IonosCloudMachine is currently waiting
+	// for an IPAM provider or an IP block of the cluster to allocate an IP address for one of its NICs.
+	WaitingForIPAddressReason = "WaitingForIPAddress"
+
+	// InstanceHealthyCondition reports whether the VM of an IonosCloudMachine is in the state which is expected
+	// from its desired power state.
+	InstanceHealthyCondition clusterv1.ConditionType = "InstanceHealthy"
+
+	// InstanceCrashedReason (Severity=Error) indicates that the VM has crashed. The IonosCloudMachine is marked
+	// as failed, so that it can be remediated.
+	InstanceCrashedReason = "InstanceCrashed"
+
+	// InstanceStoppedReason (Severity=Warning) indicates that the VM was shut off unexpectedly and is
+	// being restarted.
+	InstanceStoppedReason = "InstanceStopped"
+
+	// BootstrapExecutedSuccessfullyCondition reports whether the bootstrap data has been executed successfully
+	// on the VM, which is detected by the Node of the IonosCloudMachine registering in the workload cluster.
+	// It is only set if the controller manager has access to the workload clusters.
+	BootstrapExecutedSuccessfullyCondition clusterv1.ConditionType = "BootstrapExecutedSuccessfully"
+
+	// WaitingForBootstrapExecutionReason (Severity=Info) indicates that the VM is available, but the bootstrap
+	// has not finished yet, as the Node of the machine has not registered in the workload cluster.
+	WaitingForBootstrapExecutionReason = "WaitingForBootstrapExecution"
+
+	// BootstrapExecutionTimedOutReason (Severity=Warning) indicates that the Node of the machine has not
+	// registered within the bootstrap execution timeout after the VM became available. This usually means
+	// that the bootstrap, e.g. kubeadm, failed on the VM.
+	BootstrapExecutionTimedOutReason = "BootstrapExecutionTimedOut"
+
+	// CPUFamilyAvailableCondition reports whether the CPU family of the spec is offered in the location
+	// of the data center. It is only set for machines, which request a CPU family.
+	CPUFamilyAvailableCondition clusterv1.ConditionType = "CPUFamilyAvailable"
+
+	// CPUFamilySubstitutedReason (Severity=Warning) indicates that the requested CPU family is not offered
+	// in the location of the data center and the VM was created with a compatible one instead.
+	CPUFamilySubstitutedReason = "CPUFamilySubstituted"
+
+	// BootstrapImageAvailableCondition reports whether the CD-ROM image, which delivers bootstrap data
+	// exceeding the user data limit of the boot volume, is available. It is only set for such machines.
+	BootstrapImageAvailableCondition clusterv1.ConditionType = "BootstrapImageAvailable"
+
+	// BootstrapImageProcessingReason (Severity=Info) indicates that the bootstrap image was uploaded
+	// and is being processed by IONOS Cloud.
+	BootstrapImageProcessingReason = "BootstrapImageProcessing"
+
+	// UserDataTooLargeReason (Severity=Warning) indicates that the rendered user data exceeds the limit of
+	// the boot volume and cannot be delivered otherwise. The VM is not created until the bootstrap data fits
+	// or a bootstrap storage is configured.
+	UserDataTooLargeReason = "UserDataTooLarge"
+
+	// RedeliveringBootstrapDataReason (Severity=Warning) indicates that the bootstrap data is re-delivered
+	// by replacing the boot volume of the VM. The VM is stopped and all data on the boot volume is lost.
+	RedeliveringBootstrapDataReason = "RedeliveringBootstrapData"
+
+	// CloudResourceConfigAuto is a constant to indicate that the cloud resource should be managed by the
+	// Cluster API provider implementation.
+	CloudResourceConfigAuto = "AUTO"
+)
+
+// VolumeDiskType specifies the type of  hard disk.
+type VolumeDiskType string
+
+const (
+	// VolumeDiskTypeHDD defines the disk type HDD.
+	VolumeDiskTypeHDD VolumeDiskType = "HDD"
+	// VolumeDiskTypeSSDStandard defines the standard SSD disk type.
+	// This is the same as VolumeDiskTypeSSD.
+	VolumeDiskTypeSSDStandard VolumeDiskType = "SSD Standard"
+	// VolumeDiskTypeSSDPremium defines the premium SSD disk type.
+	VolumeDiskTypeSSDPremium VolumeDiskType = "SSD Premium"
+)
+
+// String returns the string representation of the VolumeDiskType.
+func (v VolumeDiskType) String() string {
+	return string(v)
+}
+
+// DefaultBootVolumeDiskType is the disk type of boot volumes, which don't set one. It is more expensive than HDD,
+// which is the default of additional volumes and of boot volumes in v1alpha1.
+const DefaultBootVolumeDiskType = VolumeDiskTypeSSDStandard
+
+// VolumeBus specifies the bus type, which is used to attach a volume to the VM.
+type VolumeBus string
+
+const (
+	// VolumeBusVirtIO attaches the volume using VirtIO.
+	VolumeBusVirtIO VolumeBus = "VIRTIO"
+	// VolumeBusIDE attaches the volume using IDE.
+	VolumeBusIDE VolumeBus = "IDE"
+)
+
+// String returns the string representation of the VolumeBus.
+func (v VolumeBus) String() string {
+	return string(v)
+}
+
+// ImageUpgradePolicy defines, which image is used for the boot volume of new machines.
+type ImageUpgradePolicy string
+
+const (
+	// ImageUpgradePolicyPin uses the image, which was resolved for the first machine of the template.
+	ImageUpgradePolicyPin ImageUpgradePolicy = "Pin"
+	// ImageUpgradePolicyLatestOnRecreate resolves the image again for every new machine.
+	ImageUpgradePolicyLatestOnRecreate ImageUpgradePolicy = "LatestOnRecreate"
+)
+
+// String returns the string representation of the ImageUpgradePolicy.
+func (p ImageUpgradePolicy) String() string {
+	return string(p)
+}
+
+// AvailabilityZone is the availability zone where different cloud resources are created in.
+type AvailabilityZone string
+
+const (
+	// AvailabilityZoneAuto automatically selects an availability zone.
+	AvailabilityZoneAuto AvailabilityZone = "AUTO"
+	// AvailabilityZoneOne zone 1.
+	AvailabilityZoneOne AvailabilityZone = "ZONE_1"
+	// AvailabilityZoneTwo zone 2.
+	AvailabilityZoneTwo AvailabilityZone = "ZONE_2"
+	// AvailabilityZoneThree zone 3.
+	AvailabilityZoneThree AvailabilityZone = "ZONE_3"
+)
+
+// String returns the string representation of the AvailabilityZone.
+func (a AvailabilityZone) String() string {
+	return string(a)
+}
+
+// SpreadStrategy defines how machines are distributed across availability zones.
+type SpreadStrategy string
+
+const (
+	// SpreadStrategyNone leaves the selection of the availability zone to the cloud.
+	SpreadStrategyNone SpreadStrategy = "None"
+	// SpreadStrategyZoneRoundRobin places each new machine in the availability zone with the fewest machines
+	// of the same MachineDeployment, MachinePool or control plane in the same data center.
+	SpreadStrategyZoneRoundRobin SpreadStrategy = "ZoneRoundRobin"
+)
+
+// ServerType is the type of server which is created (ENTERPRISE, VCPU or CUBE).
+type ServerType string
+
+const (
+	// ServerTypeEnterprise server of type ENTERPRISE.
+	ServerTypeEnterprise ServerType = "ENTERPRISE"
+	// ServerTypeVCPU server of type VCPU.
+	ServerTypeVCPU ServerType = "VCPU"
+	// ServerTypeCube server of type CUBE.
+	ServerTypeCube ServerType = "CUBE"
+)
+
+// String returns the string representation of the ServerType.
+func (a ServerType) String() string {
+	return string(a)
+}
+
+// PowerState is the power state of a VM.
+type PowerState string
+
+const (
+	// PowerStateRunning means that the VM is powered on.
+	PowerStateRunning PowerState = "Running"
+	// PowerStateStopped means that the VM is powered off.
+	PowerStateStopped PowerState = "Stopped"
+)
+
+// String returns the string representation of the PowerState.
+func (p PowerState) String() string {
+	return string(p)
+}
+
+// ReadinessStrategy defines when an IonosCloudMachine is reported as ready to Cluster API.
+type ReadinessStrategy string
+
+const (
+	// ReadinessStrategyServerAvailable means that the machine is ready once its VM is available and running.
+	ReadinessStrategyServerAvailable ReadinessStrategy = "ServerAvailable"
+	// ReadinessStrategyNodeRegistered means that the machine is ready once its VM is running and its Node has
+	// registered in the workload cluster, i.e. the bootstrap has been executed.
+	ReadinessStrategyNodeRegistered ReadinessStrategy = "NodeRegistered"
+)
+
+// String returns the string representation of the ReadinessStrategy.
+func (r ReadinessStrategy) String() string {
+	return string(r)
+}
+
+// UserDataCompression defines whether the user data of the boot volume is compressed.
+type UserDataCompression string
+
+const (
+	// UserDataCompressionAuto means that the user data is compressed if it exceeds the limit of the boot volume.
+	UserDataCompressionAuto UserDataCompression = "Auto"
+	// UserDataCompressionGzip means that the user data is always compressed.
+	UserDataCompressionGzip UserDataCompression = "Gzip"
+	// UserDataCompressionNone means that the user data is never compressed.
+	UserDataCompressionNone UserDataCompression = "None"
+)
+
+// String returns the string representation of the UserDataCompression.
+func (c UserDataCompression) String() string {
+	return string(c)
+}
+
+// DriftPolicy defines how differences between the VM of a provisioned IonosCloudMachine and its spec are handled.
+type DriftPolicy string
+
+const (
+	// DriftPolicyReconcile means that the VM is updated in place to match the spec.
+	DriftPolicyReconcile DriftPolicy = "Reconcile"
+	// DriftPolicyReport means that differences are only reported in the ServerInSync condition.
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// String returns the string representation of the DriftPolicy.
+func (d DriftPolicy) String() string {
+	return string(d)
+}
+
+// VolumeDeletionPolicy defines what happens to the volumes of a machine when the machine is deleted.
+type VolumeDeletionPolicy string
+
+const (
+	// VolumeDeletionPolicyDelete means that the boot and additional volumes are deleted together with the VM.
+	VolumeDeletionPolicyDelete VolumeDeletionPolicy = "Delete"
+	// VolumeDeletionPolicyRetain means that the boot and additional volumes are detached from the VM
+	// and kept after the VM has been deleted.
+	VolumeDeletionPolicyRetain VolumeDeletionPolicy = "Retain"
+)
+
+// String returns the string representation of the VolumeDeletionPolicy.
+func (p VolumeDeletionPolicy) String() string {
+	return string(p)
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(oldSelf.datacenterID) || has(self.datacenterID)",message="datacenterID cannot be removed"
+//+kubebuilder:validation:XValidation:rule="has(self.ipv4PoolRef) == has(oldSelf.ipv4PoolRef)",message="ipv4PoolRef cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.disk.image) || has(self.cdrom) || (has(self.boot) && self.boot.device == 'Network')",message="disk.image must be set unless a cdrom is attached or the VM boots from the network"
+//+kubebuilder:validation:XValidation:rule="!has(self.boot) || self.boot.device != 'CDROM' || has(self.cdrom)",message="boot.device CDROM requires a cdrom"
+//+kubebuilder:validation:XValidation:rule="!has(self.ipBlock) || !has(self.ipv4PoolRef)",message="ipBlock and ipv4PoolRef are mutually exclusive"
+//+kubebuilder:validation:XValidation:rule="has(self.ipBlock) == has(oldSelf.ipBlock)",message="ipBlock cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="!has(self.privateIP) || (!has(self.ipBlock) && !has(self.ipv4PoolRef))",message="privateIP is mutually exclusive with ipBlock and ipv4PoolRef"
+//+kubebuilder:validation:XValidation:rule="has(self.privateIP) == has(oldSelf.privateIP)",message="privateIP cannot be added or removed"
+//+kubebuilder:validation:XValidation:rule="has(self.importExisting) == has(oldSelf.importExisting)",message="importExisting cannot be added or removed"
+
+// IonosCloudMachineSpec defines the desired state of IonosCloudMachine.
+type IonosCloudMachineSpec struct {
+	// ProviderID is the IONOS Cloud provider ID
+	// will be in the format ionos://ee090ff2-1eef-48ec-a246-a51a33aa4f3a
+	//+optional
+	ProviderID *string `json:"providerID,omitempty"`
+
+	// ImportExisting is the ID of an existing server in the data center of the machine, which is adopted
+	// instead of creating a new server, e.g. to bring a cluster, which was built by hand, under the management
+	// of Cluster API. The server must match the type, cores and memory of the machine, be connected to the LAN
+	// of the cluster and must not belong to another machine. Its bootstrap data is not delivered again.
+	// The existing labels of the server and its volumes are kept, so labels, which are removed from the machine,
+	// are not removed from the server. It cannot be set in an IonosCloudMachineTemplate or IonosCloudMachinePool.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="importExisting is immutable"
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	ImportExisting string `json:"importExisting,omitempty"`
+
+	// DatacenterID is the ID of the data center where the VM should be created in.
+	// It can be omitted, if the machine is placed in a failure domain, which defines the data center.
+	// In this case, the data center ID is set by the controller.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenterID is immutable"
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	DatacenterID string `json:"datacenterID,omitempty"`
+
+	// NumCores defines the number of cores for the VM.
+	// Changing the number of cores of an existing VM updates it in place.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	//+optional
+	NumCores int32 `json:"numCores,omitempty"`
+
+	// AvailabilityZone is the availability zone in which the VM should be provisioned.
+	//+kubebuilder:validation:Enum=AUTO;ZONE_1;ZONE_2
+	//+kubebuilder:default=AUTO
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// SpreadStrategy defines how machines without an explicit availability zone are distributed
+	// across availability zones. If not specified, the spread strategy of the IonosCloudCluster is used.
+	//+kubebuilder:validation:Enum=None;ZoneRoundRobin
+	//+optional
+	SpreadStrategy SpreadStrategy `json:"spreadStrategy,omitempty"`
+
+	// MemoryMB is the memory size for the VM in MB.
+	// Size must be specified in multiples of 256 MB with a minimum of 1024 MB
+	// which is required as we are using hot-pluggable RAM by default.
+	// Changing the memory size of an existing VM updates it in place.
+	//+kubebuilder:validation:MultipleOf=1024
+	//+kubebuilder:validation:Minimum=2048
+	//+kubebuilder:default=3072
+	//+optional
+	MemoryMB int32 `json:"memoryMB,omitempty"`
+
+	// CPUFamily defines the CPU architecture, which will be used for this VM.
+	// Not all CPU architectures are available in all data centers.
+	// If the CPU family is not offered in the location of the data center, the VM is created
+	// with a compatible one of the same vendor, or any available one, which is reported by
+	// the CPUFamilyAvailable condition.
+	//
+	// If not specified, the cloud will select a suitable CPU family
+	// based on the availability in the data center.
+	//+kubebuilder:example=AMD_OPTERON
+	//+optional
+	CPUFamily *string `json:"cpuFamily,omitempty"`
+
+	// Disk defines the boot volume of the VM.
+	Disk *Volume `json:"disk"`
+
+	// CDROM attaches an ISO image to the VM, from which the VM boots, e.g. the installer of Talos Linux
+	// or of another operating system, which is installed onto the boot volume.
+	// If a CD-ROM is attached, the image of the boot volume can be omitted to create an empty boot volume.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="cdrom is immutable"
+	//+optional
+	CDROM *CDROMSpec `json:"cdrom,omitempty"`
+
+	// Boot configures the device, from which the VM boots, and the boot order of its volumes.
+	// If not set, a VM with a CD-ROM boots from the CD-ROM and any other VM from its boot volume.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="boot is immutable"
+	//+optional
+	Boot *BootConfig `json:"boot,omitempty"`
+
+	// AdditionalVolumes defines data volumes, which will be created and attached to the VM
+	// in addition to the boot volume.
+	//
+	// These volumes belong to the machine and are removed together with the VM, unless they are retained.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	AdditionalVolumes []VolumeSpec `json:"additionalVolumes,omitempty"`
+
+	// VolumeDeletionPolicy defines whether the boot and additional volumes are deleted together with the VM,
+	// or retained for later inspection, e.g. of the disks of nodes, which were removed during a scale-down.
+	// Retained volumes are detached from the VM and labeled with orphaned=true. They are not removed by the
+	// garbage collector and need to be deleted manually, unless the data center is deleted with the cluster.
+	// The policy is evaluated when the machine is deleted, so it can be changed at any time before.
+	//+kubebuilder:validation:Enum=Delete;Retain
+	//+kubebuilder:default=Delete
+	//+optional
+	VolumeDeletionPolicy VolumeDeletionPolicy `json:"volumeDeletionPolicy,omitempty"`
+
+	// AdditionalNetworks defines the additional network configurations for the VM.
+	// For each network, a secondary NIC is attached to the given LAN. The NICs are removed together with the VM.
+	// LANs referenced by their ID are not managed by the provider, while LANs referenced by name belong to
+	// a network of the IonosCloudCluster.
+	// Changing the networks of an existing VM is not supported.
+	// NOTE(lubedacht): We currently only support networks with DHCP enabled, unless DHCP is disabled for
+	// a network of the cluster. Static IP addresses can be assigned via IPAM pools, which are handed out
+	// by the DHCP server of IONOS Cloud.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="additionalNetworks is immutable"
+	//+optional
+	AdditionalNetworks Networks `json:"additionalNetworks,omitempty"`
+
+	// IPAMConfig configures the IPAM pool, from which the IPv4 address of the primary NIC is claimed.
+	IPAMConfig `json:",inline"`
+
+	// IPv6 configures IPv6 on the primary NIC of the VM, which is attached to the cluster LAN.
+	// If set, IPv6 will be enabled on the cluster LAN, if it is not enabled already.
+	// This is needed for dual-stack clusters.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipv6 is immutable"
+	//+optional
+	IPv6 *IPv6Config `json:"ipv6,omitempty"`
+
+	// FirewallRules defines the firewall rules of the primary NIC of the VM.
+	// If rules are set, the firewall of the NIC is activated and only traffic, which matches one of the rules,
+	// is allowed in the directions the rules are defined for. The rules are reconciled by the controller,
+	// which means that changes made outside of the controller are reverted.
+	// Removing all rules deactivates the firewall of the NIC.
+	//+listType=map
+	//+listMapKey=name
+	//+optional
+	FirewallRules []FirewallRule `json:"firewallRules,omitempty"`
+
+	// FailoverIP can be set to enable failover for VMs in the same MachineDeployment.
+	// It can be either set to an already reserved IPv4 address, or it can be set to "AUTO"
+	// which will automatically reserve an IPv4 address for the Failover Group.
+	//
+	// If the machine is a control plane machine, this field will not be taken into account.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="failoverIP is immutable"
+	//+kubebuilder:validation:XValidation:rule=`self == "AUTO" || self.matches("((25[0-5]|(2[0-4]|1\\d|[1-9]|)\\d)\\.?\\b){4}$")`,message="failoverIP must be either 'AUTO' or a valid IPv4 address"
+	//+optional
+	FailoverIP *string `json:"failoverIP,omitempty"`
+
+	// FailoverGroups defines IP failover groups in the LANs of additional networks of control plane machines.
+	// The IP of each group is added to the NIC of every control plane machine in the LAN, and one of these NICs
+	// is registered in the IP failover group of the LAN. This allows a virtual IP, which is managed by a VRRP
+	// implementation like keepalived or kube-vip, to float between the control plane machines.
+	// Each group must reference the LAN of one of the additional networks.
+	//
+	// If the machine is not a control plane machine, this field will not be taken into account.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="failoverGroups is immutable"
+	//+kubebuilder:validation:MaxItems=8
+	//+optional
+	FailoverGroups []FailoverGroup `json:"failoverGroups,omitempty"`
+
+	// IPBlock is the name of an IP block of the IonosCloudCluster, from which an IP is assigned to the
+	// primary NIC of the VM. The IP is assigned by the cluster and kept for the lifetime of the machine,
+	// which allows stable public IPs for inbound and outbound traffic. The primary NIC must be attached
+	// to a public LAN in the location of the IP block.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipBlock is immutable"
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	IPBlock string `json:"ipBlock,omitempty"`
+
+	// PrivateIP configures a stable private IPv4 address of the primary NIC of the VM, which must be attached
+	// to a private LAN. The address can either be pinned, or the address, which was assigned to the first VM
+	// of the machine, can be retained for a machine with the same name, which is created later on.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="privateIP is immutable"
+	//+optional
+	PrivateIP *PrivateIPConfig `json:"privateIP,omitempty"`
+
+	// Type is the server type of the VM. Can be either ENTERPRISE, VCPU or CUBE.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	//+kubebuilder:validation:Enum=ENTERPRISE;VCPU;CUBE
+	//+kubebuilder:default=ENTERPRISE
+	//+optional
+	Type ServerType `json:"type,omitempty"`
+
+	// Template is the template of a CUBE server, which defines the number of cores, the memory size
+	// and the size of the boot volume. It is required for and only allowed with the server type CUBE.
+	// For CUBE servers, numCores, memoryMB as well as the size and the type of the boot volume are ignored.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="template is immutable"
+	//+optional
+	Template *ServerTemplate `json:"template,omitempty"`
+
+	// ShutdownTimeout is the time to wait for the VM to shut down gracefully, before it is deleted
+	// during machine deletion. After the timeout, the VM is deleted regardless of its state.
+	// A timeout of 0 deletes the VM without shutting it down first.
+	//+kubebuilder:default="2m"
+	//+optional
+	ShutdownTimeout *metav1.Duration `json:"shutdownTimeout,omitempty"`
+
+	// DesiredPowerState is the power state, which the VM should be in.
+	// If set to Stopped, the VM is shut down, but its volumes and NICs are kept, which allows
+	// saving costs for machines that are not needed temporarily. Setting it back to Running
+	// starts the VM again.
+	//+kubebuilder:validation:Enum=Running;Stopped
+	//+kubebuilder:default=Running
+	//+optional
+	DesiredPowerState PowerState `json:"desiredPowerState,omitempty"`
+
+	// ReadinessStrategy defines when the machine is reported as ready to Cluster API. With ServerAvailable,
+	// the machine is ready once its VM is available and running. With NodeRegistered, the machine is only ready
+	// once the bootstrap has been executed and the Node of the machine has registered in the workload cluster.
+	// During a rolling update, Cluster API only deletes old machines once new ones are ready, so NodeRegistered
+	// keeps the old machines until their replacements have actually joined the cluster.
+	// NodeRegistered requires the controller manager to access the workload cluster, which is enabled by
+	// the --enable-node-provider-id flag. If it is not set, ServerAvailable is used.
+	//+kubebuilder:validation:Enum=ServerAvailable;NodeRegistered
+	//+optional
+	ReadinessStrategy ReadinessStrategy `json:"readinessStrategy,omitempty"`
+
+	// DriftPolicy defines how differences between the VM and the spec are handled, once the machine has been
+	// provisioned, e.g. after the VM was edited manually. The number of cores, the memory size, the labels and
+	// the firewall rules are compared. With Reconcile, the VM is updated in place to match the spec. With Report,
+	// the differences are only reported in the ServerInSync condition, which also applies to changes of the spec.
+	// If not set, Reconcile is used.
+	//+kubebuilder:validation:Enum=Reconcile;Report
+	//+optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// AdditionalUserData is a list of additional cloud-init user data parts, e.g. for configuring
+	// registry mirrors or proxy settings. The parts are combined with the bootstrap data into a
+	// multipart MIME document in the given order.
+	// Additional user data is only supported with cloud-config bootstrap data.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="additionalUserData is immutable"
+	//+kubebuilder:validation:MaxItems=10
+	//+listType=atomic
+	//+optional
+	AdditionalUserData []UserDataPart `json:"additionalUserData,omitempty"`
+
+	// UserDataCompression defines whether cloud-config user data is compressed with gzip, which cloud-init
+	// detects and decompresses, before it is passed to the boot volume. With Auto, the user data is only compressed
+	// if it exceeds the limit of the Cloud API, which saves delivering it as a CD-ROM image. Compression doesn't
+	// apply to Ignition and Talos, or if a bootstrap storage is configured. It only affects new VMs.
+	// If not set, Auto is used.
+	//+kubebuilder:validation:Enum=Auto;Gzip;None
+	//+optional
+	UserDataCompression UserDataCompression `json:"userDataCompression,omitempty"`
+
+	// SSHKeys are public SSH keys in the authorized_keys format, which IONOS Cloud injects into the boot volume,
+	// if it is created from a public image. This allows logging into machines, whose bootstrap provider doesn't
+	// manage SSH access. If not set, the SSH keys of the machine defaults of the cluster are used.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="sshKeys is immutable"
+	//+kubebuilder:validation:MaxItems=16
+	//+kubebuilder:validation:items:MinLength=1
+	//+listType=atomic
+	//+optional
+	SSHKeys []string `json:"sshKeys,omitempty"`
+
+	// Labels are added as IONOS Cloud labels to the server and to the boot and additional volumes of the machine.
+	// They take precedence over the labels of the cluster. The labels cluster-name, cluster-namespace and
+	// machine-name are added automatically and must not be set.
+	//+kubebuilder:validation:XValidation:rule="!('cluster-name' in self) && !('cluster-namespace' in self) && !('machine-name' in self)",message="cluster-name, cluster-namespace and machine-name are reserved labels"
+	//+optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// HostnameFormat is a Go template, from which the hostname of the VM is rendered. The hostname is used
+	// as name of the server in IONOS Cloud and is passed to the operating system, so it usually becomes the
+	// name of the Kubernetes node. The template can use the fields .ClusterName, .MachineName, .Namespace and
+	// .Random, which consists of 5 lowercase alphanumeric characters derived from the UID of the IonosCloudMachine,
+	// e.g. "{{ .ClusterName }}-{{ .Random }}".
+	// The rendered hostname must be a valid DNS label. It is rendered once before the server is created and
+	// reported in the status.
+	// If not set, the name of the IonosCloudMachine is used.
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="hostnameFormat is immutable"
+	//+kubebuilder:validation:MaxLength=253
+	//+optional
+	HostnameFormat string `json:"hostnameFormat,omitempty"`
+}
+
+// UserDataPart is a cloud-init user data part, whose content is stored in a secret.
+type UserDataPart struct {
+	// SecretRef references a key of a secret in the namespace of the machine, which contains
+	// the content of the part.
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+
+	// ContentType is the MIME type of the part.
+	// cloud-config parts are merged with the bootstrap data. Lists are appended, while keys
+	// of the bootstrap data are not overridden.
+	//+kubebuilder:validation:Enum=text/cloud-config;text/x-shellscript;text/cloud-boothook
+	//+kubebuilder:default=text/cloud-config
+	//+optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.id) != has(self.name)",message="exactly one of id or name must be set"
+
+// ServerTemplate references a template of a CUBE server either by its ID or by its name.
+type ServerTemplate struct {
+	// ID is the UUID of the template.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the template, e.g. "Basic Cube XS".
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+}
+
+//+listType=atomic
+//+kubebuilder:validation:MaxItems=16
+//+kubebuilder:validation:XValidation:rule="self.all(a, self.exists_one(b, has(a.name) ? has(b.name) && b.name == a.name : has(b.networkID) && b.networkID == a.networkID))",message="networks must be unique"
+
+// Networks contains a list of additional LANs
+// that should be attached to the VM.
+type Networks []Network
+
+//+kubebuilder:validation:XValidation:rule="has(self.networkID) != has(self.name)",message="either networkID or name must be set"
+
+// Network contains the config for additional LANs.
+type Network struct {
+	// NetworkID represents an ID an existing LAN in the data center.
+	// This LAN will be excluded from the deletion process.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	NetworkID int32 `json:"networkID,omitempty"`
+
+	// Name references a network of the IonosCloudCluster by its name.
+	// The LAN of the network is created and deleted by the cluster.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// IPAMConfig configures the IPAM pool, from which the IPv4 address of the NIC is claimed.
+	IPAMConfig `json:",inline"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.networkID) != has(self.network)",message="either networkID or network must be set"
+
+// FailoverGroup defines an IP failover group in the LAN of an additional network.
+type FailoverGroup struct {
+	// NetworkID is the ID of an existing LAN, which is referenced by the networkID of an additional network.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	NetworkID int32 `json:"networkID,omitempty"`
+
+	// Network references a network of the IonosCloudCluster by its name, which is referenced by the name
+	// of an additional network.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Network string `json:"network,omitempty"`
+
+	// IP is the IPv4 address, which floats between the NICs of the group.
+	//+kubebuilder:validation:XValidation:rule=`self.matches("^((25[0-5]|(2[0-4]|1\\d|[1-9]|)\\d)\\.?\\b){4}$")`,message="ip must be a valid IPv4 address"
+	IP string `json:"ip"`
+}
+
+// IPAMConfig configures the IP address management of a NIC.
+type IPAMConfig struct {
+	// IPv4PoolRef references an IPAM pool of a Cluster API IPAM provider, e.g. an InClusterIPPool.
+	// If set, an IPAddressClaim is created for the NIC and the claimed address is assigned to it.
+	// The address is handed out to the VM by the DHCP server of IONOS Cloud.
+	//+kubebuilder:validation:XValidation:rule="has(self.apiGroup) && self.apiGroup != ''",message="ipv4PoolRef.apiGroup must be set"
+	//+kubebuilder:validation:XValidation:rule="self.kind != ''",message="ipv4PoolRef.kind must be set"
+	//+kubebuilder:validation:XValidation:rule="self.name != ''",message="ipv4PoolRef.name must be set"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipv4PoolRef is immutable"
+	//+optional
+	IPv4PoolRef *corev1.TypedLocalObjectReference `json:"ipv4PoolRef,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.address) || (has(self.retain) && self.retain)",message="either address or retain must be set"
+
+// PrivateIPConfig configures the private IPv4 address of the primary NIC.
+type PrivateIPConfig struct {
+	// Address is the private IPv4 address, which is assigned to the primary NIC. It must be a free address
+	// in the subnet of the LAN. If it is not set, the address is assigned by the DHCP server of IONOS Cloud.
+	//+kubebuilder:validation:XValidation:rule=`self.matches("^((25[0-5]|(2[0-4]|1\\d|[1-9]|)\\d)\\.?\\b){4}$")`,message="address must be a valid IPv4 address"
+	//+optional
+	Address string `json:"address,omitempty"`
+
+	// Retain reserves the address of the primary NIC for the name of the machine in the IonosCloudCluster,
+	// once the VM has been created. A machine with the same name, e.g. a machine which was deleted and
+	// created again, gets the same address. The reservation is kept until the cluster is deleted.
+	//+optional
+	Retain bool `json:"retain,omitempty"`
+}
+
+// IPv6Config contains the IPv6 configuration of a NIC.
+type IPv6Config struct {
+	// DHCP indicates whether the NIC will receive its IPv6 address via DHCPv6.
+	//+kubebuilder:default=true
+	//+optional
+	DHCP *bool `json:"dhcp,omitempty"`
+}
+
+// FirewallRuleProtocol is the protocol of a firewall rule.
+type FirewallRuleProtocol string
+
+const (
+	// FirewallRuleProtocolTCP matches TCP traffic.
+	FirewallRuleProtocolTCP FirewallRuleProtocol = "TCP"
+	// FirewallRuleProtocolUDP matches UDP traffic.
+	FirewallRuleProtocolUDP FirewallRuleProtocol = "UDP"
+	// FirewallRuleProtocolICMP matches ICMP traffic.
+	FirewallRuleProtocolICMP FirewallRuleProtocol = "ICMP"
+	// FirewallRuleProtocolAny matches traffic of any protocol.
+	FirewallRuleProtocolAny FirewallRuleProtocol = "ANY"
+)
+
+// String returns the string representation of the FirewallRuleProtocol.
+func (p FirewallRuleProtocol) String() string {
+	return string(p)
+}
+
+// FirewallRuleDirection is the direction of the traffic, which a firewall rule applies to.
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIngress applies the rule to incoming traffic.
+	FirewallRuleDirectionIngress FirewallRuleDirection = "INGRESS"
+	// FirewallRuleDirectionEgress applies the rule to outgoing traffic.
+	FirewallRuleDirectionEgress FirewallRuleDirection = "EGRESS"
+)
+
+// String returns the string representation of the FirewallRuleDirection.
+func (d FirewallRuleDirection) String() string {
+	return string(d)
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.portRangeStart) == has(self.portRangeEnd)",message="portRangeStart and portRangeEnd must be set together"
+//+kubebuilder:validation:XValidation:rule="!has(self.portRangeStart) || self.portRangeStart <= self.portRangeEnd",message="portRangeStart must not be greater than portRangeEnd"
+//+kubebuilder:validation:XValidation:rule="!has(self.portRangeStart) || self.protocol in ['TCP', 'UDP']",message="port ranges are only supported for TCP and UDP"
+
+// FirewallRule defines a firewall rule, which allows traffic on the primary NIC of the VM.
+type FirewallRule struct {
+	// Name is the name of the firewall rule. It must be unique within the firewall rules of a machine.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Protocol is the protocol of the traffic, which is allowed by this rule.
+	//+kubebuilder:validation:Enum=TCP;UDP;ICMP;ANY
+	Protocol FirewallRuleProtocol `json:"protocol"`
+
+	// Direction is the direction of the traffic, which is allowed by this rule.
+	//+kubebuilder:validation:Enum=INGRESS;EGRESS
+	//+kubebuilder:default=INGRESS
+	//+optional
+	Direction FirewallRuleDirection `json:"direction,omitempty"`
+
+	// SourceCIDR restricts the rule to traffic originating from the given IP address or CIDR block.
+	// If not set, traffic from any source is allowed.
+	//+kubebuilder:example="198.51.100.0/24"
+	//+optional
+	SourceCIDR *string `json:"sourceCIDR,omitempty"`
+
+	// PortRangeStart is the first port of the port range, which is allowed by this rule.
+	// If no port range is set, all ports are allowed.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65534
+	//+optional
+	PortRangeStart *int32 `json:"portRangeStart,omitempty"`
+
+	// PortRangeEnd is the last port of the port range, which is allowed by this rule.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=65534
+	//+optional
+	PortRangeEnd *int32 `json:"portRangeEnd,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(self.imageUpgradePolicy) || self.imageUpgradePolicy != 'Pin' || has(self.image) && (has(self.image.snapshot) || has(self.image.private))",message="imageUpgradePolicy Pin requires a snapshot or a private image"
+
+// Volume is the physical storage on the VM.
+type Volume struct {
+	// Name is the name of the volume
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// DiskType defines the type of the hard drive. If not specified, the disk type of the machine defaults
+	// of the cluster is used, or the boot volume is created as SSD Standard otherwise.
+	// Note that SSD Standard is billed at a higher rate than HDD, which is the default of additional volumes
+	// and of boot volumes in v1alpha1.
+	//+kubebuilder:validation:Enum=HDD;SSD Standard;SSD Premium
+	//+optional
+	DiskType VolumeDiskType `json:"diskType,omitempty"`
+
+	// SizeGB defines the size of the volume in GB
+	//+kubebuilder:validation:Minimum=10
+	//+kubebuilder:default=20
+	//+optional
+	SizeGB int `json:"sizeGB,omitempty"`
+
+	// AvailabilityZone is the availability zone where the volume will be created.
+	//+kubebuilder:validation:Enum=AUTO;ZONE_1;ZONE_2;ZONE_3
+	//+kubebuilder:default=AUTO
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// Bus is the bus type, which is used to attach the volume to the VM.
+	// If not specified, the cloud attaches the volume using VirtIO.
+	//+kubebuilder:validation:Enum=VIRTIO;IDE
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="bus is immutable"
+	//+optional
+	Bus VolumeBus `json:"bus,omitempty"`
+
+	// BackupUnitID is the ID of the backup unit, which is used to back up the volume.
+	// The backup unit must be accessible with the credentials of the cluster.
+	//+kubebuilder:validation:Format=uuid
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="backupUnitID is immutable"
+	//+optional
+	BackupUnitID string `json:"backupUnitID,omitempty"`
+
+	// Image is the image to use for the VM.
+	// It can be omitted if a CD-ROM is attached to the VM, which installs the operating system
+	// onto the empty boot volume.
+	//+optional
+	Image *ImageSpec `json:"image,omitempty"`
+
+	// ImageUpgradePolicy defines, which image is used for the boot volume, if the image is referenced
+	// by a snapshot or a private image. With LatestOnRecreate, the reference is resolved for every new machine,
+	// so that recreated machines boot from the latest matching image. With Pin, the image, which was resolved
+	// for the first machine of an IonosCloudMachineTemplate in a data center, is used for all other machines
+	// of the template in that data center, so that rollouts are deterministic.
+	// If not specified, LatestOnRecreate is used.
+	//+kubebuilder:validation:Enum=Pin;LatestOnRecreate
+	//+optional
+	ImageUpgradePolicy ImageUpgradePolicy `json:"imageUpgradePolicy,omitempty"`
+}
+
+// EffectiveDiskType returns the disk type, with which the volume is created.
+// It defaults to DefaultBootVolumeDiskType.
+func (v *Volume) EffectiveDiskType() VolumeDiskType {
+	if v.DiskType == "" {
+		return DefaultBootVolumeDiskType
+	}
+	return v.DiskType
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.id) != has(self.private)",message="exactly one of id or private must be set"
+
+// CDROMSpec defines an ISO image, which is attached to the VM as CD-ROM.
+type CDROMSpec struct {
+	// ID is the ID of the ISO image.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Private references a private ISO image of the contract, e.g. an image, which was uploaded via FTP.
+	// The image must be located in the same location as the data center of the VM.
+	//+optional
+	Private *PrivateImageReference `json:"private,omitempty"`
+
+	// EjectAfterFirstBoot ejects the CD-ROM once the node of the machine has joined the cluster,
+	// i.e. once the operating system has been installed onto the boot volume.
+	// Afterwards, the VM boots from the boot volume.
+	//+optional
+	EjectAfterFirstBoot bool `json:"ejectAfterFirstBoot,omitempty"`
+}
+
+// BootDevice is the device, from which a VM boots.
+type BootDevice string
+
+const (
+	// BootDeviceVolume boots the VM from its boot volume.
+	BootDeviceVolume BootDevice = "Volume"
+	// BootDeviceCDROM boots the VM from the attached CD-ROM.
+	BootDeviceCDROM BootDevice = "CDROM"
+	// BootDeviceNetwork excludes all volumes and CD-ROMs from booting, so that the VM boots via PXE
+	// from the network, e.g. from a provisioning server in one of its LANs.
+	BootDeviceNetwork BootDevice = "Network"
+)
+
+// String returns the string representation of the BootDevice.
+func (d BootDevice) String() string {
+	return string(d)
+}
+
+// VolumeBootOrder defines whether a volume is used for booting the VM.
+type VolumeBootOrder string
+
+const (
+	// VolumeBootOrderAuto leaves the choice to the Cloud API, which only boots from the volume
+	// if no other volumes or CD-ROMs are attached.
+	VolumeBootOrderAuto VolumeBootOrder = "AUTO"
+	// VolumeBootOrderPrimary makes the volume the boot device. All other volumes are excluded from booting.
+	VolumeBootOrderPrimary VolumeBootOrder = "PRIMARY"
+	// VolumeBootOrderNone excludes the volume from booting.
+	VolumeBootOrderNone VolumeBootOrder = "NONE"
+)
+
+// String returns the string representation of the VolumeBootOrder.
+func (o VolumeBootOrder) String() string {
+	return string(o)
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(self.volumeBootOrder) || (self.device == 'Volume' && self.volumeBootOrder != 'NONE') || (self.device == 'CDROM' && self.volumeBootOrder == 'AUTO') || (self.device == 'Network' && self.volumeBootOrder == 'NONE')",message="volumeBootOrder is not supported with the boot device"
+
+// BootConfig configures how a VM boots.
+type BootConfig struct {
+	// Device is the device, from which the VM boots. Booting from the CD-ROM requires a CD-ROM to be attached.
+	// With the Network device, the boot volume can be created without an image, e.g. to install
+	// the operating system via PXE.
+	//+kubebuilder:validation:Enum=Volume;CDROM;Network
+	Device BootDevice `json:"device"`
+
+	// VolumeBootOrder sets the boot order of the boot volume in the Cloud API. With PRIMARY, the VM boots
+	// from the boot volume and the additional volumes are excluded from booting. With AUTO, the Cloud API
+	// decides, which is needed to boot from a CD-ROM. NONE excludes all volumes from booting.
+	//
+	// If not set, PRIMARY is used for the Volume device, AUTO for the CDROM device and NONE for
+	// the Network device.
+	//+kubebuilder:validation:Enum=AUTO;PRIMARY;NONE
+	//+optional
+	VolumeBootOrder VolumeBootOrder `json:"volumeBootOrder,omitempty"`
+}
+
+// VolumeSpec defines a data volume, which is attached to the VM.
+type VolumeSpec struct {
+	// Name is the name of the volume. It must be unique within the additional volumes
+	// of a machine and is used to derive the name of the volume in the cloud.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DiskType defines the type of the hard drive.
+	//+kubebuilder:validation:Enum=HDD;SSD Standard;SSD Premium
+	//+kubebuilder:default=HDD
+	//+optional
+	DiskType VolumeDiskType `json:"diskType,omitempty"`
+
+	// SizeGB defines the size of the volume in GB
+	//+kubebuilder:validation:Minimum=10
+	//+kubebuilder:default=20
+	//+optional
+	SizeGB int `json:"sizeGB,omitempty"`
+
+	// AvailabilityZone is the availability zone where the volume will be created.
+	//+kubebuilder:validation:Enum=AUTO;ZONE_1;ZONE_2;ZONE_3
+	//+kubebuilder:default=AUTO
+	//+optional
+	AvailabilityZone AvailabilityZone `json:"availabilityZone,omitempty"`
+
+	// Bus is the bus type, which is used to attach the volume to the VM.
+	// If not specified, the cloud attaches the volume using VirtIO.
+	//+kubebuilder:validation:Enum=VIRTIO;IDE
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="bus is immutable"
+	//+optional
+	Bus VolumeBus `json:"bus,omitempty"`
+
+	// Retain keeps the volume, when the machine is deleted, regardless of the VolumeDeletionPolicy of the machine,
+	// e.g. for volumes with persistent data. The volume is detached from the VM before the VM is deleted
+	// and labeled with the cluster and the machine, to which it belonged, and with orphaned=true.
+	// It is evaluated when the machine is deleted, so it can be changed at any time before.
+	//+optional
+	Retain bool `json:"retain,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="[has(self.id), has(self.snapshot), has(self.private)].filter(x, x).size() == 1",message="exactly one of id, snapshot or private must be set"
+
+// ImageSpec defines the image to use for the VM.
+type ImageSpec struct {
+	// ID is the ID of the image to use for the VM.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Snapshot references a snapshot, which is used instead of a public image.
+	// The snapshot must be located in the same location as the data center of the VM.
+	// If the snapshot is larger than the configured size of the boot volume,
+	// the size of the snapshot is used instead.
+	//+optional
+	Snapshot *SnapshotReference `json:"snapshot,omitempty"`
+
+	// Private references a private image of the contract, e.g. an image, which was built with image-builder
+	// and uploaded via FTP. The image must be located in the same location as the data center of the VM.
+	//+optional
+	Private *PrivateImageReference `json:"private,omitempty"`
+}
+
+// ImageLicenceType is the licence type of an image.
+type ImageLicenceType string
+
+const (
+	// ImageLicenceTypeLinux is the licence type of Linux images.
+	ImageLicenceTypeLinux ImageLicenceType = "LINUX"
+	// ImageLicenceTypeRHEL is the licence type of Red Hat Enterprise Linux images.
+	ImageLicenceTypeRHEL ImageLicenceType = "RHEL"
+	// ImageLicenceTypeOther is the licence type of images with other operating systems.
+	ImageLicenceTypeOther ImageLicenceType = "OTHER"
+)
+
+// String returns the string representation of the ImageLicenceType.
+func (t ImageLicenceType) String() string {
+	return string(t)
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.name) != has(self.alias)",message="exactly one of name or alias must be set"
+
+// PrivateImageReference references a private image either by its name or by one of its aliases.
+type PrivateImageReference struct {
+	// Name is the name of the image. Images, which were uploaded via FTP, are named after the uploaded file.
+	// Images with the same name can exist in multiple locations, the one in the location of the data center
+	// of the VM is used. The name must be unique within a location.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// Alias is an alias of the image. The alias must be unique within a location.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Alias string `json:"alias,omitempty"`
+
+	// LicenceType is set as the licence type of the image, if its licence type is still UNKNOWN.
+	// This is the case for images, which were uploaded via FTP, and which can't be used before their
+	// licence type is set.
+	//+kubebuilder:validation:Enum=LINUX;RHEL;OTHER
+	//+kubebuilder:default=LINUX
+	//+optional
+	LicenceType ImageLicenceType `json:"licenceType,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="has(self.id) != has(self.name)",message="exactly one of id or name must be set"
+
+// SnapshotReference references a snapshot either by its ID or by its name.
+type SnapshotReference struct {
+	// ID is the UUID of the snapshot.
+	//+kubebuilder:validation:Format=uuid
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the snapshot. Snapshots with the same name can exist in multiple locations,
+	// the one in the location of the data center of the VM is used. The name must be unique within a location.
+	//+kubebuilder:validation:MinLength=1
+	//+optional
+	Name string `json:"name,omitempty"`
+}
+
+// IonosCloudMachineStatus defines the observed state of IonosCloudMachine.
+type IonosCloudMachineStatus struct {
+	// Ready indicates the VM has been provisioned and is ready.
+	//+optional
+	Ready bool `json:"ready"`
+
+	// MachineNetworkInfo contains information about the network configuration of the VM.
+	// This information is only available after the VM has been provisioned.
+	MachineNetworkInfo *MachineNetworkInfo `json:"machineNetworkInfo,omitempty"`
+
+	// Addresses contains the IP addresses of all NICs of the VM. Cluster API copies them to the Machine.
+	// Private addresses are reported as InternalIP, all other addresses as ExternalIP.
+	//+optional
+	Addresses clusterv1.MachineAddresses `json:"addresses,omitempty"`
+
+	// Volumes contains information about the volumes, which are attached to the VM.
+	// This information is only available after the VM has been provisioned.
+	//+optional
+	Volumes []VolumeInfo `json:"volumes,omitempty"`
+
+	// InstanceState is the state of the VM as reported by IONOS Cloud, e.g. RUNNING or SHUTOFF.
+	//+optional
+	InstanceState string `json:"instanceState,omitempty"`
+
+	// Hostname is the hostname of the VM, which was rendered from the hostname format.
+	// It is empty, if the name of the IonosCloudMachine is used as hostname.
+	//+optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// RemoteConsole references the URL of the remote console of the VM, which was requested with the
+	// remote console annotation.
+	//+optional
+	RemoteConsole *RemoteConsoleStatus `json:"remoteConsole,omitempty"`
+
+	// FailureReason will be set in the event that there is a terminal problem
+	// reconciling the Machine and will contain a succinct value suitable
+	// for machine interpretation.
+	//
+	// This field should not be set for transitive errors that a controller
+	// faces that are expected to be fixed automatically over
+	// time (like service outages), but instead indicate that something is
+	// fundamentally wrong with the Machine's spec or the configuration of
+	// the controller, and that manual intervention is required. Examples
+	// of terminal errors would be invalid combinations of settings in the
+	// spec, values that are unsupported by the controller, or the
+	// responsible controller itself being critically misconfigured.
+	//
+	// Any transient errors that occur during the reconciliation of IonosCloudMachines
+	// can be added as events to the IonosCloudMachine object and/or logged in the
+	// controller's output.
+	//+optional
+	FailureReason *errors.MachineStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set in the event that there is a terminal problem
+	// reconciling the Machine and will contain a more verbose string suitable
+	// for logging and human consumption.
+	//
+	// This field should not be set for transitive errors that a controller
+	// faces that are expected to be fixed automatically over
+	// time (like service outages), but instead indicate that something is
+	// fundamentally wrong with the Machine's spec or the configuration of
+	// the controller, and that manual intervention is required. Examples
+	// of terminal errors would be invalid combinations of settings in the
+	// spec, values that are unsupported by the controller, or the
+	// responsible controller itself being critically misconfigured.
+	//
+	// Any transient errors that occur during the reconciliation of IonosCloudMachines
+	// can be added as events to the IonosCloudMachine object and/or logged in the
+	// controller's output.
+	//+optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the IonosCloudMachine.
+	//+optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// CurrentRequest shows the current provisioning request for any
+	// cloud resource that is being provisioned.
+	//+optional
+	CurrentRequest *ProvisioningRequest `json:"currentRequest,omitempty"`
+
+	// RecentRequests references the last provisioning requests, which were made for the machine,
+	// with the most recent request last.
+	//+kubebuilder:validation:MaxItems=5
+	//+optional
+	RecentRequests []ProvisioningRequestReference `json:"recentRequests,omitempty"`
+
+	// ProvisioningFailures is the number of consecutive failed attempts to provision the machine.
+	// The attempts are retried with an exponential backoff, which is reset once the machine has been provisioned.
+	//+optional
+	ProvisioningFailures int32 `json:"provisioningFailures,omitempty"`
+
+	// ProvisioningRetryTime is the time, after which the provisioning of the machine is attempted again.
+	//+optional
+	ProvisioningRetryTime *metav1.Time `json:"provisioningRetryTime,omitempty"`
+
+	// ResolvedImageID is the ID of the image or snapshot, from which the boot volume of the server was created.
+	// If the image is referenced by a snapshot or a private image, it contains the ID, to which the reference
+	// was resolved, so that it can be audited, which image every node booted from.
+	//+optional
+	ResolvedImageID string `json:"resolvedImageID,omitempty"`
+
+	// ProvisioningTimestamps contains the times, at which the machine reached the phases of its provisioning.
+	//+optional
+	ProvisioningTimestamps *ProvisioningTimestamps `json:"provisioningTimestamps,omitempty"`
+
+	// BootstrapRedelivery tracks the replacement of the boot volume, with which the bootstrap data is re-delivered.
+	// It is removed once the re-delivery has been completed.
+	//+optional
+	BootstrapRedelivery *BootstrapRedeliveryStatus `json:"bootstrapRedelivery,omitempty"`
+
+	// ShutdownRequestedAt is the time, at which the shutdown of the server was requested before its deletion.
+	// Once the shutdown timeout has passed since then, the server is deleted regardless of its state.
+	//+optional
+	ShutdownRequestedAt *metav1.Time `json:"shutdownRequestedAt,omitempty"`
+}
+
+// BootstrapRedeliveryStatus tracks the replacement of the boot volume of a server,
+// with which the bootstrap data is re-delivered.
+type BootstrapRedeliveryStatus struct {
+	// PreviousVolumeID is the ID of the boot volume, which is replaced.
+	PreviousVolumeID string `json:"previousVolumeID"`
+
+	// VolumeID is the ID of the new boot volume, once its creation has been requested.
+	//+optional
+	VolumeID string `json:"volumeID,omitempty"`
+}
+
+// ProvisioningTimestamps contains the times, at which a machine reached the phases of its provisioning.
+// Each timestamp is only recorded once.
+type ProvisioningTimestamps struct {
+	// ServerRequested is the time, at which the creation of the server was requested.
+	//+optional
+	ServerRequested *metav1.Time `json:"serverRequested,omitempty"`
+
+	// BootstrapDelivered is the time, at which the server was created with the bootstrap data.
+	//+optional
+	BootstrapDelivered *metav1.Time `json:"bootstrapDelivered,omitempty"`
+
+	// ServerAvailable is the time, at which the server was available in its desired power state.
+	//+optional
+	ServerAvailable *metav1.Time `json:"serverAvailable,omitempty"`
+
+	// NodeReady is the time, at which the node of the machine was reported healthy by Cluster API.
+	//+optional
+	NodeReady *metav1.Time `json:"nodeReady,omitempty"`
+}
+
+// RemoteConsoleStatus references the secret, which contains the URL of the remote console of the VM.
+type RemoteConsoleStatus struct {
+	// SecretName is the name of the secret in the namespace of the IonosCloudMachine, which contains the URL
+	// in the key url. The URL contains a short-lived token, which grants access to the console.
+	SecretName string `json:"secretName"`
+
+	// RequestedAt is the time, at which the URL was requested from IONOS Cloud.
+	RequestedAt metav1.Time `json:"requestedAt"`
+}
+
+// MachineNetworkInfo contains information about the network configuration of the VM.
+type MachineNetworkInfo struct {
+	// NICInfo holds information about the NICs, which are attached to the VM.
+	//+optional
+	NICInfo []NICInfo `json:"nicInfo,omitempty"`
+}
+
+// NICInfo provides information about the NIC of the VM.
+type NICInfo struct {
+	// ID is the ID of the NIC in the cloud.
+	//+optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the name of the NIC in the cloud.
+	//+optional
+	Name string `json:"name,omitempty"`
+
+	// MACAddress is the MAC address of the NIC.
+	//+optional
+	MACAddress string `json:"macAddress,omitempty"`
+
+	// IPv4Addresses contains the IPv4 addresses of the NIC.
+	IPv4Addresses []string `json:"ipv4Addresses"`
+
+	// IPv6Addresses contains the IPv6 addresses of the NIC.
+	IPv6Addresses []string `json:"ipv6Addresses"`
+
+	// NetworkID is the ID of the LAN to which the NIC is connected.
+	NetworkID int32 `json:"networkID"`
+
+	// Primary indicates whether the NIC is the primary NIC of the VM.
+	Primary bool `json:"primary"`
+
+	// FirewallActive indicates whether the firewall of the NIC is active.
+	//+optional
+	FirewallActive bool `json:"firewallActive,omitempty"`
+
+	// FirewallType is the type of the firewall rules, which are applied to the NIC, e.g. INGRESS.
+	//+optional
+	FirewallType string `json:"firewallType,omitempty"`
+}
+
+// VolumeInfo provides information about a volume attached to the VM.
+type VolumeInfo struct {
+	// ID is the ID of the volume in the cloud.
+	ID string `json:"id"`
+
+	// Name is the name of the volume in the cloud.
+	Name string `json:"name"`
+
+	// Boot indicates whether the volume is the boot volume of the VM.
+	Boot bool `json:"boot"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=ionoscloudmachines,scope=Namespaced,categories=cluster-api;ionoscloud,shortName=icm
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine is ready"
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.instanceState",description="State of the VM"
+//+kubebuilder:printcolumn:name="IPv4 Addresses",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].ipv4Addresses"
+//+kubebuilder:printcolumn:name="Machine Connected Networks",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].networkID"
+//+kubebuilder:printcolumn:name="IPv6 Addresses",type="string",JSONPath=".status.machineNetworkInfo.nicInfo[*].ipv6Addresses",priority=1
+
+// IonosCloudMachine is the Schema for the ionoscloudmachines API.
+type IonosCloudMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	//+kubebuilder:validation:XValidation:rule="self.type != 'VCPU' || !has(self.cpuFamily)",message="cpuFamily must not be specified when using VCPU"
+	//+kubebuilder:validation:XValidation:rule="self.type != 'CUBE' || !has(self.cpuFamily)",message="cpuFamily must not be specified when using CUBE"
+	//+kubebuilder:validation:XValidation:rule="self.type != 'CUBE' || has(self.template)",message="template must be specified when using CUBE"
+	//+kubebuilder:validation:XValidation:rule="self.type == 'CUBE' || !has(self.template)",message="template can only be specified when using CUBE"
+	Spec   IonosCloudMachineSpec   `json:"spec,omitempty"`
+	Status IonosCloudMachineStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IonosCloudMachineList contains a list of IonosCloudMachine.
+type IonosCloudMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IonosCloudMachine `json:"items"`
+}
+
+// GetConditions returns the observations of the operational state of the IonosCloudMachine resource.
+func (m *IonosCloudMachine) GetConditions() clusterv1.Conditions {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the IonosCloudMachine to the predescribed clusterv1.Conditions.
+func (m *IonosCloudMachine) SetConditions(conditions clusterv1.Conditions) {
+	m.Status.Conditions = conditions
+}
+
+// ExtractServerID extracts the server ID from the provider ID.
+// if the provider ID is empty, an empty string will be returned instead.
+func (m *IonosCloudMachine) ExtractServerID() string {
+	if m.Spec.ProviderID == nil || *m.Spec.ProviderID == "" {
+		return ""
+	}
+
+	before, after, _ := strings.Cut(ptr.Deref(m.Spec.ProviderID, ""), "://")
+	// if the provider ID does not start with "ionos", we can assume that it is not a valid provider ID.
+	if before != "ionos" {
+		return ""
+	}
+
+	return after
+}
+
+// SetCurrentRequest sets the current provisioning request for the machine.
+func (m *IonosCloudMachine) SetCurrentRequest(method, status, requestPath string) {
+	m.Status.CurrentRequest = &ProvisioningRequest{
+		Method:      method,
+		RequestPath: requestPath,
+		State:       status,
+	}
+	m.recordRequest(m.Status.CurrentRequest)
+}
+
+// DeleteCurrentRequest deletes the current provisioning request for the machine.
+// The last known state of the request is kept in the recent requests.
+func (m *IonosCloudMachine) DeleteCurrentRequest() {
+	if m.Status.CurrentRequest != nil {
+		m.recordRequest(m.Status.CurrentRequest)
+	}
+	m.Status.CurrentRequest = nil
+}
+
+// AbandonCurrentRequest stops tracking the current provisioning request of the machine, which is stuck.
+// The request is marked as abandoned in the recent requests.
+func (m *IonosCloudMachine) AbandonCurrentRequest() {
+	req := m.Status.CurrentRequest
+	if req == nil {
+		return
+	}
+	m.DeleteCurrentRequest()
+	if ref := m.recentRequest(req.ID()); ref != nil {
+		ref.Abandoned = true
+	}
+}
+
+// AbandonedRequestIDs returns the IDs of the recent requests, which were abandoned.
+func (m *IonosCloudMachine) AbandonedRequestIDs() []string {
+	var ids []string
+	for _, ref := range m.Status.RecentRequests {
+		if ref.Abandoned {
+			ids = append(ids, ref.ID)
+		}
+	}
+	return ids
+}
+
+// CurrentRequestTimestamp returns the time, at which the current provisioning request was recorded.
+// If there is no current request or it was not recorded, nil is returned.
+func (m *IonosCloudMachine) CurrentRequestTimestamp() *metav1.Time {
+	if m.Status.CurrentRequest == nil {
+		return nil
+	}
+	if ref := m.recentRequest(m.Status.CurrentRequest.ID()); ref != nil {
+		return &ref.CreationTimestamp
+	}
+	return nil
+}
+
+// recentRequest returns the recent request with the given ID, or nil if there is none.
+func (m *IonosCloudMachine) recentRequest(id string) *ProvisioningRequestReference {
+	if id == "" {
+		return nil
+	}
+	for i := range m.Status.RecentRequests {
+		if m.Status.RecentRequests[i].ID == id {
+			return &m.Status.RecentRequests[i]
+		}
+	}
+	return nil
+}
+
+// recordRequest adds the request to the recent requests of the machine or updates the state of
+// the existing entry. Only the last MaxRecentRequests requests are kept.
+func (m *IonosCloudMachine) recordRequest(req *ProvisioningRequest) {
+	id := req.ID()
+	if id == "" {
+		return
+	}
+	if ref := m.recentRequest(id); ref != nil {
+		ref.State = req.State
+		return
+	}
+	m.Status.RecentRequests = append(m.Status.RecentRequests, ProvisioningRequestReference{
+		ID:                id,
+		Method:            req.Method,
+		State:             req.State,
+		CreationTimestamp: metav1.Now(),
+	})
+	if overflow := len(m.Status.RecentRequests) - MaxRecentRequests; overflow > 0 {
+		m.Status.RecentRequests = m.Status.RecentRequests[overflow:]
+	}
+}
+
+// HasPendingRequest returns true if the machine tracks a provisioning request, which has not completed yet.
+func (m *IonosCloudMachine) HasPendingRequest() bool {
+	return m.Status.CurrentRequest != nil
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IonosCloudMachine{}, &IonosCloudMachineList{})
+}
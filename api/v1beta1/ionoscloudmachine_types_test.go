@@ -0,0 +1,1153 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func defaultMachine() *IonosCloudMachine {
+	return &IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: IonosCloudMachineSpec{
+			ProviderID:       ptr.To("ionos://ee090ff2-1eef-48ec-a246-a51a33aa4f3a"),
+			DatacenterID:     "ee090ff2-1eef-48ec-a246-a51a33aa4f3a",
+			NumCores:         1,
+			AvailabilityZone: AvailabilityZoneTwo,
+			MemoryMB:         2048,
+			CPUFamily:        ptr.To("AMD_OPTERON"),
+			Disk: &Volume{
+				Name:             "disk",
+				DiskType:         VolumeDiskTypeSSDStandard,
+				SizeGB:           23,
+				AvailabilityZone: AvailabilityZoneOne,
+				Image: &ImageSpec{
+					ID: "1eef-48ec-a246-a51a33aa4f3a",
+				},
+			},
+			AdditionalNetworks: Networks{
+				{
+					NetworkID: 1,
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("IonosCloudMachine Tests", func() {
+	AfterEach(func() {
+		m := &IonosCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machine",
+				Namespace: metav1.NamespaceDefault,
+			},
+		}
+		err := k8sClient.Delete(context.Background(), m)
+		Expect(client.IgnoreNotFound(err)).ToNot(HaveOccurred())
+	})
+
+	Context("Validation", func() {
+		It("should work if everything is set properly", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+
+		Context("Provider ID", func() {
+			It("should work if not set", func() {
+				m := defaultMachine()
+				want := ""
+				m.Spec.ProviderID = &want
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(*m.Spec.ProviderID).To(Equal(want))
+			})
+			DescribeTable("tests for extraction of provider IDs", func(providerID, want string) {
+				m := defaultMachine()
+				m.Spec.ProviderID = &providerID
+				Expect(m.ExtractServerID()).To(Equal(want))
+			},
+				Entry("valid ID", "ionos://ee090ff2-1eef-48ec-a246-a51a33aa4f3a",
+					"ee090ff2-1eef-48ec-a246-a51a33aa4f3a"),
+				Entry("invalid provider name", "ionoscloud://ee090ff2-1eef-48ec-a246-a51a33aa4f3a", ""),
+				Entry("typo in provider name", "ions://ee090ff2-1eef-48ec-a246-a51a33aa4f3a", ""),
+				Entry("no provider name", "://ee090ff2-1eef-48ec-a246-a51a33aa4f3a", ""),
+			)
+		})
+
+		Context("Data center ID", func() {
+			It("should allow an empty data center ID", func() {
+				m := defaultMachine()
+				m.Spec.DatacenterID = ""
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+			It("should allow setting the data center ID, if it is not set", func() {
+				m := defaultMachine()
+				m.Spec.DatacenterID = ""
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.DatacenterID = "6ded8c5f-8df2-46ef-b4ce-61833daf0961"
+				Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+			})
+			It("should not allow removing the data center ID", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.DatacenterID = ""
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("datacenterID cannot be removed")))
+			})
+
+			It("should fail if not a UUID", func() {
+				m := defaultMachine()
+				want := "not-a-UUID"
+				m.Spec.DatacenterID = want
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should be immutable", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.DatacenterID).To(Equal(defaultMachine().Spec.DatacenterID))
+				m.Spec.DatacenterID = "6ded8c5f-8df2-46ef-b4ce-61833daf0961"
+				Expect(k8sClient.Update(context.Background(), m)).ToNot(Succeed())
+			})
+		})
+
+		Context("Number of cores", func() {
+			It("should fail if less than 1", func() {
+				m := defaultMachine()
+				m.Spec.NumCores = -1
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should have a minimum value of 1", func() {
+				m := defaultMachine()
+				want := int32(1)
+				m.Spec.NumCores = want
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.NumCores).To(Equal(want))
+			})
+			It("should default to 1", func() {
+				m := defaultMachine()
+				// because NumCores is int32, setting the value as 0 is the same as not setting anything
+				m.Spec.NumCores = 0
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.NumCores).To(Equal(int32(1)))
+			})
+			It("should be mutable", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+				m.Spec.NumCores = 4
+				m.Spec.MemoryMB = 8192
+				Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+			})
+		})
+
+		Context("Availability zone", func() {
+			It("should default to AUTO", func() {
+				m := defaultMachine()
+				// because AvailabilityZone is a string, setting the value as "" is the same as not setting anything
+				m.Spec.AvailabilityZone = ""
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AvailabilityZone).To(Equal(AvailabilityZoneAuto))
+			})
+			It("should fail if not part of the enum", func() {
+				m := defaultMachine()
+				m.Spec.AvailabilityZone = "this-should-not-work"
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			DescribeTable("should work for value",
+				func(zone AvailabilityZone) {
+					m := defaultMachine()
+					m.Spec.AvailabilityZone = zone
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.AvailabilityZone).To(Equal(zone))
+				},
+				Entry("AUTO", AvailabilityZoneAuto),
+				Entry("ZONE_1", AvailabilityZoneOne),
+				Entry("ZONE_2", AvailabilityZoneTwo),
+			)
+			It("Should fail for ZONE_3", func() {
+				m := defaultMachine()
+				m.Spec.AvailabilityZone = AvailabilityZoneThree
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+		})
+
+		Context("Memory size", func() {
+			It("should default to 3072MB", func() {
+				m := defaultMachine()
+				// because MemoryMB is an int32, setting the value as 0 is the same as not setting anything
+				m.Spec.MemoryMB = 0
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.MemoryMB).To(Equal(int32(3072)))
+			})
+			It("should be at least 2048, therefore less than it should fail", func() {
+				m := defaultMachine()
+				m.Spec.MemoryMB = 1024
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should be at least 2048, therefore 2048 should work", func() {
+				m := defaultMachine()
+				want := int32(2048)
+				m.Spec.MemoryMB = want
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.MemoryMB).To(Equal(want))
+			})
+			It("should be a multiple of 1024", func() {
+				m := defaultMachine()
+				m.Spec.MemoryMB = 2100
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should be at least 2048 and a multiple of 1024, therefore 4096 should work", func() {
+				m := defaultMachine()
+				want := int32(4096)
+				m.Spec.MemoryMB = want
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.MemoryMB).To(Equal(want))
+			})
+		})
+
+		Context("CPU Family", func() {
+			It("should not fail if not set", func() {
+				m := defaultMachine()
+				m.Spec.CPUFamily = nil
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+		})
+
+		Context("Disk", func() {
+			It("should fail if not set", func() {
+				m := defaultMachine()
+				m.Spec.Disk = nil
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("can have an optional name", func() {
+				m := defaultMachine()
+				want := ""
+				m.Spec.Disk.Name = want
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.Disk.Name).To(Equal(want))
+			})
+			Context("Availability zone", func() {
+				It("should default to AUTO", func() {
+					m := defaultMachine()
+					// because AvailabilityZone is a string, setting the value as "" is the same as not setting anything
+					m.Spec.Disk.AvailabilityZone = ""
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.AvailabilityZone).To(Equal(AvailabilityZoneAuto))
+				})
+				It("should fail if not part of the enum", func() {
+					m := defaultMachine()
+					m.Spec.Disk.AvailabilityZone = "this-should-not-work"
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				DescribeTable("should work for value",
+					func(zone AvailabilityZone) {
+						m := defaultMachine()
+						m.Spec.Disk.AvailabilityZone = zone
+						Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+						Expect(m.Spec.Disk.AvailabilityZone).To(Equal(zone))
+					},
+					Entry("AUTO", AvailabilityZoneAuto),
+					Entry("ZONE_1", AvailabilityZoneOne),
+					Entry("ZONE_2", AvailabilityZoneTwo),
+					Entry("ZONE_3", AvailabilityZoneThree),
+				)
+			})
+			Context("Size (in GB)", func() {
+				It("should fail if less than 10", func() {
+					m := defaultMachine()
+					m.Spec.Disk.SizeGB = 9
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				It("should default to 20", func() {
+					m := defaultMachine()
+					// Because disk size is an int, setting it as 0 is the same as not setting anything
+					m.Spec.Disk.SizeGB = 0
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.SizeGB).To(Equal(20))
+				})
+				It("should be at least 10; therefore 10 should work", func() {
+					m := defaultMachine()
+					want := 10
+					m.Spec.Disk.SizeGB = want
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.SizeGB).To(Equal(want))
+				})
+			})
+			Context("DiskType", func() {
+				It("should be optional", func() {
+					m := defaultMachine()
+					// because DiskType is a string, setting the value as "" is the same as not setting anything.
+					// The disk type is defaulted by the webhook, which is not part of this test environment.
+					m.Spec.Disk.DiskType = ""
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.DiskType).To(BeEmpty())
+				})
+				It("should fail if not part of the enum", func() {
+					m := defaultMachine()
+					m.Spec.Disk.AvailabilityZone = "tape"
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				DescribeTable("should work for value",
+					func(diskType VolumeDiskType) {
+						m := defaultMachine()
+						m.Spec.Disk.DiskType = diskType
+						Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+						Expect(m.Spec.Disk.DiskType).To(Equal(diskType))
+					},
+					Entry("HDD", VolumeDiskTypeHDD),
+					Entry("SSD Standard", VolumeDiskTypeSSDStandard),
+					Entry("SSD Premium", VolumeDiskTypeSSDPremium),
+				)
+			})
+			Context("Bus", func() {
+				It("should be optional", func() {
+					m := defaultMachine()
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.Bus).To(BeEmpty())
+				})
+				It("should fail if not part of the enum", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Bus = "SCSI"
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				It("should be immutable", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Bus = VolumeBusVirtIO
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					m.Spec.Disk.Bus = VolumeBusIDE
+					Expect(k8sClient.Update(context.Background(), m)).
+						Should(MatchError(ContainSubstring("bus is immutable")))
+				})
+			})
+			Context("BackupUnitID", func() {
+				It("should fail if not a UUID", func() {
+					m := defaultMachine()
+					m.Spec.Disk.BackupUnitID = "backup"
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				It("should be immutable", func() {
+					m := defaultMachine()
+					m.Spec.Disk.BackupUnitID = "8a36c7a6-3aa7-4ca5-9935-7c9a0d3305a8"
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					m.Spec.Disk.BackupUnitID = "0f52c5ad-4b25-4a9f-9a96-3b1e7e8cf3c5"
+					Expect(k8sClient.Update(context.Background(), m)).
+						Should(MatchError(ContainSubstring("backupUnitID is immutable")))
+				})
+			})
+			Context("Image", func() {
+				It("should fail if not set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = nil
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("disk.image must be set unless a cdrom is attached")))
+				})
+				It("should not fail if not set and a CD-ROM is attached", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = nil
+					m.Spec.CDROM = &CDROMSpec{Private: &PrivateImageReference{Name: "talos-amd64.iso"}}
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				})
+				It("should fail none is set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image.ID = ""
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+				It("should not fail if ID is set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image.ID = "1eef-48ec-a246-a51a33aa4f3a"
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				})
+				It("should not fail if a snapshot is set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{Snapshot: &SnapshotReference{Name: "golden-image"}}
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				})
+				It("should fail if both ID and snapshot are set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image.Snapshot = &SnapshotReference{Name: "golden-image"}
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("exactly one of id, snapshot or private must be set")))
+				})
+				It("should not fail if a private image is set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{Private: &PrivateImageReference{Name: "ubuntu-2204-kube-v1.29.4"}}
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					Expect(m.Spec.Disk.Image.Private.LicenceType).To(Equal(ImageLicenceTypeLinux))
+				})
+				It("should fail if both snapshot and private image are set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{
+						Snapshot: &SnapshotReference{Name: "golden-image"},
+						Private:  &PrivateImageReference{Name: "ubuntu-2204-kube-v1.29.4"},
+					}
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("exactly one of id, snapshot or private must be set")))
+				})
+				It("should fail if both private image name and alias are set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{Private: &PrivateImageReference{
+						Name:  "ubuntu-2204-kube-v1.29.4",
+						Alias: "ubuntu:latest",
+					}}
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("exactly one of name or alias must be set")))
+				})
+				It("should fail if both snapshot ID and name are set", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{Snapshot: &SnapshotReference{
+						ID:   "15c6dd2f-02d2-4987-b439-9a58dd59ecc3",
+						Name: "golden-image",
+					}}
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("exactly one of id or name must be set")))
+				})
+			})
+			Context("ImageUpgradePolicy", func() {
+				It("should allow pinning a private image", func() {
+					m := defaultMachine()
+					m.Spec.Disk.Image = &ImageSpec{Private: &PrivateImageReference{Alias: "ubuntu:latest"}}
+					m.Spec.Disk.ImageUpgradePolicy = ImageUpgradePolicyPin
+					Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				})
+				It("should not allow pinning an image ID", func() {
+					m := defaultMachine()
+					m.Spec.Disk.ImageUpgradePolicy = ImageUpgradePolicyPin
+					Expect(k8sClient.Create(context.Background(), m)).
+						Should(MatchError(ContainSubstring("imageUpgradePolicy Pin requires a snapshot or a private image")))
+				})
+				It("should not allow an unknown policy", func() {
+					m := defaultMachine()
+					m.Spec.Disk.ImageUpgradePolicy = "Always"
+					Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				})
+			})
+		})
+		Context("CDROM", func() {
+			It("should fail if both ID and private image are set", func() {
+				m := defaultMachine()
+				m.Spec.CDROM = &CDROMSpec{
+					ID:      "15c6dd2f-02d2-4987-b439-9a58dd59ecc3",
+					Private: &PrivateImageReference{Name: "talos-amd64.iso"},
+				}
+				Expect(k8sClient.Create(context.Background(), m)).
+					Should(MatchError(ContainSubstring("exactly one of id or private must be set")))
+			})
+			It("should be immutable", func() {
+				m := defaultMachine()
+				m.Spec.CDROM = &CDROMSpec{ID: "15c6dd2f-02d2-4987-b439-9a58dd59ecc3"}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.CDROM.EjectAfterFirstBoot = true
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("cdrom is immutable")))
+			})
+		})
+		Context("SSHKeys", func() {
+			It("should be immutable", func() {
+				m := defaultMachine()
+				m.Spec.SSHKeys = []string{"ssh-ed25519 AAAA first"}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.SSHKeys = append(m.Spec.SSHKeys, "ssh-ed25519 AAAA second")
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("sshKeys is immutable")))
+			})
+			It("should not allow empty keys", func() {
+				m := defaultMachine()
+				m.Spec.SSHKeys = []string{""}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+		})
+		Context("Boot", func() {
+			It("should require a CD-ROM to boot from it", func() {
+				m := defaultMachine()
+				m.Spec.Boot = &BootConfig{Device: BootDeviceCDROM}
+				Expect(k8sClient.Create(context.Background(), m)).
+					Should(MatchError(ContainSubstring("boot.device CDROM requires a cdrom")))
+			})
+			It("should not require an image to boot from the network", func() {
+				m := defaultMachine()
+				m.Spec.Disk.Image = nil
+				m.Spec.Boot = &BootConfig{Device: BootDeviceNetwork}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+			DescribeTable("should validate the volume boot order",
+				func(device BootDevice, order VolumeBootOrder, valid bool) {
+					m := defaultMachine()
+					m.Spec.CDROM = &CDROMSpec{ID: "15c6dd2f-02d2-4987-b439-9a58dd59ecc3"}
+					m.Spec.Boot = &BootConfig{Device: device, VolumeBootOrder: order}
+					if valid {
+						Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+					} else {
+						Expect(k8sClient.Create(context.Background(), m)).
+							Should(MatchError(ContainSubstring("volumeBootOrder is not supported with the boot device")))
+					}
+				},
+				Entry("Volume with PRIMARY", BootDeviceVolume, VolumeBootOrderPrimary, true),
+				Entry("Volume with AUTO", BootDeviceVolume, VolumeBootOrderAuto, true),
+				Entry("Volume with NONE", BootDeviceVolume, VolumeBootOrderNone, false),
+				Entry("CDROM with AUTO", BootDeviceCDROM, VolumeBootOrderAuto, true),
+				Entry("CDROM with PRIMARY", BootDeviceCDROM, VolumeBootOrderPrimary, false),
+				Entry("Network with NONE", BootDeviceNetwork, VolumeBootOrderNone, true),
+				Entry("Network with AUTO", BootDeviceNetwork, VolumeBootOrderAuto, false),
+			)
+			It("should be immutable", func() {
+				m := defaultMachine()
+				m.Spec.Boot = &BootConfig{Device: BootDeviceVolume}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.Boot.VolumeBootOrder = VolumeBootOrderAuto
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("boot is immutable")))
+			})
+		})
+		Context("Hostname format", func() {
+			It("should be optional", func() {
+				m := defaultMachine()
+				m.Spec.HostnameFormat = ""
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+			It("should be immutable", func() {
+				m := defaultMachine()
+				m.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.HostnameFormat = "{{ .MachineName }}"
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("hostnameFormat is immutable")))
+			})
+		})
+		Context("Additional Networks", func() {
+			It("network config should be optional", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = nil
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AdditionalNetworks).To(BeNil())
+			})
+			It("network ID must be greater than 0", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks[0].NetworkID = 0
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+				m.Spec.AdditionalNetworks[0].NetworkID = -1
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("network IDs must be unique", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = Networks{{NetworkID: 1}, {NetworkID: 1}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should allow referencing a cluster network by name", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = Networks{{NetworkID: 1}, {Name: "storage"}}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			})
+			It("network names must be unique", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = Networks{{Name: "storage"}, {Name: "storage"}}
+				Expect(k8sClient.Create(context.Background(), m)).
+					Should(MatchError(ContainSubstring("networks must be unique")))
+			})
+			It("should not allow setting both network ID and name", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalNetworks = Networks{{NetworkID: 1, Name: "storage"}}
+				Expect(k8sClient.Create(context.Background(), m)).
+					Should(MatchError(ContainSubstring("either networkID or name must be set")))
+			})
+			It("should be immutable", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				m.Spec.AdditionalNetworks = append(m.Spec.AdditionalNetworks, Network{NetworkID: 10})
+				Expect(k8sClient.Update(context.Background(), m)).
+					Should(MatchError(ContainSubstring("additionalNetworks is immutable")))
+			})
+		})
+		Context("Additional Volumes", func() {
+			It("should be optional", func() {
+				m := defaultMachine()
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AdditionalVolumes).To(BeNil())
+			})
+			It("should apply the defaults", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data"}}
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.AdditionalVolumes[0].SizeGB).To(Equal(20))
+				Expect(m.Spec.AdditionalVolumes[0].DiskType).To(Equal(VolumeDiskTypeHDD))
+				Expect(m.Spec.AdditionalVolumes[0].AvailabilityZone).To(Equal(AvailabilityZoneAuto))
+			})
+			It("should fail if the name is not set", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{SizeGB: 10}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if names are not unique", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data"}, {Name: "data"}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if the size is less than 10", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data", SizeGB: 9}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+			It("should fail if the disk type is not part of the enum", func() {
+				m := defaultMachine()
+				m.Spec.AdditionalVolumes = []VolumeSpec{{Name: "data", DiskType: "tape"}}
+				Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+			})
+		})
+	})
+	Context("IPv6", func() {
+		It("should be optional", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.IPv6).To(BeNil())
+		})
+		It("should enable DHCP by default", func() {
+			m := defaultMachine()
+			m.Spec.IPv6 = &IPv6Config{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.IPv6.DHCP).To(Equal(ptr.To(true)))
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.IPv6 = &IPv6Config{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.IPv6.DHCP = ptr.To(false)
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipv6 is immutable")))
+		})
+	})
+	Context("Firewall rules", func() {
+		sshRule := func() FirewallRule {
+			return FirewallRule{
+				Name:           "ssh",
+				Protocol:       FirewallRuleProtocolTCP,
+				PortRangeStart: ptr.To[int32](22),
+				PortRangeEnd:   ptr.To[int32](22),
+			}
+		}
+		It("should default the direction to INGRESS", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FirewallRules[0].Direction).To(Equal(FirewallRuleDirectionIngress))
+		})
+		It("should allow changing the rules", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.FirewallRules[0].SourceCIDR = ptr.To("198.51.100.0/24")
+			Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+		})
+		It("should fail if the names are not unique", func() {
+			m := defaultMachine()
+			m.Spec.FirewallRules = []FirewallRule{sshRule(), sshRule()}
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should fail if only one end of the port range is set", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.PortRangeEnd = nil
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("portRangeStart and portRangeEnd must be set together")))
+		})
+		It("should fail if the port range is inverted", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.PortRangeStart = ptr.To[int32](23)
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("portRangeStart must not be greater than portRangeEnd")))
+		})
+		It("should fail if a port range is set for ICMP", func() {
+			m := defaultMachine()
+			rule := sshRule()
+			rule.Protocol = FirewallRuleProtocolICMP
+			m.Spec.FirewallRules = []FirewallRule{rule}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("port ranges are only supported for TCP and UDP")))
+		})
+	})
+	Context("FailoverIP", func() {
+		It("should allow setting AUTO as the value", func() {
+			m := defaultMachine()
+			m.Spec.FailoverIP = ptr.To(CloudResourceConfigAuto)
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FailoverIP).To(Equal(ptr.To(CloudResourceConfigAuto)))
+		})
+		It("should allow setting a valid IPv4 address", func() {
+			m := defaultMachine()
+			m.Spec.FailoverIP = ptr.To("203.0.113.1")
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FailoverIP).To(Equal(ptr.To("203.0.113.1")))
+		})
+		It("should allow setting null", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FailoverIP).To(BeNil())
+		})
+		DescribeTable("should not allow setting invalid IPv4 addresses", func(ip string) {
+			m := defaultMachine()
+			m.Spec.FailoverIP = &ip
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		},
+			Entry("IPv4 out of range", "203.0.113.256"),
+			Entry("IPv4 missing a block", "203.0.113"),
+			Entry("IPv4 ends on a dot", "203.0.113.255."),
+			Entry("IPv4 two dots", "203..0.113.255"),
+			Entry("IPv4 using commas", "203,0,113,255"),
+		)
+		It("should require AUTO to be in capital letters", func() {
+			m := defaultMachine()
+			m.Spec.FailoverIP = ptr.To("Auto")
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.FailoverIP = ptr.To(CloudResourceConfigAuto)
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.FailoverIP).To(Equal(ptr.To(CloudResourceConfigAuto)))
+			m.Spec.FailoverIP = ptr.To("127.0.0.1")
+			Expect(k8sClient.Update(context.Background(), m)).ToNot(Succeed())
+			m.Spec.FailoverIP = ptr.To("")
+			Expect(k8sClient.Update(context.Background(), m)).ToNot(Succeed())
+		})
+	})
+	Context("FailoverGroups", func() {
+		It("should allow referencing a LAN by ID or by network name", func() {
+			m := defaultMachine()
+			m.Spec.FailoverGroups = []FailoverGroup{
+				{NetworkID: 3, IP: "10.0.0.100"},
+				{Network: "private", IP: "10.1.0.100"},
+			}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+		DescribeTable("should not allow invalid groups", func(group FailoverGroup, message string) {
+			m := defaultMachine()
+			m.Spec.FailoverGroups = []FailoverGroup{group}
+			Expect(k8sClient.Create(context.Background(), m)).Should(MatchError(ContainSubstring(message)))
+		},
+			Entry("no LAN", FailoverGroup{IP: "10.0.0.100"}, "either networkID or network must be set"),
+			Entry("two LANs", FailoverGroup{NetworkID: 3, Network: "private", IP: "10.0.0.100"},
+				"either networkID or network must be set"),
+			Entry("invalid IP", FailoverGroup{NetworkID: 3, IP: "10.0.0.256"}, "ip must be a valid IPv4 address"),
+		)
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.FailoverGroups = []FailoverGroup{{NetworkID: 3, IP: "10.0.0.100"}}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.FailoverGroups[0].IP = "10.0.0.101"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("failoverGroups is immutable")))
+		})
+	})
+	Context("ServerType", func() {
+		It("should default to ENTERPRISE", func() {
+			m := defaultMachine()
+			// because Type is a string, setting the value as "" is the same as not setting anything
+			m.Spec.Type = ""
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.Type).To(Equal(ServerTypeEnterprise))
+		})
+		It("should fail if not part of the enum", func() {
+			m := defaultMachine()
+			m.Spec.Type = "this-should-fail"
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should fail if cpuFamily is set and type is VCPU", func() {
+			m := defaultMachine()
+			m.Spec.CPUFamily = ptr.To("some-cpu-family")
+			m.Spec.Type = ServerTypeVCPU
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		DescribeTable("should work for value",
+			func(serverType ServerType) {
+				m := defaultMachine()
+				m.Spec.Type = serverType
+				m.Spec.CPUFamily = nil
+				Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+				Expect(m.Spec.Type).To(Equal(serverType))
+			},
+			Entry("ENTERPRISE", ServerTypeEnterprise),
+			Entry("VCPU", ServerTypeVCPU),
+		)
+		It("should work for CUBE with a template", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+		It("should fail if type is CUBE and no template is set", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("template must be specified when using CUBE")))
+		})
+		It("should fail if cpuFamily is set and type is CUBE", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = ptr.To("some-cpu-family")
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should fail if a template is set and type is not CUBE", func() {
+			m := defaultMachine()
+			m.Spec.Template = &ServerTemplate{Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("template can only be specified when using CUBE")))
+		})
+		It("should fail if both template ID and name are set", func() {
+			m := defaultMachine()
+			m.Spec.Type = ServerTypeCube
+			m.Spec.CPUFamily = nil
+			m.Spec.Template = &ServerTemplate{ID: "15c6dd2f-02d2-4987-b439-9a58dd59ecc3", Name: "Basic Cube XS"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("exactly one of id or name must be set")))
+		})
+	})
+	Context("ShutdownTimeout", func() {
+		It("should default to 2 minutes", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.ShutdownTimeout).To(Equal(&metav1.Duration{Duration: 2 * time.Minute}))
+		})
+		It("should allow disabling the shutdown", func() {
+			m := defaultMachine()
+			m.Spec.ShutdownTimeout = &metav1.Duration{}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.ShutdownTimeout.Duration).To(BeZero())
+		})
+	})
+	Context("DesiredPowerState", func() {
+		It("should default to Running", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.DesiredPowerState).To(Equal(PowerStateRunning))
+		})
+		It("should allow stopping the machine", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.DesiredPowerState = PowerStateStopped
+			Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+		})
+		It("should not allow an unknown power state", func() {
+			m := defaultMachine()
+			m.Spec.DesiredPowerState = "Paused"
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+	})
+	Context("AdditionalUserData", func() {
+		It("should default the content type to cloud-config", func() {
+			m := defaultMachine()
+			m.Spec.AdditionalUserData = []UserDataPart{{
+				SecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+					Key:                  "mirrors",
+				},
+			}}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(m.Spec.AdditionalUserData[0].ContentType).To(Equal("text/cloud-config"))
+		})
+		It("should not allow an unknown content type", func() {
+			m := defaultMachine()
+			m.Spec.AdditionalUserData = []UserDataPart{{
+				SecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+					Key:                  "mirrors",
+				},
+				ContentType: "text/plain",
+			}}
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			m.Spec.AdditionalUserData = []UserDataPart{{
+				SecretRef: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+					Key:                  "mirrors",
+				},
+			}}
+			Expect(k8sClient.Update(context.Background(), m)).ToNot(Succeed())
+		})
+	})
+	Context("Labels", func() {
+		It("should allow updating labels", func() {
+			m := defaultMachine()
+			m.Spec.Labels = map[string]string{"team": "platform"}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.Labels["team"] = "infra"
+			Expect(k8sClient.Update(context.Background(), m)).To(Succeed())
+		})
+		It("should not allow setting reserved labels", func() {
+			m := defaultMachine()
+			m.Spec.Labels = map[string]string{"machine-name": "other"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("cluster-namespace and machine-name are reserved labels")))
+		})
+	})
+	Context("IPAMConfig", func() {
+		poolRef := func() *corev1.TypedLocalObjectReference {
+			return &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+				Kind:     "InClusterIPPool",
+				Name:     "pool",
+			}
+		}
+		It("should allow setting a pool for the primary and additional NICs", func() {
+			m := defaultMachine()
+			m.Spec.IPv4PoolRef = poolRef()
+			m.Spec.AdditionalNetworks[0].IPv4PoolRef = poolRef()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+		It("should require the apiGroup of the pool", func() {
+			m := defaultMachine()
+			m.Spec.IPv4PoolRef = poolRef()
+			m.Spec.IPv4PoolRef.APIGroup = nil
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipv4PoolRef.apiGroup must be set")))
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.IPv4PoolRef = poolRef()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.IPv4PoolRef.Name = "other"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipv4PoolRef is immutable")))
+		})
+		It("should not allow removing the pool", func() {
+			m := defaultMachine()
+			m.Spec.IPv4PoolRef = poolRef()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.IPv4PoolRef = nil
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipv4PoolRef cannot be added or removed")))
+		})
+	})
+	Context("IPBlock", func() {
+		It("should not allow combining an IP block with a pool", func() {
+			m := defaultMachine()
+			m.Spec.IPBlock = "egress"
+			m.Spec.IPv4PoolRef = &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+				Kind:     "InClusterIPPool",
+				Name:     "pool",
+			}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipBlock and ipv4PoolRef are mutually exclusive")))
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.IPBlock = "egress"
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.IPBlock = "ingress"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipBlock is immutable")))
+		})
+		It("should not allow adding an IP block", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.IPBlock = "egress"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("ipBlock cannot be added or removed")))
+		})
+	})
+	Context("PrivateIP", func() {
+		It("should allow pinning an address", func() {
+			m := defaultMachine()
+			m.Spec.PrivateIP = &PrivateIPConfig{Address: "10.0.0.10", Retain: true}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+		})
+		It("should not allow an invalid address", func() {
+			m := defaultMachine()
+			m.Spec.PrivateIP = &PrivateIPConfig{Address: "10.0.0"}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("address must be a valid IPv4 address")))
+		})
+		It("should require an address or retain", func() {
+			m := defaultMachine()
+			m.Spec.PrivateIP = &PrivateIPConfig{}
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("either address or retain must be set")))
+		})
+		It("should not allow combining a private IP with an IP block", func() {
+			m := defaultMachine()
+			m.Spec.PrivateIP = &PrivateIPConfig{Retain: true}
+			m.Spec.IPBlock = "egress"
+			Expect(k8sClient.Create(context.Background(), m)).
+				Should(MatchError(ContainSubstring("privateIP is mutually exclusive with ipBlock and ipv4PoolRef")))
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.PrivateIP = &PrivateIPConfig{Retain: true}
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.PrivateIP.Address = "10.0.0.10"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("privateIP is immutable")))
+		})
+	})
+	Context("ImportExisting", func() {
+		It("should not allow an invalid server ID", func() {
+			m := defaultMachine()
+			m.Spec.ImportExisting = "hand-built"
+			Expect(k8sClient.Create(context.Background(), m)).ToNot(Succeed())
+		})
+		It("should be immutable", func() {
+			m := defaultMachine()
+			m.Spec.ImportExisting = "b1c85ae9-29b4-4a57-9e2b-2f3ac4b7bf3e"
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.ImportExisting = "36e4fa43-6bd2-4cc4-9d2d-d9f5ea6b1b9d"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("importExisting is immutable")))
+		})
+		It("should not be added later", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+
+			m.Spec.ImportExisting = "b1c85ae9-29b4-4a57-9e2b-2f3ac4b7bf3e"
+			Expect(k8sClient.Update(context.Background(), m)).
+				Should(MatchError(ContainSubstring("importExisting cannot be added or removed")))
+		})
+	})
+	Context("Conditions", func() {
+		It("should correctly set and get the conditions", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(k8sClient.Get(
+				context.Background(), client.ObjectKey{Name: m.Name, Namespace: m.Namespace}, m)).To(Succeed())
+
+			// Calls SetConditions with required fields
+			conditions.MarkTrue(m, ServerCreatedCondition)
+
+			Expect(k8sClient.Status().Update(context.Background(), m)).To(Succeed())
+			Expect(k8sClient.Get(context.Background(),
+				client.ObjectKey{Name: m.Name, Namespace: m.Namespace}, m)).To(Succeed())
+
+			machineConditions := m.GetConditions()
+			Expect(machineConditions).To(HaveLen(1))
+			Expect(machineConditions[0].Type).To(Equal(ServerCreatedCondition))
+			Expect(machineConditions[0].Status).To(Equal(corev1.ConditionTrue))
+		})
+	})
+	Context("Status", func() {
+		It("should correctly set and get the status", func() {
+			m := defaultMachine()
+			Expect(k8sClient.Create(context.Background(), m)).To(Succeed())
+			Expect(k8sClient.Get(context.Background(),
+				client.ObjectKey{Name: m.Name, Namespace: m.Namespace}, m)).To(Succeed())
+
+			m.Status.Ready = true
+			conditions.MarkTrue(m, ServerCreatedCondition)
+			m.Status.CurrentRequest = &ProvisioningRequest{
+				Method:      "GET",
+				RequestPath: "path/to/resource",
+				State:       sdk.RequestStatusRunning,
+			}
+			m.Status.FailureReason = ptr.To(errors.InvalidConfigurationMachineError)
+			m.Status.FailureMessage = ptr.To("Failure message")
+
+			m.Status.MachineNetworkInfo = &MachineNetworkInfo{
+				NICInfo: []NICInfo{
+					{
+						IPv4Addresses: []string{"198.51.100.10"},
+						IPv6Addresses: []string{"2001:db8:2c3:30a0::1", "2001:db8:2c3:30a0::2"},
+						NetworkID:     10,
+						Primary:       false,
+					},
+				},
+			}
+
+			want := *m.DeepCopy()
+
+			Expect(k8sClient.Status().Update(context.Background(), m)).To(Succeed())
+			Expect(k8sClient.Get(context.Background(),
+				client.ObjectKey{Name: m.Name, Namespace: m.Namespace}, m)).To(Succeed())
+
+			// Gomega matcher seems to have issues with comparing the dates.
+			diff := cmp.Diff(want.Status, m.Status)
+			Expect(diff).To(BeEmpty(), "m.Status differs from want.Status (-want +got):\n%s", diff)
+		})
+		It("should record the recent requests", func() {
+			m := defaultMachine()
+			requestPath := func(i int) string {
+				return fmt.Sprintf("https://api.ionos.com/cloudapi/v6/requests/request-%d/status", i)
+			}
+
+			m.SetCurrentRequest("POST", sdk.RequestStatusQueued, requestPath(0))
+			Expect(m.Status.RecentRequests).To(HaveLen(1))
+			Expect(m.Status.RecentRequests[0].ID).To(Equal("request-0"))
+			Expect(m.Status.RecentRequests[0].State).To(Equal(sdk.RequestStatusQueued))
+
+			m.Status.CurrentRequest.State = sdk.RequestStatusFailed
+			m.DeleteCurrentRequest()
+			Expect(m.Status.CurrentRequest).To(BeNil())
+			Expect(m.Status.RecentRequests).To(HaveLen(1))
+			Expect(m.Status.RecentRequests[0].State).To(Equal(sdk.RequestStatusFailed))
+
+			for i := 1; i <= MaxRecentRequests; i++ {
+				m.SetCurrentRequest("PATCH", sdk.RequestStatusQueued, requestPath(i))
+			}
+			Expect(m.Status.RecentRequests).To(HaveLen(MaxRecentRequests))
+			Expect(m.Status.RecentRequests[0].ID).To(Equal("request-1"))
+			Expect(m.Status.RecentRequests[MaxRecentRequests-1].ID).To(Equal(fmt.Sprintf("request-%d", MaxRecentRequests)))
+		})
+		It("should abandon the current request", func() {
+			m := defaultMachine()
+			Expect(m.CurrentRequestTimestamp()).To(BeNil())
+
+			m.SetCurrentRequest("DELETE", sdk.RequestStatusQueued,
+				"https://api.ionos.com/cloudapi/v6/requests/request-0/status")
+			Expect(m.CurrentRequestTimestamp()).To(Equal(&m.Status.RecentRequests[0].CreationTimestamp))
+			Expect(m.AbandonedRequestIDs()).To(BeEmpty())
+
+			m.AbandonCurrentRequest()
+			Expect(m.Status.CurrentRequest).To(BeNil())
+			Expect(m.Status.RecentRequests[0].Abandoned).To(BeTrue())
+			Expect(m.AbandonedRequestIDs()).To(ConsistOf("request-0"))
+		})
+		It("should not record requests without an ID", func() {
+			m := defaultMachine()
+			m.SetCurrentRequest("GET", sdk.RequestStatusRunning, "path/to/resource")
+			Expect(m.Status.RecentRequests).To(BeEmpty())
+		})
+	})
+})
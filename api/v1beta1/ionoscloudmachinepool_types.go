@@ -0,0 +1,132 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// IonosCloudMachinePoolKind is the string resource kind of the IonosCloudMachinePool resource.
+	IonosCloudMachinePoolKind = "IonosCloudMachinePool"
+
+	// MachinePoolFinalizer allows cleanup of the IonosCloudMachines, which belong to
+	// the IonosCloudMachinePool before removing it from the API server.
+	MachinePoolFinalizer = "ionoscloudmachinepool.infrastructure.cluster.x-k8s.io"
+
+	// ReplicasReadyCondition reports whether all replicas of the IonosCloudMachinePool
+	// have been provisioned and are ready.
+	ReplicasReadyCondition clusterv1.ConditionType = "ReplicasReady"
+
+	// WaitingForReplicasReadyReason (Severity=Info) indicates that the IonosCloudMachinePool is currently
+	// waiting for its IonosCloudMachines to become ready.
+	WaitingForReplicasReadyReason = "WaitingForReplicasReady"
+)
+
+// IonosCloudMachinePoolSpec defines the desired state of IonosCloudMachinePool.
+type IonosCloudMachinePoolSpec struct {
+	// ProviderIDList contains the provider IDs of all machines, which are part of the pool.
+	// This field is maintained by the controller and must not be set by the user.
+	//+optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+
+	// Template contains the configuration, which is used to create the IonosCloudMachines of the pool.
+	Template IonosCloudMachineTemplateResource `json:"template"`
+}
+
+// IonosCloudMachinePoolStatus defines the observed state of IonosCloudMachinePool.
+// Besides the MachinePool Machines contract of Cluster API, it implements the scale from zero contract
+// of the cluster autoscaler, which uses the capacity to build a node for a pool without machines.
+type IonosCloudMachinePoolStatus struct {
+	// Ready indicates that all replicas of the pool are provisioned.
+	//+optional
+	Ready bool `json:"ready"`
+
+	// Replicas is the number of machines, which are currently part of the pool.
+	//+optional
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of machines of the pool, which are ready.
+	//+optional
+	ReadyReplicas int32 `json:"readyReplicas"`
+
+	// InfrastructureMachineKind is the kind of the infrastructure resources, which back
+	// the machines of the pool.
+	//+optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+
+	// PendingMachineName is the name of the machine, which is being created for the pool. It is kept until
+	// the machine shows up in the cache, so that a stale cache doesn't lead to a second machine.
+	//+optional
+	PendingMachineName string `json:"pendingMachineName,omitempty"`
+
+	// Capacity is the amount of resources, which a node of the pool provides. It is derived from the template
+	// of the pool like the capacity of an IonosCloudMachineTemplate.
+	//+optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// NodeInfo contains information about the nodes of the pool.
+	//+optional
+	NodeInfo *NodeInfo `json:"nodeInfo,omitempty"`
+
+	// Conditions defines current service state of the IonosCloudMachinePool.
+	//+optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=ionoscloudmachinepools,scope=Namespaced,categories=cluster-api;ionoscloud,shortName=icmp
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+//+kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Number of machines in the pool"
+//+kubebuilder:printcolumn:name="Ready Replicas",type="integer",JSONPath=".status.readyReplicas",description="Number of ready machines in the pool"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine pool is ready"
+
+// IonosCloudMachinePool is the Schema for the ionoscloudmachinepools API.
+type IonosCloudMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IonosCloudMachinePoolSpec   `json:"spec,omitempty"`
+	Status IonosCloudMachinePoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IonosCloudMachinePoolList contains a list of IonosCloudMachinePool.
+type IonosCloudMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IonosCloudMachinePool `json:"items"`
+}
+
+// GetConditions returns the observations of the operational state of the IonosCloudMachinePool resource.
+func (m *IonosCloudMachinePool) GetConditions() clusterv1.Conditions {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the IonosCloudMachinePool to the predescribed clusterv1.Conditions.
+func (m *IonosCloudMachinePool) SetConditions(conditions clusterv1.Conditions) {
+	m.Status.Conditions = conditions
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IonosCloudMachinePool{}, &IonosCloudMachinePoolList{})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// IonosCloudMachineTemplateSpec defines the desired state of IonosCloudMachineTemplate.
+type IonosCloudMachineTemplateSpec struct {
+	// Template is the IonosCloudMachineTemplateResource for the IonosCloudMachineTemplate.
+	Template IonosCloudMachineTemplateResource `json:"template"`
+}
+
+// IonosCloudMachineTemplateStatus defines the observed state of IonosCloudMachineTemplate.
+// It implements the scale from zero contract of the cluster autoscaler, which uses the status
+// to build a node for a node group without machines.
+type IonosCloudMachineTemplateStatus struct {
+	// Capacity is the amount of resources, which a node created from the template provides.
+	// It contains the CPU and memory of the servers and the size of the boot volume as ephemeral storage.
+	// It is empty for CUBE servers, whose resources are defined by their template in IONOS Cloud.
+	//+optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+
+	// NodeInfo contains information about the nodes, which are created from the template.
+	//+optional
+	NodeInfo *NodeInfo `json:"nodeInfo,omitempty"`
+}
+
+// Architecture is the CPU architecture of a node.
+type Architecture string
+
+const (
+	// ArchitectureAmd64 is the architecture of servers with x86-64 CPUs.
+	ArchitectureAmd64 Architecture = "amd64"
+)
+
+// OperatingSystem is the operating system of a node.
+type OperatingSystem string
+
+const (
+	// OperatingSystemLinux is the Linux operating system.
+	OperatingSystemLinux OperatingSystem = "linux"
+)
+
+// NodeInfo contains information about the nodes, which are created from an IonosCloudMachineTemplate.
+type NodeInfo struct {
+	// Architecture is the CPU architecture of the nodes.
+	//+optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// OperatingSystem is the operating system of the nodes.
+	//+optional
+	OperatingSystem OperatingSystem `json:"operatingSystem,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:subresource:status
+
+// IonosCloudMachineTemplate is the Schema for the ionoscloudmachinetemplates API.
+type IonosCloudMachineTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IonosCloudMachineTemplateSpec   `json:"spec,omitempty"`
+	Status IonosCloudMachineTemplateStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// IonosCloudMachineTemplateList contains a list of IonosCloudMachineTemplate.
+type IonosCloudMachineTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IonosCloudMachineTemplate `json:"items"`
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(self.spec.privateIP) || !has(self.spec.privateIP.address)",message="privateIP.address can't be set in a template, as every machine would get the same address"
+//+kubebuilder:validation:XValidation:rule="!has(self.spec.importExisting)",message="importExisting can't be set in a template, as every machine would adopt the same server"
+
+// IonosCloudMachineTemplateResource defines the spec and metadata for IonosCloudMachineTemplate supported by capi.
+type IonosCloudMachineTemplateResource struct {
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	//+optional
+	ObjectMeta clusterv1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec is the IonosCloudMachineSpec for the IonosCloudMachineTemplate.
+	Spec IonosCloudMachineSpec `json:"spec"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IonosCloudMachineTemplate{}, &IonosCloudMachineTemplateList{})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProvisioningRequest is a definition of a provisioning request
+// in the IONOS Cloud.
+type ProvisioningRequest struct {
+	// Method is the request method
+	Method string `json:"method"`
+
+	// RequestPath is the sub path for the request URL
+	RequestPath string `json:"requestPath"`
+
+	// RequestStatus is the status of the request in the queue.
+	//+kubebuilder:validation:Enum=QUEUED;RUNNING;DONE;FAILED
+	//+optional
+	State string `json:"state,omitempty"`
+
+	// Targets are the resources affected by the request, as reported by the IONOS Cloud API.
+	//+optional
+	Targets []ProvisioningRequestTarget `json:"targets,omitempty"`
+}
+
+// ProvisioningRequestTarget is a resource affected by a provisioning request.
+type ProvisioningRequestTarget struct {
+	// Type is the type of the resource, e.g. server or lan.
+	Type string `json:"type"`
+
+	// ID is the ID of the resource.
+	ID string `json:"id"`
+}
+
+// ProvisioningRequestReference references a provisioning request, which was made in the IONOS Cloud.
+// The ID can be used to look up the request in the Cloud API or when contacting the IONOS Cloud support.
+type ProvisioningRequestReference struct {
+	// ID is the ID of the request.
+	ID string `json:"id"`
+
+	// Method is the request method.
+	Method string `json:"method"`
+
+	// State is the last known state of the request.
+	//+kubebuilder:validation:Enum=QUEUED;RUNNING;DONE;FAILED
+	//+optional
+	State string `json:"state,omitempty"`
+
+	// CreationTimestamp is the time, at which the request was recorded.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// Abandoned is set, if the request was stuck and the controller stopped waiting for it.
+	// The request is ignored when looking up pending requests, so that the operation can be issued again.
+	//+optional
+	Abandoned bool `json:"abandoned,omitempty"`
+}
+
+// ID returns the ID of the request, which is part of its request path, e.g. /requests/<id>/status.
+// If the path doesn't contain an ID, an empty string is returned.
+func (r *ProvisioningRequest) ID() string {
+	_, after, found := strings.Cut(r.RequestPath, "/requests/")
+	if !found {
+		return ""
+	}
+	id, _, _ := strings.Cut(after, "/")
+	return id
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// SetupWebhookWithManager registers the conversion webhook of the IonosCloudCluster with the manager.
+func (i *IonosCloudCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(i).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook of the IonosCloudMachine with the manager.
+func (m *IonosCloudMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(m).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook of the IonosCloudMachineTemplate with the manager.
+func (t *IonosCloudMachineTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(t).Complete()
+}
+
+// SetupWebhookWithManager registers the conversion webhook of the IonosCloudMachinePool with the manager.
+func (m *IonosCloudMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(m).Complete()
+}
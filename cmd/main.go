@@ -19,28 +19,62 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"time"
 
+	sdk "github.com/ionos-cloud/sdk-go/v6"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/flags"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1alpha1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/feature"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/controller"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/locker"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/webhooks"
 )
 
 var (
 	scheme               = runtime.NewScheme()
 	setupLog             = ctrl.Log.WithName("setup")
 	healthProbeAddr      string
+	webhookPort          int
+	webhookCertDir       string
 	enableLeaderElection bool
+	serverPollInterval   time.Duration
+	apiRateLimitOptions  icc.RateLimitOptions
+	apiEndpoint          icc.Endpoint
+	apiCABundleFile      string
+	enableGC             bool
+	enableNodeProviderID bool
+	enableAPIValidation  bool
+	dryRun               bool
+	auditLog             controller.AuditLog
+	endpointProbeTimeout time.Duration
+	tracingOptions       tracing.Options
+	gcInterval           time.Duration
+	timeouts             controller.Timeouts
+	datacenterCacheTTL   time.Duration
+	clusterConcurrency   int
+	machineConcurrency   int
 	diagnosticOptions    = flags.DiagnosticsOptions{}
 )
 
@@ -48,6 +82,9 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(expv1.AddToScheme(scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme))
+	utilruntime.Must(infrav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(infrav1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -67,6 +104,10 @@ func main() {
 		HealthProbeBindAddress: healthProbeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "15f3d3ca.cluster.x-k8s.io",
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Port:    webhookPort,
+			CertDir: webhookCertDir,
+		}),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -85,21 +126,88 @@ func main() {
 	}
 
 	ctx := ctrl.SetupSignalHandler()
+	shutdownTracing, err := tracing.Setup(ctx, tracingOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
+	rateLimiter := icc.NewRateLimiter(apiRateLimitOptions)
+	if err := loadAPIEndpoint(); err != nil {
+		setupLog.Error(err, "invalid IONOS Cloud API endpoint")
+		os.Exit(1)
+	}
 
+	// The clients for the Cloud API are shared, so that every cluster has a single client.
+	clientCache := &controller.ClientCache{}
 	if err = (&controller.IonosCloudClusterReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		RateLimiter: rateLimiter,
+		APIEndpoint: apiEndpoint,
+		DryRun:      dryRun,
+		AuditLog:    auditLog,
+		Recorder:    mgr.GetEventRecorderFor("ionoscloudcluster-controller"),
+		ClientCache: clientCache,
+
+		ControlPlaneEndpointProbeTimeout: endpointProbeTimeout,
+		MaxConcurrentReconciles:          clusterConcurrency,
+		Timeouts:                         timeouts,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IonosCloudCluster")
 		os.Exit(1)
 	}
 	if err = (&controller.IonosCloudMachineReconciler{
+		Client:                  mgr.GetClient(),
+		Scheme:                  mgr.GetScheme(),
+		RateLimiter:             rateLimiter,
+		APIEndpoint:             apiEndpoint,
+		ServerStatePollInterval: serverPollInterval,
+		DryRun:                  dryRun,
+		AuditLog:                auditLog,
+		Recorder:                mgr.GetEventRecorderFor("ionoscloudmachine-controller"),
+		MaxConcurrentReconciles: machineConcurrency,
+		DatacenterLocks:         &locker.Locker{},
+		DatacenterCache:         newDatacenterCache(),
+		TemplateCache:           &cloud.TemplateCache{},
+		ClientCache:             clientCache,
+		WorkloadClusterClients:  setupWorkloadClusterClients(ctx, mgr),
+		Timeouts:                timeouts,
+	}).SetupWithManager(ctx, mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IonosCloudMachine")
+		os.Exit(1)
+	}
+	if err = (&controller.IonosCloudMachineTemplateReconciler{
 		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "IonosCloudMachine")
+		setupLog.Error(err, "unable to create controller", "controller", "IonosCloudMachineTemplate")
 		os.Exit(1)
 	}
+	if enableGC {
+		if err = (&controller.GarbageCollectorReconciler{
+			Client:      mgr.GetClient(),
+			RateLimiter: rateLimiter,
+			APIEndpoint: apiEndpoint,
+			ClientCache: clientCache,
+			Recorder:    mgr.GetEventRecorderFor("garbagecollector-controller"),
+			Interval:    gcInterval,
+			DryRun:      dryRun,
+			AuditLog:    auditLog,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GarbageCollector")
+			os.Exit(1)
+		}
+	}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		if err = (&controller.IonosCloudMachinePoolReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(ctx, mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IonosCloudMachinePool")
+			os.Exit(1)
+		}
+	}
+	setupWebhooks(mgr, rateLimiter)
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -112,12 +220,92 @@ func main() {
 	}
 
 	setupLog.Info("Starting manager")
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	// The context is already canceled, the remaining spans are flushed with a fresh one.
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		setupLog.Error(shutdownErr, "unable to flush traces")
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
+// setupWorkloadClusterClients returns the clients for the workload clusters, which are used to set
+// the provider ID of Nodes and to detect their registration. It returns nil, if setting the provider ID is disabled.
+func setupWorkloadClusterClients(ctx context.Context, mgr ctrl.Manager) controller.WorkloadClusterClients {
+	if !enableNodeProviderID {
+		return nil
+	}
+
+	log := ctrl.Log.WithName("remote").WithName("ClusterCacheTracker")
+	tracker, err := remote.NewClusterCacheTracker(mgr, remote.ClusterCacheTrackerOptions{
+		ControllerName: "ionoscloudmachine-controller",
+		Log:            &log,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create cluster cache tracker")
+		os.Exit(1)
+	}
+	if err := (&remote.ClusterCacheReconciler{
+		Client:  mgr.GetClient(),
+		Tracker: tracker,
+	}).SetupWithManager(ctx, mgr, ctrlcontroller.Options{}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterCacheReconciler")
+		os.Exit(1)
+	}
+	return tracker
+}
+
+// newDatacenterCache returns the cache of the data centers, which is shared by all machines,
+// or nil if the cache is disabled.
+func newDatacenterCache() *cloud.DatacenterCache {
+	if datacenterCacheTTL <= 0 {
+		return nil
+	}
+	return &cloud.DatacenterCache{TTL: datacenterCacheTTL}
+}
+
+// setupWebhooks registers the conversion webhooks of the hub API version,
+// the validating webhooks, which check machine specs against the Cloud API,
+// and the defaulting webhooks of machine specs.
+func setupWebhooks(mgr ctrl.Manager, rateLimiter *icc.RateLimiter) {
+	if err := (&infrav1.IonosCloudCluster{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "IonosCloudCluster")
+		os.Exit(1)
+	}
+	if err := (&infrav1.IonosCloudMachine{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "IonosCloudMachine")
+		os.Exit(1)
+	}
+	if err := (&infrav1.IonosCloudMachineTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "IonosCloudMachineTemplate")
+		os.Exit(1)
+	}
+	if err := (&infrav1.IonosCloudMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "IonosCloudMachinePool")
+		os.Exit(1)
+	}
+	if err := (&webhooks.MachineValidator{
+		Client:      mgr.GetClient(),
+		RateLimiter: rateLimiter,
+		APIEndpoint: apiEndpoint,
+		Enabled:     enableAPIValidation,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MachineValidator")
+		os.Exit(1)
+	}
+	if err := (&webhooks.MachineDefaulter{
+		Client:           mgr.GetClient(),
+		RateLimiter:      rateLimiter,
+		APIEndpoint:      apiEndpoint,
+		DefaultCPUFamily: enableAPIValidation,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MachineDefaulter")
+		os.Exit(1)
+	}
+}
+
 // initFlags parses the command line flags.
 func initFlags() {
 	klog.InitFlags(nil)
@@ -125,7 +313,107 @@ func initFlags() {
 	flags.AddDiagnosticsOptions(pflag.CommandLine, &diagnosticOptions)
 	pflag.StringVar(&healthProbeAddr, "health-probe-bind-address", ":8081",
 		"The address the probe endpoint binds to.")
+	pflag.IntVar(&webhookPort, "webhook-port", webhook.DefaultPort,
+		"The port the webhook server listens on.")
+	pflag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs/",
+		"The directory containing the certificate and key of the webhook server.")
 	pflag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	feature.MutableGates.AddFlag(pflag.CommandLine)
+	pflag.DurationVar(&serverPollInterval, "server-state-poll-interval", 5*time.Minute,
+		"The interval in which the state of the servers of provisioned machines is checked, "+
+			"e.g. to detect crashed servers. Set to 0 to disable polling.")
+	pflag.DurationVar(&timeouts.RequestPollInterval, "request-poll-interval", 20*time.Second,
+		"The interval in which the state of pending requests to the Cloud API is checked. "+
+			"It can be overridden per cluster in spec.timeouts of the IonosCloudCluster.")
+	pflag.DurationVar(&timeouts.MachineProvisioning, "machine-provisioning-timeout", 0,
+		"The time after which machines, which are still not provisioned, are marked as failed. "+
+			"It can be overridden per cluster in spec.timeouts of the IonosCloudCluster. Set to 0 to disable it.")
+	pflag.DurationVar(&timeouts.MachineDeletion, "machine-deletion-timeout", 0,
+		"The time after which the deletion of machines is reported as timed out in their "+
+			string(infrav1.ServerDeletedCondition)+" condition. "+
+			"It can be overridden per cluster in spec.timeouts of the IonosCloudCluster. Set to 0 to disable it.")
+	pflag.DurationVar(&timeouts.BootstrapExecution, "bootstrap-execution-timeout", 20*time.Minute,
+		"The time after which machines, whose Node has not registered in the workload cluster since their server "+
+			"became available, are reported as timed out in their "+
+			string(infrav1.BootstrapExecutedSuccessfullyCondition)+" condition. Requires --enable-node-provider-id. "+
+			"It can be overridden per cluster in spec.timeouts of the IonosCloudCluster. Set to 0 to disable it.")
+	pflag.DurationVar(&timeouts.StuckRequest, "stuck-request-timeout", 0,
+		"The time after which requests of machines, which are still queued or running, are considered to be stuck. "+
+			"Stuck PATCH and DELETE requests are issued again, machines with other stuck requests are marked as failed. "+
+			"It can be overridden per cluster in spec.timeouts of the IonosCloudCluster. Set to 0 to disable it.")
+	pflag.DurationVar(&datacenterCacheTTL, "datacenter-cache-ttl", cloud.DefaultDatacenterCacheTTL,
+		"The time for which the location, CPU families and features of data centers are cached "+
+			"for the creation of servers. Set to 0 to disable the cache.")
+	pflag.BoolVar(&enableGC, "enable-garbage-collection", false,
+		"Periodically delete servers and volumes, which are labeled with the name of a cluster, "+
+			"but don't belong to any of its machines anymore.")
+	pflag.DurationVar(&gcInterval, "garbage-collection-interval", time.Hour,
+		"The interval in which each cluster is checked for orphaned resources.")
+	pflag.BoolVar(&enableNodeProviderID, "enable-node-provider-id", false,
+		"Set the provider ID of Nodes in the workload clusters, if neither the kubelet nor a cloud controller manager "+
+			"has set it, and report whether the Nodes of machines have registered after their bootstrap. "+
+			"The kubeconfig secrets of the clusters are used to access them.")
+	pflag.BoolVar(&enableAPIValidation, "enable-api-validation", false,
+		"Validate the data center, image and CPU family of machines against the Cloud API on admission, "+
+			"and warn about machines, whose data center is not in the location of the cluster.")
+	pflag.BoolVar(&dryRun, "dry-run", false,
+		"Skip all mutating requests to the Cloud API and report them as events and conditions instead. "+
+			"Single clusters can be reconciled in dry-run mode with the "+infrav1.DryRunAnnotation+" annotation.")
+	pflag.BoolVar(&auditLog.Enabled, "enable-audit-log", false,
+		"Log every create, update and delete request to the Cloud API with the resource, the requesting object, "+
+			"the ID of the request and its outcome under the logger name \"audit\".")
+	pflag.BoolVar(&auditLog.Events, "audit-log-events", false,
+		"Additionally record the requests of the audit log as "+icc.AuditEventReason+" events on the "+
+			"IonosCloudCluster. Requires --enable-audit-log.")
+	pflag.DurationVar(&endpointProbeTimeout, "control-plane-endpoint-probe-timeout", 5*time.Second,
+		"The timeout for probing the control plane endpoint of the clusters, which is reported in the "+
+			string(infrav1.ControlPlaneEndpointReachableCondition)+" condition. Set to 0 to disable probing.")
+	pflag.BoolVar(&tracingOptions.Enabled, "enable-tracing", false,
+		"Export OpenTelemetry traces of the reconciliation and the requests to the IONOS Cloud API via OTLP.")
+	pflag.StringVar(&tracingOptions.Endpoint, "tracing-endpoint", "",
+		"The address of the OTLP gRPC receiver. Defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable "+
+			"or localhost:4317.")
+	pflag.BoolVar(&tracingOptions.Insecure, "tracing-insecure", false,
+		"Connect to the OTLP receiver without TLS.")
+	pflag.Float64Var(&tracingOptions.SamplingRatio, "tracing-sampling-ratio", 1,
+		"The fraction of reconciliations, which are traced.")
+	pflag.Float64Var(&apiRateLimitOptions.QPS, "ionos-api-qps", 10,
+		"The maximum number of requests per second sent to the IONOS Cloud API. Set to 0 to disable rate limiting.")
+	pflag.IntVar(&apiRateLimitOptions.Burst, "ionos-api-burst", 20,
+		"The maximum number of requests sent to the IONOS Cloud API at once.")
+	pflag.IntVar(&apiRateLimitOptions.MaxRetries, "ionos-api-max-retries", 5,
+		"The maximum number of retries of a request, which was throttled or failed temporarily.")
+	pflag.IntVar(&apiRateLimitOptions.RetryBudget, "ionos-api-retry-budget", 60,
+		"The maximum number of retries per minute, which is shared by all requests. Set to 0 for no limit.")
+	pflag.StringVar(&apiEndpoint.URL, "ionos-api-url", os.Getenv(sdk.IonosApiUrlEnvVar),
+		"The URL of the IONOS Cloud API, e.g. of a testing environment or an API proxy. Defaults to the "+
+			sdk.IonosApiUrlEnvVar+" environment variable or the public Cloud API. "+
+			"The apiURL of a credentials secret takes precedence.")
+	pflag.StringVar(&apiCABundleFile, "ionos-api-ca-bundle", "",
+		"The path to a PEM file with the CA certificates, which are trusted for the IONOS Cloud API "+
+			"instead of the system CAs. The caBundle of a credentials secret takes precedence.")
+	pflag.BoolVar(&apiEndpoint.InsecureSkipVerify, "ionos-api-insecure-skip-verify", false,
+		"Skip the verification of the TLS certificate of the IONOS Cloud API. Only use this for testing environments.")
+	pflag.StringVar(&apiEndpoint.ProxyURL, "ionos-api-proxy-url", "",
+		"The URL of an HTTP proxy for the requests to the IONOS Cloud API. Defaults to the HTTPS_PROXY "+
+			"environment variable. The proxyURL of a credentials secret takes precedence.")
+	pflag.IntVar(&clusterConcurrency, "ionoscloudcluster-concurrency", 1,
+		"The number of IonosCloudClusters, which are reconciled at the same time.")
+	pflag.IntVar(&machineConcurrency, "ionoscloudmachine-concurrency", 1,
+		"The number of IonosCloudMachines, which are reconciled at the same time. "+
+			"Machines in the same data center are reconciled one after another.")
+}
+
+// loadAPIEndpoint reads the CA bundle of the IONOS Cloud API endpoint and validates the endpoint.
+func loadAPIEndpoint() error {
+	if apiCABundleFile != "" {
+		caBundle, err := os.ReadFile(apiCABundleFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the CA bundle: %w", err)
+		}
+		apiEndpoint.CABundle = caBundle
+	}
+	return apiEndpoint.Validate()
 }
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package feature contains the feature gates of experimental features of the provider.
+// The gates are set with the --feature-gates flag of the manager.
+package feature
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// LoadBalancer enables the Network Load Balancer in front of the control plane machines,
+	// which is configured by spec.loadBalancer of the IonosCloudCluster.
+	LoadBalancer featuregate.Feature = "LoadBalancer"
+
+	// MachinePool enables the IonosCloudMachinePool controller.
+	// It requires the MachinePool feature of Cluster API to be enabled.
+	MachinePool featuregate.Feature = "MachinePool"
+
+	// IPAM enables claiming the IP addresses of NICs from the IPAM pools, which are referenced
+	// by the ipv4PoolRef of a machine spec. It requires the IPAM CRDs of Cluster API to be installed.
+	IPAM featuregate.Feature = "IPAM"
+)
+
+var (
+	// MutableGates is a mutable version of Gates.
+	// Only top-level commands and tests should use it.
+	MutableGates featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+	// Gates is the read-only set of feature gates, which is used by the controllers.
+	Gates featuregate.FeatureGate = MutableGates
+)
+
+func init() {
+	runtime.Must(MutableGates.Add(defaultFeatureGates))
+}
+
+// defaultFeatureGates contains all known feature gates and their defaults.
+// Experimental features are disabled by default.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	LoadBalancer: {Default: false, PreRelease: featuregate.Alpha},
+	MachinePool:  {Default: false, PreRelease: featuregate.Alpha},
+	IPAM:         {Default: false, PreRelease: featuregate.Alpha},
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cidata builds ISO images for the NoCloud data source of cloud-init. Attached as a CD-ROM,
+// such an image provides the user data to a server, if it is too large to be passed via the Cloud API.
+package cidata
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	// VolumeLabel is the label, which cloud-init looks for to detect the NoCloud data source.
+	VolumeLabel = "CIDATA"
+
+	// UserDataFile and MetaDataFile are the names of the files, which cloud-init reads from the image.
+	// Linux shows the ISO 9660 identifiers in lower case and without the version suffix.
+	UserDataFile = "USER-DATA.;1"
+	MetaDataFile = "META-DATA.;1"
+
+	sectorSize = 2048
+
+	// The layout of the image: 16 empty sectors for the system area, followed by the primary volume
+	// descriptor, the terminator of the volume descriptor set, both path tables and the root directory.
+	primaryVolumeDescriptorSector = 16
+	terminatorSector              = 17
+	lPathTableSector              = 18
+	mPathTableSector              = 19
+	rootDirectorySector           = 20
+	firstFileSector               = 21
+
+	pathTableSize = 10
+)
+
+type file struct {
+	name    string
+	content []byte
+	sector  uint32
+}
+
+// NewImage returns an ISO 9660 image labeled cidata, which contains the user data and the meta data.
+func NewImage(userData, metaData []byte) []byte {
+	// The records of a directory must be sorted by their identifiers.
+	files := []*file{
+		{name: MetaDataFile, content: metaData},
+		{name: UserDataFile, content: userData},
+	}
+	next := uint32(firstFileSector)
+	for _, f := range files {
+		f.sector = next
+		next += sectors(len(f.content))
+	}
+
+	image := make([]byte, int(next)*sectorSize)
+	writePrimaryVolumeDescriptor(sector(image, primaryVolumeDescriptorSector), next)
+	writeTerminator(sector(image, terminatorSector))
+	writePathTable(sector(image, lPathTableSector), binary.LittleEndian)
+	writePathTable(sector(image, mPathTableSector), binary.BigEndian)
+
+	root := sector(image, rootDirectorySector)
+	offset := writeDirectoryRecord(root, []byte{0x00}, rootDirectorySector, sectorSize, true)
+	offset += writeDirectoryRecord(root[offset:], []byte{0x01}, rootDirectorySector, sectorSize, true)
+	for _, f := range files {
+		offset += writeDirectoryRecord(root[offset:], []byte(f.name), f.sector, uint32(len(f.content)), false)
+		copy(image[int(f.sector)*sectorSize:], f.content)
+	}
+
+	return image
+}
+
+func writePrimaryVolumeDescriptor(pvd []byte, volumeSectors uint32) {
+	pvd[0] = 1
+	copy(pvd[1:6], "CD001")
+	pvd[6] = 1
+	copy(pvd[8:40], padded("", 32))
+	copy(pvd[40:72], padded(VolumeLabel, 32))
+	putBothEndian32(pvd[80:88], volumeSectors)
+	putBothEndian16(pvd[120:124], 1)
+	putBothEndian16(pvd[124:128], 1)
+	putBothEndian16(pvd[128:132], sectorSize)
+	putBothEndian32(pvd[132:140], pathTableSize)
+	binary.LittleEndian.PutUint32(pvd[140:144], lPathTableSector)
+	binary.BigEndian.PutUint32(pvd[148:152], mPathTableSector)
+	writeDirectoryRecord(pvd[156:190], []byte{0x00}, rootDirectorySector, sectorSize, true)
+	// Volume set, publisher, data preparer and application identifiers.
+	copy(pvd[190:702], padded("", 512))
+	// Copyright, abstract and bibliographic file identifiers.
+	copy(pvd[702:813], padded("", 111))
+	// Creation, modification, expiration and effective dates are not specified.
+	for _, offset := range []int{813, 830, 847, 864} {
+		copy(pvd[offset:offset+16], "0000000000000000")
+	}
+	pvd[881] = 1
+}
+
+func writeTerminator(terminator []byte) {
+	terminator[0] = 255
+	copy(terminator[1:6], "CD001")
+	terminator[6] = 1
+}
+
+// writePathTable writes a path table, which only contains the root directory.
+func writePathTable(table []byte, order binary.ByteOrder) {
+	table[0] = 1
+	order.PutUint32(table[2:6], rootDirectorySector)
+	order.PutUint16(table[6:8], 1)
+}
+
+// writeDirectoryRecord writes a directory record and returns its length.
+func writeDirectoryRecord(record, identifier []byte, extent, size uint32, directory bool) int {
+	length := 33 + len(identifier)
+	if length%2 != 0 {
+		length++
+	}
+	record[0] = byte(length)
+	putBothEndian32(record[2:10], extent)
+	putBothEndian32(record[10:18], size)
+	if directory {
+		record[25] = 2
+	}
+	putBothEndian16(record[28:32], 1)
+	record[32] = byte(len(identifier))
+	copy(record[33:], identifier)
+	return length
+}
+
+func sector(image []byte, n int) []byte {
+	return image[n*sectorSize : (n+1)*sectorSize]
+}
+
+func sectors(size int) uint32 {
+	return uint32(max(1, (size+sectorSize-1)/sectorSize))
+}
+
+func padded(s string, length int) []byte {
+	return append([]byte(s), bytes.Repeat([]byte{' '}, length-len(s))...)
+}
+
+func putBothEndian16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b[0:2], v)
+	binary.BigEndian.PutUint16(b[2:4], v)
+}
+
+func putBothEndian32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b[0:4], v)
+	binary.BigEndian.PutUint32(b[4:8], v)
+}
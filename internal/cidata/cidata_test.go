@@ -0,0 +1,72 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImage(t *testing.T) {
+	userData := []byte("#cloud-config\n" + strings.Repeat("# padding\n", 500))
+	metaData := []byte("instance-id: 9f2c\nlocal-hostname: machine\n")
+
+	image := NewImage(userData, metaData)
+	require.Zero(t, len(image)%sectorSize)
+
+	pvd := sector(image, primaryVolumeDescriptorSector)
+	require.Equal(t, "CD001", string(pvd[1:6]))
+	require.Equal(t, VolumeLabel, strings.TrimRight(string(pvd[40:72]), " "))
+	require.Equal(t, uint32(len(image)/sectorSize), binary.LittleEndian.Uint32(pvd[80:84]))
+	require.Equal(t, uint32(len(image)/sectorSize), binary.BigEndian.Uint32(pvd[84:88]))
+	require.Equal(t, byte(255), sector(image, terminatorSector)[0])
+
+	rootExtent := binary.LittleEndian.Uint32(pvd[156+2 : 156+6])
+	files := readDirectory(t, image, rootExtent)
+	require.Equal(t, map[string][]byte{
+		"\x00":       nil,
+		"\x01":       nil,
+		UserDataFile: userData,
+		MetaDataFile: metaData,
+	}, files)
+}
+
+// readDirectory returns the contents of the files in the directory. Directories have no content.
+func readDirectory(t *testing.T, image []byte, extent uint32) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	dir := sector(image, int(extent))
+	for offset := 0; offset < len(dir) && dir[offset] != 0; offset += int(dir[offset]) {
+		record := dir[offset : offset+int(dir[offset])]
+		name := string(record[33 : 33+int(record[32])])
+		if record[25]&2 != 0 {
+			require.Equal(t, extent, binary.LittleEndian.Uint32(record[2:6]))
+			files[name] = nil
+			continue
+		}
+
+		start := int(binary.LittleEndian.Uint32(record[2:6])) * sectorSize
+		size := int(binary.LittleEndian.Uint32(record[10:14]))
+		require.Equal(t, uint32(size), binary.BigEndian.Uint32(record[14:18]))
+		files[name] = bytes.Clone(image[start : start+size])
+	}
+	return files
+}
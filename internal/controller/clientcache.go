@@ -0,0 +1,91 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+// ClientCache keeps the client for the Cloud API of every IonosCloudCluster across reconciliations, so that
+// the connections to the Cloud API are reused. A client is created again, once the credentials secret of the
+// cluster has changed, e.g. because its token was rotated, or once the dry-run mode of the cluster was toggled.
+// A single cache is shared by all reconcilers, so that every cluster has one client and one connection pool, and
+// Forget removes the client for all of them. The client is created with the options of the reconciler, which
+// needs it first; the options only differ in the event recorder of the audit log.
+// The zero value is ready to use.
+type ClientCache struct {
+	mu      sync.Mutex
+	entries map[types.NamespacedName]clientCacheEntry
+}
+
+type clientCacheEntry struct {
+	// version identifies the cluster, its credentials secret and the settings, with which the client was created.
+	version string
+	client  ionoscloud.Client
+}
+
+// get returns the cached client of the cluster, or creates it with newClient, if it is not cached or was created
+// with another version of the credentials secret. A client, which cannot be created, is removed from the cache,
+// so that the outdated credentials are not used anymore. If the cache is nil, newClient is always called.
+func (c *ClientCache) get(
+	cluster *infrav1.IonosCloudCluster, secret *corev1.Secret, dryRun bool,
+	newClient func() (ionoscloud.Client, error),
+) (ionoscloud.Client, error) {
+	if c == nil {
+		return newClient()
+	}
+
+	key := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	version := fmt.Sprintf("%s/%s/%s/%t", cluster.UID, secret.UID, secret.ResourceVersion, dryRun)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && entry.version == version {
+		return entry.client, nil
+	}
+
+	ionosClient, err := newClient()
+	if err != nil {
+		delete(c.entries, key)
+		return nil, err
+	}
+	if c.entries == nil {
+		c.entries = make(map[types.NamespacedName]clientCacheEntry)
+	}
+	c.entries[key] = clientCacheEntry{version: version, client: ionosClient}
+	return ionosClient, nil
+}
+
+// Forget removes the client of the cluster from the cache, e.g. once the cluster has been deleted.
+// It does nothing if the cache is nil.
+func (c *ClientCache) Forget(cluster *infrav1.IonosCloudCluster) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	ionosfake "github.com/ionos-cloud/cluster-api-provider-ionoscloud/test/fake"
+)
+
+func TestClientCache(t *testing.T) {
+	cluster := &infrav1.IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cluster", UID: "cluster-uid"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "credentials", UID: "secret-uid", ResourceVersion: "1"},
+	}
+
+	created := 0
+	newClient := func() (ionoscloud.Client, error) {
+		created++
+		return ionosfake.NewClient(), nil
+	}
+	failingClient := func() (ionoscloud.Client, error) {
+		created++
+		return nil, errors.New("no credentials")
+	}
+
+	cache := &ClientCache{}
+	first, err := cache.get(cluster, secret, false, newClient)
+	require.NoError(t, err)
+	second, err := cache.get(cluster, secret, false, newClient)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+	require.Equal(t, 1, created)
+
+	_, err = cache.get(cluster, secret, true, newClient)
+	require.NoError(t, err)
+	require.Equal(t, 2, created, "toggling the dry-run mode must create a new client")
+
+	secret.ResourceVersion = "2"
+	rotated, err := cache.get(cluster, secret, true, newClient)
+	require.NoError(t, err)
+	require.NotSame(t, first, rotated)
+	require.Equal(t, 3, created, "a changed secret must create a new client")
+
+	secret.ResourceVersion = "3"
+	_, err = cache.get(cluster, secret, true, failingClient)
+	require.Error(t, err)
+	require.NotContains(t, cache.entries, types.NamespacedName{Namespace: "default", Name: "cluster"}, "a failed client must not be kept")
+
+	_, err = cache.get(cluster, secret, true, newClient)
+	require.NoError(t, err)
+	cache.Forget(cluster)
+	require.Empty(t, cache.entries)
+	require.Equal(t, 5, created)
+}
+
+func TestNilClientCache(t *testing.T) {
+	cluster := &infrav1.IonosCloudCluster{}
+	secret := &corev1.Secret{}
+
+	var cache *ClientCache
+	created := 0
+	for range 2 {
+		_, err := cache.get(cluster, secret, false, func() (ionoscloud.Client, error) {
+			created++
+			return ionosfake.NewClient(), nil
+		})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, created)
+	require.NotPanics(t, func() { cache.Forget(cluster) })
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// defaultAPIServerPort is probed if the control plane endpoint doesn't specify a port.
+const defaultAPIServerPort = 6443
+
+// reconcileControlPlaneEndpointReachable probes the control plane endpoint of the cluster and reports
+// the result in the ControlPlaneEndpointReachable condition. It returns true if the endpoint
+// is not reachable yet and should be probed again.
+//
+// The probe doesn't block the cluster from becoming ready, as the first control plane machine is only
+// created afterward.
+func (r *IonosCloudClusterReconciler) reconcileControlPlaneEndpointReachable(
+	ctx context.Context, clusterScope *scope.Cluster,
+) bool {
+	endpoint := clusterScope.IonosCluster.Spec.ControlPlaneEndpoint
+	if r.ControlPlaneEndpointProbeTimeout <= 0 || endpoint.Host == "" {
+		return false
+	}
+
+	err := probeControlPlaneEndpoint(ctx, r.Client, clusterScope.Cluster, endpoint, r.ControlPlaneEndpointProbeTimeout)
+	if err == nil {
+		conditions.MarkTrue(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition)
+		return false
+	}
+
+	ctrl.LoggerFrom(ctx).V(4).Info("Control plane endpoint is not reachable", "error", err.Error())
+	if conditions.IsTrue(clusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition) {
+		conditions.MarkFalse(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition,
+			infrav1.ControlPlaneEndpointUnreachableReason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	} else {
+		conditions.MarkFalse(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition,
+			infrav1.WaitingForControlPlaneReason, clusterv1.ConditionSeverityInfo, "%s", err.Error())
+	}
+	return true
+}
+
+// probeControlPlaneEndpoint checks whether the API server responds on the given endpoint.
+// If the CA of the cluster is available, an HTTPS request is sent to the readiness endpoint of the API server,
+// which must be answered with any HTTP status. Otherwise, only a TCP connection is established.
+func probeControlPlaneEndpoint(
+	ctx context.Context, c client.Reader, cluster *clusterv1.Cluster, endpoint clusterv1.APIEndpoint, timeout time.Duration,
+) error {
+	port := int(endpoint.Port)
+	if port == 0 {
+		port = defaultAPIServerPort
+	}
+	addr := net.JoinHostPort(endpoint.Host, strconv.Itoa(port))
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	caSecret, err := secret.GetFromNamespacedName(ctx, c, client.ObjectKeyFromObject(cluster), secret.ClusterCA)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("could not get CA of the cluster: %w", err)
+		}
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return fmt.Errorf("could not connect to %s: %w", addr, err)
+		}
+		return conn.Close()
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caSecret.Data[secret.TLSCrtDataName]) {
+		return errors.New("CA of the cluster doesn't contain a valid certificate")
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}}
+	defer httpClient.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+addr+"/readyz", http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API server at %s is not reachable: %w", addr, err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
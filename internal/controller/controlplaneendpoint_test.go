@@ -0,0 +1,137 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+func newEndpointProbeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func apiEndpoint(t *testing.T, addr string) clusterv1.APIEndpoint {
+	t.Helper()
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return clusterv1.APIEndpoint{Host: host, Port: int32(p)}
+}
+
+func unusedAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return addr
+}
+
+func TestProbeControlPlaneEndpointTCP(t *testing.T) {
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	c := newEndpointProbeClient(t)
+	require.NoError(t, probeControlPlaneEndpoint(context.Background(), c, cluster,
+		apiEndpoint(t, listener.Addr().String()), time.Second))
+	require.Error(t, probeControlPlaneEndpoint(context.Background(), c, cluster,
+		apiEndpoint(t, unusedAddress(t)), time.Second))
+}
+
+func TestProbeControlPlaneEndpointHTTPS(t *testing.T) {
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Anonymous requests may be rejected, but the API server is reachable nonetheless.
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: secret.Name("test", secret.ClusterCA)},
+		Data: map[string][]byte{
+			secret.TLSCrtDataName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		},
+	}
+	endpoint := apiEndpoint(t, server.Listener.Addr().String())
+	require.NoError(t, probeControlPlaneEndpoint(context.Background(), newEndpointProbeClient(t, caSecret),
+		cluster, endpoint, time.Second))
+
+	caSecret.Data[secret.TLSCrtDataName] = []byte("invalid")
+	require.ErrorContains(t, probeControlPlaneEndpoint(context.Background(), newEndpointProbeClient(t, caSecret),
+		cluster, endpoint, time.Second), "valid certificate")
+}
+
+func TestReconcileControlPlaneEndpointReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	newScope := func(addr string) *scope.Cluster {
+		return &scope.Cluster{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}},
+			IonosCluster: &infrav1.IonosCloudCluster{Spec: infrav1.IonosCloudClusterSpec{
+				ControlPlaneEndpoint: apiEndpoint(t, addr),
+			}},
+		}
+	}
+	r := &IonosCloudClusterReconciler{Client: newEndpointProbeClient(t), ControlPlaneEndpointProbeTimeout: time.Second}
+
+	clusterScope := newScope(listener.Addr().String())
+	require.False(t, r.reconcileControlPlaneEndpointReachable(context.Background(), clusterScope))
+	require.True(t, conditions.IsTrue(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition))
+
+	clusterScope = newScope(unusedAddress(t))
+	require.True(t, r.reconcileControlPlaneEndpointReachable(context.Background(), clusterScope))
+	require.Equal(t, infrav1.WaitingForControlPlaneReason,
+		conditions.GetReason(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition))
+
+	conditions.MarkTrue(clusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition)
+	require.True(t, r.reconcileControlPlaneEndpointReachable(context.Background(), clusterScope))
+	require.Equal(t, infrav1.ControlPlaneEndpointUnreachableReason,
+		conditions.GetReason(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition))
+
+	r.ControlPlaneEndpointProbeTimeout = 0
+	clusterScope = newScope(unusedAddress(t))
+	require.False(t, r.reconcileControlPlaneEndpointReachable(context.Background(), clusterScope))
+	require.False(t, conditions.Has(clusterScope.IonosCluster, infrav1.ControlPlaneEndpointReachableCondition))
+}
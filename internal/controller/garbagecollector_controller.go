@@ -0,0 +1,145 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// GarbageCollectorReconciler periodically deletes IONOS Cloud resources, which are labeled with the name of
+// an IonosCloudCluster, but don't belong to any of its IonosCloudMachines anymore.
+// It never modifies the IonosCloudCluster.
+type GarbageCollectorReconciler struct {
+	client.Client
+
+	// RateLimiter limits the requests to the Cloud API. It is shared with the other reconcilers.
+	RateLimiter *icc.RateLimiter
+
+	// APIEndpoint is the default endpoint of the Cloud API, which can be overridden in the credentials secret.
+	APIEndpoint icc.Endpoint
+	// ClientFactory creates the clients for the Cloud API. By default, clients for the real Cloud API are created.
+	ClientFactory ClientFactory
+	// ClientCache keeps the clients for the Cloud API across reconciliations. It is shared with the other reconcilers.
+	// A client is created for every reconciliation if it is nil.
+	ClientCache *ClientCache
+
+	// Recorder records the events of the deletions on the IonosCloudCluster.
+	Recorder record.EventRecorder
+
+	// Interval is the interval in which each cluster is checked for orphaned resources.
+	Interval time.Duration
+
+	// DryRun skips the deletion of orphaned resources for every cluster.
+	DryRun bool
+
+	// AuditLog configures the audit log of the deletions.
+	AuditLog AuditLog
+}
+
+// Reconcile deletes the orphaned resources of a single cluster.
+func (r *GarbageCollectorReconciler) Reconcile(
+	ctx context.Context,
+	ionosCloudCluster *infrav1.IonosCloudCluster,
+) (_ ctrl.Result, retErr error) {
+	ctx, span := tracing.Start(ctx, "GarbageCollector.Reconcile", tracing.ObjectAttributes(ionosCloudCluster)...)
+	defer func() { tracing.End(span, retErr) }()
+
+	logger := ctrl.LoggerFrom(ctx)
+
+	if !ionosCloudCluster.DeletionTimestamp.IsZero() {
+		// The remaining resources are deleted together with the cluster.
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, ionosCloudCluster.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if cluster == nil || !cluster.DeletionTimestamp.IsZero() || !ionosCloudCluster.Status.Ready {
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	if annotations.IsPaused(cluster, ionosCloudCluster) {
+		logger.Info("Either IonosCloudCluster or owner cluster is marked as paused. Garbage collection is skipped")
+		return ctrl.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	// The scope is only used to read the cluster and is never patched.
+	clusterScope, err := scope.NewCluster(scope.ClusterParams{
+		Client:       r.Client,
+		Cluster:      cluster,
+		IonosCluster: ionosCloudCluster,
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to create scope %w", err)
+	}
+
+	dryRun := isDryRun(r.DryRun, ionosCloudCluster)
+	cloudService, err := createServiceFromCluster(
+		ctx, r.Client, ionosCloudCluster, r.ClientFactory, r.ClientCache, r.RateLimiter, r.APIEndpoint, r.Recorder,
+		dryRun, r.AuditLog, logger)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Error(err, "unable to create IONOS Cloud client")
+			return ctrl.Result{RequeueAfter: r.Interval}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to create ionos client: %w", err)
+	}
+
+	if _, err := cloudService.DeleteOrphanedResources(ctx, clusterScope); err != nil {
+		var dryRunErr *icc.DryRunError
+		if errors.As(err, &dryRunErr) {
+			logger.Info("Dry run: skipped deletion of orphaned resource", "method", dryRunErr.Method, "path", dryRunErr.Path)
+			return ctrl.Result{RequeueAfter: r.Interval}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("error in step DeleteOrphanedResources: %w", err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GarbageCollectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("garbagecollector").
+		// Clusters are only enqueued once, after that the controller requeues them periodically.
+		For(&infrav1.IonosCloudCluster{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc:  func(event.UpdateEvent) bool { return false },
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		})).
+		Complete(reconcile.AsReconciler[*infrav1.IonosCloudCluster](r.Client, r))
+}
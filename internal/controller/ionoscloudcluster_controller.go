@@ -23,23 +23,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/feature"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
 
@@ -47,6 +57,38 @@ import (
 type IonosCloudClusterReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// RateLimiter limits the requests to the Cloud API. It is shared with the other reconcilers.
+	RateLimiter *icc.RateLimiter
+
+	// APIEndpoint is the default endpoint of the Cloud API, which can be overridden in the credentials secret.
+	APIEndpoint icc.Endpoint
+	// ClientFactory creates the clients for the Cloud API. By default, clients for the real Cloud API are created.
+	ClientFactory ClientFactory
+	// ClientCache keeps the clients for the Cloud API across reconciliations. It is shared with the other reconcilers.
+	// A client is created for every reconciliation if it is nil.
+	ClientCache *ClientCache
+
+	// DryRun skips all mutating requests to the Cloud API for every cluster.
+	DryRun bool
+
+	// AuditLog configures the audit log of the mutating requests to the Cloud API.
+	AuditLog AuditLog
+
+	// Recorder records the mutations, which were skipped in dry-run mode.
+	Recorder record.EventRecorder
+
+	// ControlPlaneEndpointProbeTimeout is the timeout for probing the control plane endpoint.
+	// Probing is disabled if it is zero.
+	ControlPlaneEndpointProbeTimeout time.Duration
+
+	// MaxConcurrentReconciles is the maximum number of IonosCloudClusters, which are reconciled at the same time.
+	// It defaults to 1.
+	MaxConcurrentReconciles int
+
+	// Timeouts contains the interval, in which pending requests are checked. The machine timeouts are not used
+	// by the cluster controller.
+	Timeouts Timeouts
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudclusters,verbs=get;list;watch;create;update;patch;delete
@@ -55,7 +97,11 @@ type IonosCloudClusterReconciler struct {
 
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudclusteridentities,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -65,7 +111,10 @@ type IonosCloudClusterReconciler struct {
 func (r *IonosCloudClusterReconciler) Reconcile(
 	ctx context.Context,
 	ionosCloudCluster *infrav1.IonosCloudCluster,
-) (_ ctrl.Result, retErr error) {
+) (res ctrl.Result, retErr error) {
+	ctx, span := tracing.Start(ctx, "IonosCloudCluster.Reconcile", tracing.ObjectAttributes(ionosCloudCluster)...)
+	defer func() { tracing.End(span, retErr) }()
+
 	logger := ctrl.LoggerFrom(ctx)
 
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, ionosCloudCluster.ObjectMeta)
@@ -78,11 +127,17 @@ func (r *IonosCloudClusterReconciler) Reconcile(
 		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
 	}
 
-	if annotations.IsPaused(cluster, ionosCloudCluster) {
+	paused := annotations.IsPaused(cluster, ionosCloudCluster)
+	if paused && !metav1.HasAnnotation(ionosCloudCluster.ObjectMeta, clusterctlv1.BlockMoveAnnotation) {
 		logger.Info("Either IonosCloudCluster or owner cluster is marked as paused. Reconciliation is skipped")
 		return ctrl.Result{}, nil
 	}
 
+	if annotations.IsExternallyManaged(ionosCloudCluster) {
+		logger.V(4).Info("IonosCloudCluster is externally managed. Reconciliation of the infrastructure is skipped")
+		return r.reconcileExternallyManaged(ctx, cluster, ionosCloudCluster)
+	}
+
 	clusterScope, err := scope.NewCluster(scope.ClusterParams{
 		Client:       r.Client,
 		Cluster:      cluster,
@@ -99,15 +154,32 @@ func (r *IonosCloudClusterReconciler) Reconcile(
 		}
 	}()
 
-	cloudService, err := createServiceFromCluster(ctx, r.Client, ionosCloudCluster, logger)
+	// Report a skipped mutation before the cluster is patched.
+	dryRun := isDryRun(r.DryRun, ionosCloudCluster)
+	defer func() { res, retErr = reportDryRun(r.Recorder, ionosCloudCluster, dryRun, res, retErr) }()
+
+	cloudService, err := createServiceFromCluster(
+		ctx, r.Client, ionosCloudCluster, r.ClientFactory, r.ClientCache, r.RateLimiter, r.APIEndpoint, r.Recorder,
+		dryRun, r.AuditLog, logger)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "unable to create IONOS Cloud client")
+			conditions.MarkFalse(ionosCloudCluster, infrav1.CredentialsValidCondition, infrav1.CredentialsNotFoundReason,
+				clusterv1.ConditionSeverityError, "%s", err)
 			// Secret is missing, we try again after some time.
 			return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
 		}
+		if errors.Is(err, errInvalidCredentials) {
+			conditions.MarkFalse(ionosCloudCluster, infrav1.CredentialsValidCondition, infrav1.InvalidCredentialsReason,
+				clusterv1.ConditionSeverityError, "%s", err)
+		}
 		return ctrl.Result{}, fmt.Errorf("failed to create ionos client: %w", err)
 	}
+	conditions.MarkTrue(ionosCloudCluster, infrav1.CredentialsValidCondition)
+
+	if paused {
+		return r.reconcilePaused(ctx, clusterScope, cloudService)
+	}
 
 	if !ionosCloudCluster.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, clusterScope, cloudService)
@@ -123,6 +195,15 @@ func (r *IonosCloudClusterReconciler) reconcileNormal(
 ) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	if clusterScope.IonosCluster.Spec.LoadBalancer != nil && featureGateDisabled(
+		r.Recorder, clusterScope.IonosCluster, infrav1.IonosCloudClusterReady,
+		feature.LoadBalancer, "spec.loadBalancer",
+	) {
+		log.Info("The Network Load Balancer is disabled. Reconciliation is skipped",
+			"featureGate", feature.LoadBalancer)
+		return ctrl.Result{}, nil
+	}
+
 	controllerutil.AddFinalizer(clusterScope.IonosCluster, infrav1.ClusterFinalizer)
 	log.V(4).Info("Reconciling IonosCloudCluster")
 
@@ -132,24 +213,52 @@ func (r *IonosCloudClusterReconciler) reconcileNormal(
 	}
 	if requeue {
 		log.Info("Request is still in progress")
-		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+		return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
 	}
 
+	clusterScope.SetFailureDomains()
+
 	reconcileSequence := []serviceReconcileStep[scope.Cluster]{
+		{"ReconcileDatacenter", cloudService.ReconcileDatacenter},
+		{"ReconcileNetworks", cloudService.ReconcileNetworks},
+		{"ReconcileIPv6Prefixes", cloudService.ReconcileIPv6Prefixes},
 		{"ReconcileControlPlaneEndpoint", cloudService.ReconcileControlPlaneEndpoint},
+		{"ReconcileControlPlaneEndpointDNS", cloudService.ReconcileControlPlaneEndpointDNS},
+		{"ReconcileIPBlocks", cloudService.ReconcileIPBlocks},
+		{"ReconcileRetainedPrivateIPs", cloudService.ReconcileRetainedPrivateIPs},
+		{"ReconcilePinnedImages", cloudService.ReconcilePinnedImages},
+		{"ReconcileLoadBalancerNetworks", cloudService.ReconcileLoadBalancerNetworks},
+		{"ReconcileLoadBalancer", cloudService.ReconcileLoadBalancer},
+		{"ReconcileLoadBalancerTargets", cloudService.ReconcileLoadBalancerTargets},
+		{"ReconcileNATGatewayNetwork", cloudService.ReconcileNATGatewayNetwork},
+		{"ReconcileNATGatewayIPBlock", cloudService.ReconcileNATGatewayIPBlock},
+		{"ReconcileNATGateway", cloudService.ReconcileNATGateway},
+		{"ReconcileApplicationLoadBalancerNetworks", cloudService.ReconcileApplicationLoadBalancerNetworks},
+		{"ReconcileApplicationLoadBalancerIPBlock", cloudService.ReconcileApplicationLoadBalancerIPBlock},
+		{"ReconcileTargetGroups", cloudService.ReconcileTargetGroups},
+		{"ReconcileApplicationLoadBalancer", cloudService.ReconcileApplicationLoadBalancer},
+		{"ReconcileOrphanedTargetGroupsDeletion", cloudService.ReconcileOrphanedTargetGroupsDeletion},
 	}
 	for _, step := range reconcileSequence {
-		if requeue, err := step.fn(ctx, clusterScope); err != nil || requeue {
+		if requeue, err := step.run(ctx, clusterScope); err != nil || requeue {
 			if err != nil {
-				err = fmt.Errorf("error in step %s: %w", step.name, err)
+				return stepFailedResult(ctx, r.Recorder, clusterScope.IonosCluster, step.name, err)
 			}
 
-			return ctrl.Result{RequeueAfter: defaultReconcileDuration}, err
+			return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
 		}
 	}
 
+	endpointUnreachable := r.reconcileControlPlaneEndpointReachable(ctx, clusterScope)
+
 	conditions.MarkTrue(clusterScope.IonosCluster, infrav1.IonosCloudClusterReady)
 	clusterScope.IonosCluster.Status.Ready = true
+
+	// The requests of machines are tracked in the cluster status. The cluster needs to keep polling them,
+	// in case it is paused before they have completed.
+	if clusterScope.IonosCluster.HasPendingRequests() || endpointUnreachable {
+		return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -164,13 +273,25 @@ func (r *IonosCloudClusterReconciler) reconcileDelete(
 		return ctrl.Result{}, nil
 	}
 
+	if isDeletionProtected(clusterScope.IonosCluster) {
+		log.Info("Deletion is blocked by the deletion protection", "annotation", infrav1.DeletionProtectionAnnotation)
+		r.recordDeletionProtected(clusterScope.IonosCluster)
+		conditions.MarkFalse(clusterScope.IonosCluster, infrav1.ResourcesDeletedCondition,
+			infrav1.DeletionProtectedReason, clusterv1.ConditionSeverityWarning,
+			"remove the annotation %s to delete the resources of the cluster", infrav1.DeletionProtectionAnnotation)
+		// Removing the annotation triggers another reconciliation.
+		return ctrl.Result{}, nil
+	}
+	conditions.MarkFalse(clusterScope.IonosCluster, infrav1.ResourcesDeletedCondition,
+		clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+
 	requeue, err := r.checkRequestStatus(ctx, clusterScope, cloudService)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error when trying to determine in-flight request states: %w", err)
 	}
 	if requeue {
 		log.Info("Request is still in progress")
-		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+		return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
 	}
 
 	machines, err := clusterScope.ListMachines(ctx, nil)
@@ -184,43 +305,203 @@ func (r *IonosCloudClusterReconciler) reconcileDelete(
 	}
 
 	reconcileSequence := []serviceReconcileStep[scope.Cluster]{
+		{"ReconcileApplicationLoadBalancerDeletion", cloudService.ReconcileApplicationLoadBalancerDeletion},
+		{"ReconcileTargetGroupsDeletion", cloudService.ReconcileTargetGroupsDeletion},
+		{"ReconcileApplicationLoadBalancerIPBlockDeletion", cloudService.ReconcileApplicationLoadBalancerIPBlockDeletion},
+		{"ReconcileIPBlocksDeletion", cloudService.ReconcileIPBlocksDeletion},
+		{"ReconcileApplicationLoadBalancerNetworksDeletion", cloudService.ReconcileApplicationLoadBalancerNetworksDeletion},
+		{"ReconcileNATGatewayDeletion", cloudService.ReconcileNATGatewayDeletion},
+		{"ReconcileNATGatewayIPBlockDeletion", cloudService.ReconcileNATGatewayIPBlockDeletion},
+		{"ReconcileNATGatewayNetworkDeletion", cloudService.ReconcileNATGatewayNetworkDeletion},
+		{"ReconcileLoadBalancerDeletion", cloudService.ReconcileLoadBalancerDeletion},
+		{"ReconcileLoadBalancerNetworksDeletion", cloudService.ReconcileLoadBalancerNetworksDeletion},
+		{"ReconcileControlPlaneEndpointDNSDeletion", cloudService.ReconcileControlPlaneEndpointDNSDeletion},
 		{"ReconcileControlPlaneEndpointDeletion", cloudService.ReconcileControlPlaneEndpointDeletion},
+		{"ReconcileNetworksDeletion", cloudService.ReconcileNetworksDeletion},
+		{"ReconcileCrossConnectsDeletion", cloudService.ReconcileCrossConnectsDeletion},
+		{"ReconcileDatacenterDeletion", cloudService.ReconcileDatacenterDeletion},
 	}
 	for _, step := range reconcileSequence {
-		if requeue, err := step.fn(ctx, clusterScope); err != nil || requeue {
+		if requeue, err := step.run(ctx, clusterScope); err != nil || requeue {
 			if err != nil {
-				err = fmt.Errorf("error in step %s: %w", step.name, err)
+				return stepFailedResult(ctx, r.Recorder, clusterScope.IonosCluster, step.name, err)
 			}
 
-			return ctrl.Result{RequeueAfter: defaultReconcileDuration}, err
+			return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
 		}
 	}
 	if err := removeCredentialsFinalizer(ctx, r.Client, clusterScope.IonosCluster); err != nil {
 		return ctrl.Result{}, err
 	}
 	controllerutil.RemoveFinalizer(clusterScope.IonosCluster, infrav1.ClusterFinalizer)
+	r.ClientCache.Forget(clusterScope.IonosCluster)
 	return ctrl.Result{}, nil
 }
 
-func (*IonosCloudClusterReconciler) checkRequestStatus(
+// reconcileExternallyManaged handles clusters with the managed-by annotation. Their infrastructure is provided
+// by another system, which is also responsible for marking the IonosCloudCluster as ready. The machines of the
+// cluster are still reconciled by the provider, so only the credentials secret used by them is released once
+// the cluster is deleted. The status of the IonosCloudCluster is never modified.
+func (r *IonosCloudClusterReconciler) reconcileExternallyManaged(
+	ctx context.Context, cluster *clusterv1.Cluster, ionosCloudCluster *infrav1.IonosCloudCluster,
+) (_ ctrl.Result, retErr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	patchHelper, err := patch.NewHelper(ionosCloudCluster, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, ionosCloudCluster); err != nil {
+			retErr = errors.Join(err, retErr)
+		}
+	}()
+
+	if ionosCloudCluster.DeletionTimestamp.IsZero() {
+		controllerutil.AddFinalizer(ionosCloudCluster, infrav1.ClusterFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	machines := &infrav1.IonosCloudMachineList{}
+	if err := r.Client.List(ctx, machines, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(machines.Items) > 0 {
+		log.Info("Waiting for all IonosCloudMachines to be deleted", "remaining", len(machines.Items))
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	if err := removeCredentialsFinalizer(ctx, r.Client, ionosCloudCluster); err != nil {
+		return ctrl.Result{}, err
+	}
+	controllerutil.RemoveFinalizer(ionosCloudCluster, infrav1.ClusterFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// reconcilePaused only tracks the pending requests of a paused cluster. Once they have completed,
+// the cluster doesn't block clusterctl move anymore.
+func (r *IonosCloudClusterReconciler) reconcilePaused(
 	ctx context.Context, clusterScope *scope.Cluster, cloudService *cloud.Service,
-) (requeue bool, retErr error) {
+) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
+
+	requeue, err := r.checkRequestStatus(ctx, clusterScope, cloudService)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error when trying to determine in-flight request states: %w", err)
+	}
+
 	ionosCluster := clusterScope.IonosCluster
-	if req := ionosCluster.Status.CurrentClusterRequest; req != nil {
-		status, message, err := cloudService.GetRequestStatus(ctx, req.RequestPath)
+	for datacenterID, req := range ionosCluster.Status.CurrentRequestByDatacenter {
+		datacenterRequeue, err := pollRequest(ctx, cloudService, r.Recorder, ionosCluster, &req, func() error {
+			ionosCluster.DeleteCurrentRequestByDatacenter(datacenterID)
+			return nil
+		})
 		if err != nil {
-			retErr = fmt.Errorf("could not get request status: %w", err)
-		} else {
-			requeue, retErr = withStatus(status, message, &log,
-				func() error {
-					ionosCluster.DeleteCurrentClusterRequest()
-					return nil
-				},
-			)
+			return ctrl.Result{}, fmt.Errorf("error when trying to determine in-flight request states: %w", err)
+		}
+		if datacenterRequeue {
+			ionosCluster.Status.CurrentRequestByDatacenter[datacenterID] = req
+		}
+		requeue = requeue || datacenterRequeue
+	}
+
+	if requeue {
+		log.Info("Cluster is paused, waiting for pending requests to complete")
+		return ctrl.Result{RequeueAfter: r.pollInterval(clusterScope)}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// pollInterval returns the interval, in which the pending requests of the cluster are checked.
+func (r *IonosCloudClusterReconciler) pollInterval(clusterScope *scope.Cluster) time.Duration {
+	return r.Timeouts.forCluster(clusterScope.IonosCluster).RequestPollInterval
+}
+
+func (r *IonosCloudClusterReconciler) checkRequestStatus(
+	ctx context.Context, clusterScope *scope.Cluster, cloudService *cloud.Service,
+) (requeue bool, err error) {
+	ionosCluster := clusterScope.IonosCluster
+	if req := ionosCluster.Status.CurrentClusterRequest; req != nil {
+		return pollRequest(ctx, cloudService, r.Recorder, ionosCluster, req, func() error {
+			ionosCluster.DeleteCurrentClusterRequest()
+			return nil
+		})
+	}
+	return false, nil
+}
+
+// machineToIonosCloudCluster maps IonosCloudMachines to their IonosCloudCluster.
+// This allows updating the load balancer targets as machines come and go, and creating the LANs of
+// the cluster networks in the data centers of new machines, as well as assigning the IPs of the
+// cluster IP blocks. Worker machines are only mapped if the cluster reconciles resources for them,
+// see workerMachineAffectsCluster.
+func (r *IonosCloudClusterReconciler) machineToIonosCloudCluster(
+	ctx context.Context, o client.Object,
+) []reconcile.Request {
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, metav1.ObjectMeta{
+		Namespace: o.GetNamespace(),
+		Labels:    o.GetLabels(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != infrav1.IonosCloudClusterKind {
+		return nil
+	}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}
+
+	if _, ok := o.GetLabels()[clusterv1.MachineControlPlaneLabel]; !ok {
+		machine, ok := o.(*infrav1.IonosCloudMachine)
+		ionosCluster := &infrav1.IonosCloudCluster{}
+		if !ok || r.Client.Get(ctx, key, ionosCluster) != nil || !workerMachineAffectsCluster(ionosCluster, machine) {
+			return nil
 		}
 	}
-	return requeue, retErr
+
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
+// workerMachineAffectsCluster returns whether the cluster needs to be reconciled, when the worker machine changes.
+// This is the case for the Application Load Balancer, the networks and the IP blocks of the cluster, for
+// dual-stack machines, which are waiting for their IPv6 CIDR block, and for machines, whose private IP or image
+// is not retained or pinned yet. The cluster is not requeued once it is ready, so the machine would otherwise
+// wait until the next resync.
+func workerMachineAffectsCluster(ionosCluster *infrav1.IonosCloudCluster, machine *infrav1.IonosCloudMachine) bool {
+	spec := ionosCluster.Spec
+	if spec.ApplicationLoadBalancer != nil || len(spec.Networks) > 0 || len(spec.IPBlocks) > 0 {
+		return true
+	}
+	if machine.Spec.IPv6 != nil && ionosCluster.Status.IPv6CIDRBlocks[machine.Name] == "" {
+		return true
+	}
+	if machine.Spec.PrivateIP != nil && machine.Spec.PrivateIP.Retain &&
+		ionosCluster.Status.RetainedPrivateIPs[machine.Name] == "" {
+		return true
+	}
+	if key := cloud.PinnedImageKey(machine); key != "" {
+		_, pinned := ionosCluster.Status.PinnedImages[key]
+		return !pinned
+	}
+	return false
+}
+
+// isDeletionProtected returns whether the cluster is annotated with DeletionProtectionAnnotation.
+func isDeletionProtected(cluster *infrav1.IonosCloudCluster) bool {
+	return cluster.GetAnnotations()[infrav1.DeletionProtectionAnnotation] == "true"
+}
+
+// recordDeletionProtected records a DeletionBlocked event, when the deletion protection starts blocking
+// the deletion of the cluster. The event is not repeated while the deletion stays blocked.
+func (r *IonosCloudClusterReconciler) recordDeletionProtected(cluster *infrav1.IonosCloudCluster) {
+	if r.Recorder == nil ||
+		conditions.GetReason(cluster, infrav1.ResourcesDeletedCondition) == infrav1.DeletionProtectedReason {
+		return
+	}
+	r.Recorder.Eventf(cluster, corev1.EventTypeWarning, deletionBlockedReason,
+		"Deletion of the cluster resources is blocked by the annotation %s", infrav1.DeletionProtectionAnnotation)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -238,5 +519,14 @@ func (r *IonosCloudClusterReconciler) SetupWithManager(ctx context.Context, mgr
 			),
 			builder.WithPredicates(predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx))),
 		).
+		Watches(&infrav1.IonosCloudMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.machineToIonosCloudCluster),
+		).
+		// Changes of the credentials, e.g. a rotated token, are picked up immediately. The secrets are owned
+		// by the clusters, which use them.
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1.IonosCloudCluster{}),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(reconcile.AsReconciler[*infrav1.IonosCloudCluster](r.Client, r))
 }
@@ -0,0 +1,305 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const exampleRequestPath = "/requests/a7b3a2f4-5d5e-4f3a-8bd5-9e0bc1a3a7c1/status"
+
+func TestCheckClusterRequestStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      *sdk.RequestStatus
+		statusErr   error
+		wantRequeue bool
+		wantErr     bool
+		wantRequest *infrav1.ProvisioningRequest
+	}{
+		{
+			name:        "queued request is kept",
+			status:      exampleRequestStatus(sdk.RequestStatusQueued),
+			wantRequeue: true,
+			wantRequest: &infrav1.ProvisioningRequest{
+				Method:      http.MethodPost,
+				RequestPath: exampleRequestPath,
+				State:       sdk.RequestStatusQueued,
+				Targets:     []infrav1.ProvisioningRequestTarget{{Type: string(sdk.IPBLOCK), ID: "ipb-id"}},
+			},
+		},
+		{
+			name:        "running request is kept",
+			status:      exampleRequestStatus(sdk.RequestStatusRunning),
+			wantRequeue: true,
+			wantRequest: &infrav1.ProvisioningRequest{
+				Method:      http.MethodPost,
+				RequestPath: exampleRequestPath,
+				State:       sdk.RequestStatusRunning,
+				Targets:     []infrav1.ProvisioningRequestTarget{{Type: string(sdk.IPBLOCK), ID: "ipb-id"}},
+			},
+		},
+		{
+			name:   "done request is removed",
+			status: exampleRequestStatus(sdk.RequestStatusDone),
+		},
+		{
+			name:   "failed request is removed",
+			status: exampleRequestStatus(sdk.RequestStatusFailed),
+		},
+		{
+			name:      "expired request is removed",
+			statusErr: sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound),
+		},
+		{
+			name:      "request is kept if the status is unavailable",
+			statusErr: sdk.NewGenericOpenAPIError("internal error", nil, nil, http.StatusInternalServerError),
+			wantErr:   true,
+			wantRequest: &infrav1.ProvisioningRequest{
+				Method:      http.MethodPost,
+				RequestPath: exampleRequestPath,
+				State:       sdk.RequestStatusQueued,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			ionosClient := clienttest.NewMockClient(t)
+			// The context carries the span of the poll.
+			ionosClient.EXPECT().CheckRequestStatus(mock.Anything, exampleRequestPath).Return(tt.status, tt.statusErr).Once()
+			cloudService, err := cloud.NewService(ionosClient, logr.Discard())
+			require.NoError(t, err)
+
+			clusterScope := &scope.Cluster{IonosCluster: &infrav1.IonosCloudCluster{}}
+			clusterScope.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, exampleRequestPath)
+
+			requeue, err := (&IonosCloudClusterReconciler{}).checkRequestStatus(ctx, clusterScope, cloudService)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantRequeue, requeue)
+			require.Equal(t, tt.wantRequest, clusterScope.IonosCluster.Status.CurrentClusterRequest)
+		})
+	}
+}
+
+func TestReconcileExternallyManaged(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}}
+	ionosCloudCluster := &infrav1.IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Namespace:   "default",
+			UID:         "cluster-uid",
+			Annotations: map[string]string{clusterv1.ManagedByAnnotation: "terraform"},
+		},
+		Spec:   infrav1.IonosCloudClusterSpec{CredentialsRef: &corev1.LocalObjectReference{Name: "credentials"}},
+		Status: infrav1.IonosCloudClusterStatus{Ready: true},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:       "credentials",
+		Namespace:  "default",
+		Finalizers: []string{infrav1.ClusterFinalizer + "/cluster-uid"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(ionosCloudCluster, secret).
+		WithStatusSubresource(ionosCloudCluster).
+		Build()
+	r := &IonosCloudClusterReconciler{Client: c, Scheme: scheme}
+
+	_, err := r.reconcileExternallyManaged(ctx, cluster, ionosCloudCluster)
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ionosCloudCluster), ionosCloudCluster))
+	require.Contains(t, ionosCloudCluster.Finalizers, infrav1.ClusterFinalizer)
+	require.True(t, ionosCloudCluster.Status.Ready, "the status must not be modified")
+	require.Empty(t, ionosCloudCluster.Status.Conditions, "the status must not be modified")
+
+	require.NoError(t, c.Delete(ctx, ionosCloudCluster))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(ionosCloudCluster), ionosCloudCluster))
+
+	_, err = r.reconcileExternallyManaged(ctx, cluster, ionosCloudCluster)
+	require.NoError(t, err)
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(ionosCloudCluster), ionosCloudCluster)))
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	require.Empty(t, secret.Finalizers)
+}
+
+func TestReconcileDeleteProtected(t *testing.T) {
+	ctx := context.Background()
+	// The mock fails the test on any request to the Cloud API.
+	cloudService, err := cloud.NewService(clienttest.NewMockClient(t), logr.Discard())
+	require.NoError(t, err)
+
+	clusterScope := &scope.Cluster{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+			DeletionTimestamp: ptr.To(metav1.Now()),
+		}},
+		IonosCluster: &infrav1.IonosCloudCluster{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{infrav1.DeletionProtectionAnnotation: "true"},
+			Finalizers:  []string{infrav1.ClusterFinalizer},
+		}},
+	}
+	recorder := record.NewFakeRecorder(2)
+	r := &IonosCloudClusterReconciler{Recorder: recorder}
+
+	for range 2 {
+		res, err := r.reconcileDelete(ctx, clusterScope, cloudService)
+		require.NoError(t, err)
+		require.Zero(t, res)
+		require.Contains(t, clusterScope.IonosCluster.Finalizers, infrav1.ClusterFinalizer)
+		require.True(t, conditions.IsFalse(clusterScope.IonosCluster, infrav1.ResourcesDeletedCondition))
+		require.Equal(t, infrav1.DeletionProtectedReason,
+			conditions.GetReason(clusterScope.IonosCluster, infrav1.ResourcesDeletedCondition))
+	}
+	require.Len(t, recorder.Events, 1, "the event must only be recorded once")
+	require.Contains(t, <-recorder.Events, "Warning DeletionBlocked")
+}
+
+func TestReconcileNormalLoadBalancerDisabled(t *testing.T) {
+	ctx := context.Background()
+	// The mock fails the test on any request to the Cloud API.
+	cloudService, err := cloud.NewService(clienttest.NewMockClient(t), logr.Discard())
+	require.NoError(t, err)
+
+	clusterScope := &scope.Cluster{
+		Cluster: &clusterv1.Cluster{},
+		IonosCluster: &infrav1.IonosCloudCluster{Spec: infrav1.IonosCloudClusterSpec{
+			LoadBalancer: &infrav1.LoadBalancerSpec{DatacenterID: "dc-id"},
+		}},
+	}
+	r := &IonosCloudClusterReconciler{Recorder: record.NewFakeRecorder(1)}
+
+	res, err := r.reconcileNormal(ctx, clusterScope, cloudService)
+	require.NoError(t, err)
+	require.Zero(t, res)
+	require.Empty(t, clusterScope.IonosCluster.Finalizers)
+	require.False(t, clusterScope.IonosCluster.Status.Ready)
+	require.Equal(t, infrav1.FeatureGateDisabledReason,
+		conditions.GetReason(clusterScope.IonosCluster, infrav1.IonosCloudClusterReady))
+}
+
+func TestMachineToIonosCloudCluster(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{InfrastructureRef: &corev1.ObjectReference{
+			Kind: infrav1.IonosCloudClusterKind,
+			Name: "test-cluster",
+		}},
+	}
+	// The primary LAN already has a prefix, which was recorded before the worker was created.
+	ionosCluster := &infrav1.IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Status: infrav1.IonosCloudClusterStatus{
+			Ready:        true,
+			IPv6Prefixes: map[string]string{"dc-id": "2001:db8::/56"},
+		},
+	}
+	r := &IonosCloudClusterReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, ionosCluster).Build(),
+	}
+	want := []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(ionosCluster)}}
+
+	newWorker := func() *infrav1.IonosCloudMachine {
+		return &infrav1.IonosCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "worker",
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+			},
+			Spec: infrav1.IonosCloudMachineSpec{DatacenterID: "dc-id"},
+		}
+	}
+
+	worker := newWorker()
+	require.Empty(t, r.machineToIonosCloudCluster(ctx, worker), "the worker doesn't affect the cluster")
+
+	controlPlane := newWorker()
+	controlPlane.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	require.Equal(t, want, r.machineToIonosCloudCluster(ctx, controlPlane))
+
+	worker.Spec.IPv6 = &infrav1.IPv6Config{}
+	require.Equal(t, want, r.machineToIonosCloudCluster(ctx, worker),
+		"the dual-stack worker waits for its IPv6 CIDR block")
+
+	ionosCluster.Status.IPv6CIDRBlocks = map[string]string{"worker": "2001:db8::/80"}
+	require.NoError(t, r.Client.Update(ctx, ionosCluster))
+	require.Empty(t, r.machineToIonosCloudCluster(ctx, worker), "the IPv6 CIDR block is already assigned")
+
+	worker.Spec.PrivateIP = &infrav1.PrivateIPConfig{Retain: true}
+	require.Equal(t, want, r.machineToIonosCloudCluster(ctx, worker),
+		"the private IP of the worker must be recorded")
+
+	ionosCluster.Status.RetainedPrivateIPs = map[string]string{"worker": "10.0.0.10"}
+	require.NoError(t, r.Client.Update(ctx, ionosCluster))
+	require.Empty(t, r.machineToIonosCloudCluster(ctx, worker), "the private IP is already retained")
+
+	worker.Annotations = map[string]string{clusterv1.TemplateClonedFromNameAnnotation: "workers"}
+	worker.Spec.Disk = &infrav1.Volume{ImageUpgradePolicy: infrav1.ImageUpgradePolicyPin}
+	require.Equal(t, want, r.machineToIonosCloudCluster(ctx, worker), "the image of the worker must be pinned")
+
+	ionosCluster.Status.PinnedImages = map[string]string{"workers/dc-id": "image-id"}
+	require.NoError(t, r.Client.Update(ctx, ionosCluster))
+	require.Empty(t, r.machineToIonosCloudCluster(ctx, worker), "the image is already pinned")
+}
+
+func exampleRequestStatus(status string) *sdk.RequestStatus {
+	return &sdk.RequestStatus{
+		Metadata: &sdk.RequestStatusMetadata{
+			Status: ptr.To(status),
+			Targets: &[]sdk.RequestTarget{{
+				Target: &sdk.ResourceReference{Id: ptr.To("ipb-id"), Type: ptr.To(sdk.IPBLOCK)},
+			}},
+		},
+	}
+}
@@ -20,21 +20,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"time"
 
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	exputil "sigs.k8s.io/cluster-api/exp/util"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/feature"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/ipam"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/locker"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
 
@@ -42,6 +61,55 @@ import (
 type IonosCloudMachineReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// RateLimiter limits the requests to the Cloud API. It is shared with the other reconcilers.
+	RateLimiter *icc.RateLimiter
+
+	// APIEndpoint is the default endpoint of the Cloud API, which can be overridden in the credentials secret.
+	APIEndpoint icc.Endpoint
+	// ClientFactory creates the clients for the Cloud API. By default, clients for the real Cloud API are created.
+	ClientFactory ClientFactory
+	// ClientCache keeps the clients for the Cloud API across reconciliations. It is shared with the other reconcilers.
+	// A client is created for every reconciliation if it is nil.
+	ClientCache *ClientCache
+
+	// ServerStatePollInterval is the interval in which the state of the servers of provisioned machines
+	// is checked. Polling is disabled if it is zero.
+	ServerStatePollInterval time.Duration
+
+	// DryRun skips all mutating requests to the Cloud API for every machine.
+	DryRun bool
+
+	// AuditLog configures the audit log of the mutating requests to the Cloud API.
+	AuditLog AuditLog
+
+	// Recorder records the mutations, which were skipped in dry-run mode.
+	Recorder record.EventRecorder
+
+	// MaxConcurrentReconciles is the maximum number of IonosCloudMachines, which are reconciled at the same time.
+	// It defaults to 1.
+	MaxConcurrentReconciles int
+
+	// DatacenterLocks serializes the reconciliation of machines in the same data center, which share the LAN
+	// and the pending request of the data center in the status of the IonosCloudCluster.
+	// Machines are not serialized if it is nil.
+	DatacenterLocks *locker.Locker
+
+	// DatacenterCache caches the data centers, which are needed to create servers, for all machines.
+	// Data centers are requested for every server creation if it is nil.
+	DatacenterCache *cloud.DatacenterCache
+
+	// TemplateCache caches the templates of CUBE servers, which are referenced by their name, for all machines.
+	// The templates are listed for every server creation if it is nil.
+	TemplateCache *cloud.TemplateCache
+
+	// WorkloadClusterClients provides clients for the workload clusters. If it is set, the provider ID of Nodes
+	// is set for clusters without a cloud controller manager.
+	WorkloadClusterClients WorkloadClusterClients
+
+	// Timeouts contains the interval, in which pending requests are checked, and the timeouts for the
+	// provisioning and the deletion of machines.
+	Timeouts Timeouts
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachines,verbs=get;list;watch;create;update;patch;delete
@@ -49,13 +117,19 @@ type IonosCloudMachineReconciler struct {
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachines/finalizers,verbs=update
 
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 
 func (r *IonosCloudMachineReconciler) Reconcile(
 	ctx context.Context,
 	ionosCloudMachine *infrav1.IonosCloudMachine,
-) (_ ctrl.Result, retErr error) {
+) (res ctrl.Result, retErr error) {
+	ctx, span := tracing.Start(ctx, "IonosCloudMachine.Reconcile", tracing.ObjectAttributes(ionosCloudMachine)...)
+	defer func() { tracing.End(span, retErr) }()
+
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Fetch the Machine.
@@ -75,12 +149,19 @@ func (r *IonosCloudMachineReconciler) Reconcile(
 		return ctrl.Result{}, err
 	}
 
-	if annotations.IsPaused(cluster, ionosCloudMachine) {
+	paused := annotations.IsPaused(cluster, ionosCloudMachine)
+	if paused && !metav1.HasAnnotation(ionosCloudMachine.ObjectMeta, clusterctlv1.BlockMoveAnnotation) {
 		logger.Info("IONOS Cloud machine or linked cluster is marked as paused, not reconciling")
 		return ctrl.Result{}, nil
 	}
 
-	clusterScope, err := r.getClusterScope(ctx, cluster, ionosCloudMachine)
+	// Machines, which are part of a machine pool, receive their bootstrap data from the machine pool.
+	machinePool, err := exputil.GetOwnerMachinePool(ctx, r.Client, machine.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get owner machine pool: %w", err)
+	}
+
+	clusterScope, err := getClusterScope(ctx, r.Client, cluster)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("error getting infra provider cluster or control plane object: %w", err)
 	}
@@ -95,6 +176,7 @@ func (r *IonosCloudMachineReconciler) Reconcile(
 		Machine:      machine,
 		ClusterScope: clusterScope,
 		IonosMachine: ionosCloudMachine,
+		MachinePool:  machinePool,
 	})
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to create scope: %w", err)
@@ -106,7 +188,14 @@ func (r *IonosCloudMachineReconciler) Reconcile(
 		}
 	}()
 
-	cloudService, err := createServiceFromCluster(ctx, r.Client, clusterScope.IonosCluster, logger)
+	// Report a skipped mutation before the machine is patched.
+	dryRun := isDryRun(r.DryRun, clusterScope.IonosCluster)
+	defer func() { res, retErr = reportDryRun(r.Recorder, ionosCloudMachine, dryRun, res, retErr) }()
+
+	cloudService, err := createServiceFromCluster(
+		ctx, r.Client, clusterScope.IonosCluster, r.ClientFactory, r.ClientCache, r.RateLimiter, r.APIEndpoint, r.Recorder,
+		dryRun, r.AuditLog, logger,
+		cloud.WithDatacenterCache(r.DatacenterCache), cloud.WithTemplateCache(r.TemplateCache))
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Error(err, "unable to create IONOS Cloud client")
@@ -120,28 +209,68 @@ func (r *IonosCloudMachineReconciler) Reconcile(
 	if err != nil {
 		return ctrl.Result{}, errors.New("could not create machine service")
 	}
+	if paused {
+		return r.reconcilePaused(ctx, machineScope, cloudService)
+	}
 	if !ionosCloudMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, machineScope, cloudService)
 	}
 
-	return r.reconcileNormal(ctx, cloudService, machineScope)
+	ipamService, err := ipam.NewService(r.Client, logger)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("could not create IPAM service: %w", err)
+	}
+
+	return r.reconcileNormal(ctx, cloudService, ipamService, machineScope)
 }
 
 func (r *IonosCloudMachineReconciler) reconcileNormal(
-	ctx context.Context, cloudService *cloud.Service, machineScope *scope.Machine,
+	ctx context.Context, cloudService *cloud.Service, ipamService *ipam.Service, machineScope *scope.Machine,
 ) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(4).Info("Reconciling IonosCloudMachine")
 
+	// The remote console is especially needed to investigate failed machines, which are not reconciled otherwise.
+	if _, err := cloudService.ReconcileRemoteConsole(ctx, machineScope); err != nil {
+		return stepFailedResult(ctx, r.Recorder, machineScope.IonosMachine, "ReconcileRemoteConsole", err)
+	}
+
 	if machineScope.HasFailed() {
 		log.Info("Error state detected, skipping reconciliation")
 		return ctrl.Result{}, nil
 	}
 
+	timeouts := r.Timeouts.forCluster(machineScope.ClusterScope.IonosCluster)
+	if !machineScope.IonosMachine.Status.Ready &&
+		exceeded(&machineScope.IonosMachine.CreationTimestamp, timeouts.MachineProvisioning) {
+		log.Info("Machine was not provisioned in time, marking it as failed", "timeout", timeouts.MachineProvisioning)
+		message := fmt.Sprintf("machine was not provisioned within %s", timeouts.MachineProvisioning)
+		machineScope.SetFailure(capierrors.CreateMachineError, message)
+		if r.Recorder != nil {
+			r.Recorder.Event(machineScope.IonosMachine, corev1.EventTypeWarning, provisioningTimedOutReason, message)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if usesIPAMPool(machineScope.IonosMachine) && featureGateDisabled(
+		r.Recorder, machineScope.IonosMachine, infrav1.IPAddressClaimedCondition, feature.IPAM, "ipv4PoolRef",
+	) {
+		log.Info("IPAM is disabled. Reconciliation is skipped", "featureGate", feature.IPAM)
+		return ctrl.Result{}, nil
+	}
+
 	if !r.isInfrastructureReady(ctx, machineScope) {
 		return ctrl.Result{}, nil
 	}
 
+	if err := machineScope.ApplyFailureDomain(); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to place machine in failure domain: %w", err)
+	}
+	machineScope.ApplyDefaultAvailabilityZone()
+	if err := machineScope.ApplySpreadStrategy(ctx); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to spread machine across availability zones: %w", err)
+	}
+
 	if controllerutil.AddFinalizer(machineScope.IonosMachine, infrav1.MachineFinalizer) {
 		if err := machineScope.PatchObject(); err != nil {
 			err = fmt.Errorf("unable to update finalizer on object: %w", err)
@@ -149,6 +278,12 @@ func (r *IonosCloudMachineReconciler) reconcileNormal(
 		}
 	}
 
+	// The data center is known once the machine has been placed in its failure domain.
+	if !r.lockDatacenter(ctx, machineScope) {
+		return ctrl.Result{RequeueAfter: datacenterLockRetryInterval}, nil
+	}
+	defer r.unlockDatacenter(machineScope)
+
 	requeue, err := r.checkRequestStates(ctx, machineScope, cloudService)
 	if err != nil {
 		// In case the request state cannot be determined, we want to continue with the
@@ -161,28 +296,46 @@ func (r *IonosCloudMachineReconciler) reconcileNormal(
 
 	if requeue {
 		log.Info("Request is still in progress")
-		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+		return ctrl.Result{RequeueAfter: timeouts.RequestPollInterval}, nil
+	}
+
+	if backoff := remainingProvisioningBackoff(machineScope); backoff > 0 && !machineScope.IonosMachine.Status.Ready {
+		log.V(4).Info("Waiting for the provisioning backoff", "backoff", backoff)
+		return ctrl.Result{RequeueAfter: backoff}, nil
 	}
 
-	// TODO(piepmatz): This is not thread-safe, but needs to be. Add locking.
 	reconcileSequence := []serviceReconcileStep[scope.Machine]{
 		{"ReconcileLAN", cloudService.ReconcileLAN},
+		{"ReconcileIPAddresses", ipamService.ReconcileIPAddresses},
+		{"ReconcileIPBlockAddress", cloudService.ReconcileIPBlockAddress},
+		{"ReconcilePrivateIPAddress", cloudService.ReconcilePrivateIPAddress},
+		{"ReconcileIPv6CIDRBlock", cloudService.ReconcileIPv6CIDRBlock},
 		{"ReconcileServer", cloudService.ReconcileServer},
+		{"ReconcileServerLabels", cloudService.ReconcileServerLabels},
+		{"ReconcileFirewallRules", cloudService.ReconcileFirewallRules},
 		{"ReconcileIPFailover", cloudService.ReconcileIPFailover},
+		{"ReconcileFailoverGroups", cloudService.ReconcileFailoverGroups},
+		{"ReconcileBootstrapExecution", r.reconcileBootstrapExecution},
 		{"FinalizeMachineProvisioning", cloudService.FinalizeMachineProvisioning},
 	}
 
 	for _, step := range reconcileSequence {
-		if requeue, err := step.fn(ctx, machineScope); err != nil || requeue {
+		if requeue, err := step.run(ctx, machineScope); err != nil || requeue {
 			if err != nil {
-				err = fmt.Errorf("error in step %s: %w", step.name, err)
+				return r.provisioningStepFailed(ctx, machineScope, step.name, err)
 			}
 
-			return ctrl.Result{RequeueAfter: defaultReconcileDuration}, err
+			return ctrl.Result{RequeueAfter: timeouts.RequestPollInterval}, nil
 		}
 	}
+	resetProvisioningBackoff(machineScope)
 
-	return ctrl.Result{}, nil
+	if r.reconcileNodeProviderID(ctx, machineScope) {
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	// Changes of the server state are not reported by IONOS Cloud, so we need to check it periodically.
+	return ctrl.Result{RequeueAfter: r.ServerStatePollInterval}, nil
 }
 
 func (r *IonosCloudMachineReconciler) reconcileDelete(
@@ -190,10 +343,21 @@ func (r *IonosCloudMachineReconciler) reconcileDelete(
 ) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// TODO(piepmatz): This is not thread-safe, but needs to be. Add locking.
-	//  Moreover, should only be attempted if it's the last machine using that LAN. We should check that our machines
-	//  at least, but need to accept that users added their own infrastructure into our LAN (in that case a LAN deletion
-	//  attempt will be denied with HTTP 422).
+	// The deletion steps report their progress in the ServerDeleted condition. Therefore, the timeout
+	// is reported once they have run.
+	timeouts := r.Timeouts.forCluster(machineScope.ClusterScope.IonosCluster)
+	timedOut := conditions.GetReason(machineScope.IonosMachine, infrav1.ServerDeletedCondition) ==
+		infrav1.DeletionTimedOutReason
+	defer r.reportDeletionTimeout(machineScope, timeouts.MachineDeletion, timedOut)
+
+	if !r.lockDatacenter(ctx, machineScope) {
+		return ctrl.Result{RequeueAfter: datacenterLockRetryInterval}, nil
+	}
+	defer r.unlockDatacenter(machineScope)
+
+	// TODO(piepmatz): The LAN deletion should only be attempted if it's the last machine using that LAN.
+	//  We should check that our machines at least, but need to accept that users added their own infrastructure
+	//  into our LAN (in that case a LAN deletion attempt will be denied with HTTP 422).
 	requeue, err := r.checkRequestStates(ctx, machineScope, cloudService)
 	if err != nil {
 		// In case the request state cannot be determined, we want to continue with the
@@ -206,6 +370,23 @@ func (r *IonosCloudMachineReconciler) reconcileDelete(
 
 	if requeue {
 		log.Info("Deletion request is still in progress")
+		return ctrl.Result{RequeueAfter: timeouts.RequestPollInterval}, nil
+	}
+
+	if r.isClusterDeletionProtected(ctx, machineScope) {
+		// The annotation is removed from the IonosCloudCluster, which is not watched by the reconciler.
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	lastControlPlaneMachine, err := r.isLastControlPlaneMachine(ctx, machineScope)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to check the remaining control plane machines: %w", err)
+	}
+	if lastControlPlaneMachine {
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	if !r.isNodeDrained(ctx, machineScope) {
 		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
 	}
 
@@ -214,22 +395,54 @@ func (r *IonosCloudMachineReconciler) reconcileDelete(
 		// by a request to delete the server. Therefore, during deletion, we need to remove the NIC from
 		// the IP failover configuration.
 		{"ReconcileIPFailoverDeletion", cloudService.ReconcileIPFailoverDeletion},
+		{"ReconcileFailoverGroupsDeletion", cloudService.ReconcileFailoverGroupsDeletion},
 		{"ReconcileServerDeletion", cloudService.ReconcileServerDeletion},
 		{"ReconcileLANDeletion", cloudService.ReconcileLANDeletion},
 		{"ReconcileFailoverIPBlockDeletion", cloudService.ReconcileFailoverIPBlockDeletion},
 	}
 
 	for _, step := range reconcileSequence {
-		if requeue, err := step.fn(ctx, machineScope); err != nil || requeue {
+		if requeue, err := step.run(ctx, machineScope); err != nil || requeue {
 			if err != nil {
-				err = fmt.Errorf("error in step %s: %w", step.name, err)
+				return stepFailedResult(ctx, r.Recorder, machineScope.IonosMachine, step.name, err)
 			}
 
-			return ctrl.Result{RequeueAfter: defaultReconcileDuration}, err
+			return ctrl.Result{RequeueAfter: timeouts.RequestPollInterval}, nil
 		}
 	}
 
 	controllerutil.RemoveFinalizer(machineScope.IonosMachine, infrav1.MachineFinalizer)
+	if machineScope.ClusterScope.IsDeleted() {
+		// The client is created again for the remaining machines of the cluster.
+		r.ClientCache.Forget(machineScope.ClusterScope.IonosCluster)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcilePaused only tracks the pending request of a paused machine. Once it has completed,
+// the machine doesn't block clusterctl move anymore.
+func (r *IonosCloudMachineReconciler) reconcilePaused(
+	ctx context.Context, machineScope *scope.Machine, cloudService *cloud.Service,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	req := machineScope.CurrentRequest()
+	if req == nil {
+		return ctrl.Result{}, nil
+	}
+
+	timeouts := r.Timeouts.forCluster(machineScope.ClusterScope.IonosCluster)
+	requeue, err := pollRequest(ctx, cloudService, r.Recorder, machineScope.IonosMachine, req, func() error {
+		machineScope.IonosMachine.DeleteCurrentRequest()
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error when trying to determine in-flight request states: %w", err)
+	}
+	if requeue {
+		log.Info("Machine is paused, waiting for pending request to complete")
+		return ctrl.Result{RequeueAfter: timeouts.RequestPollInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -240,50 +453,102 @@ func (r *IonosCloudMachineReconciler) reconcileDelete(
 //   - Queued, Running => Requeue the current request
 //   - Failed => Log the error and continue also apply the same logic as in Done.
 //   - Done => Clear request from the status and continue reconciliation.
-func (*IonosCloudMachineReconciler) checkRequestStates(
+//   - Not found => The request expired, apply the same logic as in Done.
+//
+// Requests of the machine, which are queued or running for longer than the stuck request timeout,
+// are handled by handleStuckRequest.
+func (r *IonosCloudMachineReconciler) checkRequestStates(
 	ctx context.Context,
 	machineScope *scope.Machine,
 	cloudService *cloud.Service,
 ) (requeue bool, retErr error) {
 	log := ctrl.LoggerFrom(ctx)
-	// check cluster wide request
+	// check cluster wide request. The status of an externally managed cluster is not written by the provider.
 	ionosCluster := machineScope.ClusterScope.IonosCluster
-	if req, exists := ionosCluster.Status.CurrentRequestByDatacenter[machineScope.DatacenterID()]; exists {
-		status, message, err := cloudService.GetRequestStatus(ctx, req.RequestPath)
-		if err != nil {
-			retErr = fmt.Errorf("could not get request status: %w", err)
-		} else {
-			requeue, retErr = withStatus(status, message, &log,
-				func() error {
-					// remove the request from the status and patch the cluster
-					ionosCluster.DeleteCurrentRequestByDatacenter(machineScope.DatacenterID())
-					return machineScope.ClusterScope.PatchObject()
-				},
-			)
+	datacenterID := machineScope.DatacenterID()
+	req, exists := ionosCluster.Status.CurrentRequestByDatacenter[datacenterID]
+	if exists && !annotations.IsExternallyManaged(ionosCluster) {
+		requeue, retErr = pollRequest(ctx, cloudService, r.Recorder, ionosCluster, &req, func() error {
+			// remove the request from the status and patch the cluster
+			ionosCluster.DeleteCurrentRequestByDatacenter(datacenterID)
+			return machineScope.ClusterScope.PatchObject()
+		})
+		if requeue {
+			ionosCluster.Status.CurrentRequestByDatacenter[datacenterID] = req
 		}
 	}
 
 	// check machine related request
-	if req := machineScope.IonosMachine.Status.CurrentRequest; req != nil {
-		status, message, err := cloudService.GetRequestStatus(ctx, req.RequestPath)
-		if err != nil {
-			retErr = errors.Join(retErr, fmt.Errorf("could not get request status: %w", err))
-		} else {
-			requeue, _ = withStatus(status, message, &log,
-				func() error {
-					// no need to patch the machine here as it will be patched
-					// after the machine reconciliation is done.
-					log.V(4).Info("Request is done, clearing it from the status")
-					machineScope.IonosMachine.DeleteCurrentRequest()
-					return nil
-				},
-			)
+	cloudService.IgnoreRequests(machineScope.IonosMachine.AbandonedRequestIDs()...)
+	if req := machineScope.CurrentRequest(); req != nil {
+		machineRequeue, err := pollRequest(ctx, cloudService, r.Recorder, machineScope.IonosMachine, req, func() error {
+			// no need to patch the machine here as it will be patched
+			// after the machine reconciliation is done.
+			log.V(4).Info("Request is done, clearing it from the status")
+			if req.State == sdk.RequestStatusFailed && !machineScope.IonosMachine.Status.Ready &&
+				machineScope.IonosMachine.DeletionTimestamp.IsZero() {
+				recordProvisioningFailure(ctx, machineScope)
+			}
+			machineScope.IonosMachine.DeleteCurrentRequest()
+			return nil
+		})
+		if machineRequeue {
+			machineRequeue = r.handleStuckRequest(ctx, machineScope, cloudService)
 		}
+		requeue = requeue || machineRequeue
+		retErr = errors.Join(retErr, err)
 	}
 
 	return requeue, retErr
 }
 
+// handleStuckRequest stops waiting for the pending request of the machine, if it has been tracked for longer
+// than the stuck request timeout. It returns whether the machine still has to wait for the request.
+//
+// PATCH and DELETE requests are abandoned and ignored from then on. The reconciliation looks up the affected
+// resources again and issues the operation again, if it has not taken effect yet. Issuing other requests again
+// could create duplicate resources. Therefore, the machine is marked as failed instead, so that it can be
+// remediated. A machine, which is being deleted, keeps waiting for these requests, as the resources it is
+// waiting for would otherwise be left behind.
+func (r *IonosCloudMachineReconciler) handleStuckRequest(
+	ctx context.Context, ms *scope.Machine, cloudService *cloud.Service,
+) (requeue bool) {
+	timeout := r.Timeouts.forCluster(ms.ClusterScope.IonosCluster).StuckRequest
+	req := ms.CurrentRequest()
+	if !exceeded(ms.IonosMachine.CurrentRequestTimestamp(), timeout) {
+		return true
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("method", req.Method, "requestPath", req.RequestPath,
+		"requestID", req.ID(), "state", req.State, "timeout", timeout)
+	message := fmt.Sprintf("request %s %s has been %s for more than %s, request ID: %s",
+		req.Method, req.RequestPath, req.State, timeout, req.ID())
+
+	switch {
+	case req.Method == http.MethodPatch || req.Method == http.MethodDelete:
+		log.Info("Request is stuck, issuing it again")
+		cloudService.IgnoreRequests(req.ID())
+		ms.IonosMachine.AbandonCurrentRequest()
+		r.recordRequestStuck(ms, message+", issuing it again")
+		return false
+	case ms.IonosMachine.DeletionTimestamp.IsZero() && !ms.HasFailed():
+		log.Info("Request is stuck, marking machine as failed")
+		reason := capierrors.UpdateMachineError
+		if !ms.IonosMachine.Status.Ready {
+			reason = capierrors.CreateMachineError
+		}
+		ms.SetFailure(reason, message)
+		r.recordRequestStuck(ms, message)
+	}
+	return true
+}
+
+func (r *IonosCloudMachineReconciler) recordRequestStuck(ms *scope.Machine, message string) {
+	if r.Recorder != nil {
+		r.Recorder.Event(ms.IonosMachine, corev1.EventTypeWarning, requestStuckReason, message)
+	}
+}
+
 func (*IonosCloudMachineReconciler) isInfrastructureReady(ctx context.Context, ms *scope.Machine) bool {
 	log := ctrl.LoggerFrom(ctx)
 	// Make sure the infrastructure is ready.
@@ -291,7 +556,7 @@ func (*IonosCloudMachineReconciler) isInfrastructureReady(ctx context.Context, m
 		log.Info("Cluster infrastructure is not ready yet")
 		conditions.MarkFalse(
 			ms.IonosMachine,
-			infrav1.MachineProvisionedCondition,
+			infrav1.ServerCreatedCondition,
 			infrav1.WaitingForClusterInfrastructureReason,
 			clusterv1.ConditionSeverityInfo, "")
 
@@ -299,11 +564,11 @@ func (*IonosCloudMachineReconciler) isInfrastructureReady(ctx context.Context, m
 	}
 
 	// Make sure to wait until the data secret was created
-	if ms.Machine.Spec.Bootstrap.DataSecretName == nil {
+	if ms.BootstrapDataSecretName() == "" {
 		log.Info("Bootstrap data secret is not available yet")
 		conditions.MarkFalse(
 			ms.IonosMachine,
-			infrav1.MachineProvisionedCondition,
+			infrav1.BootstrapDeliveredCondition,
 			infrav1.WaitingForBootstrapDataReason,
 			clusterv1.ConditionSeverityInfo, "",
 		)
@@ -314,49 +579,206 @@ func (*IonosCloudMachineReconciler) isInfrastructureReady(ctx context.Context, m
 	return true
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *IonosCloudMachineReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&infrav1.IonosCloudMachine{}).
-		Watches(
-			&clusterv1.Machine{},
-			handler.EnqueueRequestsFromMapFunc(
-				util.MachineToInfrastructureMapFunc(infrav1.GroupVersion.WithKind(infrav1.IonosCloudMachineType)))).
-		Complete(reconcile.AsReconciler[*infrav1.IonosCloudMachine](r.Client, r))
-}
+// isNodeDrained checks whether Cluster API has finished draining the node of the machine and
+// waiting for its volumes to be detached. The VM must not be deleted before, as this would kill
+// the workloads on the node and could corrupt the data of volumes, which are still in use.
+// If the Cluster API machine is not being deleted, e.g. because the IonosCloudMachine was deleted
+// directly, there is no drain to wait for. Like Cluster API, it stops waiting once the drain or the
+// volume detachment has timed out or is excluded by an annotation, as the machine would be stuck otherwise.
+func (r *IonosCloudMachineReconciler) isNodeDrained(ctx context.Context, ms *scope.Machine) bool {
+	log := ctrl.LoggerFrom(ctx)
+	machine := ms.Machine
+	if machine.Status.NodeRef == nil || machine.DeletionTimestamp.IsZero() {
+		return true
+	}
 
-func (r *IonosCloudMachineReconciler) getClusterScope(
-	ctx context.Context, cluster *clusterv1.Cluster, ionosCloudMachine *infrav1.IonosCloudMachine,
-) (*scope.Cluster, error) {
-	var clusterScope *scope.Cluster
-	var err error
+	if conditions.IsFalse(machine, clusterv1.DrainingSucceededCondition) &&
+		!waitSkipped(machine, clusterv1.ExcludeNodeDrainingAnnotation,
+			clusterv1.DrainingSucceededCondition, machine.Spec.NodeDrainTimeout) {
+		log.Info("Waiting for the node to be drained", "node", machine.Status.NodeRef.Name)
+		r.recordDeletionBlocked(ms, infrav1.WaitingForNodeDrainReason,
+			"Waiting for node %s to be drained before deleting the server")
+		conditions.MarkFalse(
+			ms.IonosMachine,
+			infrav1.ServerDeletedCondition,
+			infrav1.WaitingForNodeDrainReason,
+			clusterv1.ConditionSeverityInfo, "",
+		)
 
-	ionosCloudCluster := &infrav1.IonosCloudCluster{}
+		return false
+	}
+
+	if conditions.IsFalse(machine, clusterv1.VolumeDetachSucceededCondition) &&
+		!waitSkipped(machine, clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation,
+			clusterv1.VolumeDetachSucceededCondition, machine.Spec.NodeVolumeDetachTimeout) {
+		log.Info("Waiting for the volumes to be detached from the node", "node", machine.Status.NodeRef.Name)
+		r.recordDeletionBlocked(ms, infrav1.WaitingForVolumeDetachReason,
+			"Waiting for the volumes to be detached from node %s before deleting the server")
+		conditions.MarkFalse(
+			ms.IonosMachine,
+			infrav1.ServerDeletedCondition,
+			infrav1.WaitingForVolumeDetachReason,
+			clusterv1.ConditionSeverityInfo, "",
+		)
 
-	infraClusterName := client.ObjectKey{
-		Namespace: ionosCloudMachine.Namespace,
-		Name:      cluster.Spec.InfrastructureRef.Name,
+		return false
 	}
 
-	if err := r.Client.Get(ctx, infraClusterName, ionosCloudCluster); err != nil {
-		if apierrors.IsNotFound(err) {
-			// Cluster has not yet been created
-			return nil, nil
+	return true
+}
+
+// waitSkipped checks whether Cluster API skips waiting for the condition of the machine, because the
+// annotation is set or the timeout has passed since the condition changed, see isNodeDrainAllowed
+// and nodeDrainTimeoutExceeded of the Cluster API machine controller.
+func waitSkipped(
+	machine *clusterv1.Machine, annotation string, conditionType clusterv1.ConditionType, timeout *metav1.Duration,
+) bool {
+	if _, ok := machine.Annotations[annotation]; ok {
+		return true
+	}
+	if timeout == nil || timeout.Duration <= 0 {
+		return false
+	}
+	return time.Since(conditions.GetLastTransitionTime(machine, conditionType).Time) >= timeout.Duration
+}
+
+// isLastControlPlaneMachine checks whether the machine is the last healthy control plane machine of a cluster,
+// which is not being deleted. The deletion of its server is blocked, as it would wipe out the control plane,
+// e.g. because of a misconfigured MachineHealthCheck or an accidental deletion. Machines, which are not provisioned
+// or have failed, are not considered healthy and can always be deleted.
+func (r *IonosCloudMachineReconciler) isLastControlPlaneMachine(ctx context.Context, ms *scope.Machine) (bool, error) {
+	if !util.IsControlPlaneMachine(ms.Machine) || ms.ClusterScope.IsDeleted() || !isHealthyMachine(ms.IonosMachine) {
+		return false, nil
+	}
+
+	machines, err := ms.ListMachines(ctx, client.MatchingLabels{clusterv1.MachineControlPlaneLabel: ""})
+	if err != nil {
+		return false, err
+	}
+	for i := range machines {
+		if machines[i].Name != ms.IonosMachine.Name && machines[i].DeletionTimestamp.IsZero() &&
+			isHealthyMachine(&machines[i]) {
+			return false, nil
 		}
-		// We at most expect that the cluster cannot be found.
-		// If the error is different, we should return that particular error.
-		return nil, err
 	}
 
-	// Create the cluster scope
-	clusterScope, err = scope.NewCluster(scope.ClusterParams{
-		Client:       r.Client,
-		Cluster:      cluster,
-		IonosCluster: ionosCloudCluster,
+	ctrl.LoggerFrom(ctx).Info("Machine is the last healthy control plane machine, blocking the deletion of its server")
+	if r.Recorder != nil &&
+		conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition) != infrav1.LastControlPlaneMachineReason {
+		r.Recorder.Event(ms.IonosMachine, corev1.EventTypeWarning, deletionBlockedReason,
+			"Deletion of the server is blocked, as it hosts the last healthy control plane machine of the cluster")
+	}
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition, infrav1.LastControlPlaneMachineReason,
+		clusterv1.ConditionSeverityWarning, "the last healthy control plane machine is only deleted with the cluster")
+	return true, nil
+}
+
+// isClusterDeletionProtected checks whether the machine is deleted together with its cluster, while the
+// IonosCloudCluster is protected by DeletionProtectionAnnotation. Cluster API deletes all machines before the
+// IonosCloudCluster, so their servers are kept as well, until the annotation is removed.
+func (r *IonosCloudMachineReconciler) isClusterDeletionProtected(ctx context.Context, ms *scope.Machine) bool {
+	if !ms.ClusterScope.IsDeleted() || !isDeletionProtected(ms.ClusterScope.IonosCluster) {
+		return false
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Deletion is blocked by the deletion protection of the cluster",
+		"annotation", infrav1.DeletionProtectionAnnotation)
+	if r.Recorder != nil &&
+		conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition) != infrav1.DeletionProtectedReason {
+		r.Recorder.Eventf(ms.IonosMachine, corev1.EventTypeWarning, deletionBlockedReason,
+			"Deletion of the server is blocked by the annotation %s of the cluster",
+			infrav1.DeletionProtectionAnnotation)
+	}
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition, infrav1.DeletionProtectedReason,
+		clusterv1.ConditionSeverityWarning, "remove the annotation %s from the IonosCloudCluster to delete the server",
+		infrav1.DeletionProtectionAnnotation)
+	return true
+}
+
+// isHealthyMachine returns whether the machine is provisioned and has not failed.
+func isHealthyMachine(m *infrav1.IonosCloudMachine) bool {
+	return m.Status.Ready && m.Status.FailureReason == nil && m.Status.FailureMessage == nil
+}
+
+// recordDeletionBlocked records a DeletionBlocked event, when the deletion of the server starts waiting
+// for the given reason. The event is not repeated while the machine keeps waiting.
+func (r *IonosCloudMachineReconciler) recordDeletionBlocked(ms *scope.Machine, reason, messageFmt string) {
+	if r.Recorder == nil || conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition) == reason {
+		return
+	}
+	r.Recorder.Eventf(ms.IonosMachine, corev1.EventTypeNormal, deletionBlockedReason,
+		messageFmt, ms.Machine.Status.NodeRef.Name)
+}
+
+// reportDeletionTimeout marks the ServerDeleted condition of a machine, whose deletion exceeded the timeout,
+// with reason DeletionTimedOut, as long as the deletion has not completed. The warning event is only recorded,
+// if the timeout was not reported before.
+func (r *IonosCloudMachineReconciler) reportDeletionTimeout(ms *scope.Machine, timeout time.Duration, reported bool) {
+	if !controllerutil.ContainsFinalizer(ms.IonosMachine, infrav1.MachineFinalizer) ||
+		!exceeded(ms.IonosMachine.DeletionTimestamp, timeout) {
+		return
+	}
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition, infrav1.DeletionTimedOutReason,
+		clusterv1.ConditionSeverityError, "machine was not deleted within %s", timeout)
+	if r.Recorder != nil && !reported {
+		r.Recorder.Eventf(ms.IonosMachine, corev1.EventTypeWarning, infrav1.DeletionTimedOutReason,
+			"Machine was not deleted within %s", timeout)
+	}
+}
+
+// usesIPAMPool returns whether one of the NICs of the machine references an IPAM pool.
+func usesIPAMPool(m *infrav1.IonosCloudMachine) bool {
+	if m.Spec.IPv4PoolRef != nil {
+		return true
+	}
+	return slices.ContainsFunc(m.Spec.AdditionalNetworks, func(n infrav1.Network) bool {
+		return n.IPv4PoolRef != nil
 	})
+}
+
+// lockDatacenter acquires the lock of the data center of the machine. It returns false, if another machine
+// in the same data center is being reconciled.
+func (r *IonosCloudMachineReconciler) lockDatacenter(ctx context.Context, ms *scope.Machine) bool {
+	if r.DatacenterLocks == nil || r.DatacenterLocks.TryLock(ms.DatacenterID()) {
+		return true
+	}
+	ctrl.LoggerFrom(ctx).V(4).Info("Another machine in the data center is being reconciled",
+		"datacenterID", ms.DatacenterID())
+	return false
+}
+
+func (r *IonosCloudMachineReconciler) unlockDatacenter(ms *scope.Machine) {
+	if r.DatacenterLocks != nil {
+		r.DatacenterLocks.Unlock(ms.DatacenterID())
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IonosCloudMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	clusterToIonosCloudMachines, err := util.ClusterToTypedObjectsMapper(
+		r.Client, &infrav1.IonosCloudMachineList{}, mgr.GetScheme())
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cluster scope: %w", err)
+		return fmt.Errorf("failed to create mapper for Cluster to IonosCloudMachines: %w", err)
 	}
 
-	return clusterScope, nil
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.IonosCloudMachine{}).
+		WithEventFilter(predicates.ResourceNotPaused(ctrl.LoggerFrom(ctx)))
+	// The IPAM CRDs of Cluster API are only required, if IPAM is enabled.
+	if feature.Gates.Enabled(feature.IPAM) {
+		b = b.Owns(&ipamv1.IPAddressClaim{})
+	}
+	return b.
+		Watches(
+			&clusterv1.Machine{},
+			handler.EnqueueRequestsFromMapFunc(
+				util.MachineToInfrastructureMapFunc(infrav1.GroupVersion.WithKind(infrav1.IonosCloudMachineType)))).
+		// Machines need to be reconciled again once their cluster is unpaused.
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToIonosCloudMachines),
+			builder.WithPredicates(predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx))),
+		).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(reconcile.AsReconciler[*infrav1.IonosCloudMachine](r.Client, r))
 }
@@ -0,0 +1,535 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/locker"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+func TestIsNodeDrained(t *testing.T) {
+	tests := []struct {
+		name        string
+		deleting    bool
+		nodeRef     *corev1.ObjectReference
+		annotations map[string]string
+		spec        clusterv1.MachineSpec
+		conditions  clusterv1.Conditions
+		wantDrained bool
+		wantReason  string
+	}{
+		{
+			name:        "machine without node",
+			deleting:    true,
+			wantDrained: true,
+		},
+		{
+			name:        "machine is not being deleted",
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+			conditions:  clusterv1.Conditions{*drainingCondition()},
+			wantDrained: true,
+		},
+		{
+			name:       "node is being drained",
+			deleting:   true,
+			nodeRef:    &corev1.ObjectReference{Name: "node"},
+			conditions: clusterv1.Conditions{*drainingCondition()},
+			wantReason: infrav1.WaitingForNodeDrainReason,
+		},
+		{
+			name:     "volumes are being detached",
+			deleting: true,
+			nodeRef:  &corev1.ObjectReference{Name: "node"},
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+				*volumeDetachCondition(),
+			},
+			wantReason: infrav1.WaitingForVolumeDetachReason,
+		},
+		{
+			name:     "node is drained",
+			deleting: true,
+			nodeRef:  &corev1.ObjectReference{Name: "node"},
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+				*conditions.TrueCondition(clusterv1.VolumeDetachSucceededCondition),
+			},
+			wantDrained: true,
+		},
+		{
+			name:        "node draining is skipped",
+			deleting:    true,
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+			wantDrained: true,
+		},
+		{
+			name:       "node drain timeout is not exceeded",
+			deleting:   true,
+			nodeRef:    &corev1.ObjectReference{Name: "node"},
+			spec:       clusterv1.MachineSpec{NodeDrainTimeout: &metav1.Duration{Duration: time.Hour}},
+			conditions: clusterv1.Conditions{*drainingCondition()},
+			wantReason: infrav1.WaitingForNodeDrainReason,
+		},
+		{
+			name:        "node drain timeout is exceeded",
+			deleting:    true,
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+			spec:        clusterv1.MachineSpec{NodeDrainTimeout: &metav1.Duration{Duration: time.Minute}},
+			conditions:  clusterv1.Conditions{*drainingCondition()},
+			wantDrained: true,
+		},
+		{
+			name:        "node draining is excluded",
+			deleting:    true,
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+			annotations: map[string]string{clusterv1.ExcludeNodeDrainingAnnotation: ""},
+			conditions:  clusterv1.Conditions{*drainingCondition()},
+			wantDrained: true,
+		},
+		{
+			name:     "volume detach timeout is exceeded",
+			deleting: true,
+			nodeRef:  &corev1.ObjectReference{Name: "node"},
+			spec:     clusterv1.MachineSpec{NodeVolumeDetachTimeout: &metav1.Duration{Duration: time.Minute}},
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+				*volumeDetachCondition(),
+			},
+			wantDrained: true,
+		},
+		{
+			name:        "waiting for volume detach is excluded",
+			deleting:    true,
+			nodeRef:     &corev1.ObjectReference{Name: "node"},
+			annotations: map[string]string{clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation: ""},
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+				*volumeDetachCondition(),
+			},
+			wantDrained: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Finalizers:  []string{clusterv1.MachineFinalizer},
+					Annotations: tt.annotations,
+				},
+				Spec: tt.spec,
+				Status: clusterv1.MachineStatus{
+					NodeRef:    tt.nodeRef,
+					Conditions: tt.conditions,
+				},
+			}
+			if tt.deleting {
+				machine.DeletionTimestamp = ptr.To(metav1.Now())
+			}
+			ms := &scope.Machine{
+				Machine:      machine,
+				IonosMachine: &infrav1.IonosCloudMachine{},
+			}
+
+			recorder := record.NewFakeRecorder(2)
+			r := &IonosCloudMachineReconciler{Recorder: recorder}
+			require.Equal(t, tt.wantDrained, r.isNodeDrained(context.Background(), ms))
+			if tt.wantReason == "" {
+				require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.ServerDeletedCondition))
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.True(t, conditions.IsFalse(ms.IonosMachine, infrav1.ServerDeletedCondition))
+			require.Equal(t, tt.wantReason, conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition))
+			require.Contains(t, <-recorder.Events, "Normal DeletionBlocked")
+
+			// The event is only recorded once while the deletion is blocked.
+			require.False(t, r.isNodeDrained(context.Background(), ms))
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestReconcileNormalProvisioningTimeout(t *testing.T) {
+	ms := &scope.Machine{
+		Machine: &clusterv1.Machine{},
+		IonosMachine: &infrav1.IonosCloudMachine{ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}},
+		ClusterScope: &scope.Cluster{IonosCluster: &infrav1.IonosCloudCluster{}},
+	}
+	recorder := record.NewFakeRecorder(1)
+	r := &IonosCloudMachineReconciler{Recorder: recorder, Timeouts: Timeouts{MachineProvisioning: 30 * time.Minute}}
+
+	// The services are not needed, as the reconciliation stops once the machine has failed.
+	res, err := r.reconcileNormal(context.Background(), nil, nil, ms)
+	require.NoError(t, err)
+	require.Zero(t, res)
+	require.True(t, ms.HasFailed())
+	require.Equal(t, "machine was not provisioned within 30m0s", *ms.IonosMachine.Status.FailureMessage)
+	require.Contains(t, <-recorder.Events, "Warning ProvisioningTimedOut")
+}
+
+func TestReportDeletionTimeout(t *testing.T) {
+	ms := &scope.Machine{IonosMachine: &infrav1.IonosCloudMachine{ObjectMeta: metav1.ObjectMeta{
+		DeletionTimestamp: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+		Finalizers:        []string{infrav1.MachineFinalizer},
+	}}}
+	recorder := record.NewFakeRecorder(2)
+	r := &IonosCloudMachineReconciler{Recorder: recorder}
+
+	r.reportDeletionTimeout(ms, 2*time.Hour, false)
+	require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.ServerDeletedCondition))
+
+	for _, reported := range []bool{false, true} {
+		// The deletion steps report their progress in the same condition.
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+			infrav1.DeletingVolumesReason, clusterv1.ConditionSeverityInfo, "")
+		r.reportDeletionTimeout(ms, 30*time.Minute, reported)
+		require.True(t, conditions.IsFalse(ms.IonosMachine, infrav1.ServerDeletedCondition))
+		require.Equal(t, infrav1.DeletionTimedOutReason,
+			conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition))
+	}
+	require.Len(t, recorder.Events, 1, "the event must only be recorded once")
+	require.Contains(t, <-recorder.Events, "Warning DeletionTimedOut")
+
+	ms.IonosMachine.Finalizers = nil
+	conditions.Delete(ms.IonosMachine, infrav1.ServerDeletedCondition)
+	r.reportDeletionTimeout(ms, 30*time.Minute, false)
+	require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.ServerDeletedCondition))
+}
+
+func TestHandleStuckRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		age          time.Duration
+		ready        bool
+		deleting     bool
+		wantRequeue  bool
+		wantAbandon  bool
+		wantFailure  capierrors.MachineStatusError
+		wantNoEvents bool
+	}{{
+		name:         "request is not stuck",
+		method:       http.MethodDelete,
+		age:          time.Minute,
+		wantRequeue:  true,
+		wantNoEvents: true,
+	}, {
+		name:        "stuck DELETE request is issued again",
+		method:      http.MethodDelete,
+		age:         time.Hour,
+		deleting:    true,
+		wantAbandon: true,
+	}, {
+		name:        "stuck PATCH request is issued again",
+		method:      http.MethodPatch,
+		age:         time.Hour,
+		ready:       true,
+		wantAbandon: true,
+	}, {
+		name:        "stuck POST request fails machine",
+		method:      http.MethodPost,
+		age:         time.Hour,
+		wantRequeue: true,
+		wantFailure: capierrors.CreateMachineError,
+	}, {
+		name:        "stuck POST request fails provisioned machine",
+		method:      http.MethodPost,
+		age:         time.Hour,
+		ready:       true,
+		wantRequeue: true,
+		wantFailure: capierrors.UpdateMachineError,
+	}, {
+		name:         "stuck POST request of deleted machine",
+		method:       http.MethodPost,
+		age:          time.Hour,
+		deleting:     true,
+		wantRequeue:  true,
+		wantNoEvents: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ionosMachine := &infrav1.IonosCloudMachine{}
+			ionosMachine.Status.Ready = tt.ready
+			if tt.deleting {
+				ionosMachine.DeletionTimestamp = ptr.To(metav1.Now())
+			}
+			ionosMachine.SetCurrentRequest(tt.method, sdk.RequestStatusQueued,
+				"https://api.ionos.com/cloudapi/v6/requests/request-0/status")
+			ionosMachine.Status.RecentRequests[0].CreationTimestamp = metav1.NewTime(time.Now().Add(-tt.age))
+			ms := &scope.Machine{
+				IonosMachine: ionosMachine,
+				ClusterScope: &scope.Cluster{IonosCluster: &infrav1.IonosCloudCluster{}},
+			}
+
+			cloudService, err := cloud.NewService(clienttest.NewMockClient(t), logr.Discard())
+			require.NoError(t, err)
+			recorder := record.NewFakeRecorder(1)
+			r := &IonosCloudMachineReconciler{Recorder: recorder, Timeouts: Timeouts{StuckRequest: 30 * time.Minute}}
+
+			require.Equal(t, tt.wantRequeue, r.handleStuckRequest(context.Background(), ms, cloudService))
+			require.Equal(t, tt.wantAbandon, ms.CurrentRequest() == nil)
+			require.Equal(t, tt.wantAbandon, ionosMachine.Status.RecentRequests[0].Abandoned)
+			if tt.wantFailure != "" {
+				require.Equal(t, tt.wantFailure, *ionosMachine.Status.FailureReason)
+				require.Contains(t, *ionosMachine.Status.FailureMessage, "has been QUEUED for more than 30m0s")
+			} else {
+				require.False(t, ms.HasFailed())
+			}
+			if tt.wantNoEvents {
+				require.Empty(t, recorder.Events)
+			} else {
+				require.Contains(t, <-recorder.Events, "Warning RequestStuck")
+			}
+		})
+	}
+}
+
+func TestCheckRequestStatesExternallyManaged(t *testing.T) {
+	ionosCluster := &infrav1.IonosCloudCluster{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{clusterv1.ManagedByAnnotation: "terraform"},
+	}}
+	ionosCluster.SetCurrentRequestByDatacenter("dc", http.MethodPost, sdk.RequestStatusQueued,
+		"https://api.ionos.com/cloudapi/v6/requests/request-0/status")
+	ionosMachine := &infrav1.IonosCloudMachine{Spec: infrav1.IonosCloudMachineSpec{DatacenterID: "dc"}}
+	ms := &scope.Machine{IonosMachine: ionosMachine, ClusterScope: &scope.Cluster{IonosCluster: ionosCluster}}
+
+	// The mock fails the test, if the request of the cluster is polled.
+	cloudService, err := cloud.NewService(clienttest.NewMockClient(t), logr.Discard())
+	require.NoError(t, err)
+	r := &IonosCloudMachineReconciler{Recorder: record.NewFakeRecorder(1)}
+
+	requeue, err := r.checkRequestStates(context.Background(), ms, cloudService)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Contains(t, ionosCluster.Status.CurrentRequestByDatacenter, "dc",
+		"the status of an externally managed cluster must not be changed")
+}
+
+func TestIsLastControlPlaneMachine(t *testing.T) {
+	controlPlaneMachine := func(name string, ready bool) *infrav1.IonosCloudMachine {
+		return &infrav1.IonosCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:         "test-cluster",
+					clusterv1.MachineControlPlaneLabel: "",
+				},
+			},
+			Status: infrav1.IonosCloudMachineStatus{Ready: ready},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		worker         bool
+		notReady       bool
+		clusterDeleted bool
+		others         []*infrav1.IonosCloudMachine
+		wantBlocked    bool
+	}{{
+		name:        "last control plane machine",
+		wantBlocked: true,
+	}, {
+		name:        "other control plane machine is not ready",
+		others:      []*infrav1.IonosCloudMachine{controlPlaneMachine("other", false)},
+		wantBlocked: true,
+	}, {
+		name: "other control plane machine is being deleted",
+		others: []*infrav1.IonosCloudMachine{func() *infrav1.IonosCloudMachine {
+			m := controlPlaneMachine("other", true)
+			m.DeletionTimestamp = ptr.To(metav1.Now())
+			m.Finalizers = []string{infrav1.MachineFinalizer}
+			return m
+		}()},
+		wantBlocked: true,
+	}, {
+		name:   "other control plane machine is ready",
+		others: []*infrav1.IonosCloudMachine{controlPlaneMachine("other", true)},
+	}, {
+		name:           "cluster is being deleted",
+		clusterDeleted: true,
+	}, {
+		name:     "machine is not ready",
+		notReady: true,
+	}, {
+		name:   "worker machine",
+		worker: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, clusterv1.AddToScheme(scheme))
+			require.NoError(t, infrav1.AddToScheme(scheme))
+
+			ionosMachine := controlPlaneMachine("machine", !tt.notReady)
+			ionosMachine.DeletionTimestamp = ptr.To(metav1.Now())
+			ionosMachine.Finalizers = []string{infrav1.MachineFinalizer}
+			machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Labels: ionosMachine.Labels}}
+			if tt.worker {
+				machine.Labels = nil
+			}
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ionosMachine)
+			for _, other := range tt.others {
+				builder = builder.WithObjects(other)
+			}
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault}}
+			if tt.clusterDeleted {
+				cluster.DeletionTimestamp = ptr.To(metav1.Now())
+			}
+			clusterScope, err := scope.NewCluster(scope.ClusterParams{
+				Client:       builder.Build(),
+				Cluster:      cluster,
+				IonosCluster: &infrav1.IonosCloudCluster{},
+			})
+			require.NoError(t, err)
+			ms := &scope.Machine{Machine: machine, IonosMachine: ionosMachine, ClusterScope: clusterScope}
+
+			recorder := record.NewFakeRecorder(2)
+			r := &IonosCloudMachineReconciler{Recorder: recorder}
+			blocked, err := r.isLastControlPlaneMachine(context.Background(), ms)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBlocked, blocked)
+			if !tt.wantBlocked {
+				require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.ServerDeletedCondition))
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.Equal(t, infrav1.LastControlPlaneMachineReason,
+				conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition))
+			require.Contains(t, <-recorder.Events, "Warning DeletionBlocked")
+
+			// The event is only recorded once while the deletion is blocked.
+			_, err = r.isLastControlPlaneMachine(context.Background(), ms)
+			require.NoError(t, err)
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+func TestIsClusterDeletionProtected(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterDeleted bool
+		protected      bool
+		wantBlocked    bool
+	}{
+		{name: "cluster is deleted", clusterDeleted: true},
+		{name: "machine of a protected cluster is deleted", protected: true},
+		{name: "protected cluster is deleted", clusterDeleted: true, protected: true, wantBlocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &clusterv1.Cluster{}
+			if tt.clusterDeleted {
+				cluster.DeletionTimestamp = ptr.To(metav1.Now())
+			}
+			ionosCluster := &infrav1.IonosCloudCluster{}
+			if tt.protected {
+				ionosCluster.Annotations = map[string]string{infrav1.DeletionProtectionAnnotation: "true"}
+			}
+			ms := &scope.Machine{
+				IonosMachine: &infrav1.IonosCloudMachine{},
+				ClusterScope: &scope.Cluster{Cluster: cluster, IonosCluster: ionosCluster},
+			}
+
+			recorder := record.NewFakeRecorder(2)
+			r := &IonosCloudMachineReconciler{Recorder: recorder}
+			require.Equal(t, tt.wantBlocked, r.isClusterDeletionProtected(context.Background(), ms))
+			if !tt.wantBlocked {
+				require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.ServerDeletedCondition))
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.Equal(t, infrav1.DeletionProtectedReason,
+				conditions.GetReason(ms.IonosMachine, infrav1.ServerDeletedCondition))
+			require.Contains(t, <-recorder.Events, "Warning DeletionBlocked")
+
+			// The event is only recorded once while the deletion is blocked.
+			require.True(t, r.isClusterDeletionProtected(context.Background(), ms))
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
+
+// drainingCondition returns the condition of a drain, which was started ten minutes ago.
+func drainingCondition() *clusterv1.Condition {
+	condition := conditions.FalseCondition(clusterv1.DrainingSucceededCondition,
+		clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "")
+	condition.LastTransitionTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	return condition
+}
+
+// volumeDetachCondition returns the condition of a volume detachment, which was started ten minutes ago.
+func volumeDetachCondition() *clusterv1.Condition {
+	condition := conditions.FalseCondition(clusterv1.VolumeDetachSucceededCondition,
+		clusterv1.WaitingForVolumeDetachReason, clusterv1.ConditionSeverityInfo, "")
+	condition.LastTransitionTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	return condition
+}
+
+func TestLockDatacenter(t *testing.T) {
+	machineInDatacenter := func(datacenterID string) *scope.Machine {
+		return &scope.Machine{IonosMachine: &infrav1.IonosCloudMachine{
+			Spec: infrav1.IonosCloudMachineSpec{DatacenterID: datacenterID},
+		}}
+	}
+	first := machineInDatacenter("dc-1")
+	second := machineInDatacenter("dc-1")
+	other := machineInDatacenter("dc-2")
+
+	r := &IonosCloudMachineReconciler{DatacenterLocks: &locker.Locker{}}
+	ctx := context.Background()
+	require.True(t, r.lockDatacenter(ctx, first))
+	require.False(t, r.lockDatacenter(ctx, second), "machines in the same data center must be serialized")
+	require.True(t, r.lockDatacenter(ctx, other))
+
+	r.unlockDatacenter(first)
+	require.True(t, r.lockDatacenter(ctx, second))
+
+	r = &IonosCloudMachineReconciler{}
+	require.True(t, r.lockDatacenter(ctx, first))
+	require.True(t, r.lockDatacenter(ctx, first), "machines must not be serialized without locks")
+}
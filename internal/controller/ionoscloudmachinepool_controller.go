@@ -0,0 +1,434 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	exputil "sigs.k8s.io/cluster-api/exp/util"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// machineNameSuffixLength is the length of the random suffix of the names of pool machines.
+const machineNameSuffixLength = 5
+
+// IonosCloudMachinePoolReconciler reconciles a IonosCloudMachinePool object.
+//
+// The reconciler implements the MachinePool Machines contract of Cluster API:
+// For each replica of the pool, an IonosCloudMachine is created, for which Cluster API
+// will create a corresponding Machine. The provisioning of the servers is then handled
+// by the IonosCloudMachineReconciler.
+type IonosCloudMachinePoolReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinepools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinepools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinepools/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
+
+func (r *IonosCloudMachinePoolReconciler) Reconcile(
+	ctx context.Context,
+	ionosCloudMachinePool *infrav1.IonosCloudMachinePool,
+) (_ ctrl.Result, retErr error) {
+	ctx, span := tracing.Start(ctx, "IonosCloudMachinePool.Reconcile", tracing.ObjectAttributes(ionosCloudMachinePool)...)
+	defer func() { tracing.End(span, retErr) }()
+
+	logger := ctrl.LoggerFrom(ctx)
+
+	// Fetch the MachinePool.
+	machinePool, err := exputil.GetOwnerMachinePool(ctx, r.Client, ionosCloudMachinePool.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		logger.Info("MachinePool controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		logger.Info("MachinePool is missing cluster label or cluster does not exist")
+		return ctrl.Result{}, err
+	}
+
+	if annotations.IsPaused(cluster, ionosCloudMachinePool) {
+		logger.Info("IONOS Cloud machine pool or linked cluster is marked as paused, not reconciling")
+		return ctrl.Result{}, nil
+	}
+
+	clusterScope, err := getClusterScope(ctx, r.Client, cluster)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error getting infra provider cluster object: %w", err)
+	}
+	if clusterScope == nil {
+		logger.Info("IONOS Cloud machine pool is not ready yet")
+		return ctrl.Result{}, nil
+	}
+
+	machinePoolScope, err := scope.NewMachinePool(scope.MachinePoolParams{
+		Client:           r.Client,
+		MachinePool:      machinePool,
+		ClusterScope:     clusterScope,
+		IonosMachinePool: ionosCloudMachinePool,
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+
+	defer func() {
+		if err := machinePoolScope.Finalize(); err != nil {
+			retErr = errors.Join(err, retErr)
+		}
+	}()
+
+	if !ionosCloudMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, machinePoolScope)
+	}
+
+	return r.reconcileNormal(ctx, machinePoolScope)
+}
+
+func (r *IonosCloudMachinePoolReconciler) reconcileNormal(
+	ctx context.Context, machinePoolScope *scope.MachinePool,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(4).Info("Reconciling IonosCloudMachinePool")
+
+	pool := machinePoolScope.IonosMachinePool
+	if controllerutil.AddFinalizer(pool, infrav1.MachinePoolFinalizer) {
+		if err := machinePoolScope.PatchObject(); err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to update finalizer on object: %w", err)
+		}
+	}
+
+	// Let Cluster API know that the machines of the pool are backed by IonosCloudMachines.
+	pool.Status.InfrastructureMachineKind = infrav1.IonosCloudMachineType
+
+	if !machinePoolScope.ClusterScope.Cluster.Status.InfrastructureReady {
+		log.Info("Cluster infrastructure is not ready yet")
+		conditions.MarkFalse(
+			pool,
+			infrav1.ReplicasReadyCondition,
+			infrav1.WaitingForClusterInfrastructureReason,
+			clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	machines, err := machinePoolScope.ListMachines(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list machines of the pool: %w", err)
+	}
+	// The pending machine has been created once it is in the cache, even if it is already being deleted.
+	pendingCreated := slices.ContainsFunc(machines, func(m infrav1.IonosCloudMachine) bool {
+		return m.Name == pool.Status.PendingMachineName
+	})
+
+	// Machines, which are already being deleted, are no longer part of the pool.
+	machines = slices.DeleteFunc(machines, func(m infrav1.IonosCloudMachine) bool {
+		return !m.DeletionTimestamp.IsZero()
+	})
+
+	desiredReplicas := int(machinePoolScope.DesiredReplicas())
+	if pendingCreated || len(machines) >= desiredReplicas {
+		pool.Status.PendingMachineName = ""
+	}
+
+	requeue := false
+	switch {
+	case len(machines) < desiredReplicas:
+		// The list of machines is read from the cache and might miss machines, which were just created.
+		// Only one machine is created per reconciliation, and its name is recorded in the status before,
+		// which prevents that a missing machine is created twice.
+		if pool.Status.PendingMachineName == "" {
+			pool.Status.PendingMachineName = newMachineName(pool.Name)
+			if err := machinePoolScope.PatchObject(); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to record the name of the next machine: %w", err)
+			}
+		}
+		if err := r.createMachine(ctx, machinePoolScope, pool.Status.PendingMachineName); err != nil {
+			return ctrl.Result{}, err
+		}
+		requeue = true
+	case len(machines) > desiredReplicas:
+		if err := r.deleteMachines(ctx, machinePoolScope, machines, len(machines)-desiredReplicas); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	updatePoolStatus(machinePoolScope, machines)
+
+	if requeue {
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if !pool.Status.Ready {
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *IonosCloudMachinePoolReconciler) reconcileDelete(
+	ctx context.Context, machinePoolScope *scope.MachinePool,
+) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(4).Info("Deleting IonosCloudMachinePool")
+
+	machines, err := machinePoolScope.ListMachines(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list machines of the pool: %w", err)
+	}
+
+	if len(machines) > 0 {
+		log.Info("Waiting for the machines of the pool to be deleted", "remaining", len(machines))
+		if err := r.deleteMachines(ctx, machinePoolScope, machines, len(machines)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	}
+
+	controllerutil.RemoveFinalizer(machinePoolScope.IonosMachinePool, infrav1.MachinePoolFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// newMachineName returns a new name for a machine of the pool, which is the name of the pool followed by
+// a random suffix. As servers are found by the name of their machine, names must not be reused, e.g. after
+// the pool was scaled down, or by a pool with the same name in another namespace.
+func newMachineName(poolName string) string {
+	return poolName + "-" + utilrand.String(machineNameSuffixLength)
+}
+
+// createMachine creates a new IonosCloudMachine with the given name from the template of the machine pool.
+// If the machine of the pool already exists, e.g. because it is not in the cache yet, nothing is created.
+// If the name is taken by another machine, a new name is used in the next reconciliation.
+func (r *IonosCloudMachinePoolReconciler) createMachine(
+	ctx context.Context, machinePoolScope *scope.MachinePool, name string,
+) error {
+	pool := machinePoolScope.IonosMachinePool
+	template := pool.Spec.Template.DeepCopy()
+
+	machineLabels := template.ObjectMeta.Labels
+	if machineLabels == nil {
+		machineLabels = map[string]string{}
+	}
+	for k, v := range machinePoolScope.MachineLabels() {
+		machineLabels[k] = v
+	}
+
+	machine := &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   pool.Namespace,
+			Labels:      machineLabels,
+			Annotations: template.ObjectMeta.Annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         infrav1.GroupVersion.String(),
+				Kind:               infrav1.IonosCloudMachinePoolKind,
+				Name:               pool.Name,
+				UID:                pool.UID,
+				BlockOwnerDeletion: ptr.To(true),
+			}},
+		},
+		Spec: template.Spec,
+	}
+	machine.Spec.ProviderID = nil
+
+	if err := r.Client.Create(ctx, machine); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create machine for pool %s: %w", pool.Name, err)
+		}
+
+		existing := &infrav1.IonosCloudMachine{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(machine), existing); err != nil {
+			return fmt.Errorf("failed to get existing machine %s: %w", name, err)
+		}
+		if !slices.ContainsFunc(existing.OwnerReferences, func(ref metav1.OwnerReference) bool {
+			return ref.UID == pool.UID
+		}) {
+			pool.Status.PendingMachineName = ""
+			return fmt.Errorf("machine %s already exists and is not owned by pool %s", name, pool.Name)
+		}
+		ctrl.LoggerFrom(ctx).V(4).Info("Machine of pool already exists", "machine", name)
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Created machine for pool", "machine", machine.Name)
+	return nil
+}
+
+// deleteMachines deletes count machines of the pool. Machines, which were marked for deletion by the user
+// or are not ready yet, are deleted first. Afterward, the newest machines are removed.
+//
+// If Cluster API already created a Machine for an IonosCloudMachine, the Machine is deleted instead,
+// which makes sure that the node is drained before the server is removed.
+func (r *IonosCloudMachinePoolReconciler) deleteMachines(
+	ctx context.Context,
+	machinePoolScope *scope.MachinePool,
+	machines []infrav1.IonosCloudMachine,
+	count int,
+) error {
+	candidates := make([]deletionCandidate, 0, len(machines))
+	for i := range machines {
+		machine, err := util.GetOwnerMachine(ctx, r.Client, machines[i].ObjectMeta)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get owner machine of %s: %w", machines[i].Name, err)
+		}
+		candidates = append(candidates, deletionCandidate{ionosMachine: &machines[i], machine: machine})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b deletionCandidate) int {
+		if a.priority() != b.priority() {
+			return b.priority() - a.priority()
+		}
+		// newest machines first
+		return b.ionosMachine.CreationTimestamp.Compare(a.ionosMachine.CreationTimestamp.Time)
+	})
+
+	var errs []error
+	for _, candidate := range candidates[:min(count, len(candidates))] {
+		var obj client.Object = candidate.ionosMachine
+		if candidate.machine != nil {
+			obj = candidate.machine
+		}
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		ctrl.LoggerFrom(ctx).Info("Deleting machine of pool",
+			"pool", machinePoolScope.IonosMachinePool.Name, "machine", obj.GetName())
+		if err := r.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete machine %s: %w", obj.GetName(), err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+type deletionCandidate struct {
+	ionosMachine *infrav1.IonosCloudMachine
+	machine      *clusterv1.Machine
+}
+
+// priority returns the deletion priority of the candidate. Candidates with a higher priority are deleted first.
+func (d deletionCandidate) priority() int {
+	switch {
+	case d.machine != nil && annotations.HasWithPrefix(clusterv1.DeleteMachineAnnotation, d.machine.Annotations):
+		return 2
+	case !d.ionosMachine.Status.Ready:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// updatePoolStatus updates the provider ID list and the status of the pool based on the provided machines.
+// Only ready machines are part of the provider ID list, so that Cluster API doesn't wait for the nodes of
+// machines, which are still being provisioned.
+func updatePoolStatus(machinePoolScope *scope.MachinePool, machines []infrav1.IonosCloudMachine) {
+	pool := machinePoolScope.IonosMachinePool
+
+	providerIDs := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		if machine.Status.Ready && ptr.Deref(machine.Spec.ProviderID, "") != "" {
+			providerIDs = append(providerIDs, *machine.Spec.ProviderID)
+		}
+	}
+	slices.Sort(providerIDs)
+
+	pool.Spec.ProviderIDList = providerIDs
+	// The cluster autoscaler reads the capacity to scale the pool from zero replicas.
+	templateStatus := machineTemplateStatus(&pool.Spec.Template.Spec)
+	pool.Status.Capacity = templateStatus.Capacity
+	pool.Status.NodeInfo = templateStatus.NodeInfo
+
+	pool.Status.Replicas = int32(len(machines))
+	pool.Status.ReadyReplicas = int32(len(providerIDs))
+	pool.Status.Ready = len(providerIDs) == int(machinePoolScope.DesiredReplicas())
+
+	if pool.Status.Ready {
+		conditions.MarkTrue(pool, infrav1.ReplicasReadyCondition)
+		return
+	}
+
+	conditions.MarkFalse(
+		pool,
+		infrav1.ReplicasReadyCondition,
+		infrav1.WaitingForReplicasReadyReason,
+		clusterv1.ConditionSeverityInfo,
+		"%d of %d replicas are ready", len(providerIDs), machinePoolScope.DesiredReplicas())
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IonosCloudMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	clusterToIonosCloudMachinePools, err := util.ClusterToTypedObjectsMapper(
+		r.Client, &infrav1.IonosCloudMachinePoolList{}, mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("failed to create mapper for Cluster to IonosCloudMachinePools: %w", err)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.IonosCloudMachinePool{}).
+		WithEventFilter(predicates.ResourceNotPaused(ctrl.LoggerFrom(ctx))).
+		Watches(
+			&expv1.MachinePool{},
+			handler.EnqueueRequestsFromMapFunc(
+				exputil.MachinePoolToInfrastructureMapFunc(
+					infrav1.GroupVersion.WithKind(infrav1.IonosCloudMachinePoolKind),
+					ctrl.LoggerFrom(ctx),
+				),
+			),
+		).
+		Watches(
+			&infrav1.IonosCloudMachine{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1.IonosCloudMachinePool{}),
+		).
+		// Machine pools need to be reconciled again once their cluster is unpaused.
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToIonosCloudMachinePools),
+			builder.WithPredicates(predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx))),
+		).
+		Complete(reconcile.AsReconciler[*infrav1.IonosCloudMachinePool](r.Client, r))
+}
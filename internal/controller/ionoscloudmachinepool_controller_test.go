@@ -0,0 +1,242 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+func TestDeletionCandidatePriority(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate deletionCandidate
+		priority  int
+	}{
+		{
+			name: "ready machine",
+			candidate: deletionCandidate{
+				ionosMachine: &infrav1.IonosCloudMachine{Status: infrav1.IonosCloudMachineStatus{Ready: true}},
+				machine:      &clusterv1.Machine{},
+			},
+			priority: 0,
+		},
+		{
+			name: "machine not ready",
+			candidate: deletionCandidate{
+				ionosMachine: &infrav1.IonosCloudMachine{},
+			},
+			priority: 1,
+		},
+		{
+			name: "machine marked for deletion",
+			candidate: deletionCandidate{
+				ionosMachine: &infrav1.IonosCloudMachine{Status: infrav1.IonosCloudMachineStatus{Ready: true}},
+				machine: func() *clusterv1.Machine {
+					m := &clusterv1.Machine{}
+					m.SetAnnotations(map[string]string{clusterv1.DeleteMachineAnnotation: ""})
+					return m
+				}(),
+			},
+			priority: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.priority, tt.candidate.priority())
+		})
+	}
+}
+
+func TestUpdatePoolStatus(t *testing.T) {
+	machinePoolScope := &scope.MachinePool{
+		MachinePool: &expv1.MachinePool{
+			Spec: expv1.MachinePoolSpec{Replicas: ptr.To(int32(2))},
+		},
+		IonosMachinePool: &infrav1.IonosCloudMachinePool{
+			Spec: infrav1.IonosCloudMachinePoolSpec{Template: infrav1.IonosCloudMachineTemplateResource{
+				Spec: infrav1.IonosCloudMachineSpec{NumCores: 2, MemoryMB: 4096},
+			}},
+		},
+	}
+
+	machines := []infrav1.IonosCloudMachine{
+		{
+			Spec:   infrav1.IonosCloudMachineSpec{ProviderID: ptr.To("ionos://b")},
+			Status: infrav1.IonosCloudMachineStatus{Ready: true},
+		},
+		{
+			Spec: infrav1.IonosCloudMachineSpec{ProviderID: ptr.To("ionos://c")},
+		},
+	}
+
+	updatePoolStatus(machinePoolScope, machines)
+	pool := machinePoolScope.IonosMachinePool
+	require.Equal(t, []string{"ionos://b"}, pool.Spec.ProviderIDList)
+	require.Equal(t, int32(2), pool.Status.Replicas)
+	require.Equal(t, int32(1), pool.Status.ReadyReplicas)
+	require.True(t, resource.MustParse("2").Equal(pool.Status.Capacity[corev1.ResourceCPU]))
+	require.True(t, resource.MustParse("4Gi").Equal(pool.Status.Capacity[corev1.ResourceMemory]))
+	require.Equal(t, infrav1.ArchitectureAmd64, pool.Status.NodeInfo.Architecture)
+	require.False(t, pool.Status.Ready)
+	require.True(t, conditions.IsFalse(pool, infrav1.ReplicasReadyCondition))
+
+	machines = append(machines, infrav1.IonosCloudMachine{
+		Spec:   infrav1.IonosCloudMachineSpec{ProviderID: ptr.To("ionos://a")},
+		Status: infrav1.IonosCloudMachineStatus{Ready: true},
+	})
+
+	updatePoolStatus(machinePoolScope, machines)
+	require.Equal(t, []string{"ionos://a", "ionos://b"}, pool.Spec.ProviderIDList)
+	require.Equal(t, int32(3), pool.Status.Replicas)
+	require.Equal(t, int32(2), pool.Status.ReadyReplicas)
+	require.True(t, pool.Status.Ready)
+	require.True(t, conditions.IsTrue(pool, infrav1.ReplicasReadyCondition))
+}
+
+func TestNewMachineName(t *testing.T) {
+	name := newMachineName("pool")
+	require.Regexp(t, "^pool-[a-z0-9]{5}$", name)
+	require.NotEqual(t, name, newMachineName("pool"), "names must not be reused")
+}
+
+func TestCreateMachineAlreadyExists(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1.AddToScheme(scheme))
+	owned := &infrav1.IonosCloudMachine{ObjectMeta: metav1.ObjectMeta{
+		Name:            "pool-abcde",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Name: "pool", UID: "pool-uid"}},
+	}}
+	foreign := &infrav1.IonosCloudMachine{ObjectMeta: metav1.ObjectMeta{Name: "pool-fghij", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owned, foreign).Build()
+
+	machinePoolScope := &scope.MachinePool{
+		MachinePool: &expv1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}},
+		ClusterScope: &scope.Cluster{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+		},
+		IonosMachinePool: &infrav1.IonosCloudMachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"},
+		},
+	}
+	r := &IonosCloudMachinePoolReconciler{Client: c}
+
+	// A machine, which is missing in the cache, is not created twice.
+	require.NoError(t, r.createMachine(context.Background(), machinePoolScope, "pool-abcde"))
+	require.NoError(t, r.createMachine(context.Background(), machinePoolScope, "pool-klmno"))
+
+	// A machine, which belongs to someone else, is not taken over.
+	machinePoolScope.IonosMachinePool.Status.PendingMachineName = "pool-fghij"
+	require.ErrorContains(t, r.createMachine(context.Background(), machinePoolScope, "pool-fghij"),
+		"not owned by pool pool")
+	require.Empty(t, machinePoolScope.IonosMachinePool.Status.PendingMachineName, "a new name must be used")
+
+	var machines infrav1.IonosCloudMachineList
+	require.NoError(t, c.List(context.Background(), &machines))
+	require.Len(t, machines.Items, 3)
+	require.Equal(t, "pool", machines.Items[2].Labels[clusterv1.MachinePoolNameLabel])
+}
+
+func TestReconcileNormalPendingMachineName(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, expv1.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+		Status:     clusterv1.ClusterStatus{InfrastructureReady: true},
+	}
+	machinePool := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec:       expv1.MachinePoolSpec{Replicas: ptr.To[int32](2)},
+	}
+	pool := &infrav1.IonosCloudMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "pool-uid"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool).WithStatusSubresource(pool).Build()
+	// The machines are listed from a stale cache, which doesn't contain any machine.
+	staleCache := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &IonosCloudMachinePoolReconciler{Client: c}
+
+	reconcile := func(cache client.Client) {
+		t.Helper()
+		pool := &infrav1.IonosCloudMachinePool{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "pool"}, pool))
+		clusterScope, err := scope.NewCluster(scope.ClusterParams{
+			Client:       cache,
+			Cluster:      cluster,
+			IonosCluster: &infrav1.IonosCloudCluster{},
+		})
+		require.NoError(t, err)
+		machinePoolScope, err := scope.NewMachinePool(scope.MachinePoolParams{
+			Client:           c,
+			MachinePool:      machinePool,
+			ClusterScope:     clusterScope,
+			IonosMachinePool: pool,
+		})
+		require.NoError(t, err)
+		_, err = r.reconcileNormal(ctx, machinePoolScope)
+		require.NoError(t, err)
+		require.NoError(t, machinePoolScope.Finalize())
+	}
+	machineNames := func() []string {
+		t.Helper()
+		var machines infrav1.IonosCloudMachineList
+		require.NoError(t, c.List(ctx, &machines))
+		names := make([]string, 0, len(machines.Items))
+		for _, machine := range machines.Items {
+			names = append(names, machine.Name)
+		}
+		return names
+	}
+
+	reconcile(staleCache)
+	first := machineNames()
+	require.Len(t, first, 1)
+
+	reconcile(staleCache)
+	require.Equal(t, first, machineNames(), "the pending machine must not be created twice")
+
+	reconcile(c)
+	names := machineNames()
+	require.Len(t, names, 2)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	require.Contains(t, names, pool.Status.PendingMachineName, "the second machine is pending")
+
+	reconcile(c)
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(pool), pool))
+	require.Empty(t, pool.Status.PendingMachineName)
+	require.Len(t, machineNames(), 2)
+}
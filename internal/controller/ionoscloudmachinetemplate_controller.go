@@ -0,0 +1,105 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
+)
+
+// IonosCloudMachineTemplateReconciler reconciles a IonosCloudMachineTemplate object.
+//
+// The reconciler derives the capacity of the nodes, which are created from the template, from its machine spec.
+// The cluster autoscaler reads it from the status to scale MachineDeployments from and to zero replicas.
+type IonosCloudMachineTemplateReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinetemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinetemplates/status,verbs=get;update;patch
+
+func (r *IonosCloudMachineTemplateReconciler) Reconcile(
+	ctx context.Context,
+	template *infrav1.IonosCloudMachineTemplate,
+) (_ ctrl.Result, retErr error) {
+	ctx, span := tracing.Start(ctx, "IonosCloudMachineTemplate.Reconcile", tracing.ObjectAttributes(template)...)
+	defer func() { tracing.End(span, retErr) }()
+
+	status := machineTemplateStatus(&template.Spec.Template.Spec)
+	if equality.Semantic.DeepEqual(status, template.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(template, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+
+	ctrl.LoggerFrom(ctx).V(4).Info("Updating capacity of IonosCloudMachineTemplate", "capacity", status.Capacity)
+	template.Status = status
+	if err := patchHelper.Patch(ctx, template); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to patch status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// machineTemplateStatus returns the status of a machine template with the given machine spec.
+// The resources of CUBE servers are defined by their template in IONOS Cloud, which is why
+// their capacity is unknown.
+func machineTemplateStatus(spec *infrav1.IonosCloudMachineSpec) infrav1.IonosCloudMachineTemplateStatus {
+	status := infrav1.IonosCloudMachineTemplateStatus{
+		// IONOS Cloud only offers CPU families with the x86-64 architecture.
+		NodeInfo: &infrav1.NodeInfo{
+			Architecture:    infrav1.ArchitectureAmd64,
+			OperatingSystem: infrav1.OperatingSystemLinux,
+		},
+	}
+	if spec.Type == infrav1.ServerTypeCube {
+		return status
+	}
+
+	status.Capacity = corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewQuantity(int64(spec.NumCores), resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(int64(spec.MemoryMB)*1024*1024, resource.BinarySI),
+	}
+	// The kubelet stores its ephemeral data on the boot volume.
+	if spec.Disk != nil && spec.Disk.SizeGB > 0 {
+		status.Capacity[corev1.ResourceEphemeralStorage] = *resource.NewQuantity(
+			int64(spec.Disk.SizeGB)*1024*1024*1024, resource.BinarySI)
+	}
+	return status
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IonosCloudMachineTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infrav1.IonosCloudMachineTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(reconcile.AsReconciler[*infrav1.IonosCloudMachineTemplate](r.Client, r))
+}
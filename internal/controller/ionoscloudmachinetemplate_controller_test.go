@@ -0,0 +1,89 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+func TestMachineTemplateStatus(t *testing.T) {
+	status := machineTemplateStatus(&infrav1.IonosCloudMachineSpec{
+		NumCores: 4,
+		MemoryMB: 8192,
+		Disk:     &infrav1.Volume{SizeGB: 50},
+	})
+	require.Equal(t, infrav1.ArchitectureAmd64, status.NodeInfo.Architecture)
+	require.Equal(t, infrav1.OperatingSystemLinux, status.NodeInfo.OperatingSystem)
+	require.True(t, resource.MustParse("4").Equal(status.Capacity[corev1.ResourceCPU]))
+	require.True(t, resource.MustParse("8Gi").Equal(status.Capacity[corev1.ResourceMemory]))
+	require.True(t, resource.MustParse("50Gi").Equal(status.Capacity[corev1.ResourceEphemeralStorage]))
+
+	status = machineTemplateStatus(&infrav1.IonosCloudMachineSpec{NumCores: 4, MemoryMB: 8192})
+	require.NotContains(t, status.Capacity, corev1.ResourceEphemeralStorage)
+
+	status = machineTemplateStatus(&infrav1.IonosCloudMachineSpec{
+		NumCores: 1,
+		MemoryMB: 3072,
+		Type:     infrav1.ServerTypeCube,
+		Template: &infrav1.ServerTemplate{Name: "Basic Cube XS"},
+	})
+	require.Empty(t, status.Capacity, "the capacity of CUBE servers is defined by their template")
+	require.NotNil(t, status.NodeInfo)
+}
+
+func TestIonosCloudMachineTemplateReconcile(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	template := &infrav1.IonosCloudMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default"},
+		Spec: infrav1.IonosCloudMachineTemplateSpec{Template: infrav1.IonosCloudMachineTemplateResource{
+			Spec: infrav1.IonosCloudMachineSpec{
+				NumCores: 2,
+				MemoryMB: 4096,
+				Disk:     &infrav1.Volume{SizeGB: 20},
+			},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(template).
+		WithStatusSubresource(template).
+		Build()
+	r := &IonosCloudMachineTemplateReconciler{Client: c}
+
+	_, err := r.Reconcile(ctx, template)
+	require.NoError(t, err)
+
+	got := &infrav1.IonosCloudMachineTemplate{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(template), got))
+	require.True(t, resource.MustParse("2").Equal(got.Status.Capacity[corev1.ResourceCPU]))
+	require.True(t, resource.MustParse("4Gi").Equal(got.Status.Capacity[corev1.ResourceMemory]))
+	require.True(t, resource.MustParse("20Gi").Equal(got.Status.Capacity[corev1.ResourceEphemeralStorage]))
+	require.Equal(t, infrav1.ArchitectureAmd64, got.Status.NodeInfo.Architecture)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// WorkloadClusterClients provides clients for the workload clusters. It is implemented by remote.ClusterCacheTracker.
+type WorkloadClusterClients interface {
+	// GetClient returns a client for the workload cluster of the given Cluster.
+	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
+}
+
+// reconcileNodeProviderID sets the provider ID of the Node of the machine in the workload cluster, if neither
+// the kubelet nor a cloud controller manager has set it. Cluster API matches Nodes to Machines by their provider ID,
+// which is why the Node reference of the machine is only set afterward.
+// The Node is found by its name, which is the hostname of the server. It returns true, if the Node should be
+// checked again.
+func (r *IonosCloudMachineReconciler) reconcileNodeProviderID(ctx context.Context, ms *scope.Machine) bool {
+	providerID := ptr.Deref(ms.IonosMachine.Spec.ProviderID, "")
+	if r.WorkloadClusterClients == nil || providerID == "" || ms.Machine.Status.NodeRef != nil ||
+		!conditions.IsTrue(ms.ClusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return false
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("node", ms.Hostname())
+	workloadClient, err := r.WorkloadClusterClients.GetClient(ctx, client.ObjectKeyFromObject(ms.ClusterScope.Cluster))
+	if err != nil {
+		log.V(4).Info("Workload cluster is not accessible", "error", err.Error())
+		return true
+	}
+
+	node := &corev1.Node{}
+	if err := workloadClient.Get(ctx, client.ObjectKey{Name: ms.Hostname()}, node); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Unable to get Node from the workload cluster")
+		}
+		return true
+	}
+
+	switch node.Spec.ProviderID {
+	case providerID:
+		// Cluster API sets the Node reference of the machine shortly.
+		return false
+	case "":
+	default:
+		log.Info("Node has a different provider ID", "providerID", node.Spec.ProviderID)
+		return false
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.ProviderID = providerID
+	if err := workloadClient.Patch(ctx, node, patch); err != nil {
+		log.Error(err, "Unable to set the provider ID of the Node")
+		return true
+	}
+	log.Info("Successfully set the provider ID of the Node", "providerID", providerID)
+	return false
+}
+
+// reconcileBootstrapExecution reports in the BootstrapExecutedSuccessfully condition, whether the bootstrap data
+// has been executed on the VM of the machine, i.e. whether its Node has registered in the workload cluster.
+// The Node is found by its name, like in reconcileNodeProviderID. If the Node has not registered within the
+// bootstrap execution timeout after the VM became available, the bootstrap is reported as timed out.
+// Machines with the NodeRegistered readiness strategy are requeued until their Node has registered,
+// which holds back their readiness.
+func (r *IonosCloudMachineReconciler) reconcileBootstrapExecution(
+	ctx context.Context, ms *scope.Machine,
+) (requeue bool, err error) {
+	awaitNode := ms.IonosMachine.Spec.ReadinessStrategy == infrav1.ReadinessStrategyNodeRegistered &&
+		!ms.IonosMachine.Status.Ready
+	if conditions.IsTrue(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition) ||
+		!conditions.IsTrue(ms.IonosMachine, infrav1.ServerCreatedCondition) {
+		return false, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("node", ms.Hostname())
+	if r.WorkloadClusterClients == nil {
+		if awaitNode {
+			log.Info("Access to the workload cluster is disabled, the Node registration is not awaited")
+		}
+		return false, nil
+	}
+
+	waiting := func(message string) (bool, error) {
+		timeout := r.Timeouts.forCluster(ms.ClusterScope.IonosCluster).BootstrapExecution
+		available := conditions.GetLastTransitionTime(ms.IonosMachine, infrav1.ServerCreatedCondition)
+		if !exceeded(available, timeout) {
+			conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition,
+				infrav1.WaitingForBootstrapExecutionReason, clusterv1.ConditionSeverityInfo, message)
+			return awaitNode, nil
+		}
+
+		timedOut := fmt.Sprintf("Node %s has not registered within %s after the server became available, "+
+			"check the cloud-init output of the server", ms.Hostname(), timeout)
+		if conditions.GetReason(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition) !=
+			infrav1.BootstrapExecutionTimedOutReason {
+			log.Info("Node has not registered in time, the bootstrap might have failed", "timeout", timeout)
+			if r.Recorder != nil {
+				r.Recorder.Event(ms.IonosMachine, corev1.EventTypeWarning, infrav1.BootstrapExecutionTimedOutReason, timedOut)
+			}
+		}
+		conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition,
+			infrav1.BootstrapExecutionTimedOutReason, clusterv1.ConditionSeverityWarning, "%s", timedOut)
+		return awaitNode, nil
+	}
+
+	if !conditions.IsTrue(ms.ClusterScope.Cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return waiting("waiting for the control plane to be initialized")
+	}
+	workloadClient, err := r.WorkloadClusterClients.GetClient(ctx, client.ObjectKeyFromObject(ms.ClusterScope.Cluster))
+	if err != nil {
+		log.V(4).Info("Workload cluster is not accessible", "error", err.Error())
+		return waiting("waiting for the workload cluster to be accessible")
+	}
+	if err := workloadClient.Get(ctx, client.ObjectKey{Name: ms.Hostname()}, &corev1.Node{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Unable to get Node from the workload cluster")
+		}
+		return waiting(fmt.Sprintf("waiting for Node %s to register", ms.Hostname()))
+	}
+
+	conditions.MarkTrue(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition)
+	return false, nil
+}
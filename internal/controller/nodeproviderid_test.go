@@ -0,0 +1,224 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const exampleProviderID = "ionos://3f2b4c7e-9a1d-4e6f-8b2a-5c7d9e0f1a2b"
+
+type fakeWorkloadClusterClients struct {
+	client client.Client
+}
+
+func (f *fakeWorkloadClusterClients) GetClient(context.Context, client.ObjectKey) (client.Client, error) {
+	return f.client, nil
+}
+
+func TestReconcileNodeProviderID(t *testing.T) {
+	tests := []struct {
+		name           string
+		node           *corev1.Node
+		notInitialized bool
+		wantRequeue    bool
+		wantProviderID string
+	}{{
+		name:           "provider ID is set",
+		node:           &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine"}},
+		wantProviderID: exampleProviderID,
+	}, {
+		name: "other provider ID is kept",
+		node: &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+			Spec:       corev1.NodeSpec{ProviderID: "ionos://other"},
+		},
+		wantProviderID: "ionos://other",
+	}, {
+		name:        "node has not registered yet",
+		wantRequeue: true,
+	}, {
+		name:           "control plane is not initialized",
+		node:           &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine"}},
+		notInitialized: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			builder := fake.NewClientBuilder()
+			if tt.node != nil {
+				builder = builder.WithObjects(tt.node)
+			}
+			workloadClient := builder.Build()
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+			if !tt.notInitialized {
+				conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+			}
+			ms := &scope.Machine{
+				Machine: &clusterv1.Machine{},
+				IonosMachine: &infrav1.IonosCloudMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+					Spec:       infrav1.IonosCloudMachineSpec{ProviderID: ptr.To(exampleProviderID)},
+				},
+				ClusterScope: &scope.Cluster{Cluster: cluster},
+			}
+
+			r := &IonosCloudMachineReconciler{
+				WorkloadClusterClients: &fakeWorkloadClusterClients{client: workloadClient},
+			}
+			require.Equal(t, tt.wantRequeue, r.reconcileNodeProviderID(ctx, ms))
+
+			if tt.node != nil {
+				node := &corev1.Node{}
+				require.NoError(t, workloadClient.Get(ctx, client.ObjectKeyFromObject(tt.node), node))
+				require.Equal(t, tt.wantProviderID, node.Spec.ProviderID)
+			}
+		})
+	}
+}
+
+func TestReconcileNodeProviderIDDisabled(t *testing.T) {
+	ms := &scope.Machine{
+		Machine:      &clusterv1.Machine{},
+		IonosMachine: &infrav1.IonosCloudMachine{Spec: infrav1.IonosCloudMachineSpec{ProviderID: ptr.To(exampleProviderID)}},
+		ClusterScope: &scope.Cluster{Cluster: &clusterv1.Cluster{}},
+	}
+	require.False(t, (&IonosCloudMachineReconciler{}).reconcileNodeProviderID(context.Background(), ms))
+}
+
+func TestReconcileBootstrapExecution(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       infrav1.ReadinessStrategy
+		node           *corev1.Node
+		notInitialized bool
+		availableSince time.Duration
+		wantRequeue    bool
+		wantStatus     corev1.ConditionStatus
+		wantReason     string
+	}{{
+		name:       "node has registered",
+		node:       &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine"}},
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "node has not registered yet",
+		wantStatus: corev1.ConditionFalse,
+		wantReason: infrav1.WaitingForBootstrapExecutionReason,
+	}, {
+		name:        "node registration is awaited",
+		strategy:    infrav1.ReadinessStrategyNodeRegistered,
+		wantRequeue: true,
+		wantStatus:  corev1.ConditionFalse,
+		wantReason:  infrav1.WaitingForBootstrapExecutionReason,
+	}, {
+		name:           "control plane is not initialized",
+		strategy:       infrav1.ReadinessStrategyNodeRegistered,
+		node:           &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine"}},
+		notInitialized: true,
+		wantRequeue:    true,
+		wantStatus:     corev1.ConditionFalse,
+		wantReason:     infrav1.WaitingForBootstrapExecutionReason,
+	}, {
+		name:           "node has not registered in time",
+		availableSince: time.Hour,
+		wantStatus:     corev1.ConditionFalse,
+		wantReason:     infrav1.BootstrapExecutionTimedOutReason,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			if tt.node != nil {
+				builder = builder.WithObjects(tt.node)
+			}
+
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+			if !tt.notInitialized {
+				conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+			}
+			ms := &scope.Machine{
+				Machine: &clusterv1.Machine{},
+				IonosMachine: &infrav1.IonosCloudMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "machine"},
+					Spec:       infrav1.IonosCloudMachineSpec{ReadinessStrategy: tt.strategy},
+				},
+				ClusterScope: &scope.Cluster{Cluster: cluster, IonosCluster: &infrav1.IonosCloudCluster{}},
+			}
+			conditions.Set(ms.IonosMachine, &clusterv1.Condition{
+				Type:               infrav1.ServerCreatedCondition,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-tt.availableSince)),
+			})
+
+			recorder := record.NewFakeRecorder(1)
+			r := &IonosCloudMachineReconciler{
+				Recorder:               recorder,
+				WorkloadClusterClients: &fakeWorkloadClusterClients{client: builder.Build()},
+				Timeouts:               Timeouts{BootstrapExecution: 20 * time.Minute},
+			}
+			requeue, err := r.reconcileBootstrapExecution(context.Background(), ms)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRequeue, requeue)
+
+			condition := conditions.Get(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition)
+			require.NotNil(t, condition)
+			require.Equal(t, tt.wantStatus, condition.Status)
+			require.Equal(t, tt.wantReason, condition.Reason)
+			if tt.wantReason == infrav1.BootstrapExecutionTimedOutReason {
+				require.Len(t, recorder.Events, 1)
+			}
+		})
+	}
+}
+
+func TestReconcileBootstrapExecutionServerNotAvailable(t *testing.T) {
+	ms := &scope.Machine{IonosMachine: &infrav1.IonosCloudMachine{}}
+	r := &IonosCloudMachineReconciler{WorkloadClusterClients: &fakeWorkloadClusterClients{}}
+	requeue, err := r.reconcileBootstrapExecution(context.Background(), ms)
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.Nil(t, conditions.Get(ms.IonosMachine, infrav1.BootstrapExecutedSuccessfullyCondition))
+}
+
+func TestReconcileBootstrapExecutionDisabled(t *testing.T) {
+	ms := &scope.Machine{
+		IonosMachine: &infrav1.IonosCloudMachine{
+			Spec: infrav1.IonosCloudMachineSpec{ReadinessStrategy: infrav1.ReadinessStrategyNodeRegistered},
+		},
+	}
+	conditions.MarkTrue(ms.IonosMachine, infrav1.ServerCreatedCondition)
+	requeue, err := (&IonosCloudMachineReconciler{}).reconcileBootstrapExecution(context.Background(), ms)
+	require.NoError(t, err)
+	require.False(t, requeue, "the readiness must not be held back without access to the workload cluster")
+}
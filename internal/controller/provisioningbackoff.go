@@ -0,0 +1,96 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// minProvisioningBackoff is the time to wait after the first failed attempt to provision a machine.
+	minProvisioningBackoff = defaultReconcileDuration
+	// maxProvisioningBackoff limits the time to wait after consecutive failed attempts to provision a machine.
+	maxProvisioningBackoff = 10 * time.Minute
+	// provisioningBackoffJitter is the maximum factor, by which the backoff is extended randomly, so that
+	// the machines of a data center with a persistent problem don't retry at the same time.
+	provisioningBackoffJitter = 0.1
+)
+
+// provisioningBackoff returns the time to wait after the given number of consecutive failed attempts to provision
+// a machine. Starting with minProvisioningBackoff, it doubles with every failure up to maxProvisioningBackoff.
+func provisioningBackoff(failures int32) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	backoff := minProvisioningBackoff
+	for i := int32(1); i < failures && backoff < maxProvisioningBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxProvisioningBackoff)
+}
+
+// recordProvisioningFailure counts a failed attempt to provision the machine and sets the time of the next
+// attempt. It returns the time to wait until then.
+func recordProvisioningFailure(ctx context.Context, ms *scope.Machine) time.Duration {
+	status := &ms.IonosMachine.Status
+	status.ProvisioningFailures++
+	backoff := wait.Jitter(provisioningBackoff(status.ProvisioningFailures), provisioningBackoffJitter)
+	status.ProvisioningRetryTime = &metav1.Time{Time: time.Now().Add(backoff)}
+
+	ctrl.LoggerFrom(ctx).Info("Provisioning of the machine failed, backing off",
+		"failures", status.ProvisioningFailures, "backoff", backoff)
+	return backoff
+}
+
+// remainingProvisioningBackoff returns the time to wait until the next attempt to provision the machine.
+func remainingProvisioningBackoff(ms *scope.Machine) time.Duration {
+	retryTime := ms.IonosMachine.Status.ProvisioningRetryTime
+	if retryTime.IsZero() {
+		return 0
+	}
+	return max(time.Until(retryTime.Time), 0)
+}
+
+// resetProvisioningBackoff resets the backoff once the machine has been provisioned.
+func resetProvisioningBackoff(ms *scope.Machine) {
+	ms.IonosMachine.Status.ProvisioningFailures = 0
+	ms.IonosMachine.Status.ProvisioningRetryTime = nil
+}
+
+// provisioningStepFailed returns the result of a reconciliation of the machine, whose step failed with err,
+// like stepFailedResult. While the machine is not provisioned yet, the failure is counted and the next attempt
+// is delayed by the provisioning backoff. Conflicting and throttled requests are retried without backoff,
+// as they don't indicate a problem with the machine or its data center.
+func (r *IonosCloudMachineReconciler) provisioningStepFailed(
+	ctx context.Context, ms *scope.Machine, step string, err error,
+) (ctrl.Result, error) {
+	kind := ionoserrors.KindOf(err)
+	res, err := stepFailedResult(ctx, r.Recorder, ms.IonosMachine, step, err)
+	if ms.IonosMachine.Status.Ready || kind == ionoserrors.KindConflict || kind == ionoserrors.KindRateLimited {
+		return res, err
+	}
+	res.RequeueAfter = max(res.RequeueAfter, recordProvisioningFailure(ctx, ms))
+	return res, err
+}
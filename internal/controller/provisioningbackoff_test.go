@@ -0,0 +1,112 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+func TestProvisioningBackoff(t *testing.T) {
+	require.Zero(t, provisioningBackoff(0))
+	require.Equal(t, 20*time.Second, provisioningBackoff(1))
+	require.Equal(t, 40*time.Second, provisioningBackoff(2))
+	require.Equal(t, 160*time.Second, provisioningBackoff(4))
+	require.Equal(t, maxProvisioningBackoff, provisioningBackoff(6))
+	require.Equal(t, maxProvisioningBackoff, provisioningBackoff(1000))
+}
+
+func TestRecordProvisioningFailure(t *testing.T) {
+	ms := &scope.Machine{IonosMachine: &infrav1.IonosCloudMachine{}}
+	require.Zero(t, remainingProvisioningBackoff(ms))
+
+	for failures := int32(1); failures <= 3; failures++ {
+		backoff := recordProvisioningFailure(context.Background(), ms)
+		require.Equal(t, failures, ms.IonosMachine.Status.ProvisioningFailures)
+
+		// The backoff is extended by a jitter of up to 10 percent.
+		require.GreaterOrEqual(t, backoff, provisioningBackoff(failures))
+		require.LessOrEqual(t, backoff, provisioningBackoff(failures)*11/10)
+		remaining := remainingProvisioningBackoff(ms)
+		require.Positive(t, remaining)
+		require.LessOrEqual(t, remaining, backoff)
+	}
+
+	resetProvisioningBackoff(ms)
+	require.Zero(t, ms.IonosMachine.Status.ProvisioningFailures)
+	require.Zero(t, remainingProvisioningBackoff(ms))
+}
+
+func TestProvisioningStepFailed(t *testing.T) {
+	quotaExceeded := sdk.NewGenericOpenAPIError("422 Unprocessable Entity", nil, sdk.Error{
+		Messages: &[]sdk.ErrorMessage{{Message: ptr.To("Resource limit exceeded: RAM per contract")}},
+	}, http.StatusUnprocessableEntity)
+
+	tests := []struct {
+		name         string
+		err          error
+		ready        bool
+		wantFailures int32
+		minRequeue   time.Duration
+	}{{
+		name:         "failure is counted",
+		err:          errors.New("internal error"),
+		wantFailures: 2,
+		minRequeue:   provisioningBackoff(2),
+	}, {
+		name:         "longer retry interval is kept",
+		err:          quotaExceeded,
+		wantFailures: 2,
+		minRequeue:   quotaExceededRetryInterval,
+	}, {
+		name:         "throttled request is not counted",
+		err:          sdk.NewGenericOpenAPIError("", nil, nil, http.StatusTooManyRequests),
+		wantFailures: 1,
+		minRequeue:   defaultReconcileDuration,
+	}, {
+		name:       "failure of provisioned machine is not counted",
+		err:        errors.New("internal error"),
+		ready:      true,
+		minRequeue: defaultReconcileDuration,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := &scope.Machine{IonosMachine: &infrav1.IonosCloudMachine{}}
+			ms.IonosMachine.Status.Ready = tt.ready
+			if !tt.ready {
+				recordProvisioningFailure(context.Background(), ms)
+			}
+			r := &IonosCloudMachineReconciler{Recorder: record.NewFakeRecorder(1)}
+
+			res, _ := r.provisioningStepFailed(context.Background(), ms, "ReconcileServer", tt.err)
+			require.GreaterOrEqual(t, res.RequeueAfter, tt.minRequeue)
+			require.Equal(t, tt.wantFailures, ms.IonosMachine.Status.ProvisioningFailures)
+		})
+	}
+}
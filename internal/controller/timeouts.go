@@ -0,0 +1,81 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+// Timeouts contains the request poll interval and the machine timeouts, which are configured by the flags
+// of the manager. They can be overridden per cluster in spec.timeouts of the IonosCloudCluster.
+type Timeouts struct {
+	// RequestPollInterval is the interval, in which pending requests to the Cloud API are checked.
+	// It defaults to 20 seconds.
+	RequestPollInterval time.Duration
+
+	// MachineProvisioning is the time, after which a machine, which is still not provisioned, is marked as failed.
+	// The timeout is disabled if it is zero.
+	MachineProvisioning time.Duration
+
+	// MachineDeletion is the time, after which the deletion of a machine is reported as timed out.
+	// The timeout is disabled if it is zero.
+	MachineDeletion time.Duration
+
+	// BootstrapExecution is the time, after which a machine, whose Node has not registered in the workload cluster
+	// since its VM became available, is reported as timed out. The timeout is disabled if it is zero.
+	BootstrapExecution time.Duration
+
+	// StuckRequest is the time, after which a pending request of a machine is considered to be stuck.
+	// The timeout is disabled if it is zero.
+	StuckRequest time.Duration
+}
+
+// forCluster returns the timeouts, which apply to the cluster.
+func (t Timeouts) forCluster(cluster *infrav1.IonosCloudCluster) Timeouts {
+	if t.RequestPollInterval <= 0 {
+		t.RequestPollInterval = defaultReconcileDuration
+	}
+	spec := cluster.Spec.Timeouts
+	if spec == nil {
+		return t
+	}
+	if spec.RequestPollInterval != nil {
+		t.RequestPollInterval = spec.RequestPollInterval.Duration
+	}
+	if spec.MachineProvisioning != nil {
+		t.MachineProvisioning = spec.MachineProvisioning.Duration
+	}
+	if spec.MachineDeletion != nil {
+		t.MachineDeletion = spec.MachineDeletion.Duration
+	}
+	if spec.BootstrapExecution != nil {
+		t.BootstrapExecution = spec.BootstrapExecution.Duration
+	}
+	if spec.StuckRequest != nil {
+		t.StuckRequest = spec.StuckRequest.Duration
+	}
+	return t
+}
+
+// exceeded returns whether the timeout has passed since start. A timeout of zero is never exceeded.
+func exceeded(start *metav1.Time, timeout time.Duration) bool {
+	return timeout > 0 && !start.IsZero() && time.Since(start.Time) > timeout
+}
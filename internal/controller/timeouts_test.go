@@ -0,0 +1,99 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func TestTimeoutsForCluster(t *testing.T) {
+	defaults := Timeouts{
+		RequestPollInterval: 10 * time.Second,
+		MachineProvisioning: time.Hour,
+		MachineDeletion:     30 * time.Minute,
+		BootstrapExecution:  20 * time.Minute,
+		StuckRequest:        time.Hour,
+	}
+
+	tests := []struct {
+		name     string
+		defaults Timeouts
+		spec     *infrav1.TimeoutsSpec
+		want     Timeouts
+	}{{
+		name: "no flags",
+		want: Timeouts{RequestPollInterval: defaultReconcileDuration},
+	}, {
+		name:     "no overrides",
+		defaults: defaults,
+		want:     defaults,
+	}, {
+		name:     "partial overrides",
+		defaults: defaults,
+		spec: &infrav1.TimeoutsSpec{
+			RequestPollInterval: &metav1.Duration{Duration: time.Minute},
+			MachineProvisioning: &metav1.Duration{},
+		},
+		want: Timeouts{
+			RequestPollInterval: time.Minute,
+			MachineDeletion:     30 * time.Minute,
+			BootstrapExecution:  20 * time.Minute,
+			StuckRequest:        time.Hour,
+		},
+	}, {
+		name:     "all overrides",
+		defaults: defaults,
+		spec: &infrav1.TimeoutsSpec{
+			RequestPollInterval: &metav1.Duration{Duration: time.Minute},
+			MachineProvisioning: &metav1.Duration{Duration: 2 * time.Hour},
+			MachineDeletion:     &metav1.Duration{Duration: time.Hour},
+			BootstrapExecution:  &metav1.Duration{Duration: 10 * time.Minute},
+			StuckRequest:        &metav1.Duration{Duration: 15 * time.Minute},
+		},
+		want: Timeouts{
+			RequestPollInterval: time.Minute,
+			MachineProvisioning: 2 * time.Hour,
+			MachineDeletion:     time.Hour,
+			BootstrapExecution:  10 * time.Minute,
+			StuckRequest:        15 * time.Minute,
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &infrav1.IonosCloudCluster{Spec: infrav1.IonosCloudClusterSpec{Timeouts: tt.spec}}
+			require.Equal(t, tt.want, tt.defaults.forCluster(cluster))
+		})
+	}
+}
+
+func TestExceeded(t *testing.T) {
+	anHourAgo := ptr.To(metav1.NewTime(time.Now().Add(-time.Hour)))
+
+	require.True(t, exceeded(anHourAgo, time.Minute))
+	require.False(t, exceeded(anHourAgo, 2*time.Hour))
+	require.False(t, exceeded(anHourAgo, 0), "a timeout of 0 is disabled")
+	require.False(t, exceeded(nil, time.Minute))
+	require.False(t, exceeded(&metav1.Time{}, time.Minute))
+}
@@ -18,84 +18,315 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/featuregate"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/feature"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/credentials"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
 	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/tracing"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
 
 const (
 	defaultReconcileDuration = time.Second * 20
+
+	// datacenterLockRetryInterval is the interval, after which a machine is reconciled again,
+	// if another machine in the same data center was being reconciled.
+	datacenterLockRetryInterval = time.Second * 5
+
+	// requestFailedReason is the reason of the event, which is recorded when a request to the Cloud API failed.
+	requestFailedReason = "RequestFailed"
+
+	// deletionBlockedReason is the reason of the event, which is recorded when the deletion of a server
+	// has to wait for Cluster API, or when the deletion of a cluster is blocked by its deletion protection.
+	deletionBlockedReason = "DeletionBlocked"
+
+	// quotaExceededReason is the reason of the event, which is recorded when a request to the Cloud API
+	// exceeded the resource limits of the contract.
+	quotaExceededReason = "QuotaExceeded"
+
+	// unauthorizedReason is the reason of the event, which is recorded when the Cloud API rejected
+	// the credentials of a cluster.
+	unauthorizedReason = "Unauthorized"
+
+	// provisioningTimedOutReason is the reason of the event, which is recorded when a machine has failed,
+	// because it was not provisioned within the provisioning timeout.
+	provisioningTimedOutReason = "ProvisioningTimedOut"
+
+	// requestStuckReason is the reason of the event, which is recorded when a request of a machine has been
+	// queued or running for longer than the stuck request timeout.
+	requestStuckReason = "RequestStuck"
+
+	// quotaExceededRetryInterval is the interval, after which a step is retried, whose request exceeded
+	// the resource limits of the contract. Raising the limits usually takes a while.
+	quotaExceededRetryInterval = 5 * time.Minute
 )
 
+// errInvalidCredentials is returned by createServiceFromCluster, if no client could be created from the credentials.
+var errInvalidCredentials = errors.New("invalid credentials")
+
 type serviceReconcileStep[T scope.Cluster | scope.Machine] struct {
 	name string
 	fn   func(context.Context, *T) (requeue bool, err error)
 }
 
-// withStatus is a helper function to handle the different request states
-// and provides a callback function to execute when the request is done or failed.
-func withStatus(
-	status string,
-	message string,
-	log *logr.Logger,
-	doneOrFailedCallback func() error,
+// run executes the step within its own span.
+func (step serviceReconcileStep[T]) run(ctx context.Context, s *T) (requeue bool, err error) {
+	ctx, span := tracing.Start(ctx, step.name)
+	defer func() {
+		span.SetAttributes(attribute.Bool("requeue", requeue))
+		tracing.End(span, err)
+	}()
+	return step.fn(ctx, s)
+}
+
+// stepFailedResult returns the result of a reconciliation, whose step failed with err. The error is
+// classified by ionoserrors: Throttled or conflicting requests and an unavailable Cloud API are retried
+// after the default interval without reporting an error. Requests exceeding the resource limits of the
+// contract are retried after a longer interval. They are recorded as warning events on obj, just like
+// rejected credentials. All other errors are returned, so that the step is retried with backoff.
+func stepFailedResult(
+	ctx context.Context, recorder record.EventRecorder, obj runtime.Object, step string, err error,
+) (ctrl.Result, error) {
+	err = fmt.Errorf("error in step %s: %w", step, err)
+	switch ionoserrors.KindOf(err) {
+	case ionoserrors.KindConflict, ionoserrors.KindRateLimited, ionoserrors.KindUnavailable:
+		ctrl.LoggerFrom(ctx).Info("Request to the Cloud API failed temporarily", "error", err.Error())
+		return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+	case ionoserrors.KindQuotaExceeded:
+		if recorder != nil {
+			recorder.Eventf(obj, corev1.EventTypeWarning, quotaExceededReason,
+				"Step %s exceeded the resource limits: %s", step, ionoserrors.Message(err))
+		}
+		return ctrl.Result{RequeueAfter: quotaExceededRetryInterval}, nil
+	case ionoserrors.KindUnauthorized:
+		if recorder != nil {
+			recorder.Eventf(obj, corev1.EventTypeWarning, unauthorizedReason,
+				"Step %s was not authorized: %s", step, ionoserrors.Message(err))
+		}
+	}
+	return ctrl.Result{RequeueAfter: defaultReconcileDuration}, err
+}
+
+// pollRequest polls the state of a tracked request. Once the request has completed,
+// removeRequest is called to stop tracking it. A failed request is recorded as a warning event on obj.
+func pollRequest(
+	ctx context.Context,
+	cloudService *cloud.Service,
+	recorder record.EventRecorder,
+	obj runtime.Object,
+	req *infrav1.ProvisioningRequest,
+	removeRequest func() error,
 ) (requeue bool, err error) {
-	switch status {
-	case sdk.RequestStatusQueued, sdk.RequestStatusRunning:
+	ctx, span := tracing.Start(ctx, "PollRequest",
+		attribute.String("request.method", req.Method), attribute.String("request.url", req.RequestPath),
+		attribute.String("request.id", req.ID()))
+	defer func() { tracing.End(span, err) }()
+
+	pending, err := cloudService.PollRequest(ctx, req)
+	span.SetAttributes(attribute.String("request.state", req.State), attribute.Bool("request.pending", pending))
+	if err != nil {
+		return false, fmt.Errorf("could not get request status: %w", err)
+	}
+	if pending {
 		return true, nil
-	case sdk.RequestStatusFailed:
-		// log the error message
-		log.Error(nil, "Request status indicates a failure", "message", message)
-		fallthrough // we run the same logic as for status done
-	case sdk.RequestStatusDone:
-		// we don't requeue
-		return false, doneOrFailedCallback()
 	}
+	if req.State == sdk.RequestStatusFailed && recorder != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, requestFailedReason,
+			"Request %s %s failed, request ID: %s", req.Method, req.RequestPath, req.ID())
+	}
+	return false, removeRequest()
+}
 
-	return false, fmt.Errorf("unknown request status %s", status)
+// ClientFactory creates a client for the Cloud API with the credentials stored in the given secret.
+// The endpoint settings of the secret take precedence over the given defaults.
+// Tests can provide a factory, which returns a fake client.
+type ClientFactory func(secret *corev1.Secret, defaults icc.Endpoint, opts ...icc.Option) (ionoscloud.Client, error)
+
+// newClientFromSecret is the default ClientFactory, which creates a client for the Cloud API.
+func newClientFromSecret(secret *corev1.Secret, defaults icc.Endpoint, opts ...icc.Option) (ionoscloud.Client, error) {
+	ionosClient, err := icc.NewClientFromSecret(secret, defaults, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ionosClient, nil
+}
+
+// AuditLog configures the audit log of the mutating requests, which are sent to the Cloud API.
+type AuditLog struct {
+	// Enabled logs every mutating request with the resource, the ID of the request and its outcome.
+	Enabled bool
+	// Events additionally records every mutating request as an event on the IonosCloudCluster.
+	// It has no effect unless Enabled is set.
+	Events bool
 }
 
 func createServiceFromCluster(
 	ctx context.Context,
 	c client.Client,
 	cluster *infrav1.IonosCloudCluster,
+	newClient ClientFactory,
+	clients *ClientCache,
+	rateLimiter *icc.RateLimiter,
+	endpoint icc.Endpoint,
+	recorder record.EventRecorder,
+	dryRun bool,
+	audit AuditLog,
 	log logr.Logger,
+	serviceOpts ...cloud.Option,
 ) (*cloud.Service, error) {
-	secretKey := client.ObjectKey{
-		Namespace: cluster.Namespace,
-		Name:      cluster.Spec.CredentialsRef.Name,
+	authSecret, err := credentials.GetSecret(ctx, c, cluster)
+	if err != nil {
+		return nil, err
 	}
 
-	var authSecret corev1.Secret
-	if err := c.Get(ctx, secretKey, &authSecret); err != nil {
-		return nil, err
+	// The secret of an identity is shared between clusters and managed independently of them.
+	if cluster.Spec.CredentialsRef != nil {
+		if err := ensureSecretControlledByCluster(ctx, c, cluster, authSecret); err != nil {
+			return nil, err
+		}
 	}
 
-	if err := ensureSecretControlledByCluster(ctx, c, cluster, &authSecret); err != nil {
-		return nil, err
+	var opts []icc.Option
+	if rateLimiter != nil {
+		opts = append(opts, icc.WithRateLimiter(rateLimiter))
+	}
+	if dryRun {
+		opts = append(opts, icc.WithDryRun())
+	}
+	opts = append(opts, icc.WithTracing())
+	if audit.Enabled {
+		var auditRecorder record.EventRecorder
+		if audit.Events {
+			auditRecorder = recorder
+		}
+		opts = append(opts, icc.WithAuditLog(auditRecorder, cluster))
+	}
+
+	if newClient == nil {
+		newClient = newClientFromSecret
+	}
+	ionosClient, err := clients.get(cluster, authSecret, dryRun, func() (ionoscloud.Client, error) {
+		return newClient(authSecret, endpoint, opts...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidCredentials, err)
 	}
 
-	token := string(authSecret.Data["token"])
-	apiURL := string(authSecret.Data["apiURL"])
-	caBundle := authSecret.Data["caBundle"]
+	serviceOpts = append(serviceOpts, cloud.WithEventRecorder(recorder))
+	if storage := cluster.Spec.BootstrapStorage; storage != nil {
+		objectStorage, err := newObjectStorageFromCluster(ctx, c, cluster.Namespace, storage, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		serviceOpts = append(serviceOpts, cloud.WithObjectStorage(objectStorage))
+	}
+	if dns := cluster.Spec.DNS; dns != nil {
+		dnsClient, err := icc.NewDNSClientFromSecret(authSecret, endpoint, dns.Endpoint, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidCredentials, err)
+		}
+		serviceOpts = append(serviceOpts, cloud.WithDNS(dnsClient))
+	}
 
-	ionosClient, err := icc.NewClient(token, apiURL, caBundle)
+	return cloud.NewService(ionosClient, log, serviceOpts...)
+}
+
+// newObjectStorageFromCluster creates a client for the bootstrap storage of a cluster
+// with the keys stored in the referenced secret.
+func newObjectStorageFromCluster(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	storage *infrav1.BootstrapStorageSpec,
+	dryRun bool,
+) (ionoscloud.ObjectStorage, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: namespace, Name: storage.CredentialsRef.Name}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("could not get bootstrap storage credentials %s: %w", key, err)
+	}
+	objectStorage, err := icc.NewObjectStorageClientFromSecret(secret, storage.Endpoint, storage.Region, dryRun)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid bootstrap storage credentials %s: %w", key, err)
+	}
+	return objectStorage, nil
+}
+
+// isDryRun returns whether mutating requests to the Cloud API are skipped for the cluster, either because
+// the dry-run mode is enabled for the controller or because the cluster is annotated with DryRunAnnotation.
+func isDryRun(dryRun bool, cluster *infrav1.IonosCloudCluster) bool {
+	return dryRun || cluster.GetAnnotations()[infrav1.DryRunAnnotation] == "true"
+}
+
+// reportDryRun reports the outcome of a reconciliation in dry-run mode in the DryRunInSync condition of obj.
+// A skipped mutation is also recorded as an event. It doesn't fail the reconciliation, instead obj is
+// reconciled again after some time.
+func reportDryRun(
+	recorder record.EventRecorder, obj conditions.Setter, dryRun bool, result ctrl.Result, err error,
+) (ctrl.Result, error) {
+	if !dryRun {
+		conditions.Delete(obj, infrav1.DryRunInSyncCondition)
+		return result, err
+	}
+
+	var dryRunErr *icc.DryRunError
+	if !errors.As(err, &dryRunErr) {
+		if err == nil {
+			conditions.MarkTrue(obj, infrav1.DryRunInSyncCondition)
+		}
+		return result, err
 	}
 
-	return cloud.NewService(ionosClient, log)
+	conditions.MarkFalse(obj, infrav1.DryRunInSyncCondition, infrav1.MutationSkippedReason,
+		clusterv1.ConditionSeverityInfo, "Skipped %s %s", dryRunErr.Method, dryRunErr.Path)
+	if recorder != nil {
+		recorder.Eventf(obj, corev1.EventTypeNormal, infrav1.MutationSkippedReason,
+			"Dry run: skipped %s %s", dryRunErr.Method, dryRunErr.Path)
+	}
+	return ctrl.Result{RequeueAfter: defaultReconcileDuration}, nil
+}
+
+// featureGateDisabled reports whether the feature f, which is used by field of obj, is disabled.
+// In that case, the condition of obj is marked false and a warning event is recorded once.
+// As the feature gates can only be changed by restarting the manager, obj doesn't need to be requeued.
+func featureGateDisabled(
+	recorder record.EventRecorder, obj conditions.Setter, condition clusterv1.ConditionType,
+	f featuregate.Feature, field string,
+) bool {
+	if feature.Gates.Enabled(f) {
+		return false
+	}
+
+	if recorder != nil && conditions.GetReason(obj, condition) != infrav1.FeatureGateDisabledReason {
+		recorder.Eventf(obj, corev1.EventTypeWarning, infrav1.FeatureGateDisabledReason,
+			"%s requires the feature gate %s to be enabled", field, f)
+	}
+	conditions.MarkFalse(obj, condition, infrav1.FeatureGateDisabledReason, clusterv1.ConditionSeverityError,
+		"%s requires the feature gate %s to be enabled", field, f)
+	return true
 }
 
 // ensureSecretControlledByCluster ensures that the secrets will contain a cluster-specific finalizer and an owner reference.
@@ -113,16 +344,43 @@ func ensureSecretControlledByCluster(
 	if err := controllerutil.SetOwnerReference(cluster, secret, c.Scheme()); err != nil {
 		return err
 	}
+	staleFinalizersRemoved := removeStaleCredentialsFinalizers(secret)
 
-	if finalizerAdded || !cmp.Equal(old.GetOwnerReferences(), secret.GetOwnerReferences()) {
+	if finalizerAdded || staleFinalizersRemoved || !cmp.Equal(old.GetOwnerReferences(), secret.GetOwnerReferences()) {
 		return c.Update(ctx, secret)
 	}
 
 	return nil
 }
 
+// removeStaleCredentialsFinalizers removes the cluster-specific finalizers of clusters, which don't own
+// the secret anymore. The UID of a cluster changes when it is moved to another management cluster
+// with clusterctl move. The owner references are updated by clusterctl, but the finalizers are not,
+// which would otherwise prevent the secret from ever being deleted.
+func removeStaleCredentialsFinalizers(secret *corev1.Secret) bool {
+	owners := make(map[string]struct{}, len(secret.GetOwnerReferences()))
+	for _, ref := range secret.GetOwnerReferences() {
+		owners[string(ref.UID)] = struct{}{}
+	}
+
+	removed := false
+	for _, finalizer := range secret.GetFinalizers() {
+		uid, found := strings.CutPrefix(finalizer, infrav1.ClusterFinalizer+"/")
+		if !found {
+			continue
+		}
+		if _, exists := owners[uid]; !exists {
+			removed = controllerutil.RemoveFinalizer(secret, finalizer) || removed
+		}
+	}
+	return removed
+}
+
 // removeCredentialsFinalizer removes the cluster-specific finalizer from the credentials secret.
 func removeCredentialsFinalizer(ctx context.Context, c client.Client, cluster *infrav1.IonosCloudCluster) error {
+	if cluster.Spec.CredentialsRef == nil {
+		return nil
+	}
 	secretKey := client.ObjectKey{
 		Namespace: cluster.Namespace,
 		Name:      cluster.Spec.CredentialsRef.Name,
@@ -137,3 +395,36 @@ func removeCredentialsFinalizer(ctx context.Context, c client.Client, cluster *i
 	controllerutil.RemoveFinalizer(&secret, fmt.Sprintf("%s/%s", infrav1.ClusterFinalizer, cluster.GetUID()))
 	return c.Update(ctx, &secret)
 }
+
+// getClusterScope returns the cluster scope for the provided Cluster API cluster.
+// If the infrastructure cluster has not been created yet, nil is returned.
+func getClusterScope(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) (*scope.Cluster, error) {
+	ionosCloudCluster := &infrav1.IonosCloudCluster{}
+
+	infraClusterName := client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+
+	if err := c.Get(ctx, infraClusterName, ionosCloudCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Cluster has not yet been created
+			return nil, nil
+		}
+		// We at most expect that the cluster cannot be found.
+		// If the error is different, we should return that particular error.
+		return nil, err
+	}
+
+	// Create the cluster scope
+	clusterScope, err := scope.NewCluster(scope.ClusterParams{
+		Client:       c,
+		Cluster:      cluster,
+		IonosCluster: ionosCloudCluster,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster scope: %w", err)
+	}
+
+	return clusterScope, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/feature"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/service/cloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	ionosfake "github.com/ionos-cloud/cluster-api-provider-ionoscloud/test/fake"
+)
+
+func TestRemoveStaleCredentialsFinalizers(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		OwnerReferences: []metav1.OwnerReference{{UID: "moved"}, {UID: "other"}},
+		Finalizers: []string{
+			infrav1.ClusterFinalizer + "/moved",
+			infrav1.ClusterFinalizer + "/other",
+			// The cluster had this UID before it was moved to another management cluster.
+			infrav1.ClusterFinalizer + "/source",
+			"example.com/finalizer",
+		},
+	}}
+
+	require.True(t, removeStaleCredentialsFinalizers(secret))
+	require.Equal(t, []string{
+		infrav1.ClusterFinalizer + "/moved",
+		infrav1.ClusterFinalizer + "/other",
+		"example.com/finalizer",
+	}, secret.Finalizers)
+
+	require.False(t, removeStaleCredentialsFinalizers(secret))
+}
+
+func TestReportDryRun(t *testing.T) {
+	machine := &infrav1.IonosCloudMachine{}
+	recorder := record.NewFakeRecorder(1)
+	skipped := fmt.Errorf("error in step ReconcileServer: %w",
+		&icc.DryRunError{Method: http.MethodPost, Path: "/cloudapi/v6/datacenters/dc/servers"})
+
+	res, err := reportDryRun(recorder, machine, true, ctrl.Result{}, skipped)
+	require.NoError(t, err)
+	require.Equal(t, defaultReconcileDuration, res.RequeueAfter)
+	require.True(t, conditions.IsFalse(machine, infrav1.DryRunInSyncCondition))
+	require.Equal(t, infrav1.MutationSkippedReason, conditions.GetReason(machine, infrav1.DryRunInSyncCondition))
+	require.Equal(t, "Skipped POST /cloudapi/v6/datacenters/dc/servers",
+		conditions.GetMessage(machine, infrav1.DryRunInSyncCondition))
+	require.Equal(t, "Normal MutationSkipped Dry run: skipped POST /cloudapi/v6/datacenters/dc/servers", <-recorder.Events)
+
+	_, err = reportDryRun(recorder, machine, true, ctrl.Result{}, nil)
+	require.NoError(t, err)
+	require.True(t, conditions.IsTrue(machine, infrav1.DryRunInSyncCondition))
+
+	other := errors.New("other error")
+	_, err = reportDryRun(recorder, machine, true, ctrl.Result{}, other)
+	require.ErrorIs(t, err, other)
+
+	_, err = reportDryRun(recorder, machine, false, ctrl.Result{}, nil)
+	require.NoError(t, err)
+	require.False(t, conditions.Has(machine, infrav1.DryRunInSyncCondition))
+}
+
+func TestFeatureGateDisabled(t *testing.T) {
+	machine := &infrav1.IonosCloudMachine{}
+	recorder := record.NewFakeRecorder(2)
+
+	for range 2 {
+		require.True(t, featureGateDisabled(
+			recorder, machine, infrav1.IPAddressClaimedCondition, feature.IPAM, "ipv4PoolRef"))
+		require.True(t, conditions.IsFalse(machine, infrav1.IPAddressClaimedCondition))
+		require.Equal(t, infrav1.FeatureGateDisabledReason,
+			conditions.GetReason(machine, infrav1.IPAddressClaimedCondition))
+	}
+	require.Len(t, recorder.Events, 1, "the event must only be recorded once")
+	require.Equal(t, "Warning FeatureGateDisabled ipv4PoolRef requires the feature gate IPAM to be enabled",
+		<-recorder.Events)
+
+	defer featuregatetesting.SetFeatureGateDuringTest(t, feature.Gates, feature.IPAM, true)()
+	machine = &infrav1.IonosCloudMachine{}
+	require.False(t, featureGateDisabled(
+		recorder, machine, infrav1.IPAddressClaimedCondition, feature.IPAM, "ipv4PoolRef"))
+	require.False(t, conditions.Has(machine, infrav1.IPAddressClaimedCondition))
+}
+
+func TestPollRequestRecordsFailure(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := ionosfake.NewClient()
+	cloudService, err := cloud.NewService(fakeClient, logr.Discard())
+	require.NoError(t, err)
+
+	location, err := fakeClient.DeleteDatacenter(ctx, fakeClient.AddDatacenter("dc", "de/txl"))
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.FailRequest(location, "quota exceeded"))
+
+	cluster := &infrav1.IonosCloudCluster{}
+	recorder := record.NewFakeRecorder(1)
+	req := &infrav1.ProvisioningRequest{Method: http.MethodDelete, RequestPath: location}
+	removed := false
+	requeue, err := pollRequest(ctx, cloudService, recorder, cluster, req, func() error {
+		removed = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, requeue)
+	require.True(t, removed)
+	require.Equal(t, sdk.RequestStatusFailed, req.State)
+	require.NotEmpty(t, req.ID())
+	require.Equal(t, "Warning RequestFailed Request DELETE "+location+" failed, request ID: "+req.ID(), <-recorder.Events)
+}
+
+func TestStepFailedResult(t *testing.T) {
+	quotaExceeded := sdk.NewGenericOpenAPIError("422 Unprocessable Entity", nil, sdk.Error{
+		Messages: &[]sdk.ErrorMessage{{Message: ptr.To("Resource limit exceeded: RAM per contract")}},
+	}, http.StatusUnprocessableEntity)
+
+	tests := []struct {
+		name         string
+		err          error
+		wantErr      bool
+		requeueAfter time.Duration
+		event        string
+	}{{
+		name:         "rate limited",
+		err:          sdk.NewGenericOpenAPIError("", nil, nil, http.StatusTooManyRequests),
+		requeueAfter: defaultReconcileDuration,
+	}, {
+		name:         "quota exceeded",
+		err:          quotaExceeded,
+		requeueAfter: quotaExceededRetryInterval,
+		event:        "Warning QuotaExceeded Step ReconcileServer exceeded the resource limits: Resource limit exceeded: RAM per contract",
+	}, {
+		name:         "unauthorized",
+		err:          sdk.NewGenericOpenAPIError("401 Unauthorized", nil, nil, http.StatusUnauthorized),
+		wantErr:      true,
+		requeueAfter: defaultReconcileDuration,
+		event:        "Warning Unauthorized Step ReconcileServer was not authorized: 401 Unauthorized",
+	}, {
+		name:         "other error",
+		err:          errors.New("other error"),
+		wantErr:      true,
+		requeueAfter: defaultReconcileDuration,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(1)
+			res, err := stepFailedResult(context.Background(), recorder, &infrav1.IonosCloudMachine{}, "ReconcileServer", tt.err)
+			if tt.wantErr {
+				require.EqualError(t, err, "error in step ReconcileServer: "+tt.err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.requeueAfter, res.RequeueAfter)
+			if tt.event != "" {
+				require.Equal(t, tt.event, <-recorder.Events)
+			}
+			require.Empty(t, recorder.Events)
+		})
+	}
+}
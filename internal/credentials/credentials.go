@@ -0,0 +1,108 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials resolves the secret, which contains the credentials of an IonosCloudCluster.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+)
+
+// ErrNamespaceNotAllowed is returned, if an IonosCloudClusterIdentity doesn't allow the namespace of the cluster.
+var ErrNamespaceNotAllowed = errors.New("namespace is not allowed to use the identity")
+
+// GetSecret returns the secret, which contains the credentials of the cluster.
+// If the cluster references an IonosCloudClusterIdentity, the secret of the identity is returned,
+// provided that the identity allows the namespace of the cluster. The contract number of the identity
+// is added to the returned secret, so that it takes precedence over the one stored in the secret.
+func GetSecret(ctx context.Context, c client.Reader, cluster *infrav1.IonosCloudCluster) (*corev1.Secret, error) {
+	var secretKey client.ObjectKey
+	var contractNumber *int32
+	switch {
+	case cluster.Spec.IdentityRef != nil:
+		identity := &infrav1.IonosCloudClusterIdentity{}
+		if err := c.Get(ctx, client.ObjectKey{Name: cluster.Spec.IdentityRef.Name}, identity); err != nil {
+			return nil, fmt.Errorf("could not get IonosCloudClusterIdentity %s: %w", cluster.Spec.IdentityRef.Name, err)
+		}
+
+		allowed, err := IsNamespaceAllowed(ctx, c, identity, cluster.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: identity %s, namespace %s", ErrNamespaceNotAllowed, identity.Name, cluster.Namespace)
+		}
+		secretKey = client.ObjectKey{Namespace: identity.Spec.SecretRef.Namespace, Name: identity.Spec.SecretRef.Name}
+		contractNumber = identity.Spec.ContractNumber
+	case cluster.Spec.CredentialsRef != nil:
+		secretKey = client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.CredentialsRef.Name}
+	default:
+		return nil, errors.New("neither credentialsRef nor identityRef is set")
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("could not get credentials secret %s: %w", secretKey.Name, err)
+	}
+	if contractNumber != nil {
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[icc.ContractNumberKey] = []byte(strconv.FormatInt(int64(*contractNumber), 10))
+	}
+	return secret, nil
+}
+
+// IsNamespaceAllowed checks whether IonosCloudClusters in the given namespace may use the identity.
+func IsNamespaceAllowed(
+	ctx context.Context, c client.Reader, identity *infrav1.IonosCloudClusterIdentity, namespace string,
+) (bool, error) {
+	allowed := identity.Spec.AllowedNamespaces
+	if allowed == nil {
+		return false, nil
+	}
+	if allowed.NamespaceList == nil && allowed.Selector == nil {
+		return true, nil
+	}
+	if slices.Contains(allowed.NamespaceList, namespace) {
+		return true, nil
+	}
+	if allowed.Selector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector of identity %s: %w", identity.Name, err)
+	}
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("could not get namespace %s: %w", namespace, err)
+	}
+	return selector.Matches(labels.Set(ns.GetLabels())), nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	clusterNamespace  = "tenant"
+	identityNamespace = "capic-system"
+)
+
+func TestGetSecret(t *testing.T) {
+	tests := []struct {
+		name              string
+		credentialsRef    *corev1.LocalObjectReference
+		allowedNamespaces *infrav1.AllowedNamespaces
+		wantSecret        string
+		wantErr           error
+	}{
+		{
+			name:           "credentials of the cluster",
+			credentialsRef: &corev1.LocalObjectReference{Name: "cluster-credentials"},
+			wantSecret:     "cluster-credentials",
+		},
+		{
+			name:              "identity allows all namespaces",
+			allowedNamespaces: &infrav1.AllowedNamespaces{},
+			wantSecret:        "shared-credentials",
+		},
+		{
+			name:              "identity allows the namespace by name",
+			allowedNamespaces: &infrav1.AllowedNamespaces{NamespaceList: []string{"other", clusterNamespace}},
+			wantSecret:        "shared-credentials",
+		},
+		{
+			name: "identity allows the namespace by selector",
+			allowedNamespaces: &infrav1.AllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+			},
+			wantSecret: "shared-credentials",
+		},
+		{
+			name:    "identity doesn't allow any namespace",
+			wantErr: ErrNamespaceNotAllowed,
+		},
+		{
+			name: "identity doesn't allow the namespace",
+			allowedNamespaces: &infrav1.AllowedNamespaces{
+				NamespaceList: []string{"other"},
+				Selector:      &metav1.LabelSelector{MatchLabels: map[string]string{"team": "other"}},
+			},
+			wantErr: ErrNamespaceNotAllowed,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &infrav1.IonosCloudCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: clusterNamespace},
+				Spec:       infrav1.IonosCloudClusterSpec{CredentialsRef: test.credentialsRef},
+			}
+			if test.credentialsRef == nil {
+				cluster.Spec.IdentityRef = &infrav1.IonosCloudClusterIdentityReference{Name: "identity"}
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+					Name:   clusterNamespace,
+					Labels: map[string]string{"team": "platform"},
+				}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cluster-credentials", Namespace: clusterNamespace}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shared-credentials", Namespace: identityNamespace}},
+				&infrav1.IonosCloudClusterIdentity{
+					ObjectMeta: metav1.ObjectMeta{Name: "identity"},
+					Spec: infrav1.IonosCloudClusterIdentitySpec{
+						SecretRef:         infrav1.IdentitySecretReference{Name: "shared-credentials", Namespace: identityNamespace},
+						AllowedNamespaces: test.allowedNamespaces,
+					},
+				},
+			).Build()
+
+			secret, err := GetSecret(context.Background(), cl, cluster)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantSecret, secret.Name)
+		})
+	}
+}
+
+func TestGetSecretIdentityContractNumber(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-credentials", Namespace: identityNamespace},
+			Data:       map[string][]byte{"token": []byte("token"), "contractNumber": []byte("1")},
+		},
+		&infrav1.IonosCloudClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity"},
+			Spec: infrav1.IonosCloudClusterIdentitySpec{
+				SecretRef:         infrav1.IdentitySecretReference{Name: "shared-credentials", Namespace: identityNamespace},
+				ContractNumber:    ptr.To[int32](31415),
+				AllowedNamespaces: &infrav1.AllowedNamespaces{},
+			},
+		},
+	).Build()
+
+	cluster := &infrav1.IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: clusterNamespace},
+		Spec: infrav1.IonosCloudClusterSpec{
+			IdentityRef: &infrav1.IonosCloudClusterIdentityReference{Name: "identity"},
+		},
+	}
+
+	secret, err := GetSecret(context.Background(), cl, cluster)
+	require.NoError(t, err)
+	require.Equal(t, "31415", string(secret.Data["contractNumber"]))
+	require.Equal(t, "token", string(secret.Data["token"]))
+}
+
+func TestGetSecretIdentityNotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, infrav1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cluster := &infrav1.IonosCloudCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: clusterNamespace},
+		Spec: infrav1.IonosCloudClusterSpec{
+			IdentityRef: &infrav1.IonosCloudClusterIdentityReference{Name: "missing"},
+		},
+	}
+
+	_, err := GetSecret(context.Background(), cl, cluster)
+	require.True(t, apierrors.IsNotFound(err))
+}
@@ -19,12 +19,31 @@ package ionoscloud
 
 import (
 	"context"
+	"time"
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 )
 
 // Client is an interface for abstracting Cloud API SDK, making it possible to create mocks for testing purposes.
 type Client interface {
+	// CreateDatacenter creates a new data center with the provided properties, returning the request location.
+	CreateDatacenter(ctx context.Context, properties sdk.DatacenterProperties) (string, error)
+	// ListDatacenters returns a list of data centers.
+	ListDatacenters(ctx context.Context) (*sdk.Datacenters, error)
+	// GetDatacenter returns the data center that matches the provided datacenterID.
+	GetDatacenter(ctx context.Context, datacenterID string) (*sdk.Datacenter, error)
+	// DeleteDatacenter deletes the data center that matches the provided datacenterID, returning the request location.
+	DeleteDatacenter(ctx context.Context, datacenterID string) (string, error)
+	// ListLabels returns a list of the labels of all resources.
+	ListLabels(ctx context.Context) (*sdk.Labels, error)
+	// ListDatacenterLabels returns a list of labels of the specified data center.
+	ListDatacenterLabels(ctx context.Context, datacenterID string) (*sdk.LabelResources, error)
+	// CreateDatacenterLabel adds a label with the provided key and value to the specified data center.
+	CreateDatacenterLabel(ctx context.Context, datacenterID, key, value string) error
+	// UpdateDatacenterLabel updates the value of the label with the provided key of the specified data center.
+	UpdateDatacenterLabel(ctx context.Context, datacenterID, key, value string) error
+	// DeleteDatacenterLabel removes the label with the provided key from the specified data center.
+	DeleteDatacenterLabel(ctx context.Context, datacenterID, key string) error
 	// CreateServer creates a new server with provided properties in the specified data center.
 	CreateServer(ctx context.Context, datacenterID string, properties sdk.ServerProperties,
 		entities sdk.ServerEntities) (*sdk.Server, string, error)
@@ -34,11 +53,68 @@ type Client interface {
 	GetServer(ctx context.Context, datacenterID, serverID string) (*sdk.Server, error)
 	// DeleteServer deletes the server that matches the provided serverID in the specified data center.
 	DeleteServer(ctx context.Context, datacenterID, serverID string, deleteVolumes bool) (string, error)
+	// ListServerLabels returns a list of labels of the specified server.
+	ListServerLabels(ctx context.Context, datacenterID, serverID string) (*sdk.LabelResources, error)
+	// CreateServerLabel adds a label with the provided key and value to the specified server.
+	CreateServerLabel(ctx context.Context, datacenterID, serverID, key, value string) error
+	// UpdateServerLabel updates the value of the label with the provided key of the specified server.
+	UpdateServerLabel(ctx context.Context, datacenterID, serverID, key, value string) error
+	// DeleteServerLabel removes the label with the provided key from the specified server.
+	DeleteServerLabel(ctx context.Context, datacenterID, serverID, key string) error
 	// StartServer starts the server that matches the provided serverID in the specified data center.
 	// Returning the location and an error if starting the server fails.
 	StartServer(ctx context.Context, datacenterID, serverID string) (string, error)
+	// StopServer stops the server that matches the provided serverID in the specified data center.
+	// Returning the location and an error if stopping the server fails.
+	StopServer(ctx context.Context, datacenterID, serverID string) (string, error)
+	// RebootServer reboots the server that matches the provided serverID in the specified data center.
+	// Returning the location and an error if rebooting the server fails.
+	RebootServer(ctx context.Context, datacenterID, serverID string) (string, error)
+	// GetServerRemoteConsoleURL returns the URL of the remote console of the server that matches the provided
+	// serverID in the specified data center. The URL contains a short-lived token, which grants access
+	// to the console.
+	GetServerRemoteConsoleURL(ctx context.Context, datacenterID, serverID string) (string, error)
+	// PatchServer updates the server that matches the provided serverID in the specified data center
+	// with the provided properties, returning the request location.
+	PatchServer(ctx context.Context, datacenterID, serverID string, properties sdk.ServerProperties) (string, error)
+	// ListTemplates returns a list of templates, which are used to create CUBE servers.
+	ListTemplates(ctx context.Context) (*sdk.Templates, error)
+	// GetImage returns the image that matches the provided imageID.
+	GetImage(ctx context.Context, imageID string) (*sdk.Image, error)
+	// ListImages returns a list of all images, which are accessible with the credentials.
+	ListImages(ctx context.Context) (*sdk.Images, error)
+	// PatchImage updates the private image that matches the provided imageID with the provided properties,
+	// returning the request location.
+	PatchImage(ctx context.Context, imageID string, properties sdk.ImageProperties) (string, error)
+	// DeleteImage deletes the private image that matches the provided imageID, returning the request location.
+	DeleteImage(ctx context.Context, imageID string) (string, error)
+	// UploadImage uploads an ISO image with the provided name to the FTP server of the given location,
+	// from which the Cloud API creates a private CD-ROM image with the same name.
+	UploadImage(ctx context.Context, location, name string, data []byte) error
+	// ListSnapshots returns a list of all snapshots, which are accessible with the credentials.
+	ListSnapshots(ctx context.Context) (*sdk.Snapshots, error)
+	// GetSnapshot returns the snapshot that matches the provided snapshotID.
+	GetSnapshot(ctx context.Context, snapshotID string) (*sdk.Snapshot, error)
 	// DeleteVolume deletes the volume that matches the provided volumeID in the specified data center.
 	DeleteVolume(ctx context.Context, datacenterID, volumeID string) (string, error)
+	// ListVolumeLabels returns a list of labels of the specified volume.
+	ListVolumeLabels(ctx context.Context, datacenterID, volumeID string) (*sdk.LabelResources, error)
+	// CreateVolumeLabel adds a label with the provided key and value to the specified volume.
+	CreateVolumeLabel(ctx context.Context, datacenterID, volumeID, key, value string) error
+	// UpdateVolumeLabel updates the value of the label with the provided key of the specified volume.
+	UpdateVolumeLabel(ctx context.Context, datacenterID, volumeID, key, value string) error
+	// DeleteVolumeLabel removes the label with the provided key from the specified volume.
+	DeleteVolumeLabel(ctx context.Context, datacenterID, volumeID, key string) error
+	// CreateServerVolume creates a volume with the provided properties and attaches it to the server in the
+	// specified data center, returning the volume and the request location.
+	CreateServerVolume(ctx context.Context, datacenterID, serverID string,
+		properties sdk.VolumeProperties) (*sdk.Volume, string, error)
+	// DetachVolume detaches the volume that matches the provided volumeID from the server in the specified
+	// data center, returning the request location.
+	DetachVolume(ctx context.Context, datacenterID, serverID, volumeID string) (string, error)
+	// DetachCDROM ejects the CD-ROM image that matches the provided imageID from the server in the specified
+	// data center, returning the request location.
+	DetachCDROM(ctx context.Context, datacenterID, serverID, imageID string) (string, error)
 	// CreateLAN creates a new LAN with the provided properties in the specified data center,
 	// returning the request path.
 	CreateLAN(ctx context.Context, datacenterID string, properties sdk.LanPropertiesPost) (string, error)
@@ -64,6 +140,118 @@ type Client interface {
 	WaitForRequest(ctx context.Context, requestURL string) error
 	// GetRequests returns the requests made in the last 24 hours that match the provided method and path.
 	GetRequests(ctx context.Context, method, path string) ([]sdk.Request, error)
+	// CreateNetworkLoadBalancer creates a new Network Load Balancer with the provided properties and entities in the
+	// specified data center, returning the request location.
+	CreateNetworkLoadBalancer(ctx context.Context, datacenterID string, properties sdk.NetworkLoadBalancerProperties,
+		entities sdk.NetworkLoadBalancerEntities) (string, error)
+	// ListNetworkLoadBalancers returns a list of Network Load Balancers in the specified data center.
+	ListNetworkLoadBalancers(ctx context.Context, datacenterID string) (*sdk.NetworkLoadBalancers, error)
+	// GetNetworkLoadBalancer returns the Network Load Balancer that matches the provided loadBalancerID
+	// in the specified data center.
+	GetNetworkLoadBalancer(ctx context.Context, datacenterID, loadBalancerID string) (*sdk.NetworkLoadBalancer, error)
+	// DeleteNetworkLoadBalancer deletes the Network Load Balancer that matches the provided loadBalancerID
+	// in the specified data center, returning the request location.
+	DeleteNetworkLoadBalancer(ctx context.Context, datacenterID, loadBalancerID string) (string, error)
+	// PatchNetworkLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+	// Network Load Balancer with the provided properties, returning the request location.
+	PatchNetworkLoadBalancerForwardingRule(ctx context.Context, datacenterID, loadBalancerID, ruleID string,
+		properties sdk.NetworkLoadBalancerForwardingRuleProperties) (string, error)
+	// CreateNATGateway creates a new NAT Gateway with the provided properties and entities in the
+	// specified data center, returning the request location.
+	CreateNATGateway(ctx context.Context, datacenterID string, properties sdk.NatGatewayProperties,
+		entities sdk.NatGatewayEntities) (string, error)
+	// ListNATGateways returns a list of NAT Gateways in the specified data center.
+	ListNATGateways(ctx context.Context, datacenterID string) (*sdk.NatGateways, error)
+	// DeleteNATGateway deletes the NAT Gateway that matches the provided natGatewayID
+	// in the specified data center, returning the request location.
+	DeleteNATGateway(ctx context.Context, datacenterID, natGatewayID string) (string, error)
+	// CreateApplicationLoadBalancer creates a new Application Load Balancer with the provided properties and
+	// entities in the specified data center, returning the request location.
+	CreateApplicationLoadBalancer(ctx context.Context, datacenterID string,
+		properties sdk.ApplicationLoadBalancerProperties, entities sdk.ApplicationLoadBalancerEntities) (string, error)
+	// ListApplicationLoadBalancers returns a list of Application Load Balancers in the specified data center.
+	ListApplicationLoadBalancers(ctx context.Context, datacenterID string) (*sdk.ApplicationLoadBalancers, error)
+	// DeleteApplicationLoadBalancer deletes the Application Load Balancer that matches the provided loadBalancerID
+	// in the specified data center, returning the request location.
+	DeleteApplicationLoadBalancer(ctx context.Context, datacenterID, loadBalancerID string) (string, error)
+	// PatchApplicationLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+	// Application Load Balancer with the provided properties, returning the request location.
+	PatchApplicationLoadBalancerForwardingRule(ctx context.Context, datacenterID, loadBalancerID, ruleID string,
+		properties sdk.ApplicationLoadBalancerForwardingRuleProperties) (string, error)
+	// CreateTargetGroup creates a new target group with the provided properties, returning the request location.
+	CreateTargetGroup(ctx context.Context, properties sdk.TargetGroupProperties) (string, error)
+	// ListTargetGroups returns a list of target groups.
+	ListTargetGroups(ctx context.Context) (*sdk.TargetGroups, error)
+	// PatchTargetGroup patches the target group that matches the provided targetGroupID with the provided
+	// properties, returning the request location.
+	PatchTargetGroup(ctx context.Context, targetGroupID string, properties sdk.TargetGroupProperties) (string, error)
+	// DeleteTargetGroup deletes the target group that matches the provided targetGroupID,
+	// returning the request location.
+	DeleteTargetGroup(ctx context.Context, targetGroupID string) (string, error)
+	// CreateCrossConnect creates a new Cross Connect with the provided properties, returning the request location.
+	CreateCrossConnect(ctx context.Context, properties sdk.PrivateCrossConnectProperties) (string, error)
+	// ListCrossConnects returns a list of Cross Connects.
+	ListCrossConnects(ctx context.Context) (*sdk.PrivateCrossConnects, error)
+	// DeleteCrossConnect deletes the Cross Connect that matches the provided crossConnectID,
+	// returning the request location.
+	DeleteCrossConnect(ctx context.Context, crossConnectID string) (string, error)
 	// PatchNIC updates the NIC identified by nicID with the provided properties, returning the request location.
 	PatchNIC(ctx context.Context, datacenterID, serverID, nicID string, properties sdk.NicProperties) (string, error)
+	// ListFirewallRules returns a list of firewall rules of the NIC identified by nicID.
+	ListFirewallRules(ctx context.Context, datacenterID, serverID, nicID string) (*sdk.FirewallRules, error)
+	// CreateFirewallRule creates a firewall rule with the provided properties on the NIC identified by nicID,
+	// returning the request location.
+	CreateFirewallRule(ctx context.Context, datacenterID, serverID, nicID string,
+		properties sdk.FirewallruleProperties) (string, error)
+	// PatchFirewallRule patches the firewall rule that matches ruleID of the NIC identified by nicID
+	// with the provided properties, returning the request location.
+	PatchFirewallRule(ctx context.Context, datacenterID, serverID, nicID, ruleID string,
+		properties sdk.FirewallruleProperties) (string, error)
+	// DeleteFirewallRule deletes the firewall rule that matches ruleID of the NIC identified by nicID,
+	// returning the request location.
+	DeleteFirewallRule(ctx context.Context, datacenterID, serverID, nicID, ruleID string) (string, error)
+	// ListContracts returns the contracts of the user, including their resource limits and provisioned resources.
+	ListContracts(ctx context.Context) (*sdk.Contracts, error)
+}
+
+// ObjectStorage is an interface for abstracting the S3 compatible API of IONOS Object Storage.
+type ObjectStorage interface {
+	// PutObject uploads the provided data as the object with the provided key to the bucket.
+	PutObject(ctx context.Context, bucket, key string, data []byte) error
+	// DeleteObject deletes the object with the provided key from the bucket. Deleting a missing object succeeds.
+	DeleteObject(ctx context.Context, bucket, key string) error
+	// PresignGetObject returns a URL, which allows downloading the object with the provided key
+	// without credentials until the URL expires.
+	PresignGetObject(bucket, key string, expiry time.Duration) (string, error)
+	// EnsureExpirationRule ensures the lifecycle configuration of the bucket contains a rule with the provided ID,
+	// which deletes all objects with the provided prefix after the provided number of days.
+	EnsureExpirationRule(ctx context.Context, bucket, ruleID, prefix string, days int32) error
+}
+
+// DNSRecord is a record in a zone of IONOS Cloud DNS.
+type DNSRecord struct {
+	// ID is the UUID of the record. It is empty for records, which haven't been created yet.
+	ID string
+	// Name is the name of the record relative to the zone.
+	Name string
+	// Type is the type of the record, e.g. A or AAAA.
+	Type string
+	// Content is the value of the record, e.g. an IP address.
+	Content string
+	// TTL is the time to live of the record in seconds.
+	TTL int32
+}
+
+// DNS is an interface for abstracting the API of IONOS Cloud DNS.
+type DNS interface {
+	// GetZoneID returns the ID of the zone with the provided name.
+	GetZoneID(ctx context.Context, zoneName string) (string, error)
+	// ListRecords returns the records of the zone, which have the provided name.
+	ListRecords(ctx context.Context, zoneID, name string) ([]DNSRecord, error)
+	// CreateRecord creates the provided record in the zone, returning its ID.
+	CreateRecord(ctx context.Context, zoneID string, record DNSRecord) (string, error)
+	// UpdateRecord replaces the record of the zone, which has the ID of the provided record.
+	UpdateRecord(ctx context.Context, zoneID string, record DNSRecord) error
+	// DeleteRecord deletes the record with the provided ID from the zone. Deleting a missing record succeeds.
+	DeleteRecord(ctx context.Context, zoneID, recordID string) error
 }
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AuditEventReason is the reason of the events, which are recorded for the mutating requests of a client
+// with an audit log.
+const AuditEventReason = "CloudAPIMutation"
+
+// Outcomes of the requests in the audit log.
+const (
+	auditOutcomeSucceeded = "succeeded"
+	auditOutcomeFailed    = "failed"
+	auditOutcomeSkipped   = "skipped"
+)
+
+// apiPathPrefix precedes the paths of all resources in the Cloud API.
+const apiPathPrefix = "/cloudapi/v6/"
+
+// resourceActions are the path segments, which trigger an action on a resource instead of
+// referring to a collection, e.g. /datacenters/<id>/servers/<id>/start.
+var resourceActions = map[string]bool{
+	"start":   true,
+	"stop":    true,
+	"reboot":  true,
+	"suspend": true,
+	"resume":  true,
+	"upgrade": true,
+	"restore": true,
+}
+
+// WithAuditLog logs every request of the client, which mutates a resource, i.e. every request except GET and HEAD,
+// together with the type and the IDs of the resource, the ID of the request in the Cloud API and its outcome.
+// The entries are written by the logger of the request context under the name "audit", so that they contain the
+// reconciled object. If recorder is not nil, every request is additionally recorded as an event on obj.
+// It should be passed last, so that requests, which were skipped in dry-run mode, are logged as well.
+func WithAuditLog(recorder record.EventRecorder, obj runtime.Object) Option {
+	return func(c *IonosCloudClient) {
+		cfg := c.API.GetConfig()
+		base := http.DefaultTransport
+		if cfg.HTTPClient != nil && cfg.HTTPClient.Transport != nil {
+			base = cfg.HTTPClient.Transport
+		}
+		cfg.HTTPClient = &http.Client{Transport: &auditTransport{base: base, recorder: recorder, obj: obj}}
+	}
+}
+
+type auditTransport struct {
+	base     http.RoundTripper
+	recorder record.EventRecorder
+	obj      runtime.Object
+}
+
+// auditEntry describes a single mutating request.
+type auditEntry struct {
+	method       string
+	path         string
+	resourceType string
+	// resourceID is the ID of the mutated or created resource.
+	resourceID string
+	// resourceIDs contains all IDs in the path, i.e. the IDs of the parent resources and the resource itself.
+	resourceIDs []string
+	action      string
+	requestID   string
+	statusCode  int
+	outcome     string
+	err         error
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := newAuditEntry(req)
+	res, err := t.base.RoundTrip(req)
+	entry.complete(res, err)
+	t.record(req, entry)
+	return res, err
+}
+
+func (t *auditTransport) record(req *http.Request, entry *auditEntry) {
+	keysAndValues := []any{
+		"method", entry.method,
+		"path", entry.path,
+		"resourceType", entry.resourceType,
+		"resourceID", entry.resourceID,
+		"resourceIDs", entry.resourceIDs,
+		"requestID", entry.requestID,
+		"outcome", entry.outcome,
+	}
+	if entry.action != "" {
+		keysAndValues = append(keysAndValues, "action", entry.action)
+	}
+	if entry.statusCode != 0 {
+		keysAndValues = append(keysAndValues, "statusCode", entry.statusCode)
+	}
+	if entry.err != nil {
+		keysAndValues = append(keysAndValues, "error", entry.err.Error())
+	}
+	ctrl.LoggerFrom(req.Context()).WithName("audit").Info("Mutating request to the Cloud API", keysAndValues...)
+
+	if t.recorder == nil || t.obj == nil {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if entry.outcome != auditOutcomeSucceeded {
+		eventType = corev1.EventTypeWarning
+	}
+	t.recorder.Event(t.obj, eventType, AuditEventReason, entry.message())
+}
+
+// newAuditEntry derives the type and the IDs of the resource from the path of the request, which alternates
+// between collections and the IDs of their items.
+func newAuditEntry(req *http.Request) *auditEntry {
+	entry := &auditEntry{method: req.Method, path: req.URL.Path}
+
+	path := req.URL.Path
+	if _, after, found := strings.Cut(path, apiPathPrefix); found {
+		path = after
+	}
+	segments := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if n := len(segments); n > 1 && n%2 == 1 && resourceActions[segments[n-1]] {
+		entry.action = segments[n-1]
+		segments = segments[:n-1]
+	}
+	for i, segment := range segments {
+		if i%2 == 0 {
+			entry.resourceType, entry.resourceID = segment, ""
+		} else {
+			entry.resourceID = segment
+			entry.resourceIDs = append(entry.resourceIDs, segment)
+		}
+	}
+	return entry
+}
+
+// complete adds the outcome of the request to the entry. The ID of a created resource is taken from
+// the body of the response, which is restored afterward.
+func (e *auditEntry) complete(res *http.Response, err error) {
+	var dryRunErr *DryRunError
+	switch {
+	case errors.As(err, &dryRunErr):
+		e.outcome = auditOutcomeSkipped
+		return
+	case err != nil:
+		e.outcome, e.err = auditOutcomeFailed, err
+		return
+	}
+
+	e.statusCode = res.StatusCode
+	e.requestID = requestIDFromLocation(res.Header.Get(locationHeaderKey))
+	if res.StatusCode >= http.StatusBadRequest {
+		e.outcome = auditOutcomeFailed
+		return
+	}
+	e.outcome = auditOutcomeSucceeded
+
+	if e.method != http.MethodPost || e.resourceID != "" || res.Body == nil {
+		return
+	}
+	body, readErr := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(body, &created) == nil && created.ID != "" {
+		e.resourceID = created.ID
+		e.resourceIDs = append(e.resourceIDs, created.ID)
+	}
+}
+
+func (e *auditEntry) message() string {
+	resource := e.resourceType
+	if e.resourceID != "" {
+		resource += " " + e.resourceID
+	}
+	if e.action != "" {
+		resource += " (" + e.action + ")"
+	}
+	msg := fmt.Sprintf("%s %s %s", e.method, resource, e.outcome)
+	if e.statusCode != 0 {
+		msg += fmt.Sprintf(" with status %d", e.statusCode)
+	}
+	if e.requestID != "" {
+		msg += ", request ID: " + e.requestID
+	}
+	return msg
+}
+
+// requestIDFromLocation returns the ID of the request, which is part of the location header,
+// e.g. /requests/<id>/status. If the location doesn't contain an ID, an empty string is returned.
+func requestIDFromLocation(location string) string {
+	_, after, found := strings.Cut(location, "/requests/")
+	if !found {
+		return ""
+	}
+	id, _, _ := strings.Cut(after, "/")
+	return id
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+const exampleServersURL = "https://api.ionos.com/cloudapi/v6/datacenters/dc/servers"
+
+func newAuditTransport() (*auditTransport, *httpmock.MockTransport, *record.FakeRecorder) {
+	mock := httpmock.NewMockTransport()
+	recorder := record.NewFakeRecorder(10)
+	return &auditTransport{base: mock, recorder: recorder, obj: &infrav1.IonosCloudCluster{}}, mock, recorder
+}
+
+func newLocationResponder(status int, body string) httpmock.Responder {
+	return func(*http.Request) (*http.Response, error) {
+		res := httpmock.NewStringResponse(status, body)
+		res.Header.Set(locationHeaderKey, "https://api.ionos.com/cloudapi/v6/requests/req/status")
+		return res, nil
+	}
+}
+
+func TestAuditTransportIgnoresReadRequests(t *testing.T) {
+	transport, mock, recorder := newAuditTransport()
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.NewStringResponder(http.StatusOK, ""))
+
+	require.Equal(t, http.StatusOK, roundTrip(t, transport, newRequest(t, http.MethodGet, nil)))
+	require.Empty(t, recorder.Events)
+}
+
+func TestAuditTransportRecordsCreatedResource(t *testing.T) {
+	transport, mock, recorder := newAuditTransport()
+	mock.RegisterResponder(http.MethodPost, exampleServersURL,
+		newLocationResponder(http.StatusAccepted, `{"id":"server"}`))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, exampleServersURL, nil)
+	require.NoError(t, err)
+	res, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	require.JSONEq(t, `{"id":"server"}`, string(body), "the body must be restored")
+	require.Len(t, recorder.Events, 1)
+	require.Equal(t, corev1.EventTypeNormal+" "+AuditEventReason+
+		" POST servers server succeeded with status 202, request ID: req", <-recorder.Events)
+}
+
+func TestAuditTransportRecordsFailedRequest(t *testing.T) {
+	transport, mock, recorder := newAuditTransport()
+	mock.RegisterResponder(http.MethodDelete, exampleServersURL+"/server",
+		httpmock.NewStringResponder(http.StatusNotFound, ""))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, exampleServersURL+"/server", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, roundTrip(t, transport, req))
+
+	require.Len(t, recorder.Events, 1)
+	require.Equal(t, corev1.EventTypeWarning+" "+AuditEventReason+
+		" DELETE servers server failed with status 404", <-recorder.Events)
+}
+
+func TestAuditTransportRecordsSkippedRequest(t *testing.T) {
+	transport, mock, recorder := newAuditTransport()
+	transport.base = &dryRunTransport{base: mock}
+
+	//nolint:bodyclose // No response is returned.
+	_, err := transport.RoundTrip(newRequest(t, http.MethodPost, nil))
+	var dryRunErr *DryRunError
+	require.ErrorAs(t, err, &dryRunErr)
+
+	require.Len(t, recorder.Events, 1)
+	require.Equal(t, corev1.EventTypeWarning+" "+AuditEventReason+" POST datacenters skipped", <-recorder.Events)
+}
+
+func TestAuditTransportWithoutRecorder(t *testing.T) {
+	transport, mock, _ := newAuditTransport()
+	transport.recorder = nil
+	mock.RegisterResponder(http.MethodPost, exampleURL, newLocationResponder(http.StatusAccepted, ""))
+
+	require.NotPanics(t, func() {
+		require.Equal(t, http.StatusAccepted, roundTrip(t, transport, newRequest(t, http.MethodPost, nil)))
+	})
+}
+
+func TestNewAuditEntry(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		resourceType string
+		resourceID   string
+		resourceIDs  []string
+		action       string
+	}{{
+		name:         "create",
+		method:       http.MethodPost,
+		path:         "/cloudapi/v6/datacenters/dc/servers",
+		resourceType: "servers",
+		resourceIDs:  []string{"dc"},
+	}, {
+		name:         "update",
+		method:       http.MethodPatch,
+		path:         "/cloudapi/v6/datacenters/dc/servers/server/nics/nic",
+		resourceType: "nics",
+		resourceID:   "nic",
+		resourceIDs:  []string{"dc", "server", "nic"},
+	}, {
+		name:         "action",
+		method:       http.MethodPost,
+		path:         "/cloudapi/v6/datacenters/dc/servers/server/start",
+		resourceType: "servers",
+		resourceID:   "server",
+		resourceIDs:  []string{"dc", "server"},
+		action:       "start",
+	}, {
+		name:         "custom endpoint",
+		method:       http.MethodDelete,
+		path:         "/proxy/cloudapi/v6/ipblocks/block",
+		resourceType: "ipblocks",
+		resourceID:   "block",
+		resourceIDs:  []string{"block"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(context.Background(), test.method,
+				"https://api.ionos.com"+test.path, nil)
+			require.NoError(t, err)
+
+			entry := newAuditEntry(req)
+			require.Equal(t, test.resourceType, entry.resourceType)
+			require.Equal(t, test.resourceID, entry.resourceID)
+			require.Equal(t, test.resourceIDs, entry.resourceIDs)
+			require.Equal(t, test.action, entry.action)
+		})
+	}
+}
+
+func TestWithAuditLog(t *testing.T) {
+	c, err := NewClient(Credentials{Token: "token"}, Endpoint{}, WithDryRun(), WithAuditLog(nil, nil))
+	require.NoError(t, err)
+	transport, ok := c.API.GetConfig().HTTPClient.Transport.(*auditTransport)
+	require.True(t, ok)
+	require.IsType(t, &dryRunTransport{}, transport.base)
+}
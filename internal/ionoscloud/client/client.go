@@ -24,53 +24,182 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"slices"
+	"strconv"
 	"time"
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
 )
 
 const (
-	locationHeaderKey = "Location"
+	locationHeaderKey       = "Location"
+	contractNumberHeaderKey = "X-Contract-Number"
 )
 
+// ContractNumberKey is the key of the contract number in a credentials secret.
+const ContractNumberKey = "contractNumber"
+
 // IonosCloudClient is a concrete implementation of the Client interface defined in the internal client package that
 // communicates with Cloud API using its SDK.
 type IonosCloudClient struct {
 	API          *sdk.APIClient
 	requestDepth int32
+
+	// username and password authenticate image uploads, which don't support tokens.
+	username string
+	password string
+	// dryRun prevents image uploads, while the transport of the API skips mutating requests.
+	dryRun bool
 }
 
 var _ ionoscloud.Client = &IonosCloudClient{}
 
+// Credentials are used to authenticate against the Cloud API.
+// Either a token or a username and password need to be provided.
+// If both are provided, the token takes precedence.
+type Credentials struct {
+	Token    string
+	Username string
+	Password string
+	// ContractNumber selects the contract, in which the resources are managed, for users with access to
+	// multiple contracts, e.g. resellers. If it is zero, the default contract of the user is used.
+	ContractNumber int32
+}
+
+// Endpoint configures the connection to the Cloud API. It allows using alternative endpoints,
+// e.g. of testing environments or API proxies.
+type Endpoint struct {
+	// URL is the URL of the Cloud API. If empty, the default URL of the SDK is used.
+	URL string
+	// CABundle contains PEM encoded CA certificates, which are trusted instead of the system CAs.
+	CABundle []byte
+	// InsecureSkipVerify disables the verification of the TLS certificate of the Cloud API.
+	// It must only be used for testing environments.
+	InsecureSkipVerify bool
+	// ProxyURL is the URL of an HTTP proxy, through which all requests are sent. If empty,
+	// the proxy is taken from the HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+}
+
+// Validate checks that the CA bundle and the proxy URL of the endpoint can be parsed.
+func (e Endpoint) Validate() error {
+	_, err := newTransport(e)
+	return err
+}
+
+// Option configures an IonosCloudClient.
+type Option func(*IonosCloudClient)
+
 // NewClient instantiates a usable IonosCloudClient.
-// The client needs a token to work. Basic auth is not supported.
-// Passing a CA bundle is optional.
-func NewClient(token, apiURL string, caBundle []byte) (*IonosCloudClient, error) {
-	if token == "" {
-		return nil, errors.New("token must be set")
+// The client needs either a token or a username and password to work.
+// Configuring the endpoint is optional.
+func NewClient(credentials Credentials, endpoint Endpoint, opts ...Option) (*IonosCloudClient, error) {
+	if credentials.Token == "" && (credentials.Username == "" || credentials.Password == "") {
+		return nil, errors.New("either token or username and password must be set")
+	}
+
+	username, password := credentials.Username, credentials.Password
+	if credentials.Token != "" {
+		username, password = "", ""
+	}
+	cfg := sdk.NewConfiguration(username, password, credentials.Token, endpoint.URL)
+	if credentials.ContractNumber != 0 {
+		cfg.AddDefaultHeader(contractNumberHeaderKey, strconv.FormatInt(int64(credentials.ContractNumber), 10))
+	}
+
+	if len(endpoint.CABundle) > 0 || endpoint.InsecureSkipVerify || endpoint.ProxyURL != "" {
+		transport, err := newTransport(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		cfg.HTTPClient = &http.Client{Transport: transport}
 	}
-	cfg := sdk.NewConfiguration("", "", token, apiURL)
 
-	if len(caBundle) > 0 {
+	c := &IonosCloudClient{
+		API:      sdk.NewAPIClient(cfg),
+		username: credentials.Username,
+		password: credentials.Password,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// newTransport creates the transport for a custom CA bundle, an insecure connection or an HTTP proxy.
+func newTransport(endpoint Endpoint) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{ //#nosec G402 # Use Go's default MinVersion
+		InsecureSkipVerify: endpoint.InsecureSkipVerify, //nolint:gosec // Only meant for testing environments.
+	}
+
+	if len(endpoint.CABundle) > 0 {
 		caCertPool := x509.NewCertPool()
-		if !caCertPool.AppendCertsFromPEM(caBundle) {
+		if !caCertPool.AppendCertsFromPEM(endpoint.CABundle) {
 			return nil, errors.New("failed to read trusted CA certificates bundle")
 		}
+		transport.TLSClientConfig.RootCAs = caCertPool
+	}
 
-		transport := http.DefaultTransport.(*http.Transport).Clone()
-		transport.TLSClientConfig = &tls.Config{ //#nosec G402 # Use Go's default MinVersion
-			RootCAs: caCertPool,
+	if endpoint.ProxyURL != "" {
+		proxyURL, err := url.Parse(endpoint.ProxyURL)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", endpoint.ProxyURL)
 		}
-		cfg.HTTPClient = &http.Client{Transport: transport}
+		transport.Proxy = http.ProxyURL(proxyURL)
 	}
+	return transport, nil
+}
 
-	apiClient := sdk.NewAPIClient(cfg)
-	return &IonosCloudClient{
-		API: apiClient,
-	}, nil
+// NewClientFromSecret instantiates an IonosCloudClient with the credentials stored in the given secret.
+// The secret needs to contain either a token or a username and password. The key contractNumber is optional
+// and selects the contract. The keys apiURL, caBundle, insecure and proxyURL are optional and override the
+// given defaults of the endpoint.
+func NewClientFromSecret(secret *corev1.Secret, defaults Endpoint, opts ...Option) (*IonosCloudClient, error) {
+	credentials := Credentials{
+		Token:    string(secret.Data["token"]),
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}
+	if v := secret.Data[ContractNumberKey]; len(v) > 0 {
+		contractNumber, err := strconv.ParseInt(string(v), 10, 32)
+		if err != nil || contractNumber <= 0 {
+			return nil, fmt.Errorf("invalid value of key %s: %q is not a positive number", ContractNumberKey, v)
+		}
+		credentials.ContractNumber = int32(contractNumber)
+	}
+	endpoint, err := endpointFromSecret(secret, defaults)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(credentials, endpoint, opts...)
+}
+
+// endpointFromSecret returns the endpoint, which is configured in the secret. Keys, which are not set,
+// are taken from the defaults.
+func endpointFromSecret(secret *corev1.Secret, defaults Endpoint) (Endpoint, error) {
+	endpoint := defaults
+	if v := secret.Data["apiURL"]; len(v) > 0 {
+		endpoint.URL = string(v)
+	}
+	if v := secret.Data["caBundle"]; len(v) > 0 {
+		endpoint.CABundle = v
+	}
+	if v := secret.Data["insecure"]; len(v) > 0 {
+		insecure, err := strconv.ParseBool(string(v))
+		if err != nil {
+			return Endpoint{}, fmt.Errorf("invalid value of key insecure: %w", err)
+		}
+		endpoint.InsecureSkipVerify = insecure
+	}
+	if v := secret.Data["proxyURL"]; len(v) > 0 {
+		endpoint.ProxyURL = string(v)
+	}
+	return endpoint, nil
 }
 
 // WithDepth creates a temporary copy of the client, where a custom depth can be set.
@@ -88,7 +217,189 @@ func clone(client *IonosCloudClient) *IonosCloudClient {
 	return &IonosCloudClient{
 		API:          client.API,
 		requestDepth: client.requestDepth,
+		username:     client.username,
+		password:     client.password,
+		dryRun:       client.dryRun,
+	}
+}
+
+// CreateDatacenter creates a new data center with the provided properties, returning the request location.
+func (c *IonosCloudClient) CreateDatacenter(ctx context.Context, properties sdk.DatacenterProperties) (string, error) {
+	if location := properties.GetLocation(); location == nil || *location == "" {
+		return "", errors.New("location must be set")
+	}
+
+	datacenter := sdk.Datacenter{
+		Properties: &properties,
+	}
+
+	_, res, err := c.API.DataCentersApi.
+		DatacentersPost(ctx).
+		Datacenter(datacenter).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListDatacenters returns a list of data centers.
+func (c *IonosCloudClient) ListDatacenters(ctx context.Context) (*sdk.Datacenters, error) {
+	datacenters, _, err := c.API.DataCentersApi.
+		DatacentersGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &datacenters, nil
+}
+
+// GetDatacenter returns the data center that matches the provided datacenterID.
+func (c *IonosCloudClient) GetDatacenter(ctx context.Context, datacenterID string) (*sdk.Datacenter, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+	datacenter, _, err := c.API.DataCentersApi.
+		DatacentersFindById(ctx, datacenterID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &datacenter, nil
+}
+
+// DeleteDatacenter deletes the data center that matches the provided datacenterID, returning the request location.
+func (c *IonosCloudClient) DeleteDatacenter(ctx context.Context, datacenterID string) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	res, err := c.API.DataCentersApi.
+		DatacentersDelete(ctx, datacenterID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListLabels returns a list of the labels of all resources.
+func (c *IonosCloudClient) ListLabels(ctx context.Context) (*sdk.Labels, error) {
+	labels, _, err := c.API.LabelsApi.
+		LabelsGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &labels, nil
+}
+
+// ListDatacenterLabels returns a list of labels of the specified data center.
+func (c *IonosCloudClient) ListDatacenterLabels(ctx context.Context, datacenterID string) (*sdk.LabelResources, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	labels, _, err := c.API.LabelsApi.
+		DatacentersLabelsGet(ctx, datacenterID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &labels, nil
+}
+
+// CreateDatacenterLabel adds a label with the provided key and value to the specified data center.
+func (c *IonosCloudClient) CreateDatacenterLabel(ctx context.Context, datacenterID, key, value string) error {
+	if datacenterID == "" {
+		return errDatacenterIDIsEmpty
+	}
+
+	if key == "" {
+		return errLabelKeyIsEmpty
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersLabelsPost(ctx, datacenterID).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// UpdateDatacenterLabel updates the value of the label with the provided key of the specified data center.
+func (c *IonosCloudClient) UpdateDatacenterLabel(ctx context.Context, datacenterID, key, value string) error {
+	if datacenterID == "" {
+		return errDatacenterIDIsEmpty
+	}
+
+	if key == "" {
+		return errLabelKeyIsEmpty
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersLabelsPut(ctx, datacenterID, key).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// DeleteDatacenterLabel removes the label with the provided key from the specified data center.
+func (c *IonosCloudClient) DeleteDatacenterLabel(ctx context.Context, datacenterID, key string) error {
+	if datacenterID == "" {
+		return errDatacenterIDIsEmpty
+	}
+
+	if key == "" {
+		return errLabelKeyIsEmpty
+	}
+
+	_, err := c.API.LabelsApi.
+		DatacentersLabelsDelete(ctx, datacenterID, key).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
 	}
+
+	return nil
 }
 
 // CreateServer creates a new server with provided properties in the specified data center.
@@ -173,6 +484,107 @@ func (c *IonosCloudClient) DeleteServer(ctx context.Context, datacenterID, serve
 	return "", errLocationHeaderEmpty
 }
 
+// ListServerLabels returns a list of labels of the specified server.
+func (c *IonosCloudClient) ListServerLabels(ctx context.Context, datacenterID, serverID string) (*sdk.LabelResources, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	if serverID == "" {
+		return nil, errServerIDIsEmpty
+	}
+
+	labels, _, err := c.API.LabelsApi.
+		DatacentersServersLabelsGet(ctx, datacenterID, serverID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &labels, nil
+}
+
+// CreateServerLabel adds a label with the provided key and value to the specified server.
+func (c *IonosCloudClient) CreateServerLabel(ctx context.Context, datacenterID, serverID, key, value string) error {
+	if err := validateServerLabelParams(datacenterID, serverID, key); err != nil {
+		return err
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersServersLabelsPost(ctx, datacenterID, serverID).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// UpdateServerLabel updates the value of the label with the provided key of the specified server.
+func (c *IonosCloudClient) UpdateServerLabel(ctx context.Context, datacenterID, serverID, key, value string) error {
+	if err := validateServerLabelParams(datacenterID, serverID, key); err != nil {
+		return err
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersServersLabelsPut(ctx, datacenterID, serverID, key).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// DeleteServerLabel removes the label with the provided key from the specified server.
+func (c *IonosCloudClient) DeleteServerLabel(ctx context.Context, datacenterID, serverID, key string) error {
+	if err := validateServerLabelParams(datacenterID, serverID, key); err != nil {
+		return err
+	}
+
+	_, err := c.API.LabelsApi.
+		DatacentersServersLabelsDelete(ctx, datacenterID, serverID, key).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+func validateServerLabelParams(datacenterID, serverID, key string) error {
+	if datacenterID == "" {
+		return errDatacenterIDIsEmpty
+	}
+
+	if serverID == "" {
+		return errServerIDIsEmpty
+	}
+
+	if key == "" {
+		return errLabelKeyIsEmpty
+	}
+
+	return nil
+}
+
 // StartServer starts the server that matches the provided serverID in the specified data center.
 // Returning the location and an error if starting the server fails.
 func (c *IonosCloudClient) StartServer(ctx context.Context, datacenterID, serverID string) (string, error) {
@@ -195,59 +607,416 @@ func (c *IonosCloudClient) StartServer(ctx context.Context, datacenterID, server
 	return "", errLocationHeaderEmpty
 }
 
-// DeleteVolume deletes the volume that matches the provided volumeID in the specified data center.
-func (c *IonosCloudClient) DeleteVolume(ctx context.Context, datacenterID, volumeID string) (string, error) {
+// StopServer stops the server that matches the provided serverID in the specified data center.
+// Returning the location and an error if stopping the server fails.
+func (c *IonosCloudClient) StopServer(ctx context.Context, datacenterID, serverID string) (string, error) {
 	if datacenterID == "" {
 		return "", errDatacenterIDIsEmpty
 	}
-
-	if volumeID == "" {
-		return "", errVolumeIDIsEmpty
+	if serverID == "" {
+		return "", errServerIDIsEmpty
 	}
-
-	resp, err := c.API.VolumesApi.DatacentersVolumesDelete(ctx, datacenterID, volumeID).Execute()
+	req, err := c.API.ServersApi.
+		DatacentersServersStopPost(ctx, datacenterID, serverID).
+		Execute()
 	if err != nil {
 		return "", fmt.Errorf(apiCallErrWrapper, err)
 	}
-
-	if location := resp.Header.Get(locationHeaderKey); location != "" {
+	if location := req.Header.Get(locationHeaderKey); location != "" {
 		return location, nil
 	}
 
 	return "", errLocationHeaderEmpty
 }
 
-// CreateLAN creates a new LAN with the provided properties in the specified data center,
-// returning the request location.
-func (c *IonosCloudClient) CreateLAN(ctx context.Context, datacenterID string, properties sdk.LanPropertiesPost,
-) (string, error) {
+// RebootServer reboots the server that matches the provided serverID in the specified data center.
+// Returning the location and an error if rebooting the server fails.
+func (c *IonosCloudClient) RebootServer(ctx context.Context, datacenterID, serverID string) (string, error) {
 	if datacenterID == "" {
 		return "", errDatacenterIDIsEmpty
 	}
-	lanPost := sdk.LanPost{
-		Properties: &properties,
+	if serverID == "" {
+		return "", errServerIDIsEmpty
 	}
-	_, req, err := c.API.LANsApi.DatacentersLansPost(ctx, datacenterID).Lan(lanPost).Execute()
+	req, err := c.API.ServersApi.
+		DatacentersServersRebootPost(ctx, datacenterID, serverID).
+		Execute()
 	if err != nil {
 		return "", fmt.Errorf(apiCallErrWrapper, err)
 	}
 	if location := req.Header.Get(locationHeaderKey); location != "" {
 		return location, nil
 	}
+
 	return "", errLocationHeaderEmpty
 }
 
-// PatchLAN patches the LAN that matches lanID in the specified data center
-// with the provided properties, returning the request location.
-func (c *IonosCloudClient) PatchLAN(
-	ctx context.Context, datacenterID, lanID string, properties sdk.LanProperties,
-) (string, error) {
+// GetServerRemoteConsoleURL returns the URL of the remote console of the server that matches the provided
+// serverID in the specified data center. The URL contains a short-lived token, which grants access
+// to the console.
+func (c *IonosCloudClient) GetServerRemoteConsoleURL(ctx context.Context, datacenterID, serverID string) (string, error) {
 	if datacenterID == "" {
 		return "", errDatacenterIDIsEmpty
 	}
-
-	if lanID == "" {
-		return "", errLANIDIsEmpty
+	if serverID == "" {
+		return "", errServerIDIsEmpty
+	}
+	console, _, err := c.API.ServersApi.DatacentersServersRemoteConsoleGet(ctx, datacenterID, serverID).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+	if url := console.GetUrl(); url != nil && *url != "" {
+		return *url, nil
+	}
+	return "", errRemoteConsoleURLEmpty
+}
+
+// PatchServer updates the server that matches the provided serverID in the specified data center
+// with the provided properties, returning the request location.
+func (c *IonosCloudClient) PatchServer(
+	ctx context.Context, datacenterID, serverID string, properties sdk.ServerProperties,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+	if serverID == "" {
+		return "", errServerIDIsEmpty
+	}
+	_, req, err := c.API.ServersApi.
+		DatacentersServersPatch(ctx, datacenterID, serverID).
+		Server(properties).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+	if location := req.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListTemplates returns a list of templates, which are used to create CUBE servers.
+func (c *IonosCloudClient) ListTemplates(ctx context.Context) (*sdk.Templates, error) {
+	templates, _, err := c.API.TemplatesApi.
+		TemplatesGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &templates, nil
+}
+
+// GetImage returns the image that matches the provided imageID.
+func (c *IonosCloudClient) GetImage(ctx context.Context, imageID string) (*sdk.Image, error) {
+	if imageID == "" {
+		return nil, errImageIDIsEmpty
+	}
+	image, _, err := c.API.ImagesApi.ImagesFindById(ctx, imageID).Depth(c.requestDepth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &image, nil
+}
+
+// ListImages returns a list of all images, which are accessible with the credentials.
+func (c *IonosCloudClient) ListImages(ctx context.Context) (*sdk.Images, error) {
+	images, _, err := c.API.ImagesApi.ImagesGet(ctx).Depth(c.requestDepth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &images, nil
+}
+
+// PatchImage updates the private image that matches the provided imageID with the provided properties,
+// returning the request location.
+func (c *IonosCloudClient) PatchImage(
+	ctx context.Context, imageID string, properties sdk.ImageProperties,
+) (string, error) {
+	if imageID == "" {
+		return "", errImageIDIsEmpty
+	}
+	_, req, err := c.API.ImagesApi.ImagesPatch(ctx, imageID).Image(properties).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+	if location := req.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+	return "", errLocationHeaderEmpty
+}
+
+// DeleteImage deletes the private image that matches the provided imageID, returning the request location.
+func (c *IonosCloudClient) DeleteImage(ctx context.Context, imageID string) (string, error) {
+	if imageID == "" {
+		return "", errImageIDIsEmpty
+	}
+	req, err := c.API.ImagesApi.ImagesDelete(ctx, imageID).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+	if location := req.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+	return "", errors.New(apiNoLocationErrMessage)
+}
+
+// ListSnapshots returns a list of all snapshots, which are accessible with the credentials.
+func (c *IonosCloudClient) ListSnapshots(ctx context.Context) (*sdk.Snapshots, error) {
+	snapshots, _, err := c.API.SnapshotsApi.
+		SnapshotsGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &snapshots, nil
+}
+
+// GetSnapshot returns the snapshot that matches the provided snapshotID.
+func (c *IonosCloudClient) GetSnapshot(ctx context.Context, snapshotID string) (*sdk.Snapshot, error) {
+	if snapshotID == "" {
+		return nil, errSnapshotIDIsEmpty
+	}
+	snapshot, _, err := c.API.SnapshotsApi.SnapshotsFindById(ctx, snapshotID).Depth(c.requestDepth).Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+	return &snapshot, nil
+}
+
+// DeleteVolume deletes the volume that matches the provided volumeID in the specified data center.
+func (c *IonosCloudClient) DeleteVolume(ctx context.Context, datacenterID, volumeID string) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if volumeID == "" {
+		return "", errVolumeIDIsEmpty
+	}
+
+	resp, err := c.API.VolumesApi.DatacentersVolumesDelete(ctx, datacenterID, volumeID).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := resp.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListVolumeLabels returns a list of labels of the specified volume.
+func (c *IonosCloudClient) ListVolumeLabels(ctx context.Context, datacenterID, volumeID string) (*sdk.LabelResources, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	if volumeID == "" {
+		return nil, errVolumeIDIsEmpty
+	}
+
+	labels, _, err := c.API.LabelsApi.
+		DatacentersVolumesLabelsGet(ctx, datacenterID, volumeID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &labels, nil
+}
+
+// CreateVolumeLabel adds a label with the provided key and value to the specified volume.
+func (c *IonosCloudClient) CreateVolumeLabel(ctx context.Context, datacenterID, volumeID, key, value string) error {
+	if err := validateVolumeLabelParams(datacenterID, volumeID, key); err != nil {
+		return err
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersVolumesLabelsPost(ctx, datacenterID, volumeID).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// UpdateVolumeLabel updates the value of the label with the provided key of the specified volume.
+func (c *IonosCloudClient) UpdateVolumeLabel(ctx context.Context, datacenterID, volumeID, key, value string) error {
+	if err := validateVolumeLabelParams(datacenterID, volumeID, key); err != nil {
+		return err
+	}
+
+	label := sdk.LabelResource{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   &key,
+			Value: &value,
+		},
+	}
+
+	_, _, err := c.API.LabelsApi.
+		DatacentersVolumesLabelsPut(ctx, datacenterID, volumeID, key).
+		Label(label).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+// DeleteVolumeLabel removes the label with the provided key from the specified volume.
+func (c *IonosCloudClient) DeleteVolumeLabel(ctx context.Context, datacenterID, volumeID, key string) error {
+	if err := validateVolumeLabelParams(datacenterID, volumeID, key); err != nil {
+		return err
+	}
+
+	_, err := c.API.LabelsApi.
+		DatacentersVolumesLabelsDelete(ctx, datacenterID, volumeID, key).
+		Execute()
+	if err != nil {
+		return fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return nil
+}
+
+func validateVolumeLabelParams(datacenterID, volumeID, key string) error {
+	if datacenterID == "" {
+		return errDatacenterIDIsEmpty
+	}
+
+	if volumeID == "" {
+		return errVolumeIDIsEmpty
+	}
+
+	if key == "" {
+		return errLabelKeyIsEmpty
+	}
+
+	return nil
+}
+
+// CreateServerVolume creates a volume with the provided properties and attaches it to the server in the
+// specified data center, returning the volume and the request location.
+func (c *IonosCloudClient) CreateServerVolume(
+	ctx context.Context, datacenterID, serverID string, properties sdk.VolumeProperties,
+) (*sdk.Volume, string, error) {
+	if datacenterID == "" {
+		return nil, "", errDatacenterIDIsEmpty
+	}
+	if serverID == "" {
+		return nil, "", errServerIDIsEmpty
+	}
+
+	volume, resp, err := c.API.ServersApi.DatacentersServersVolumesPost(ctx, datacenterID, serverID).
+		Volume(sdk.Volume{Properties: &properties}).
+		Execute()
+	if err != nil {
+		return nil, "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	location := resp.Header.Get(locationHeaderKey)
+	if location == "" {
+		err = errLocationHeaderEmpty
+	}
+
+	return &volume, location, err
+}
+
+// DetachVolume detaches the volume that matches the provided volumeID from the server in the specified
+// data center, returning the request location.
+func (c *IonosCloudClient) DetachVolume(ctx context.Context, datacenterID, serverID, volumeID string) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+	if serverID == "" {
+		return "", errServerIDIsEmpty
+	}
+	if volumeID == "" {
+		return "", errVolumeIDIsEmpty
+	}
+
+	resp, err := c.API.ServersApi.DatacentersServersVolumesDelete(ctx, datacenterID, serverID, volumeID).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := resp.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// DetachCDROM ejects the CD-ROM image that matches the provided imageID from the server in the specified
+// data center, returning the request location.
+func (c *IonosCloudClient) DetachCDROM(ctx context.Context, datacenterID, serverID, imageID string) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+	if serverID == "" {
+		return "", errServerIDIsEmpty
+	}
+	if imageID == "" {
+		return "", errImageIDIsEmpty
+	}
+
+	resp, err := c.API.ServersApi.DatacentersServersCdromsDelete(ctx, datacenterID, serverID, imageID).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := resp.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// CreateLAN creates a new LAN with the provided properties in the specified data center,
+// returning the request location.
+func (c *IonosCloudClient) CreateLAN(ctx context.Context, datacenterID string, properties sdk.LanPropertiesPost,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+	lanPost := sdk.LanPost{
+		Properties: &properties,
+	}
+	_, req, err := c.API.LANsApi.DatacentersLansPost(ctx, datacenterID).Lan(lanPost).Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+	if location := req.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+	return "", errLocationHeaderEmpty
+}
+
+// PatchLAN patches the LAN that matches lanID in the specified data center
+// with the provided properties, returning the request location.
+func (c *IonosCloudClient) PatchLAN(
+	ctx context.Context, datacenterID, lanID string, properties sdk.LanProperties,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if lanID == "" {
+		return "", errLANIDIsEmpty
 	}
 
 	_, res, err := c.API.LANsApi.DatacentersLansPatch(ctx, datacenterID, lanID).Lan(properties).Execute()
@@ -424,10 +1193,462 @@ func (c *IonosCloudClient) WaitForRequest(ctx context.Context, requestURL string
 	return nil
 }
 
-// PatchNIC updates the NIC identified by nicID with the provided properties.
-func (c *IonosCloudClient) PatchNIC(
-	ctx context.Context, datacenterID, serverID, nicID string, properties sdk.NicProperties,
-) (string, error) {
+// CreateNetworkLoadBalancer creates a new Network Load Balancer with the provided properties and entities in the
+// specified data center, returning the request location.
+func (c *IonosCloudClient) CreateNetworkLoadBalancer(
+	ctx context.Context,
+	datacenterID string,
+	properties sdk.NetworkLoadBalancerProperties,
+	entities sdk.NetworkLoadBalancerEntities,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	nlb := sdk.NetworkLoadBalancer{
+		Properties: &properties,
+		Entities:   &entities,
+	}
+
+	_, res, err := c.API.NetworkLoadBalancersApi.
+		DatacentersNetworkloadbalancersPost(ctx, datacenterID).
+		NetworkLoadBalancer(nlb).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListNetworkLoadBalancers returns a list of Network Load Balancers in the specified data center.
+func (c *IonosCloudClient) ListNetworkLoadBalancers(
+	ctx context.Context, datacenterID string,
+) (*sdk.NetworkLoadBalancers, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	nlbs, _, err := c.API.NetworkLoadBalancersApi.
+		DatacentersNetworkloadbalancersGet(ctx, datacenterID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &nlbs, nil
+}
+
+// GetNetworkLoadBalancer returns the Network Load Balancer that matches the provided loadBalancerID
+// in the specified data center.
+func (c *IonosCloudClient) GetNetworkLoadBalancer(
+	ctx context.Context, datacenterID, loadBalancerID string,
+) (*sdk.NetworkLoadBalancer, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	if loadBalancerID == "" {
+		return nil, errNLBIDIsEmpty
+	}
+
+	nlb, _, err := c.API.NetworkLoadBalancersApi.
+		DatacentersNetworkloadbalancersFindByNetworkLoadBalancerId(ctx, datacenterID, loadBalancerID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &nlb, nil
+}
+
+// DeleteNetworkLoadBalancer deletes the Network Load Balancer that matches the provided loadBalancerID
+// in the specified data center, returning the request location.
+func (c *IonosCloudClient) DeleteNetworkLoadBalancer(
+	ctx context.Context, datacenterID, loadBalancerID string,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if loadBalancerID == "" {
+		return "", errNLBIDIsEmpty
+	}
+
+	res, err := c.API.NetworkLoadBalancersApi.
+		DatacentersNetworkloadbalancersDelete(ctx, datacenterID, loadBalancerID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// PatchNetworkLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+// Network Load Balancer with the provided properties, returning the request location.
+func (c *IonosCloudClient) PatchNetworkLoadBalancerForwardingRule(
+	ctx context.Context,
+	datacenterID, loadBalancerID, ruleID string,
+	properties sdk.NetworkLoadBalancerForwardingRuleProperties,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if loadBalancerID == "" {
+		return "", errNLBIDIsEmpty
+	}
+
+	if ruleID == "" {
+		return "", errRuleIDIsEmpty
+	}
+
+	_, res, err := c.API.NetworkLoadBalancersApi.
+		DatacentersNetworkloadbalancersForwardingrulesPatch(ctx, datacenterID, loadBalancerID, ruleID).
+		NetworkLoadBalancerForwardingRuleProperties(properties).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// CreateNATGateway creates a new NAT Gateway with the provided properties and entities in the
+// specified data center, returning the request location.
+func (c *IonosCloudClient) CreateNATGateway(
+	ctx context.Context,
+	datacenterID string,
+	properties sdk.NatGatewayProperties,
+	entities sdk.NatGatewayEntities,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	natGateway := sdk.NatGateway{
+		Properties: &properties,
+		Entities:   &entities,
+	}
+
+	_, res, err := c.API.NATGatewaysApi.
+		DatacentersNatgatewaysPost(ctx, datacenterID).
+		NatGateway(natGateway).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListNATGateways returns a list of NAT Gateways in the specified data center.
+func (c *IonosCloudClient) ListNATGateways(ctx context.Context, datacenterID string) (*sdk.NatGateways, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	natGateways, _, err := c.API.NATGatewaysApi.
+		DatacentersNatgatewaysGet(ctx, datacenterID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &natGateways, nil
+}
+
+// DeleteNATGateway deletes the NAT Gateway that matches the provided natGatewayID
+// in the specified data center, returning the request location.
+func (c *IonosCloudClient) DeleteNATGateway(ctx context.Context, datacenterID, natGatewayID string) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if natGatewayID == "" {
+		return "", errNATGatewayIDIsEmpty
+	}
+
+	res, err := c.API.NATGatewaysApi.
+		DatacentersNatgatewaysDelete(ctx, datacenterID, natGatewayID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// CreateApplicationLoadBalancer creates a new Application Load Balancer with the provided properties and entities
+// in the specified data center, returning the request location.
+func (c *IonosCloudClient) CreateApplicationLoadBalancer(
+	ctx context.Context,
+	datacenterID string,
+	properties sdk.ApplicationLoadBalancerProperties,
+	entities sdk.ApplicationLoadBalancerEntities,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	alb := sdk.ApplicationLoadBalancer{
+		Properties: &properties,
+		Entities:   &entities,
+	}
+
+	_, res, err := c.API.ApplicationLoadBalancersApi.
+		DatacentersApplicationloadbalancersPost(ctx, datacenterID).
+		ApplicationLoadBalancer(alb).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListApplicationLoadBalancers returns a list of Application Load Balancers in the specified data center.
+func (c *IonosCloudClient) ListApplicationLoadBalancers(
+	ctx context.Context, datacenterID string,
+) (*sdk.ApplicationLoadBalancers, error) {
+	if datacenterID == "" {
+		return nil, errDatacenterIDIsEmpty
+	}
+
+	albs, _, err := c.API.ApplicationLoadBalancersApi.
+		DatacentersApplicationloadbalancersGet(ctx, datacenterID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &albs, nil
+}
+
+// DeleteApplicationLoadBalancer deletes the Application Load Balancer that matches the provided loadBalancerID
+// in the specified data center, returning the request location.
+func (c *IonosCloudClient) DeleteApplicationLoadBalancer(
+	ctx context.Context, datacenterID, loadBalancerID string,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if loadBalancerID == "" {
+		return "", errALBIDIsEmpty
+	}
+
+	res, err := c.API.ApplicationLoadBalancersApi.
+		DatacentersApplicationloadbalancersDelete(ctx, datacenterID, loadBalancerID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// PatchApplicationLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+// Application Load Balancer with the provided properties, returning the request location.
+func (c *IonosCloudClient) PatchApplicationLoadBalancerForwardingRule(
+	ctx context.Context,
+	datacenterID, loadBalancerID, ruleID string,
+	properties sdk.ApplicationLoadBalancerForwardingRuleProperties,
+) (string, error) {
+	if datacenterID == "" {
+		return "", errDatacenterIDIsEmpty
+	}
+
+	if loadBalancerID == "" {
+		return "", errALBIDIsEmpty
+	}
+
+	if ruleID == "" {
+		return "", errRuleIDIsEmpty
+	}
+
+	_, res, err := c.API.ApplicationLoadBalancersApi.
+		DatacentersApplicationloadbalancersForwardingrulesPatch(ctx, datacenterID, loadBalancerID, ruleID).
+		ApplicationLoadBalancerForwardingRuleProperties(properties).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// CreateTargetGroup creates a new target group with the provided properties, returning the request location.
+func (c *IonosCloudClient) CreateTargetGroup(
+	ctx context.Context, properties sdk.TargetGroupProperties,
+) (string, error) {
+	_, res, err := c.API.TargetGroupsApi.
+		TargetgroupsPost(ctx).
+		TargetGroup(sdk.TargetGroup{Properties: &properties}).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListTargetGroups returns a list of target groups.
+func (c *IonosCloudClient) ListTargetGroups(ctx context.Context) (*sdk.TargetGroups, error) {
+	targetGroups, _, err := c.API.TargetGroupsApi.
+		TargetgroupsGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &targetGroups, nil
+}
+
+// PatchTargetGroup patches the target group that matches the provided targetGroupID with the provided properties,
+// returning the request location.
+func (c *IonosCloudClient) PatchTargetGroup(
+	ctx context.Context, targetGroupID string, properties sdk.TargetGroupProperties,
+) (string, error) {
+	if targetGroupID == "" {
+		return "", errTargetGroupIDIsEmpty
+	}
+
+	_, res, err := c.API.TargetGroupsApi.
+		TargetgroupsPatch(ctx, targetGroupID).
+		TargetGroupProperties(properties).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// DeleteTargetGroup deletes the target group that matches the provided targetGroupID,
+// returning the request location.
+func (c *IonosCloudClient) DeleteTargetGroup(ctx context.Context, targetGroupID string) (string, error) {
+	if targetGroupID == "" {
+		return "", errTargetGroupIDIsEmpty
+	}
+
+	res, err := c.API.TargetGroupsApi.
+		TargetGroupsDelete(ctx, targetGroupID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// CreateCrossConnect creates a new Cross Connect with the provided properties, returning the request location.
+func (c *IonosCloudClient) CreateCrossConnect(
+	ctx context.Context, properties sdk.PrivateCrossConnectProperties,
+) (string, error) {
+	_, res, err := c.API.PrivateCrossConnectsApi.
+		PccsPost(ctx).
+		Pcc(sdk.PrivateCrossConnect{Properties: &properties}).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListCrossConnects returns a list of Cross Connects.
+func (c *IonosCloudClient) ListCrossConnects(ctx context.Context) (*sdk.PrivateCrossConnects, error) {
+	crossConnects, _, err := c.API.PrivateCrossConnectsApi.
+		PccsGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &crossConnects, nil
+}
+
+// DeleteCrossConnect deletes the Cross Connect that matches the provided crossConnectID,
+// returning the request location.
+func (c *IonosCloudClient) DeleteCrossConnect(ctx context.Context, crossConnectID string) (string, error) {
+	if crossConnectID == "" {
+		return "", errCrossConnectIDEmpty
+	}
+
+	res, err := c.API.PrivateCrossConnectsApi.
+		PccsDelete(ctx, crossConnectID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// PatchNIC updates the NIC identified by nicID with the provided properties.
+func (c *IonosCloudClient) PatchNIC(
+	ctx context.Context, datacenterID, serverID, nicID string, properties sdk.NicProperties,
+) (string, error) {
 	if err := validateNICParameters(datacenterID, serverID, nicID); err != nil {
 		return "", err
 	}
@@ -447,7 +1668,113 @@ func (c *IonosCloudClient) PatchNIC(
 	return "", errLocationHeaderEmpty
 }
 
-// validateNICParameters validates the parameters for the PatchNIC and DeleteNIC methods.
+// ListFirewallRules returns a list of firewall rules of the NIC identified by nicID.
+func (c *IonosCloudClient) ListFirewallRules(
+	ctx context.Context, datacenterID, serverID, nicID string,
+) (*sdk.FirewallRules, error) {
+	if err := validateNICParameters(datacenterID, serverID, nicID); err != nil {
+		return nil, err
+	}
+
+	rules, _, err := c.API.FirewallRulesApi.
+		DatacentersServersNicsFirewallrulesGet(ctx, datacenterID, serverID, nicID).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &rules, nil
+}
+
+// CreateFirewallRule creates a firewall rule with the provided properties on the NIC identified by nicID.
+func (c *IonosCloudClient) CreateFirewallRule(
+	ctx context.Context, datacenterID, serverID, nicID string, properties sdk.FirewallruleProperties,
+) (string, error) {
+	if err := validateNICParameters(datacenterID, serverID, nicID); err != nil {
+		return "", err
+	}
+
+	_, res, err := c.API.FirewallRulesApi.
+		DatacentersServersNicsFirewallrulesPost(ctx, datacenterID, serverID, nicID).
+		Firewallrule(sdk.FirewallRule{Properties: &properties}).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// PatchFirewallRule updates the firewall rule identified by ruleID with the provided properties.
+func (c *IonosCloudClient) PatchFirewallRule(
+	ctx context.Context, datacenterID, serverID, nicID, ruleID string, properties sdk.FirewallruleProperties,
+) (string, error) {
+	if err := validateNICParameters(datacenterID, serverID, nicID); err != nil {
+		return "", err
+	}
+	if ruleID == "" {
+		return "", errFirewallRuleIDEmpty
+	}
+
+	_, res, err := c.API.FirewallRulesApi.
+		DatacentersServersNicsFirewallrulesPatch(ctx, datacenterID, serverID, nicID, ruleID).
+		Firewallrule(properties).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// DeleteFirewallRule deletes the firewall rule identified by ruleID.
+func (c *IonosCloudClient) DeleteFirewallRule(
+	ctx context.Context, datacenterID, serverID, nicID, ruleID string,
+) (string, error) {
+	if err := validateNICParameters(datacenterID, serverID, nicID); err != nil {
+		return "", err
+	}
+	if ruleID == "" {
+		return "", errFirewallRuleIDEmpty
+	}
+
+	res, err := c.API.FirewallRulesApi.
+		DatacentersServersNicsFirewallrulesDelete(ctx, datacenterID, serverID, nicID, ruleID).
+		Execute()
+	if err != nil {
+		return "", fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	if location := res.Header.Get(locationHeaderKey); location != "" {
+		return location, nil
+	}
+
+	return "", errLocationHeaderEmpty
+}
+
+// ListContracts returns the contracts of the user, including their resource limits and provisioned resources.
+func (c *IonosCloudClient) ListContracts(ctx context.Context) (*sdk.Contracts, error) {
+	contracts, _, err := c.API.ContractResourcesApi.
+		ContractsGet(ctx).
+		Depth(c.requestDepth).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf(apiCallErrWrapper, err)
+	}
+
+	return &contracts, nil
+}
+
+// validateNICParameters validates the parameters for the NIC and firewall rule methods.
 func validateNICParameters(datacenterID, serverID, nicID string) (err error) {
 	if datacenterID == "" {
 		return errDatacenterIDIsEmpty
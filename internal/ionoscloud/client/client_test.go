@@ -22,9 +22,13 @@ import (
 	"net/http"
 	"testing"
 
+	sdk "github.com/ionos-cloud/sdk-go/v6"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
 const (
@@ -68,67 +72,111 @@ yuGnBXj8ytqU0CwIPX4WecigUCAkVDNx
 	const set = "SET"
 
 	tests := []struct {
-		name       string
-		token      string
-		apiURL     string
-		caBundle   []byte
-		shouldPass bool
+		name        string
+		credentials Credentials
+		endpoint    Endpoint
+		shouldPass  bool
 	}{
 		{
 			"token set",
-			set,
-			"",
-			nil,
+			Credentials{Token: set},
+			Endpoint{},
 			true,
 		},
 		{
 			"token and URL set",
-			set,
-			set,
-			nil,
+			Credentials{Token: set},
+			Endpoint{URL: set},
 			true,
 		},
 		{
 			"token missing",
-			"",
-			set,
-			nil,
+			Credentials{},
+			Endpoint{URL: set},
+			false,
+		},
+		{
+			"username and password set",
+			Credentials{Username: set, Password: set},
+			Endpoint{},
+			true,
+		},
+		{
+			"password missing",
+			Credentials{Username: set},
+			Endpoint{},
 			false,
 		},
+		{
+			"token takes precedence over username and password",
+			Credentials{Token: set, Username: set, Password: set},
+			Endpoint{},
+			true,
+		},
 		{
 			"token and CA bundle set",
-			set,
-			"",
-			[]byte(rootPEM),
+			Credentials{Token: set},
+			Endpoint{CABundle: []byte(rootPEM)},
 			true,
 		},
 		{
 			"invalid CA bundle",
-			set,
-			"",
-			[]byte("invalid"),
+			Credentials{Token: set},
+			Endpoint{CABundle: []byte("invalid")},
+			false,
+		},
+		{
+			"insecure",
+			Credentials{Token: set},
+			Endpoint{InsecureSkipVerify: true},
+			true,
+		},
+		{
+			"proxy set",
+			Credentials{Token: set},
+			Endpoint{ProxyURL: "http://proxy.example.com:3128"},
+			true,
+		},
+		{
+			"invalid proxy URL",
+			Credentials{Token: set},
+			Endpoint{ProxyURL: "proxy"},
 			false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, err := NewClient(tt.token, tt.apiURL, tt.caBundle)
+			c, err := NewClient(tt.credentials, tt.endpoint)
 			if tt.shouldPass {
 				require.NotNil(t, c, "NewClient returned a nil IonosCloudClient")
 				require.NoError(t, err, "NewClient returned an error")
 				cfg := c.API.GetConfig()
-				require.Equal(t, tt.token, cfg.Token, "token didn't match")
-				require.Equal(t, tt.apiURL, cfg.Host, "apiURL didn't match")
-				if tt.caBundle != nil {
-					require.NotNil(t, cfg.HTTPClient, "HTTP client is nil")
-					require.NotNil(t, cfg.HTTPClient.Transport, "HTTP client lacks a custom transport")
-					require.IsType(t, &http.Transport{}, cfg.HTTPClient.Transport, "transport is not an http.Transport")
-					require.NotNil(t, cfg.HTTPClient.Transport.(*http.Transport).TLSClientConfig,
-						"TLSClientConfig is nil")
-					require.NotNil(t, cfg.HTTPClient.Transport.(*http.Transport).TLSClientConfig.RootCAs,
-						"RootCAs is nil")
+				require.Equal(t, tt.credentials.Token, cfg.Token, "token didn't match")
+				if tt.credentials.Token == "" {
+					require.Equal(t, tt.credentials.Username, cfg.Username, "username didn't match")
+					require.Equal(t, tt.credentials.Password, cfg.Password, "password didn't match")
 				} else {
+					require.Empty(t, cfg.Username, "username should not be used together with a token")
+				}
+				require.Equal(t, tt.endpoint.URL, cfg.Host, "apiURL didn't match")
+				if len(tt.endpoint.CABundle) == 0 && !tt.endpoint.InsecureSkipVerify && tt.endpoint.ProxyURL == "" {
 					require.Equal(t, http.DefaultClient, cfg.HTTPClient, "HTTP client is not the default client")
+					return
+				}
+
+				require.NotNil(t, cfg.HTTPClient, "HTTP client is nil")
+				require.IsType(t, &http.Transport{}, cfg.HTTPClient.Transport, "transport is not an http.Transport")
+				transport := cfg.HTTPClient.Transport.(*http.Transport)
+				require.NotNil(t, transport.TLSClientConfig, "TLSClientConfig is nil")
+				require.Equal(t, tt.endpoint.CABundle != nil, transport.TLSClientConfig.RootCAs != nil,
+					"RootCAs didn't match")
+				require.Equal(t, tt.endpoint.InsecureSkipVerify, transport.TLSClientConfig.InsecureSkipVerify,
+					"InsecureSkipVerify didn't match")
+				if tt.endpoint.ProxyURL != "" {
+					req, _ := http.NewRequest(http.MethodGet, "https://api.ionos.com", nil)
+					proxyURL, err := transport.Proxy(req)
+					require.NoError(t, err)
+					require.Equal(t, tt.endpoint.ProxyURL, proxyURL.String(), "proxy didn't match")
 				}
 			} else {
 				require.Nil(t, c, "NewClient returned a non-nil client")
@@ -138,6 +186,63 @@ yuGnBXj8ytqU0CwIPX4WecigUCAkVDNx
 	}
 }
 
+func TestNewClientFromSecret(t *testing.T) {
+	c, err := NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{
+		"token":  []byte("token"),
+		"apiURL": []byte("api.example.com"),
+	}}, Endpoint{URL: "default.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, "token", c.API.GetConfig().Token)
+	require.Equal(t, "api.example.com", c.API.GetConfig().Host, "the URL of the secret must take precedence")
+
+	_, err = NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{"username": []byte("user")}}, Endpoint{})
+	require.Error(t, err)
+
+	_, err = NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{
+		"token":    []byte("token"),
+		"insecure": []byte("maybe"),
+	}}, Endpoint{})
+	require.ErrorContains(t, err, "invalid value of key insecure")
+}
+
+func TestNewClientFromSecretContractNumber(t *testing.T) {
+	c, err := NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{
+		"token":          []byte("token"),
+		"contractNumber": []byte("31415"),
+	}}, Endpoint{})
+	require.NoError(t, err)
+	require.Equal(t, "31415", c.API.GetConfig().DefaultHeader[contractNumberHeaderKey])
+
+	c, err = NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{"token": []byte("token")}}, Endpoint{})
+	require.NoError(t, err)
+	require.NotContains(t, c.API.GetConfig().DefaultHeader, contractNumberHeaderKey)
+
+	for _, invalid := range []string{"contract", "0", "99999999999"} {
+		_, err = NewClientFromSecret(&corev1.Secret{Data: map[string][]byte{
+			"token":          []byte("token"),
+			"contractNumber": []byte(invalid),
+		}}, Endpoint{})
+		require.ErrorContains(t, err, "invalid value of key contractNumber")
+	}
+}
+
+func TestEndpointFromSecret(t *testing.T) {
+	defaults := Endpoint{URL: "default.example.com", InsecureSkipVerify: true, ProxyURL: "http://proxy:3128"}
+
+	endpoint, err := endpointFromSecret(&corev1.Secret{}, defaults)
+	require.NoError(t, err)
+	require.Equal(t, defaults, endpoint)
+
+	endpoint, err = endpointFromSecret(&corev1.Secret{Data: map[string][]byte{
+		"apiURL":   []byte("api.example.com"),
+		"caBundle": []byte("ca"),
+		"insecure": []byte("false"),
+		"proxyURL": []byte("http://other:3128"),
+	}}, defaults)
+	require.NoError(t, err)
+	require.Equal(t, Endpoint{URL: "api.example.com", CABundle: []byte("ca"), ProxyURL: "http://other:3128"}, endpoint)
+}
+
 type IonosCloudClientTestSuite struct {
 	*require.Assertions
 	suite.Suite
@@ -155,7 +260,7 @@ func (s *IonosCloudClientTestSuite) SetupSuite() {
 	s.ctx = context.Background()
 
 	var err error
-	s.client, err = NewClient("token", "localhost", nil)
+	s.client, err = NewClient(Credentials{Token: "token"}, Endpoint{URL: "localhost"})
 	s.NoError(err)
 
 	httpmock.Activate()
@@ -249,6 +354,634 @@ func (s *IonosCloudClientTestSuite) TestDeleteIPBlockFailureEmptyID() {
 	s.Empty(requestLocation)
 }
 
+func (s *IonosCloudClientTestSuite) TestStopServerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.StopServer(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestStopServerFailureEmptyID() {
+	requestLocation, err := s.client.StopServer(s.ctx, exampleID, "")
+	s.ErrorIs(err, errServerIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDetachCDROMSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DetachCDROM(s.ctx, exampleID, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDetachCDROMFailureEmptyID() {
+	requestLocation, err := s.client.DetachCDROM(s.ctx, exampleID, exampleID, "")
+	s.ErrorIs(err, errImageIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestRebootServerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.RebootServer(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestRebootServerFailureEmptyID() {
+	requestLocation, err := s.client.RebootServer(s.ctx, exampleID, "")
+	s.ErrorIs(err, errServerIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetServerRemoteConsoleURLSuccess() {
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{"url": "https://console.example"})
+	httpmock.RegisterResponder(http.MethodGet, catchAllMockURL, responder)
+	url, err := s.client.GetServerRemoteConsoleURL(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal("https://console.example", url)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetServerRemoteConsoleURLFailureEmptyID() {
+	url, err := s.client.GetServerRemoteConsoleURL(s.ctx, exampleID, "")
+	s.ErrorIs(err, errServerIDIsEmpty)
+	s.Empty(url)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchServerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPatch, catchAllMockURL, responder)
+	requestLocation, err := s.client.PatchServer(s.ctx, exampleID, exampleID, sdk.ServerProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchServerFailureEmptyID() {
+	requestLocation, err := s.client.PatchServer(s.ctx, exampleID, "", sdk.ServerProperties{})
+	s.ErrorIs(err, errServerIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListTemplatesSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	templates, err := s.client.ListTemplates(s.ctx)
+	s.NoError(err)
+	s.NotNil(templates)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateNetworkLoadBalancerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateNetworkLoadBalancer(s.ctx, exampleID,
+		sdk.NetworkLoadBalancerProperties{}, sdk.NetworkLoadBalancerEntities{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateNetworkLoadBalancerFailureEmptyID() {
+	requestLocation, err := s.client.CreateNetworkLoadBalancer(s.ctx, "",
+		sdk.NetworkLoadBalancerProperties{}, sdk.NetworkLoadBalancerEntities{})
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListNetworkLoadBalancersSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	nlbs, err := s.client.ListNetworkLoadBalancers(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(nlbs)
+}
+
+func (s *IonosCloudClientTestSuite) TestListNetworkLoadBalancersFailureEmptyID() {
+	nlbs, err := s.client.ListNetworkLoadBalancers(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Nil(nlbs)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetNetworkLoadBalancerSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	nlb, err := s.client.GetNetworkLoadBalancer(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.NotNil(nlb)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetNetworkLoadBalancerFailureEmptyID() {
+	nlb, err := s.client.GetNetworkLoadBalancer(s.ctx, exampleID, "")
+	s.ErrorIs(err, errNLBIDIsEmpty)
+	s.Nil(nlb)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteNetworkLoadBalancerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteNetworkLoadBalancer(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteNetworkLoadBalancerFailureEmptyID() {
+	requestLocation, err := s.client.DeleteNetworkLoadBalancer(s.ctx, exampleID, "")
+	s.ErrorIs(err, errNLBIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchNetworkLoadBalancerForwardingRuleSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPatch, catchAllMockURL, responder)
+	requestLocation, err := s.client.PatchNetworkLoadBalancerForwardingRule(s.ctx, exampleID, exampleID, exampleID,
+		sdk.NetworkLoadBalancerForwardingRuleProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchNetworkLoadBalancerForwardingRuleFailureEmptyID() {
+	requestLocation, err := s.client.PatchNetworkLoadBalancerForwardingRule(s.ctx, exampleID, exampleID, "",
+		sdk.NetworkLoadBalancerForwardingRuleProperties{})
+	s.ErrorIs(err, errRuleIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateDatacenterSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateDatacenter(s.ctx, sdk.DatacenterProperties{Location: ptr.To("de/txl")})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateDatacenterFailureEmptyLocation() {
+	requestLocation, err := s.client.CreateDatacenter(s.ctx, sdk.DatacenterProperties{})
+	s.Error(err)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListDatacentersSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	datacenters, err := s.client.ListDatacenters(s.ctx)
+	s.NoError(err)
+	s.NotNil(datacenters)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetDatacenterSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	datacenter, err := s.client.GetDatacenter(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(datacenter)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetDatacenterFailureEmptyID() {
+	datacenter, err := s.client.GetDatacenter(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Nil(datacenter)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetImageSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	image, err := s.client.GetImage(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(image)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetImageFailureEmptyID() {
+	image, err := s.client.GetImage(s.ctx, "")
+	s.ErrorIs(err, errImageIDIsEmpty)
+	s.Nil(image)
+}
+
+func (s *IonosCloudClientTestSuite) TestListImagesSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	images, err := s.client.ListImages(s.ctx)
+	s.NoError(err)
+	s.NotNil(images)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteImageSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteImage(s.ctx, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteImageFailureEmptyID() {
+	requestLocation, err := s.client.DeleteImage(s.ctx, "")
+	s.ErrorIs(err, errImageIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListSnapshotsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	snapshots, err := s.client.ListSnapshots(s.ctx)
+	s.NoError(err)
+	s.NotNil(snapshots)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetSnapshotSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	snapshot, err := s.client.GetSnapshot(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(snapshot)
+}
+
+func (s *IonosCloudClientTestSuite) TestGetSnapshotFailureEmptyID() {
+	snapshot, err := s.client.GetSnapshot(s.ctx, "")
+	s.ErrorIs(err, errSnapshotIDIsEmpty)
+	s.Nil(snapshot)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteDatacenterSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteDatacenter(s.ctx, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteDatacenterFailureEmptyID() {
+	requestLocation, err := s.client.DeleteDatacenter(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListLabelsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	labels, err := s.client.ListLabels(s.ctx)
+	s.NoError(err)
+	s.NotNil(labels)
+}
+
+func (s *IonosCloudClientTestSuite) TestListDatacenterLabelsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	labels, err := s.client.ListDatacenterLabels(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(labels)
+}
+
+func (s *IonosCloudClientTestSuite) TestListDatacenterLabelsFailureEmptyID() {
+	labels, err := s.client.ListDatacenterLabels(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Nil(labels)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateDatacenterLabelSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusCreated, map[string]any{}),
+	)
+	s.NoError(s.client.CreateDatacenterLabel(s.ctx, exampleID, "key", "value"))
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateDatacenterLabelFailureEmptyKey() {
+	s.ErrorIs(s.client.CreateDatacenterLabel(s.ctx, exampleID, "", "value"), errLabelKeyIsEmpty)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateNATGatewaySuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateNATGateway(s.ctx, exampleID,
+		sdk.NatGatewayProperties{}, sdk.NatGatewayEntities{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateNATGatewayFailureEmptyID() {
+	requestLocation, err := s.client.CreateNATGateway(s.ctx, "",
+		sdk.NatGatewayProperties{}, sdk.NatGatewayEntities{})
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListNATGatewaysSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	natGateways, err := s.client.ListNATGateways(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(natGateways)
+}
+
+func (s *IonosCloudClientTestSuite) TestListNATGatewaysFailureEmptyID() {
+	natGateways, err := s.client.ListNATGateways(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Nil(natGateways)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteNATGatewaySuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteNATGateway(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteNATGatewayFailureEmptyID() {
+	requestLocation, err := s.client.DeleteNATGateway(s.ctx, exampleID, "")
+	s.ErrorIs(err, errNATGatewayIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateApplicationLoadBalancerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateApplicationLoadBalancer(s.ctx, exampleID,
+		sdk.ApplicationLoadBalancerProperties{}, sdk.ApplicationLoadBalancerEntities{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateApplicationLoadBalancerFailureEmptyID() {
+	requestLocation, err := s.client.CreateApplicationLoadBalancer(s.ctx, "",
+		sdk.ApplicationLoadBalancerProperties{}, sdk.ApplicationLoadBalancerEntities{})
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListApplicationLoadBalancersSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	albs, err := s.client.ListApplicationLoadBalancers(s.ctx, exampleID)
+	s.NoError(err)
+	s.NotNil(albs)
+}
+
+func (s *IonosCloudClientTestSuite) TestListApplicationLoadBalancersFailureEmptyID() {
+	albs, err := s.client.ListApplicationLoadBalancers(s.ctx, "")
+	s.ErrorIs(err, errDatacenterIDIsEmpty)
+	s.Nil(albs)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteApplicationLoadBalancerSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteApplicationLoadBalancer(s.ctx, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteApplicationLoadBalancerFailureEmptyID() {
+	requestLocation, err := s.client.DeleteApplicationLoadBalancer(s.ctx, exampleID, "")
+	s.ErrorIs(err, errALBIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchApplicationLoadBalancerForwardingRuleSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPatch, catchAllMockURL, responder)
+	requestLocation, err := s.client.PatchApplicationLoadBalancerForwardingRule(s.ctx, exampleID, exampleID, exampleID,
+		sdk.ApplicationLoadBalancerForwardingRuleProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchApplicationLoadBalancerForwardingRuleFailureEmptyID() {
+	requestLocation, err := s.client.PatchApplicationLoadBalancerForwardingRule(s.ctx, exampleID, exampleID, "",
+		sdk.ApplicationLoadBalancerForwardingRuleProperties{})
+	s.ErrorIs(err, errRuleIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateTargetGroupSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateTargetGroup(s.ctx, sdk.TargetGroupProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListTargetGroupsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	targetGroups, err := s.client.ListTargetGroups(s.ctx)
+	s.NoError(err)
+	s.NotNil(targetGroups)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchTargetGroupSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPatch, catchAllMockURL, responder)
+	requestLocation, err := s.client.PatchTargetGroup(s.ctx, exampleID, sdk.TargetGroupProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchTargetGroupFailureEmptyID() {
+	requestLocation, err := s.client.PatchTargetGroup(s.ctx, "", sdk.TargetGroupProperties{})
+	s.ErrorIs(err, errTargetGroupIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteTargetGroupSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteTargetGroup(s.ctx, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteTargetGroupFailureEmptyID() {
+	requestLocation, err := s.client.DeleteTargetGroup(s.ctx, "")
+	s.ErrorIs(err, errTargetGroupIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateCrossConnectSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateCrossConnect(s.ctx, sdk.PrivateCrossConnectProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListCrossConnectsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	crossConnects, err := s.client.ListCrossConnects(s.ctx)
+	s.NoError(err)
+	s.NotNil(crossConnects)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteCrossConnectFailureEmptyID() {
+	requestLocation, err := s.client.DeleteCrossConnect(s.ctx, "")
+	s.ErrorIs(err, errCrossConnectIDEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListFirewallRulesSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	rules, err := s.client.ListFirewallRules(s.ctx, exampleID, exampleID, exampleID)
+	s.NoError(err)
+	s.NotNil(rules)
+}
+
+func (s *IonosCloudClientTestSuite) TestListFirewallRulesFailureEmptyID() {
+	rules, err := s.client.ListFirewallRules(s.ctx, exampleID, exampleID, "")
+	s.ErrorIs(err, errNICIDIsEmpty)
+	s.Nil(rules)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateFirewallRuleSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPost, catchAllMockURL, responder)
+	requestLocation, err := s.client.CreateFirewallRule(s.ctx, exampleID, exampleID, exampleID,
+		sdk.FirewallruleProperties{Protocol: ptr.To("TCP")})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestCreateFirewallRuleFailureEmptyID() {
+	requestLocation, err := s.client.CreateFirewallRule(s.ctx, exampleID, "", exampleID,
+		sdk.FirewallruleProperties{})
+	s.ErrorIs(err, errServerIDIsEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchFirewallRuleSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodPatch, catchAllMockURL, responder)
+	requestLocation, err := s.client.PatchFirewallRule(s.ctx, exampleID, exampleID, exampleID, exampleID,
+		sdk.FirewallruleProperties{})
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestPatchFirewallRuleFailureEmptyID() {
+	requestLocation, err := s.client.PatchFirewallRule(s.ctx, exampleID, exampleID, exampleID, "",
+		sdk.FirewallruleProperties{})
+	s.ErrorIs(err, errFirewallRuleIDEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteFirewallRuleSuccess() {
+	header := http.Header{}
+	header.Set(locationHeaderKey, examplePath)
+	responder := httpmock.NewJsonResponderOrPanic(http.StatusAccepted, map[string]any{}).HeaderSet(header)
+	httpmock.RegisterResponder(http.MethodDelete, catchAllMockURL, responder)
+	requestLocation, err := s.client.DeleteFirewallRule(s.ctx, exampleID, exampleID, exampleID, exampleID)
+	s.NoError(err)
+	s.Equal(examplePath, requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestDeleteFirewallRuleFailureEmptyID() {
+	requestLocation, err := s.client.DeleteFirewallRule(s.ctx, exampleID, exampleID, exampleID, "")
+	s.ErrorIs(err, errFirewallRuleIDEmpty)
+	s.Empty(requestLocation)
+}
+
+func (s *IonosCloudClientTestSuite) TestListContractsSuccess() {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		catchAllMockURL,
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{}),
+	)
+	contracts, err := s.client.ListContracts(s.ctx)
+	s.NoError(err)
+	s.NotNil(contracts)
+}
+
 func TestWithDepth(t *testing.T) {
 	tests := []struct {
 		depth int32
@@ -0,0 +1,276 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+// DefaultDNSEndpoint is the URL of the IONOS Cloud DNS API, which is used if no endpoint is configured.
+const DefaultDNSEndpoint = "https://dns.de-fra.ionos.com"
+
+var _ ionoscloud.DNS = (*DNSClient)(nil)
+
+// DNSConfig configures a DNSClient.
+type DNSConfig struct {
+	// Endpoint configures the connection to the DNS API. If its URL is empty, DefaultDNSEndpoint is used.
+	Endpoint Endpoint
+	// Token is used to authenticate at the DNS API. It takes precedence over the username and password.
+	Token string
+	// Username is the username of the user, if no token is set.
+	Username string
+	// Password is the password of the user, if no token is set.
+	Password string
+}
+
+// DNSClient is a minimal client for the API of IONOS Cloud DNS, which manages the records of existing zones.
+// It authenticates with the same credentials as the Cloud API.
+type DNSClient struct {
+	endpoint   *url.URL
+	config     DNSConfig
+	httpClient *http.Client
+}
+
+// NewDNSClient instantiates a DNSClient with the given config. The options of the Cloud API client, e.g.
+// WithRateLimiter, WithDryRun and WithAuditLog, apply to the requests of the DNS client in the same way.
+func NewDNSClient(config DNSConfig, opts ...Option) (*DNSClient, error) {
+	if config.Token == "" && (config.Username == "" || config.Password == "") {
+		return nil, errors.New("either token or username and password must be set")
+	}
+	if config.Endpoint.URL == "" {
+		config.Endpoint.URL = DefaultDNSEndpoint
+	}
+	endpoint, err := url.Parse(config.Endpoint.URL)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid DNS endpoint %q", config.Endpoint.URL)
+	}
+
+	transport, err := newTransport(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// The options wrap the transport of the SDK configuration, so they are applied to a client, which is only
+	// used to build the transport.
+	cfg := sdk.NewConfiguration("", "", "", "")
+	cfg.HTTPClient = &http.Client{Transport: transport}
+	c := &IonosCloudClient{API: sdk.NewAPIClient(cfg)}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	httpClient := &http.Client{Transport: cfg.HTTPClient.Transport, Timeout: 30 * time.Second}
+	return &DNSClient{endpoint: endpoint, config: config, httpClient: httpClient}, nil
+}
+
+// NewDNSClientFromSecret instantiates a DNSClient with the credentials of the Cloud API stored in the given secret.
+// The CA bundle, the proxy and the TLS verification are configured like the endpoint of the Cloud API, see
+// NewClientFromSecret, while dnsURL selects the DNS API.
+func NewDNSClientFromSecret(
+	secret *corev1.Secret, defaults Endpoint, dnsURL string, opts ...Option,
+) (*DNSClient, error) {
+	endpoint, err := endpointFromSecret(secret, defaults)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.URL = dnsURL
+	return NewDNSClient(DNSConfig{
+		Endpoint: endpoint,
+		Token:    string(secret.Data["token"]),
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}, opts...)
+}
+
+// GetZoneID returns the ID of the zone with the given name.
+func (c *DNSClient) GetZoneID(ctx context.Context, zoneName string) (string, error) {
+	var zones struct {
+		Items []struct {
+			ID         string `json:"id"`
+			Properties struct {
+				ZoneName string `json:"zoneName"`
+			} `json:"properties"`
+		} `json:"items"`
+	}
+	query := url.Values{"filter.zoneName": {zoneName}}
+	if err := c.do(ctx, http.MethodGet, "/zones", query, nil, &zones); err != nil {
+		return "", err
+	}
+	// The filter also matches zones, whose name only contains the given name.
+	for _, zone := range zones.Items {
+		if strings.EqualFold(strings.TrimSuffix(zone.Properties.ZoneName, "."), strings.TrimSuffix(zoneName, ".")) {
+			return zone.ID, nil
+		}
+	}
+	return "", fmt.Errorf("zone %s not found", zoneName)
+}
+
+// ListRecords returns the records of the zone, which have the given name.
+func (c *DNSClient) ListRecords(ctx context.Context, zoneID, name string) ([]ionoscloud.DNSRecord, error) {
+	var records struct {
+		Items []dnsRecord `json:"items"`
+	}
+	query := url.Values{"filter.name": {name}, "limit": {"1000"}}
+	if err := c.do(ctx, http.MethodGet, recordsPath(zoneID), query, nil, &records); err != nil {
+		return nil, err
+	}
+	// The filter also matches records, whose name only contains the given name.
+	var result []ionoscloud.DNSRecord
+	for _, record := range records.Items {
+		if record.Properties.Name == name {
+			result = append(result, record.toDNSRecord())
+		}
+	}
+	return result, nil
+}
+
+// CreateRecord creates the record in the zone and returns its ID.
+func (c *DNSClient) CreateRecord(ctx context.Context, zoneID string, record ionoscloud.DNSRecord) (string, error) {
+	var created dnsRecord
+	if err := c.do(ctx, http.MethodPost, recordsPath(zoneID), nil, newDNSRecord(record), &created); err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// UpdateRecord replaces the record of the zone, which has the ID of the given record.
+func (c *DNSClient) UpdateRecord(ctx context.Context, zoneID string, record ionoscloud.DNSRecord) error {
+	if record.ID == "" {
+		return errors.New("record ID must be set")
+	}
+	return c.do(ctx, http.MethodPut, recordsPath(zoneID)+"/"+url.PathEscape(record.ID), nil, newDNSRecord(record), nil)
+}
+
+// DeleteRecord deletes the record with the given ID from the zone. Deleting a missing record succeeds.
+func (c *DNSClient) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	err := c.do(ctx, http.MethodDelete, recordsPath(zoneID)+"/"+url.PathEscape(recordID), nil, nil, nil)
+	var apiErr *DNSError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// DNSError is returned for requests, which were answered with an error by the DNS API.
+type DNSError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *DNSError) Error() string {
+	return fmt.Sprintf("DNS request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// dnsRecord is the representation of a record in the DNS API.
+type dnsRecord struct {
+	ID         string              `json:"id,omitempty"`
+	Properties dnsRecordProperties `json:"properties"`
+}
+
+type dnsRecordProperties struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int32  `json:"ttl,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+func newDNSRecord(record ionoscloud.DNSRecord) *dnsRecord {
+	return &dnsRecord{Properties: dnsRecordProperties{
+		Name:    record.Name,
+		Type:    record.Type,
+		Content: record.Content,
+		TTL:     record.TTL,
+		Enabled: true,
+	}}
+}
+
+func (r dnsRecord) toDNSRecord() ionoscloud.DNSRecord {
+	return ionoscloud.DNSRecord{
+		ID:      r.ID,
+		Name:    r.Properties.Name,
+		Type:    r.Properties.Type,
+		Content: r.Properties.Content,
+		TTL:     r.Properties.TTL,
+	}
+}
+
+func recordsPath(zoneID string) string {
+	return "/zones/" + url.PathEscape(zoneID) + "/records"
+}
+
+func (c *DNSClient) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("unable to encode body of DNS request %s %s: %w", method, path, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return fmt.Errorf("unable to create DNS request %s %s: %w", method, u.Path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	} else {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("DNS request %s %s failed: %w", method, u.Path, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read response of DNS request %s %s: %w", method, u.Path, err)
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		return &DNSError{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(data))}
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("unable to parse response of DNS request %s %s: %w", method, u.Path, err)
+	}
+	return nil
+}
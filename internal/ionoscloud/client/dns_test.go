@@ -0,0 +1,144 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+func TestDNSClientRecords(t *testing.T) {
+	var created, updated dnsRecord
+	deleted := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/zones":
+			require.Equal(t, "example.com", r.URL.Query().Get("filter.zoneName"))
+			_, _ = w.Write([]byte(`{"items":[{"id":"other","properties":{"zoneName":"sub.example.com"}},` +
+				`{"id":"zone","properties":{"zoneName":"example.com"}}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/zones/zone/records":
+			require.Equal(t, "cluster", r.URL.Query().Get("filter.name"))
+			_, _ = w.Write([]byte(`{"items":[` +
+				`{"id":"a","properties":{"name":"cluster","type":"A","content":"198.51.100.1","ttl":300}},` +
+				`{"id":"b","properties":{"name":"*.cluster","type":"A","content":"198.51.100.1","ttl":300}}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/zones/zone/records":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"id":"c"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/zones/zone/records/a":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		case r.Method == http.MethodDelete && r.URL.Path == "/zones/zone/records/a":
+			deleted = "a"
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewDNSClient(DNSConfig{Endpoint: Endpoint{URL: server.URL}, Token: "token"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	zoneID, err := c.GetZoneID(ctx, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, "zone", zoneID)
+
+	records, err := c.ListRecords(ctx, zoneID, "cluster")
+	require.NoError(t, err)
+	require.Equal(t, []ionoscloud.DNSRecord{
+		{ID: "a", Name: "cluster", Type: "A", Content: "198.51.100.1", TTL: 300},
+	}, records)
+
+	record := ionoscloud.DNSRecord{Name: "cluster", Type: "A", Content: "198.51.100.2", TTL: 60}
+	id, err := c.CreateRecord(ctx, zoneID, record)
+	require.NoError(t, err)
+	require.Equal(t, "c", id)
+	require.Equal(t, dnsRecordProperties{Name: "cluster", Type: "A", Content: "198.51.100.2", TTL: 60, Enabled: true},
+		created.Properties)
+
+	record.ID = "a"
+	require.NoError(t, c.UpdateRecord(ctx, zoneID, record))
+	require.Equal(t, "198.51.100.2", updated.Properties.Content)
+
+	require.NoError(t, c.DeleteRecord(ctx, zoneID, "a"))
+	require.Equal(t, "a", deleted)
+	require.NoError(t, c.DeleteRecord(ctx, zoneID, "missing"))
+}
+
+func TestDNSClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"unauthorized"}]}`))
+	}))
+	defer server.Close()
+
+	_, err := NewDNSClient(DNSConfig{Endpoint: Endpoint{URL: server.URL}})
+	require.Error(t, err)
+
+	c, err := NewDNSClient(DNSConfig{Endpoint: Endpoint{URL: server.URL}, Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	_, err = c.GetZoneID(context.Background(), "example.com")
+	var apiErr *DNSError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	c, err = NewDNSClient(DNSConfig{Endpoint: Endpoint{URL: server.URL}, Token: "token"}, WithDryRun())
+	require.NoError(t, err)
+	_, err = c.CreateRecord(context.Background(), "zone", ionoscloud.DNSRecord{Name: "cluster", Type: "A"})
+	var dryRunErr *DryRunError
+	require.ErrorAs(t, err, &dryRunErr)
+	require.Equal(t, http.MethodPost, dryRunErr.Method)
+}
+
+func TestNewDNSClientFromSecret(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id":"c"}`))
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	secret := &corev1.Secret{Data: map[string][]byte{"token": []byte("token"), "caBundle": caBundle}}
+	recorder := record.NewFakeRecorder(10)
+	c, err := NewDNSClientFromSecret(secret, Endpoint{URL: "https://api.example.com"}, server.URL,
+		WithRateLimiter(NewRateLimiter(RateLimitOptions{})), WithAuditLog(recorder, &infrav1.IonosCloudCluster{}))
+	require.NoError(t, err)
+	require.Equal(t, server.URL, c.endpoint.String(), "the URL of the Cloud API must not be used")
+
+	audit, ok := c.httpClient.Transport.(*auditTransport)
+	require.True(t, ok)
+	require.IsType(t, &rateLimitedTransport{}, audit.base)
+
+	id, err := c.CreateRecord(context.Background(), "zone", ionoscloud.DNSRecord{Name: "cluster", Type: "A"})
+	require.NoError(t, err, "the CA bundle of the secret must be trusted")
+	require.Equal(t, "c", id)
+	require.Equal(t, "Normal "+AuditEventReason+" POST records c succeeded with status 202", <-recorder.Events)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DryRunError is returned for every request of a dry-run client, which would mutate a resource.
+type DryRunError struct {
+	// Method is the HTTP method of the skipped request.
+	Method string
+	// Path is the path of the skipped request.
+	Path string
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("dry run: skipped %s %s", e.Method, e.Path)
+}
+
+// WithDryRun prevents the client from mutating any resources. Only GET and HEAD requests are sent to the
+// Cloud API. All other requests are logged together with their body and fail with a DryRunError,
+// just like image uploads.
+func WithDryRun() Option {
+	return func(c *IonosCloudClient) {
+		cfg := c.API.GetConfig()
+		base := http.DefaultTransport
+		if cfg.HTTPClient != nil && cfg.HTTPClient.Transport != nil {
+			base = cfg.HTTPClient.Transport
+		}
+		cfg.HTTPClient = &http.Client{Transport: &dryRunTransport{base: base}}
+		c.dryRun = true
+	}
+}
+
+type dryRunTransport struct {
+	base http.RoundTripper
+}
+
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+	}
+	ctrl.LoggerFrom(req.Context()).Info("Dry run: skipping request to the Cloud API",
+		"method", req.Method, "path", req.URL.Path, "body", string(body))
+
+	return nil, &DryRunError{Method: req.Method, Path: req.URL.Path}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func TestDryRunTransportSendsReadRequests(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.NewStringResponder(http.StatusOK, ""))
+	transport := &dryRunTransport{base: mock}
+
+	require.Equal(t, http.StatusOK, roundTrip(t, transport, newRequest(t, http.MethodGet, nil)))
+	require.Equal(t, 1, mock.GetTotalCallCount())
+}
+
+func TestDryRunTransportSkipsMutatingRequests(t *testing.T) {
+	mock := httpmock.NewMockTransport()
+	transport := &dryRunTransport{base: mock}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		//nolint:bodyclose // No response is returned.
+		_, err := transport.RoundTrip(newRequest(t, method, strings.NewReader("{}")))
+		var dryRunErr *DryRunError
+		require.ErrorAs(t, err, &dryRunErr)
+		require.Equal(t, method, dryRunErr.Method)
+		require.Equal(t, "/cloudapi/v6/datacenters", dryRunErr.Path)
+	}
+	require.Zero(t, mock.GetTotalCallCount())
+}
+
+func TestWithDryRun(t *testing.T) {
+	c, err := NewClient(Credentials{Token: "token"}, Endpoint{}, WithDryRun())
+	require.NoError(t, err)
+	require.IsType(t, &dryRunTransport{}, c.API.GetConfig().HTTPClient.Transport)
+
+	_, err = c.CreateDatacenter(context.Background(), sdk.DatacenterProperties{Location: ptr.To("de/txl")})
+	var dryRunErr *DryRunError
+	require.ErrorAs(t, err, &dryRunErr)
+	require.Equal(t, http.MethodPost, dryRunErr.Method)
+}
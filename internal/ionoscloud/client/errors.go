@@ -19,14 +19,27 @@ package client
 import "errors"
 
 var (
-	errDatacenterIDIsEmpty = errors.New("error parsing data center ID: value cannot be empty")
-	errServerIDIsEmpty     = errors.New("error parsing server ID: value cannot be empty")
-	errVolumeIDIsEmpty     = errors.New("error parsing volume ID: value cannot be empty")
-	errLANIDIsEmpty        = errors.New("error parsing LAN ID: value cannot be empty")
-	errNICIDIsEmpty        = errors.New("error parsing NIC ID: value cannot be empty")
-	errIPBlockIDIsEmpty    = errors.New("error parsing IP block ID: value cannot be empty")
-	errRequestURLIsEmpty   = errors.New("a request URL is necessary for the operation")
-	errLocationHeaderEmpty = errors.New(apiNoLocationErrMessage)
+	errDatacenterIDIsEmpty   = errors.New("error parsing data center ID: value cannot be empty")
+	errServerIDIsEmpty       = errors.New("error parsing server ID: value cannot be empty")
+	errVolumeIDIsEmpty       = errors.New("error parsing volume ID: value cannot be empty")
+	errImageIDIsEmpty        = errors.New("error parsing image ID: value cannot be empty")
+	errSnapshotIDIsEmpty     = errors.New("error parsing snapshot ID: value cannot be empty")
+	errLANIDIsEmpty          = errors.New("error parsing LAN ID: value cannot be empty")
+	errNICIDIsEmpty          = errors.New("error parsing NIC ID: value cannot be empty")
+	errIPBlockIDIsEmpty      = errors.New("error parsing IP block ID: value cannot be empty")
+	errNLBIDIsEmpty          = errors.New("error parsing network load balancer ID: value cannot be empty")
+	errNATGatewayIDIsEmpty   = errors.New("error parsing NAT gateway ID: value cannot be empty")
+	errALBIDIsEmpty          = errors.New("error parsing application load balancer ID: value cannot be empty")
+	errTargetGroupIDIsEmpty  = errors.New("error parsing target group ID: value cannot be empty")
+	errCrossConnectIDEmpty   = errors.New("error parsing Cross Connect ID: value cannot be empty")
+	errRuleIDIsEmpty         = errors.New("error parsing forwarding rule ID: value cannot be empty")
+	errFirewallRuleIDEmpty   = errors.New("error parsing firewall rule ID: value cannot be empty")
+	errRequestURLIsEmpty     = errors.New("a request URL is necessary for the operation")
+	errLabelKeyIsEmpty       = errors.New("error parsing label key: value cannot be empty")
+	errLocationHeaderEmpty   = errors.New(apiNoLocationErrMessage)
+	errLocationIsEmpty       = errors.New("error parsing location: value cannot be empty")
+	errUploadCredentials     = errors.New("uploading images requires a username and password")
+	errRemoteConsoleURLEmpty = errors.New("request to Cloud API did not return the remote console URL")
 )
 
 const (
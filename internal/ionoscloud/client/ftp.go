@@ -0,0 +1,179 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ftpTimeout limits the duration of an upload, if the context has no deadline.
+const ftpTimeout = 5 * time.Minute
+
+// ftpsUpload uploads a single file via explicit FTPS in passive mode. It only implements the commands,
+// which are needed to upload images to the FTP servers of IONOS Cloud.
+type ftpsUpload struct {
+	addr      string
+	tlsConfig *tls.Config
+	username  string
+	password  string
+}
+
+func (u *ftpsUpload) store(ctx context.Context, path string, data []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", u.addr)
+	if err != nil {
+		return fmt.Errorf("could not connect to FTP server %s: %w", u.addr, err)
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ftpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	control := textproto.NewConn(conn)
+	if _, _, err := control.ReadResponse(220); err != nil {
+		return fmt.Errorf("unexpected greeting of FTP server: %w", err)
+	}
+	if _, _, err := command(control, 234, "AUTH TLS"); err != nil {
+		return err
+	}
+
+	// The TLS session of the control connection is resumed for the data connection,
+	// which is required by most FTP servers.
+	tlsConfig := u.tlsConfig.Clone()
+	tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(1)
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return fmt.Errorf("TLS handshake with FTP server failed: %w", err)
+	}
+	control = textproto.NewConn(tlsConn)
+
+	if err := u.login(control); err != nil {
+		return err
+	}
+	for _, cmd := range []string{"PBSZ 0", "PROT P", "TYPE I"} {
+		if _, _, err := command(control, 200, cmd); err != nil {
+			return err
+		}
+	}
+
+	_, msg, err := command(control, 227, "PASV")
+	if err != nil {
+		return err
+	}
+	port, err := parsePassivePort(msg)
+	if err != nil {
+		return err
+	}
+	// The address in the reply is ignored, as it is often wrong if the server is behind a NAT.
+	host, _, err := net.SplitHostPort(u.addr)
+	if err != nil {
+		return err
+	}
+	dataConn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("could not open FTP data connection: %w", err)
+	}
+	defer dataConn.Close()
+	if err := dataConn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	if _, _, err := command(control, 1, "STOR "+path); err != nil {
+		return err
+	}
+	tlsDataConn := tls.Client(dataConn, tlsConfig)
+	if _, err := tlsDataConn.Write(data); err != nil {
+		return fmt.Errorf("could not upload %s: %w", path, err)
+	}
+	if err := tlsDataConn.Close(); err != nil {
+		return fmt.Errorf("could not upload %s: %w", path, err)
+	}
+	if _, _, err := control.ReadResponse(2); err != nil {
+		return fmt.Errorf("could not upload %s: %w", path, err)
+	}
+
+	_, _, _ = command(control, 221, "QUIT")
+	return nil
+}
+
+func (u *ftpsUpload) login(control *textproto.Conn) error {
+	code, msg, err := command(control, 0, "USER "+u.username)
+	if err != nil {
+		return err
+	}
+	switch code {
+	case 230:
+		return nil
+	case 331:
+	default:
+		return fmt.Errorf("FTP login failed: %d %s", code, msg)
+	}
+	if _, _, err := command(control, 230, "PASS "+u.password); err != nil {
+		return errors.New("FTP login failed: invalid credentials")
+	}
+	return nil
+}
+
+// command sends the command and reads the response, whose status code must match expectCode.
+// An expectCode of 0 disables the check.
+func command(control *textproto.Conn, expectCode int, cmd string) (code int, msg string, err error) {
+	id, err := control.Cmd("%s", cmd)
+	if err != nil {
+		return 0, "", err
+	}
+	control.StartResponse(id)
+	defer control.EndResponse(id)
+
+	code, msg, err = control.ReadResponse(expectCode)
+	if err != nil {
+		// The command isn't part of the error, as it might contain the password.
+		name, _, _ := strings.Cut(cmd, " ")
+		return code, msg, fmt.Errorf("FTP command %s failed: %w", name, err)
+	}
+	return code, msg, nil
+}
+
+// parsePassivePort returns the port of a reply to PASV, e.g. "Entering Passive Mode (192,0,2,1,195,80)".
+func parsePassivePort(msg string) (int, error) {
+	start, end := strings.Index(msg, "("), strings.Index(msg, ")")
+	if start < 0 || end < start {
+		return 0, fmt.Errorf("invalid reply to PASV: %s", msg)
+	}
+	fields := strings.Split(msg[start+1:end], ",")
+	if len(fields) != 6 {
+		return 0, fmt.Errorf("invalid reply to PASV: %s", msg)
+	}
+	high, err1 := strconv.Atoi(strings.TrimSpace(fields[4]))
+	low, err2 := strconv.Atoi(strings.TrimSpace(fields[5]))
+	if err := errors.Join(err1, err2); err != nil {
+		return 0, fmt.Errorf("invalid reply to PASV: %s", msg)
+	}
+	return high<<8 | low, nil
+}
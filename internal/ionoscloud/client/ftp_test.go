@@ -0,0 +1,203 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFTPServer accepts a single upload via explicit FTPS.
+type fakeFTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	password  string
+
+	path string
+	data []byte
+	done chan error
+}
+
+func newFakeFTPServer(t *testing.T) (*fakeFTPServer, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &fakeFTPServer{
+		listener: listener,
+		tlsConfig: &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+			MinVersion:   tls.VersionTLS12,
+		},
+		password: "password",
+		done:     make(chan error, 1),
+	}
+	go func() { server.done <- server.serve() }()
+	return server, pool
+}
+
+func (s *fakeFTPServer) serve() error {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	reply := func(w io.Writer, format string, args ...any) {
+		_, _ = fmt.Fprintf(w, format+"\r\n", args...)
+	}
+	reply(conn, "220 ready")
+	var control io.ReadWriter = conn
+	reader := bufio.NewReader(control)
+	var dataListener net.Listener
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+		switch cmd {
+		case "AUTH":
+			reply(control, "234 AUTH TLS successful")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			control, reader = tlsConn, bufio.NewReader(tlsConn)
+		case "USER":
+			reply(control, "331 password required")
+		case "PASS":
+			if arg != s.password {
+				reply(control, "530 login incorrect")
+				continue
+			}
+			reply(control, "230 logged in")
+		case "PBSZ", "PROT", "TYPE":
+			reply(control, "200 ok")
+		case "PASV":
+			if dataListener, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+				return err
+			}
+			port := dataListener.Addr().(*net.TCPAddr).Port
+			// The address is deliberately wrong, the client must use the address of the control connection.
+			reply(control, "227 Entering Passive Mode (192,0,2,1,%d,%d)", port>>8, port&0xff)
+		case "STOR":
+			dataConn, err := dataListener.Accept()
+			if err != nil {
+				return err
+			}
+			reply(control, "150 opening data connection")
+			tlsDataConn := tls.Server(dataConn, s.tlsConfig)
+			s.path = arg
+			s.data, err = io.ReadAll(tlsDataConn)
+			_ = dataConn.Close()
+			_ = dataListener.Close()
+			if err != nil {
+				return err
+			}
+			reply(control, "226 transfer complete")
+		case "QUIT":
+			reply(control, "221 bye")
+			return nil
+		default:
+			reply(control, "502 not implemented")
+		}
+	}
+}
+
+func newFTPSUpload(server *fakeFTPServer, pool *x509.CertPool, password string) *ftpsUpload {
+	_, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	return &ftpsUpload{
+		addr:      net.JoinHostPort("localhost", port),
+		tlsConfig: &tls.Config{ServerName: "localhost", RootCAs: pool, MinVersion: tls.VersionTLS12},
+		username:  "user",
+		password:  password,
+	}
+}
+
+func TestFTPSUploadStore(t *testing.T) {
+	server, pool := newFakeFTPServer(t)
+	upload := newFTPSUpload(server, pool, server.password)
+
+	data := []byte(strings.Repeat("iso", 4096))
+	require.NoError(t, upload.store(context.Background(), "/iso-images/cidata.iso", data))
+	require.NoError(t, <-server.done)
+	require.Equal(t, "/iso-images/cidata.iso", server.path)
+	require.Equal(t, data, server.data)
+}
+
+func TestFTPSUploadInvalidCredentials(t *testing.T) {
+	server, pool := newFakeFTPServer(t)
+	upload := newFTPSUpload(server, pool, "wrong")
+
+	err := upload.store(context.Background(), "/iso-images/cidata.iso", []byte("iso"))
+	require.ErrorContains(t, err, "invalid credentials")
+	require.NotContains(t, err.Error(), "wrong")
+}
+
+func TestParsePassivePort(t *testing.T) {
+	port, err := parsePassivePort("Entering Passive Mode (192,0,2,1,195,80).")
+	require.NoError(t, err)
+	require.Equal(t, 195<<8|80, port)
+
+	_, err = parsePassivePort("Entering Passive Mode")
+	require.Error(t, err)
+}
+
+func TestUploadImage(t *testing.T) {
+	c, err := NewClient(Credentials{Token: "token"}, Endpoint{})
+	require.NoError(t, err)
+	require.ErrorIs(t, c.UploadImage(context.Background(), "de/fra", "cidata.iso", nil), errUploadCredentials)
+
+	c, err = NewClient(Credentials{Username: "user", Password: "password"}, Endpoint{}, WithDryRun())
+	require.NoError(t, err)
+	var dryRunErr *DryRunError
+	require.ErrorAs(t, c.UploadImage(context.Background(), "de/fra", "cidata.iso", nil), &dryRunErr)
+	require.Equal(t, "/iso-images/cidata.iso", dryRunErr.Path)
+
+	require.Equal(t, "ftp-fra.ionos.com", ftpHost("de/fra"))
+}
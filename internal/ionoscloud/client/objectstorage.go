@@ -0,0 +1,409 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // S3 requires the MD5 digest of lifecycle configurations.
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+const (
+	// maxPresignExpiry is the maximum validity of a pre-signed URL with Signature Version 4.
+	maxPresignExpiry = 7 * 24 * time.Hour
+
+	signatureAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload    = "UNSIGNED-PAYLOAD"
+	amzDateFormat      = "20060102T150405Z"
+)
+
+var _ ionoscloud.ObjectStorage = (*ObjectStorageClient)(nil)
+
+// ObjectStorageConfig configures an ObjectStorageClient.
+type ObjectStorageConfig struct {
+	// Endpoint is the URL of the S3 endpoint, e.g. https://s3.eu-central-1.ionoscloud.com.
+	// If empty, the endpoint of IONOS Object Storage in the region is used.
+	Endpoint string
+	// Region is the region of the endpoint, which is part of the request signature.
+	Region string
+	// AccessKey is the access key of the Object Storage user.
+	AccessKey string
+	// SecretKey is the secret key of the Object Storage user.
+	SecretKey string
+	// DryRun prevents the client from mutating any objects, see WithDryRun.
+	DryRun bool
+}
+
+// ObjectStorageClient is a minimal client for the S3 API of IONOS Object Storage. Requests are signed with
+// AWS Signature Version 4 and sent to virtual-hosted-style URLs of the buckets.
+type ObjectStorageClient struct {
+	endpoint   *url.URL
+	region     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewObjectStorageClient instantiates an ObjectStorageClient with the given config.
+func NewObjectStorageClient(config ObjectStorageConfig) (*ObjectStorageClient, error) {
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return nil, errors.New("access key and secret key must be set")
+	}
+	if config.Region == "" {
+		return nil, errors.New("region must be set")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://s3." + config.Region + ".ionoscloud.com"
+	}
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid Object Storage endpoint %q", config.Endpoint)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if config.DryRun {
+		httpClient.Transport = &dryRunTransport{base: http.DefaultTransport}
+	}
+	return &ObjectStorageClient{
+		endpoint:   endpoint,
+		region:     config.Region,
+		accessKey:  config.AccessKey,
+		secretKey:  config.SecretKey,
+		httpClient: httpClient,
+		now:        time.Now,
+	}, nil
+}
+
+// NewObjectStorageClientFromSecret instantiates an ObjectStorageClient with the keys stored in the given secret.
+// The secret needs to contain the keys accessKey and secretKey.
+func NewObjectStorageClientFromSecret(
+	secret *corev1.Secret, endpoint, region string, dryRun bool,
+) (*ObjectStorageClient, error) {
+	return NewObjectStorageClient(ObjectStorageConfig{
+		Endpoint:  endpoint,
+		Region:    region,
+		AccessKey: string(secret.Data["accessKey"]),
+		SecretKey: string(secret.Data["secretKey"]),
+		DryRun:    dryRun,
+	})
+}
+
+// PutObject uploads data as the object with the given key into the bucket.
+func (c *ObjectStorageClient) PutObject(ctx context.Context, bucket, key string, data []byte) error {
+	_, err := c.do(ctx, http.MethodPut, bucket, key, nil, data, nil)
+	return err
+}
+
+// DeleteObject deletes the object with the given key from the bucket. Deleting a missing object succeeds.
+func (c *ObjectStorageClient) DeleteObject(ctx context.Context, bucket, key string) error {
+	_, err := c.do(ctx, http.MethodDelete, bucket, key, nil, nil, nil)
+	return err
+}
+
+// PresignGetObject returns a URL, which allows downloading the object with the given key
+// without credentials until the expiry has passed.
+func (c *ObjectStorageClient) PresignGetObject(bucket, key string, expiry time.Duration) (string, error) {
+	if expiry <= 0 || expiry > maxPresignExpiry {
+		return "", fmt.Errorf("expiry must be between 1s and %s", maxPresignExpiry)
+	}
+
+	now := c.now().UTC()
+	u := c.objectURL(bucket, key)
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", signatureAlgorithm)
+	query.Set("X-Amz-Credential", c.accessKey+"/"+c.credentialScope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	headers := http.Header{}
+	headers.Set("Host", u.Host)
+	signature := c.signature(now, http.MethodGet, u.EscapedPath(), query, headers, unsignedPayload)
+	query.Set("X-Amz-Signature", signature)
+	u.RawQuery = canonicalQuery(query)
+	return u.String(), nil
+}
+
+// EnsureExpirationRule ensures that the lifecycle configuration of the bucket contains a rule with the given ID,
+// which deletes the objects with the given prefix after the given number of days.
+// Other rules of the bucket are kept.
+func (c *ObjectStorageClient) EnsureExpirationRule(
+	ctx context.Context, bucket, ruleID, prefix string, days int32,
+) error {
+	lifecycle := url.Values{"lifecycle": {""}}
+	body, err := c.do(ctx, http.MethodGet, bucket, "", lifecycle, nil, nil)
+	var apiErr *ObjectStorageError
+	if errors.As(err, &apiErr) && apiErr.Code == "NoSuchLifecycleConfiguration" {
+		body, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var current lifecycleConfiguration
+	if len(body) > 0 {
+		if err := xml.Unmarshal(body, &current); err != nil {
+			return fmt.Errorf("unable to parse lifecycle configuration of bucket %s: %w", bucket, err)
+		}
+	}
+
+	want := lifecycleRule{ID: ruleID, Prefix: prefix, Status: "Enabled", Expiration: lifecycleExpiration{Days: days}}
+	config := rawLifecycleConfiguration{}
+	for _, rule := range current.Rules {
+		if rule.ID != ruleID {
+			config.Rules = append(config.Rules, innerXML{Inner: rule.Inner})
+			continue
+		}
+		var existing lifecycleRule
+		if err := xml.Unmarshal([]byte("<Rule>"+rule.Inner+"</Rule>"), &existing); err == nil {
+			existing.XMLName = xml.Name{}
+			if existing == want {
+				return nil
+			}
+		}
+	}
+	rule, err := xml.Marshal(want)
+	if err != nil {
+		return err
+	}
+	config.Rules = append(config.Rules, innerXML{
+		Inner: strings.TrimSuffix(strings.TrimPrefix(string(rule), "<Rule>"), "</Rule>"),
+	})
+
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	digest := md5.Sum(data) //nolint:gosec // See import.
+	headers := http.Header{}
+	headers.Set("Content-MD5", base64.StdEncoding.EncodeToString(digest[:]))
+	_, err = c.do(ctx, http.MethodPut, bucket, "", lifecycle, data, headers)
+	return err
+}
+
+// ObjectStorageError is returned for requests, which were answered with an error by the S3 API.
+type ObjectStorageError struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e *ObjectStorageError) Error() string {
+	return fmt.Sprintf("object storage request failed with status %d: %s %s", e.StatusCode, e.Code, e.Message)
+}
+
+type lifecycleConfiguration struct {
+	XMLName xml.Name           `xml:"LifecycleConfiguration"`
+	Rules   []rawLifecycleRule `xml:"Rule"`
+}
+
+// rawLifecycleRule keeps the XML of a lifecycle rule, so that rules, which weren't created by the client,
+// are written back unchanged.
+type rawLifecycleRule struct {
+	ID    string `xml:"ID"`
+	Inner string `xml:",innerxml"`
+}
+
+type rawLifecycleConfiguration struct {
+	XMLName xml.Name   `xml:"LifecycleConfiguration"`
+	Rules   []innerXML `xml:"Rule"`
+}
+
+type innerXML struct {
+	Inner string `xml:",innerxml"`
+}
+
+type lifecycleRule struct {
+	XMLName    xml.Name            `xml:"Rule"`
+	ID         string              `xml:"ID"`
+	Prefix     string              `xml:"Prefix"`
+	Status     string              `xml:"Status"`
+	Expiration lifecycleExpiration `xml:"Expiration"`
+}
+
+type lifecycleExpiration struct {
+	Days int32 `xml:"Days"`
+}
+
+func (c *ObjectStorageClient) do(
+	ctx context.Context, method, bucket, key string, query url.Values, body []byte, headers http.Header,
+) ([]byte, error) {
+	now := c.now().UTC()
+	u := c.objectURL(bucket, key)
+	if headers == nil {
+		headers = http.Header{}
+	}
+	payloadHash := sha256.Sum256(body)
+	headers.Set("Host", u.Host)
+	headers.Set("X-Amz-Date", now.Format(amzDateFormat))
+	headers.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	signature := c.signature(now, method, u.EscapedPath(), query, headers, hex.EncodeToString(payloadHash[:]))
+	headers.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signatureAlgorithm, c.accessKey, c.credentialScope(now), signedHeaders(headers), signature))
+
+	u.RawQuery = canonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		if name != "Host" {
+			req.Header[name] = values
+		}
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("object storage request %s %s failed: %w", method, u.Path, err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := &ObjectStorageError{StatusCode: res.StatusCode}
+		_ = xml.Unmarshal(data, apiErr)
+		return nil, apiErr
+	}
+	return data, nil
+}
+
+// objectURL returns the virtual-hosted-style URL of the object. The bucket itself is addressed with an empty key.
+func (c *ObjectStorageClient) objectURL(bucket, key string) *url.URL {
+	return &url.URL{
+		Scheme:  c.endpoint.Scheme,
+		Host:    bucket + "." + c.endpoint.Host,
+		Path:    "/" + key,
+		RawPath: "/" + uriEncode(key, false),
+	}
+}
+
+func (c *ObjectStorageClient) credentialScope(t time.Time) string {
+	return strings.Join([]string{t.Format("20060102"), c.region, "s3", "aws4_request"}, "/")
+}
+
+// signature calculates the Signature Version 4 of a request. All given headers are signed.
+func (c *ObjectStorageClient) signature(
+	t time.Time, method, escapedPath string, query url.Values, headers http.Header, payloadHash string,
+) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, strings.ToLower(name))
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(headers.Get(name)))
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		canonicalQuery(query),
+		canonicalHeaders.String(),
+		signedHeaders(headers),
+		payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signatureAlgorithm,
+		t.Format(amzDateFormat),
+		c.credentialScope(t),
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretKey), t.Format("20060102"))
+	for _, part := range []string{c.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func signedHeaders(headers http.Header) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if name == "Authorization" {
+			continue
+		}
+		names = append(names, strings.ToLower(name))
+	}
+	slices.Sort(names)
+	return strings.Join(names, ";")
+}
+
+// canonicalQuery returns the query sorted by key with all reserved characters encoded.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode encodes every byte except the unreserved characters. Slashes are only encoded if encodeSlash is true.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			b.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
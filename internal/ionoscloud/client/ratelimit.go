@@ -0,0 +1,177 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMinBackoff = 500 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// RateLimitOptions configures how requests to the Cloud API are limited and retried.
+type RateLimitOptions struct {
+	// QPS is the number of requests per second, which may be sent to the Cloud API.
+	// Rate limiting is disabled if it is zero.
+	QPS float64
+	// Burst is the number of requests, which may be sent at once.
+	Burst int
+	// MaxRetries is the number of times a request is retried, if the Cloud API is throttling
+	// or temporarily unavailable.
+	MaxRetries int
+	// RetryBudget is the number of retries per minute. Once the budget is exhausted, requests fail
+	// right away, so that the reconcilers back off instead of increasing the load on the Cloud API.
+	// The budget is unlimited if it is zero.
+	RetryBudget int
+	// MinBackoff is the time to wait before the first retry. It is doubled with every further retry.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum time to wait before a retry.
+	MaxBackoff time.Duration
+}
+
+// RateLimiter limits the requests, which are sent to the Cloud API, and retries throttled requests with
+// exponential backoff. The limits and the retry budget are shared by all clients using the same RateLimiter,
+// which makes it possible to share them between reconcilers and clusters with different credentials.
+type RateLimiter struct {
+	requests *rate.Limiter
+	retries  *rate.Limiter
+	opts     RateLimitOptions
+}
+
+// NewRateLimiter creates a RateLimiter with the given options.
+func NewRateLimiter(opts RateLimitOptions) *RateLimiter {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+
+	l := &RateLimiter{
+		requests: rate.NewLimiter(rate.Inf, 0),
+		retries:  rate.NewLimiter(rate.Inf, 0),
+		opts:     opts,
+	}
+	if opts.QPS > 0 {
+		l.requests = rate.NewLimiter(rate.Limit(opts.QPS), max(opts.Burst, 1))
+	}
+	if opts.RetryBudget > 0 {
+		l.retries = rate.NewLimiter(rate.Every(time.Minute/time.Duration(opts.RetryBudget)), opts.RetryBudget)
+	}
+	return l
+}
+
+// WithRateLimiter sends all requests of the client through the given RateLimiter.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *IonosCloudClient) {
+		cfg := c.API.GetConfig()
+		base := http.DefaultTransport
+		if cfg.HTTPClient != nil && cfg.HTTPClient.Transport != nil {
+			base = cfg.HTTPClient.Transport
+		}
+		cfg.HTTPClient = &http.Client{Transport: &rateLimitedTransport{base: base, limiter: limiter}}
+		// The transport takes care of retries, which must not be multiplied by the retries of the SDK.
+		cfg.MaxRetries = 1
+	}
+}
+
+// rateLimitedTransport is an http.RoundTripper, which waits for the RateLimiter before sending a request.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.requests.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		res, err := t.base.RoundTrip(req)
+		if err != nil || !isRetryable(req, res) || attempt >= t.limiter.opts.MaxRetries {
+			return res, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			// The body has already been consumed and cannot be sent again.
+			return res, nil
+		}
+		if !t.limiter.retries.Allow() {
+			return res, nil
+		}
+
+		backoff := t.limiter.backoff(attempt, res)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+// isRetryable returns true if the request failed because the Cloud API is throttling or temporarily
+// unavailable. POST requests are only retried if they were throttled, as they might have been
+// processed otherwise.
+func isRetryable(req *http.Request, res *http.Response) bool {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return req.Method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+// backoff returns the time to wait before retrying a request. If the Cloud API sent a Retry-After header,
+// it takes precedence over the exponential backoff.
+func (l *RateLimiter) backoff(attempt int, res *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return min(time.Duration(seconds)*time.Second, l.opts.MaxBackoff)
+	}
+
+	backoff := l.opts.MinBackoff
+	for i := 0; i < attempt && backoff < l.opts.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, l.opts.MaxBackoff)
+	// Add up to 20% of jitter, so that throttled reconcilers don't retry in lockstep.
+	return backoff + rand.N(backoff/5+1) //nolint:gosec // No cryptographic randomness needed.
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleURL = "https://api.ionos.com/cloudapi/v6/datacenters"
+
+func newRateLimitedTransport(opts RateLimitOptions) (*rateLimitedTransport, *httpmock.MockTransport) {
+	if opts.MinBackoff == 0 {
+		opts.MinBackoff = time.Millisecond
+	}
+	mock := httpmock.NewMockTransport()
+	return &rateLimitedTransport{base: mock, limiter: NewRateLimiter(opts)}, mock
+}
+
+func TestRateLimitedTransportRetriesThrottledRequests(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{MaxRetries: 3})
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.ResponderFromMultipleResponses([]*http.Response{
+		httpmock.NewStringResponse(http.StatusTooManyRequests, ""),
+		httpmock.NewStringResponse(http.StatusServiceUnavailable, ""),
+		httpmock.NewStringResponse(http.StatusOK, ""),
+	}))
+
+	require.Equal(t, http.StatusOK, roundTrip(t, transport, newRequest(t, http.MethodGet, nil)))
+	require.Equal(t, 3, mock.GetTotalCallCount())
+}
+
+func TestRateLimitedTransportMaxRetries(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{MaxRetries: 2})
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.NewStringResponder(http.StatusTooManyRequests, ""))
+
+	require.Equal(t, http.StatusTooManyRequests, roundTrip(t, transport, newRequest(t, http.MethodGet, nil)))
+	require.Equal(t, 3, mock.GetTotalCallCount())
+}
+
+func TestRateLimitedTransportRetryBudget(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{MaxRetries: 5, RetryBudget: 1})
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.NewStringResponder(http.StatusTooManyRequests, ""))
+
+	require.Equal(t, http.StatusTooManyRequests, roundTrip(t, transport, newRequest(t, http.MethodGet, nil)))
+	require.Equal(t, 2, mock.GetTotalCallCount(), "only one retry should be allowed by the budget")
+
+	roundTrip(t, transport, newRequest(t, http.MethodGet, nil))
+	require.Equal(t, 3, mock.GetTotalCallCount(), "the budget should be exhausted")
+}
+
+func TestRateLimitedTransportUnavailablePOST(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{MaxRetries: 3})
+	mock.RegisterResponder(http.MethodPost, exampleURL, httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	require.Equal(t, http.StatusServiceUnavailable, roundTrip(t, transport, newRequest(t, http.MethodPost, strings.NewReader("{}"))))
+	require.Equal(t, 1, mock.GetTotalCallCount(), "POST requests might have been processed and must not be retried")
+}
+
+func TestRateLimitedTransportResendsBody(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{MaxRetries: 1})
+	var bodies []string
+	mock.RegisterResponder(http.MethodPost, exampleURL, func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return httpmock.NewStringResponse(http.StatusTooManyRequests, ""), nil
+		}
+		return httpmock.NewStringResponse(http.StatusAccepted, ""), nil
+	})
+
+	require.Equal(t, http.StatusAccepted, roundTrip(t, transport, newRequest(t, http.MethodPost, strings.NewReader(`{"name":"dc"}`))))
+	require.Equal(t, []string{`{"name":"dc"}`, `{"name":"dc"}`}, bodies)
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitOptions{MinBackoff: time.Second, MaxBackoff: 10 * time.Second})
+	res := httpmock.NewStringResponse(http.StatusTooManyRequests, "")
+
+	require.InDelta(t, time.Second, limiter.backoff(0, res), float64(200*time.Millisecond))
+	require.InDelta(t, 4*time.Second, limiter.backoff(2, res), float64(800*time.Millisecond))
+	require.InDelta(t, 10*time.Second, limiter.backoff(100, res), float64(2*time.Second))
+
+	res.Header.Set("Retry-After", "3")
+	require.Equal(t, 3*time.Second, limiter.backoff(0, res))
+	res.Header.Set("Retry-After", "60")
+	require.Equal(t, 10*time.Second, limiter.backoff(0, res))
+}
+
+func TestRateLimiterLimitsRequests(t *testing.T) {
+	transport, mock := newRateLimitedTransport(RateLimitOptions{QPS: 20, Burst: 1})
+	mock.RegisterResponder(http.MethodGet, exampleURL, httpmock.NewStringResponder(http.StatusOK, ""))
+
+	start := time.Now()
+	for range 3 {
+		roundTrip(t, transport, newRequest(t, http.MethodGet, nil))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond, "requests should have been limited to 20 per second")
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	c, err := NewClient(Credentials{Token: "token"}, Endpoint{}, WithRateLimiter(NewRateLimiter(RateLimitOptions{})))
+	require.NoError(t, err)
+	cfg := c.API.GetConfig()
+	require.IsType(t, &rateLimitedTransport{}, cfg.HTTPClient.Transport)
+	require.Equal(t, 1, cfg.MaxRetries, "the SDK should not retry requests on its own")
+	require.NotEqual(t, http.DefaultClient, cfg.HTTPClient, "the default client must not be modified")
+}
+
+// roundTrip sends the request and returns the status code of the response.
+func roundTrip(t *testing.T, transport http.RoundTripper, req *http.Request) int {
+	t.Helper()
+	res, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	return res.StatusCode
+}
+
+func newRequest(t *testing.T, method string, body io.Reader) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, exampleURL, body)
+	require.NoError(t, err)
+	return req
+}
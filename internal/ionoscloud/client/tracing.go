@@ -0,0 +1,41 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// WithTracing creates a span for every request of the client, using the global tracer provider.
+// It should be passed after WithRateLimiter, so that the span includes the time spent waiting for
+// the rate limiter and all retries.
+func WithTracing() Option {
+	return func(c *IonosCloudClient) {
+		cfg := c.API.GetConfig()
+		base := http.DefaultTransport
+		if cfg.HTTPClient != nil && cfg.HTTPClient.Transport != nil {
+			base = cfg.HTTPClient.Transport
+		}
+		cfg.HTTPClient = &http.Client{Transport: otelhttp.NewTransport(base,
+			otelhttp.WithSpanNameFormatter(func(_ string, req *http.Request) string {
+				return "IONOS API " + req.Method
+			}),
+		)}
+	}
+}
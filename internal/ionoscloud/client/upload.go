@@ -0,0 +1,73 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// isoImageDirectory is the directory of the FTP servers, from which CD-ROM images are created.
+const isoImageDirectory = "/iso-images"
+
+// UploadImage uploads an ISO image with the provided name to the FTP server of the given location.
+// The Cloud API creates a private CD-ROM image from it, which is named like the uploaded file.
+// As the FTP servers don't accept tokens, the client needs a username and password.
+func (c *IonosCloudClient) UploadImage(ctx context.Context, location, name string, data []byte) error {
+	if location == "" {
+		return errLocationIsEmpty
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid image name %q", name)
+	}
+
+	remotePath := path.Join(isoImageDirectory, name)
+	if c.dryRun {
+		ctrl.LoggerFrom(ctx).Info("Dry run: skipping image upload", "location", location, "path", remotePath)
+		return &DryRunError{Method: "STOR", Path: remotePath}
+	}
+	if c.username == "" || c.password == "" {
+		return errUploadCredentials
+	}
+
+	host := ftpHost(location)
+	upload := &ftpsUpload{
+		addr:      net.JoinHostPort(host, "21"),
+		tlsConfig: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12},
+		username:  c.username,
+		password:  c.password,
+	}
+	if err := upload.store(ctx, remotePath, data); err != nil {
+		return fmt.Errorf("could not upload image %s to %s: %w", name, host, err)
+	}
+	return nil
+}
+
+// ftpHost returns the FTP server of the location, e.g. ftp-fra.ionos.com for de/fra.
+func ftpHost(location string) string {
+	_, city, found := strings.Cut(location, "/")
+	if !found {
+		city = location
+	}
+	return fmt.Sprintf("ftp-%s.ionos.com", city)
+}
@@ -97,27 +97,27 @@ func (_c *MockClient_CheckRequestStatus_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
-// CreateLAN provides a mock function with given fields: ctx, datacenterID, properties
-func (_m *MockClient) CreateLAN(ctx context.Context, datacenterID string, properties ionoscloud.LanPropertiesPost) (string, error) {
-	ret := _m.Called(ctx, datacenterID, properties)
+// CreateApplicationLoadBalancer provides a mock function with given fields: ctx, datacenterID, properties, entities
+func (_m *MockClient) CreateApplicationLoadBalancer(ctx context.Context, datacenterID string, properties ionoscloud.ApplicationLoadBalancerProperties, entities ionoscloud.ApplicationLoadBalancerEntities) (string, error) {
+	ret := _m.Called(ctx, datacenterID, properties, entities)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLAN")
+		panic("no return value specified for CreateApplicationLoadBalancer")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.LanPropertiesPost) (string, error)); ok {
-		return rf(ctx, datacenterID, properties)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ApplicationLoadBalancerProperties, ionoscloud.ApplicationLoadBalancerEntities) (string, error)); ok {
+		return rf(ctx, datacenterID, properties, entities)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.LanPropertiesPost) string); ok {
-		r0 = rf(ctx, datacenterID, properties)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ApplicationLoadBalancerProperties, ionoscloud.ApplicationLoadBalancerEntities) string); ok {
+		r0 = rf(ctx, datacenterID, properties, entities)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.LanPropertiesPost) error); ok {
-		r1 = rf(ctx, datacenterID, properties)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.ApplicationLoadBalancerProperties, ionoscloud.ApplicationLoadBalancerEntities) error); ok {
+		r1 = rf(ctx, datacenterID, properties, entities)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -125,125 +125,115 @@ func (_m *MockClient) CreateLAN(ctx context.Context, datacenterID string, proper
 	return r0, r1
 }
 
-// MockClient_CreateLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLAN'
-type MockClient_CreateLAN_Call struct {
+// MockClient_CreateApplicationLoadBalancer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApplicationLoadBalancer'
+type MockClient_CreateApplicationLoadBalancer_Call struct {
 	*mock.Call
 }
 
-// CreateLAN is a helper method to define mock.On call
+// CreateApplicationLoadBalancer is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - properties ionoscloud.LanPropertiesPost
-func (_e *MockClient_Expecter) CreateLAN(ctx interface{}, datacenterID interface{}, properties interface{}) *MockClient_CreateLAN_Call {
-	return &MockClient_CreateLAN_Call{Call: _e.mock.On("CreateLAN", ctx, datacenterID, properties)}
+//   - properties ionoscloud.ApplicationLoadBalancerProperties
+//   - entities ionoscloud.ApplicationLoadBalancerEntities
+func (_e *MockClient_Expecter) CreateApplicationLoadBalancer(ctx interface{}, datacenterID interface{}, properties interface{}, entities interface{}) *MockClient_CreateApplicationLoadBalancer_Call {
+	return &MockClient_CreateApplicationLoadBalancer_Call{Call: _e.mock.On("CreateApplicationLoadBalancer", ctx, datacenterID, properties, entities)}
 }
 
-func (_c *MockClient_CreateLAN_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.LanPropertiesPost)) *MockClient_CreateLAN_Call {
+func (_c *MockClient_CreateApplicationLoadBalancer_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.ApplicationLoadBalancerProperties, entities ionoscloud.ApplicationLoadBalancerEntities)) *MockClient_CreateApplicationLoadBalancer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.LanPropertiesPost))
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.ApplicationLoadBalancerProperties), args[3].(ionoscloud.ApplicationLoadBalancerEntities))
 	})
 	return _c
 }
 
-func (_c *MockClient_CreateLAN_Call) Return(_a0 string, _a1 error) *MockClient_CreateLAN_Call {
+func (_c *MockClient_CreateApplicationLoadBalancer_Call) Return(_a0 string, _a1 error) *MockClient_CreateApplicationLoadBalancer_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_CreateLAN_Call) RunAndReturn(run func(context.Context, string, ionoscloud.LanPropertiesPost) (string, error)) *MockClient_CreateLAN_Call {
+func (_c *MockClient_CreateApplicationLoadBalancer_Call) RunAndReturn(run func(context.Context, string, ionoscloud.ApplicationLoadBalancerProperties, ionoscloud.ApplicationLoadBalancerEntities) (string, error)) *MockClient_CreateApplicationLoadBalancer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateServer provides a mock function with given fields: ctx, datacenterID, properties, entities
-func (_m *MockClient) CreateServer(ctx context.Context, datacenterID string, properties ionoscloud.ServerProperties, entities ionoscloud.ServerEntities) (*ionoscloud.Server, string, error) {
-	ret := _m.Called(ctx, datacenterID, properties, entities)
+// CreateCrossConnect provides a mock function with given fields: ctx, properties
+func (_m *MockClient) CreateCrossConnect(ctx context.Context, properties ionoscloud.PrivateCrossConnectProperties) (string, error) {
+	ret := _m.Called(ctx, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateServer")
+		panic("no return value specified for CreateCrossConnect")
 	}
 
-	var r0 *ionoscloud.Server
-	var r1 string
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) (*ionoscloud.Server, string, error)); ok {
-		return rf(ctx, datacenterID, properties, entities)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) *ionoscloud.Server); ok {
-		r0 = rf(ctx, datacenterID, properties, entities)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ionoscloud.Server)
-		}
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.PrivateCrossConnectProperties) (string, error)); ok {
+		return rf(ctx, properties)
 	}
-
-	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) string); ok {
-		r1 = rf(ctx, datacenterID, properties, entities)
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.PrivateCrossConnectProperties) string); ok {
+		r0 = rf(ctx, properties)
 	} else {
-		r1 = ret.Get(1).(string)
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) error); ok {
-		r2 = rf(ctx, datacenterID, properties, entities)
+	if rf, ok := ret.Get(1).(func(context.Context, ionoscloud.PrivateCrossConnectProperties) error); ok {
+		r1 = rf(ctx, properties)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockClient_CreateServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServer'
-type MockClient_CreateServer_Call struct {
+// MockClient_CreateCrossConnect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCrossConnect'
+type MockClient_CreateCrossConnect_Call struct {
 	*mock.Call
 }
 
-// CreateServer is a helper method to define mock.On call
+// CreateCrossConnect is a helper method to define mock.On call
 //   - ctx context.Context
-//   - datacenterID string
-//   - properties ionoscloud.ServerProperties
-//   - entities ionoscloud.ServerEntities
-func (_e *MockClient_Expecter) CreateServer(ctx interface{}, datacenterID interface{}, properties interface{}, entities interface{}) *MockClient_CreateServer_Call {
-	return &MockClient_CreateServer_Call{Call: _e.mock.On("CreateServer", ctx, datacenterID, properties, entities)}
+//   - properties ionoscloud.PrivateCrossConnectProperties
+func (_e *MockClient_Expecter) CreateCrossConnect(ctx interface{}, properties interface{}) *MockClient_CreateCrossConnect_Call {
+	return &MockClient_CreateCrossConnect_Call{Call: _e.mock.On("CreateCrossConnect", ctx, properties)}
 }
 
-func (_c *MockClient_CreateServer_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.ServerProperties, entities ionoscloud.ServerEntities)) *MockClient_CreateServer_Call {
+func (_c *MockClient_CreateCrossConnect_Call) Run(run func(ctx context.Context, properties ionoscloud.PrivateCrossConnectProperties)) *MockClient_CreateCrossConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.ServerProperties), args[3].(ionoscloud.ServerEntities))
+		run(args[0].(context.Context), args[1].(ionoscloud.PrivateCrossConnectProperties))
 	})
 	return _c
 }
 
-func (_c *MockClient_CreateServer_Call) Return(_a0 *ionoscloud.Server, _a1 string, _a2 error) *MockClient_CreateServer_Call {
-	_c.Call.Return(_a0, _a1, _a2)
+func (_c *MockClient_CreateCrossConnect_Call) Return(_a0 string, _a1 error) *MockClient_CreateCrossConnect_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_CreateServer_Call) RunAndReturn(run func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) (*ionoscloud.Server, string, error)) *MockClient_CreateServer_Call {
+func (_c *MockClient_CreateCrossConnect_Call) RunAndReturn(run func(context.Context, ionoscloud.PrivateCrossConnectProperties) (string, error)) *MockClient_CreateCrossConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteIPBlock provides a mock function with given fields: ctx, ipBlockID
-func (_m *MockClient) DeleteIPBlock(ctx context.Context, ipBlockID string) (string, error) {
-	ret := _m.Called(ctx, ipBlockID)
+// CreateDatacenter provides a mock function with given fields: ctx, properties
+func (_m *MockClient) CreateDatacenter(ctx context.Context, properties ionoscloud.DatacenterProperties) (string, error) {
+	ret := _m.Called(ctx, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteIPBlock")
+		panic("no return value specified for CreateDatacenter")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.DatacenterProperties) (string, error)); ok {
+		return rf(ctx, properties)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.DatacenterProperties) string); ok {
+		r0 = rf(ctx, properties)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(1).(func(context.Context, ionoscloud.DatacenterProperties) error); ok {
+		r1 = rf(ctx, properties)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -251,114 +241,105 @@ func (_m *MockClient) DeleteIPBlock(ctx context.Context, ipBlockID string) (stri
 	return r0, r1
 }
 
-// MockClient_DeleteIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIPBlock'
-type MockClient_DeleteIPBlock_Call struct {
+// MockClient_CreateDatacenter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDatacenter'
+type MockClient_CreateDatacenter_Call struct {
 	*mock.Call
 }
 
-// DeleteIPBlock is a helper method to define mock.On call
+// CreateDatacenter is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ipBlockID string
-func (_e *MockClient_Expecter) DeleteIPBlock(ctx interface{}, ipBlockID interface{}) *MockClient_DeleteIPBlock_Call {
-	return &MockClient_DeleteIPBlock_Call{Call: _e.mock.On("DeleteIPBlock", ctx, ipBlockID)}
+//   - properties ionoscloud.DatacenterProperties
+func (_e *MockClient_Expecter) CreateDatacenter(ctx interface{}, properties interface{}) *MockClient_CreateDatacenter_Call {
+	return &MockClient_CreateDatacenter_Call{Call: _e.mock.On("CreateDatacenter", ctx, properties)}
 }
 
-func (_c *MockClient_DeleteIPBlock_Call) Run(run func(ctx context.Context, ipBlockID string)) *MockClient_DeleteIPBlock_Call {
+func (_c *MockClient_CreateDatacenter_Call) Run(run func(ctx context.Context, properties ionoscloud.DatacenterProperties)) *MockClient_CreateDatacenter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(ionoscloud.DatacenterProperties))
 	})
 	return _c
 }
 
-func (_c *MockClient_DeleteIPBlock_Call) Return(requestPath string, err error) *MockClient_DeleteIPBlock_Call {
-	_c.Call.Return(requestPath, err)
+func (_c *MockClient_CreateDatacenter_Call) Return(_a0 string, _a1 error) *MockClient_CreateDatacenter_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_DeleteIPBlock_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteIPBlock_Call {
+func (_c *MockClient_CreateDatacenter_Call) RunAndReturn(run func(context.Context, ionoscloud.DatacenterProperties) (string, error)) *MockClient_CreateDatacenter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteLAN provides a mock function with given fields: ctx, datacenterID, lanID
-func (_m *MockClient) DeleteLAN(ctx context.Context, datacenterID string, lanID string) (string, error) {
-	ret := _m.Called(ctx, datacenterID, lanID)
+// CreateDatacenterLabel provides a mock function with given fields: ctx, datacenterID, key, value
+func (_m *MockClient) CreateDatacenterLabel(ctx context.Context, datacenterID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, key, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLAN")
-	}
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, datacenterID, lanID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, datacenterID, lanID)
-	} else {
-		r0 = ret.Get(0).(string)
+		panic("no return value specified for CreateDatacenterLabel")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, datacenterID, lanID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, key, value)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockClient_DeleteLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLAN'
-type MockClient_DeleteLAN_Call struct {
+// MockClient_CreateDatacenterLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDatacenterLabel'
+type MockClient_CreateDatacenterLabel_Call struct {
 	*mock.Call
 }
 
-// DeleteLAN is a helper method to define mock.On call
+// CreateDatacenterLabel is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - lanID string
-func (_e *MockClient_Expecter) DeleteLAN(ctx interface{}, datacenterID interface{}, lanID interface{}) *MockClient_DeleteLAN_Call {
-	return &MockClient_DeleteLAN_Call{Call: _e.mock.On("DeleteLAN", ctx, datacenterID, lanID)}
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) CreateDatacenterLabel(ctx interface{}, datacenterID interface{}, key interface{}, value interface{}) *MockClient_CreateDatacenterLabel_Call {
+	return &MockClient_CreateDatacenterLabel_Call{Call: _e.mock.On("CreateDatacenterLabel", ctx, datacenterID, key, value)}
 }
 
-func (_c *MockClient_DeleteLAN_Call) Run(run func(ctx context.Context, datacenterID string, lanID string)) *MockClient_DeleteLAN_Call {
+func (_c *MockClient_CreateDatacenterLabel_Call) Run(run func(ctx context.Context, datacenterID string, key string, value string)) *MockClient_CreateDatacenterLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_DeleteLAN_Call) Return(_a0 string, _a1 error) *MockClient_DeleteLAN_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreateDatacenterLabel_Call) Return(_a0 error) *MockClient_CreateDatacenterLabel_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockClient_DeleteLAN_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteLAN_Call {
+func (_c *MockClient_CreateDatacenterLabel_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockClient_CreateDatacenterLabel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteServer provides a mock function with given fields: ctx, datacenterID, serverID, deleteVolumes
-func (_m *MockClient) DeleteServer(ctx context.Context, datacenterID string, serverID string, deleteVolumes bool) (string, error) {
-	ret := _m.Called(ctx, datacenterID, serverID, deleteVolumes)
+// CreateFirewallRule provides a mock function with given fields: ctx, datacenterID, serverID, nicID, properties
+func (_m *MockClient) CreateFirewallRule(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.FirewallruleProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, nicID, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteServer")
+		panic("no return value specified for CreateFirewallRule")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (string, error)); ok {
-		return rf(ctx, datacenterID, serverID, deleteVolumes)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.FirewallruleProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, nicID, properties)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) string); ok {
-		r0 = rf(ctx, datacenterID, serverID, deleteVolumes)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.FirewallruleProperties) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, nicID, properties)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
-		r1 = rf(ctx, datacenterID, serverID, deleteVolumes)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ionoscloud.FirewallruleProperties) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, nicID, properties)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -366,58 +347,59 @@ func (_m *MockClient) DeleteServer(ctx context.Context, datacenterID string, ser
 	return r0, r1
 }
 
-// MockClient_DeleteServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServer'
-type MockClient_DeleteServer_Call struct {
+// MockClient_CreateFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFirewallRule'
+type MockClient_CreateFirewallRule_Call struct {
 	*mock.Call
 }
 
-// DeleteServer is a helper method to define mock.On call
+// CreateFirewallRule is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
 //   - serverID string
-//   - deleteVolumes bool
-func (_e *MockClient_Expecter) DeleteServer(ctx interface{}, datacenterID interface{}, serverID interface{}, deleteVolumes interface{}) *MockClient_DeleteServer_Call {
-	return &MockClient_DeleteServer_Call{Call: _e.mock.On("DeleteServer", ctx, datacenterID, serverID, deleteVolumes)}
+//   - nicID string
+//   - properties ionoscloud.FirewallruleProperties
+func (_e *MockClient_Expecter) CreateFirewallRule(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}, properties interface{}) *MockClient_CreateFirewallRule_Call {
+	return &MockClient_CreateFirewallRule_Call{Call: _e.mock.On("CreateFirewallRule", ctx, datacenterID, serverID, nicID, properties)}
 }
 
-func (_c *MockClient_DeleteServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, deleteVolumes bool)) *MockClient_DeleteServer_Call {
+func (_c *MockClient_CreateFirewallRule_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.FirewallruleProperties)) *MockClient_CreateFirewallRule_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(ionoscloud.FirewallruleProperties))
 	})
 	return _c
 }
 
-func (_c *MockClient_DeleteServer_Call) Return(_a0 string, _a1 error) *MockClient_DeleteServer_Call {
+func (_c *MockClient_CreateFirewallRule_Call) Return(_a0 string, _a1 error) *MockClient_CreateFirewallRule_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_DeleteServer_Call) RunAndReturn(run func(context.Context, string, string, bool) (string, error)) *MockClient_DeleteServer_Call {
+func (_c *MockClient_CreateFirewallRule_Call) RunAndReturn(run func(context.Context, string, string, string, ionoscloud.FirewallruleProperties) (string, error)) *MockClient_CreateFirewallRule_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteVolume provides a mock function with given fields: ctx, datacenterID, volumeID
-func (_m *MockClient) DeleteVolume(ctx context.Context, datacenterID string, volumeID string) (string, error) {
-	ret := _m.Called(ctx, datacenterID, volumeID)
+// CreateLAN provides a mock function with given fields: ctx, datacenterID, properties
+func (_m *MockClient) CreateLAN(ctx context.Context, datacenterID string, properties ionoscloud.LanPropertiesPost) (string, error) {
+	ret := _m.Called(ctx, datacenterID, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteVolume")
+		panic("no return value specified for CreateLAN")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, datacenterID, volumeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.LanPropertiesPost) (string, error)); ok {
+		return rf(ctx, datacenterID, properties)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, datacenterID, volumeID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.LanPropertiesPost) string); ok {
+		r0 = rf(ctx, datacenterID, properties)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, datacenterID, volumeID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.LanPropertiesPost) error); ok {
+		r1 = rf(ctx, datacenterID, properties)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -425,59 +407,57 @@ func (_m *MockClient) DeleteVolume(ctx context.Context, datacenterID string, vol
 	return r0, r1
 }
 
-// MockClient_DeleteVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVolume'
-type MockClient_DeleteVolume_Call struct {
+// MockClient_CreateLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLAN'
+type MockClient_CreateLAN_Call struct {
 	*mock.Call
 }
 
-// DeleteVolume is a helper method to define mock.On call
+// CreateLAN is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - volumeID string
-func (_e *MockClient_Expecter) DeleteVolume(ctx interface{}, datacenterID interface{}, volumeID interface{}) *MockClient_DeleteVolume_Call {
-	return &MockClient_DeleteVolume_Call{Call: _e.mock.On("DeleteVolume", ctx, datacenterID, volumeID)}
+//   - properties ionoscloud.LanPropertiesPost
+func (_e *MockClient_Expecter) CreateLAN(ctx interface{}, datacenterID interface{}, properties interface{}) *MockClient_CreateLAN_Call {
+	return &MockClient_CreateLAN_Call{Call: _e.mock.On("CreateLAN", ctx, datacenterID, properties)}
 }
 
-func (_c *MockClient_DeleteVolume_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string)) *MockClient_DeleteVolume_Call {
+func (_c *MockClient_CreateLAN_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.LanPropertiesPost)) *MockClient_CreateLAN_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.LanPropertiesPost))
 	})
 	return _c
 }
 
-func (_c *MockClient_DeleteVolume_Call) Return(_a0 string, _a1 error) *MockClient_DeleteVolume_Call {
+func (_c *MockClient_CreateLAN_Call) Return(_a0 string, _a1 error) *MockClient_CreateLAN_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_DeleteVolume_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteVolume_Call {
+func (_c *MockClient_CreateLAN_Call) RunAndReturn(run func(context.Context, string, ionoscloud.LanPropertiesPost) (string, error)) *MockClient_CreateLAN_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIPBlock provides a mock function with given fields: ctx, ipBlockID
-func (_m *MockClient) GetIPBlock(ctx context.Context, ipBlockID string) (*ionoscloud.IpBlock, error) {
-	ret := _m.Called(ctx, ipBlockID)
+// CreateNATGateway provides a mock function with given fields: ctx, datacenterID, properties, entities
+func (_m *MockClient) CreateNATGateway(ctx context.Context, datacenterID string, properties ionoscloud.NatGatewayProperties, entities ionoscloud.NatGatewayEntities) (string, error) {
+	ret := _m.Called(ctx, datacenterID, properties, entities)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetIPBlock")
+		panic("no return value specified for CreateNATGateway")
 	}
 
-	var r0 *ionoscloud.IpBlock
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.IpBlock, error)); ok {
-		return rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.NatGatewayProperties, ionoscloud.NatGatewayEntities) (string, error)); ok {
+		return rf(ctx, datacenterID, properties, entities)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.IpBlock); ok {
-		r0 = rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.NatGatewayProperties, ionoscloud.NatGatewayEntities) string); ok {
+		r0 = rf(ctx, datacenterID, properties, entities)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ionoscloud.IpBlock)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, ipBlockID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.NatGatewayProperties, ionoscloud.NatGatewayEntities) error); ok {
+		r1 = rf(ctx, datacenterID, properties, entities)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -485,58 +465,58 @@ func (_m *MockClient) GetIPBlock(ctx context.Context, ipBlockID string) (*ionosc
 	return r0, r1
 }
 
-// MockClient_GetIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIPBlock'
-type MockClient_GetIPBlock_Call struct {
+// MockClient_CreateNATGateway_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNATGateway'
+type MockClient_CreateNATGateway_Call struct {
 	*mock.Call
 }
 
-// GetIPBlock is a helper method to define mock.On call
+// CreateNATGateway is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ipBlockID string
-func (_e *MockClient_Expecter) GetIPBlock(ctx interface{}, ipBlockID interface{}) *MockClient_GetIPBlock_Call {
-	return &MockClient_GetIPBlock_Call{Call: _e.mock.On("GetIPBlock", ctx, ipBlockID)}
+//   - datacenterID string
+//   - properties ionoscloud.NatGatewayProperties
+//   - entities ionoscloud.NatGatewayEntities
+func (_e *MockClient_Expecter) CreateNATGateway(ctx interface{}, datacenterID interface{}, properties interface{}, entities interface{}) *MockClient_CreateNATGateway_Call {
+	return &MockClient_CreateNATGateway_Call{Call: _e.mock.On("CreateNATGateway", ctx, datacenterID, properties, entities)}
 }
 
-func (_c *MockClient_GetIPBlock_Call) Run(run func(ctx context.Context, ipBlockID string)) *MockClient_GetIPBlock_Call {
+func (_c *MockClient_CreateNATGateway_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.NatGatewayProperties, entities ionoscloud.NatGatewayEntities)) *MockClient_CreateNATGateway_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.NatGatewayProperties), args[3].(ionoscloud.NatGatewayEntities))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetIPBlock_Call) Return(_a0 *ionoscloud.IpBlock, _a1 error) *MockClient_GetIPBlock_Call {
+func (_c *MockClient_CreateNATGateway_Call) Return(_a0 string, _a1 error) *MockClient_CreateNATGateway_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetIPBlock_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.IpBlock, error)) *MockClient_GetIPBlock_Call {
+func (_c *MockClient_CreateNATGateway_Call) RunAndReturn(run func(context.Context, string, ionoscloud.NatGatewayProperties, ionoscloud.NatGatewayEntities) (string, error)) *MockClient_CreateNATGateway_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRequests provides a mock function with given fields: ctx, method, path
-func (_m *MockClient) GetRequests(ctx context.Context, method string, path string) ([]ionoscloud.Request, error) {
-	ret := _m.Called(ctx, method, path)
+// CreateNetworkLoadBalancer provides a mock function with given fields: ctx, datacenterID, properties, entities
+func (_m *MockClient) CreateNetworkLoadBalancer(ctx context.Context, datacenterID string, properties ionoscloud.NetworkLoadBalancerProperties, entities ionoscloud.NetworkLoadBalancerEntities) (string, error) {
+	ret := _m.Called(ctx, datacenterID, properties, entities)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRequests")
+		panic("no return value specified for CreateNetworkLoadBalancer")
 	}
 
-	var r0 []ionoscloud.Request
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]ionoscloud.Request, error)); ok {
-		return rf(ctx, method, path)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.NetworkLoadBalancerProperties, ionoscloud.NetworkLoadBalancerEntities) (string, error)); ok {
+		return rf(ctx, datacenterID, properties, entities)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []ionoscloud.Request); ok {
-		r0 = rf(ctx, method, path)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.NetworkLoadBalancerProperties, ionoscloud.NetworkLoadBalancerEntities) string); ok {
+		r0 = rf(ctx, datacenterID, properties, entities)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ionoscloud.Request)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, method, path)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.NetworkLoadBalancerProperties, ionoscloud.NetworkLoadBalancerEntities) error); ok {
+		r1 = rf(ctx, datacenterID, properties, entities)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -544,236 +524,244 @@ func (_m *MockClient) GetRequests(ctx context.Context, method string, path strin
 	return r0, r1
 }
 
-// MockClient_GetRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequests'
-type MockClient_GetRequests_Call struct {
+// MockClient_CreateNetworkLoadBalancer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNetworkLoadBalancer'
+type MockClient_CreateNetworkLoadBalancer_Call struct {
 	*mock.Call
 }
 
-// GetRequests is a helper method to define mock.On call
+// CreateNetworkLoadBalancer is a helper method to define mock.On call
 //   - ctx context.Context
-//   - method string
-//   - path string
-func (_e *MockClient_Expecter) GetRequests(ctx interface{}, method interface{}, path interface{}) *MockClient_GetRequests_Call {
-	return &MockClient_GetRequests_Call{Call: _e.mock.On("GetRequests", ctx, method, path)}
+//   - datacenterID string
+//   - properties ionoscloud.NetworkLoadBalancerProperties
+//   - entities ionoscloud.NetworkLoadBalancerEntities
+func (_e *MockClient_Expecter) CreateNetworkLoadBalancer(ctx interface{}, datacenterID interface{}, properties interface{}, entities interface{}) *MockClient_CreateNetworkLoadBalancer_Call {
+	return &MockClient_CreateNetworkLoadBalancer_Call{Call: _e.mock.On("CreateNetworkLoadBalancer", ctx, datacenterID, properties, entities)}
 }
 
-func (_c *MockClient_GetRequests_Call) Run(run func(ctx context.Context, method string, path string)) *MockClient_GetRequests_Call {
+func (_c *MockClient_CreateNetworkLoadBalancer_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.NetworkLoadBalancerProperties, entities ionoscloud.NetworkLoadBalancerEntities)) *MockClient_CreateNetworkLoadBalancer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.NetworkLoadBalancerProperties), args[3].(ionoscloud.NetworkLoadBalancerEntities))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetRequests_Call) Return(_a0 []ionoscloud.Request, _a1 error) *MockClient_GetRequests_Call {
+func (_c *MockClient_CreateNetworkLoadBalancer_Call) Return(_a0 string, _a1 error) *MockClient_CreateNetworkLoadBalancer_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetRequests_Call) RunAndReturn(run func(context.Context, string, string) ([]ionoscloud.Request, error)) *MockClient_GetRequests_Call {
+func (_c *MockClient_CreateNetworkLoadBalancer_Call) RunAndReturn(run func(context.Context, string, ionoscloud.NetworkLoadBalancerProperties, ionoscloud.NetworkLoadBalancerEntities) (string, error)) *MockClient_CreateNetworkLoadBalancer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetServer provides a mock function with given fields: ctx, datacenterID, serverID
-func (_m *MockClient) GetServer(ctx context.Context, datacenterID string, serverID string) (*ionoscloud.Server, error) {
-	ret := _m.Called(ctx, datacenterID, serverID)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetServer")
+// CreateServer provides a mock function with given fields: ctx, datacenterID, properties, entities
+func (_m *MockClient) CreateServer(ctx context.Context, datacenterID string, properties ionoscloud.ServerProperties, entities ionoscloud.ServerEntities) (*ionoscloud.Server, string, error) {
+	ret := _m.Called(ctx, datacenterID, properties, entities)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateServer")
 	}
 
 	var r0 *ionoscloud.Server
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ionoscloud.Server, error)); ok {
-		return rf(ctx, datacenterID, serverID)
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) (*ionoscloud.Server, string, error)); ok {
+		return rf(ctx, datacenterID, properties, entities)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ionoscloud.Server); ok {
-		r0 = rf(ctx, datacenterID, serverID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) *ionoscloud.Server); ok {
+		r0 = rf(ctx, datacenterID, properties, entities)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*ionoscloud.Server)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, datacenterID, serverID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) string); ok {
+		r1 = rf(ctx, datacenterID, properties, entities)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) error); ok {
+		r2 = rf(ctx, datacenterID, properties, entities)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// MockClient_GetServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServer'
-type MockClient_GetServer_Call struct {
+// MockClient_CreateServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServer'
+type MockClient_CreateServer_Call struct {
 	*mock.Call
 }
 
-// GetServer is a helper method to define mock.On call
+// CreateServer is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - serverID string
-func (_e *MockClient_Expecter) GetServer(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_GetServer_Call {
-	return &MockClient_GetServer_Call{Call: _e.mock.On("GetServer", ctx, datacenterID, serverID)}
+//   - properties ionoscloud.ServerProperties
+//   - entities ionoscloud.ServerEntities
+func (_e *MockClient_Expecter) CreateServer(ctx interface{}, datacenterID interface{}, properties interface{}, entities interface{}) *MockClient_CreateServer_Call {
+	return &MockClient_CreateServer_Call{Call: _e.mock.On("CreateServer", ctx, datacenterID, properties, entities)}
 }
 
-func (_c *MockClient_GetServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_GetServer_Call {
+func (_c *MockClient_CreateServer_Call) Run(run func(ctx context.Context, datacenterID string, properties ionoscloud.ServerProperties, entities ionoscloud.ServerEntities)) *MockClient_CreateServer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.ServerProperties), args[3].(ionoscloud.ServerEntities))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetServer_Call) Return(_a0 *ionoscloud.Server, _a1 error) *MockClient_GetServer_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreateServer_Call) Return(_a0 *ionoscloud.Server, _a1 string, _a2 error) *MockClient_CreateServer_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockClient_GetServer_Call) RunAndReturn(run func(context.Context, string, string) (*ionoscloud.Server, error)) *MockClient_GetServer_Call {
+func (_c *MockClient_CreateServer_Call) RunAndReturn(run func(context.Context, string, ionoscloud.ServerProperties, ionoscloud.ServerEntities) (*ionoscloud.Server, string, error)) *MockClient_CreateServer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListIPBlocks provides a mock function with given fields: ctx
-func (_m *MockClient) ListIPBlocks(ctx context.Context) (*ionoscloud.IpBlocks, error) {
-	ret := _m.Called(ctx)
+// CreateServerLabel provides a mock function with given fields: ctx, datacenterID, serverID, key, value
+func (_m *MockClient) CreateServerLabel(ctx context.Context, datacenterID string, serverID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, serverID, key, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListIPBlocks")
-	}
-
-	var r0 *ionoscloud.IpBlocks
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.IpBlocks, error)); ok {
-		return rf(ctx)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.IpBlocks); ok {
-		r0 = rf(ctx)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ionoscloud.IpBlocks)
-		}
+		panic("no return value specified for CreateServerLabel")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, serverID, key, value)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockClient_ListIPBlocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIPBlocks'
-type MockClient_ListIPBlocks_Call struct {
+// MockClient_CreateServerLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServerLabel'
+type MockClient_CreateServerLabel_Call struct {
 	*mock.Call
 }
 
-// ListIPBlocks is a helper method to define mock.On call
+// CreateServerLabel is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockClient_Expecter) ListIPBlocks(ctx interface{}) *MockClient_ListIPBlocks_Call {
-	return &MockClient_ListIPBlocks_Call{Call: _e.mock.On("ListIPBlocks", ctx)}
+//   - datacenterID string
+//   - serverID string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) CreateServerLabel(ctx interface{}, datacenterID interface{}, serverID interface{}, key interface{}, value interface{}) *MockClient_CreateServerLabel_Call {
+	return &MockClient_CreateServerLabel_Call{Call: _e.mock.On("CreateServerLabel", ctx, datacenterID, serverID, key, value)}
 }
 
-func (_c *MockClient_ListIPBlocks_Call) Run(run func(ctx context.Context)) *MockClient_ListIPBlocks_Call {
+func (_c *MockClient_CreateServerLabel_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, key string, value string)) *MockClient_CreateServerLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListIPBlocks_Call) Return(_a0 *ionoscloud.IpBlocks, _a1 error) *MockClient_ListIPBlocks_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreateServerLabel_Call) Return(_a0 error) *MockClient_CreateServerLabel_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockClient_ListIPBlocks_Call) RunAndReturn(run func(context.Context) (*ionoscloud.IpBlocks, error)) *MockClient_ListIPBlocks_Call {
+func (_c *MockClient_CreateServerLabel_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockClient_CreateServerLabel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListLANs provides a mock function with given fields: ctx, datacenterID
-func (_m *MockClient) ListLANs(ctx context.Context, datacenterID string) (*ionoscloud.Lans, error) {
-	ret := _m.Called(ctx, datacenterID)
+// CreateServerVolume provides a mock function with given fields: ctx, datacenterID, serverID, properties
+func (_m *MockClient) CreateServerVolume(ctx context.Context, datacenterID string, serverID string, properties ionoscloud.VolumeProperties) (*ionoscloud.Volume, string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLANs")
+		panic("no return value specified for CreateServerVolume")
 	}
 
-	var r0 *ionoscloud.Lans
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Lans, error)); ok {
-		return rf(ctx, datacenterID)
+	var r0 *ionoscloud.Volume
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.VolumeProperties) (*ionoscloud.Volume, string, error)); ok {
+		return rf(ctx, datacenterID, serverID, properties)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Lans); ok {
-		r0 = rf(ctx, datacenterID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.VolumeProperties) *ionoscloud.Volume); ok {
+		r0 = rf(ctx, datacenterID, serverID, properties)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ionoscloud.Lans)
+			r0 = ret.Get(0).(*ionoscloud.Volume)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, datacenterID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ionoscloud.VolumeProperties) string); ok {
+		r1 = rf(ctx, datacenterID, serverID, properties)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, ionoscloud.VolumeProperties) error); ok {
+		r2 = rf(ctx, datacenterID, serverID, properties)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// MockClient_ListLANs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLANs'
-type MockClient_ListLANs_Call struct {
+// MockClient_CreateServerVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateServerVolume'
+type MockClient_CreateServerVolume_Call struct {
 	*mock.Call
 }
 
-// ListLANs is a helper method to define mock.On call
+// CreateServerVolume is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-func (_e *MockClient_Expecter) ListLANs(ctx interface{}, datacenterID interface{}) *MockClient_ListLANs_Call {
-	return &MockClient_ListLANs_Call{Call: _e.mock.On("ListLANs", ctx, datacenterID)}
+//   - serverID string
+//   - properties ionoscloud.VolumeProperties
+func (_e *MockClient_Expecter) CreateServerVolume(ctx interface{}, datacenterID interface{}, serverID interface{}, properties interface{}) *MockClient_CreateServerVolume_Call {
+	return &MockClient_CreateServerVolume_Call{Call: _e.mock.On("CreateServerVolume", ctx, datacenterID, serverID, properties)}
 }
 
-func (_c *MockClient_ListLANs_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListLANs_Call {
+func (_c *MockClient_CreateServerVolume_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, properties ionoscloud.VolumeProperties)) *MockClient_CreateServerVolume_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(ionoscloud.VolumeProperties))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListLANs_Call) Return(_a0 *ionoscloud.Lans, _a1 error) *MockClient_ListLANs_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreateServerVolume_Call) Return(_a0 *ionoscloud.Volume, _a1 string, _a2 error) *MockClient_CreateServerVolume_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *MockClient_ListLANs_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Lans, error)) *MockClient_ListLANs_Call {
+func (_c *MockClient_CreateServerVolume_Call) RunAndReturn(run func(context.Context, string, string, ionoscloud.VolumeProperties) (*ionoscloud.Volume, string, error)) *MockClient_CreateServerVolume_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListServers provides a mock function with given fields: ctx, datacenterID
-func (_m *MockClient) ListServers(ctx context.Context, datacenterID string) (*ionoscloud.Servers, error) {
-	ret := _m.Called(ctx, datacenterID)
+// CreateTargetGroup provides a mock function with given fields: ctx, properties
+func (_m *MockClient) CreateTargetGroup(ctx context.Context, properties ionoscloud.TargetGroupProperties) (string, error) {
+	ret := _m.Called(ctx, properties)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListServers")
+		panic("no return value specified for CreateTargetGroup")
 	}
 
-	var r0 *ionoscloud.Servers
+	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Servers, error)); ok {
-		return rf(ctx, datacenterID)
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.TargetGroupProperties) (string, error)); ok {
+		return rf(ctx, properties)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Servers); ok {
-		r0 = rf(ctx, datacenterID)
+	if rf, ok := ret.Get(0).(func(context.Context, ionoscloud.TargetGroupProperties) string); ok {
+		r0 = rf(ctx, properties)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*ionoscloud.Servers)
-		}
+		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, datacenterID)
+	if rf, ok := ret.Get(1).(func(context.Context, ionoscloud.TargetGroupProperties) error); ok {
+		r1 = rf(ctx, properties)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -781,115 +769,106 @@ func (_m *MockClient) ListServers(ctx context.Context, datacenterID string) (*io
 	return r0, r1
 }
 
-// MockClient_ListServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServers'
-type MockClient_ListServers_Call struct {
+// MockClient_CreateTargetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTargetGroup'
+type MockClient_CreateTargetGroup_Call struct {
 	*mock.Call
 }
 
-// ListServers is a helper method to define mock.On call
+// CreateTargetGroup is a helper method to define mock.On call
 //   - ctx context.Context
-//   - datacenterID string
-func (_e *MockClient_Expecter) ListServers(ctx interface{}, datacenterID interface{}) *MockClient_ListServers_Call {
-	return &MockClient_ListServers_Call{Call: _e.mock.On("ListServers", ctx, datacenterID)}
+//   - properties ionoscloud.TargetGroupProperties
+func (_e *MockClient_Expecter) CreateTargetGroup(ctx interface{}, properties interface{}) *MockClient_CreateTargetGroup_Call {
+	return &MockClient_CreateTargetGroup_Call{Call: _e.mock.On("CreateTargetGroup", ctx, properties)}
 }
 
-func (_c *MockClient_ListServers_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListServers_Call {
+func (_c *MockClient_CreateTargetGroup_Call) Run(run func(ctx context.Context, properties ionoscloud.TargetGroupProperties)) *MockClient_CreateTargetGroup_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(ionoscloud.TargetGroupProperties))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListServers_Call) Return(_a0 *ionoscloud.Servers, _a1 error) *MockClient_ListServers_Call {
+func (_c *MockClient_CreateTargetGroup_Call) Return(_a0 string, _a1 error) *MockClient_CreateTargetGroup_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_ListServers_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Servers, error)) *MockClient_ListServers_Call {
+func (_c *MockClient_CreateTargetGroup_Call) RunAndReturn(run func(context.Context, ionoscloud.TargetGroupProperties) (string, error)) *MockClient_CreateTargetGroup_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PatchLAN provides a mock function with given fields: ctx, datacenterID, lanID, properties
-func (_m *MockClient) PatchLAN(ctx context.Context, datacenterID string, lanID string, properties ionoscloud.LanProperties) (string, error) {
-	ret := _m.Called(ctx, datacenterID, lanID, properties)
+// CreateVolumeLabel provides a mock function with given fields: ctx, datacenterID, volumeID, key, value
+func (_m *MockClient) CreateVolumeLabel(ctx context.Context, datacenterID string, volumeID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, volumeID, key, value)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PatchLAN")
-	}
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.LanProperties) (string, error)); ok {
-		return rf(ctx, datacenterID, lanID, properties)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.LanProperties) string); ok {
-		r0 = rf(ctx, datacenterID, lanID, properties)
-	} else {
-		r0 = ret.Get(0).(string)
+		panic("no return value specified for CreateVolumeLabel")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, ionoscloud.LanProperties) error); ok {
-		r1 = rf(ctx, datacenterID, lanID, properties)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, volumeID, key, value)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// MockClient_PatchLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchLAN'
-type MockClient_PatchLAN_Call struct {
+// MockClient_CreateVolumeLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateVolumeLabel'
+type MockClient_CreateVolumeLabel_Call struct {
 	*mock.Call
 }
 
-// PatchLAN is a helper method to define mock.On call
+// CreateVolumeLabel is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - lanID string
-//   - properties ionoscloud.LanProperties
-func (_e *MockClient_Expecter) PatchLAN(ctx interface{}, datacenterID interface{}, lanID interface{}, properties interface{}) *MockClient_PatchLAN_Call {
-	return &MockClient_PatchLAN_Call{Call: _e.mock.On("PatchLAN", ctx, datacenterID, lanID, properties)}
+//   - volumeID string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) CreateVolumeLabel(ctx interface{}, datacenterID interface{}, volumeID interface{}, key interface{}, value interface{}) *MockClient_CreateVolumeLabel_Call {
+	return &MockClient_CreateVolumeLabel_Call{Call: _e.mock.On("CreateVolumeLabel", ctx, datacenterID, volumeID, key, value)}
 }
 
-func (_c *MockClient_PatchLAN_Call) Run(run func(ctx context.Context, datacenterID string, lanID string, properties ionoscloud.LanProperties)) *MockClient_PatchLAN_Call {
+func (_c *MockClient_CreateVolumeLabel_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string, key string, value string)) *MockClient_CreateVolumeLabel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(ionoscloud.LanProperties))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_PatchLAN_Call) Return(_a0 string, _a1 error) *MockClient_PatchLAN_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *MockClient_CreateVolumeLabel_Call) Return(_a0 error) *MockClient_CreateVolumeLabel_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockClient_PatchLAN_Call) RunAndReturn(run func(context.Context, string, string, ionoscloud.LanProperties) (string, error)) *MockClient_PatchLAN_Call {
+func (_c *MockClient_CreateVolumeLabel_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockClient_CreateVolumeLabel_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PatchNIC provides a mock function with given fields: ctx, datacenterID, serverID, nicID, properties
-func (_m *MockClient) PatchNIC(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.NicProperties) (string, error) {
-	ret := _m.Called(ctx, datacenterID, serverID, nicID, properties)
+// DeleteApplicationLoadBalancer provides a mock function with given fields: ctx, datacenterID, loadBalancerID
+func (_m *MockClient) DeleteApplicationLoadBalancer(ctx context.Context, datacenterID string, loadBalancerID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, loadBalancerID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PatchNIC")
+		panic("no return value specified for DeleteApplicationLoadBalancer")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NicProperties) (string, error)); ok {
-		return rf(ctx, datacenterID, serverID, nicID, properties)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, loadBalancerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NicProperties) string); ok {
-		r0 = rf(ctx, datacenterID, serverID, nicID, properties)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, loadBalancerID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ionoscloud.NicProperties) error); ok {
-		r1 = rf(ctx, datacenterID, serverID, nicID, properties)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, loadBalancerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -897,59 +876,57 @@ func (_m *MockClient) PatchNIC(ctx context.Context, datacenterID string, serverI
 	return r0, r1
 }
 
-// MockClient_PatchNIC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchNIC'
-type MockClient_PatchNIC_Call struct {
+// MockClient_DeleteApplicationLoadBalancer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteApplicationLoadBalancer'
+type MockClient_DeleteApplicationLoadBalancer_Call struct {
 	*mock.Call
 }
 
-// PatchNIC is a helper method to define mock.On call
+// DeleteApplicationLoadBalancer is a helper method to define mock.On call
 //   - ctx context.Context
 //   - datacenterID string
-//   - serverID string
-//   - nicID string
-//   - properties ionoscloud.NicProperties
-func (_e *MockClient_Expecter) PatchNIC(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}, properties interface{}) *MockClient_PatchNIC_Call {
-	return &MockClient_PatchNIC_Call{Call: _e.mock.On("PatchNIC", ctx, datacenterID, serverID, nicID, properties)}
+//   - loadBalancerID string
+func (_e *MockClient_Expecter) DeleteApplicationLoadBalancer(ctx interface{}, datacenterID interface{}, loadBalancerID interface{}) *MockClient_DeleteApplicationLoadBalancer_Call {
+	return &MockClient_DeleteApplicationLoadBalancer_Call{Call: _e.mock.On("DeleteApplicationLoadBalancer", ctx, datacenterID, loadBalancerID)}
 }
 
-func (_c *MockClient_PatchNIC_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.NicProperties)) *MockClient_PatchNIC_Call {
+func (_c *MockClient_DeleteApplicationLoadBalancer_Call) Run(run func(ctx context.Context, datacenterID string, loadBalancerID string)) *MockClient_DeleteApplicationLoadBalancer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(ionoscloud.NicProperties))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_PatchNIC_Call) Return(_a0 string, _a1 error) *MockClient_PatchNIC_Call {
+func (_c *MockClient_DeleteApplicationLoadBalancer_Call) Return(_a0 string, _a1 error) *MockClient_DeleteApplicationLoadBalancer_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_PatchNIC_Call) RunAndReturn(run func(context.Context, string, string, string, ionoscloud.NicProperties) (string, error)) *MockClient_PatchNIC_Call {
+func (_c *MockClient_DeleteApplicationLoadBalancer_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteApplicationLoadBalancer_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReserveIPBlock provides a mock function with given fields: ctx, name, location, size
-func (_m *MockClient) ReserveIPBlock(ctx context.Context, name string, location string, size int32) (string, error) {
-	ret := _m.Called(ctx, name, location, size)
+// DeleteCrossConnect provides a mock function with given fields: ctx, crossConnectID
+func (_m *MockClient) DeleteCrossConnect(ctx context.Context, crossConnectID string) (string, error) {
+	ret := _m.Called(ctx, crossConnectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReserveIPBlock")
+		panic("no return value specified for DeleteCrossConnect")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) (string, error)); ok {
-		return rf(ctx, name, location, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, crossConnectID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) string); ok {
-		r0 = rf(ctx, name, location, size)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, crossConnectID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, int32) error); ok {
-		r1 = rf(ctx, name, location, size)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, crossConnectID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -957,52 +934,3016 @@ func (_m *MockClient) ReserveIPBlock(ctx context.Context, name string, location
 	return r0, r1
 }
 
-// MockClient_ReserveIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveIPBlock'
-type MockClient_ReserveIPBlock_Call struct {
+// MockClient_DeleteCrossConnect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCrossConnect'
+type MockClient_DeleteCrossConnect_Call struct {
 	*mock.Call
 }
 
-// ReserveIPBlock is a helper method to define mock.On call
+// DeleteCrossConnect is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-//   - location string
-//   - size int32
-func (_e *MockClient_Expecter) ReserveIPBlock(ctx interface{}, name interface{}, location interface{}, size interface{}) *MockClient_ReserveIPBlock_Call {
-	return &MockClient_ReserveIPBlock_Call{Call: _e.mock.On("ReserveIPBlock", ctx, name, location, size)}
+//   - crossConnectID string
+func (_e *MockClient_Expecter) DeleteCrossConnect(ctx interface{}, crossConnectID interface{}) *MockClient_DeleteCrossConnect_Call {
+	return &MockClient_DeleteCrossConnect_Call{Call: _e.mock.On("DeleteCrossConnect", ctx, crossConnectID)}
 }
 
-func (_c *MockClient_ReserveIPBlock_Call) Run(run func(ctx context.Context, name string, location string, size int32)) *MockClient_ReserveIPBlock_Call {
+func (_c *MockClient_DeleteCrossConnect_Call) Run(run func(ctx context.Context, crossConnectID string)) *MockClient_DeleteCrossConnect_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int32))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockClient_ReserveIPBlock_Call) Return(requestPath string, err error) *MockClient_ReserveIPBlock_Call {
-	_c.Call.Return(requestPath, err)
+func (_c *MockClient_DeleteCrossConnect_Call) Return(_a0 string, _a1 error) *MockClient_DeleteCrossConnect_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_ReserveIPBlock_Call) RunAndReturn(run func(context.Context, string, string, int32) (string, error)) *MockClient_ReserveIPBlock_Call {
+func (_c *MockClient_DeleteCrossConnect_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteCrossConnect_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StartServer provides a mock function with given fields: ctx, datacenterID, serverID
-func (_m *MockClient) StartServer(ctx context.Context, datacenterID string, serverID string) (string, error) {
-	ret := _m.Called(ctx, datacenterID, serverID)
+// DeleteDatacenter provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) DeleteDatacenter(ctx context.Context, datacenterID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StartServer")
+		panic("no return value specified for DeleteDatacenter")
 	}
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, datacenterID, serverID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, datacenterID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, datacenterID, serverID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteDatacenter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDatacenter'
+type MockClient_DeleteDatacenter_Call struct {
+	*mock.Call
+}
+
+// DeleteDatacenter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) DeleteDatacenter(ctx interface{}, datacenterID interface{}) *MockClient_DeleteDatacenter_Call {
+	return &MockClient_DeleteDatacenter_Call{Call: _e.mock.On("DeleteDatacenter", ctx, datacenterID)}
+}
+
+func (_c *MockClient_DeleteDatacenter_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_DeleteDatacenter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteDatacenter_Call) Return(_a0 string, _a1 error) *MockClient_DeleteDatacenter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteDatacenter_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteDatacenter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDatacenterLabel provides a mock function with given fields: ctx, datacenterID, key
+func (_m *MockClient) DeleteDatacenterLabel(ctx context.Context, datacenterID string, key string) error {
+	ret := _m.Called(ctx, datacenterID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDatacenterLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteDatacenterLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDatacenterLabel'
+type MockClient_DeleteDatacenterLabel_Call struct {
+	*mock.Call
+}
+
+// DeleteDatacenterLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - key string
+func (_e *MockClient_Expecter) DeleteDatacenterLabel(ctx interface{}, datacenterID interface{}, key interface{}) *MockClient_DeleteDatacenterLabel_Call {
+	return &MockClient_DeleteDatacenterLabel_Call{Call: _e.mock.On("DeleteDatacenterLabel", ctx, datacenterID, key)}
+}
+
+func (_c *MockClient_DeleteDatacenterLabel_Call) Run(run func(ctx context.Context, datacenterID string, key string)) *MockClient_DeleteDatacenterLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteDatacenterLabel_Call) Return(_a0 error) *MockClient_DeleteDatacenterLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteDatacenterLabel_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_DeleteDatacenterLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFirewallRule provides a mock function with given fields: ctx, datacenterID, serverID, nicID, ruleID
+func (_m *MockClient) DeleteFirewallRule(ctx context.Context, datacenterID string, serverID string, nicID string, ruleID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, nicID, ruleID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFirewallRule")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, nicID, ruleID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, nicID, ruleID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, nicID, ruleID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFirewallRule'
+type MockClient_DeleteFirewallRule_Call struct {
+	*mock.Call
+}
+
+// DeleteFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - nicID string
+//   - ruleID string
+func (_e *MockClient_Expecter) DeleteFirewallRule(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}, ruleID interface{}) *MockClient_DeleteFirewallRule_Call {
+	return &MockClient_DeleteFirewallRule_Call{Call: _e.mock.On("DeleteFirewallRule", ctx, datacenterID, serverID, nicID, ruleID)}
+}
+
+func (_c *MockClient_DeleteFirewallRule_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string, ruleID string)) *MockClient_DeleteFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallRule_Call) Return(_a0 string, _a1 error) *MockClient_DeleteFirewallRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteFirewallRule_Call) RunAndReturn(run func(context.Context, string, string, string, string) (string, error)) *MockClient_DeleteFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIPBlock provides a mock function with given fields: ctx, ipBlockID
+func (_m *MockClient) DeleteIPBlock(ctx context.Context, ipBlockID string) (string, error) {
+	ret := _m.Called(ctx, ipBlockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIPBlock")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, ipBlockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ipBlockID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ipBlockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIPBlock'
+type MockClient_DeleteIPBlock_Call struct {
+	*mock.Call
+}
+
+// DeleteIPBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ipBlockID string
+func (_e *MockClient_Expecter) DeleteIPBlock(ctx interface{}, ipBlockID interface{}) *MockClient_DeleteIPBlock_Call {
+	return &MockClient_DeleteIPBlock_Call{Call: _e.mock.On("DeleteIPBlock", ctx, ipBlockID)}
+}
+
+func (_c *MockClient_DeleteIPBlock_Call) Run(run func(ctx context.Context, ipBlockID string)) *MockClient_DeleteIPBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteIPBlock_Call) Return(requestPath string, err error) *MockClient_DeleteIPBlock_Call {
+	_c.Call.Return(requestPath, err)
+	return _c
+}
+
+func (_c *MockClient_DeleteIPBlock_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteIPBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteImage provides a mock function with given fields: ctx, imageID
+func (_m *MockClient) DeleteImage(ctx context.Context, imageID string) (string, error) {
+	ret := _m.Called(ctx, imageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteImage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, imageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, imageID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, imageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteImage'
+type MockClient_DeleteImage_Call struct {
+	*mock.Call
+}
+
+// DeleteImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imageID string
+func (_e *MockClient_Expecter) DeleteImage(ctx interface{}, imageID interface{}) *MockClient_DeleteImage_Call {
+	return &MockClient_DeleteImage_Call{Call: _e.mock.On("DeleteImage", ctx, imageID)}
+}
+
+func (_c *MockClient_DeleteImage_Call) Run(run func(ctx context.Context, imageID string)) *MockClient_DeleteImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteImage_Call) Return(_a0 string, _a1 error) *MockClient_DeleteImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteImage_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLAN provides a mock function with given fields: ctx, datacenterID, lanID
+func (_m *MockClient) DeleteLAN(ctx context.Context, datacenterID string, lanID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, lanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLAN")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, lanID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, lanID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, lanID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLAN'
+type MockClient_DeleteLAN_Call struct {
+	*mock.Call
+}
+
+// DeleteLAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - lanID string
+func (_e *MockClient_Expecter) DeleteLAN(ctx interface{}, datacenterID interface{}, lanID interface{}) *MockClient_DeleteLAN_Call {
+	return &MockClient_DeleteLAN_Call{Call: _e.mock.On("DeleteLAN", ctx, datacenterID, lanID)}
+}
+
+func (_c *MockClient_DeleteLAN_Call) Run(run func(ctx context.Context, datacenterID string, lanID string)) *MockClient_DeleteLAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteLAN_Call) Return(_a0 string, _a1 error) *MockClient_DeleteLAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteLAN_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteLAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNATGateway provides a mock function with given fields: ctx, datacenterID, natGatewayID
+func (_m *MockClient) DeleteNATGateway(ctx context.Context, datacenterID string, natGatewayID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, natGatewayID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNATGateway")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, natGatewayID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, natGatewayID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, natGatewayID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteNATGateway_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNATGateway'
+type MockClient_DeleteNATGateway_Call struct {
+	*mock.Call
+}
+
+// DeleteNATGateway is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - natGatewayID string
+func (_e *MockClient_Expecter) DeleteNATGateway(ctx interface{}, datacenterID interface{}, natGatewayID interface{}) *MockClient_DeleteNATGateway_Call {
+	return &MockClient_DeleteNATGateway_Call{Call: _e.mock.On("DeleteNATGateway", ctx, datacenterID, natGatewayID)}
+}
+
+func (_c *MockClient_DeleteNATGateway_Call) Run(run func(ctx context.Context, datacenterID string, natGatewayID string)) *MockClient_DeleteNATGateway_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteNATGateway_Call) Return(_a0 string, _a1 error) *MockClient_DeleteNATGateway_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteNATGateway_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteNATGateway_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNetworkLoadBalancer provides a mock function with given fields: ctx, datacenterID, loadBalancerID
+func (_m *MockClient) DeleteNetworkLoadBalancer(ctx context.Context, datacenterID string, loadBalancerID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, loadBalancerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNetworkLoadBalancer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, loadBalancerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, loadBalancerID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, loadBalancerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteNetworkLoadBalancer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNetworkLoadBalancer'
+type MockClient_DeleteNetworkLoadBalancer_Call struct {
+	*mock.Call
+}
+
+// DeleteNetworkLoadBalancer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - loadBalancerID string
+func (_e *MockClient_Expecter) DeleteNetworkLoadBalancer(ctx interface{}, datacenterID interface{}, loadBalancerID interface{}) *MockClient_DeleteNetworkLoadBalancer_Call {
+	return &MockClient_DeleteNetworkLoadBalancer_Call{Call: _e.mock.On("DeleteNetworkLoadBalancer", ctx, datacenterID, loadBalancerID)}
+}
+
+func (_c *MockClient_DeleteNetworkLoadBalancer_Call) Run(run func(ctx context.Context, datacenterID string, loadBalancerID string)) *MockClient_DeleteNetworkLoadBalancer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteNetworkLoadBalancer_Call) Return(_a0 string, _a1 error) *MockClient_DeleteNetworkLoadBalancer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteNetworkLoadBalancer_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteNetworkLoadBalancer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteServer provides a mock function with given fields: ctx, datacenterID, serverID, deleteVolumes
+func (_m *MockClient) DeleteServer(ctx context.Context, datacenterID string, serverID string, deleteVolumes bool) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, deleteVolumes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteServer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, deleteVolumes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, deleteVolumes)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, deleteVolumes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServer'
+type MockClient_DeleteServer_Call struct {
+	*mock.Call
+}
+
+// DeleteServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - deleteVolumes bool
+func (_e *MockClient_Expecter) DeleteServer(ctx interface{}, datacenterID interface{}, serverID interface{}, deleteVolumes interface{}) *MockClient_DeleteServer_Call {
+	return &MockClient_DeleteServer_Call{Call: _e.mock.On("DeleteServer", ctx, datacenterID, serverID, deleteVolumes)}
+}
+
+func (_c *MockClient_DeleteServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, deleteVolumes bool)) *MockClient_DeleteServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteServer_Call) Return(_a0 string, _a1 error) *MockClient_DeleteServer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteServer_Call) RunAndReturn(run func(context.Context, string, string, bool) (string, error)) *MockClient_DeleteServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteServerLabel provides a mock function with given fields: ctx, datacenterID, serverID, key
+func (_m *MockClient) DeleteServerLabel(ctx context.Context, datacenterID string, serverID string, key string) error {
+	ret := _m.Called(ctx, datacenterID, serverID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteServerLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, serverID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteServerLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteServerLabel'
+type MockClient_DeleteServerLabel_Call struct {
+	*mock.Call
+}
+
+// DeleteServerLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - key string
+func (_e *MockClient_Expecter) DeleteServerLabel(ctx interface{}, datacenterID interface{}, serverID interface{}, key interface{}) *MockClient_DeleteServerLabel_Call {
+	return &MockClient_DeleteServerLabel_Call{Call: _e.mock.On("DeleteServerLabel", ctx, datacenterID, serverID, key)}
+}
+
+func (_c *MockClient_DeleteServerLabel_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, key string)) *MockClient_DeleteServerLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteServerLabel_Call) Return(_a0 error) *MockClient_DeleteServerLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteServerLabel_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockClient_DeleteServerLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTargetGroup provides a mock function with given fields: ctx, targetGroupID
+func (_m *MockClient) DeleteTargetGroup(ctx context.Context, targetGroupID string) (string, error) {
+	ret := _m.Called(ctx, targetGroupID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTargetGroup")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, targetGroupID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, targetGroupID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, targetGroupID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteTargetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTargetGroup'
+type MockClient_DeleteTargetGroup_Call struct {
+	*mock.Call
+}
+
+// DeleteTargetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetGroupID string
+func (_e *MockClient_Expecter) DeleteTargetGroup(ctx interface{}, targetGroupID interface{}) *MockClient_DeleteTargetGroup_Call {
+	return &MockClient_DeleteTargetGroup_Call{Call: _e.mock.On("DeleteTargetGroup", ctx, targetGroupID)}
+}
+
+func (_c *MockClient_DeleteTargetGroup_Call) Run(run func(ctx context.Context, targetGroupID string)) *MockClient_DeleteTargetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteTargetGroup_Call) Return(_a0 string, _a1 error) *MockClient_DeleteTargetGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteTargetGroup_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockClient_DeleteTargetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVolume provides a mock function with given fields: ctx, datacenterID, volumeID
+func (_m *MockClient) DeleteVolume(ctx context.Context, datacenterID string, volumeID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, volumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVolume")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, volumeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, volumeID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, volumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DeleteVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVolume'
+type MockClient_DeleteVolume_Call struct {
+	*mock.Call
+}
+
+// DeleteVolume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - volumeID string
+func (_e *MockClient_Expecter) DeleteVolume(ctx interface{}, datacenterID interface{}, volumeID interface{}) *MockClient_DeleteVolume_Call {
+	return &MockClient_DeleteVolume_Call{Call: _e.mock.On("DeleteVolume", ctx, datacenterID, volumeID)}
+}
+
+func (_c *MockClient_DeleteVolume_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string)) *MockClient_DeleteVolume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteVolume_Call) Return(_a0 string, _a1 error) *MockClient_DeleteVolume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DeleteVolume_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_DeleteVolume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVolumeLabel provides a mock function with given fields: ctx, datacenterID, volumeID, key
+func (_m *MockClient) DeleteVolumeLabel(ctx context.Context, datacenterID string, volumeID string, key string) error {
+	ret := _m.Called(ctx, datacenterID, volumeID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVolumeLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, volumeID, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteVolumeLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVolumeLabel'
+type MockClient_DeleteVolumeLabel_Call struct {
+	*mock.Call
+}
+
+// DeleteVolumeLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - volumeID string
+//   - key string
+func (_e *MockClient_Expecter) DeleteVolumeLabel(ctx interface{}, datacenterID interface{}, volumeID interface{}, key interface{}) *MockClient_DeleteVolumeLabel_Call {
+	return &MockClient_DeleteVolumeLabel_Call{Call: _e.mock.On("DeleteVolumeLabel", ctx, datacenterID, volumeID, key)}
+}
+
+func (_c *MockClient_DeleteVolumeLabel_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string, key string)) *MockClient_DeleteVolumeLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteVolumeLabel_Call) Return(_a0 error) *MockClient_DeleteVolumeLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteVolumeLabel_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockClient_DeleteVolumeLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachCDROM provides a mock function with given fields: ctx, datacenterID, serverID, imageID
+func (_m *MockClient) DetachCDROM(ctx context.Context, datacenterID string, serverID string, imageID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, imageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetachCDROM")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, imageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, imageID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, imageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DetachCDROM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachCDROM'
+type MockClient_DetachCDROM_Call struct {
+	*mock.Call
+}
+
+// DetachCDROM is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - imageID string
+func (_e *MockClient_Expecter) DetachCDROM(ctx interface{}, datacenterID interface{}, serverID interface{}, imageID interface{}) *MockClient_DetachCDROM_Call {
+	return &MockClient_DetachCDROM_Call{Call: _e.mock.On("DetachCDROM", ctx, datacenterID, serverID, imageID)}
+}
+
+func (_c *MockClient_DetachCDROM_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, imageID string)) *MockClient_DetachCDROM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DetachCDROM_Call) Return(_a0 string, _a1 error) *MockClient_DetachCDROM_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DetachCDROM_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *MockClient_DetachCDROM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DetachVolume provides a mock function with given fields: ctx, datacenterID, serverID, volumeID
+func (_m *MockClient) DetachVolume(ctx context.Context, datacenterID string, serverID string, volumeID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, volumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DetachVolume")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, volumeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, volumeID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, volumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_DetachVolume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DetachVolume'
+type MockClient_DetachVolume_Call struct {
+	*mock.Call
+}
+
+// DetachVolume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - volumeID string
+func (_e *MockClient_Expecter) DetachVolume(ctx interface{}, datacenterID interface{}, serverID interface{}, volumeID interface{}) *MockClient_DetachVolume_Call {
+	return &MockClient_DetachVolume_Call{Call: _e.mock.On("DetachVolume", ctx, datacenterID, serverID, volumeID)}
+}
+
+func (_c *MockClient_DetachVolume_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, volumeID string)) *MockClient_DetachVolume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DetachVolume_Call) Return(_a0 string, _a1 error) *MockClient_DetachVolume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_DetachVolume_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *MockClient_DetachVolume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDatacenter provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) GetDatacenter(ctx context.Context, datacenterID string) (*ionoscloud.Datacenter, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDatacenter")
+	}
+
+	var r0 *ionoscloud.Datacenter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Datacenter, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Datacenter); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Datacenter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetDatacenter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDatacenter'
+type MockClient_GetDatacenter_Call struct {
+	*mock.Call
+}
+
+// GetDatacenter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) GetDatacenter(ctx interface{}, datacenterID interface{}) *MockClient_GetDatacenter_Call {
+	return &MockClient_GetDatacenter_Call{Call: _e.mock.On("GetDatacenter", ctx, datacenterID)}
+}
+
+func (_c *MockClient_GetDatacenter_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_GetDatacenter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetDatacenter_Call) Return(_a0 *ionoscloud.Datacenter, _a1 error) *MockClient_GetDatacenter_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetDatacenter_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Datacenter, error)) *MockClient_GetDatacenter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIPBlock provides a mock function with given fields: ctx, ipBlockID
+func (_m *MockClient) GetIPBlock(ctx context.Context, ipBlockID string) (*ionoscloud.IpBlock, error) {
+	ret := _m.Called(ctx, ipBlockID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIPBlock")
+	}
+
+	var r0 *ionoscloud.IpBlock
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.IpBlock, error)); ok {
+		return rf(ctx, ipBlockID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.IpBlock); ok {
+		r0 = rf(ctx, ipBlockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.IpBlock)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ipBlockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIPBlock'
+type MockClient_GetIPBlock_Call struct {
+	*mock.Call
+}
+
+// GetIPBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ipBlockID string
+func (_e *MockClient_Expecter) GetIPBlock(ctx interface{}, ipBlockID interface{}) *MockClient_GetIPBlock_Call {
+	return &MockClient_GetIPBlock_Call{Call: _e.mock.On("GetIPBlock", ctx, ipBlockID)}
+}
+
+func (_c *MockClient_GetIPBlock_Call) Run(run func(ctx context.Context, ipBlockID string)) *MockClient_GetIPBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetIPBlock_Call) Return(_a0 *ionoscloud.IpBlock, _a1 error) *MockClient_GetIPBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetIPBlock_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.IpBlock, error)) *MockClient_GetIPBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImage provides a mock function with given fields: ctx, imageID
+func (_m *MockClient) GetImage(ctx context.Context, imageID string) (*ionoscloud.Image, error) {
+	ret := _m.Called(ctx, imageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImage")
+	}
+
+	var r0 *ionoscloud.Image
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Image, error)); ok {
+		return rf(ctx, imageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Image); ok {
+		r0 = rf(ctx, imageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Image)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, imageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImage'
+type MockClient_GetImage_Call struct {
+	*mock.Call
+}
+
+// GetImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imageID string
+func (_e *MockClient_Expecter) GetImage(ctx interface{}, imageID interface{}) *MockClient_GetImage_Call {
+	return &MockClient_GetImage_Call{Call: _e.mock.On("GetImage", ctx, imageID)}
+}
+
+func (_c *MockClient_GetImage_Call) Run(run func(ctx context.Context, imageID string)) *MockClient_GetImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetImage_Call) Return(_a0 *ionoscloud.Image, _a1 error) *MockClient_GetImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetImage_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Image, error)) *MockClient_GetImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNetworkLoadBalancer provides a mock function with given fields: ctx, datacenterID, loadBalancerID
+func (_m *MockClient) GetNetworkLoadBalancer(ctx context.Context, datacenterID string, loadBalancerID string) (*ionoscloud.NetworkLoadBalancer, error) {
+	ret := _m.Called(ctx, datacenterID, loadBalancerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNetworkLoadBalancer")
+	}
+
+	var r0 *ionoscloud.NetworkLoadBalancer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ionoscloud.NetworkLoadBalancer, error)); ok {
+		return rf(ctx, datacenterID, loadBalancerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ionoscloud.NetworkLoadBalancer); ok {
+		r0 = rf(ctx, datacenterID, loadBalancerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.NetworkLoadBalancer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, loadBalancerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetNetworkLoadBalancer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNetworkLoadBalancer'
+type MockClient_GetNetworkLoadBalancer_Call struct {
+	*mock.Call
+}
+
+// GetNetworkLoadBalancer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - loadBalancerID string
+func (_e *MockClient_Expecter) GetNetworkLoadBalancer(ctx interface{}, datacenterID interface{}, loadBalancerID interface{}) *MockClient_GetNetworkLoadBalancer_Call {
+	return &MockClient_GetNetworkLoadBalancer_Call{Call: _e.mock.On("GetNetworkLoadBalancer", ctx, datacenterID, loadBalancerID)}
+}
+
+func (_c *MockClient_GetNetworkLoadBalancer_Call) Run(run func(ctx context.Context, datacenterID string, loadBalancerID string)) *MockClient_GetNetworkLoadBalancer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetNetworkLoadBalancer_Call) Return(_a0 *ionoscloud.NetworkLoadBalancer, _a1 error) *MockClient_GetNetworkLoadBalancer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetNetworkLoadBalancer_Call) RunAndReturn(run func(context.Context, string, string) (*ionoscloud.NetworkLoadBalancer, error)) *MockClient_GetNetworkLoadBalancer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRequests provides a mock function with given fields: ctx, method, path
+func (_m *MockClient) GetRequests(ctx context.Context, method string, path string) ([]ionoscloud.Request, error) {
+	ret := _m.Called(ctx, method, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequests")
+	}
+
+	var r0 []ionoscloud.Request
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]ionoscloud.Request, error)); ok {
+		return rf(ctx, method, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []ionoscloud.Request); ok {
+		r0 = rf(ctx, method, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ionoscloud.Request)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, method, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetRequests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequests'
+type MockClient_GetRequests_Call struct {
+	*mock.Call
+}
+
+// GetRequests is a helper method to define mock.On call
+//   - ctx context.Context
+//   - method string
+//   - path string
+func (_e *MockClient_Expecter) GetRequests(ctx interface{}, method interface{}, path interface{}) *MockClient_GetRequests_Call {
+	return &MockClient_GetRequests_Call{Call: _e.mock.On("GetRequests", ctx, method, path)}
+}
+
+func (_c *MockClient_GetRequests_Call) Run(run func(ctx context.Context, method string, path string)) *MockClient_GetRequests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetRequests_Call) Return(_a0 []ionoscloud.Request, _a1 error) *MockClient_GetRequests_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetRequests_Call) RunAndReturn(run func(context.Context, string, string) ([]ionoscloud.Request, error)) *MockClient_GetRequests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServer provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) GetServer(ctx context.Context, datacenterID string, serverID string) (*ionoscloud.Server, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServer")
+	}
+
+	var r0 *ionoscloud.Server
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ionoscloud.Server, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ionoscloud.Server); ok {
+		r0 = rf(ctx, datacenterID, serverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Server)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServer'
+type MockClient_GetServer_Call struct {
+	*mock.Call
+}
+
+// GetServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+func (_e *MockClient_Expecter) GetServer(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_GetServer_Call {
+	return &MockClient_GetServer_Call{Call: _e.mock.On("GetServer", ctx, datacenterID, serverID)}
+}
+
+func (_c *MockClient_GetServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_GetServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetServer_Call) Return(_a0 *ionoscloud.Server, _a1 error) *MockClient_GetServer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetServer_Call) RunAndReturn(run func(context.Context, string, string) (*ionoscloud.Server, error)) *MockClient_GetServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServerRemoteConsoleURL provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) GetServerRemoteConsoleURL(ctx context.Context, datacenterID string, serverID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServerRemoteConsoleURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetServerRemoteConsoleURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerRemoteConsoleURL'
+type MockClient_GetServerRemoteConsoleURL_Call struct {
+	*mock.Call
+}
+
+// GetServerRemoteConsoleURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+func (_e *MockClient_Expecter) GetServerRemoteConsoleURL(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_GetServerRemoteConsoleURL_Call {
+	return &MockClient_GetServerRemoteConsoleURL_Call{Call: _e.mock.On("GetServerRemoteConsoleURL", ctx, datacenterID, serverID)}
+}
+
+func (_c *MockClient_GetServerRemoteConsoleURL_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_GetServerRemoteConsoleURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetServerRemoteConsoleURL_Call) Return(_a0 string, _a1 error) *MockClient_GetServerRemoteConsoleURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetServerRemoteConsoleURL_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_GetServerRemoteConsoleURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSnapshot provides a mock function with given fields: ctx, snapshotID
+func (_m *MockClient) GetSnapshot(ctx context.Context, snapshotID string) (*ionoscloud.Snapshot, error) {
+	ret := _m.Called(ctx, snapshotID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSnapshot")
+	}
+
+	var r0 *ionoscloud.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Snapshot, error)); ok {
+		return rf(ctx, snapshotID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Snapshot); ok {
+		r0 = rf(ctx, snapshotID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, snapshotID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSnapshot'
+type MockClient_GetSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - snapshotID string
+func (_e *MockClient_Expecter) GetSnapshot(ctx interface{}, snapshotID interface{}) *MockClient_GetSnapshot_Call {
+	return &MockClient_GetSnapshot_Call{Call: _e.mock.On("GetSnapshot", ctx, snapshotID)}
+}
+
+func (_c *MockClient_GetSnapshot_Call) Run(run func(ctx context.Context, snapshotID string)) *MockClient_GetSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetSnapshot_Call) Return(_a0 *ionoscloud.Snapshot, _a1 error) *MockClient_GetSnapshot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetSnapshot_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Snapshot, error)) *MockClient_GetSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListApplicationLoadBalancers provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListApplicationLoadBalancers(ctx context.Context, datacenterID string) (*ionoscloud.ApplicationLoadBalancers, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListApplicationLoadBalancers")
+	}
+
+	var r0 *ionoscloud.ApplicationLoadBalancers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.ApplicationLoadBalancers, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.ApplicationLoadBalancers); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.ApplicationLoadBalancers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListApplicationLoadBalancers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListApplicationLoadBalancers'
+type MockClient_ListApplicationLoadBalancers_Call struct {
+	*mock.Call
+}
+
+// ListApplicationLoadBalancers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListApplicationLoadBalancers(ctx interface{}, datacenterID interface{}) *MockClient_ListApplicationLoadBalancers_Call {
+	return &MockClient_ListApplicationLoadBalancers_Call{Call: _e.mock.On("ListApplicationLoadBalancers", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListApplicationLoadBalancers_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListApplicationLoadBalancers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListApplicationLoadBalancers_Call) Return(_a0 *ionoscloud.ApplicationLoadBalancers, _a1 error) *MockClient_ListApplicationLoadBalancers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListApplicationLoadBalancers_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.ApplicationLoadBalancers, error)) *MockClient_ListApplicationLoadBalancers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListContracts provides a mock function with given fields: ctx
+func (_m *MockClient) ListContracts(ctx context.Context) (*ionoscloud.Contracts, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContracts")
+	}
+
+	var r0 *ionoscloud.Contracts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Contracts, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Contracts); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Contracts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListContracts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContracts'
+type MockClient_ListContracts_Call struct {
+	*mock.Call
+}
+
+// ListContracts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListContracts(ctx interface{}) *MockClient_ListContracts_Call {
+	return &MockClient_ListContracts_Call{Call: _e.mock.On("ListContracts", ctx)}
+}
+
+func (_c *MockClient_ListContracts_Call) Run(run func(ctx context.Context)) *MockClient_ListContracts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListContracts_Call) Return(_a0 *ionoscloud.Contracts, _a1 error) *MockClient_ListContracts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListContracts_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Contracts, error)) *MockClient_ListContracts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCrossConnects provides a mock function with given fields: ctx
+func (_m *MockClient) ListCrossConnects(ctx context.Context) (*ionoscloud.PrivateCrossConnects, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCrossConnects")
+	}
+
+	var r0 *ionoscloud.PrivateCrossConnects
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.PrivateCrossConnects, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.PrivateCrossConnects); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.PrivateCrossConnects)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListCrossConnects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCrossConnects'
+type MockClient_ListCrossConnects_Call struct {
+	*mock.Call
+}
+
+// ListCrossConnects is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListCrossConnects(ctx interface{}) *MockClient_ListCrossConnects_Call {
+	return &MockClient_ListCrossConnects_Call{Call: _e.mock.On("ListCrossConnects", ctx)}
+}
+
+func (_c *MockClient_ListCrossConnects_Call) Run(run func(ctx context.Context)) *MockClient_ListCrossConnects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListCrossConnects_Call) Return(_a0 *ionoscloud.PrivateCrossConnects, _a1 error) *MockClient_ListCrossConnects_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListCrossConnects_Call) RunAndReturn(run func(context.Context) (*ionoscloud.PrivateCrossConnects, error)) *MockClient_ListCrossConnects_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDatacenterLabels provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListDatacenterLabels(ctx context.Context, datacenterID string) (*ionoscloud.LabelResources, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDatacenterLabels")
+	}
+
+	var r0 *ionoscloud.LabelResources
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.LabelResources, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.LabelResources); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.LabelResources)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListDatacenterLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDatacenterLabels'
+type MockClient_ListDatacenterLabels_Call struct {
+	*mock.Call
+}
+
+// ListDatacenterLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListDatacenterLabels(ctx interface{}, datacenterID interface{}) *MockClient_ListDatacenterLabels_Call {
+	return &MockClient_ListDatacenterLabels_Call{Call: _e.mock.On("ListDatacenterLabels", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListDatacenterLabels_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListDatacenterLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListDatacenterLabels_Call) Return(_a0 *ionoscloud.LabelResources, _a1 error) *MockClient_ListDatacenterLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListDatacenterLabels_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.LabelResources, error)) *MockClient_ListDatacenterLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDatacenters provides a mock function with given fields: ctx
+func (_m *MockClient) ListDatacenters(ctx context.Context) (*ionoscloud.Datacenters, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDatacenters")
+	}
+
+	var r0 *ionoscloud.Datacenters
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Datacenters, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Datacenters); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Datacenters)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListDatacenters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDatacenters'
+type MockClient_ListDatacenters_Call struct {
+	*mock.Call
+}
+
+// ListDatacenters is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListDatacenters(ctx interface{}) *MockClient_ListDatacenters_Call {
+	return &MockClient_ListDatacenters_Call{Call: _e.mock.On("ListDatacenters", ctx)}
+}
+
+func (_c *MockClient_ListDatacenters_Call) Run(run func(ctx context.Context)) *MockClient_ListDatacenters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListDatacenters_Call) Return(_a0 *ionoscloud.Datacenters, _a1 error) *MockClient_ListDatacenters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListDatacenters_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Datacenters, error)) *MockClient_ListDatacenters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFirewallRules provides a mock function with given fields: ctx, datacenterID, serverID, nicID
+func (_m *MockClient) ListFirewallRules(ctx context.Context, datacenterID string, serverID string, nicID string) (*ionoscloud.FirewallRules, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, nicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFirewallRules")
+	}
+
+	var r0 *ionoscloud.FirewallRules
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*ionoscloud.FirewallRules, error)); ok {
+		return rf(ctx, datacenterID, serverID, nicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *ionoscloud.FirewallRules); ok {
+		r0 = rf(ctx, datacenterID, serverID, nicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.FirewallRules)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, nicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListFirewallRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFirewallRules'
+type MockClient_ListFirewallRules_Call struct {
+	*mock.Call
+}
+
+// ListFirewallRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - nicID string
+func (_e *MockClient_Expecter) ListFirewallRules(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}) *MockClient_ListFirewallRules_Call {
+	return &MockClient_ListFirewallRules_Call{Call: _e.mock.On("ListFirewallRules", ctx, datacenterID, serverID, nicID)}
+}
+
+func (_c *MockClient_ListFirewallRules_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string)) *MockClient_ListFirewallRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListFirewallRules_Call) Return(_a0 *ionoscloud.FirewallRules, _a1 error) *MockClient_ListFirewallRules_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListFirewallRules_Call) RunAndReturn(run func(context.Context, string, string, string) (*ionoscloud.FirewallRules, error)) *MockClient_ListFirewallRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIPBlocks provides a mock function with given fields: ctx
+func (_m *MockClient) ListIPBlocks(ctx context.Context) (*ionoscloud.IpBlocks, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIPBlocks")
+	}
+
+	var r0 *ionoscloud.IpBlocks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.IpBlocks, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.IpBlocks); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.IpBlocks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListIPBlocks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIPBlocks'
+type MockClient_ListIPBlocks_Call struct {
+	*mock.Call
+}
+
+// ListIPBlocks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListIPBlocks(ctx interface{}) *MockClient_ListIPBlocks_Call {
+	return &MockClient_ListIPBlocks_Call{Call: _e.mock.On("ListIPBlocks", ctx)}
+}
+
+func (_c *MockClient_ListIPBlocks_Call) Run(run func(ctx context.Context)) *MockClient_ListIPBlocks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListIPBlocks_Call) Return(_a0 *ionoscloud.IpBlocks, _a1 error) *MockClient_ListIPBlocks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListIPBlocks_Call) RunAndReturn(run func(context.Context) (*ionoscloud.IpBlocks, error)) *MockClient_ListIPBlocks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListImages provides a mock function with given fields: ctx
+func (_m *MockClient) ListImages(ctx context.Context) (*ionoscloud.Images, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListImages")
+	}
+
+	var r0 *ionoscloud.Images
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Images, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Images); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Images)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListImages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListImages'
+type MockClient_ListImages_Call struct {
+	*mock.Call
+}
+
+// ListImages is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListImages(ctx interface{}) *MockClient_ListImages_Call {
+	return &MockClient_ListImages_Call{Call: _e.mock.On("ListImages", ctx)}
+}
+
+func (_c *MockClient_ListImages_Call) Run(run func(ctx context.Context)) *MockClient_ListImages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListImages_Call) Return(_a0 *ionoscloud.Images, _a1 error) *MockClient_ListImages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListImages_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Images, error)) *MockClient_ListImages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLANs provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListLANs(ctx context.Context, datacenterID string) (*ionoscloud.Lans, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLANs")
+	}
+
+	var r0 *ionoscloud.Lans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Lans, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Lans); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Lans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListLANs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLANs'
+type MockClient_ListLANs_Call struct {
+	*mock.Call
+}
+
+// ListLANs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListLANs(ctx interface{}, datacenterID interface{}) *MockClient_ListLANs_Call {
+	return &MockClient_ListLANs_Call{Call: _e.mock.On("ListLANs", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListLANs_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListLANs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListLANs_Call) Return(_a0 *ionoscloud.Lans, _a1 error) *MockClient_ListLANs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListLANs_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Lans, error)) *MockClient_ListLANs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLabels provides a mock function with given fields: ctx
+func (_m *MockClient) ListLabels(ctx context.Context) (*ionoscloud.Labels, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLabels")
+	}
+
+	var r0 *ionoscloud.Labels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Labels, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Labels); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Labels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLabels'
+type MockClient_ListLabels_Call struct {
+	*mock.Call
+}
+
+// ListLabels is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListLabels(ctx interface{}) *MockClient_ListLabels_Call {
+	return &MockClient_ListLabels_Call{Call: _e.mock.On("ListLabels", ctx)}
+}
+
+func (_c *MockClient_ListLabels_Call) Run(run func(ctx context.Context)) *MockClient_ListLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListLabels_Call) Return(_a0 *ionoscloud.Labels, _a1 error) *MockClient_ListLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListLabels_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Labels, error)) *MockClient_ListLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNATGateways provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListNATGateways(ctx context.Context, datacenterID string) (*ionoscloud.NatGateways, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNATGateways")
+	}
+
+	var r0 *ionoscloud.NatGateways
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.NatGateways, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.NatGateways); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.NatGateways)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListNATGateways_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNATGateways'
+type MockClient_ListNATGateways_Call struct {
+	*mock.Call
+}
+
+// ListNATGateways is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListNATGateways(ctx interface{}, datacenterID interface{}) *MockClient_ListNATGateways_Call {
+	return &MockClient_ListNATGateways_Call{Call: _e.mock.On("ListNATGateways", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListNATGateways_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListNATGateways_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListNATGateways_Call) Return(_a0 *ionoscloud.NatGateways, _a1 error) *MockClient_ListNATGateways_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListNATGateways_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.NatGateways, error)) *MockClient_ListNATGateways_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNetworkLoadBalancers provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListNetworkLoadBalancers(ctx context.Context, datacenterID string) (*ionoscloud.NetworkLoadBalancers, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNetworkLoadBalancers")
+	}
+
+	var r0 *ionoscloud.NetworkLoadBalancers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.NetworkLoadBalancers, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.NetworkLoadBalancers); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.NetworkLoadBalancers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListNetworkLoadBalancers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNetworkLoadBalancers'
+type MockClient_ListNetworkLoadBalancers_Call struct {
+	*mock.Call
+}
+
+// ListNetworkLoadBalancers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListNetworkLoadBalancers(ctx interface{}, datacenterID interface{}) *MockClient_ListNetworkLoadBalancers_Call {
+	return &MockClient_ListNetworkLoadBalancers_Call{Call: _e.mock.On("ListNetworkLoadBalancers", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListNetworkLoadBalancers_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListNetworkLoadBalancers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListNetworkLoadBalancers_Call) Return(_a0 *ionoscloud.NetworkLoadBalancers, _a1 error) *MockClient_ListNetworkLoadBalancers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListNetworkLoadBalancers_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.NetworkLoadBalancers, error)) *MockClient_ListNetworkLoadBalancers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServerLabels provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) ListServerLabels(ctx context.Context, datacenterID string, serverID string) (*ionoscloud.LabelResources, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServerLabels")
+	}
+
+	var r0 *ionoscloud.LabelResources
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ionoscloud.LabelResources, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ionoscloud.LabelResources); ok {
+		r0 = rf(ctx, datacenterID, serverID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.LabelResources)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListServerLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServerLabels'
+type MockClient_ListServerLabels_Call struct {
+	*mock.Call
+}
+
+// ListServerLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+func (_e *MockClient_Expecter) ListServerLabels(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_ListServerLabels_Call {
+	return &MockClient_ListServerLabels_Call{Call: _e.mock.On("ListServerLabels", ctx, datacenterID, serverID)}
+}
+
+func (_c *MockClient_ListServerLabels_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_ListServerLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListServerLabels_Call) Return(_a0 *ionoscloud.LabelResources, _a1 error) *MockClient_ListServerLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListServerLabels_Call) RunAndReturn(run func(context.Context, string, string) (*ionoscloud.LabelResources, error)) *MockClient_ListServerLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListServers provides a mock function with given fields: ctx, datacenterID
+func (_m *MockClient) ListServers(ctx context.Context, datacenterID string) (*ionoscloud.Servers, error) {
+	ret := _m.Called(ctx, datacenterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListServers")
+	}
+
+	var r0 *ionoscloud.Servers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ionoscloud.Servers, error)); ok {
+		return rf(ctx, datacenterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ionoscloud.Servers); ok {
+		r0 = rf(ctx, datacenterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Servers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, datacenterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListServers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListServers'
+type MockClient_ListServers_Call struct {
+	*mock.Call
+}
+
+// ListServers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+func (_e *MockClient_Expecter) ListServers(ctx interface{}, datacenterID interface{}) *MockClient_ListServers_Call {
+	return &MockClient_ListServers_Call{Call: _e.mock.On("ListServers", ctx, datacenterID)}
+}
+
+func (_c *MockClient_ListServers_Call) Run(run func(ctx context.Context, datacenterID string)) *MockClient_ListServers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListServers_Call) Return(_a0 *ionoscloud.Servers, _a1 error) *MockClient_ListServers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListServers_Call) RunAndReturn(run func(context.Context, string) (*ionoscloud.Servers, error)) *MockClient_ListServers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSnapshots provides a mock function with given fields: ctx
+func (_m *MockClient) ListSnapshots(ctx context.Context) (*ionoscloud.Snapshots, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSnapshots")
+	}
+
+	var r0 *ionoscloud.Snapshots
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Snapshots, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Snapshots); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Snapshots)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSnapshots'
+type MockClient_ListSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListSnapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListSnapshots(ctx interface{}) *MockClient_ListSnapshots_Call {
+	return &MockClient_ListSnapshots_Call{Call: _e.mock.On("ListSnapshots", ctx)}
+}
+
+func (_c *MockClient_ListSnapshots_Call) Run(run func(ctx context.Context)) *MockClient_ListSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListSnapshots_Call) Return(_a0 *ionoscloud.Snapshots, _a1 error) *MockClient_ListSnapshots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListSnapshots_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Snapshots, error)) *MockClient_ListSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTargetGroups provides a mock function with given fields: ctx
+func (_m *MockClient) ListTargetGroups(ctx context.Context) (*ionoscloud.TargetGroups, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTargetGroups")
+	}
+
+	var r0 *ionoscloud.TargetGroups
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.TargetGroups, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.TargetGroups); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.TargetGroups)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListTargetGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTargetGroups'
+type MockClient_ListTargetGroups_Call struct {
+	*mock.Call
+}
+
+// ListTargetGroups is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListTargetGroups(ctx interface{}) *MockClient_ListTargetGroups_Call {
+	return &MockClient_ListTargetGroups_Call{Call: _e.mock.On("ListTargetGroups", ctx)}
+}
+
+func (_c *MockClient_ListTargetGroups_Call) Run(run func(ctx context.Context)) *MockClient_ListTargetGroups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListTargetGroups_Call) Return(_a0 *ionoscloud.TargetGroups, _a1 error) *MockClient_ListTargetGroups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListTargetGroups_Call) RunAndReturn(run func(context.Context) (*ionoscloud.TargetGroups, error)) *MockClient_ListTargetGroups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplates provides a mock function with given fields: ctx
+func (_m *MockClient) ListTemplates(ctx context.Context) (*ionoscloud.Templates, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 *ionoscloud.Templates
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*ionoscloud.Templates, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *ionoscloud.Templates); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.Templates)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
+type MockClient_ListTemplates_Call struct {
+	*mock.Call
+}
+
+// ListTemplates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListTemplates(ctx interface{}) *MockClient_ListTemplates_Call {
+	return &MockClient_ListTemplates_Call{Call: _e.mock.On("ListTemplates", ctx)}
+}
+
+func (_c *MockClient_ListTemplates_Call) Run(run func(ctx context.Context)) *MockClient_ListTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListTemplates_Call) Return(_a0 *ionoscloud.Templates, _a1 error) *MockClient_ListTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListTemplates_Call) RunAndReturn(run func(context.Context) (*ionoscloud.Templates, error)) *MockClient_ListTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVolumeLabels provides a mock function with given fields: ctx, datacenterID, volumeID
+func (_m *MockClient) ListVolumeLabels(ctx context.Context, datacenterID string, volumeID string) (*ionoscloud.LabelResources, error) {
+	ret := _m.Called(ctx, datacenterID, volumeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVolumeLabels")
+	}
+
+	var r0 *ionoscloud.LabelResources
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*ionoscloud.LabelResources, error)); ok {
+		return rf(ctx, datacenterID, volumeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *ionoscloud.LabelResources); ok {
+		r0 = rf(ctx, datacenterID, volumeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ionoscloud.LabelResources)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, volumeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListVolumeLabels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVolumeLabels'
+type MockClient_ListVolumeLabels_Call struct {
+	*mock.Call
+}
+
+// ListVolumeLabels is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - volumeID string
+func (_e *MockClient_Expecter) ListVolumeLabels(ctx interface{}, datacenterID interface{}, volumeID interface{}) *MockClient_ListVolumeLabels_Call {
+	return &MockClient_ListVolumeLabels_Call{Call: _e.mock.On("ListVolumeLabels", ctx, datacenterID, volumeID)}
+}
+
+func (_c *MockClient_ListVolumeLabels_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string)) *MockClient_ListVolumeLabels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListVolumeLabels_Call) Return(_a0 *ionoscloud.LabelResources, _a1 error) *MockClient_ListVolumeLabels_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListVolumeLabels_Call) RunAndReturn(run func(context.Context, string, string) (*ionoscloud.LabelResources, error)) *MockClient_ListVolumeLabels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchApplicationLoadBalancerForwardingRule provides a mock function with given fields: ctx, datacenterID, loadBalancerID, ruleID, properties
+func (_m *MockClient) PatchApplicationLoadBalancerForwardingRule(ctx context.Context, datacenterID string, loadBalancerID string, ruleID string, properties ionoscloud.ApplicationLoadBalancerForwardingRuleProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, loadBalancerID, ruleID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchApplicationLoadBalancerForwardingRule")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.ApplicationLoadBalancerForwardingRuleProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.ApplicationLoadBalancerForwardingRuleProperties) string); ok {
+		r0 = rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ionoscloud.ApplicationLoadBalancerForwardingRuleProperties) error); ok {
+		r1 = rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchApplicationLoadBalancerForwardingRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchApplicationLoadBalancerForwardingRule'
+type MockClient_PatchApplicationLoadBalancerForwardingRule_Call struct {
+	*mock.Call
+}
+
+// PatchApplicationLoadBalancerForwardingRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - loadBalancerID string
+//   - ruleID string
+//   - properties ionoscloud.ApplicationLoadBalancerForwardingRuleProperties
+func (_e *MockClient_Expecter) PatchApplicationLoadBalancerForwardingRule(ctx interface{}, datacenterID interface{}, loadBalancerID interface{}, ruleID interface{}, properties interface{}) *MockClient_PatchApplicationLoadBalancerForwardingRule_Call {
+	return &MockClient_PatchApplicationLoadBalancerForwardingRule_Call{Call: _e.mock.On("PatchApplicationLoadBalancerForwardingRule", ctx, datacenterID, loadBalancerID, ruleID, properties)}
+}
+
+func (_c *MockClient_PatchApplicationLoadBalancerForwardingRule_Call) Run(run func(ctx context.Context, datacenterID string, loadBalancerID string, ruleID string, properties ionoscloud.ApplicationLoadBalancerForwardingRuleProperties)) *MockClient_PatchApplicationLoadBalancerForwardingRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(ionoscloud.ApplicationLoadBalancerForwardingRuleProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchApplicationLoadBalancerForwardingRule_Call) Return(_a0 string, _a1 error) *MockClient_PatchApplicationLoadBalancerForwardingRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchApplicationLoadBalancerForwardingRule_Call) RunAndReturn(run func(context.Context, string, string, string, ionoscloud.ApplicationLoadBalancerForwardingRuleProperties) (string, error)) *MockClient_PatchApplicationLoadBalancerForwardingRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchFirewallRule provides a mock function with given fields: ctx, datacenterID, serverID, nicID, ruleID, properties
+func (_m *MockClient) PatchFirewallRule(ctx context.Context, datacenterID string, serverID string, nicID string, ruleID string, properties ionoscloud.FirewallruleProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, nicID, ruleID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchFirewallRule")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, ionoscloud.FirewallruleProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, nicID, ruleID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, ionoscloud.FirewallruleProperties) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, nicID, ruleID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, ionoscloud.FirewallruleProperties) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, nicID, ruleID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchFirewallRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchFirewallRule'
+type MockClient_PatchFirewallRule_Call struct {
+	*mock.Call
+}
+
+// PatchFirewallRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - nicID string
+//   - ruleID string
+//   - properties ionoscloud.FirewallruleProperties
+func (_e *MockClient_Expecter) PatchFirewallRule(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}, ruleID interface{}, properties interface{}) *MockClient_PatchFirewallRule_Call {
+	return &MockClient_PatchFirewallRule_Call{Call: _e.mock.On("PatchFirewallRule", ctx, datacenterID, serverID, nicID, ruleID, properties)}
+}
+
+func (_c *MockClient_PatchFirewallRule_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string, ruleID string, properties ionoscloud.FirewallruleProperties)) *MockClient_PatchFirewallRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(ionoscloud.FirewallruleProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchFirewallRule_Call) Return(_a0 string, _a1 error) *MockClient_PatchFirewallRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchFirewallRule_Call) RunAndReturn(run func(context.Context, string, string, string, string, ionoscloud.FirewallruleProperties) (string, error)) *MockClient_PatchFirewallRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchImage provides a mock function with given fields: ctx, imageID, properties
+func (_m *MockClient) PatchImage(ctx context.Context, imageID string, properties ionoscloud.ImageProperties) (string, error) {
+	ret := _m.Called(ctx, imageID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchImage")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ImageProperties) (string, error)); ok {
+		return rf(ctx, imageID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.ImageProperties) string); ok {
+		r0 = rf(ctx, imageID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.ImageProperties) error); ok {
+		r1 = rf(ctx, imageID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchImage'
+type MockClient_PatchImage_Call struct {
+	*mock.Call
+}
+
+// PatchImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - imageID string
+//   - properties ionoscloud.ImageProperties
+func (_e *MockClient_Expecter) PatchImage(ctx interface{}, imageID interface{}, properties interface{}) *MockClient_PatchImage_Call {
+	return &MockClient_PatchImage_Call{Call: _e.mock.On("PatchImage", ctx, imageID, properties)}
+}
+
+func (_c *MockClient_PatchImage_Call) Run(run func(ctx context.Context, imageID string, properties ionoscloud.ImageProperties)) *MockClient_PatchImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.ImageProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchImage_Call) Return(_a0 string, _a1 error) *MockClient_PatchImage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchImage_Call) RunAndReturn(run func(context.Context, string, ionoscloud.ImageProperties) (string, error)) *MockClient_PatchImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchLAN provides a mock function with given fields: ctx, datacenterID, lanID, properties
+func (_m *MockClient) PatchLAN(ctx context.Context, datacenterID string, lanID string, properties ionoscloud.LanProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, lanID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchLAN")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.LanProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, lanID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.LanProperties) string); ok {
+		r0 = rf(ctx, datacenterID, lanID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ionoscloud.LanProperties) error); ok {
+		r1 = rf(ctx, datacenterID, lanID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchLAN_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchLAN'
+type MockClient_PatchLAN_Call struct {
+	*mock.Call
+}
+
+// PatchLAN is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - lanID string
+//   - properties ionoscloud.LanProperties
+func (_e *MockClient_Expecter) PatchLAN(ctx interface{}, datacenterID interface{}, lanID interface{}, properties interface{}) *MockClient_PatchLAN_Call {
+	return &MockClient_PatchLAN_Call{Call: _e.mock.On("PatchLAN", ctx, datacenterID, lanID, properties)}
+}
+
+func (_c *MockClient_PatchLAN_Call) Run(run func(ctx context.Context, datacenterID string, lanID string, properties ionoscloud.LanProperties)) *MockClient_PatchLAN_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(ionoscloud.LanProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchLAN_Call) Return(_a0 string, _a1 error) *MockClient_PatchLAN_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchLAN_Call) RunAndReturn(run func(context.Context, string, string, ionoscloud.LanProperties) (string, error)) *MockClient_PatchLAN_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchNIC provides a mock function with given fields: ctx, datacenterID, serverID, nicID, properties
+func (_m *MockClient) PatchNIC(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.NicProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, nicID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchNIC")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NicProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, nicID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NicProperties) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, nicID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ionoscloud.NicProperties) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, nicID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchNIC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchNIC'
+type MockClient_PatchNIC_Call struct {
+	*mock.Call
+}
+
+// PatchNIC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - nicID string
+//   - properties ionoscloud.NicProperties
+func (_e *MockClient_Expecter) PatchNIC(ctx interface{}, datacenterID interface{}, serverID interface{}, nicID interface{}, properties interface{}) *MockClient_PatchNIC_Call {
+	return &MockClient_PatchNIC_Call{Call: _e.mock.On("PatchNIC", ctx, datacenterID, serverID, nicID, properties)}
+}
+
+func (_c *MockClient_PatchNIC_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, nicID string, properties ionoscloud.NicProperties)) *MockClient_PatchNIC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(ionoscloud.NicProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchNIC_Call) Return(_a0 string, _a1 error) *MockClient_PatchNIC_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchNIC_Call) RunAndReturn(run func(context.Context, string, string, string, ionoscloud.NicProperties) (string, error)) *MockClient_PatchNIC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchNetworkLoadBalancerForwardingRule provides a mock function with given fields: ctx, datacenterID, loadBalancerID, ruleID, properties
+func (_m *MockClient) PatchNetworkLoadBalancerForwardingRule(ctx context.Context, datacenterID string, loadBalancerID string, ruleID string, properties ionoscloud.NetworkLoadBalancerForwardingRuleProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, loadBalancerID, ruleID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchNetworkLoadBalancerForwardingRule")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NetworkLoadBalancerForwardingRuleProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ionoscloud.NetworkLoadBalancerForwardingRuleProperties) string); ok {
+		r0 = rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ionoscloud.NetworkLoadBalancerForwardingRuleProperties) error); ok {
+		r1 = rf(ctx, datacenterID, loadBalancerID, ruleID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchNetworkLoadBalancerForwardingRule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchNetworkLoadBalancerForwardingRule'
+type MockClient_PatchNetworkLoadBalancerForwardingRule_Call struct {
+	*mock.Call
+}
+
+// PatchNetworkLoadBalancerForwardingRule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - loadBalancerID string
+//   - ruleID string
+//   - properties ionoscloud.NetworkLoadBalancerForwardingRuleProperties
+func (_e *MockClient_Expecter) PatchNetworkLoadBalancerForwardingRule(ctx interface{}, datacenterID interface{}, loadBalancerID interface{}, ruleID interface{}, properties interface{}) *MockClient_PatchNetworkLoadBalancerForwardingRule_Call {
+	return &MockClient_PatchNetworkLoadBalancerForwardingRule_Call{Call: _e.mock.On("PatchNetworkLoadBalancerForwardingRule", ctx, datacenterID, loadBalancerID, ruleID, properties)}
+}
+
+func (_c *MockClient_PatchNetworkLoadBalancerForwardingRule_Call) Run(run func(ctx context.Context, datacenterID string, loadBalancerID string, ruleID string, properties ionoscloud.NetworkLoadBalancerForwardingRuleProperties)) *MockClient_PatchNetworkLoadBalancerForwardingRule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(ionoscloud.NetworkLoadBalancerForwardingRuleProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchNetworkLoadBalancerForwardingRule_Call) Return(_a0 string, _a1 error) *MockClient_PatchNetworkLoadBalancerForwardingRule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchNetworkLoadBalancerForwardingRule_Call) RunAndReturn(run func(context.Context, string, string, string, ionoscloud.NetworkLoadBalancerForwardingRuleProperties) (string, error)) *MockClient_PatchNetworkLoadBalancerForwardingRule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchServer provides a mock function with given fields: ctx, datacenterID, serverID, properties
+func (_m *MockClient) PatchServer(ctx context.Context, datacenterID string, serverID string, properties ionoscloud.ServerProperties) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchServer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.ServerProperties) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ionoscloud.ServerProperties) string); ok {
+		r0 = rf(ctx, datacenterID, serverID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ionoscloud.ServerProperties) error); ok {
+		r1 = rf(ctx, datacenterID, serverID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchServer'
+type MockClient_PatchServer_Call struct {
+	*mock.Call
+}
+
+// PatchServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - properties ionoscloud.ServerProperties
+func (_e *MockClient_Expecter) PatchServer(ctx interface{}, datacenterID interface{}, serverID interface{}, properties interface{}) *MockClient_PatchServer_Call {
+	return &MockClient_PatchServer_Call{Call: _e.mock.On("PatchServer", ctx, datacenterID, serverID, properties)}
+}
+
+func (_c *MockClient_PatchServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, properties ionoscloud.ServerProperties)) *MockClient_PatchServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(ionoscloud.ServerProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchServer_Call) Return(_a0 string, _a1 error) *MockClient_PatchServer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchServer_Call) RunAndReturn(run func(context.Context, string, string, ionoscloud.ServerProperties) (string, error)) *MockClient_PatchServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchTargetGroup provides a mock function with given fields: ctx, targetGroupID, properties
+func (_m *MockClient) PatchTargetGroup(ctx context.Context, targetGroupID string, properties ionoscloud.TargetGroupProperties) (string, error) {
+	ret := _m.Called(ctx, targetGroupID, properties)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTargetGroup")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.TargetGroupProperties) (string, error)); ok {
+		return rf(ctx, targetGroupID, properties)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ionoscloud.TargetGroupProperties) string); ok {
+		r0 = rf(ctx, targetGroupID, properties)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ionoscloud.TargetGroupProperties) error); ok {
+		r1 = rf(ctx, targetGroupID, properties)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_PatchTargetGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchTargetGroup'
+type MockClient_PatchTargetGroup_Call struct {
+	*mock.Call
+}
+
+// PatchTargetGroup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - targetGroupID string
+//   - properties ionoscloud.TargetGroupProperties
+func (_e *MockClient_Expecter) PatchTargetGroup(ctx interface{}, targetGroupID interface{}, properties interface{}) *MockClient_PatchTargetGroup_Call {
+	return &MockClient_PatchTargetGroup_Call{Call: _e.mock.On("PatchTargetGroup", ctx, targetGroupID, properties)}
+}
+
+func (_c *MockClient_PatchTargetGroup_Call) Run(run func(ctx context.Context, targetGroupID string, properties ionoscloud.TargetGroupProperties)) *MockClient_PatchTargetGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(ionoscloud.TargetGroupProperties))
+	})
+	return _c
+}
+
+func (_c *MockClient_PatchTargetGroup_Call) Return(_a0 string, _a1 error) *MockClient_PatchTargetGroup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_PatchTargetGroup_Call) RunAndReturn(run func(context.Context, string, ionoscloud.TargetGroupProperties) (string, error)) *MockClient_PatchTargetGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RebootServer provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) RebootServer(ctx context.Context, datacenterID string, serverID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RebootServer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_RebootServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RebootServer'
+type MockClient_RebootServer_Call struct {
+	*mock.Call
+}
+
+// RebootServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+func (_e *MockClient_Expecter) RebootServer(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_RebootServer_Call {
+	return &MockClient_RebootServer_Call{Call: _e.mock.On("RebootServer", ctx, datacenterID, serverID)}
+}
+
+func (_c *MockClient_RebootServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_RebootServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_RebootServer_Call) Return(_a0 string, _a1 error) *MockClient_RebootServer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_RebootServer_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_RebootServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveIPBlock provides a mock function with given fields: ctx, name, location, size
+func (_m *MockClient) ReserveIPBlock(ctx context.Context, name string, location string, size int32) (string, error) {
+	ret := _m.Called(ctx, name, location, size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveIPBlock")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) (string, error)); ok {
+		return rf(ctx, name, location, size)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int32) string); ok {
+		r0 = rf(ctx, name, location, size)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int32) error); ok {
+		r1 = rf(ctx, name, location, size)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ReserveIPBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveIPBlock'
+type MockClient_ReserveIPBlock_Call struct {
+	*mock.Call
+}
+
+// ReserveIPBlock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - location string
+//   - size int32
+func (_e *MockClient_Expecter) ReserveIPBlock(ctx interface{}, name interface{}, location interface{}, size interface{}) *MockClient_ReserveIPBlock_Call {
+	return &MockClient_ReserveIPBlock_Call{Call: _e.mock.On("ReserveIPBlock", ctx, name, location, size)}
+}
+
+func (_c *MockClient_ReserveIPBlock_Call) Run(run func(ctx context.Context, name string, location string, size int32)) *MockClient_ReserveIPBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockClient_ReserveIPBlock_Call) Return(requestPath string, err error) *MockClient_ReserveIPBlock_Call {
+	_c.Call.Return(requestPath, err)
+	return _c
+}
+
+func (_c *MockClient_ReserveIPBlock_Call) RunAndReturn(run func(context.Context, string, string, int32) (string, error)) *MockClient_ReserveIPBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartServer provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) StartServer(ctx context.Context, datacenterID string, serverID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartServer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -1046,6 +3987,262 @@ func (_c *MockClient_StartServer_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// StopServer provides a mock function with given fields: ctx, datacenterID, serverID
+func (_m *MockClient) StopServer(ctx context.Context, datacenterID string, serverID string) (string, error) {
+	ret := _m.Called(ctx, datacenterID, serverID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopServer")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, datacenterID, serverID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, datacenterID, serverID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, datacenterID, serverID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_StopServer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopServer'
+type MockClient_StopServer_Call struct {
+	*mock.Call
+}
+
+// StopServer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+func (_e *MockClient_Expecter) StopServer(ctx interface{}, datacenterID interface{}, serverID interface{}) *MockClient_StopServer_Call {
+	return &MockClient_StopServer_Call{Call: _e.mock.On("StopServer", ctx, datacenterID, serverID)}
+}
+
+func (_c *MockClient_StopServer_Call) Run(run func(ctx context.Context, datacenterID string, serverID string)) *MockClient_StopServer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_StopServer_Call) Return(_a0 string, _a1 error) *MockClient_StopServer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_StopServer_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockClient_StopServer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateDatacenterLabel provides a mock function with given fields: ctx, datacenterID, key, value
+func (_m *MockClient) UpdateDatacenterLabel(ctx context.Context, datacenterID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDatacenterLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateDatacenterLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDatacenterLabel'
+type MockClient_UpdateDatacenterLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateDatacenterLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) UpdateDatacenterLabel(ctx interface{}, datacenterID interface{}, key interface{}, value interface{}) *MockClient_UpdateDatacenterLabel_Call {
+	return &MockClient_UpdateDatacenterLabel_Call{Call: _e.mock.On("UpdateDatacenterLabel", ctx, datacenterID, key, value)}
+}
+
+func (_c *MockClient_UpdateDatacenterLabel_Call) Run(run func(ctx context.Context, datacenterID string, key string, value string)) *MockClient_UpdateDatacenterLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateDatacenterLabel_Call) Return(_a0 error) *MockClient_UpdateDatacenterLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateDatacenterLabel_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockClient_UpdateDatacenterLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateServerLabel provides a mock function with given fields: ctx, datacenterID, serverID, key, value
+func (_m *MockClient) UpdateServerLabel(ctx context.Context, datacenterID string, serverID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, serverID, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateServerLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, serverID, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateServerLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateServerLabel'
+type MockClient_UpdateServerLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateServerLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - serverID string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) UpdateServerLabel(ctx interface{}, datacenterID interface{}, serverID interface{}, key interface{}, value interface{}) *MockClient_UpdateServerLabel_Call {
+	return &MockClient_UpdateServerLabel_Call{Call: _e.mock.On("UpdateServerLabel", ctx, datacenterID, serverID, key, value)}
+}
+
+func (_c *MockClient_UpdateServerLabel_Call) Run(run func(ctx context.Context, datacenterID string, serverID string, key string, value string)) *MockClient_UpdateServerLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateServerLabel_Call) Return(_a0 error) *MockClient_UpdateServerLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateServerLabel_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockClient_UpdateServerLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVolumeLabel provides a mock function with given fields: ctx, datacenterID, volumeID, key, value
+func (_m *MockClient) UpdateVolumeLabel(ctx context.Context, datacenterID string, volumeID string, key string, value string) error {
+	ret := _m.Called(ctx, datacenterID, volumeID, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVolumeLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, datacenterID, volumeID, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateVolumeLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVolumeLabel'
+type MockClient_UpdateVolumeLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateVolumeLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - datacenterID string
+//   - volumeID string
+//   - key string
+//   - value string
+func (_e *MockClient_Expecter) UpdateVolumeLabel(ctx interface{}, datacenterID interface{}, volumeID interface{}, key interface{}, value interface{}) *MockClient_UpdateVolumeLabel_Call {
+	return &MockClient_UpdateVolumeLabel_Call{Call: _e.mock.On("UpdateVolumeLabel", ctx, datacenterID, volumeID, key, value)}
+}
+
+func (_c *MockClient_UpdateVolumeLabel_Call) Run(run func(ctx context.Context, datacenterID string, volumeID string, key string, value string)) *MockClient_UpdateVolumeLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateVolumeLabel_Call) Return(_a0 error) *MockClient_UpdateVolumeLabel_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateVolumeLabel_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockClient_UpdateVolumeLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UploadImage provides a mock function with given fields: ctx, location, name, data
+func (_m *MockClient) UploadImage(ctx context.Context, location string, name string, data []byte) error {
+	ret := _m.Called(ctx, location, name, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, location, name, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UploadImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UploadImage'
+type MockClient_UploadImage_Call struct {
+	*mock.Call
+}
+
+// UploadImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - location string
+//   - name string
+//   - data []byte
+func (_e *MockClient_Expecter) UploadImage(ctx interface{}, location interface{}, name interface{}, data interface{}) *MockClient_UploadImage_Call {
+	return &MockClient_UploadImage_Call{Call: _e.mock.On("UploadImage", ctx, location, name, data)}
+}
+
+func (_c *MockClient_UploadImage_Call) Run(run func(ctx context.Context, location string, name string, data []byte)) *MockClient_UploadImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockClient_UploadImage_Call) Return(_a0 error) *MockClient_UploadImage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UploadImage_Call) RunAndReturn(run func(context.Context, string, string, []byte) error) *MockClient_UploadImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WaitForRequest provides a mock function with given fields: ctx, requestURL
 func (_m *MockClient) WaitForRequest(ctx context.Context, requestURL string) error {
 	ret := _m.Called(ctx, requestURL)
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ionoserrors classifies the errors returned by the IONOS Cloud API, so that reconcilers can decide
+// whether a failed request is retried or fails a resource permanently.
+package ionoserrors
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+)
+
+// Kind is the class of an error returned by the Cloud API.
+type Kind string
+
+const (
+	// KindUnknown is the kind of all errors, which are not returned by the Cloud API, e.g. network errors.
+	KindUnknown Kind = "Unknown"
+	// KindNotFound indicates that the requested resource does not exist.
+	KindNotFound Kind = "NotFound"
+	// KindConflict indicates that the resource is locked or was modified concurrently.
+	KindConflict Kind = "Conflict"
+	// KindRateLimited indicates that the Cloud API throttled the request.
+	KindRateLimited Kind = "RateLimited"
+	// KindQuotaExceeded indicates that the request exceeds the resource limits of the contract.
+	KindQuotaExceeded Kind = "QuotaExceeded"
+	// KindUnauthorized indicates that the credentials are invalid or lack the required privileges.
+	KindUnauthorized Kind = "Unauthorized"
+	// KindInvalid indicates that the Cloud API rejected the request because of its content.
+	KindInvalid Kind = "Invalid"
+	// KindUnavailable indicates that the Cloud API is temporarily unavailable.
+	KindUnavailable Kind = "Unavailable"
+)
+
+// quotaExceededPatterns are parts of the messages, with which the Cloud API rejects requests
+// exceeding the resource limits of the contract. They are compared case-insensitively.
+var quotaExceededPatterns = []string{"resource limit", "limit exceeded", "exceeds the limit", "quota"}
+
+// KindOf returns the kind of err, which may wrap an error of the Cloud API.
+func KindOf(err error) Kind {
+	var apiErr sdk.GenericOpenAPIError
+	if !errors.As(err, &apiErr) {
+		return KindUnknown
+	}
+
+	switch code := apiErr.StatusCode(); {
+	case code == http.StatusNotFound:
+		return KindNotFound
+	case code == http.StatusConflict || code == http.StatusPreconditionFailed:
+		return KindConflict
+	case code == http.StatusTooManyRequests:
+		return KindRateLimited
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		if isQuotaExceeded(apiErr) {
+			return KindQuotaExceeded
+		}
+		return KindUnauthorized
+	case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+		if isQuotaExceeded(apiErr) {
+			return KindQuotaExceeded
+		}
+		return KindInvalid
+	case code >= http.StatusInternalServerError:
+		return KindUnavailable
+	default:
+		return KindUnknown
+	}
+}
+
+// IsNotFound returns true if err indicates that the requested resource does not exist.
+func IsNotFound(err error) bool {
+	return KindOf(err) == KindNotFound
+}
+
+// IsConflict returns true if err indicates that the resource is locked or was modified concurrently.
+func IsConflict(err error) bool {
+	return KindOf(err) == KindConflict
+}
+
+// IsRateLimited returns true if err indicates that the Cloud API throttled the request.
+func IsRateLimited(err error) bool {
+	return KindOf(err) == KindRateLimited
+}
+
+// IsQuotaExceeded returns true if err indicates that the request exceeds the resource limits of the contract.
+func IsQuotaExceeded(err error) bool {
+	return KindOf(err) == KindQuotaExceeded
+}
+
+// IsUnauthorized returns true if err indicates that the credentials are invalid or lack the required privileges.
+func IsUnauthorized(err error) bool {
+	return KindOf(err) == KindUnauthorized
+}
+
+// IsRetryable returns true if repeating the failed request is expected to succeed without any changes,
+// e.g. because the Cloud API was throttling or unavailable. Errors, which are not returned by the
+// Cloud API, are considered retryable.
+func IsRetryable(err error) bool {
+	switch KindOf(err) {
+	case KindConflict, KindRateLimited, KindUnavailable, KindUnknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminal returns true if the Cloud API rejected the request because of its content. Repeating
+// the request fails the same way, so the resource, which the request was derived from, needs to be fixed.
+func IsTerminal(err error) bool {
+	return KindOf(err) == KindInvalid
+}
+
+// Message returns the messages of the Cloud API error wrapped by err. If the Cloud API didn't return
+// any messages, the message of the error itself is returned.
+func Message(err error) string {
+	var apiErr sdk.GenericOpenAPIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	if messages := apiMessages(apiErr); len(messages) > 0 {
+		return strings.Join(messages, "; ")
+	}
+	return apiErr.Error()
+}
+
+func isQuotaExceeded(apiErr sdk.GenericOpenAPIError) bool {
+	for _, message := range apiMessages(apiErr) {
+		message = strings.ToLower(message)
+		for _, pattern := range quotaExceededPatterns {
+			if strings.Contains(message, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// apiMessages returns the messages of the error model of the Cloud API.
+func apiMessages(apiErr sdk.GenericOpenAPIError) []string {
+	var model sdk.Error
+	switch m := apiErr.Model().(type) {
+	case sdk.Error:
+		model = m
+	case *sdk.Error:
+		if m == nil {
+			return nil
+		}
+		model = *m
+	default:
+		return nil
+	}
+
+	if model.Messages == nil {
+		return nil
+	}
+	messages := make([]string, 0, len(*model.Messages))
+	for _, message := range *model.Messages {
+		if message.Message != nil && *message.Message != "" {
+			messages = append(messages, *message.Message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ionoserrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func apiError(statusCode int, messages ...string) error {
+	model := sdk.Error{HttpStatus: ptr.To(int32(statusCode)), Messages: &[]sdk.ErrorMessage{}}
+	for _, message := range messages {
+		*model.Messages = append(*model.Messages, sdk.ErrorMessage{Message: ptr.To(message)})
+	}
+	return sdk.NewGenericOpenAPIError(http.StatusText(statusCode), nil, model, statusCode)
+}
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		kind      Kind
+		retryable bool
+		terminal  bool
+	}{{
+		name:      "no API error",
+		err:       errors.New("connection reset by peer"),
+		kind:      KindUnknown,
+		retryable: true,
+	}, {
+		name: "not found",
+		err:  apiError(http.StatusNotFound),
+		kind: KindNotFound,
+	}, {
+		name:      "wrapped conflict",
+		err:       fmt.Errorf("failed to patch server: %w", apiError(http.StatusConflict)),
+		kind:      KindConflict,
+		retryable: true,
+	}, {
+		name:      "rate limited",
+		err:       apiError(http.StatusTooManyRequests),
+		kind:      KindRateLimited,
+		retryable: true,
+	}, {
+		name: "quota exceeded",
+		err:  apiError(http.StatusUnprocessableEntity, "[VDC-1-1] Resource limit exceeded: cores per contract"),
+		kind: KindQuotaExceeded,
+	}, {
+		name: "unauthorized",
+		err:  apiError(http.StatusUnauthorized),
+		kind: KindUnauthorized,
+	}, {
+		name:     "invalid request",
+		err:      apiError(http.StatusUnprocessableEntity, "[VDC-5-6] The requested image does not exist"),
+		kind:     KindInvalid,
+		terminal: true,
+	}, {
+		name:      "unavailable",
+		err:       apiError(http.StatusServiceUnavailable),
+		kind:      KindUnavailable,
+		retryable: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.kind, KindOf(tt.err))
+			require.Equal(t, tt.retryable, IsRetryable(tt.err))
+			require.Equal(t, tt.terminal, IsTerminal(tt.err))
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	require.Equal(t, "first; second", Message(apiError(http.StatusBadRequest, "first", "second")))
+	require.Equal(t, "Not Found", Message(sdk.NewGenericOpenAPIError("Not Found", nil, nil, http.StatusNotFound)))
+	require.Equal(t, "plain", Message(errors.New("plain")))
+}
@@ -0,0 +1,857 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// listApplicationLoadBalancersDepth is the depth needed for getting the forwarding rules of each load balancer.
+	listApplicationLoadBalancersDepth = 3
+
+	albForwardingRuleName     = "http"
+	albForwardingRuleProtocol = "HTTP"
+	albHTTPRuleType           = "FORWARD"
+
+	albHostConditionType       = "HOST"
+	albHostConditionComparison = "EQUALS"
+	albPathConditionType       = "PATH"
+	albPathConditionComparison = "STARTS_WITH"
+
+	// defaultApplicationLoadBalancerPort is used if no port was configured.
+	defaultApplicationLoadBalancerPort int32 = 80
+
+	targetGroupAlgorithm          = "ROUND_ROBIN"
+	targetGroupProtocol           = "HTTP"
+	targetGroupTargetWeight int32 = 1
+)
+
+// applicationLoadBalancerName returns the name of the Application Load Balancer of the cluster.
+func (*Service) applicationLoadBalancerName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("alb-%s-%s", c.Namespace, c.Name)
+}
+
+// applicationLoadBalancerIPBlockName returns the name of the IP block, which provides the public IP
+// of the Application Load Balancer.
+func (*Service) applicationLoadBalancerIPBlockName(cs *scope.Cluster) string {
+	return fmt.Sprintf("alb-ipb-%s-%s", cs.Cluster.Namespace, cs.Cluster.Name)
+}
+
+// applicationLoadBalancerListenerLANName returns the name of the public LAN, on which the Application Load Balancer
+// accepts traffic.
+func (*Service) applicationLoadBalancerListenerLANName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("lan-alb-listener-%s-%s", c.Namespace, c.Name)
+}
+
+// applicationLoadBalancerTargetLANName returns the name of the private LAN, which connects the Application Load
+// Balancer with the worker machines.
+func (*Service) applicationLoadBalancerTargetLANName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("lan-alb-target-%s-%s", c.Namespace, c.Name)
+}
+
+// targetGroupNamePrefix returns the prefix of the names of all target groups of the cluster.
+// As rule names must not contain dots, the separator allows telling apart the target groups of clusters,
+// whose names share a common prefix.
+func (*Service) targetGroupNamePrefix(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("tg-%s-%s.", c.Namespace, c.Name)
+}
+
+// targetGroupName returns the name of the target group, to which the traffic matching the HTTP rule is forwarded.
+func (s *Service) targetGroupName(c *clusterv1.Cluster, ruleName string) string {
+	return s.targetGroupNamePrefix(c) + ruleName
+}
+
+func (s *Service) applicationLoadBalancerLANs(c *clusterv1.Cluster) []loadBalancerLAN {
+	return []loadBalancerLAN{
+		{name: s.applicationLoadBalancerListenerLANName(c), public: true},
+		{name: s.applicationLoadBalancerTargetLANName(c), public: false},
+	}
+}
+
+func (*Service) applicationLoadBalancersURL(datacenterID string) string {
+	return path.Join("datacenters", datacenterID, "applicationloadbalancers")
+}
+
+func (*Service) applicationLoadBalancerURL(datacenterID, id string) string {
+	return path.Join("datacenters", datacenterID, "applicationloadbalancers", id)
+}
+
+func (*Service) targetGroupsURL() string {
+	return "targetgroups"
+}
+
+func (*Service) targetGroupURL(id string) string {
+	return path.Join("targetgroups", id)
+}
+
+// isApplicationLoadBalancerTarget returns true if the machine should be registered as a target
+// of the Application Load Balancer.
+func isApplicationLoadBalancerTarget(ms *scope.Machine) bool {
+	alb := ms.ClusterScope.IonosCluster.Spec.ApplicationLoadBalancer
+	return alb != nil && !util.IsControlPlaneMachine(ms.Machine) && alb.DatacenterID == ms.DatacenterID()
+}
+
+// ReconcileApplicationLoadBalancerNetworks ensures the listener and target LANs of the Application Load Balancer exist.
+func (s *Service) ReconcileApplicationLoadBalancerNetworks(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	for _, lan := range s.applicationLoadBalancerLANs(cs.Cluster) {
+		if requeue, err := s.reconcileClusterOwnedLAN(ctx, cs, alb.DatacenterID, lan.properties()); err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+// ReconcileApplicationLoadBalancerNetworksDeletion ensures the listener and target LANs of the Application
+// Load Balancer are deleted.
+func (s *Service) ReconcileApplicationLoadBalancerNetworksDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	for _, lan := range s.applicationLoadBalancerLANs(cs.Cluster) {
+		if requeue, err := s.reconcileClusterOwnedLANDeletion(ctx, cs, alb.DatacenterID, lan.name); err != nil ||
+			requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+// ReconcileApplicationLoadBalancerIPBlock ensures that the IP block for the public IP of the
+// Application Load Balancer is reserved.
+func (s *Service) ReconcileApplicationLoadBalancerIPBlock(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileApplicationLoadBalancerIPBlock")
+
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	if alb.IPBlock != "" {
+		// The IP is assigned from an IP block of the cluster by ReconcileIPBlocks.
+		block := cs.IPBlock(alb.IPBlock)
+		if block == nil || block.Assignments[applicationLoadBalancerIPBlockConsumer] == "" {
+			log.Info("Waiting for an IP of the IP block to be assigned", "ipBlock", alb.IPBlock)
+			return true, nil
+		}
+		cs.SetApplicationLoadBalancerIPBlock(block.ID, block.Assignments[applicationLoadBalancerIPBlockConsumer])
+		return false, nil
+	}
+
+	ipBlock, request, err := scopedFindResource(
+		ctx, cs,
+		s.getApplicationLoadBalancerIPBlock,
+		s.getLatestApplicationLoadBalancerIPBlockCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if ipBlock != nil {
+		ip := ""
+		if ips := ptr.Deref(ipBlock.GetProperties().GetIps(), nil); len(ips) > 0 {
+			ip = ips[0]
+		}
+		cs.SetApplicationLoadBalancerIPBlock(ptr.Deref(ipBlock.GetId(), ""), ip)
+		if state := getState(ipBlock); !isAvailable(state) {
+			log.Info("IP block is not available yet", "state", state)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No IP block was found. Creating new IP block")
+	err = s.reserveIPBlock(
+		ctx, s.applicationLoadBalancerIPBlockName(cs),
+		cs.Location(), 1, log,
+		cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest,
+	)
+	return err == nil, err
+}
+
+// ReconcileApplicationLoadBalancerIPBlockDeletion ensures that the IP block of the Application Load Balancer
+// is deleted.
+func (s *Service) ReconcileApplicationLoadBalancerIPBlockDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileApplicationLoadBalancerIPBlockDeletion")
+
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	if alb.IPBlock != "" {
+		// The IP block belongs to the cluster and is deleted by ReconcileIPBlocksDeletion.
+		cs.SetApplicationLoadBalancerIPBlock("", "")
+		return false, nil
+	}
+
+	ipBlock, request, err := scopedFindResource(
+		ctx, cs,
+		s.getApplicationLoadBalancerIPBlock,
+		s.getLatestApplicationLoadBalancerIPBlockCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if ipBlock == nil {
+		cs.SetApplicationLoadBalancerIPBlock("", "")
+		return false, nil
+	}
+
+	ipBlockID := ptr.Deref(ipBlock.GetId(), "")
+	request, err = s.getLatestIPBlockDeletionRequest(ctx, ipBlockID)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	err = s.deleteIPBlock(ctx, log, ipBlockID, cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest)
+	return err == nil, err
+}
+
+// ReconcileTargetGroups ensures that a target group exists for each HTTP rule of the Application Load Balancer.
+// All worker machines, which are connected to the target LAN, are registered as targets of each group.
+func (s *Service) ReconcileTargetGroups(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileTargetGroups")
+
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	targetGroups, err := s.getTargetGroups(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	targetLANID, err := s.getLANIDByName(ctx, alb.DatacenterID, s.applicationLoadBalancerTargetLANName(cs.Cluster))
+	if err != nil {
+		return false, err
+	}
+
+	ips, err := s.applicationLoadBalancerTargetIPs(ctx, cs, targetLANID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, rule := range alb.Rules {
+		name := s.targetGroupName(cs.Cluster, rule.Name)
+		targets := s.buildTargetGroupTargets(ips, rule.TargetPort)
+
+		targetGroup, ok := targetGroups[name]
+		if !ok {
+			return true, s.createTargetGroup(ctx, cs, name, targets)
+		}
+
+		if state := getState(&targetGroup); !isAvailable(state) {
+			log.Info("Target group is not available yet", "state", state, "name", name)
+			return true, nil
+		}
+
+		currentTargets := ptr.Deref(targetGroup.GetProperties().GetTargets(), nil)
+		if equalLoadBalancerTargets(currentTargets, targets) {
+			continue
+		}
+
+		properties := *targetGroup.GetProperties()
+		properties.Targets = &targets
+
+		requestPath, err := s.ionosClient.PatchTargetGroup(ctx, ptr.Deref(targetGroup.GetId(), ""), properties)
+		if err != nil {
+			return false, fmt.Errorf("unable to update targets of target group %s: %w", name, err)
+		}
+
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPatch, sdk.RequestStatusQueued, requestPath)
+		log.Info("Successfully requested for target group update", "requestPath", requestPath,
+			"name", name, "targets", len(targets))
+		return true, nil
+	}
+
+	log.V(4).Info("Target groups are up to date")
+	return false, nil
+}
+
+// createTargetGroup requests the creation of the target group, unless a creation request is already pending.
+func (s *Service) createTargetGroup(
+	ctx context.Context, cs *scope.Cluster, name string, targets []sdk.TargetGroupTarget,
+) error {
+	log := s.logger.WithName("createTargetGroup").WithValues("name", name)
+
+	request, err := getMatchingRequest(
+		ctx, s, http.MethodPost, s.targetGroupsURL(),
+		matchByName[*sdk.TargetGroup, *sdk.TargetGroupProperties](name),
+	)
+	if err != nil {
+		return err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return nil
+	}
+
+	requestPath, err := s.ionosClient.CreateTargetGroup(ctx, sdk.TargetGroupProperties{
+		Name:      ptr.To(name),
+		Algorithm: ptr.To(targetGroupAlgorithm),
+		Protocol:  ptr.To(targetGroupProtocol),
+		Targets:   &targets,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create target group %s: %w", name, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	log.Info("Successfully requested for target group creation", "requestPath", requestPath)
+	return nil
+}
+
+// ReconcileOrphanedTargetGroupsDeletion ensures that target groups of HTTP rules, which were removed from the spec,
+// are deleted. It runs after the load balancer has been updated, as referenced target groups can't be deleted.
+func (s *Service) ReconcileOrphanedTargetGroupsDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	keep := make([]string, 0, len(alb.Rules))
+	for _, rule := range alb.Rules {
+		keep = append(keep, s.targetGroupName(cs.Cluster, rule.Name))
+	}
+
+	return s.deleteTargetGroups(ctx, cs, keep)
+}
+
+// ReconcileTargetGroupsDeletion ensures that all target groups of the Application Load Balancer are deleted.
+func (s *Service) ReconcileTargetGroupsDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	if cs.IonosCluster.Spec.ApplicationLoadBalancer == nil {
+		return false, nil
+	}
+
+	return s.deleteTargetGroups(ctx, cs, nil)
+}
+
+// deleteTargetGroups deletes the target groups of the cluster, whose names are not contained in keep.
+// Only one target group is deleted at a time.
+func (s *Service) deleteTargetGroups(ctx context.Context, cs *scope.Cluster, keep []string) (requeue bool, err error) {
+	log := s.logger.WithName("deleteTargetGroups")
+
+	targetGroups, err := s.getTargetGroups(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	names := make([]string, 0, len(targetGroups))
+	for name := range targetGroups {
+		if !slices.Contains(keep, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		targetGroup := targetGroups[name]
+		targetGroupID := ptr.Deref(targetGroup.GetId(), "")
+		request, err := getMatchingRequest[sdk.TargetGroup](
+			ctx, s, http.MethodDelete, s.targetGroupURL(targetGroupID),
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+			log.Info("Deletion request is pending", "location", request.location, "requestID", request.id(), "name", name)
+			return true, nil
+		}
+
+		requestPath, err := s.ionosClient.DeleteTargetGroup(ctx, targetGroupID)
+		if err != nil {
+			return false, fmt.Errorf("unable to request deletion of target group %s: %w", name, err)
+		}
+
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+		log.Info("Successfully requested for target group deletion", "requestPath", requestPath, "name", name)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ReconcileApplicationLoadBalancer ensures the Application Load Balancer exists, creating one if it doesn't.
+// The HTTP rules of an existing load balancer are updated to match the spec.
+func (s *Service) ReconcileApplicationLoadBalancer(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileApplicationLoadBalancer")
+
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	loadBalancer, request, err := scopedFindResource(
+		ctx, cs, s.getApplicationLoadBalancer, s.getLatestApplicationLoadBalancerCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if loadBalancer == nil {
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+			log.Info("Request is pending", "location", request.location, "requestID", request.id())
+			return true, nil
+		}
+
+		log.V(4).Info("No Application Load Balancer was found. Creating new load balancer")
+		if err := s.createApplicationLoadBalancer(ctx, cs); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	albID := ptr.Deref(loadBalancer.GetId(), "")
+	cs.SetApplicationLoadBalancerID(albID)
+	if state := getState(loadBalancer); !isAvailable(state) {
+		log.Info("Application Load Balancer is not available yet", "state", state)
+		return true, nil
+	}
+
+	rule := findApplicationLoadBalancerForwardingRule(loadBalancer)
+	if rule == nil {
+		return false, fmt.Errorf("unable to find forwarding rule %s on the Application Load Balancer",
+			albForwardingRuleName)
+	}
+
+	httpRules, err := s.buildHTTPRules(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	port := applicationLoadBalancerPort(alb)
+	properties := *rule.GetProperties()
+	currentRules := ptr.Deref(properties.GetHttpRules(), nil)
+	if ptr.Deref(properties.GetListenerPort(), 0) == port && equalHTTPRules(currentRules, httpRules) {
+		log.V(4).Info("Application Load Balancer is up to date")
+		return false, nil
+	}
+
+	properties.ListenerPort = &port
+	properties.HttpRules = &httpRules
+
+	requestPath, err := s.ionosClient.PatchApplicationLoadBalancerForwardingRule(
+		ctx, alb.DatacenterID, albID, ptr.Deref(rule.GetId(), ""), properties,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unable to update HTTP rules of the Application Load Balancer: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPatch, sdk.RequestStatusQueued, requestPath)
+	log.Info("Successfully requested for Application Load Balancer update", "requestPath", requestPath)
+	return true, nil
+}
+
+// ReconcileApplicationLoadBalancerDeletion ensures the Application Load Balancer is deleted.
+func (s *Service) ReconcileApplicationLoadBalancerDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileApplicationLoadBalancerDeletion")
+
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+	if alb == nil {
+		return false, nil
+	}
+
+	loadBalancer, request, err := scopedFindResource(
+		ctx, cs, s.getApplicationLoadBalancer, s.getLatestApplicationLoadBalancerCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if loadBalancer == nil {
+		cs.SetApplicationLoadBalancerID("")
+		return false, nil
+	}
+
+	albID := ptr.Deref(loadBalancer.GetId(), "")
+	request, err = getMatchingRequest[sdk.ApplicationLoadBalancer](
+		ctx, s, http.MethodDelete, s.applicationLoadBalancerURL(alb.DatacenterID, albID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	requestPath, err := s.ionosClient.DeleteApplicationLoadBalancer(ctx, alb.DatacenterID, albID)
+	if err != nil {
+		return false, fmt.Errorf("unable to request Application Load Balancer deletion: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, loadBalancerDeletionRequestedReason,
+		"Requested deletion of Application Load Balancer %s in data center %s", albID, alb.DatacenterID)
+	log.Info("Successfully requested for Application Load Balancer deletion", "requestPath", requestPath)
+	return true, nil
+}
+
+// getApplicationLoadBalancer tries to retrieve the Application Load Balancer of the cluster in the data center.
+func (s *Service) getApplicationLoadBalancer(
+	ctx context.Context, cs *scope.Cluster,
+) (*sdk.ApplicationLoadBalancer, error) {
+	datacenterID := cs.IonosCluster.Spec.ApplicationLoadBalancer.DatacenterID
+	albs, err := s.apiWithDepth(listApplicationLoadBalancersDepth).ListApplicationLoadBalancers(ctx, datacenterID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Application Load Balancers in data center %s: %w", datacenterID, err)
+	}
+
+	var (
+		expectedName = s.applicationLoadBalancerName(cs.Cluster)
+		count        = 0
+		foundALB     *sdk.ApplicationLoadBalancer
+	)
+
+	for _, alb := range ptr.Deref(albs.GetItems(), nil) {
+		if ptr.Deref(alb.GetProperties().GetName(), "") == expectedName {
+			foundALB = &alb
+			count++
+		}
+
+		if count > 1 {
+			return nil, fmt.Errorf("found multiple Application Load Balancers with the name: %s", expectedName)
+		}
+	}
+
+	return foundALB, nil
+}
+
+func (s *Service) getLatestApplicationLoadBalancerCreationRequest(
+	ctx context.Context, cs *scope.Cluster,
+) (*requestInfo, error) {
+	return getMatchingRequest(
+		ctx, s, http.MethodPost,
+		s.applicationLoadBalancersURL(cs.IonosCluster.Spec.ApplicationLoadBalancer.DatacenterID),
+		matchByName[*sdk.ApplicationLoadBalancer, *sdk.ApplicationLoadBalancerProperties](
+			s.applicationLoadBalancerName(cs.Cluster),
+		),
+	)
+}
+
+// getApplicationLoadBalancerIPBlock finds the IP block of the Application Load Balancer by its name and location.
+func (s *Service) getApplicationLoadBalancerIPBlock(ctx context.Context, cs *scope.Cluster) (*sdk.IpBlock, error) {
+	blocks, err := s.apiWithDepth(listIPBlocksDepth).ListIPBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP blocks: %w", err)
+	}
+
+	for _, block := range ptr.Deref(blocks.GetItems(), nil) {
+		props := block.GetProperties()
+		if ptr.Deref(props.GetLocation(), "") != cs.Location() {
+			continue
+		}
+		if ptr.Deref(props.GetName(), "") == s.applicationLoadBalancerIPBlockName(cs) {
+			return s.cloudAPIStateInconsistencyWorkaround(ctx, &block)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Service) getLatestApplicationLoadBalancerIPBlockCreationRequest(
+	ctx context.Context, cs *scope.Cluster,
+) (*requestInfo, error) {
+	return s.getLatestIPBlockRequestByNameAndLocation(
+		ctx, http.MethodPost,
+		s.applicationLoadBalancerIPBlockName(cs),
+		cs.Location(),
+	)
+}
+
+// getTargetGroups returns the target groups of the cluster by their name.
+func (s *Service) getTargetGroups(ctx context.Context, cs *scope.Cluster) (map[string]sdk.TargetGroup, error) {
+	targetGroups, err := s.apiWithDepth(1).ListTargetGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list target groups: %w", err)
+	}
+
+	prefix := s.targetGroupNamePrefix(cs.Cluster)
+	found := make(map[string]sdk.TargetGroup)
+	for _, targetGroup := range ptr.Deref(targetGroups.GetItems(), nil) {
+		name := ptr.Deref(targetGroup.GetProperties().GetName(), "")
+		ruleName, ok := strings.CutPrefix(name, prefix)
+		if !ok || ruleName == "" || strings.Contains(ruleName, ".") {
+			continue
+		}
+		if _, exists := found[name]; exists {
+			return nil, fmt.Errorf("found multiple target groups with the name: %s", name)
+		}
+		found[name] = targetGroup
+	}
+
+	return found, nil
+}
+
+func (s *Service) createApplicationLoadBalancer(ctx context.Context, cs *scope.Cluster) error {
+	log := s.logger.WithName("createApplicationLoadBalancer")
+	alb := cs.IonosCluster.Spec.ApplicationLoadBalancer
+
+	publicIP := cs.IonosCluster.Status.ApplicationLoadBalancerIP
+	if publicIP == "" {
+		return errors.New("a reserved IP is required to create the Application Load Balancer")
+	}
+
+	listenerLANID, err := s.getLANIDByName(ctx, alb.DatacenterID, s.applicationLoadBalancerListenerLANName(cs.Cluster))
+	if err != nil {
+		return err
+	}
+
+	targetLANID, err := s.getLANIDByName(ctx, alb.DatacenterID, s.applicationLoadBalancerTargetLANName(cs.Cluster))
+	if err != nil {
+		return err
+	}
+
+	httpRules, err := s.buildHTTPRules(ctx, cs)
+	if err != nil {
+		return err
+	}
+
+	properties := sdk.ApplicationLoadBalancerProperties{
+		Name:        ptr.To(s.applicationLoadBalancerName(cs.Cluster)),
+		Ips:         &[]string{publicIP},
+		ListenerLan: &listenerLANID,
+		TargetLan:   &targetLANID,
+	}
+
+	entities := sdk.ApplicationLoadBalancerEntities{
+		Forwardingrules: &sdk.ApplicationLoadBalancerForwardingRules{
+			Items: &[]sdk.ApplicationLoadBalancerForwardingRule{{
+				Properties: &sdk.ApplicationLoadBalancerForwardingRuleProperties{
+					Name:         ptr.To(albForwardingRuleName),
+					Protocol:     ptr.To(albForwardingRuleProtocol),
+					ListenerIp:   &publicIP,
+					ListenerPort: ptr.To(applicationLoadBalancerPort(alb)),
+					HttpRules:    &httpRules,
+				},
+			}},
+		},
+	}
+
+	requestPath, err := s.ionosClient.CreateApplicationLoadBalancer(ctx, alb.DatacenterID, properties, entities)
+	if err != nil {
+		return fmt.Errorf("unable to create Application Load Balancer in data center %s: %w", alb.DatacenterID, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, loadBalancerCreationRequestedReason,
+		"Requested creation of Application Load Balancer in data center %s", alb.DatacenterID)
+	log.Info("Successfully requested for Application Load Balancer creation", "requestPath", requestPath)
+	return nil
+}
+
+// buildHTTPRules returns the HTTP rules of the Application Load Balancer, which forward the traffic
+// to the target groups of the rules. The target groups must already exist.
+func (s *Service) buildHTTPRules(
+	ctx context.Context, cs *scope.Cluster,
+) ([]sdk.ApplicationLoadBalancerHttpRule, error) {
+	targetGroups, err := s.getTargetGroups(ctx, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	specRules := cs.IonosCluster.Spec.ApplicationLoadBalancer.Rules
+	httpRules := make([]sdk.ApplicationLoadBalancerHttpRule, 0, len(specRules))
+	for _, rule := range specRules {
+		name := s.targetGroupName(cs.Cluster, rule.Name)
+		targetGroup, ok := targetGroups[name]
+		if !ok {
+			return nil, fmt.Errorf("unable to find target group %s", name)
+		}
+
+		httpRules = append(httpRules, sdk.ApplicationLoadBalancerHttpRule{
+			Name:        ptr.To(rule.Name),
+			Type:        ptr.To(albHTTPRuleType),
+			TargetGroup: targetGroup.GetId(),
+			Conditions:  buildHTTPRuleConditions(rule),
+		})
+	}
+
+	return httpRules, nil
+}
+
+func buildHTTPRuleConditions(rule infrav1.ApplicationLoadBalancerRule) *[]sdk.ApplicationLoadBalancerHttpRuleCondition {
+	var conditions []sdk.ApplicationLoadBalancerHttpRuleCondition
+	if rule.Host != "" {
+		conditions = append(conditions, sdk.ApplicationLoadBalancerHttpRuleCondition{
+			Type:      ptr.To(albHostConditionType),
+			Condition: ptr.To(albHostConditionComparison),
+			Value:     ptr.To(rule.Host),
+		})
+	}
+	if rule.PathPrefix != "" {
+		conditions = append(conditions, sdk.ApplicationLoadBalancerHttpRuleCondition{
+			Type:      ptr.To(albPathConditionType),
+			Condition: ptr.To(albPathConditionComparison),
+			Value:     ptr.To(rule.PathPrefix),
+		})
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+	return &conditions
+}
+
+// applicationLoadBalancerTargetIPs returns the IPs of all worker machines in the target LAN.
+// Machines, which are about to be deleted, are not considered.
+func (*Service) applicationLoadBalancerTargetIPs(
+	ctx context.Context, cs *scope.Cluster, targetLANID int32,
+) ([]string, error) {
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	for _, machine := range machines {
+		if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			continue
+		}
+		if !machine.DeletionTimestamp.IsZero() || machine.Status.MachineNetworkInfo == nil {
+			continue
+		}
+		for _, nic := range machine.Status.MachineNetworkInfo.NICInfo {
+			if nic.NetworkID == targetLANID && len(nic.IPv4Addresses) > 0 {
+				ips = append(ips, nic.IPv4Addresses[0])
+			}
+		}
+	}
+	slices.Sort(ips)
+
+	return ips, nil
+}
+
+func (*Service) buildTargetGroupTargets(ips []string, port int32) []sdk.TargetGroupTarget {
+	targets := make([]sdk.TargetGroupTarget, 0, len(ips))
+	for _, ip := range ips {
+		targets = append(targets, sdk.TargetGroupTarget{
+			Ip:                 ptr.To(ip),
+			Port:               ptr.To(port),
+			Weight:             ptr.To(targetGroupTargetWeight),
+			HealthCheckEnabled: ptr.To(true),
+		})
+	}
+	return targets
+}
+
+func findApplicationLoadBalancerForwardingRule(
+	alb *sdk.ApplicationLoadBalancer,
+) *sdk.ApplicationLoadBalancerForwardingRule {
+	rules := ptr.Deref(alb.GetEntities().GetForwardingrules().GetItems(), nil)
+	for i := range rules {
+		if ptr.Deref(rules[i].GetProperties().GetName(), "") == albForwardingRuleName {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func applicationLoadBalancerPort(alb *infrav1.ApplicationLoadBalancerSpec) int32 {
+	if alb.Port == 0 {
+		return defaultApplicationLoadBalancerPort
+	}
+	return alb.Port
+}
+
+// equalHTTPRules checks if both lists contain the same HTTP rules in the same order.
+// Only the properties, which are managed by the controller, are compared.
+func equalHTTPRules(a, b []sdk.ApplicationLoadBalancerHttpRule) bool {
+	toKeys := func(rules []sdk.ApplicationLoadBalancerHttpRule) []string {
+		keys := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			key := fmt.Sprintf("%s/%s/%s", ptr.Deref(rule.GetName(), ""), ptr.Deref(rule.GetType(), ""),
+				ptr.Deref(rule.GetTargetGroup(), ""))
+			for _, c := range ptr.Deref(rule.GetConditions(), nil) {
+				key += fmt.Sprintf("/%s:%s:%s", ptr.Deref(c.GetType(), ""), ptr.Deref(c.GetCondition(), ""),
+					ptr.Deref(c.GetValue(), ""))
+			}
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	return slices.Equal(toKeys(a), toKeys(b))
+}
@@ -0,0 +1,518 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	exampleALBID               = "0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c40"
+	exampleALBForwardingRuleID = "0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c41"
+	exampleALBIPBlockID        = "0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c42"
+	exampleTargetGroupID       = "0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c43"
+	exampleALBIP               = "203.0.113.20"
+	exampleALBListenerLANID    = "45"
+	exampleALBTargetLANID      = "46"
+	exampleALBTargetIP         = "10.0.1.2"
+	exampleALBRuleName         = "ingress"
+	exampleALBRuleTargetPort   = int32(30080)
+)
+
+type applicationLoadBalancerTestSuite struct {
+	ServiceTestSuite
+}
+
+func TestApplicationLoadBalancerTestSuite(t *testing.T) {
+	suite.Run(t, new(applicationLoadBalancerTestSuite))
+}
+
+func (s *applicationLoadBalancerTestSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraCluster.Spec.ApplicationLoadBalancer = &infrav1.ApplicationLoadBalancerSpec{
+		DatacenterID: s.machineScope.DatacenterID(),
+		Rules: []infrav1.ApplicationLoadBalancerRule{{
+			Name:       exampleALBRuleName,
+			Host:       "app.example.com",
+			TargetPort: exampleALBRuleTargetPort,
+		}},
+	}
+}
+
+func (s *applicationLoadBalancerTestSuite) TestApplicationLoadBalancerNames() {
+	s.Equal("alb-default-test-cluster", s.service.applicationLoadBalancerName(s.capiCluster))
+	s.Equal("alb-ipb-default-test-cluster", s.service.applicationLoadBalancerIPBlockName(s.clusterScope))
+	s.Equal("lan-alb-listener-default-test-cluster", s.service.applicationLoadBalancerListenerLANName(s.capiCluster))
+	s.Equal("lan-alb-target-default-test-cluster", s.service.applicationLoadBalancerTargetLANName(s.capiCluster))
+	s.Equal("tg-default-test-cluster.ingress", s.service.targetGroupName(s.capiCluster, exampleALBRuleName))
+}
+
+func (s *applicationLoadBalancerTestSuite) TestIsApplicationLoadBalancerTarget() {
+	s.True(isApplicationLoadBalancerTarget(s.machineScope))
+
+	s.capiMachine.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+	s.False(isApplicationLoadBalancerTarget(s.machineScope), "control plane machines must not be registered")
+
+	s.capiMachine.SetLabels(nil)
+	s.infraCluster.Spec.ApplicationLoadBalancer.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+	s.False(isApplicationLoadBalancerTarget(s.machineScope), "machines in other data centers must not be registered")
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerNotConfigured() {
+	s.infraCluster.Spec.ApplicationLoadBalancer = nil
+
+	for _, reconcile := range []func() (bool, error){
+		func() (bool, error) { return s.service.ReconcileApplicationLoadBalancerNetworks(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileApplicationLoadBalancerIPBlock(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileTargetGroups(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileApplicationLoadBalancer(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileOrphanedTargetGroupsDeletion(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileApplicationLoadBalancerDeletion(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileTargetGroupsDeletion(s.ctx, s.clusterScope) },
+		func() (bool, error) {
+			return s.service.ReconcileApplicationLoadBalancerIPBlockDeletion(s.ctx, s.clusterScope)
+		},
+		func() (bool, error) {
+			return s.service.ReconcileApplicationLoadBalancerNetworksDeletion(s.ctx, s.clusterScope)
+		},
+	} {
+		requeue, err := reconcile()
+		s.NoError(err)
+		s.False(requeue)
+	}
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerNetworksAvailable() {
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Twice()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerIPBlockReserve() {
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{}}, nil).Once()
+	s.mockGetIPBlocksRequestsPostCall().Return(nil, nil).Once()
+	s.mockReserveIPBlockCall(s.service.applicationLoadBalancerIPBlockName(s.clusterScope), exampleLocation).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerIPBlockAvailable() {
+	s.mockALBIPBlock().Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleALBIPBlockID, s.infraCluster.Status.ApplicationLoadBalancerIPBlockID)
+	s.Equal(exampleALBIP, s.infraCluster.Status.ApplicationLoadBalancerIP)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerIPBlockDeletion() {
+	s.mockALBIPBlock().Once()
+	s.mockGetIPBlocksRequestsDeleteCall(exampleALBIPBlockID).Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteIPBlock(s.ctx, exampleALBIPBlockID).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerIPBlockDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileTargetGroupsCreate() {
+	s.createWorkerMachine("worker-0", exampleALBTargetIP)
+
+	s.mockListTargetGroupsCall().Return(&sdk.TargetGroups{Items: &[]sdk.TargetGroup{}}, nil).Once()
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Once()
+	s.mockGetTGCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateTargetGroup(s.ctx, sdk.TargetGroupProperties{
+		Name:      ptr.To(s.service.targetGroupName(s.capiCluster, exampleALBRuleName)),
+		Algorithm: ptr.To(targetGroupAlgorithm),
+		Protocol:  ptr.To(targetGroupProtocol),
+		Targets:   &[]sdk.TargetGroupTarget{exampleTargetGroupTarget(exampleALBTargetIP)},
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileTargetGroups(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileTargetGroupsCreationPending() {
+	s.mockListTargetGroupsCall().Return(&sdk.TargetGroups{Items: &[]sdk.TargetGroup{}}, nil).Once()
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Once()
+	s.mockGetTGCreationRequestsCall().Return([]sdk.Request{s.exampleRequest(requestBuildOptions{
+		status:     sdk.RequestStatusRunning,
+		method:     http.MethodPost,
+		url:        s.service.targetGroupsURL(),
+		body:       `{"properties": {"name": "tg-default-test-cluster.ingress"}}`,
+		href:       exampleRequestPath,
+		targetType: sdk.TARGET_GROUP,
+	})}, nil).Once()
+
+	requeue, err := s.service.ReconcileTargetGroups(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(sdk.RequestStatusRunning, s.infraCluster.Status.CurrentClusterRequest.State)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileTargetGroupsRegisterWorkers() {
+	s.createWorkerMachine("worker-0", exampleALBTargetIP)
+	s.createControlPlaneMachine("cp-0", exampleLoadBalancerTargetIP)
+
+	targetGroups := s.exampleTargetGroups()
+	s.mockListTargetGroupsCall().Return(targetGroups, nil).Once()
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Once()
+
+	wantProps := *(*targetGroups.Items)[0].Properties
+	wantProps.Targets = &[]sdk.TargetGroupTarget{exampleTargetGroupTarget(exampleALBTargetIP)}
+	s.ionosClient.EXPECT().PatchTargetGroup(s.ctx, exampleTargetGroupID, wantProps).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileTargetGroups(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileTargetGroupsUpToDate() {
+	s.createWorkerMachine("worker-0", exampleALBTargetIP)
+
+	targetGroups := s.exampleTargetGroups()
+	(*targetGroups.Items)[0].Properties.Targets = &[]sdk.TargetGroupTarget{
+		exampleTargetGroupTarget(exampleALBTargetIP),
+	}
+	s.mockListTargetGroupsCall().Return(targetGroups, nil).Once()
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Once()
+
+	requeue, err := s.service.ReconcileTargetGroups(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileOrphanedTargetGroupsDeletion() {
+	targetGroups := s.exampleTargetGroups()
+	orphaned := exampleTargetGroup(
+		"0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c44", s.service.targetGroupName(s.capiCluster, "removed"),
+	)
+	otherCluster := exampleTargetGroup(
+		"0d8c3b5e-4a0f-4d2b-9c61-7e6f1b2a3c45", "tg-default-test-cluster.other.removed",
+	)
+	*targetGroups.Items = append(*targetGroups.Items, orphaned, otherCluster)
+
+	s.mockListTargetGroupsCall().Return(targetGroups, nil).Once()
+	s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete, s.service.targetGroupURL(*orphaned.Id)).
+		Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteTargetGroup(s.ctx, *orphaned.Id).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileOrphanedTargetGroupsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileOrphanedTargetGroupsDeletionNothingToDelete() {
+	s.mockListTargetGroupsCall().Return(s.exampleTargetGroups(), nil).Once()
+
+	requeue, err := s.service.ReconcileOrphanedTargetGroupsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileTargetGroupsDeletion() {
+	s.mockListTargetGroupsCall().Return(s.exampleTargetGroups(), nil).Once()
+	s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete, s.service.targetGroupURL(exampleTargetGroupID)).
+		Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteTargetGroup(s.ctx, exampleTargetGroupID).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileTargetGroupsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerCreate() {
+	s.infraCluster.Status.ApplicationLoadBalancerIP = exampleALBIP
+
+	s.mockListALBsCall().
+		Return(&sdk.ApplicationLoadBalancers{Items: &[]sdk.ApplicationLoadBalancer{}}, nil).Once()
+	s.mockGetALBCreationRequestsCall().Return(nil, nil).Once()
+	s.mockListLANsCall().Return(s.applicationLoadBalancerLANs(), nil).Twice()
+	s.mockListTargetGroupsCall().Return(s.exampleTargetGroups(), nil).Once()
+
+	s.ionosClient.EXPECT().CreateApplicationLoadBalancer(
+		s.ctx, s.machineScope.DatacenterID(), sdk.ApplicationLoadBalancerProperties{
+			Name:        ptr.To(s.service.applicationLoadBalancerName(s.capiCluster)),
+			Ips:         &[]string{exampleALBIP},
+			ListenerLan: ptr.To(int32(45)),
+			TargetLan:   ptr.To(int32(46)),
+		},
+		sdk.ApplicationLoadBalancerEntities{
+			Forwardingrules: &sdk.ApplicationLoadBalancerForwardingRules{
+				Items: &[]sdk.ApplicationLoadBalancerForwardingRule{{
+					Properties: &sdk.ApplicationLoadBalancerForwardingRuleProperties{
+						Name:         ptr.To(albForwardingRuleName),
+						Protocol:     ptr.To(albForwardingRuleProtocol),
+						ListenerIp:   ptr.To(exampleALBIP),
+						ListenerPort: ptr.To(defaultApplicationLoadBalancerPort),
+						HttpRules:    &[]sdk.ApplicationLoadBalancerHttpRule{exampleHTTPRule()},
+					},
+				}},
+			},
+		},
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerCreateWithoutIP() {
+	s.mockListALBsCall().
+		Return(&sdk.ApplicationLoadBalancers{Items: &[]sdk.ApplicationLoadBalancer{}}, nil).Once()
+	s.mockGetALBCreationRequestsCall().Return(nil, nil).Once()
+
+	_, err := s.service.ReconcileApplicationLoadBalancer(s.ctx, s.clusterScope)
+	s.ErrorContains(err, "a reserved IP is required")
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerUpToDate() {
+	s.mockListALBsCall().Return(s.exampleApplicationLoadBalancers(), nil).Once()
+	s.mockListTargetGroupsCall().Return(s.exampleTargetGroups(), nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleALBID, s.infraCluster.Status.ApplicationLoadBalancerID)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerUpdateRules() {
+	alb := s.infraCluster.Spec.ApplicationLoadBalancer
+	alb.Port = 8080
+	alb.Rules[0].PathPrefix = "/api"
+
+	albs := s.exampleApplicationLoadBalancers()
+	s.mockListALBsCall().Return(albs, nil).Once()
+	s.mockListTargetGroupsCall().Return(s.exampleTargetGroups(), nil).Once()
+
+	rule := exampleHTTPRule()
+	*rule.Conditions = append(*rule.Conditions, sdk.ApplicationLoadBalancerHttpRuleCondition{
+		Type:      ptr.To(albPathConditionType),
+		Condition: ptr.To(albPathConditionComparison),
+		Value:     ptr.To("/api"),
+	})
+	wantProps := *(*(*albs.Items)[0].Entities.Forwardingrules.Items)[0].Properties
+	wantProps.ListenerPort = ptr.To(int32(8080))
+	wantProps.HttpRules = &[]sdk.ApplicationLoadBalancerHttpRule{rule}
+
+	s.ionosClient.EXPECT().PatchApplicationLoadBalancerForwardingRule(
+		s.ctx, s.machineScope.DatacenterID(), exampleALBID, exampleALBForwardingRuleID, wantProps,
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerDeletion() {
+	s.mockListALBsCall().Return(s.exampleApplicationLoadBalancers(), nil).Once()
+	s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete,
+		s.service.applicationLoadBalancerURL(s.machineScope.DatacenterID(), exampleALBID)).Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteApplicationLoadBalancer(s.ctx, s.machineScope.DatacenterID(), exampleALBID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestReconcileApplicationLoadBalancerDeletionNotFound() {
+	s.infraCluster.Status.ApplicationLoadBalancerID = exampleALBID
+	s.mockListALBsCall().
+		Return(&sdk.ApplicationLoadBalancers{Items: &[]sdk.ApplicationLoadBalancer{}}, nil).Once()
+	s.mockGetALBCreationRequestsCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileApplicationLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.ApplicationLoadBalancerID)
+}
+
+func (s *applicationLoadBalancerTestSuite) TestEqualHTTPRules() {
+	a := []sdk.ApplicationLoadBalancerHttpRule{exampleHTTPRule()}
+	s.True(equalHTTPRules(a, []sdk.ApplicationLoadBalancerHttpRule{exampleHTTPRule()}))
+	s.True(equalHTTPRules(nil, []sdk.ApplicationLoadBalancerHttpRule{}))
+
+	changed := exampleHTTPRule()
+	changed.Conditions = nil
+	s.False(equalHTTPRules(a, []sdk.ApplicationLoadBalancerHttpRule{changed}))
+}
+
+func (s *applicationLoadBalancerTestSuite) createWorkerMachine(name, targetIP string) {
+	s.createMachine(name, targetIP, map[string]string{clusterv1.ClusterNameLabel: s.capiCluster.Name})
+}
+
+func (s *applicationLoadBalancerTestSuite) createControlPlaneMachine(name, targetIP string) {
+	s.createMachine(name, targetIP, map[string]string{
+		clusterv1.ClusterNameLabel:         s.capiCluster.Name,
+		clusterv1.MachineControlPlaneLabel: "",
+	})
+}
+
+func (s *applicationLoadBalancerTestSuite) createMachine(name, targetIP string, labels map[string]string) {
+	machine := &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: *s.infraMachine.Spec.DeepCopy(),
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, machine))
+
+	machine.Status.MachineNetworkInfo = &infrav1.MachineNetworkInfo{
+		NICInfo: []infrav1.NICInfo{
+			{IPv4Addresses: []string{exampleDHCPIP}, NetworkID: 42, Primary: true},
+			{IPv4Addresses: []string{targetIP}, NetworkID: 46},
+		},
+	}
+	s.NoError(s.k8sClient.Status().Update(s.ctx, machine))
+}
+
+func exampleTargetGroupTarget(ip string) sdk.TargetGroupTarget {
+	return sdk.TargetGroupTarget{
+		Ip:                 ptr.To(ip),
+		Port:               ptr.To(exampleALBRuleTargetPort),
+		Weight:             ptr.To(targetGroupTargetWeight),
+		HealthCheckEnabled: ptr.To(true),
+	}
+}
+
+func exampleTargetGroup(id, name string) sdk.TargetGroup {
+	return sdk.TargetGroup{
+		Id: ptr.To(id),
+		Metadata: &sdk.DatacenterElementMetadata{
+			State: ptr.To(sdk.Available),
+		},
+		Properties: &sdk.TargetGroupProperties{
+			Name:      ptr.To(name),
+			Algorithm: ptr.To(targetGroupAlgorithm),
+			Protocol:  ptr.To(targetGroupProtocol),
+		},
+	}
+}
+
+func exampleHTTPRule() sdk.ApplicationLoadBalancerHttpRule {
+	return sdk.ApplicationLoadBalancerHttpRule{
+		Name:        ptr.To(exampleALBRuleName),
+		Type:        ptr.To(albHTTPRuleType),
+		TargetGroup: ptr.To(exampleTargetGroupID),
+		Conditions: &[]sdk.ApplicationLoadBalancerHttpRuleCondition{{
+			Type:      ptr.To(albHostConditionType),
+			Condition: ptr.To(albHostConditionComparison),
+			Value:     ptr.To("app.example.com"),
+		}},
+	}
+}
+
+func (s *applicationLoadBalancerTestSuite) exampleTargetGroups() *sdk.TargetGroups {
+	return &sdk.TargetGroups{
+		Items: &[]sdk.TargetGroup{
+			exampleTargetGroup(exampleTargetGroupID, s.service.targetGroupName(s.capiCluster, exampleALBRuleName)),
+		},
+	}
+}
+
+func (s *applicationLoadBalancerTestSuite) exampleApplicationLoadBalancers() *sdk.ApplicationLoadBalancers {
+	return &sdk.ApplicationLoadBalancers{
+		Items: &[]sdk.ApplicationLoadBalancer{{
+			Id: ptr.To(exampleALBID),
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.ApplicationLoadBalancerProperties{
+				Name: ptr.To(s.service.applicationLoadBalancerName(s.capiCluster)),
+			},
+			Entities: &sdk.ApplicationLoadBalancerEntities{
+				Forwardingrules: &sdk.ApplicationLoadBalancerForwardingRules{
+					Items: &[]sdk.ApplicationLoadBalancerForwardingRule{{
+						Id: ptr.To(exampleALBForwardingRuleID),
+						Properties: &sdk.ApplicationLoadBalancerForwardingRuleProperties{
+							Name:         ptr.To(albForwardingRuleName),
+							Protocol:     ptr.To(albForwardingRuleProtocol),
+							ListenerIp:   ptr.To(exampleALBIP),
+							ListenerPort: ptr.To(defaultApplicationLoadBalancerPort),
+							HttpRules:    &[]sdk.ApplicationLoadBalancerHttpRule{exampleHTTPRule()},
+						},
+					}},
+				},
+			},
+		}},
+	}
+}
+
+func (s *applicationLoadBalancerTestSuite) applicationLoadBalancerLANs() *sdk.Lans {
+	listenerLAN := s.exampleLAN()
+	listenerLAN.Id = ptr.To(exampleALBListenerLANID)
+	listenerLAN.Properties.Name = ptr.To(s.service.applicationLoadBalancerListenerLANName(s.capiCluster))
+
+	targetLAN := s.exampleLAN()
+	targetLAN.Id = ptr.To(exampleALBTargetLANID)
+	targetLAN.Properties.Name = ptr.To(s.service.applicationLoadBalancerTargetLANName(s.capiCluster))
+	return &sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN(), listenerLAN, targetLAN}}
+}
+
+func (s *applicationLoadBalancerTestSuite) mockALBIPBlock() *clienttest.MockClient_GetIPBlock_Call {
+	ipBlock := exampleIPBlockWithName(s.service.applicationLoadBalancerIPBlockName(s.clusterScope))
+	ipBlock.Id = ptr.To(exampleALBIPBlockID)
+	ipBlock.Properties.Ips = &[]string{exampleALBIP}
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{*ipBlock}}, nil).Once()
+	return s.mockGetIPBlockByIDCall(exampleALBIPBlockID).Return(ipBlock, nil)
+}
+
+func (s *applicationLoadBalancerTestSuite) mockListALBsCall() *clienttest.MockClient_ListApplicationLoadBalancers_Call {
+	return s.ionosClient.EXPECT().ListApplicationLoadBalancers(s.ctx, s.machineScope.DatacenterID())
+}
+
+func (s *applicationLoadBalancerTestSuite) mockGetALBCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodPost, s.service.applicationLoadBalancersURL(s.machineScope.DatacenterID()))
+}
+
+func (s *applicationLoadBalancerTestSuite) mockListTargetGroupsCall() *clienttest.MockClient_ListTargetGroups_Call {
+	return s.ionosClient.EXPECT().ListTargetGroups(s.ctx)
+}
+
+func (s *applicationLoadBalancerTestSuite) mockGetTGCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodPost, s.service.targetGroupsURL())
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/cidata"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// maxUserDataSize is the maximum size of the base64 encoded user data of a volume,
+	// which is accepted by the Cloud API.
+	maxUserDataSize = 64 << 10
+
+	// bootstrapImageProcessingTimeout is the time after which an uploaded bootstrap image,
+	// which didn't show up in the Cloud API, is uploaded again.
+	bootstrapImageProcessingTimeout = 15 * time.Minute
+
+	imageTypeCDROM = "CDROM"
+)
+
+// useBootstrapImage returns true if the rendered user data exceeds the limit of the boot volume
+// and the bootstrap data has to be delivered via a CD-ROM image instead.
+func useBootstrapImage(renderedData string) bool {
+	return len(renderedData) > maxUserDataSize
+}
+
+// reconcileBootstrapImage ensures that the bootstrap image of the machine exists in the location of
+// the data center. The image is a cloud-init NoCloud data source, which contains the rendered user data.
+// It returns the ID of the image, or an empty string if the image is not available yet.
+func (s *Service) reconcileBootstrapImage(
+	ctx context.Context, ms *scope.Machine, datacenter *sdk.Datacenter, renderedData string,
+) (string, error) {
+	log := s.logger.WithName("reconcileBootstrapImage")
+
+	name := s.bootstrapImageName(ms.IonosMachine)
+	location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+	images, err := s.findBootstrapImages(ctx, ms.IonosMachine, location)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(images) {
+	case 0:
+	case 1:
+		image := images[0]
+		if state := getState(&image); !isAvailable(state) {
+			log.Info("Bootstrap image is not available yet", "name", name, "state", state)
+			return "", nil
+		}
+		conditions.MarkTrue(ms.IonosMachine, infrav1.BootstrapImageAvailableCondition)
+		return ptr.Deref(image.GetId(), ""), nil
+	default:
+		return "", fmt.Errorf("found multiple bootstrap images with the name %s in location %s", name, location)
+	}
+
+	// The Cloud API needs some time to create the image from an uploaded file.
+	if cond := conditions.Get(ms.IonosMachine, infrav1.BootstrapImageAvailableCondition); cond != nil &&
+		cond.Reason == infrav1.BootstrapImageProcessingReason &&
+		time.Since(cond.LastTransitionTime.Time) < bootstrapImageProcessingTimeout {
+		log.Info("Waiting for the uploaded bootstrap image to be processed", "name", name)
+		return "", nil
+	}
+
+	userData, err := base64.StdEncoding.DecodeString(renderedData)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode user data: %w", err)
+	}
+	metaData := fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", ms.IonosMachine.UID, ms.Hostname())
+
+	if err := s.ionosClient.UploadImage(ctx, location, name, cidata.NewImage(userData, []byte(metaData))); err != nil {
+		return "", fmt.Errorf("failed to upload bootstrap image: %w", err)
+	}
+
+	log.Info("Successfully uploaded bootstrap image", "name", name, "location", location)
+	conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapImageAvailableCondition,
+		infrav1.BootstrapImageProcessingReason, clusterv1.ConditionSeverityInfo,
+		"bootstrap image %s is being processed", name)
+	return "", nil
+}
+
+// deleteBootstrapImages deletes the bootstrap images of the machine. It returns true if a deletion
+// was requested.
+func (s *Service) deleteBootstrapImages(ctx context.Context, ms *scope.Machine) (bool, error) {
+	if !conditions.Has(ms.IonosMachine, infrav1.BootstrapImageAvailableCondition) {
+		return false, nil
+	}
+
+	images, err := s.findBootstrapImages(ctx, ms.IonosMachine, "")
+	if err != nil {
+		return false, err
+	}
+
+	for _, image := range images {
+		imageID := ptr.Deref(image.GetId(), "")
+		if state := getState(&image); state == sdk.Busy {
+			s.logger.Info("Bootstrap image is busy", "imageID", imageID, "state", state)
+			return true, nil
+		}
+		location, err := s.ionosClient.DeleteImage(ctx, imageID)
+		if err != nil {
+			return false, fmt.Errorf("failed to delete bootstrap image %s: %w", imageID, err)
+		}
+		s.logger.Info("Successfully requested for bootstrap image deletion", "imageID", imageID, "location", location)
+	}
+	return len(images) > 0, nil
+}
+
+// findBootstrapImages returns the bootstrap images of the machine. If location is not empty,
+// only images in the given location are returned.
+func (s *Service) findBootstrapImages(
+	ctx context.Context, m *infrav1.IonosCloudMachine, location string,
+) ([]sdk.Image, error) {
+	images, err := s.apiWithDepth(1).ListImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list images: %w", err)
+	}
+
+	name := s.bootstrapImageName(m)
+	var matches []sdk.Image
+	for _, image := range ptr.Deref(images.GetItems(), nil) {
+		props := image.GetProperties()
+		if ptr.Deref(props.GetName(), "") != name || ptr.Deref(props.GetImageType(), "") != imageTypeCDROM {
+			continue
+		}
+		if location != "" && ptr.Deref(props.GetLocation(), "") != location {
+			continue
+		}
+		matches = append(matches, image)
+	}
+	return matches, nil
+}
+
+func (*Service) bootstrapImageName(m *infrav1.IonosCloudMachine) string {
+	return "cidata-" + m.Name + ".iso"
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const exampleBootstrapImageID = "4b0a2b3e-8c0c-4b8c-9f0a-6f1d7e5c3a21"
+
+type bootstrapImageSuite struct {
+	ServiceTestSuite
+}
+
+func TestBootstrapImageSuite(t *testing.T) {
+	suite.Run(t, new(bootstrapImageSuite))
+}
+
+func (s *bootstrapImageSuite) datacenter() *sdk.Datacenter {
+	return &sdk.Datacenter{
+		Id:         ptr.To(s.machineScope.DatacenterID()),
+		Properties: &sdk.DatacenterProperties{Location: ptr.To("de/txl")},
+	}
+}
+
+func (s *bootstrapImageSuite) image(id, location, state string) sdk.Image {
+	return sdk.Image{
+		Id:       ptr.To(id),
+		Metadata: &sdk.DatacenterElementMetadata{State: ptr.To(state)},
+		Properties: &sdk.ImageProperties{
+			Name:      ptr.To(s.service.bootstrapImageName(s.infraMachine)),
+			Location:  ptr.To(location),
+			ImageType: ptr.To(imageTypeCDROM),
+		},
+	}
+}
+
+func (s *bootstrapImageSuite) renderedData() string {
+	return base64.StdEncoding.EncodeToString([]byte("#cloud-config\n" + strings.Repeat("#", maxUserDataSize)))
+}
+
+func (s *bootstrapImageSuite) TestUseBootstrapImage() {
+	s.False(useBootstrapImage(strings.Repeat("a", maxUserDataSize)))
+	s.True(useBootstrapImage(strings.Repeat("a", maxUserDataSize+1)))
+}
+
+func (s *bootstrapImageSuite) TestReconcileBootstrapImageUpload() {
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{Items: &[]sdk.Image{
+		s.image("other-location", "us/las", sdk.Available),
+	}}, nil).Once()
+	s.ionosClient.EXPECT().UploadImage(s.ctx, "de/txl", "cidata-"+s.infraMachine.Name+".iso", mock.Anything).
+		Return(nil).Once()
+
+	imageID, err := s.service.reconcileBootstrapImage(s.ctx, s.machineScope, s.datacenter(), s.renderedData())
+	s.NoError(err)
+	s.Empty(imageID)
+	s.Equal(infrav1.BootstrapImageProcessingReason,
+		conditions.GetReason(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+}
+
+func (s *bootstrapImageSuite) TestReconcileBootstrapImageProcessing() {
+	conditions.MarkFalse(s.infraMachine, infrav1.BootstrapImageAvailableCondition,
+		infrav1.BootstrapImageProcessingReason, "Info", "")
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{}, nil).Once()
+
+	imageID, err := s.service.reconcileBootstrapImage(s.ctx, s.machineScope, s.datacenter(), s.renderedData())
+	s.NoError(err)
+	s.Empty(imageID)
+}
+
+func (s *bootstrapImageSuite) TestReconcileBootstrapImageProcessingTimeout() {
+	conditions.MarkFalse(s.infraMachine, infrav1.BootstrapImageAvailableCondition,
+		infrav1.BootstrapImageProcessingReason, "Info", "")
+	s.infraMachine.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-bootstrapImageProcessingTimeout))
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{}, nil).Once()
+	s.ionosClient.EXPECT().UploadImage(s.ctx, "de/txl", mock.Anything, mock.Anything).Return(nil).Once()
+
+	imageID, err := s.service.reconcileBootstrapImage(s.ctx, s.machineScope, s.datacenter(), s.renderedData())
+	s.NoError(err)
+	s.Empty(imageID)
+}
+
+func (s *bootstrapImageSuite) TestReconcileBootstrapImageBusy() {
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{Items: &[]sdk.Image{
+		s.image(exampleBootstrapImageID, "de/txl", sdk.Busy),
+	}}, nil).Once()
+
+	imageID, err := s.service.reconcileBootstrapImage(s.ctx, s.machineScope, s.datacenter(), s.renderedData())
+	s.NoError(err)
+	s.Empty(imageID)
+}
+
+func (s *bootstrapImageSuite) TestReconcileBootstrapImageAvailable() {
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{Items: &[]sdk.Image{
+		s.image(exampleBootstrapImageID, "de/txl", sdk.Available),
+	}}, nil).Once()
+
+	imageID, err := s.service.reconcileBootstrapImage(s.ctx, s.machineScope, s.datacenter(), s.renderedData())
+	s.NoError(err)
+	s.Equal(exampleBootstrapImageID, imageID)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+}
+
+func (s *bootstrapImageSuite) TestBuildServerEntitiesBootstrapImage() {
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		boostrapData:     s.renderedData(),
+		bootstrapImageID: exampleBootstrapImageID,
+		machineSpec:      s.infraMachine.Spec,
+		lanID:            1,
+	})
+
+	s.Nil((*entities.Volumes.Items)[0].Properties.UserData)
+	s.Equal([]sdk.Image{{Id: ptr.To(exampleBootstrapImageID)}}, *entities.Cdroms.Items)
+}
+
+func (s *bootstrapImageSuite) TestDeleteBootstrapImagesNoCondition() {
+	requeue, err := s.service.deleteBootstrapImages(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *bootstrapImageSuite) TestDeleteBootstrapImages() {
+	conditions.MarkTrue(s.infraMachine, infrav1.BootstrapImageAvailableCondition)
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{Items: &[]sdk.Image{
+		s.image(exampleBootstrapImageID, "de/txl", sdk.Available),
+	}}, nil).Once()
+	s.ionosClient.EXPECT().DeleteImage(s.ctx, exampleBootstrapImageID).Return("delete/location", nil).Once()
+
+	requeue, err := s.service.deleteBootstrapImages(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+
+	s.ionosClient.EXPECT().ListImages(s.ctx).Return(&sdk.Images{}, nil).Once()
+	requeue, err = s.service.deleteBootstrapImages(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// reconcileBootstrapRedelivery re-delivers the bootstrap data to the server, if the machine is annotated
+// with the RedeliverBootstrapDataAnnotation. As the Cloud API only injects user data into volumes while
+// they are created, the boot volume is replaced by a new volume, which is created from the same image
+// with the rendered bootstrap data. The server is stopped during the replacement and the previous
+// boot volume is deleted afterward, so that all data on it is lost.
+// It returns true, as long as the re-delivery is in progress.
+func (s *Service) reconcileBootstrapRedelivery(
+	ctx context.Context, ms *scope.Machine, secret *corev1.Secret, server *sdk.Server,
+) (bool, error) {
+	log := s.logger.WithName("reconcileBootstrapRedelivery")
+
+	status := &ms.IonosMachine.Status
+	if status.BootstrapRedelivery == nil {
+		if ms.IonosMachine.Annotations[infrav1.RedeliverBootstrapDataAnnotation] != "true" {
+			return false, nil
+		}
+		// The annotation is only removed once the request has been handled, so that it is kept on transient errors.
+		_, unsupported, err := s.renderRedeliveredUserData(ctx, ms, secret, false)
+		if err != nil {
+			return false, err
+		}
+		if unsupported != "" {
+			log.Info("Ignoring bootstrap data re-delivery", "reason", unsupported)
+			s.recordWarningEvent(ms.IonosMachine, bootstrapRedeliveryUnsupportedReason,
+				"Bootstrap data cannot be re-delivered: %s", unsupported)
+			delete(ms.IonosMachine.Annotations, infrav1.RedeliverBootstrapDataAnnotation)
+			return false, nil
+		}
+
+		volumeID := ptr.Deref(server.GetProperties().GetBootVolume().GetId(), "")
+		if volumeID == "" {
+			log.Info("Ignoring bootstrap data re-delivery, as the server doesn't boot from a volume")
+			s.recordWarningEvent(ms.IonosMachine, bootstrapRedeliveryUnsupportedReason,
+				"Bootstrap data cannot be re-delivered: server doesn't boot from a volume")
+			delete(ms.IonosMachine.Annotations, infrav1.RedeliverBootstrapDataAnnotation)
+			return false, nil
+		}
+		status.BootstrapRedelivery = &infrav1.BootstrapRedeliveryStatus{PreviousVolumeID: volumeID}
+		delete(ms.IonosMachine.Annotations, infrav1.RedeliverBootstrapDataAnnotation)
+		s.recordEvent(ms.IonosMachine, bootstrapRedeliveryRequestedReason,
+			"Re-delivering bootstrap data by replacing boot volume %s", volumeID)
+	}
+
+	redelivery := status.BootstrapRedelivery
+	conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapDeliveredCondition,
+		infrav1.RedeliveringBootstrapDataReason, clusterv1.ConditionSeverityWarning,
+		"bootstrap data is re-delivered by replacing boot volume %s: the server is stopped "+
+			"and all data on the boot volume is lost", redelivery.PreviousVolumeID)
+
+	// The boot volume must not be replaced while the server is running.
+	if requeue, err := s.ensureServerStopped(ctx, ms, server); requeue || err != nil {
+		return true, err
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	attached := func(volumeID string) bool {
+		return slices.ContainsFunc(ptr.Deref(server.GetEntities().GetVolumes().GetItems(), nil),
+			func(v sdk.Volume) bool { return ptr.Deref(v.GetId(), "") == volumeID })
+	}
+
+	bootVolumeID := ptr.Deref(server.GetProperties().GetBootVolume().GetId(), "")
+	if bootVolumeID == redelivery.PreviousVolumeID {
+		if redelivery.VolumeID == "" || !attached(redelivery.VolumeID) {
+			// The volume is created again, if its creation failed.
+			return true, s.createRedeliveredBootVolume(ctx, ms, secret, server)
+		}
+
+		properties := sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: &redelivery.VolumeID}}
+		requestLocation, err := s.ionosClient.PatchServer(ctx, ms.DatacenterID(), serverID, properties)
+		if err != nil {
+			return true, fmt.Errorf("failed to request boot from volume %s: %w", redelivery.VolumeID, err)
+		}
+
+		ms.IonosMachine.SetCurrentRequest(http.MethodPatch, sdk.RequestStatusQueued, requestLocation)
+		s.recordEvent(ms.IonosMachine, serverUpdateRequestedReason,
+			"Requested boot of server %s from volume %s", serverID, redelivery.VolumeID)
+		log.V(4).Info("Successfully requested boot from volume", "volumeID", redelivery.VolumeID,
+			"location", requestLocation)
+		return true, nil
+	}
+
+	if attached(redelivery.PreviousVolumeID) {
+		requestLocation, err := s.ionosClient.DeleteVolume(ctx, ms.DatacenterID(), redelivery.PreviousVolumeID)
+		if err != nil {
+			return true, fmt.Errorf("failed to request boot volume deletion: %w", err)
+		}
+
+		ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+		s.recordEvent(ms.IonosMachine, volumeDeletionRequestedReason,
+			"Requested deletion of boot volume %s", redelivery.PreviousVolumeID)
+		log.V(4).Info("Successfully requested for boot volume deletion", "location", requestLocation)
+		return true, nil
+	}
+
+	log.Info("Bootstrap data was re-delivered", "volumeID", bootVolumeID)
+	s.recordEvent(ms.IonosMachine, bootstrapRedeliveredReason,
+		"Re-delivered bootstrap data with boot volume %s", bootVolumeID)
+	status.BootstrapRedelivery = nil
+	conditions.MarkTrue(ms.IonosMachine, infrav1.BootstrapDeliveredCondition)
+	// The server is started again, once it is ensured to be available.
+	return false, nil
+}
+
+// createRedeliveredBootVolume requests the creation of a new boot volume of the server, which contains
+// the rendered bootstrap data, and stores its ID in the status of the machine.
+func (s *Service) createRedeliveredBootVolume(
+	ctx context.Context, ms *scope.Machine, secret *corev1.Secret, server *sdk.Server,
+) error {
+	log := s.logger.WithName("createRedeliveredBootVolume")
+
+	userData, unsupported, err := s.renderRedeliveredUserData(ctx, ms, secret, true)
+	if err != nil {
+		return err
+	}
+	if unsupported != "" {
+		return fmt.Errorf("unable to re-deliver bootstrap data: %s", unsupported)
+	}
+
+	redelivery := ms.IonosMachine.Status.BootstrapRedelivery
+	disk := ms.IonosMachine.Spec.Disk
+	imageID := redeliveredImageID(ms)
+	properties := sdk.VolumeProperties{
+		AvailabilityZone: ptr.To(disk.AvailabilityZone.String()),
+		Name:             ptr.To(s.volumeName(ms.IonosMachine)),
+		Size:             ptr.To(float32(disk.SizeGB)),
+		Type:             ptr.To(disk.EffectiveDiskType().String()),
+		Image:            &imageID,
+		UserData:         &userData,
+	}
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), nil) {
+		// The new volume keeps the size of the previous volume, which might have been resized.
+		if ptr.Deref(volume.GetId(), "") == redelivery.PreviousVolumeID && volume.GetProperties().GetSize() != nil {
+			properties.Size = volume.GetProperties().GetSize()
+		}
+	}
+	if disk.Image != nil && disk.Image.Snapshot == nil && disk.Image.Private == nil &&
+		len(ms.IonosMachine.Spec.SSHKeys) > 0 {
+		// The Cloud API only injects SSH keys into volumes, which are created from a public image.
+		properties.SshKeys = &ms.IonosMachine.Spec.SSHKeys
+	}
+	if disk.Bus != "" {
+		properties.Bus = ptr.To(disk.Bus.String())
+	}
+	if disk.BackupUnitID != "" {
+		properties.BackupunitId = &disk.BackupUnitID
+	}
+	if _, bootOrder := bootConfig(&ms.IonosMachine.Spec); bootOrder != "" {
+		properties.BootOrder = ptr.To(bootOrder.String())
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	volume, requestLocation, err := s.ionosClient.CreateServerVolume(ctx, ms.DatacenterID(), serverID, properties)
+	if err != nil {
+		return fmt.Errorf("failed to request boot volume creation: %w", err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, requestLocation)
+	redelivery.VolumeID = ptr.Deref(volume.GetId(), "")
+	s.recordEvent(ms.IonosMachine, volumeCreationRequestedReason,
+		"Requested creation of boot volume %s for server %s", redelivery.VolumeID, serverID)
+	if redelivery.VolumeID == "" {
+		return errors.New("volume ID is empty")
+	}
+	log.V(4).Info("Successfully requested for boot volume creation", "location", requestLocation)
+	return nil
+}
+
+// renderRedeliveredUserData renders the bootstrap data of the machine as user data of its boot volume in the same
+// way as for the creation of its server. The bootstrap data is only uploaded to the bootstrap storage, if upload
+// is true. If the bootstrap data of the machine cannot be delivered with its boot volume, the reason is returned.
+func (s *Service) renderRedeliveredUserData(
+	ctx context.Context, ms *scope.Machine, secret *corev1.Secret, upload bool,
+) (userData, unsupported string, err error) {
+	switch {
+	case ms.IonosMachine.Spec.Type == infrav1.ServerTypeCube:
+		return "", "boot volumes of CUBE servers cannot be replaced", nil
+	case ms.IonosMachine.Spec.CDROM != nil:
+		return "", "operating system is installed from a CD-ROM", nil
+	case redeliveredImageID(ms) == "":
+		return "", "boot volume was not created from a known image", nil
+	}
+
+	bootstrapData, exists := secret.Data["value"]
+	if !exists {
+		return "", "", errors.New("unable to obtain bootstrap data from secret")
+	}
+
+	format := getBootstrapDataFormat(secret)
+	if format == bootstrapDataFormatTalos {
+		return "", "Talos machine configs are delivered with a bootstrap image", nil
+	}
+
+	additionalUserData, err := s.getAdditionalUserData(ctx, ms)
+	if err != nil {
+		return "", "", err
+	}
+	renderedData, err := s.renderUserData(ms, string(bootstrapData), format, additionalUserData...)
+	if err != nil {
+		return "", "", err
+	}
+
+	storage := s.bootstrapStorage(ms)
+	if storage == nil && format == bootstrapDataFormatCloudConfig {
+		if renderedData, err = s.compressUserData(ms, renderedData); err != nil {
+			return "", "", err
+		}
+	}
+	if storage == nil && useBootstrapImage(renderedData) {
+		return "", fmt.Sprintf("user data of %d bytes exceeds the limit of %d bytes", len(renderedData),
+			maxUserDataSize), nil
+	}
+
+	if storage != nil && upload {
+		if renderedData, err = s.uploadBootstrapData(ctx, ms, storage, renderedData, format); err != nil {
+			return "", "", err
+		}
+	}
+	return renderedData, "", nil
+}
+
+// redeliveredImageID returns the ID of the image, from which the boot volume of the machine was created.
+func redeliveredImageID(ms *scope.Machine) string {
+	if id := ms.IonosMachine.Status.ResolvedImageID; id != "" {
+		return id
+	}
+	if image := ms.IonosMachine.Spec.Disk.Image; image != nil {
+		return image.ID
+	}
+	return ""
+}
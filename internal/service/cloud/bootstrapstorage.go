@@ -0,0 +1,159 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// bootstrapObjectPrefix is the prefix of the keys of all bootstrap data objects.
+	// The lifecycle rule of the bucket only applies to objects with this prefix.
+	bootstrapObjectPrefix = "bootstrap/"
+
+	// bootstrapLifecycleRuleID is the ID of the lifecycle rule, which deletes expired bootstrap data.
+	bootstrapLifecycleRuleID = "cluster-api-provider-ionoscloud-bootstrap"
+
+	defaultBootstrapURLExpiry      = 24 * time.Hour
+	defaultBootstrapExpirationDays = 1
+)
+
+// WithObjectStorage makes the Service upload the bootstrap data of machines to the bucket
+// configured in the bootstrap storage of the cluster.
+func WithObjectStorage(storage ionoscloud.ObjectStorage) Option {
+	return func(s *Service) {
+		s.objectStorage = storage
+	}
+}
+
+// bootstrapStorage returns the bootstrap storage of the cluster of the machine, or nil if the
+// bootstrap data is passed as user data directly.
+func (s *Service) bootstrapStorage(ms *scope.Machine) *infrav1.BootstrapStorageSpec {
+	if s.objectStorage == nil {
+		return nil
+	}
+	return ms.ClusterScope.IonosCluster.Spec.BootstrapStorage
+}
+
+// uploadBootstrapData uploads the rendered user data of the machine to the bootstrap storage and
+// returns the base64 encoded user data, which only fetches the uploaded data via a pre-signed URL.
+func (s *Service) uploadBootstrapData(
+	ctx context.Context, ms *scope.Machine, storage *infrav1.BootstrapStorageSpec,
+	renderedData string, format bootstrapDataFormat,
+) (string, error) {
+	log := s.logger.WithName("uploadBootstrapData")
+
+	userData, err := base64.StdEncoding.DecodeString(renderedData)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode user data: %w", err)
+	}
+
+	expirationDays := storage.ExpirationDays
+	if expirationDays == 0 {
+		expirationDays = defaultBootstrapExpirationDays
+	}
+	if err := s.objectStorage.EnsureExpirationRule(
+		ctx, storage.Bucket, bootstrapLifecycleRuleID, bootstrapObjectPrefix, expirationDays,
+	); err != nil {
+		return "", fmt.Errorf("failed to configure lifecycle of bucket %s: %w", storage.Bucket, err)
+	}
+
+	key := bootstrapObjectKey(ms)
+	if err := s.objectStorage.PutObject(ctx, storage.Bucket, key, userData); err != nil {
+		return "", fmt.Errorf("failed to upload bootstrap data to bucket %s: %w", storage.Bucket, err)
+	}
+
+	expiry := defaultBootstrapURLExpiry
+	if storage.URLExpiry != nil {
+		expiry = storage.URLExpiry.Duration
+	}
+	presignedURL, err := s.objectStorage.PresignGetObject(storage.Bucket, key, expiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to pre-sign URL of bootstrap data: %w", err)
+	}
+	log.Info("Successfully uploaded bootstrap data", "bucket", storage.Bucket, "key", key)
+
+	fetchData, err := bootstrapFetchData(presignedURL, string(userData), format)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(fetchData)), nil
+}
+
+// deleteBootstrapData deletes the uploaded bootstrap data of the machine from the bootstrap storage.
+func (s *Service) deleteBootstrapData(ctx context.Context, ms *scope.Machine) error {
+	storage := s.bootstrapStorage(ms)
+	if storage == nil {
+		return nil
+	}
+
+	key := bootstrapObjectKey(ms)
+	if err := s.objectStorage.DeleteObject(ctx, storage.Bucket, key); err != nil {
+		return fmt.Errorf("failed to delete bootstrap data from bucket %s: %w", storage.Bucket, err)
+	}
+	s.logger.Info("Successfully deleted bootstrap data", "bucket", storage.Bucket, "key", key)
+	return nil
+}
+
+// bootstrapObjectKey returns the key of the bootstrap data object of the machine.
+func bootstrapObjectKey(ms *scope.Machine) string {
+	return bootstrapObjectPrefix + path.Join(
+		ms.IonosMachine.Namespace, ms.ClusterScope.Cluster.Name, ms.IonosMachine.Name,
+	)
+}
+
+// bootstrapFetchData returns the user data, which makes the VM fetch its bootstrap data from the given URL.
+// cloud-init includes the fetched data via an include file, while Ignition replaces its config with it.
+func bootstrapFetchData(presignedURL, userData string, format bootstrapDataFormat) (string, error) {
+	switch format {
+	case bootstrapDataFormatCloudConfig:
+		return "#include\n" + presignedURL + "\n", nil
+	case bootstrapDataFormatIgnition:
+		var config struct {
+			Ignition struct {
+				Version string `json:"version"`
+			} `json:"ignition"`
+		}
+		if err := json.Unmarshal([]byte(userData), &config); err != nil {
+			return "", fmt.Errorf("unable to parse Ignition config: %w", err)
+		}
+		stub := map[string]any{
+			"ignition": map[string]any{
+				"version": config.Ignition.Version,
+				"config": map[string]any{
+					"replace": map[string]any{"source": presignedURL},
+				},
+			},
+		}
+		data, err := json.Marshal(stub)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return "", fmt.Errorf("unsupported bootstrap data format %q", format)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const examplePresignedURL = "https://bucket.s3.example.com/bootstrap/default/test/machine?X-Amz-Expires=86400"
+
+func TestBootstrapFetchData(t *testing.T) {
+	data, err := bootstrapFetchData(examplePresignedURL, "#cloud-config\n", bootstrapDataFormatCloudConfig)
+	require.NoError(t, err)
+	require.Equal(t, "#include\n"+examplePresignedURL+"\n", data)
+
+	data, err = bootstrapFetchData(examplePresignedURL, `{"ignition":{"version":"3.4.0"}}`, bootstrapDataFormatIgnition)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"ignition":{"version":"3.4.0","config":{"replace":{"source":"`+examplePresignedURL+`"}}}}`, data)
+
+	_, err = bootstrapFetchData(examplePresignedURL, "#cloud-config\n", bootstrapDataFormatIgnition)
+	require.Error(t, err)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const crossConnectsPath = "pccs"
+
+// crossConnectName returns the name of the Cross Connect, which connects the LANs of a cluster network.
+func (*Service) crossConnectName(c *clusterv1.Cluster, network string) string {
+	return fmt.Sprintf(
+		"pcc-%s-%s-%s",
+		c.Namespace,
+		c.Name,
+		network)
+}
+
+func (*Service) crossConnectURL(id string) string {
+	return path.Join(crossConnectsPath, id)
+}
+
+// reconcileCrossConnects ensures that the Cross Connects of the cluster networks exist and are available.
+// Cross Connects, which are referenced by ID, are expected to exist already.
+// It returns the IDs of the Cross Connects by the names of their networks.
+func (s *Service) reconcileCrossConnects(
+	ctx context.Context, cs *scope.Cluster,
+) (crossConnectIDs map[string]string, requeue bool, err error) {
+	log := s.logger.WithName("reconcileCrossConnects")
+
+	crossConnectIDs = make(map[string]string)
+	for _, network := range cs.IonosCluster.Spec.Networks {
+		if network.CrossConnect == nil {
+			continue
+		}
+
+		if id := network.CrossConnect.ID; id != "" {
+			crossConnect, err := s.getCrossConnectByID(ctx, id)
+			if err != nil {
+				return nil, false, err
+			}
+			if state := getState(crossConnect); !isAvailable(state) {
+				log.Info("Cross Connect is not available yet", "id", id, "state", state)
+				return nil, true, nil
+			}
+			crossConnectIDs[network.Name] = id
+			continue
+		}
+
+		name := s.crossConnectName(cs.Cluster, network.Name)
+		crossConnect, request, err := s.findCrossConnect(ctx, name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if crossConnect != nil {
+			if state := getState(crossConnect); !isAvailable(state) {
+				log.Info("Cross Connect is not available yet", "name", name, "state", state)
+				return nil, true, nil
+			}
+			crossConnectIDs[network.Name] = ptr.Deref(crossConnect.GetId(), "")
+			continue
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+			log.Info("Request is pending", "location", request.location, "requestID", request.id(), "name", name)
+			return nil, true, nil
+		}
+
+		log.V(4).Info("No Cross Connect was found. Creating new Cross Connect", "name", name)
+		return nil, true, s.createCrossConnect(ctx, cs, name)
+	}
+
+	return crossConnectIDs, false, nil
+}
+
+// ReconcileCrossConnectsDeletion ensures that the Cross Connects, which have been created for the cluster networks,
+// are deleted. Cross Connects, which are referenced by ID, are kept.
+// It needs to run after the LANs of the networks have been deleted.
+func (s *Service) ReconcileCrossConnectsDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileCrossConnectsDeletion")
+
+	for _, network := range cs.IonosCluster.Spec.Networks {
+		if network.CrossConnect == nil || network.CrossConnect.ID != "" {
+			continue
+		}
+
+		name := s.crossConnectName(cs.Cluster, network.Name)
+		crossConnect, request, err := s.findCrossConnect(ctx, name)
+		if err != nil {
+			return false, err
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+			log.Info("Creation request is pending", "location", request.location, "requestID", request.id(), "name", name)
+			return true, nil
+		}
+
+		if crossConnect == nil {
+			continue
+		}
+
+		crossConnectID := ptr.Deref(crossConnect.GetId(), "")
+		request, err = getMatchingRequest[sdk.PrivateCrossConnect](
+			ctx, s, http.MethodDelete, s.crossConnectURL(crossConnectID),
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+			log.Info("Deletion request is pending", "location", request.location, "requestID", request.id(), "name", name)
+			return true, nil
+		}
+
+		requestPath, err := s.ionosClient.DeleteCrossConnect(ctx, crossConnectID)
+		if err != nil {
+			return false, fmt.Errorf("unable to request deletion of Cross Connect %s: %w", name, err)
+		}
+
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+		s.recordEvent(cs.IonosCluster, crossConnectDeletionRequestedReason,
+			"Requested deletion of Cross Connect %s", crossConnectID)
+		log.Info("Successfully requested for Cross Connect deletion", "requestPath", requestPath, "name", name)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// findCrossConnect tries to retrieve the Cross Connect with the given name or the request, which creates it.
+func (s *Service) findCrossConnect(
+	ctx context.Context, name string,
+) (*sdk.PrivateCrossConnect, *requestInfo, error) {
+	return findResource(ctx,
+		func(ctx context.Context) (*sdk.PrivateCrossConnect, error) {
+			return s.getCrossConnectByName(ctx, name)
+		},
+		func(ctx context.Context) (*requestInfo, error) {
+			return getMatchingRequest(ctx, s, http.MethodPost, crossConnectsPath,
+				matchByName[*sdk.PrivateCrossConnect, *sdk.PrivateCrossConnectProperties](name))
+		},
+	)
+}
+
+// getCrossConnectByID returns the Cross Connect with the given ID.
+// An error is returned, if there is no such Cross Connect.
+func (s *Service) getCrossConnectByID(ctx context.Context, id string) (*sdk.PrivateCrossConnect, error) {
+	crossConnects, err := s.listCrossConnects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, crossConnect := range crossConnects {
+		if ptr.Deref(crossConnect.GetId(), "") == id {
+			return &crossConnect, nil
+		}
+	}
+
+	return nil, fmt.Errorf("referenced Cross Connect %s does not exist", id)
+}
+
+// getCrossConnectByName returns the Cross Connect with the given name.
+// Nil is returned, if there is no such Cross Connect.
+func (s *Service) getCrossConnectByName(ctx context.Context, name string) (*sdk.PrivateCrossConnect, error) {
+	crossConnects, err := s.listCrossConnects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *sdk.PrivateCrossConnect
+	for _, crossConnect := range crossConnects {
+		if ptr.Deref(crossConnect.GetProperties().GetName(), "") != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple Cross Connects with the name: %s", name)
+		}
+		found = &crossConnect
+	}
+
+	return found, nil
+}
+
+func (s *Service) listCrossConnects(ctx context.Context) ([]sdk.PrivateCrossConnect, error) {
+	crossConnects, err := s.apiWithDepth(1).ListCrossConnects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Cross Connects: %w", err)
+	}
+	return ptr.Deref(crossConnects.GetItems(), nil), nil
+}
+
+func (s *Service) createCrossConnect(ctx context.Context, cs *scope.Cluster, name string) error {
+	log := s.logger.WithName("createCrossConnect")
+
+	properties := sdk.PrivateCrossConnectProperties{
+		Name:        ptr.To(name),
+		Description: ptr.To(fmt.Sprintf("Connects the LANs of cluster %s/%s", cs.Cluster.Namespace, cs.Cluster.Name)),
+	}
+	requestPath, err := s.ionosClient.CreateCrossConnect(ctx, properties)
+	if err != nil {
+		return fmt.Errorf("unable to create Cross Connect %s: %w", name, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, crossConnectCreationRequestedReason, "Requested creation of Cross Connect %s", name)
+	log.Info("Successfully requested for Cross Connect creation", "requestPath", requestPath)
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// datacenterName returns the name of the data center, which is owned by the cluster.
+func (*Service) datacenterName(cs *scope.Cluster) string {
+	if name := cs.IonosCluster.Spec.Datacenter.Name; name != "" {
+		return name
+	}
+	return fmt.Sprintf("dc-%s-%s", cs.Cluster.Namespace, cs.Cluster.Name)
+}
+
+func (*Service) datacentersURL() string {
+	return "datacenters"
+}
+
+func (*Service) datacenterURL(id string) string {
+	return path.Join("datacenters", id)
+}
+
+// ReconcileDatacenter ensures that the data center owned by the cluster exists, creating one if it doesn't.
+// Once the data center is available, it is labeled with the name and the labels of the cluster.
+func (s *Service) ReconcileDatacenter(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileDatacenter")
+
+	if cs.IonosCluster.Spec.Datacenter == nil {
+		return false, nil
+	}
+
+	datacenter, request, err := scopedFindResource(ctx, cs, s.getDatacenter, s.getLatestDatacenterCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if datacenter != nil {
+		datacenterID := ptr.Deref(datacenter.GetId(), "")
+		cs.SetDatacenterID(datacenterID)
+		if state := getState(datacenter); !isAvailable(state) {
+			log.Info("Data center is not available yet", "state", state)
+			return true, nil
+		}
+		ops := s.datacenterLabelOperations(datacenterID)
+		if err := s.reconcileLabels(ctx, ops, clusterLabels(cs), true); err != nil {
+			return false, fmt.Errorf("could not reconcile labels of data center %s: %w", datacenterID, err)
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No data center was found. Creating new data center")
+	if err := s.createDatacenter(ctx, cs); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReconcileDatacenterDeletion ensures that the data center owned by the cluster is deleted.
+// The data center is only deleted if it does not contain any servers or LANs anymore.
+// Otherwise, it is kept to not delete resources, which are not managed by the cluster.
+func (s *Service) ReconcileDatacenterDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileDatacenterDeletion")
+
+	if cs.IonosCluster.Spec.Datacenter == nil {
+		return false, nil
+	}
+
+	datacenter, request, err := scopedFindResource(ctx, cs, s.getDatacenter, s.getLatestDatacenterCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if datacenter == nil {
+		cs.SetDatacenterID("")
+		return false, nil
+	}
+
+	datacenterID := ptr.Deref(datacenter.GetId(), "")
+	request, err = getMatchingRequest[sdk.Datacenter](ctx, s, http.MethodDelete, s.datacenterURL(datacenterID))
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	empty, err := s.isDatacenterEmpty(ctx, datacenterID)
+	if err != nil {
+		return false, err
+	}
+	if !empty {
+		log.Info("Data center still contains servers or LANs and is not deleted", "datacenterID", datacenterID)
+		return false, nil
+	}
+
+	requestPath, err := s.ionosClient.DeleteDatacenter(ctx, datacenterID)
+	if err != nil {
+		return false, fmt.Errorf("unable to request data center deletion: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, datacenterDeletionRequestedReason, "Requested deletion of data center %s", datacenterID)
+	log.Info("Successfully requested for data center deletion", "requestPath", requestPath)
+	return true, nil
+}
+
+// getDatacenter tries to retrieve the data center of the cluster by its name and location.
+func (s *Service) getDatacenter(ctx context.Context, cs *scope.Cluster) (*sdk.Datacenter, error) {
+	datacenters, err := s.apiWithDepth(1).ListDatacenters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list data centers: %w", err)
+	}
+
+	var (
+		expectedName = s.datacenterName(cs)
+		count        = 0
+		foundDC      *sdk.Datacenter
+	)
+
+	for _, datacenter := range ptr.Deref(datacenters.GetItems(), nil) {
+		props := datacenter.GetProperties()
+		if ptr.Deref(props.GetName(), "") == expectedName && ptr.Deref(props.GetLocation(), "") == cs.Location() {
+			foundDC = &datacenter
+			count++
+		}
+
+		if count > 1 {
+			return nil, fmt.Errorf("found multiple data centers with the name: %s", expectedName)
+		}
+	}
+
+	return foundDC, nil
+}
+
+func (s *Service) getLatestDatacenterCreationRequest(ctx context.Context, cs *scope.Cluster) (*requestInfo, error) {
+	return getMatchingRequest(
+		ctx, s, http.MethodPost,
+		s.datacentersURL(),
+		matchByName[*sdk.Datacenter, *sdk.DatacenterProperties](s.datacenterName(cs)),
+	)
+}
+
+func (s *Service) createDatacenter(ctx context.Context, cs *scope.Cluster) error {
+	log := s.logger.WithName("createDatacenter")
+
+	properties := sdk.DatacenterProperties{
+		Name:     ptr.To(s.datacenterName(cs)),
+		Location: ptr.To(cs.Location()),
+	}
+	if description := cs.IonosCluster.Spec.Datacenter.Description; description != "" {
+		properties.Description = &description
+	}
+
+	requestPath, err := s.ionosClient.CreateDatacenter(ctx, properties)
+	if err != nil {
+		return fmt.Errorf("unable to create data center: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, datacenterCreationRequestedReason,
+		"Requested creation of data center %s in %s", *properties.Name, *properties.Location)
+	log.Info("Successfully requested for data center creation", "requestPath", requestPath)
+	return nil
+}
+
+// isDatacenterEmpty returns true if the data center does not contain any servers or LANs.
+func (s *Service) isDatacenterEmpty(ctx context.Context, datacenterID string) (bool, error) {
+	servers, err := s.ionosClient.ListServers(ctx, datacenterID)
+	if err != nil {
+		return false, fmt.Errorf("could not list servers in data center %s: %w", datacenterID, err)
+	}
+	if len(ptr.Deref(servers.GetItems(), nil)) > 0 {
+		return false, nil
+	}
+
+	lans, err := s.ionosClient.ListLANs(ctx, datacenterID)
+	if err != nil {
+		return false, fmt.Errorf("could not list LANs in data center %s: %w", datacenterID, err)
+	}
+	return len(ptr.Deref(lans.GetItems(), nil)) == 0, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const exampleClusterDatacenterID = "3f7c8e2a-4d1b-4e9a-8c6f-2b5d7a9e1c04"
+
+type datacenterTestSuite struct {
+	ServiceTestSuite
+}
+
+func TestDatacenterTestSuite(t *testing.T) {
+	suite.Run(t, new(datacenterTestSuite))
+}
+
+func (s *datacenterTestSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraCluster.Spec.Datacenter = &infrav1.DatacenterSpec{}
+}
+
+func (s *datacenterTestSuite) TestDatacenterName() {
+	s.Equal("dc-default-test-cluster", s.service.datacenterName(s.clusterScope))
+
+	s.infraCluster.Spec.Datacenter.Name = "custom"
+	s.Equal("custom", s.service.datacenterName(s.clusterScope))
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterNotConfigured() {
+	s.infraCluster.Spec.Datacenter = nil
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileDatacenterDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterCreate() {
+	s.infraCluster.Spec.Datacenter.Description = "test description"
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(&sdk.Datacenters{Items: &[]sdk.Datacenter{}}, nil).Once()
+	s.mockGetDatacenterCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateDatacenter(s.ctx, sdk.DatacenterProperties{
+		Name:        ptr.To(s.service.datacenterName(s.clusterScope)),
+		Location:    ptr.To(exampleLocation),
+		Description: ptr.To("test description"),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+	s.Equal(exampleRequestPath, s.infraCluster.Status.CurrentClusterRequest.RequestPath)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterCreationPending() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(&sdk.Datacenters{Items: &[]sdk.Datacenter{}}, nil).Once()
+	s.mockGetDatacenterCreationRequestsCall().Return([]sdk.Request{
+		s.exampleRequest(requestBuildOptions{
+			status:     sdk.RequestStatusRunning,
+			method:     http.MethodPost,
+			url:        s.service.datacentersURL(),
+			body:       `{"properties": {"name": "dc-default-test-cluster"}}`,
+			href:       exampleRequestPath,
+			targetType: sdk.DATACENTER,
+		}),
+	}, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(sdk.RequestStatusRunning, s.infraCluster.Status.CurrentClusterRequest.State)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterNotAvailable() {
+	datacenters := s.exampleDatacenters()
+	(*datacenters.Items)[0].Metadata.State = ptr.To(sdk.Busy)
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(datacenters, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(exampleClusterDatacenterID, s.infraCluster.Status.DatacenterID)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterIgnoresOtherLocations() {
+	datacenters := s.exampleDatacenters()
+	(*datacenters.Items)[0].Properties.Location = ptr.To("us/las")
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(datacenters, nil).Once()
+	s.mockGetDatacenterCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateDatacenter(s.ctx, sdk.DatacenterProperties{
+		Name:     ptr.To(s.service.datacenterName(s.clusterScope)),
+		Location: ptr.To(exampleLocation),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterAddsLabel() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockListDatacenterLabelsCall().Return(&sdk.LabelResources{Items: &[]sdk.LabelResource{}}, nil).Once()
+	s.ionosClient.EXPECT().
+		CreateDatacenterLabel(s.ctx, exampleClusterDatacenterID, clusterNameLabelKey, s.capiCluster.Name).
+		Return(nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleClusterDatacenterID, s.infraCluster.Status.DatacenterID)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterAlreadyLabeled() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockListDatacenterLabelsCall().Return(&sdk.LabelResources{Items: &[]sdk.LabelResource{{
+		Properties: &sdk.LabelResourceProperties{
+			Key:   ptr.To(clusterNameLabelKey),
+			Value: ptr.To(s.capiCluster.Name),
+		},
+	}}}, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterUpdatesLabels() {
+	s.infraCluster.Spec.Labels = map[string]string{"team": "platform"}
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockListDatacenterLabelsCall().Return(labelResources(map[string]string{
+		clusterNameLabelKey: s.capiCluster.Name,
+		"team":              "infra",
+		"stale":             "true",
+	}), nil).Once()
+	s.ionosClient.EXPECT().UpdateDatacenterLabel(s.ctx, exampleClusterDatacenterID, "team", "platform").
+		Return(nil).Once()
+	s.ionosClient.EXPECT().DeleteDatacenterLabel(s.ctx, exampleClusterDatacenterID, "stale").Return(nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenter(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterDeletion() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockGetDatacenterDeletionRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().ListServers(s.ctx, exampleClusterDatacenterID).
+		Return(&sdk.Servers{Items: &[]sdk.Server{}}, nil).Once()
+	s.ionosClient.EXPECT().ListLANs(s.ctx, exampleClusterDatacenterID).
+		Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.ionosClient.EXPECT().DeleteDatacenter(s.ctx, exampleClusterDatacenterID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenterDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterDeletionKeepsNonEmptyDatacenter() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockGetDatacenterDeletionRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().ListServers(s.ctx, exampleClusterDatacenterID).
+		Return(&sdk.Servers{Items: &[]sdk.Server{}}, nil).Once()
+	s.ionosClient.EXPECT().ListLANs(s.ctx, exampleClusterDatacenterID).
+		Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenterDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraCluster.Status.CurrentClusterRequest)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterDeletionPending() {
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(s.exampleDatacenters(), nil).Once()
+	s.mockGetDatacenterDeletionRequestsCall().Return([]sdk.Request{s.exampleRequest(requestBuildOptions{
+		status:     sdk.RequestStatusQueued,
+		method:     http.MethodDelete,
+		url:        s.service.datacenterURL(exampleClusterDatacenterID),
+		href:       exampleRequestPath,
+		targetID:   exampleClusterDatacenterID,
+		targetType: sdk.DATACENTER,
+	})}, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenterDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *datacenterTestSuite) TestReconcileDatacenterDeletionNotFound() {
+	s.infraCluster.Status.DatacenterID = exampleClusterDatacenterID
+	s.ionosClient.EXPECT().ListDatacenters(s.ctx).Return(&sdk.Datacenters{Items: &[]sdk.Datacenter{}}, nil).Once()
+	s.mockGetDatacenterCreationRequestsCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileDatacenterDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.DatacenterID)
+}
+
+func (s *datacenterTestSuite) exampleDatacenters() *sdk.Datacenters {
+	return &sdk.Datacenters{
+		Items: &[]sdk.Datacenter{{
+			Id: ptr.To(exampleClusterDatacenterID),
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.DatacenterProperties{
+				Name:     ptr.To(s.service.datacenterName(s.clusterScope)),
+				Location: ptr.To(exampleLocation),
+			},
+		}},
+	}
+}
+
+func (s *datacenterTestSuite) mockListDatacenterLabelsCall() *clienttest.MockClient_ListDatacenterLabels_Call {
+	return s.ionosClient.EXPECT().ListDatacenterLabels(s.ctx, exampleClusterDatacenterID)
+}
+
+func (s *datacenterTestSuite) mockGetDatacenterCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodPost, s.service.datacentersURL())
+}
+
+func (s *datacenterTestSuite) mockGetDatacenterDeletionRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodDelete, s.service.datacenterURL(exampleClusterDatacenterID))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+)
+
+// DefaultDatacenterCacheTTL is the time, for which a data center is cached, if the DatacenterCache has no TTL.
+const DefaultDatacenterCacheTTL = 10 * time.Minute
+
+// DatacenterCache caches the metadata of data centers, i.e. their location, CPU families and features, which
+// is needed to create servers. It is shared by the services of all reconciliations, so that machines in the same
+// data center don't request the data center again. The zero value is ready to use.
+type DatacenterCache struct {
+	// TTL is the time, after which a cached data center is requested again. It defaults to DefaultDatacenterCacheTTL.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]datacenterCacheEntry
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+type datacenterCacheEntry struct {
+	datacenter *sdk.Datacenter
+	expiresAt  time.Time
+}
+
+// WithDatacenterCache makes the Service cache the data centers, which it looks up by their ID.
+func WithDatacenterCache(cache *DatacenterCache) Option {
+	return func(s *Service) {
+		s.datacenterCache = cache
+	}
+}
+
+// get returns the cached data center with the given ID, or nil if it is not cached or expired.
+func (c *DatacenterCache) get(datacenterID string) *sdk.Datacenter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[datacenterID]
+	if !ok {
+		return nil
+	}
+	if !c.currentTime().Before(entry.expiresAt) {
+		delete(c.entries, datacenterID)
+		return nil
+	}
+	return entry.datacenter
+}
+
+// set caches the data center with the given ID.
+func (c *DatacenterCache) set(datacenterID string, datacenter *sdk.Datacenter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultDatacenterCacheTTL
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]datacenterCacheEntry)
+	}
+	c.entries[datacenterID] = datacenterCacheEntry{datacenter: datacenter, expiresAt: c.currentTime().Add(ttl)}
+}
+
+// Invalidate removes the data center with the given ID from the cache, so that it is requested again.
+// It does nothing if the cache is nil.
+func (c *DatacenterCache) Invalidate(datacenterID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, datacenterID)
+}
+
+func (c *DatacenterCache) currentTime() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// getDatacenterByID returns the data center with the given ID. If the Service has a DatacenterCache,
+// the data center is only requested if it is not cached. A failed request invalidates the cached data center.
+// The returned data center is shared and must not be modified.
+func (s *Service) getDatacenterByID(ctx context.Context, datacenterID string) (*sdk.Datacenter, error) {
+	if s.datacenterCache == nil {
+		return s.ionosClient.GetDatacenter(ctx, datacenterID)
+	}
+	if datacenter := s.datacenterCache.get(datacenterID); datacenter != nil {
+		return datacenter, nil
+	}
+
+	datacenter, err := s.ionosClient.GetDatacenter(ctx, datacenterID)
+	if err != nil {
+		s.datacenterCache.Invalidate(datacenterID)
+		return nil, err
+	}
+	s.datacenterCache.set(datacenterID, datacenter)
+	return datacenter, nil
+}
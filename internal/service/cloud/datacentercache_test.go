@@ -0,0 +1,98 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+type datacenterCacheSuite struct {
+	ServiceTestSuite
+
+	cache *DatacenterCache
+	now   time.Time
+}
+
+func TestDatacenterCacheSuite(t *testing.T) {
+	suite.Run(t, new(datacenterCacheSuite))
+}
+
+func (s *datacenterCacheSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.now = time.Now()
+	s.cache = &DatacenterCache{TTL: time.Minute, now: func() time.Time { return s.now }}
+	WithDatacenterCache(s.cache)(s.service)
+}
+
+func (s *datacenterCacheSuite) TestCachedDatacenter() {
+	datacenterID := s.machineScope.DatacenterID()
+	datacenter := &sdk.Datacenter{Id: ptr.To(datacenterID)}
+	s.ionosClient.EXPECT().GetDatacenter(s.ctx, datacenterID).Return(datacenter, nil).Once()
+
+	for range 2 {
+		got, err := s.service.getDatacenterByID(s.ctx, datacenterID)
+		s.NoError(err)
+		s.Same(datacenter, got)
+	}
+}
+
+func (s *datacenterCacheSuite) TestExpiredDatacenter() {
+	datacenterID := s.machineScope.DatacenterID()
+	s.ionosClient.EXPECT().GetDatacenter(s.ctx, datacenterID).Return(&sdk.Datacenter{}, nil).Twice()
+
+	_, err := s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+	s.now = s.now.Add(time.Minute)
+	_, err = s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+}
+
+func (s *datacenterCacheSuite) TestInvalidatedDatacenter() {
+	datacenterID := s.machineScope.DatacenterID()
+	s.ionosClient.EXPECT().GetDatacenter(s.ctx, datacenterID).Return(&sdk.Datacenter{}, nil).Twice()
+
+	_, err := s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+	s.cache.Invalidate(datacenterID)
+	_, err = s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+}
+
+func (s *datacenterCacheSuite) TestFailedLookupIsNotCached() {
+	datacenterID := s.machineScope.DatacenterID()
+	s.ionosClient.EXPECT().GetDatacenter(s.ctx, datacenterID).Return(nil, errors.New("timeout")).Once()
+	s.ionosClient.EXPECT().GetDatacenter(s.ctx, datacenterID).Return(&sdk.Datacenter{}, nil).Once()
+
+	_, err := s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.Error(err)
+	_, err = s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+	_, err = s.service.getDatacenterByID(s.ctx, datacenterID)
+	s.NoError(err)
+}
+
+func (s *datacenterCacheSuite) TestInvalidateNilCache() {
+	var cache *DatacenterCache
+	s.NotPanics(func() { cache.Invalidate("dc") })
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// defaultDNSRecordTTL is the time to live of the DNS records in seconds, if no TTL is set.
+	defaultDNSRecordTTL = 300
+
+	// dnsOwnershipRecordType is the type of the records, which mark the DNS records as owned by a cluster.
+	dnsOwnershipRecordType = "TXT"
+)
+
+var errDNSNotConfigured = errors.New("no client for IONOS Cloud DNS is configured")
+
+// WithDNS makes the Service manage the DNS records of the control plane endpoint
+// in the IONOS Cloud DNS zone configured for the cluster.
+func WithDNS(dns ionoscloud.DNS) Option {
+	return func(s *Service) {
+		s.dns = dns
+	}
+}
+
+// ReconcileControlPlaneEndpointDNS ensures that the DNS records of the control plane endpoint exist and point to
+// its IP. Existing records, which are not owned by the cluster, are never taken over. The wildcard record is
+// deleted again, once it is disabled.
+func (s *Service) ReconcileControlPlaneEndpointDNS(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileControlPlaneEndpointDNS")
+
+	spec := cs.IonosCluster.Spec.DNS
+	if spec == nil {
+		return false, nil
+	}
+	if s.dns == nil {
+		return false, errDNSNotConfigured
+	}
+
+	ip, err := cs.GetControlPlaneEndpointIP(ctx)
+	if err != nil {
+		return false, err
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		log.Info("Waiting for the IP of the control plane endpoint", "ip", ip)
+		return true, nil
+	}
+
+	setControlPlaneEndpointDNSAddress(cs, addr.String())
+	status := cs.IonosCluster.Status.ControlPlaneEndpointDNS
+	if status.ZoneID == "" {
+		if status.ZoneID, err = s.dns.GetZoneID(ctx, spec.ZoneName); err != nil {
+			return false, fmt.Errorf("failed to get DNS zone %s: %w", spec.ZoneName, err)
+		}
+	}
+
+	recordType, ttl := "A", spec.TTL
+	if addr.Is6() {
+		recordType = "AAAA"
+	}
+	if ttl == 0 {
+		ttl = defaultDNSRecordTTL
+	}
+
+	names := []string{cs.ControlPlaneEndpointDNSRecordName()}
+	if spec.Wildcard {
+		names = append(names, "*."+names[0])
+	}
+	for _, name := range names {
+		record := ionoscloud.DNSRecord{Name: name, Type: recordType, Content: status.Address, TTL: ttl}
+		if err := s.ensureDNSRecord(ctx, cs, status, record); err != nil {
+			return false, err
+		}
+	}
+
+	for _, name := range dnsRecordNames(status) {
+		if !slices.Contains(names, name) {
+			if err := s.deleteDNSRecord(ctx, cs, status, name); err != nil {
+				return false, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// ReconcileControlPlaneEndpointDNSDeletion ensures that the DNS records of the control plane endpoint are deleted.
+func (s *Service) ReconcileControlPlaneEndpointDNSDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	status := cs.IonosCluster.Status.ControlPlaneEndpointDNS
+	if status == nil || len(status.RecordIDs) == 0 && len(status.OwnershipRecordIDs) == 0 {
+		return false, nil
+	}
+	if s.dns == nil {
+		return false, errDNSNotConfigured
+	}
+
+	for _, name := range dnsRecordNames(status) {
+		if err := s.deleteDNSRecord(ctx, cs, status, name); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// ensureDNSRecord creates the record or updates it, if its content or TTL differ. A record is only updated,
+// if it is owned by the cluster, i.e. its ID is stored in the status or a TXT record with the owner of the
+// cluster exists next to it. Records created by the provider are marked with such a TXT record, and the IDs of
+// both records are stored in the status.
+func (s *Service) ensureDNSRecord(
+	ctx context.Context, cs *scope.Cluster, status *infrav1.DNSStatus, want ionoscloud.DNSRecord,
+) error {
+	log := s.logger.WithName("ensureDNSRecord")
+
+	records, err := s.dns.ListRecords(ctx, status.ZoneID, want.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list DNS records %s: %w", want.Name, err)
+	}
+
+	owner := dnsRecordOwner(cs)
+	var current, ownership *ionoscloud.DNSRecord
+	for i := range records {
+		switch record := &records[i]; {
+		case record.Type == dnsOwnershipRecordType && strings.Trim(record.Content, `"`) == owner:
+			ownership = record
+		case record.Type != want.Type:
+		case current == nil || record.ID == status.RecordIDs[want.Name]:
+			current = record
+		}
+	}
+
+	fqdn := dnsRecordFQDN(cs, want.Name)
+	if current != nil && ownership == nil && current.ID != status.RecordIDs[want.Name] {
+		s.recordWarningEvent(cs.IonosCluster, dnsRecordNotOwnedReason,
+			"DNS record %s %s already exists and is not owned by the cluster", want.Type, fqdn)
+		return fmt.Errorf("DNS record %s %s already exists and is not owned by the cluster", want.Type, fqdn)
+	}
+
+	if ownership == nil {
+		txt := ionoscloud.DNSRecord{Name: want.Name, Type: dnsOwnershipRecordType, Content: owner, TTL: want.TTL}
+		if txt.ID, err = s.dns.CreateRecord(ctx, status.ZoneID, txt); err != nil {
+			return fmt.Errorf("failed to create DNS ownership record %s: %w", want.Name, err)
+		}
+		ownership = &txt
+	}
+	if status.OwnershipRecordIDs == nil {
+		status.OwnershipRecordIDs = make(map[string]string)
+	}
+	status.OwnershipRecordIDs[want.Name] = ownership.ID
+
+	switch {
+	case current == nil:
+		id, err := s.dns.CreateRecord(ctx, status.ZoneID, want)
+		if err != nil {
+			return fmt.Errorf("failed to create DNS record %s: %w", want.Name, err)
+		}
+		want.ID = id
+		log.Info("Created DNS record", "name", want.Name, "content", want.Content)
+		s.recordEvent(cs.IonosCluster, dnsRecordCreatedReason,
+			"Created DNS record %s %s pointing to %s", want.Type, fqdn, want.Content)
+	case current.Content != want.Content || current.TTL != want.TTL:
+		want.ID = current.ID
+		if err := s.dns.UpdateRecord(ctx, status.ZoneID, want); err != nil {
+			return fmt.Errorf("failed to update DNS record %s: %w", want.Name, err)
+		}
+		log.Info("Updated DNS record", "name", want.Name, "content", want.Content)
+		s.recordEvent(cs.IonosCluster, dnsRecordUpdatedReason,
+			"Updated DNS record %s %s to point to %s", want.Type, fqdn, want.Content)
+	default:
+		want.ID = current.ID
+	}
+
+	if status.RecordIDs == nil {
+		status.RecordIDs = make(map[string]string)
+	}
+	status.RecordIDs[want.Name] = want.ID
+	return nil
+}
+
+// deleteDNSRecord deletes the record with the given name and its ownership record, and removes them from
+// the status. Only records, whose IDs are stored in the status, are deleted.
+func (s *Service) deleteDNSRecord(
+	ctx context.Context, cs *scope.Cluster, status *infrav1.DNSStatus, name string,
+) error {
+	if id, ok := status.RecordIDs[name]; ok {
+		if err := s.dns.DeleteRecord(ctx, status.ZoneID, id); err != nil {
+			return fmt.Errorf("failed to delete DNS record %s: %w", name, err)
+		}
+		s.logger.Info("Deleted DNS record", "name", name)
+		s.recordEvent(cs.IonosCluster, dnsRecordDeletedReason, "Deleted DNS record %s", dnsRecordFQDN(cs, name))
+		delete(status.RecordIDs, name)
+	}
+	if id, ok := status.OwnershipRecordIDs[name]; ok {
+		if err := s.dns.DeleteRecord(ctx, status.ZoneID, id); err != nil {
+			return fmt.Errorf("failed to delete DNS ownership record %s: %w", name, err)
+		}
+		delete(status.OwnershipRecordIDs, name)
+	}
+	return nil
+}
+
+// dnsRecordNames returns the sorted names of the records, which are stored in the status.
+func dnsRecordNames(status *infrav1.DNSStatus) []string {
+	names := sortedKeys(status.RecordIDs)
+	for _, name := range sortedKeys(status.OwnershipRecordIDs) {
+		if _, ok := status.RecordIDs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// dnsRecordOwner returns the content of the TXT records, which mark DNS records as owned by the cluster.
+func dnsRecordOwner(cs *scope.Cluster) string {
+	return fmt.Sprintf("heritage=cluster-api-provider-ionoscloud,cluster=%s/%s",
+		cs.Cluster.Namespace, cs.Cluster.Name)
+}
+
+// setControlPlaneEndpointDNSAddress sets the address, to which the DNS records of the control plane endpoint point.
+func setControlPlaneEndpointDNSAddress(cs *scope.Cluster, ip string) {
+	if cs.IonosCluster.Status.ControlPlaneEndpointDNS == nil {
+		cs.IonosCluster.Status.ControlPlaneEndpointDNS = &infrav1.DNSStatus{}
+	}
+	cs.IonosCluster.Status.ControlPlaneEndpointDNS.Address = ip
+}
+
+// dnsRecordFQDN returns the fully qualified name of the record with the given name in the zone of the cluster.
+func dnsRecordFQDN(cs *scope.Cluster, name string) string {
+	if dns := cs.IonosCluster.Spec.DNS; dns != nil {
+		return name + "." + strings.TrimSuffix(dns.ZoneName, ".")
+	}
+	return name
+}
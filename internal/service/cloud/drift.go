@@ -0,0 +1,156 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// reportsDrift returns whether differences between the VM and the spec of the machine are only reported
+// instead of being reconciled, because the machine has been provisioned and its drift policy is Report.
+// Until the machine is provisioned, the VM is always configured according to the spec.
+func reportsDrift(ms *scope.Machine) bool {
+	return ms.IonosMachine.Spec.DriftPolicy == infrav1.DriftPolicyReport && ms.IonosMachine.Status.Ready
+}
+
+// reportDrift records a difference between the VM and the spec of the machine, which is reported
+// in the ServerInSync condition at the end of the reconciliation.
+func (s *Service) reportDrift(ms *scope.Machine, format string, args ...any) {
+	drift := fmt.Sprintf(format, args...)
+	s.logger.V(4).Info("Detected drift of the server", "drift", drift)
+	ms.Drift = append(ms.Drift, drift)
+}
+
+// markServerInSync reports the drift, which was detected during the reconciliation, in the ServerInSync
+// condition. The condition is removed unless the drift of the machine is reported.
+func markServerInSync(ms *scope.Machine) {
+	if !reportsDrift(ms) {
+		conditions.Delete(ms.IonosMachine, infrav1.ServerInSyncCondition)
+		return
+	}
+	if len(ms.Drift) == 0 {
+		conditions.MarkTrue(ms.IonosMachine, infrav1.ServerInSyncCondition)
+		return
+	}
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerInSyncCondition, infrav1.DriftDetectedReason,
+		clusterv1.ConditionSeverityWarning, "%s", strings.Join(ms.Drift, "; "))
+}
+
+// reportFirewallDrift reports the differences between the firewall of the primary NIC and the firewall rules
+// of the machine.
+func (s *Service) reportFirewallDrift(
+	ctx context.Context, ms *scope.Machine, serverID, nicID, currentType, wantType string,
+) error {
+	if currentType != wantType {
+		s.reportDrift(ms, "firewall of NIC %s has type %q instead of %q", nicID, currentType, wantType)
+	}
+
+	existingRules, err := s.apiWithDepth(1).ListFirewallRules(ctx, ms.DatacenterID(), serverID, nicID)
+	if err != nil {
+		return fmt.Errorf("failed to list firewall rules of NIC %s: %w", nicID, err)
+	}
+
+	want := make(map[string]infrav1.FirewallRule)
+	for _, rule := range ms.IonosMachine.Spec.FirewallRules {
+		want[rule.Name] = rule
+	}
+	for _, rule := range ptr.Deref(existingRules.GetItems(), []sdk.FirewallRule{}) {
+		name := ptr.Deref(rule.GetProperties().GetName(), "")
+		wantRule, expected := want[name]
+		switch {
+		case !expected:
+			s.reportDrift(ms, "firewall rule %q is unexpected", name)
+		case !firewallRuleMatches(rule.GetProperties(), s.buildFirewallRuleProperties(wantRule)):
+			s.reportDrift(ms, "firewall rule %q differs from the spec", name)
+		}
+		delete(want, name)
+	}
+
+	for _, rule := range ms.IonosMachine.Spec.FirewallRules {
+		if _, missing := want[rule.Name]; missing {
+			s.reportDrift(ms, "firewall rule %q is missing", rule.Name)
+		}
+	}
+	return nil
+}
+
+// reportServerLabelDrift reports the differences between the labels of the server and its volumes
+// and the labels of the machine.
+func (s *Service) reportServerLabelDrift(
+	ctx context.Context, ms *scope.Machine, server *sdk.Server, desired map[string]string,
+) error {
+	serverID := ptr.Deref(server.GetId(), "")
+	if err := s.reportLabelDrift(ctx, ms, "server "+serverID,
+		s.serverLabelOperations(ms.DatacenterID(), serverID), desired); err != nil {
+		return fmt.Errorf("could not list labels of server %s: %w", serverID, err)
+	}
+
+	for _, volumeID := range s.machineVolumeIDs(ms, server) {
+		if err := s.reportLabelDrift(ctx, ms, "volume "+volumeID,
+			s.volumeLabelOperations(ms.DatacenterID(), volumeID), desired); err != nil {
+			return fmt.Errorf("could not list labels of volume %s: %w", volumeID, err)
+		}
+	}
+	return nil
+}
+
+// reportLabelDrift reports missing, changed and unexpected labels of a resource.
+func (s *Service) reportLabelDrift(
+	ctx context.Context, ms *scope.Machine, resource string, ops labelOperations, desired map[string]string,
+) error {
+	current, err := currentLabels(ctx, ops)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(desired) {
+		currentValue, exists := current[key]
+		switch {
+		case !exists:
+			s.reportDrift(ms, "label %q of %s is missing", key, resource)
+		case currentValue != desired[key]:
+			s.reportDrift(ms, "label %q of %s is %q instead of %q", key, resource, currentValue, desired[key])
+		}
+	}
+	for _, key := range sortedKeys(current) {
+		if _, exists := desired[key]; !exists && !keepsUnknownLabels(ms) {
+			s.reportDrift(ms, "label %q of %s is unexpected", key, resource)
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
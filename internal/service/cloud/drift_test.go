@@ -0,0 +1,71 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+type driftSuite struct {
+	ServiceTestSuite
+}
+
+func TestDriftSuite(t *testing.T) {
+	suite.Run(t, new(driftSuite))
+}
+
+func (s *driftSuite) TestReportsDrift() {
+	s.False(reportsDrift(s.machineScope))
+
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.False(reportsDrift(s.machineScope), "drift must not be reported before the machine is provisioned")
+
+	s.infraMachine.Status.Ready = true
+	s.True(reportsDrift(s.machineScope))
+
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReconcile
+	s.False(reportsDrift(s.machineScope))
+}
+
+func (s *driftSuite) TestMarkServerInSync() {
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.infraMachine.Status.Ready = true
+
+	markServerInSync(s.machineScope)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerInSyncCondition))
+
+	s.service.reportDrift(s.machineScope, "server has %d cores instead of %d", 1, 2)
+	s.service.reportDrift(s.machineScope, "firewall rule %q is missing", "ssh")
+	markServerInSync(s.machineScope)
+	s.True(conditions.IsFalse(s.infraMachine, infrav1.ServerInSyncCondition))
+	s.Equal(infrav1.DriftDetectedReason, conditions.GetReason(s.infraMachine, infrav1.ServerInSyncCondition))
+	s.Equal(`server has 1 cores instead of 2; firewall rule "ssh" is missing`,
+		conditions.GetMessage(s.infraMachine, infrav1.ServerInSyncCondition))
+}
+
+func (s *driftSuite) TestMarkServerInSyncReconcile() {
+	s.infraMachine.Status.Ready = true
+	conditions.MarkTrue(s.infraMachine, infrav1.ServerInSyncCondition)
+
+	markServerInSync(s.machineScope)
+	s.Nil(conditions.Get(s.infraMachine, infrav1.ServerInSyncCondition))
+}
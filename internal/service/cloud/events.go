@@ -0,0 +1,94 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Reasons of the events, which are recorded for the provisioning steps of clusters and machines.
+const (
+	serverCreationRequestedReason        = "ServerCreationRequested"
+	serverProvisionedReason              = "ServerProvisioned"
+	serverAdoptedReason                  = "ServerAdopted"
+	serverUpdateRequestedReason          = "ServerUpdateRequested"
+	serverStartRequestedReason           = "ServerStartRequested"
+	serverStopRequestedReason            = "ServerStopRequested"
+	serverRebootRequestedReason          = "ServerRebootRequested"
+	bootstrapRedeliveryRequestedReason   = "BootstrapRedeliveryRequested"
+	bootstrapRedeliveryUnsupportedReason = "BootstrapRedeliveryUnsupported"
+	bootstrapRedeliveredReason           = "BootstrapRedelivered"
+	remoteConsoleURLStoredReason         = "RemoteConsoleURLStored"
+	serverDeletionRequestedReason        = "ServerDeletionRequested"
+	imageUpdateRequestedReason           = "ImageUpdateRequested"
+	cdromEjectionRequestedReason         = "CDROMEjectionRequested"
+	volumeCreationRequestedReason        = "VolumeCreationRequested"
+	volumeAttachedReason                 = "VolumeAttached"
+	volumeDeletionRequestedReason        = "VolumeDeletionRequested"
+	volumeDetachmentRequestedReason      = "VolumeDetachmentRequested"
+	volumeRetainedReason                 = "VolumeRetained"
+	ipAddressAllocatedReason             = "IPAddressAllocated"
+	ipBlockReservationRequestedReason    = "IPBlockReservationRequested"
+	ipBlockDeletionRequestedReason       = "IPBlockDeletionRequested"
+	ipBlockExhaustedReason               = "IPBlockExhausted"
+	privateIPRetainedReason              = "PrivateIPRetained"
+	imagePinnedReason                    = "ImagePinned"
+	ipv6PrefixDelegatedReason            = "IPv6PrefixDelegated"
+	dnsRecordCreatedReason               = "DNSRecordCreated"
+	dnsRecordUpdatedReason               = "DNSRecordUpdated"
+	dnsRecordDeletedReason               = "DNSRecordDeleted"
+	dnsRecordNotOwnedReason              = "DNSRecordNotOwned"
+	datacenterCreationRequestedReason    = "DatacenterCreationRequested"
+	datacenterDeletionRequestedReason    = "DatacenterDeletionRequested"
+	lanCreationRequestedReason           = "LANCreationRequested"
+	lanDeletionRequestedReason           = "LANDeletionRequested"
+	loadBalancerCreationRequestedReason  = "LoadBalancerCreationRequested"
+	loadBalancerDeletionRequestedReason  = "LoadBalancerDeletionRequested"
+	natGatewayCreationRequestedReason    = "NATGatewayCreationRequested"
+	natGatewayDeletionRequestedReason    = "NATGatewayDeletionRequested"
+	crossConnectCreationRequestedReason  = "CrossConnectCreationRequested"
+	crossConnectDeletionRequestedReason  = "CrossConnectDeletionRequested"
+)
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithEventRecorder makes the Service record an event for each significant provisioning step
+// on the reconciled IonosCloudCluster or IonosCloudMachine.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(s *Service) {
+		s.recorder = recorder
+	}
+}
+
+// recordEvent records a normal event on obj. Nothing is recorded if the Service has no recorder.
+func (s *Service) recordEvent(obj runtime.Object, reason, messageFmt string, args ...any) {
+	if s.recorder == nil {
+		return
+	}
+	s.recorder.Eventf(obj, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// recordWarningEvent records a warning event on obj. Nothing is recorded if the Service has no recorder.
+func (s *Service) recordWarningEvent(obj runtime.Object, reason, messageFmt string, args ...any) {
+	if s.recorder == nil {
+		return
+	}
+	s.recorder.Eventf(obj, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// ReconcileFailoverGroups ensures that the NICs of a control plane machine in the LANs of its failover groups
+// contain the IP of the group, and that the IP failover configuration of each LAN contains an entry for the IP.
+// The entry is added with the NIC of the first machine, which is reconciled. The NICs of the other machines
+// hold the IP as well, so that it can fail over to them.
+func (s *Service) ReconcileFailoverGroups(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileFailoverGroups")
+
+	if !failoverGroupsRequired(ms) {
+		log.V(4).Info("No failover groups are required for this machine.")
+		return false, nil
+	}
+
+	server, err := s.getServer(ctx, ms)
+	if err != nil {
+		return false, err
+	}
+	serverID := ptr.Deref(server.GetId(), "")
+
+	for _, group := range ms.IonosMachine.Spec.FailoverGroups {
+		lan, err := s.failoverGroupLAN(ctx, ms, group)
+		if err != nil {
+			return false, err
+		}
+		lanID := ptr.Deref(lan.GetId(), "")
+		nic := findLANNIC(server, lanID)
+		if nic == nil {
+			return false, fmt.Errorf("the server has no NIC in LAN %s of failover group %s, "+
+				"which must be one of the additional networks", lanID, group.IP)
+		}
+		nicID := ptr.Deref(nic.GetId(), "")
+
+		if !nicHasIP(nic, group.IP) {
+			ri, err := s.getLatestNICPatchRequest(ctx, ms, serverID, nicID)
+			if err != nil {
+				return false, fmt.Errorf("unable to check for pending NIC patch request: %w", err)
+			}
+			if ri != nil && ri.isPending() {
+				log.Info("Found pending NIC request. Waiting for it to be finished", "nicID", nicID)
+				return true, nil
+			}
+
+			log.V(4).Info("Adding failover IP to NIC", "nicID", nicID, "failoverIP", group.IP)
+			nicIPs := append(ptr.Deref(nic.GetProperties().GetIps(), []string{}), group.IP)
+			if err := s.patchNIC(ctx, ms, serverID, nic, sdk.NicProperties{Ips: &nicIPs}); err != nil {
+				return false, err
+			}
+			ms.IonosMachine.DeleteCurrentRequest()
+			return true, nil
+		}
+
+		if pending, err := s.isLANPatchPending(ctx, lanID, ms); pending || err != nil {
+			return pending, err
+		}
+
+		ipFailoverConfig := ptr.Deref(lan.GetProperties().GetIpFailover(), []sdk.IPFailover{})
+		if slices.ContainsFunc(ipFailoverConfig, func(failover sdk.IPFailover) bool {
+			return ptr.Deref(failover.GetIp(), unknownValue) == group.IP
+		}) {
+			continue
+		}
+
+		ipFailoverConfig = append(ipFailoverConfig, sdk.IPFailover{Ip: ptr.To(group.IP), NicUuid: &nicID})
+		log.V(4).Info("Patching LAN failover group to add NIC", "lanID", lanID, "nicID", nicID, "failoverIP", group.IP)
+		return true, s.patchLAN(ctx, ms, lanID, sdk.LanProperties{IpFailover: &ipFailoverConfig})
+	}
+
+	return false, nil
+}
+
+// ReconcileFailoverGroupsDeletion hands the IP failover entries of the failover groups, which point to the NIC of
+// the machine, over to the NIC of another control plane machine, which holds the IP. If there is no such machine,
+// the entry is removed. NICs, which are part of an IP failover configuration, cannot be deleted with their server.
+func (s *Service) ReconcileFailoverGroupsDeletion(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileFailoverGroupsDeletion")
+
+	if !failoverGroupsRequired(ms) {
+		log.V(4).Info("No failover groups are required for this machine. Deletion not necessary")
+		return false, nil
+	}
+
+	server, err := s.getServer(ctx, ms)
+	if err != nil {
+		if ionoserrors.IsNotFound(err) {
+			log.Info("Server was not found or already deleted.")
+			return false, nil
+		}
+		return false, err
+	}
+	if server == nil {
+		return false, nil
+	}
+
+	for _, group := range ms.IonosMachine.Spec.FailoverGroups {
+		lan, err := s.failoverGroupLAN(ctx, ms, group)
+		if err != nil {
+			return false, err
+		}
+		lanID := ptr.Deref(lan.GetId(), "")
+		nic := findLANNIC(server, lanID)
+		if nic == nil {
+			log.V(4).Info("Server has no NIC in LAN of failover group. No action required.", "lanID", lanID)
+			continue
+		}
+		nicID := ptr.Deref(nic.GetId(), "")
+
+		if pending, err := s.isLANPatchPending(ctx, lanID, ms); pending || err != nil {
+			return pending, err
+		}
+
+		ipFailoverConfig := ptr.Deref(lan.GetProperties().GetIpFailover(), []sdk.IPFailover{})
+		index := slices.IndexFunc(ipFailoverConfig, func(failover sdk.IPFailover) bool {
+			return ptr.Deref(failover.GetNicUuid(), unknownValue) == nicID
+		})
+		if index < 0 {
+			log.V(4).Info("NIC not found in failover group. No action required.", "nicID", nicID)
+			continue
+		}
+
+		newNICID, err := s.failoverGroupTakeoverNICID(ctx, ms, lanID, group.IP)
+		if err != nil {
+			return false, err
+		}
+		if newNICID == "" {
+			log.V(4).Info("Patching LAN failover group to remove NIC", "lanID", lanID, "nicID", nicID)
+			ipFailoverConfig = slices.Delete(ipFailoverConfig, index, index+1)
+		} else {
+			log.V(4).Info("Updating failover group with new NIC", "lanID", lanID, "oldNICID", nicID, "newNICID", newNICID)
+			ipFailoverConfig[index].NicUuid = &newNICID
+		}
+		return true, s.patchLAN(ctx, ms, lanID, sdk.LanProperties{IpFailover: &ipFailoverConfig})
+	}
+
+	return false, nil
+}
+
+// failoverGroupTakeoverNICID returns the ID of the NIC in the LAN of another control plane machine,
+// which holds the failover IP. If there is no such NIC, an empty string is returned.
+func (s *Service) failoverGroupTakeoverNICID(
+	ctx context.Context, ms *scope.Machine, lanID, failoverIP string,
+) (string, error) {
+	machine, err := ms.FindLatestMachine(ctx, client.MatchingLabels{clusterv1.MachineControlPlaneLabel: ""})
+	if err != nil || machine == nil {
+		return "", err
+	}
+
+	server, err := s.getServerByServerID(ctx, ms.DatacenterID(), machine.ExtractServerID())
+	if err != nil {
+		return "", ignoreNotFound(err)
+	}
+	if server == nil {
+		return "", nil
+	}
+
+	nic := findLANNIC(server, lanID)
+	if nic == nil || !nicHasIP(nic, failoverIP) {
+		// The entry is added again, once the other machine has added the IP to its NIC.
+		return "", nil
+	}
+	return ptr.Deref(nic.GetId(), ""), nil
+}
+
+// failoverGroupLAN returns the LAN of the failover group, which is either referenced by its ID
+// or belongs to a network of the cluster.
+func (s *Service) failoverGroupLAN(
+	ctx context.Context, ms *scope.Machine, group infrav1.FailoverGroup,
+) (*sdk.Lan, error) {
+	lans, err := s.listLANs(ctx, ms.DatacenterID())
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Network != "" {
+		network := ms.ClusterScope.Network(group.Network)
+		if network == nil {
+			return nil, fmt.Errorf("network %s of failover group %s is not defined in the cluster", group.Network, group.IP)
+		}
+		lan, err := s.findNetworkLAN(lans, ms.ClusterScope.Cluster, network)
+		if err != nil {
+			return nil, err
+		}
+		if lan == nil {
+			return nil, fmt.Errorf("unable to find the LAN of network %s of failover group %s", group.Network, group.IP)
+		}
+		return lan, nil
+	}
+
+	lan := findLANByID(lans, group.NetworkID)
+	if lan == nil {
+		return nil, fmt.Errorf("unable to find LAN %d of failover group %s", group.NetworkID, group.IP)
+	}
+	return lan, nil
+}
+
+// findLANNIC returns the NIC of the server, which is attached to the LAN with the given ID.
+// If the server has no NIC in the LAN, nil is returned.
+func findLANNIC(server *sdk.Server, lanID string) *sdk.Nic {
+	for _, nic := range ptr.Deref(server.GetEntities().GetNics().GetItems(), []sdk.Nic{}) {
+		if fmt.Sprint(ptr.Deref(nic.GetProperties().GetLan(), 0)) == lanID {
+			return &nic
+		}
+	}
+	return nil
+}
+
+// failoverGroupsRequired returns whether the machine is a control plane machine with failover groups.
+func failoverGroupsRequired(ms *scope.Machine) bool {
+	return util.IsControlPlaneMachine(ms.Machine) && len(ms.IonosMachine.Spec.FailoverGroups) > 0
+}
@@ -0,0 +1,1326 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	ionosfake "github.com/ionos-cloud/cluster-api-provider-ionoscloud/test/fake"
+)
+
+// fakeClientSuite runs the service against the in-memory fake of the Cloud API instead of mocks.
+type fakeClientSuite struct {
+	ServiceTestSuite
+	cloud    *ionosfake.Client
+	recorder *record.FakeRecorder
+}
+
+func TestFakeClientSuite(t *testing.T) {
+	suite.Run(t, new(fakeClientSuite))
+}
+
+func (s *fakeClientSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+
+	s.cloud = ionosfake.NewClient()
+	s.infraMachine.Spec.DatacenterID = s.cloud.AddDatacenter("test", s.infraCluster.Spec.Location)
+
+	var err error
+	s.recorder = record.NewFakeRecorder(10)
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder))
+	s.NoError(err)
+}
+
+func (s *fakeClientSuite) TestReconcileLANLifecycle() {
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.PendingRequests())
+	s.Contains(<-s.recorder.Events, "Normal LANCreationRequested")
+
+	requeue, err = s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue, "the LAN must not be reported as ready while it is being created")
+	s.Equal(1, s.cloud.PendingRequests(), "the LAN must not be created twice")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileLANDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.CompleteRequests())
+	s.Contains(<-s.recorder.Events, "Normal LANDeletionRequested")
+	s.Empty(s.recorder.Events)
+
+	lans, err := s.cloud.ListLANs(s.ctx, s.machineScope.DatacenterID())
+	s.NoError(err)
+	s.Empty(*lans.Items)
+}
+
+func (s *fakeClientSuite) TestReconcileNetworksCrossConnect() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary", CrossConnect: &infrav1.CrossConnectSpec{}}}
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal CrossConnectCreationRequested")
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err = s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err = s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	crossConnects, err := s.cloud.ListCrossConnects(s.ctx)
+	s.NoError(err)
+	s.Len(*crossConnects.Items, 1)
+	peers := *(*crossConnects.Items)[0].Properties.Peers
+	s.Len(peers, 1)
+	s.Equal(s.infraMachine.Spec.DatacenterID, *peers[0].DatacenterId)
+	s.Equal(s.service.networkLANName(s.capiCluster, "primary"), *peers[0].Name)
+
+	requeue, err = s.service.ReconcileNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileCrossConnectsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileCrossConnectsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	crossConnects, err = s.cloud.ListCrossConnects(s.ctx)
+	s.NoError(err)
+	s.Empty(*crossConnects.Items)
+}
+
+func (s *fakeClientSuite) TestReconcileNetworksExistingCrossConnect() {
+	location, err := s.cloud.CreateCrossConnect(s.ctx, sdk.PrivateCrossConnectProperties{Name: ptr.To("shared")})
+	s.NoError(err)
+	s.NoError(s.cloud.WaitForRequest(s.ctx, location))
+	crossConnects, err := s.cloud.ListCrossConnects(s.ctx)
+	s.NoError(err)
+	crossConnectID := *(*crossConnects.Items)[0].Id
+
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{
+		Name:         "primary",
+		CrossConnect: &infrav1.CrossConnectSpec{ID: crossConnectID},
+	}}
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal LANCreationRequested")
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err = s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	lans, err := s.cloud.ListLANs(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Equal(crossConnectID, *(*lans.Items)[0].Properties.Pcc)
+
+	requeue, err = s.service.ReconcileCrossConnectsDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Zero(s.cloud.PendingRequests(), "a referenced Cross Connect must not be deleted")
+}
+
+func (s *fakeClientSuite) TestReconcileNetworksMissingCrossConnect() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{
+		Name:         "primary",
+		CrossConnect: &infrav1.CrossConnectSpec{ID: "da10c5b6-4bd7-4b4a-9c44-1e1b2d8ce5a0"},
+	}}
+
+	_, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.ErrorContains(err, "referenced Cross Connect da10c5b6-4bd7-4b4a-9c44-1e1b2d8ce5a0 does not exist")
+	s.Zero(s.cloud.PendingRequests())
+}
+
+func (s *fakeClientSuite) TestReconcileServerProvisioningConditions() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(conditions.IsFalse(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.Equal(infrav1.CreatingServerReason, conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ProviderIDSetCondition))
+
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.CreatingServerReason, conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.BootstrapDeliveredCondition))
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	for _, condition := range []clusterv1.ConditionType{
+		infrav1.ServerCreatedCondition,
+		infrav1.VolumeReadyCondition,
+		infrav1.NICConfiguredCondition,
+		infrav1.BootstrapDeliveredCondition,
+		infrav1.ProviderIDSetCondition,
+	} {
+		s.True(conditions.IsTrue(s.infraMachine, condition), "condition %s must be true", condition)
+	}
+}
+
+func (s *fakeClientSuite) TestReconcileServerHostnameFormat() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("#cloud-config\n")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	hostname := s.infraMachine.Status.Hostname
+	s.Regexp("^test-cluster-[a-z0-9]{5}$", hostname)
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	server := (*servers.Items)[0]
+	s.Equal(hostname, *server.Properties.Name)
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.Equal(s.service.volumeName(s.infraMachine), *bootVolume.Properties.Name)
+	userData, err := base64.StdEncoding.DecodeString(*bootVolume.Properties.UserData)
+	s.NoError(err)
+	s.Contains(string(userData), "hostname "+hostname)
+
+	// The server is found by its hostname.
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(hostname, s.infraMachine.Status.Hostname)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerCreatedCondition))
+}
+
+func (s *fakeClientSuite) TestReconcileServerPrivateImage() {
+	imageID := s.cloud.AddImage(sdk.ImageProperties{
+		Name:        ptr.To("ubuntu-2204-kube-v1.29.4"),
+		Location:    ptr.To(s.infraCluster.Spec.Location),
+		ImageType:   ptr.To("HDD"),
+		LicenceType: ptr.To("UNKNOWN"),
+		Size:        ptr.To(float32(30)),
+		Public:      ptr.To(false),
+	})
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.Disk.Image = &infrav1.ImageSpec{
+		Private: &infrav1.PrivateImageReference{Name: "ubuntu-2204-kube-v1.29.4"},
+	}
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ImageUpdateRequested")
+
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Empty(s.recorder.Events, "the server must not be created while the image is being updated")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	image, err := s.cloud.GetImage(s.ctx, imageID)
+	s.NoError(err)
+	s.Equal(infrav1.ImageLicenceTypeLinux.String(), *image.Properties.LicenceType)
+
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	bootVolume := (*(*servers.Items)[0].Entities.Volumes.Items)[0]
+	s.Equal(imageID, *bootVolume.Properties.Image)
+	s.Equal(float32(30), *bootVolume.Properties.Size)
+	s.Equal(imageID, s.infraMachine.Status.ResolvedImageID)
+	s.NotNil(s.infraMachine.Status.ProvisioningTimestamps.ServerRequested)
+}
+
+func (s *fakeClientSuite) TestReconcileServerBootstrapStorage() {
+	objectStorage := ionosfake.NewObjectStorage()
+	var err error
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder), WithObjectStorage(objectStorage))
+	s.NoError(err)
+	s.infraCluster.Spec.BootstrapStorage = &infrav1.BootstrapStorageSpec{
+		Bucket:         "bootstrap-data",
+		Region:         "eu-central-3",
+		CredentialsRef: corev1.LocalObjectReference{Name: "object-storage"},
+		URLExpiry:      &metav1.Duration{Duration: time.Hour},
+		ExpirationDays: 2,
+	}
+
+	// The bootstrap data exceeds the user data limit, but no bootstrap image is needed.
+	bootstrapData := "#cloud-config\n" + strings.Repeat("#", maxUserDataSize)
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte(bootstrapData)},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err = s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.False(conditions.Has(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+
+	rule, ok := objectStorage.ExpirationRule("bootstrap-data", bootstrapLifecycleRuleID)
+	s.True(ok)
+	s.Equal(ionosfake.ExpirationRule{Prefix: bootstrapObjectPrefix, Days: 2}, rule)
+
+	key := bootstrapObjectKey(s.machineScope)
+	s.Equal(bootstrapObjectPrefix+path.Join(s.infraMachine.Namespace, s.capiCluster.Name, s.infraMachine.Name), key)
+	data, ok := objectStorage.Object("bootstrap-data", key)
+	s.True(ok)
+	s.Contains(string(data), bootstrapData)
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	bootVolume := (*(*servers.Items)[0].Entities.Volumes.Items)[0]
+	userData, err := base64.StdEncoding.DecodeString(*bootVolume.Properties.UserData)
+	s.NoError(err)
+	presignedURL, err := objectStorage.PresignGetObject("bootstrap-data", key, time.Hour)
+	s.NoError(err)
+	s.Equal("#include\n"+presignedURL+"\n", string(userData))
+
+	s.NoError(s.service.deleteBootstrapData(s.ctx, s.machineScope))
+	_, ok = objectStorage.Object("bootstrap-data", key)
+	s.False(ok)
+}
+
+func (s *fakeClientSuite) TestReconcileServerCompressedUserData() {
+	// The bootstrap data exceeds the user data limit, but fits once it is compressed.
+	bootstrapData := "#cloud-config\n" + strings.Repeat("#", maxUserDataSize)
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte(bootstrapData)},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.False(conditions.Has(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	bootVolume := (*(*servers.Items)[0].Entities.Volumes.Items)[0]
+	s.LessOrEqual(len(*bootVolume.Properties.UserData), maxUserDataSize)
+	userData, err := base64.StdEncoding.DecodeString(*bootVolume.Properties.UserData)
+	s.NoError(err)
+	r, err := gzip.NewReader(bytes.NewReader(userData))
+	s.NoError(err)
+	decompressed, err := io.ReadAll(r)
+	s.NoError(err)
+	s.True(strings.HasPrefix(string(decompressed), bootstrapData))
+}
+
+func (s *fakeClientSuite) TestReconcileServerQuotaExceeded() {
+	s.cloud.SetResourceLimits(sdk.ResourceLimits{
+		CoresPerServer:       ptr.To(int32(16)),
+		CoresPerContract:     ptr.To(int32(8)),
+		CoresProvisioned:     ptr.To(int32(7)),
+		RamPerServer:         ptr.To(int32(65536)),
+		RamPerContract:       ptr.To(int32(32768)),
+		RamProvisioned:       ptr.To(int32(8192)),
+		HddLimitPerVolume:    ptr.To(int64(10240)),
+		HddLimitPerContract:  ptr.To(int64(1048576)),
+		HddVolumeProvisioned: ptr.To(int64(0)),
+	})
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.QuotaExceededReason, conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.Equal(clusterv1.ConditionSeverityWarning,
+		*conditions.GetSeverity(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.Equal("server exceeds the resource limits of the contract: "+
+		"cores: requested 2, available 1, HDD volume size (MB): requested 20480, limit 10240",
+		conditions.GetMessage(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.Contains(<-s.recorder.Events, "Warning QuotaExceeded")
+	s.Nil(s.infraMachine.Status.FailureReason)
+	s.Equal(float64(1), testutil.ToFloat64(remainingResources.WithLabelValues("31721234", "cores")))
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Empty(*servers.Items)
+
+	s.cloud.SetResourceLimits(sdk.ResourceLimits{CoresPerContract: ptr.To(int32(8))})
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.CreatingServerReason, conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+}
+
+func (s *fakeClientSuite) TestReconcileServerCDROM() {
+	isoID := s.cloud.AddImage(sdk.ImageProperties{
+		Name:        ptr.To("talos-amd64.iso"),
+		Location:    ptr.To(s.infraCluster.Spec.Location),
+		ImageType:   ptr.To(imageTypeCDROM),
+		LicenceType: ptr.To("LINUX"),
+		Public:      ptr.To(false),
+	})
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.Disk.Image = nil
+	s.infraMachine.Spec.CDROM = &infrav1.CDROMSpec{
+		Private:             &infrav1.PrivateImageReference{Name: "talos-amd64.iso"},
+		EjectAfterFirstBoot: true,
+	}
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue, "the CD-ROM must not be ejected before the node has joined the cluster")
+	for len(s.recorder.Events) > 0 {
+		s.NotContains(<-s.recorder.Events, "CDROMEjectionRequested")
+	}
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	server := (*servers.Items)[0]
+	s.Equal(isoID, *server.Properties.BootCdrom.Id)
+	s.Nil(server.Properties.BootVolume)
+	s.Equal(isoID, *(*server.Entities.Cdroms.Items)[0].Id)
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.Nil(bootVolume.Properties.Image)
+	s.Equal("LINUX", *bootVolume.Properties.LicenceType)
+	s.True(s.infraMachine.Status.Volumes[0].Boot)
+
+	s.machineScope.Machine.Status.NodeRef = &corev1.ObjectReference{Name: s.infraMachine.Name}
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerUpdateRequested")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal CDROMEjectionRequested")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	ejected, err := s.cloud.GetServer(s.ctx, s.infraMachine.Spec.DatacenterID, *server.Id)
+	s.NoError(err)
+	s.Nil(ejected.Properties.BootCdrom)
+	s.Equal(*bootVolume.Id, *ejected.Properties.BootVolume.Id)
+	s.Empty(*ejected.Entities.Cdroms.Items)
+}
+
+func (s *fakeClientSuite) TestReconcileServerBootDevice() {
+	isoID := s.cloud.AddImage(sdk.ImageProperties{
+		Name:        ptr.To("drivers.iso"),
+		Location:    ptr.To(s.infraCluster.Spec.Location),
+		ImageType:   ptr.To(imageTypeCDROM),
+		LicenceType: ptr.To("LINUX"),
+		Public:      ptr.To(false),
+	})
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.CDROM = &infrav1.CDROMSpec{ID: isoID}
+	s.infraMachine.Spec.Boot = &infrav1.BootConfig{Device: infrav1.BootDeviceVolume}
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	server := (*servers.Items)[0]
+	s.Nil(server.Properties.BootCdrom, "the VM must boot from the volume, even though a CD-ROM is attached")
+	s.Equal(isoID, *(*server.Entities.Cdroms.Items)[0].Id)
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.Equal(*bootVolume.Id, *server.Properties.BootVolume.Id)
+	s.Equal("PRIMARY", *bootVolume.Properties.BootOrder)
+}
+
+func (s *fakeClientSuite) TestReconcileServerNetworkBoot() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.Disk.Image = nil
+	s.infraMachine.Spec.Boot = &infrav1.BootConfig{Device: infrav1.BootDeviceNetwork}
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	server := (*servers.Items)[0]
+	s.Nil(server.Properties.BootCdrom)
+	s.Nil(server.Properties.BootVolume, "no volume must be used for booting")
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.Nil(bootVolume.Properties.Image)
+	s.Equal("NONE", *bootVolume.Properties.BootOrder)
+}
+
+func (s *fakeClientSuite) TestReconcileServerTalos() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte(exampleTalosConfig)},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	// Talos reads its machine config from the bootstrap image, regardless of its size.
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.BootstrapImageProcessingReason,
+		conditions.GetReason(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.BootstrapImageAvailableCondition))
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+	server := (*servers.Items)[0]
+	s.Equal(s.service.bootstrapImageName(s.infraMachine), *(*server.Entities.Cdroms.Items)[0].Properties.Name)
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.Nil(bootVolume.Properties.UserData, "Talos doesn't read user data")
+}
+
+func (s *fakeClientSuite) TestReconcileServerBootstrapRedelivery() {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("#cloud-config\nruncmd: [initial]\n")},
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, secret))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+	s.recorder = record.NewFakeRecorder(50)
+	s.service.recorder = s.recorder
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	servers, err := s.cloud.ListServers(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	previousVolumeID := *(*servers.Items)[0].Properties.BootVolume.Id
+
+	secret.Data["value"] = []byte("#cloud-config\nruncmd: [rotated]\n")
+	s.NoError(s.k8sClient.Update(s.ctx, secret))
+	s.infraMachine.Annotations = map[string]string{infrav1.RedeliverBootstrapDataAnnotation: "true"}
+	for len(s.recorder.Events) > 0 {
+		<-s.recorder.Events
+	}
+
+	// The server is stopped, before its boot volume is replaced.
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.NotContains(s.infraMachine.Annotations, infrav1.RedeliverBootstrapDataAnnotation)
+	s.Equal(previousVolumeID, s.infraMachine.Status.BootstrapRedelivery.PreviousVolumeID)
+	s.True(conditions.IsFalse(s.infraMachine, infrav1.BootstrapDeliveredCondition))
+	s.Equal(infrav1.RedeliveringBootstrapDataReason,
+		conditions.GetReason(s.infraMachine, infrav1.BootstrapDeliveredCondition))
+	s.Contains(conditions.GetMessage(s.infraMachine, infrav1.BootstrapDeliveredCondition),
+		"all data on the boot volume is lost")
+	s.Contains(<-s.recorder.Events, "Normal BootstrapRedeliveryRequested")
+	s.Contains(<-s.recorder.Events, "Normal ServerStopRequested")
+
+	for _, event := range []string{
+		"Normal VolumeCreationRequested",
+		"Normal ServerUpdateRequested",
+		"Normal VolumeDeletionRequested",
+	} {
+		s.Equal(1, s.cloud.CompleteRequests())
+		requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+		s.NoError(err)
+		s.True(requeue)
+		s.Contains(<-s.recorder.Events, event)
+	}
+
+	// The server is started again with the new boot volume.
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal BootstrapRedelivered")
+	s.Contains(<-s.recorder.Events, "Normal ServerStartRequested")
+	s.Nil(s.infraMachine.Status.BootstrapRedelivery)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.BootstrapDeliveredCondition))
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	server, err := s.cloud.GetServer(s.ctx, s.infraMachine.Spec.DatacenterID, s.machineScope.ServerID())
+	s.NoError(err)
+	s.Len(*server.Entities.Volumes.Items, 1)
+	bootVolume := (*server.Entities.Volumes.Items)[0]
+	s.NotEqual(previousVolumeID, *bootVolume.Id)
+	s.Equal(*bootVolume.Id, *server.Properties.BootVolume.Id)
+	s.Equal(s.service.volumeName(s.infraMachine), *bootVolume.Properties.Name)
+	s.Equal(s.infraMachine.Spec.Disk.Image.ID, *bootVolume.Properties.Image)
+	userData, err := base64.StdEncoding.DecodeString(*bootVolume.Properties.UserData)
+	s.NoError(err)
+	s.Contains(string(userData), "rotated")
+}
+
+func (s *fakeClientSuite) TestReconcileServerBootstrapRedeliveryError() {
+	server := &sdk.Server{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: ptr.To("volume")}},
+	}
+	s.infraMachine.Annotations = map[string]string{infrav1.RedeliverBootstrapDataAnnotation: "true"}
+
+	// The request is kept, if the bootstrap data cannot be rendered, so that it is retried.
+	_, err := s.service.reconcileBootstrapRedelivery(s.ctx, s.machineScope, &corev1.Secret{}, server)
+	s.ErrorContains(err, "unable to obtain bootstrap data from secret")
+	s.Equal("true", s.infraMachine.Annotations[infrav1.RedeliverBootstrapDataAnnotation])
+	s.Nil(s.infraMachine.Status.BootstrapRedelivery)
+	s.Empty(s.recorder.Events)
+}
+
+func (s *fakeClientSuite) TestReconcileServerBootstrapRedeliveryTalos() {
+	secret := &corev1.Secret{Data: map[string][]byte{"value": []byte(exampleTalosConfig)}}
+	server := &sdk.Server{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: ptr.To("volume")}},
+	}
+	s.infraMachine.Annotations = map[string]string{infrav1.RedeliverBootstrapDataAnnotation: "true"}
+
+	// Talos machine configs are delivered with a bootstrap image instead of the boot volume.
+	requeue, err := s.service.reconcileBootstrapRedelivery(s.ctx, s.machineScope, secret, server)
+	s.NoError(err)
+	s.False(requeue)
+	s.NotContains(s.infraMachine.Annotations, infrav1.RedeliverBootstrapDataAnnotation)
+	s.Nil(s.infraMachine.Status.BootstrapRedelivery)
+	s.Contains(<-s.recorder.Events, "Warning BootstrapRedeliveryUnsupported")
+}
+
+func (s *fakeClientSuite) TestReconcileServerImportExisting() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+	lan, err := s.service.getLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	lanID, err := strconv.ParseInt(*lan.Id, 10, 32)
+	s.NoError(err)
+
+	dcID := s.infraMachine.Spec.DatacenterID
+	server, _, err := s.cloud.CreateServer(s.ctx, dcID, sdk.ServerProperties{
+		Name:  ptr.To("hand-built"),
+		Type:  ptr.To(infrav1.ServerTypeEnterprise.String()),
+		Cores: ptr.To(int32(4)),
+		Ram:   ptr.To(s.infraMachine.Spec.MemoryMB),
+	}, sdk.ServerEntities{
+		Nics: &sdk.Nics{Items: &[]sdk.Nic{{Properties: &sdk.NicProperties{Lan: ptr.To(int32(lanID))}}}},
+	})
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	s.NoError(s.cloud.CreateServerLabel(s.ctx, dcID, *server.Id, machineNameLabelKey, "other-machine"))
+	s.infraMachine.Spec.ImportExisting = *server.Id
+
+	// The server is not imported, as long as it doesn't match the machine.
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Nil(s.infraMachine.Spec.ProviderID)
+	s.Equal(infrav1.ServerImportRejectedReason, conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+	message := conditions.GetMessage(s.infraMachine, infrav1.ServerCreatedCondition)
+	s.Contains(message, "4 cores don't match 2")
+	s.Contains(message, "server belongs to machine other-machine")
+	s.Contains(<-s.recorder.Events, "Warning ServerImportRejected")
+
+	_, err = s.cloud.PatchServer(s.ctx, dcID, *server.Id, sdk.ServerProperties{Cores: ptr.To(int32(2))})
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	s.NoError(s.cloud.DeleteServerLabel(s.ctx, dcID, *server.Id, machineNameLabelKey))
+
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(*server.Id, s.machineScope.ServerID())
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ProviderIDSetCondition))
+	s.Contains(<-s.recorder.Events, "Normal ServerAdopted")
+
+	// The imported server is reconciled like a created one, without creating another server.
+	requeue, err = s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerCreatedCondition))
+	servers, err := s.cloud.ListServers(s.ctx, dcID)
+	s.NoError(err)
+	s.Len(*servers.Items, 1)
+
+	_, err = s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	labels, err := currentLabels(s.ctx, s.service.serverLabelOperations(dcID, *server.Id))
+	s.NoError(err)
+	s.Equal(s.infraMachine.Name, labels[machineNameLabelKey])
+}
+
+func (s *fakeClientSuite) TestReconcileFailoverGroupsLifecycle() {
+	const failoverIP = "10.0.0.100"
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bootstrap", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"value": []byte("test")},
+	}))
+	s.capiMachine.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+	s.capiMachine.Spec.Bootstrap.DataSecretName = ptr.To("bootstrap")
+	s.infraMachine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	s.infraMachine.Spec.ProviderID = nil
+
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	_, err = s.cloud.CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{Name: ptr.To("private")})
+	s.NoError(err)
+	s.Equal(2, s.cloud.CompleteRequests())
+	<-s.recorder.Events
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{NetworkID: 2}}
+	s.infraMachine.Spec.FailoverGroups = []infrav1.FailoverGroup{{NetworkID: 2, IP: failoverIP}}
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal ServerCreationRequested")
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	// The IP is added to the NIC first, then the NIC is added to the failover group of the LAN.
+	for range 2 {
+		requeue, err = s.service.ReconcileFailoverGroups(s.ctx, s.machineScope)
+		s.NoError(err)
+		s.True(requeue)
+	}
+	requeue, err = s.service.ReconcileFailoverGroups(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	server, err := s.service.getServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	nic := findLANNIC(server, "2")
+	s.Require().NotNil(nic)
+	s.Contains(*nic.Properties.Ips, failoverIP)
+	s.Equal([]sdk.IPFailover{{Ip: ptr.To(failoverIP), NicUuid: nic.Id}}, s.lanFailoverConfig("2"))
+
+	// The failover group is handed over to another control plane machine, which holds the IP.
+	other, _, err := s.cloud.CreateServer(s.ctx, s.machineScope.DatacenterID(),
+		sdk.ServerProperties{Name: ptr.To("other-machine")},
+		sdk.ServerEntities{Nics: &sdk.Nics{Items: &[]sdk.Nic{{Properties: &sdk.NicProperties{
+			Lan: ptr.To[int32](2),
+			Ips: &[]string{"10.0.0.2", failoverIP},
+		}}}}})
+	s.NoError(err)
+	s.Equal(1, s.cloud.CompleteRequests())
+	otherMachine := &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other-machine", Namespace: s.infraMachine.Namespace,
+			Labels:            s.infraMachine.Labels,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(time.Minute)),
+		},
+		Spec: infrav1.IonosCloudMachineSpec{
+			ProviderID:   ptr.To("ionos://" + *other.Id),
+			DatacenterID: s.machineScope.DatacenterID(),
+		},
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, otherMachine))
+
+	requeue, err = s.service.ReconcileFailoverGroupsDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	otherServer, err := s.cloud.GetServer(s.ctx, s.machineScope.DatacenterID(), *other.Id)
+	s.NoError(err)
+	otherNIC := (*otherServer.Entities.Nics.Items)[0]
+	s.Equal([]sdk.IPFailover{{Ip: ptr.To(failoverIP), NicUuid: otherNIC.Id}}, s.lanFailoverConfig("2"))
+
+	requeue, err = s.service.ReconcileFailoverGroupsDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue, "the failover group must not point to the NIC of the machine anymore")
+
+	// The failover entry is removed with the last control plane machine.
+	s.NoError(s.k8sClient.Delete(s.ctx, otherMachine))
+	otherScope := *s.machineScope
+	otherScope.IonosMachine = otherMachine
+	requeue, err = s.service.ReconcileFailoverGroupsDeletion(s.ctx, &otherScope)
+	s.NoError(err)
+	s.False(requeue, "the other machine has no failover groups")
+
+	otherMachine.Spec.AdditionalNetworks = s.infraMachine.Spec.AdditionalNetworks
+	otherMachine.Spec.FailoverGroups = s.infraMachine.Spec.FailoverGroups
+	s.NoError(s.k8sClient.Delete(s.ctx, s.infraMachine))
+	requeue, err = s.service.ReconcileFailoverGroupsDeletion(s.ctx, &otherScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Empty(s.lanFailoverConfig("2"))
+}
+
+// lanFailoverConfig returns the IP failover configuration of the LAN with the given ID.
+func (s *fakeClientSuite) lanFailoverConfig(lanID string) []sdk.IPFailover {
+	lans, err := s.cloud.ListLANs(s.ctx, s.machineScope.DatacenterID())
+	s.NoError(err)
+	for _, lan := range *lans.Items {
+		if *lan.Id == lanID {
+			return ptr.Deref(lan.Properties.IpFailover, nil)
+		}
+	}
+	s.Failf("LAN not found", "LAN %s", lanID)
+	return nil
+}
+
+func (s *fakeClientSuite) TestReconcileIPBlocksLifecycle() {
+	s.infraCluster.Spec.IPBlocks = []infrav1.IPBlockSpec{{Name: "egress", Size: 2}}
+	s.infraCluster.Spec.ApplicationLoadBalancer = &infrav1.ApplicationLoadBalancerSpec{IPBlock: "egress"}
+	s.infraMachine.Spec.IPBlock = "egress"
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+	other := s.infraMachine.DeepCopy()
+	other.ObjectMeta = metav1.ObjectMeta{
+		Name: "other-machine", Namespace: s.infraMachine.Namespace,
+		Labels: s.infraMachine.Labels,
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, other))
+
+	requeue, err := s.service.ReconcileIPBlocks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal IPBlockReservationRequested")
+
+	requeue, err = s.service.ReconcileIPBlocks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue, "the IP blocks must not be reported as ready while they are being reserved")
+	s.Equal(1, s.cloud.PendingRequests(), "the IP block must not be reserved twice")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileIPBlocks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	block := s.clusterScope.IPBlock("egress")
+	s.Require().NotNil(block)
+	s.NotEmpty(block.ID)
+	s.Len(block.IPs, 2)
+	s.Equal(map[string]string{
+		"ApplicationLoadBalancer":         block.IPs[0],
+		"IonosCloudMachine/other-machine": block.IPs[1],
+	}, block.Assignments)
+	s.Contains(<-s.recorder.Events, "Normal IPAddressAllocated")
+	s.Contains(<-s.recorder.Events, "Normal IPAddressAllocated")
+	s.Contains(<-s.recorder.Events, "Warning IPBlockExhausted")
+
+	requeue, err = s.service.ReconcileIPBlockAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue, "the machine must wait for a free IP")
+
+	// The IP of a deleted machine is assigned to the next one.
+	s.NoError(s.k8sClient.Delete(s.ctx, other))
+	requeue, err = s.service.ReconcileIPBlocks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[string]string{
+		"ApplicationLoadBalancer":        block.IPs[0],
+		"IonosCloudMachine/test-machine": block.IPs[1],
+	}, block.Assignments)
+	s.Contains(<-s.recorder.Events, "Normal IPAddressAllocated")
+
+	requeue, err = s.service.ReconcileIPBlockAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[int]string{0: block.IPs[1]}, s.machineScope.ClaimedIPv4Addresses)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.IPAddressClaimedCondition))
+
+	requeue, err = s.service.ReconcileApplicationLoadBalancerIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(block.ID, s.infraCluster.Status.ApplicationLoadBalancerIPBlockID)
+	s.Equal(block.IPs[0], s.infraCluster.Status.ApplicationLoadBalancerIP)
+
+	requeue, err = s.service.ReconcileApplicationLoadBalancerIPBlockDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Zero(s.cloud.PendingRequests(), "the IP block of the cluster must not be deleted with the load balancer")
+
+	requeue, err = s.service.ReconcileIPBlocksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal IPBlockDeletionRequested")
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err = s.service.ReconcileIPBlocksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.IPBlocks)
+	s.Empty(s.recorder.Events)
+
+	blocks, err := s.cloud.ListIPBlocks(s.ctx)
+	s.NoError(err)
+	s.Empty(*blocks.Items)
+}
+
+func (s *fakeClientSuite) TestReconcileIPBlockAddressWaiting() {
+	s.infraMachine.Spec.IPBlock = "egress"
+
+	requeue, err := s.service.ReconcileIPBlockAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Nil(s.machineScope.ClaimedIPv4Addresses)
+	s.Equal(infrav1.WaitingForIPAddressReason,
+		conditions.GetReason(s.infraMachine, infrav1.IPAddressClaimedCondition))
+}
+
+func (s *fakeClientSuite) TestReconcileControlPlaneEndpointDNS() {
+	dns := ionosfake.NewDNS()
+	zoneID := dns.AddZone("example.com")
+	var err error
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder), WithDNS(dns))
+	s.NoError(err)
+	s.infraCluster.Spec.DNS = &infrav1.DNSSpec{ZoneName: "example.com", Wildcard: true, TTL: 60}
+
+	requeue, err := s.service.ReconcileControlPlaneEndpoint(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Contains(<-s.recorder.Events, "Normal IPBlockReservationRequested")
+	s.Equal(1, s.cloud.CompleteRequests())
+
+	requeue, err = s.service.ReconcileControlPlaneEndpoint(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal("test-cluster.default.example.com", s.infraCluster.Spec.ControlPlaneEndpoint.Host)
+	s.Contains(<-s.recorder.Events, "Normal IPAddressAllocated")
+	ip, err := s.clusterScope.GetControlPlaneEndpointIP(s.ctx)
+	s.NoError(err)
+	s.NotEmpty(ip)
+
+	// The IP block is still found, once the host is the name of the record.
+	requeue, err = s.service.ReconcileControlPlaneEndpoint(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordCreated")
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordCreated")
+	owner := "heritage=cluster-api-provider-ionoscloud,cluster=default/test-cluster"
+	records := dns.Records(zoneID)
+	s.Require().Len(records, 4)
+	s.Equal(ionoscloud.DNSRecord{
+		ID: records[0].ID, Name: "*.test-cluster.default", Type: "A", Content: ip, TTL: 60,
+	}, records[0])
+	s.Equal(ionoscloud.DNSRecord{
+		ID: records[1].ID, Name: "*.test-cluster.default", Type: "TXT", Content: owner, TTL: 60,
+	}, records[1])
+	s.Equal(ionoscloud.DNSRecord{
+		ID: records[2].ID, Name: "test-cluster.default", Type: "A", Content: ip, TTL: 60,
+	}, records[2])
+	s.Equal(ionoscloud.DNSRecord{
+		ID: records[3].ID, Name: "test-cluster.default", Type: "TXT", Content: owner, TTL: 60,
+	}, records[3])
+	s.Equal(&infrav1.DNSStatus{
+		ZoneID:    zoneID,
+		Address:   ip,
+		RecordIDs: map[string]string{"*.test-cluster.default": records[0].ID, "test-cluster.default": records[2].ID},
+		OwnershipRecordIDs: map[string]string{
+			"*.test-cluster.default": records[1].ID, "test-cluster.default": records[3].ID,
+		},
+	}, s.infraCluster.Status.ControlPlaneEndpointDNS)
+
+	// A changed record is corrected and the wildcard record is deleted, once it is disabled.
+	s.NoError(dns.UpdateRecord(s.ctx, zoneID, ionoscloud.DNSRecord{
+		ID: records[2].ID, Name: "test-cluster.default", Type: "A", Content: "198.51.100.1", TTL: 60,
+	}))
+	s.infraCluster.Spec.DNS.Wildcard = false
+	requeue, err = s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordUpdated")
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordDeleted")
+	s.Equal(records[2:], dns.Records(zoneID))
+	s.Empty(s.recorder.Events)
+
+	requeue, err = s.service.ReconcileControlPlaneEndpointDNSDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordDeleted")
+	s.Empty(dns.Records(zoneID))
+	s.Empty(s.infraCluster.Status.ControlPlaneEndpointDNS.RecordIDs)
+	s.Empty(s.infraCluster.Status.ControlPlaneEndpointDNS.OwnershipRecordIDs)
+}
+
+func (s *fakeClientSuite) TestReconcileControlPlaneEndpointDNSForeignRecord() {
+	dns := ionosfake.NewDNS()
+	zoneID := dns.AddZone("example.com")
+	var err error
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder), WithDNS(dns))
+	s.NoError(err)
+	s.infraCluster.Spec.DNS = &infrav1.DNSSpec{ZoneName: "example.com", RecordName: "api"}
+	s.infraCluster.Spec.ControlPlaneEndpoint.Host = "198.51.100.1"
+	foreign := ionoscloud.DNSRecord{Name: "api", Type: "A", Content: "198.51.100.2", TTL: 60}
+	foreign.ID, err = dns.CreateRecord(s.ctx, zoneID, foreign)
+	s.NoError(err)
+
+	_, err = s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.ErrorContains(err, "DNS record A api.example.com already exists and is not owned by the cluster")
+	s.Contains(<-s.recorder.Events, "Warning DNSRecordNotOwned")
+	s.Equal([]ionoscloud.DNSRecord{foreign}, dns.Records(zoneID), "a foreign record must not be changed")
+
+	// The foreign record is kept, when the cluster is deleted.
+	requeue, err := s.service.ReconcileControlPlaneEndpointDNSDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal([]ionoscloud.DNSRecord{foreign}, dns.Records(zoneID))
+	s.Empty(s.recorder.Events)
+
+	// A record marked with the owner of the cluster is taken over, e.g. after the status was lost.
+	_, err = dns.CreateRecord(s.ctx, zoneID, ionoscloud.DNSRecord{
+		Name: "api", Type: "TXT", Content: `"heritage=cluster-api-provider-ionoscloud,cluster=default/test-cluster"`,
+	})
+	s.NoError(err)
+	requeue, err = s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordUpdated")
+	s.Equal(foreign.ID, s.infraCluster.Status.ControlPlaneEndpointDNS.RecordIDs["api"])
+}
+
+func (s *fakeClientSuite) TestReconcileControlPlaneEndpointDNSUserSetIP() {
+	dns := ionosfake.NewDNS()
+	zoneID := dns.AddZone("example.com")
+	var err error
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder), WithDNS(dns))
+	s.NoError(err)
+	s.infraCluster.Spec.DNS = &infrav1.DNSSpec{ZoneName: "example.com", RecordName: "api.test"}
+	s.infraCluster.Spec.ControlPlaneEndpoint.Host = "2001:db8::1"
+
+	requeue, err := s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(<-s.recorder.Events, "Normal DNSRecordCreated")
+	records := dns.Records(zoneID)
+	s.Require().Len(records, 2)
+	s.Equal(ionoscloud.DNSRecord{
+		ID: records[0].ID, Name: "api.test", Type: "AAAA", Content: "2001:db8::1", TTL: defaultDNSRecordTTL,
+	}, records[0])
+	s.Equal("2001:db8::1", s.infraCluster.Spec.ControlPlaneEndpoint.Host, "a host set by the user must be kept")
+}
+
+func (s *fakeClientSuite) TestReconcileControlPlaneEndpointDNSMissingZone() {
+	var err error
+	s.service, err = NewService(s.cloud, s.log, WithEventRecorder(s.recorder), WithDNS(ionosfake.NewDNS()))
+	s.NoError(err)
+	s.infraCluster.Spec.DNS = &infrav1.DNSSpec{ZoneName: "example.com"}
+	s.infraCluster.Spec.ControlPlaneEndpoint.Host = "198.51.100.1"
+
+	_, err = s.service.ReconcileControlPlaneEndpointDNS(s.ctx, s.clusterScope)
+	s.ErrorContains(err, "zone example.com not found")
+	s.Empty(s.recorder.Events)
+}
+
+func (s *fakeClientSuite) TestRetainedPrivateIP() {
+	s.infraMachine.Spec.PrivateIP = &infrav1.PrivateIPConfig{Retain: true}
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+
+	requeue, err := s.service.ReconcilePrivateIPAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.machineScope.ClaimedIPv4Addresses, "the first VM must get its address by DHCP")
+
+	s.infraMachine.Status.MachineNetworkInfo = &infrav1.MachineNetworkInfo{NICInfo: []infrav1.NICInfo{
+		{IPv4Addresses: []string{"10.0.0.20"}, NetworkID: 2},
+		{IPv4Addresses: []string{"10.0.0.10"}, NetworkID: 1, Primary: true},
+	}}
+	s.NoError(s.k8sClient.Status().Update(s.ctx, s.infraMachine))
+
+	requeue, err = s.service.ReconcileRetainedPrivateIPs(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[string]string{s.infraMachine.Name: "10.0.0.10"}, s.infraCluster.Status.RetainedPrivateIPs)
+	s.Contains(<-s.recorder.Events, "Normal PrivateIPRetained")
+
+	// The address is kept for a machine with the same name after the machine has been deleted.
+	s.NoError(s.k8sClient.Delete(s.ctx, s.infraMachine))
+	requeue, err = s.service.ReconcileRetainedPrivateIPs(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[string]string{s.infraMachine.Name: "10.0.0.10"}, s.infraCluster.Status.RetainedPrivateIPs)
+	s.Empty(s.recorder.Events)
+
+	requeue, err = s.service.ReconcilePrivateIPAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[int]string{0: "10.0.0.10"}, s.machineScope.ClaimedIPv4Addresses)
+}
+
+func (s *fakeClientSuite) TestPinnedPrivateIP() {
+	s.infraMachine.Spec.PrivateIP = &infrav1.PrivateIPConfig{Address: "10.0.0.42"}
+
+	requeue, err := s.service.ReconcilePrivateIPAddress(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[int]string{0: "10.0.0.42"}, s.machineScope.ClaimedIPv4Addresses)
+}
+
+func (s *fakeClientSuite) TestPinnedImage() {
+	imageProperties := sdk.ImageProperties{
+		Name:        ptr.To("ubuntu-2204-kube-v1.29.4"),
+		Location:    ptr.To(s.infraCluster.Spec.Location),
+		ImageType:   ptr.To("HDD"),
+		LicenceType: ptr.To("LINUX"),
+		Size:        ptr.To(float32(30)),
+		Public:      ptr.To(false),
+	}
+	imageID := s.cloud.AddImage(imageProperties)
+	template := &infrav1.IonosCloudMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: s.infraMachine.Namespace},
+		Spec: infrav1.IonosCloudMachineTemplateSpec{
+			Template: infrav1.IonosCloudMachineTemplateResource{Spec: s.infraMachine.Spec},
+		},
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, template))
+	s.infraMachine.Annotations = map[string]string{clusterv1.TemplateClonedFromNameAnnotation: template.Name}
+	s.infraMachine.Spec.Disk.ImageUpgradePolicy = infrav1.ImageUpgradePolicyPin
+	s.infraMachine.Spec.Disk.Image = &infrav1.ImageSpec{
+		Private: &infrav1.PrivateImageReference{Name: "ubuntu-2204-kube-v1.29.4"},
+	}
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+	s.infraMachine.Status.ResolvedImageID = imageID
+	s.NoError(s.k8sClient.Status().Update(s.ctx, s.infraMachine))
+
+	requeue, err := s.service.ReconcilePinnedImages(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	key := template.Name + "/" + s.infraMachine.Spec.DatacenterID
+	s.Equal(map[string]string{key: imageID}, s.infraCluster.Status.PinnedImages)
+	s.Contains(<-s.recorder.Events, "Normal ImagePinned")
+
+	// A newer image with the same name is ignored for the machines of the template.
+	s.cloud.AddImage(imageProperties)
+	datacenter, err := s.cloud.GetDatacenter(s.ctx, s.infraMachine.Spec.DatacenterID)
+	s.NoError(err)
+	disk := s.infraMachine.Spec.Disk.DeepCopy()
+	ready, err := s.service.resolvePrivateImage(s.ctx, s.machineScope, datacenter, disk)
+	s.NoError(err)
+	s.True(ready)
+	s.Equal(imageID, disk.Image.ID)
+	s.Equal(30, disk.SizeGB)
+
+	s.NoError(s.k8sClient.Delete(s.ctx, template))
+	requeue, err = s.service.ReconcilePinnedImages(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.PinnedImages)
+	s.Empty(s.recorder.Events)
+}
+
+func (s *fakeClientSuite) TestIPv6Prefixes() {
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{}
+	s.infraMachine.Spec.ProviderID = nil
+	s.NoError(s.k8sClient.Update(s.ctx, s.infraMachine))
+
+	// The first slice is used by the server of another machine.
+	existing := s.infraMachine.DeepCopy()
+	existing.Name = "existing"
+	existing.ResourceVersion = ""
+	existing.Spec.ProviderID = ptr.To("ionos://" + exampleServerID)
+	s.NoError(s.k8sClient.Create(s.ctx, existing))
+	existing.Status.MachineNetworkInfo = &infrav1.MachineNetworkInfo{NICInfo: []infrav1.NICInfo{
+		{IPv6Addresses: []string{"2001:db8:0:1:1::5"}, Primary: true},
+	}}
+	s.NoError(s.k8sClient.Status().Update(s.ctx, existing))
+
+	requeue, err := s.service.ReconcileIPv6CIDRBlock(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue, "the server must not be created before the cluster assigned a CIDR block")
+
+	_, err = s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	<-s.recorder.Events
+	requeue, err = s.service.ReconcileIPv6Prefixes(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.IPv6CIDRBlocks, "no blocks must be assigned while the LAN is being created")
+
+	s.Equal(1, s.cloud.CompleteRequests())
+	requeue, err = s.service.ReconcileIPv6Prefixes(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[string]string{s.infraMachine.Spec.DatacenterID: "2001:db8:0:1::/64"},
+		s.infraCluster.Status.IPv6Prefixes)
+	s.Equal(map[string]string{s.infraMachine.Name: "2001:db8:0:1:2::/80"}, s.infraCluster.Status.IPv6CIDRBlocks)
+	s.Contains(<-s.recorder.Events, "Normal IPv6PrefixDelegated")
+
+	requeue, err = s.service.ReconcileIPv6CIDRBlock(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal("2001:db8:0:1:2::/80", s.machineScope.ClaimedIPv6CIDRBlock)
+
+	// The block is released once the machine has been deleted.
+	s.NoError(s.k8sClient.Delete(s.ctx, s.infraMachine))
+	requeue, err = s.service.ReconcileIPv6Prefixes(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.IPv6CIDRBlocks)
+	s.Empty(s.recorder.Events)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	firewallTypeIngress       = "INGRESS"
+	firewallTypeEgress        = "EGRESS"
+	firewallTypeBidirectional = "BIDIRECTIONAL"
+)
+
+// ReconcileFirewallRules ensures that the firewall of the primary NIC matches the firewall rules of the
+// IonosCloudMachine. Rules, which are unknown to the machine, are deleted. Only a single change is requested
+// per reconciliation, which is why the machine is requeued until the firewall is in sync.
+func (s *Service) ReconcileFirewallRules(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileFirewallRules")
+
+	server, err := s.getServer(ctx, ms)
+	if ignoreNotFound(err) != nil {
+		return false, err
+	}
+	if server == nil {
+		log.V(4).Info("Server does not exist yet, skipping firewall reconciliation")
+		return false, nil
+	}
+
+	nic, err := s.findPrimaryNIC(ms.IonosMachine, server)
+	if err != nil {
+		return false, err
+	}
+
+	rules := ms.IonosMachine.Spec.FirewallRules
+	currentType := nicFirewallType(nic)
+	wantType := firewallType(rules)
+	if currentType == "" && wantType == "" {
+		return false, nil
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	nicID := ptr.Deref(nic.GetId(), "")
+
+	if reportsDrift(ms) {
+		return false, s.reportFirewallDrift(ctx, ms, serverID, nicID, currentType, wantType)
+	}
+
+	// Narrowing the firewall before touching the rules makes sure that removing the last rule of a direction
+	// never blocks all traffic in that direction.
+	if currentType != wantType && !widensFirewall(currentType, wantType) {
+		return true, s.patchNICFirewall(ctx, ms, serverID, nicID, wantType)
+	}
+
+	existingRules, err := s.apiWithDepth(1).ListFirewallRules(ctx, ms.DatacenterID(), serverID, nicID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list firewall rules of NIC %s: %w", nicID, err)
+	}
+
+	existing := make(map[string]sdk.FirewallRule)
+	for _, rule := range ptr.Deref(existingRules.GetItems(), []sdk.FirewallRule{}) {
+		name := ptr.Deref(rule.GetProperties().GetName(), "")
+		if _, duplicate := existing[name]; duplicate || !hasFirewallRule(rules, name) {
+			return true, s.deleteFirewallRule(ctx, ms, serverID, nicID, ptr.Deref(rule.GetId(), ""))
+		}
+		existing[name] = rule
+	}
+
+	for _, rule := range rules {
+		want := s.buildFirewallRuleProperties(rule)
+		current, exists := existing[rule.Name]
+		if !exists {
+			return true, s.createFirewallRule(ctx, ms, serverID, nicID, want)
+		}
+
+		if firewallRuleMatches(current.GetProperties(), want) {
+			continue
+		}
+
+		ruleID := ptr.Deref(current.GetId(), "")
+		// The protocol cannot be changed and port ranges cannot be removed via a patch request.
+		// The rule needs to be recreated in this case.
+		if !canPatchFirewallRule(current.GetProperties(), want) {
+			return true, s.deleteFirewallRule(ctx, ms, serverID, nicID, ruleID)
+		}
+		return true, s.patchFirewallRule(ctx, ms, serverID, nicID, ruleID, want)
+	}
+
+	if currentType != wantType {
+		return true, s.patchNICFirewall(ctx, ms, serverID, nicID, wantType)
+	}
+
+	log.V(4).Info("Firewall rules are in sync")
+	return false, nil
+}
+
+func (s *Service) createFirewallRule(
+	ctx context.Context, ms *scope.Machine, serverID, nicID string, props sdk.FirewallruleProperties,
+) error {
+	log := s.logger.WithName("createFirewallRule")
+
+	location, err := s.ionosClient.CreateFirewallRule(ctx, ms.DatacenterID(), serverID, nicID, props)
+	if err != nil {
+		return fmt.Errorf("failed to create firewall rule %s: %w", ptr.Deref(props.GetName(), ""), err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, location)
+	log.V(4).Info("Successfully requested firewall rule creation", "location", location)
+	return nil
+}
+
+func (s *Service) patchFirewallRule(
+	ctx context.Context, ms *scope.Machine, serverID, nicID, ruleID string, props sdk.FirewallruleProperties,
+) error {
+	log := s.logger.WithName("patchFirewallRule")
+
+	// The protocol must not be part of a patch request.
+	props.Protocol = nil
+	if props.SourceIp == nil {
+		props.SetSourceIpNil()
+	}
+
+	location, err := s.ionosClient.PatchFirewallRule(ctx, ms.DatacenterID(), serverID, nicID, ruleID, props)
+	if err != nil {
+		return fmt.Errorf("failed to patch firewall rule %s: %w", ruleID, err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodPatch, sdk.RequestStatusQueued, location)
+	log.V(4).Info("Successfully requested firewall rule update", "location", location)
+	return nil
+}
+
+func (s *Service) deleteFirewallRule(ctx context.Context, ms *scope.Machine, serverID, nicID, ruleID string) error {
+	log := s.logger.WithName("deleteFirewallRule")
+
+	location, err := s.ionosClient.DeleteFirewallRule(ctx, ms.DatacenterID(), serverID, nicID, ruleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall rule %s: %w", ruleID, err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, location)
+	log.V(4).Info("Successfully requested firewall rule deletion", "location", location)
+	return nil
+}
+
+// patchNICFirewall activates the firewall of the NIC with the given type.
+// An empty firewall type deactivates the firewall.
+func (s *Service) patchNICFirewall(
+	ctx context.Context, ms *scope.Machine, serverID, nicID, firewallType string,
+) error {
+	log := s.logger.WithName("patchNICFirewall")
+
+	props := sdk.NicProperties{FirewallActive: ptr.To(firewallType != "")}
+	if firewallType != "" {
+		props.FirewallType = &firewallType
+	}
+
+	location, err := s.ionosClient.PatchNIC(ctx, ms.DatacenterID(), serverID, nicID, props)
+	if err != nil {
+		return fmt.Errorf("failed to patch firewall of NIC %s: %w", nicID, err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodPatch, sdk.RequestStatusQueued, location)
+	log.V(4).Info("Successfully requested NIC firewall update", "location", location)
+	return nil
+}
+
+// buildFirewallRuleProperties returns the properties of the expected firewall rule in the cloud.
+func (*Service) buildFirewallRuleProperties(rule infrav1.FirewallRule) sdk.FirewallruleProperties {
+	return sdk.FirewallruleProperties{
+		Name:           ptr.To(rule.Name),
+		Protocol:       ptr.To(rule.Protocol.String()),
+		Type:           ptr.To(firewallRuleDirection(rule).String()),
+		SourceIp:       rule.SourceCIDR,
+		PortRangeStart: rule.PortRangeStart,
+		PortRangeEnd:   rule.PortRangeEnd,
+	}
+}
+
+// buildFirewallRules returns the firewall rules, which are created together with the primary NIC.
+func (s *Service) buildFirewallRules(rules []infrav1.FirewallRule) *sdk.FirewallRules {
+	items := make([]sdk.FirewallRule, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, sdk.FirewallRule{Properties: ptr.To(s.buildFirewallRuleProperties(rule))})
+	}
+	return &sdk.FirewallRules{Items: &items}
+}
+
+func firewallRuleDirection(rule infrav1.FirewallRule) infrav1.FirewallRuleDirection {
+	if rule.Direction == "" {
+		return infrav1.FirewallRuleDirectionIngress
+	}
+	return rule.Direction
+}
+
+// firewallType returns the firewall type of the NIC, which is needed for the given rules.
+// An empty string is returned if no rules are given, meaning that the firewall should be inactive.
+func firewallType(rules []infrav1.FirewallRule) string {
+	var ingress, egress bool
+	for _, rule := range rules {
+		switch firewallRuleDirection(rule) {
+		case infrav1.FirewallRuleDirectionIngress:
+			ingress = true
+		case infrav1.FirewallRuleDirectionEgress:
+			egress = true
+		}
+	}
+
+	switch {
+	case ingress && egress:
+		return firewallTypeBidirectional
+	case egress:
+		return firewallTypeEgress
+	case ingress:
+		return firewallTypeIngress
+	default:
+		return ""
+	}
+}
+
+// nicFirewallType returns the firewall type of the NIC or an empty string, if the firewall is inactive.
+func nicFirewallType(nic *sdk.Nic) string {
+	props := nic.GetProperties()
+	if !ptr.Deref(props.GetFirewallActive(), false) {
+		return ""
+	}
+	return ptr.Deref(props.GetFirewallType(), firewallTypeIngress)
+}
+
+// widensFirewall returns true if the wanted firewall type filters traffic in a direction,
+// which is not filtered by the current firewall type.
+func widensFirewall(current, want string) bool {
+	filters := func(firewallType, direction string) bool {
+		return firewallType == direction || firewallType == firewallTypeBidirectional
+	}
+	return (filters(want, firewallTypeIngress) && !filters(current, firewallTypeIngress)) ||
+		(filters(want, firewallTypeEgress) && !filters(current, firewallTypeEgress))
+}
+
+func hasFirewallRule(rules []infrav1.FirewallRule, name string) bool {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func firewallRuleMatches(current *sdk.FirewallruleProperties, want sdk.FirewallruleProperties) bool {
+	return ptr.Deref(current.GetProtocol(), "") == ptr.Deref(want.Protocol, "") &&
+		ptr.Deref(current.GetType(), firewallTypeIngress) == ptr.Deref(want.Type, "") &&
+		ptr.Deref(current.GetSourceIp(), "") == ptr.Deref(want.SourceIp, "") &&
+		ptr.Deref(current.GetPortRangeStart(), 0) == ptr.Deref(want.PortRangeStart, 0) &&
+		ptr.Deref(current.GetPortRangeEnd(), 0) == ptr.Deref(want.PortRangeEnd, 0)
+}
+
+func canPatchFirewallRule(current *sdk.FirewallruleProperties, want sdk.FirewallruleProperties) bool {
+	if ptr.Deref(current.GetProtocol(), "") != ptr.Deref(want.Protocol, "") {
+		return false
+	}
+	return want.PortRangeStart != nil || current.GetPortRangeStart() == nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	exampleFirewallRuleID       = "9a4b5e4c-5b72-4f40-a3c5-0e0c1a61d3a7"
+	exampleFirewallRequestPath  = "path/to/firewall/request"
+	exampleFirewallSourceCIDR   = "198.51.100.0/24"
+	exampleFirewallRuleNameSSH  = "ssh"
+	exampleFirewallRuleNameHTTP = "http"
+)
+
+type firewallSuite struct {
+	ServiceTestSuite
+}
+
+func TestFirewallSuite(t *testing.T) {
+	suite.Run(t, new(firewallSuite))
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesNoRules() {
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraMachine.Status.CurrentRequest)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesNoServer() {
+	s.infraMachine.Spec.ProviderID = nil
+	s.ionosClient.EXPECT().ListServers(s.ctx, s.machineScope.DatacenterID()).Return(&sdk.Servers{}, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesCreateRule() {
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{s.sshRule()}
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+	s.mockListFirewallRulesCall().Return(&sdk.FirewallRules{Items: &[]sdk.FirewallRule{}}, nil).Once()
+	s.ionosClient.EXPECT().CreateFirewallRule(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID,
+		s.service.buildFirewallRuleProperties(s.sshRule())).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraMachine.Status.CurrentRequest.Method)
+	s.Equal(exampleFirewallRequestPath, s.infraMachine.Status.CurrentRequest.RequestPath)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesActivateFirewall() {
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{s.sshRule()}
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(s.sshRule()), nil).Once()
+	s.mockPatchNICCall(sdk.NicProperties{
+		FirewallActive: ptr.To(true),
+		FirewallType:   ptr.To(firewallTypeIngress),
+	}).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraMachine.Status.CurrentRequest.Method)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesInSync() {
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{s.sshRule()}
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeIngress), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(s.sshRule()), nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraMachine.Status.CurrentRequest)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesDeleteUnknownRule() {
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{s.sshRule()}
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeIngress), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(s.sshRule(), s.httpRule()), nil).Once()
+	s.ionosClient.EXPECT().DeleteFirewallRule(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID,
+		exampleFirewallRuleNameHTTP).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraMachine.Status.CurrentRequest.Method)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesPatchDrift() {
+	rule := s.sshRule()
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{rule}
+
+	drifted := rule
+	drifted.SourceCIDR = ptr.To(exampleFirewallSourceCIDR)
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeIngress), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(drifted), nil).Once()
+	s.ionosClient.EXPECT().PatchFirewallRule(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID,
+		exampleFirewallRuleNameSSH, mock.MatchedBy(func(props sdk.FirewallruleProperties) bool {
+			return props.Protocol == nil && props.SourceIp == &sdk.Nilstring &&
+				ptr.Deref(props.PortRangeStart, 0) == 22
+		})).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraMachine.Status.CurrentRequest.Method)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesReportDrift() {
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.infraMachine.Status.Ready = true
+	rule := s.sshRule()
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{rule, s.httpRule()}
+
+	drifted := rule
+	drifted.SourceCIDR = ptr.To(exampleFirewallSourceCIDR)
+	unexpected := rule
+	unexpected.Name = "unexpected"
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeEgress), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(drifted, unexpected), nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraMachine.Status.CurrentRequest)
+	s.Equal([]string{
+		`firewall of NIC ` + exampleNICID + ` has type "EGRESS" instead of "INGRESS"`,
+		`firewall rule "ssh" differs from the spec`,
+		`firewall rule "unexpected" is unexpected`,
+		`firewall rule "http" is missing`,
+	}, s.machineScope.Drift)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesRecreateOnProtocolChange() {
+	rule := s.sshRule()
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{rule}
+
+	drifted := rule
+	drifted.Protocol = infrav1.FirewallRuleProtocolUDP
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeIngress), nil).Once()
+	s.mockListFirewallRulesCall().Return(s.exampleFirewallRules(drifted), nil).Once()
+	s.ionosClient.EXPECT().DeleteFirewallRule(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID,
+		exampleFirewallRuleNameSSH).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraMachine.Status.CurrentRequest.Method)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesDeactivateFirewall() {
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeIngress), nil).Once()
+	s.mockPatchNICCall(sdk.NicProperties{FirewallActive: ptr.To(false)}).
+		Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraMachine.Status.CurrentRequest.Method)
+}
+
+func (s *firewallSuite) TestReconcileFirewallRulesNarrowFirewallFirst() {
+	s.infraMachine.Spec.FirewallRules = []infrav1.FirewallRule{s.sshRule()}
+	s.mockGetServerCall(exampleServerID).Return(s.serverWithFirewall(firewallTypeBidirectional), nil).Once()
+	s.mockPatchNICCall(sdk.NicProperties{
+		FirewallActive: ptr.To(true),
+		FirewallType:   ptr.To(firewallTypeIngress),
+	}).Return(exampleFirewallRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileFirewallRules(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func TestFirewallType(t *testing.T) {
+	ingress := infrav1.FirewallRule{Name: "in", Protocol: infrav1.FirewallRuleProtocolAny}
+	egress := infrav1.FirewallRule{
+		Name:      "out",
+		Protocol:  infrav1.FirewallRuleProtocolAny,
+		Direction: infrav1.FirewallRuleDirectionEgress,
+	}
+
+	require.Equal(t, "", firewallType(nil))
+	require.Equal(t, firewallTypeIngress, firewallType([]infrav1.FirewallRule{ingress}))
+	require.Equal(t, firewallTypeEgress, firewallType([]infrav1.FirewallRule{egress}))
+	require.Equal(t, firewallTypeBidirectional, firewallType([]infrav1.FirewallRule{ingress, egress}))
+}
+
+func TestWidensFirewall(t *testing.T) {
+	require.True(t, widensFirewall("", firewallTypeIngress))
+	require.True(t, widensFirewall(firewallTypeIngress, firewallTypeBidirectional))
+	require.True(t, widensFirewall(firewallTypeIngress, firewallTypeEgress))
+	require.False(t, widensFirewall(firewallTypeBidirectional, firewallTypeEgress))
+	require.False(t, widensFirewall(firewallTypeIngress, ""))
+}
+
+func (*firewallSuite) sshRule() infrav1.FirewallRule {
+	return infrav1.FirewallRule{
+		Name:           exampleFirewallRuleNameSSH,
+		Protocol:       infrav1.FirewallRuleProtocolTCP,
+		PortRangeStart: ptr.To[int32](22),
+		PortRangeEnd:   ptr.To[int32](22),
+	}
+}
+
+func (*firewallSuite) httpRule() infrav1.FirewallRule {
+	return infrav1.FirewallRule{
+		Name:           exampleFirewallRuleNameHTTP,
+		Protocol:       infrav1.FirewallRuleProtocolTCP,
+		PortRangeStart: ptr.To[int32](80),
+		PortRangeEnd:   ptr.To[int32](80),
+	}
+}
+
+// exampleFirewallRules returns the given rules as they are returned by the cloud.
+// The ID of each rule equals its name.
+func (s *firewallSuite) exampleFirewallRules(rules ...infrav1.FirewallRule) *sdk.FirewallRules {
+	items := make([]sdk.FirewallRule, 0, len(rules))
+	for _, rule := range rules {
+		items = append(items, sdk.FirewallRule{
+			Id:         ptr.To(rule.Name),
+			Properties: ptr.To(s.service.buildFirewallRuleProperties(rule)),
+		})
+	}
+	return &sdk.FirewallRules{Items: &items}
+}
+
+func (s *firewallSuite) serverWithFirewall(firewallType string) *sdk.Server {
+	server := s.defaultServer(s.infraMachine, exampleDHCPIP)
+	props := (*server.Entities.Nics.Items)[0].Properties
+	props.FirewallActive = ptr.To(true)
+	props.FirewallType = &firewallType
+	return server
+}
+
+func (s *firewallSuite) mockListFirewallRulesCall() *clienttest.MockClient_ListFirewallRules_Call {
+	return s.ionosClient.EXPECT().ListFirewallRules(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID)
+}
+
+func (s *firewallSuite) mockPatchNICCall(props sdk.NicProperties) *clienttest.MockClient_PatchNIC_Call {
+	return s.ionosClient.EXPECT().PatchNIC(s.ctx, s.machineScope.DatacenterID(), exampleServerID, exampleNICID, props)
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// labeledResource is an IONOS Cloud resource, which is labeled with the name of the machine owning it.
+type labeledResource struct {
+	id           string
+	resourceType sdk.Type
+	datacenterID string
+	machineName  string
+}
+
+// DeleteOrphanedResources deletes servers and volumes, which are labeled with the name and namespace of the
+// cluster, but whose IonosCloudMachine doesn't exist anymore. Such resources are leaked if the deletion of a
+// machine failed, e.g. because its finalizer was removed manually. Only the data centers, which are used by
+// the cluster, are considered, so that clusters with the same name sharing a contract don't collect the
+// resources of each other.
+//
+// NICs are deleted together with their server. LANs and IP blocks cannot be labeled, but belong to the
+// cluster, which still exists, and are deleted with it.
+func (s *Service) DeleteOrphanedResources(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("DeleteOrphanedResources")
+
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not list machines: %w", err)
+	}
+	machineNames := make(map[string]struct{}, len(machines))
+	for _, machine := range machines {
+		machineNames[machine.Name] = struct{}{}
+	}
+
+	datacenterIDs, err := s.networkDatacenterIDs(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	resources, err := s.labeledResources(ctx, cs, datacenterIDs)
+	if err != nil {
+		return false, err
+	}
+
+	// The volumes of orphaned servers are deleted together with the server.
+	orphanedServers := make(map[string]struct{})
+	for _, resource := range resources {
+		if _, exists := machineNames[resource.machineName]; exists || resource.resourceType != sdk.SERVER {
+			continue
+		}
+		orphanedServers[resource.machineName] = struct{}{}
+		if err := s.deleteOrphanedServer(ctx, resource); err != nil {
+			return false, err
+		}
+	}
+
+	for _, resource := range resources {
+		if _, exists := machineNames[resource.machineName]; exists || resource.resourceType != sdk.VOLUME {
+			continue
+		}
+		if _, exists := orphanedServers[resource.machineName]; exists {
+			continue
+		}
+		if err := s.deleteOrphanedVolume(ctx, resource); err != nil {
+			return false, err
+		}
+	}
+
+	log.V(4).Info("Checked for orphaned resources", "resources", len(resources))
+	return false, nil
+}
+
+// labeledResources returns all servers and volumes in the given data centers, which are labeled with the name
+// and namespace of the cluster and the name of a machine. Resources without the namespace label are ignored.
+func (s *Service) labeledResources(
+	ctx context.Context, cs *scope.Cluster, datacenterIDs []string,
+) ([]labeledResource, error) {
+	labels, err := s.apiWithDepth(1).ListLabels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list labels: %w", err)
+	}
+
+	// Labels are listed individually, so they need to be grouped by the resource they belong to.
+	type resourceLabels struct {
+		resourceType string
+		href         string
+		labels       map[string]string
+	}
+	byResource := make(map[string]*resourceLabels)
+	for _, label := range ptr.Deref(labels.GetItems(), nil) {
+		props := label.GetProperties()
+		id := ptr.Deref(props.GetResourceId(), "")
+		if id == "" {
+			continue
+		}
+		if _, exists := byResource[id]; !exists {
+			byResource[id] = &resourceLabels{
+				resourceType: ptr.Deref(props.GetResourceType(), ""),
+				href:         ptr.Deref(props.GetResourceHref(), ""),
+				labels:       make(map[string]string),
+			}
+		}
+		byResource[id].labels[ptr.Deref(props.GetKey(), "")] = ptr.Deref(props.GetValue(), "")
+	}
+
+	var resources []labeledResource
+	for id, res := range byResource {
+		resourceType := sdk.Type(res.resourceType)
+		if resourceType != sdk.SERVER && resourceType != sdk.VOLUME {
+			continue
+		}
+		machineName := res.labels[machineNameLabelKey]
+		if res.labels[clusterNameLabelKey] != cs.Cluster.Name ||
+			res.labels[clusterNamespaceLabelKey] != cs.Cluster.Namespace || machineName == "" {
+			continue
+		}
+		// Volumes, which were retained on purpose, are kept until they are deleted manually.
+		if res.labels[orphanedLabelKey] == "true" {
+			continue
+		}
+		datacenterID := datacenterIDFromHref(res.href)
+		if datacenterID == "" || !slices.Contains(datacenterIDs, datacenterID) {
+			continue
+		}
+		resources = append(resources, labeledResource{
+			id:           id,
+			resourceType: resourceType,
+			datacenterID: datacenterID,
+			machineName:  machineName,
+		})
+	}
+
+	return resources, nil
+}
+
+// deleteOrphanedServer requests the deletion of an orphaned server and all of its volumes,
+// unless the server is already being deleted.
+func (s *Service) deleteOrphanedServer(ctx context.Context, server labeledResource) error {
+	log := s.logger.WithName("deleteOrphanedServer")
+
+	request, err := s.getLatestServerDeletionRequest(ctx, server.datacenterID, server.id)
+	if err != nil {
+		return err
+	}
+	if request != nil && request.isPending() {
+		log.V(4).Info("Deletion of orphaned server is pending", "serverID", server.id, "location", request.location, "requestID", request.id())
+		return nil
+	}
+
+	requestLocation, err := s.ionosClient.DeleteServer(ctx, server.datacenterID, server.id, true)
+	if ignoreNotFound(err) != nil {
+		return fmt.Errorf("failed to request deletion of orphaned server %s: %w", server.id, err)
+	}
+
+	log.Info("Requested deletion of orphaned server",
+		"serverID", server.id, "machine", server.machineName, "location", requestLocation)
+	return nil
+}
+
+// deleteOrphanedVolume requests the deletion of an orphaned volume, unless it is already being deleted.
+func (s *Service) deleteOrphanedVolume(ctx context.Context, volume labeledResource) error {
+	log := s.logger.WithName("deleteOrphanedVolume")
+
+	request, err := getMatchingRequest[sdk.Volume](
+		ctx,
+		s,
+		http.MethodDelete,
+		path.Join("datacenters", volume.datacenterID, "volumes", volume.id),
+	)
+	if err != nil {
+		return err
+	}
+	if request != nil && request.isPending() {
+		log.V(4).Info("Deletion of orphaned volume is pending", "volumeID", volume.id, "location", request.location, "requestID", request.id())
+		return nil
+	}
+
+	requestLocation, err := s.ionosClient.DeleteVolume(ctx, volume.datacenterID, volume.id)
+	if ignoreNotFound(err) != nil {
+		return fmt.Errorf("failed to request deletion of orphaned volume %s: %w", volume.id, err)
+	}
+
+	log.Info("Requested deletion of orphaned volume",
+		"volumeID", volume.id, "machine", volume.machineName, "location", requestLocation)
+	return nil
+}
+
+// datacenterIDFromHref extracts the ID of the data center from the URL of a resource inside a data center.
+func datacenterIDFromHref(href string) string {
+	segments := strings.Split(strings.Trim(href, "/"), "/")
+	if i := slices.Index(segments, "datacenters"); i >= 0 && i+1 < len(segments) {
+		return segments[i+1]
+	}
+	return ""
+}
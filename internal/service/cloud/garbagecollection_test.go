@@ -0,0 +1,173 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"path"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const exampleOrphanedVolumeID = "2b3a9e4c-7d7e-4d6e-9a0e-0f1f2c3d4e5f"
+
+type garbageCollectionSuite struct {
+	ServiceTestSuite
+}
+
+func TestGarbageCollectionSuite(t *testing.T) {
+	suite.Run(t, new(garbageCollectionSuite))
+}
+
+func (s *garbageCollectionSuite) TestDeleteOrphanedResources() {
+	s.ionosClient.EXPECT().ListLabels(s.ctx).Return(s.exampleLabels(
+		// The machine still exists.
+		s.labeledServer(exampleServerID, s.capiCluster.Name, s.infraMachine.Name),
+		// The volume is deleted together with its server.
+		s.labeledServer(exampleSecondaryServerID, s.capiCluster.Name, "deleted-machine"),
+		s.labeledVolume(exampleAdditionalVolumeID, s.capiCluster.Name, "deleted-machine"),
+		s.labeledVolume(exampleOrphanedVolumeID, s.capiCluster.Name, "detached-machine"),
+		// The resources of other clusters must be kept.
+		s.labeledServer(exampleTemplateID, "other-cluster", "other-machine"),
+		s.resourceLabels(sdk.SERVER, path.Join(s.service.serversURL(s.machineScope.DatacenterID()), "other-namespace"),
+			"other-namespace", s.capiCluster.Name, "other", "deleted-machine"),
+		s.resourceLabels(sdk.SERVER, path.Join(s.service.serversURL("other-datacenter"), "other-datacenter"),
+			"other-datacenter", s.capiCluster.Name, s.capiCluster.Namespace, "deleted-machine"),
+		// Resources without the namespace label cannot be attributed to the cluster.
+		s.resourceLabels(sdk.SERVER, path.Join(s.service.serversURL(s.machineScope.DatacenterID()), "unlabeled"),
+			"unlabeled", s.capiCluster.Name, "", "deleted-machine"),
+	), nil).Once()
+
+	datacenterID := s.machineScope.DatacenterID()
+	s.mockGetServerDeletionRequestCall(exampleSecondaryServerID).Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteServer(s.ctx, datacenterID, exampleSecondaryServerID, true).
+		Return("delete/server", nil).Once()
+	s.mockGetVolumeDeletionRequestCall(exampleOrphanedVolumeID).Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteVolume(s.ctx, datacenterID, exampleOrphanedVolumeID).
+		Return("delete/volume", nil).Once()
+
+	requeue, err := s.service.DeleteOrphanedResources(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *garbageCollectionSuite) TestDeleteOrphanedResourcesDeletionPending() {
+	s.ionosClient.EXPECT().ListLabels(s.ctx).Return(s.exampleLabels(
+		s.labeledServer(exampleSecondaryServerID, s.capiCluster.Name, "deleted-machine"),
+	), nil).Once()
+
+	request := s.exampleRequest(requestBuildOptions{
+		status:     sdk.RequestStatusRunning,
+		method:     http.MethodDelete,
+		url:        path.Join(s.service.serversURL(s.machineScope.DatacenterID()), exampleSecondaryServerID),
+		href:       exampleRequestPath,
+		targetID:   exampleSecondaryServerID,
+		targetType: sdk.SERVER,
+	})
+	s.mockGetServerDeletionRequestCall(exampleSecondaryServerID).Return([]sdk.Request{request}, nil).Once()
+
+	requeue, err := s.service.DeleteOrphanedResources(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *garbageCollectionSuite) TestDeleteOrphanedResourcesRetainedVolume() {
+	retained := s.labeledVolume(exampleOrphanedVolumeID, s.capiCluster.Name, "deleted-machine")
+	retainedLabel := *retained[0].Properties
+	retainedLabel.Key = ptr.To(orphanedLabelKey)
+	retainedLabel.Value = ptr.To("true")
+	retained = append(retained, sdk.Label{Properties: &retainedLabel})
+
+	s.ionosClient.EXPECT().ListLabels(s.ctx).Return(s.exampleLabels(retained), nil).Once()
+
+	requeue, err := s.service.DeleteOrphanedResources(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *garbageCollectionSuite) TestDeleteOrphanedResourcesNoLabels() {
+	s.ionosClient.EXPECT().ListLabels(s.ctx).Return(&sdk.Labels{}, nil).Once()
+
+	requeue, err := s.service.DeleteOrphanedResources(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *garbageCollectionSuite) TestDatacenterIDFromHref() {
+	datacenterID := s.machineScope.DatacenterID()
+	s.Equal(datacenterID, datacenterIDFromHref("https://api.ionos.com/cloudapi/v6/datacenters/"+datacenterID+"/servers/1"))
+	s.Equal(datacenterID, datacenterIDFromHref("/datacenters/"+datacenterID+"/volumes/1"))
+	s.Empty(datacenterIDFromHref("https://api.ionos.com/cloudapi/v6/ipblocks/1"))
+	s.Empty(datacenterIDFromHref("/datacenters"))
+}
+
+func (s *garbageCollectionSuite) exampleLabels(labels ...[]sdk.Label) *sdk.Labels {
+	var items []sdk.Label
+	for _, l := range labels {
+		items = append(items, l...)
+	}
+	return &sdk.Labels{Items: &items}
+}
+
+func (s *garbageCollectionSuite) labeledServer(id, clusterName, machineName string) []sdk.Label {
+	return s.resourceLabels(sdk.SERVER, path.Join(s.service.serversURL(s.machineScope.DatacenterID()), id),
+		id, clusterName, s.capiCluster.Namespace, machineName)
+}
+
+func (s *garbageCollectionSuite) labeledVolume(id, clusterName, machineName string) []sdk.Label {
+	return s.resourceLabels(sdk.VOLUME, path.Join("datacenters", s.machineScope.DatacenterID(), "volumes", id),
+		id, clusterName, s.capiCluster.Namespace, machineName)
+}
+
+// resourceLabels returns the labels of a resource. The namespace label is omitted, if namespace is empty.
+func (*garbageCollectionSuite) resourceLabels(
+	resourceType sdk.Type, href, id, clusterName, namespace, machineName string,
+) []sdk.Label {
+	label := func(key, value string) sdk.Label {
+		return sdk.Label{Properties: &sdk.LabelProperties{
+			Key:          ptr.To(key),
+			Value:        ptr.To(value),
+			ResourceId:   ptr.To(id),
+			ResourceType: ptr.To(string(resourceType)),
+			ResourceHref: ptr.To("https://api.ionos.com/cloudapi/v6/" + href),
+		}}
+	}
+	labels := []sdk.Label{
+		label(clusterNameLabelKey, clusterName),
+		label(machineNameLabelKey, machineName),
+		label("team", "platform"),
+	}
+	if namespace != "" {
+		labels = append(labels, label(clusterNamespaceLabelKey, namespace))
+	}
+	return labels
+}
+
+func (s *garbageCollectionSuite) mockGetServerDeletionRequestCall(serverID string) *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete,
+		path.Join(s.service.serversURL(s.machineScope.DatacenterID()), serverID))
+}
+
+func (s *garbageCollectionSuite) mockGetVolumeDeletionRequestCall(volumeID string) *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete,
+		path.Join("datacenters", s.machineScope.DatacenterID(), "volumes", volumeID))
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// hostnameRandomLength is the number of random characters provided to hostname formats.
+const hostnameRandomLength = 5
+
+// hostnameRandomAlphabet contains the characters of the random part of hostnames. Vowels and ambiguous
+// characters are left out, like in the names generated by Kubernetes.
+const hostnameRandomAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+// hostnameFormatData is the data, with which hostname formats are rendered.
+type hostnameFormatData struct {
+	ClusterName string
+	MachineName string
+	Namespace   string
+	Random      string
+}
+
+// renderHostname renders the hostname format of the machine and stores the hostname in its status.
+// The hostname is only rendered once before the server is created, as it must not change afterward.
+func renderHostname(ms *scope.Machine) error {
+	format := ms.IonosMachine.Spec.HostnameFormat
+	if format == "" || ms.IonosMachine.Status.Hostname != "" || ms.ServerID() != "" {
+		return nil
+	}
+
+	tmpl, err := template.New("hostname").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return fmt.Errorf("failed to parse hostname format: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, hostnameFormatData{
+		ClusterName: ms.ClusterScope.Cluster.Name,
+		MachineName: ms.IonosMachine.Name,
+		Namespace:   ms.IonosMachine.Namespace,
+		Random:      hostnameRandom(ms),
+	}); err != nil {
+		return fmt.Errorf("failed to render hostname format: %w", err)
+	}
+
+	hostname := b.String()
+	if errs := validation.IsDNS1123Label(hostname); len(errs) > 0 {
+		return fmt.Errorf("rendered hostname %q is invalid: %s", hostname, strings.Join(errs, ", "))
+	}
+
+	ms.IonosMachine.Status.Hostname = hostname
+	return nil
+}
+
+// hostnameRandom returns the random characters of the hostname of the machine. They are derived from the UID of
+// the IonosCloudMachine, so that the same hostname is rendered again if the status couldn't be persisted after
+// the creation of the server was requested. This allows finding the server by its name instead of creating
+// another one.
+func hostnameRandom(ms *scope.Machine) string {
+	sum := sha256.Sum256([]byte(ms.IonosMachine.Namespace + "/" + ms.IonosMachine.Name + "/" +
+		string(ms.IonosMachine.UID)))
+	random := make([]byte, hostnameRandomLength)
+	for i := range random {
+		random[i] = hostnameRandomAlphabet[int(sum[i])%len(hostnameRandomAlphabet)]
+	}
+	return string(random)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+type hostnameSuite struct {
+	ServiceTestSuite
+}
+
+func TestHostnameSuite(t *testing.T) {
+	suite.Run(t, new(hostnameSuite))
+}
+
+func (s *hostnameSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraMachine.Spec.ProviderID = nil
+}
+
+func (s *hostnameSuite) TestRenderHostname() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .Namespace }}-{{ .ClusterName }}-{{ .MachineName }}"
+	s.NoError(renderHostname(s.machineScope))
+	s.Equal("default-test-cluster-test-machine", s.infraMachine.Status.Hostname)
+	s.Equal("default-test-cluster-test-machine", s.machineScope.Hostname())
+}
+
+func (s *hostnameSuite) TestRenderHostnameRandom() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+	s.NoError(renderHostname(s.machineScope))
+	s.Regexp("^test-cluster-[a-z0-9]{5}$", s.infraMachine.Status.Hostname)
+
+	// The hostname is only rendered once.
+	hostname := s.infraMachine.Status.Hostname
+	s.NoError(renderHostname(s.machineScope))
+	s.Equal(hostname, s.infraMachine.Status.Hostname)
+}
+
+func (s *hostnameSuite) TestRenderHostnameRandomIsDeterministic() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+	s.infraMachine.UID = "a1b2c3"
+	s.NoError(renderHostname(s.machineScope))
+	hostname := s.infraMachine.Status.Hostname
+
+	s.infraMachine.Status.Hostname = ""
+	s.NoError(renderHostname(s.machineScope))
+	s.Equal(hostname, s.infraMachine.Status.Hostname, "the hostname must be the same after the status was lost")
+
+	s.infraMachine.Status.Hostname = ""
+	s.infraMachine.UID = "d4e5f6"
+	s.NoError(renderHostname(s.machineScope))
+	s.NotEqual(hostname, s.infraMachine.Status.Hostname, "another machine must get another hostname")
+}
+
+func (s *hostnameSuite) TestRenderHostnameNotSet() {
+	s.NoError(renderHostname(s.machineScope))
+	s.Empty(s.infraMachine.Status.Hostname)
+	s.Equal(s.infraMachine.Name, s.machineScope.Hostname())
+}
+
+func (s *hostnameSuite) TestRenderHostnameServerExists() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+	s.infraMachine.Spec.ProviderID = ptr.To("ionos://" + exampleServerID)
+	s.NoError(renderHostname(s.machineScope))
+	s.Empty(s.infraMachine.Status.Hostname, "the hostname of an existing server must not change")
+}
+
+func (s *hostnameSuite) TestRenderHostnameInvalidFormat() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName "
+	s.ErrorContains(renderHostname(s.machineScope), "failed to parse hostname format")
+
+	s.infraMachine.Spec.HostnameFormat = "{{ .Unknown }}"
+	s.ErrorContains(renderHostname(s.machineScope), "failed to render hostname format")
+	s.Empty(s.infraMachine.Status.Hostname)
+}
+
+func (s *hostnameSuite) TestRenderHostnameInvalidLabel() {
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}.{{ .MachineName }}"
+	s.ErrorContains(renderHostname(s.machineScope), `rendered hostname "test-cluster.test-machine" is invalid`)
+	s.Empty(s.infraMachine.Status.Hostname)
+}
@@ -23,13 +23,16 @@ import (
 	"net/http"
 	"path"
 	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
@@ -63,9 +66,25 @@ func (s *Service) ReconcileControlPlaneEndpoint(ctx context.Context, cs *scope.C
 			log.Info("IP block is not available yet", "state", state)
 			return true, nil
 		}
-		if cs.IonosCluster.Spec.ControlPlaneEndpoint.Host == "" {
+		host := cs.IonosCluster.Spec.ControlPlaneEndpoint.Host
+		dnsName := cs.ControlPlaneEndpointDNSName()
+		if host == "" || dnsName != "" && strings.EqualFold(host, dnsName) {
 			ip := (*ipBlock.Properties.Ips)[0]
-			cs.IonosCluster.Spec.ControlPlaneEndpoint.Host = ip
+			if dnsName != "" {
+				// The managed DNS record points to the IP, which is resolved from the status instead.
+				setControlPlaneEndpointDNSAddress(cs, ip)
+			}
+			if host == "" {
+				if dnsName != "" {
+					cs.IonosCluster.Spec.ControlPlaneEndpoint.Host = dnsName
+					s.recordEvent(cs.IonosCluster, ipAddressAllocatedReason,
+						"Control plane endpoint %s got IP address %s from IP block %s", dnsName, ip, *ipBlock.Id)
+				} else {
+					cs.IonosCluster.Spec.ControlPlaneEndpoint.Host = ip
+					s.recordEvent(cs.IonosCluster, ipAddressAllocatedReason,
+						"Control plane endpoint got IP address %s from IP block %s", ip, *ipBlock.Id)
+				}
+			}
 		}
 		if cs.IonosCluster.Spec.ControlPlaneEndpoint.Port == 0 {
 			cs.IonosCluster.Spec.ControlPlaneEndpoint.Port = defaultControlPlaneEndpointPort
@@ -77,7 +96,7 @@ func (s *Service) ReconcileControlPlaneEndpoint(ctx context.Context, cs *scope.C
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
 		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
-		log.Info("Request is pending", "location", request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -125,7 +144,7 @@ func (s *Service) ReconcileControlPlaneEndpointDeletion(
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
 		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
-		log.Info("Creation request is pending", "location", request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -137,7 +156,7 @@ func (s *Service) ReconcileControlPlaneEndpointDeletion(
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
 		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
-		log.Info("Deletion request is pending", "location", request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -340,8 +359,8 @@ func (s *Service) reserveControlPlaneEndpointIPBlock(ctx context.Context, cs *sc
 	log := s.logger.WithName("reserveControlPlaneEndpointIPBlock")
 	return s.reserveIPBlock(
 		ctx, s.controlPlaneEndpointIPBlockName(cs),
-		cs.Location(), log,
-		cs.IonosCluster.SetCurrentClusterRequest,
+		cs.Location(), 1, log,
+		cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest,
 	)
 }
 
@@ -349,8 +368,8 @@ func (s *Service) reserveMachineDeploymentFailoverIPBlock(ctx context.Context, m
 	log := s.logger.WithName("reserveMachineDeploymentFailoverIPBlock")
 	return s.reserveIPBlock(
 		ctx, s.failoverIPBlockName(ms),
-		ms.ClusterScope.Location(), log,
-		ms.IonosMachine.SetCurrentRequest,
+		ms.ClusterScope.Location(), 1, log,
+		ms.IonosMachine, ms.IonosMachine.SetCurrentRequest,
 	)
 }
 
@@ -358,15 +377,18 @@ func (s *Service) reserveIPBlock(
 	ctx context.Context,
 	ipBlockName,
 	location string,
+	size int32,
 	log logr.Logger,
+	obj runtime.Object,
 	setRequestStatusFunc func(string, string, string),
 ) error {
-	requestPath, err := s.ionosClient.ReserveIPBlock(ctx, ipBlockName, location, 1)
+	requestPath, err := s.ionosClient.ReserveIPBlock(ctx, ipBlockName, location, size)
 	if err != nil {
 		return fmt.Errorf("failed to request the cloud for IP block reservation: %w", err)
 	}
 
 	setRequestStatusFunc(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(obj, ipBlockReservationRequestedReason, "Requested reservation of IP block %s in %s", ipBlockName, location)
 	log.Info("Successfully requested for IP block reservation", "requestPath", requestPath)
 
 	return nil
@@ -375,19 +397,20 @@ func (s *Service) reserveIPBlock(
 // deleteControlPlaneEndpointIPBlock requests for the deletion of the control plane IP block with the given ID.
 func (s *Service) deleteControlPlaneEndpointIPBlock(ctx context.Context, cs *scope.Cluster, ipBlockID string) error {
 	log := s.logger.WithName("deleteControlPlaneEndpointIPBlock")
-	return s.deleteIPBlock(ctx, log, ipBlockID, cs.IonosCluster.SetCurrentClusterRequest)
+	return s.deleteIPBlock(ctx, log, ipBlockID, cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest)
 }
 
 // deleteFailoverIPBlock requests for the deletion of the failover IP block with the given ID.
 func (s *Service) deleteFailoverIPBlock(ctx context.Context, ms *scope.Machine, ipBlockID string) error {
 	log := s.logger.WithName("deleteFailoverIPBlock")
-	return s.deleteIPBlock(ctx, log, ipBlockID, ms.IonosMachine.SetCurrentRequest)
+	return s.deleteIPBlock(ctx, log, ipBlockID, ms.IonosMachine, ms.IonosMachine.SetCurrentRequest)
 }
 
 func (s *Service) deleteIPBlock(
 	ctx context.Context,
 	log logr.Logger,
 	ipBlockID string,
+	obj runtime.Object,
 	setRequestStatusFunc func(string, string, string),
 ) error {
 	requestPath, err := s.ionosClient.DeleteIPBlock(ctx, ipBlockID)
@@ -396,6 +419,7 @@ func (s *Service) deleteIPBlock(
 	}
 
 	setRequestStatusFunc(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(obj, ipBlockDeletionRequestedReason, "Requested deletion of IP block %s", ipBlockID)
 	log.Info("Successfully requested for IP block deletion", "requestPath", requestPath)
 	return nil
 }
@@ -463,3 +487,238 @@ func ignoreErrUserSetIPNotFound(err error) error {
 	}
 	return err
 }
+
+// applicationLoadBalancerIPBlockConsumer identifies the Application Load Balancer in the assignments of
+// the IP blocks of the cluster.
+const applicationLoadBalancerIPBlockConsumer = "ApplicationLoadBalancer"
+
+// machineIPBlockConsumer returns the key, which identifies a machine in the assignments of the IP blocks
+// of the cluster.
+func machineIPBlockConsumer(machineName string) string {
+	return infrav1.IonosCloudMachineType + "/" + machineName
+}
+
+// ReconcileIPBlocks ensures that the IP blocks, which are declared in the cluster spec, are reserved.
+// Afterward, their IPs are assigned to the machines and the Application Load Balancer referencing them.
+func (s *Service) ReconcileIPBlocks(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileIPBlocks")
+
+	for _, spec := range cs.IonosCluster.Spec.IPBlocks {
+		ipBlock, request, err := findResource(ctx,
+			func(ctx context.Context) (*sdk.IpBlock, error) { return s.getClusterIPBlock(ctx, cs, spec) },
+			func(ctx context.Context) (*requestInfo, error) {
+				return s.getLatestClusterIPBlockCreationRequest(ctx, cs, spec)
+			},
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if ipBlock != nil {
+			if state := getState(ipBlock); !isAvailable(state) {
+				log.Info("IP block is not available yet", "ipBlock", spec.Name, "state", state)
+				return true, nil
+			}
+			cs.SetIPBlock(spec.Name, ptr.Deref(ipBlock.GetId(), ""), ptr.Deref(ipBlock.GetProperties().GetIps(), nil))
+			continue
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+			log.Info("Request is pending", "ipBlock", spec.Name, "location", request.location, "requestID", request.id())
+			return true, nil
+		}
+
+		log.V(4).Info("No IP block was found. Creating new IP block", "ipBlock", spec.Name)
+		err = s.reserveIPBlock(
+			ctx, s.clusterIPBlockName(cs, spec.Name),
+			s.clusterIPBlockLocation(cs, spec), max(spec.Size, 1), log,
+			cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest,
+		)
+		return err == nil, err
+	}
+
+	return false, s.assignIPBlockIPs(ctx, cs)
+}
+
+// assignIPBlockIPs assigns a free IP of the referenced IP block to each machine and to the Application Load
+// Balancer. Existing assignments are kept, until the machine has been deleted.
+func (s *Service) assignIPBlockIPs(ctx context.Context, cs *scope.Cluster) error {
+	log := s.logger.WithName("assignIPBlockIPs")
+
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	consumers := make(map[string][]string, len(cs.IonosCluster.Status.IPBlocks))
+	for _, machine := range machines {
+		if machine.Spec.IPBlock != "" {
+			consumers[machine.Spec.IPBlock] = append(consumers[machine.Spec.IPBlock],
+				machineIPBlockConsumer(machine.Name))
+		}
+	}
+	if alb := cs.IonosCluster.Spec.ApplicationLoadBalancer; alb != nil && alb.IPBlock != "" {
+		consumers[alb.IPBlock] = append(consumers[alb.IPBlock], applicationLoadBalancerIPBlockConsumer)
+	}
+
+	for i := range cs.IonosCluster.Status.IPBlocks {
+		block := &cs.IonosCluster.Status.IPBlocks[i]
+		blockConsumers := consumers[block.Name]
+		slices.Sort(blockConsumers)
+
+		assignments := make(map[string]string, len(blockConsumers))
+		used := sets.New[string]()
+		for _, consumer := range blockConsumers {
+			if ip, ok := block.Assignments[consumer]; ok && slices.Contains(block.IPs, ip) {
+				assignments[consumer] = ip
+				used.Insert(ip)
+			}
+		}
+
+		for _, consumer := range blockConsumers {
+			if _, ok := assignments[consumer]; ok {
+				continue
+			}
+			i := slices.IndexFunc(block.IPs, func(ip string) bool { return !used.Has(ip) })
+			if i < 0 {
+				log.Info("IP block has no free IPs left", "ipBlock", block.Name, "consumer", consumer)
+				s.recordWarningEvent(cs.IonosCluster, ipBlockExhaustedReason,
+					"IP block %s has no free IP left for %s", block.Name, consumer)
+				continue
+			}
+			assignments[consumer] = block.IPs[i]
+			used.Insert(block.IPs[i])
+			s.recordEvent(cs.IonosCluster, ipAddressAllocatedReason,
+				"%s got IP address %s from IP block %s", consumer, block.IPs[i], block.Name)
+		}
+
+		block.Assignments = nil
+		if len(assignments) > 0 {
+			block.Assignments = assignments
+		}
+	}
+
+	return nil
+}
+
+// ReconcileIPBlocksDeletion ensures that the IP blocks of the cluster are deleted.
+func (s *Service) ReconcileIPBlocksDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileIPBlocksDeletion")
+
+	for _, spec := range cs.IonosCluster.Spec.IPBlocks {
+		ipBlock, request, err := findResource(ctx,
+			func(ctx context.Context) (*sdk.IpBlock, error) { return s.getClusterIPBlock(ctx, cs, spec) },
+			func(ctx context.Context) (*requestInfo, error) {
+				return s.getLatestClusterIPBlockCreationRequest(ctx, cs, spec)
+			},
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+			log.Info("Creation request is pending", "ipBlock", spec.Name, "location", request.location, "requestID", request.id())
+			return true, nil
+		}
+
+		if ipBlock == nil {
+			cs.RemoveIPBlock(spec.Name)
+			continue
+		}
+
+		ipBlockID := ptr.Deref(ipBlock.GetId(), "")
+		request, err = s.getLatestIPBlockDeletionRequest(ctx, ipBlockID)
+		if err != nil {
+			return false, err
+		}
+
+		if request != nil && request.isPending() {
+			cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+			log.Info("Deletion request is pending", "ipBlock", spec.Name, "location", request.location, "requestID", request.id())
+			return true, nil
+		}
+
+		err = s.deleteIPBlock(ctx, log, ipBlockID, cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest)
+		return err == nil, err
+	}
+
+	return false, nil
+}
+
+// ReconcileIPBlockAddress waits for the cluster to assign an IP of the IP block, which is referenced by the
+// machine, and stores it in the machine scope, so that it is assigned to the primary NIC.
+func (s *Service) ReconcileIPBlockAddress(_ context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileIPBlockAddress")
+
+	name := ms.IonosMachine.Spec.IPBlock
+	if name == "" {
+		return false, nil
+	}
+
+	ip := ""
+	if block := ms.ClusterScope.IPBlock(name); block != nil {
+		ip = block.Assignments[machineIPBlockConsumer(ms.IonosMachine.Name)]
+	}
+	if ip == "" {
+		log.Info("Waiting for an IP of the IP block to be assigned", "ipBlock", name)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.IPAddressClaimedCondition,
+			infrav1.WaitingForIPAddressReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for an IP of IP block %s to be assigned by the IonosCloudCluster", name)
+		return true, nil
+	}
+
+	if ms.ClaimedIPv4Addresses == nil {
+		ms.ClaimedIPv4Addresses = make(map[int]string, 1)
+	}
+	ms.ClaimedIPv4Addresses[0] = ip
+	conditions.MarkTrue(ms.IonosMachine, infrav1.IPAddressClaimedCondition)
+	return false, nil
+}
+
+// getClusterIPBlock finds the IP block of the cluster with the given spec by its name and location.
+func (s *Service) getClusterIPBlock(
+	ctx context.Context, cs *scope.Cluster, spec infrav1.IPBlockSpec,
+) (*sdk.IpBlock, error) {
+	blocks, err := s.apiWithDepth(listIPBlocksDepth).ListIPBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP blocks: %w", err)
+	}
+
+	for _, block := range ptr.Deref(blocks.GetItems(), nil) {
+		props := block.GetProperties()
+		if ptr.Deref(props.GetLocation(), "") != s.clusterIPBlockLocation(cs, spec) {
+			continue
+		}
+		if ptr.Deref(props.GetName(), "") == s.clusterIPBlockName(cs, spec.Name) {
+			return s.cloudAPIStateInconsistencyWorkaround(ctx, &block)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Service) getLatestClusterIPBlockCreationRequest(
+	ctx context.Context, cs *scope.Cluster, spec infrav1.IPBlockSpec,
+) (*requestInfo, error) {
+	return s.getLatestIPBlockRequestByNameAndLocation(
+		ctx, http.MethodPost,
+		s.clusterIPBlockName(cs, spec.Name),
+		s.clusterIPBlockLocation(cs, spec),
+	)
+}
+
+// clusterIPBlockName returns the name of the IP block in IONOS Cloud for the IP block of the cluster
+// with the given name.
+func (*Service) clusterIPBlockName(cs *scope.Cluster, name string) string {
+	return fmt.Sprintf("ipb-%s-%s-%s", cs.Cluster.Namespace, cs.Cluster.Name, name)
+}
+
+// clusterIPBlockLocation returns the location of the IP block, which defaults to the location of the cluster.
+func (*Service) clusterIPBlockLocation(cs *scope.Cluster, spec infrav1.IPBlockSpec) string {
+	if spec.Location != "" {
+		return spec.Location
+	}
+	return cs.Location()
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// lanIPv6PrefixBits is the size of the IPv6 CIDR block, which IONOS Cloud delegates to a LAN.
+	lanIPv6PrefixBits = 64
+	// nicIPv6PrefixBits is the size of the IPv6 CIDR block of a NIC.
+	nicIPv6PrefixBits = 80
+	// maxIPv6Slice is the index of the last /80 slice of a /64 prefix.
+	maxIPv6Slice = 1<<(nicIPv6PrefixBits-lanIPv6PrefixBits) - 1
+)
+
+// ReconcileIPv6Prefixes records the IPv6 CIDR block, which IONOS Cloud delegated to the primary LAN of the cluster
+// in every data center with dual-stack machines, and assigns a /80 slice of it to the primary NIC of every
+// dual-stack machine, whose server has not been created yet. The slices are assigned to the machines in the order
+// of their names, starting with the second slice. Slices, which are already used by NICs, are skipped.
+func (s *Service) ReconcileIPv6Prefixes(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileIPv6Prefixes")
+
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	status := &cs.IonosCluster.Status
+	machinesByDatacenter := make(map[string][]infrav1.IonosCloudMachine)
+	dualStack := make(map[string]bool)
+	for _, machine := range machines {
+		if machine.Spec.IPv6 == nil || machine.Spec.DatacenterID == "" {
+			continue
+		}
+		datacenterID := machine.Spec.DatacenterID
+		machinesByDatacenter[datacenterID] = append(machinesByDatacenter[datacenterID], machine)
+		dualStack[machine.Name] = true
+	}
+	for datacenterID := range status.IPv6Prefixes {
+		if _, ok := machinesByDatacenter[datacenterID]; !ok {
+			delete(status.IPv6Prefixes, datacenterID)
+		}
+	}
+	for name := range status.IPv6CIDRBlocks {
+		if !dualStack[name] {
+			delete(status.IPv6CIDRBlocks, name)
+		}
+	}
+
+	datacenterIDs := make([]string, 0, len(machinesByDatacenter))
+	for datacenterID := range machinesByDatacenter {
+		datacenterIDs = append(datacenterIDs, datacenterID)
+	}
+	slices.Sort(datacenterIDs)
+
+	for _, datacenterID := range datacenterIDs {
+		lan, err := s.getPrimaryLAN(ctx, cs, datacenterID)
+		if err != nil {
+			return false, err
+		}
+		if lan == nil || !isAvailable(getState(lan)) || ptr.Deref(lan.GetProperties().GetIpv6CidrBlock(), "") == "" {
+			// IPv6 is enabled on the LAN by the machines, which are attached to it.
+			log.V(4).Info("IPv6 is not enabled on the primary LAN yet", "datacenterID", datacenterID)
+			continue
+		}
+		cidr := *lan.GetProperties().GetIpv6CidrBlock()
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil || !prefix.Addr().Is6() || prefix.Bits() != lanIPv6PrefixBits {
+			return false, fmt.Errorf("unexpected IPv6 CIDR block %s of the primary LAN in data center %s",
+				cidr, datacenterID)
+		}
+
+		if status.IPv6Prefixes[datacenterID] != cidr {
+			if status.IPv6Prefixes == nil {
+				status.IPv6Prefixes = make(map[string]string)
+			}
+			status.IPv6Prefixes[datacenterID] = cidr
+			s.recordEvent(cs.IonosCluster, ipv6PrefixDelegatedReason,
+				"IPv6 CIDR block %s is delegated to the primary LAN in data center %s", cidr, datacenterID)
+		}
+
+		if err := assignIPv6CIDRBlocks(status, prefix, machinesByDatacenter[datacenterID]); err != nil {
+			return false, fmt.Errorf("unable to assign IPv6 CIDR blocks in data center %s: %w", datacenterID, err)
+		}
+	}
+
+	return false, nil
+}
+
+// assignIPv6CIDRBlocks assigns the next free /80 slice of the prefix to the machines without an assigned slice,
+// whose server has not been created yet. Assigned slices, which are not part of the prefix, e.g. because
+// the LAN was recreated, are replaced.
+func assignIPv6CIDRBlocks(
+	status *infrav1.IonosCloudClusterStatus, prefix netip.Prefix, machines []infrav1.IonosCloudMachine,
+) error {
+	slices.SortFunc(machines, func(a, b infrav1.IonosCloudMachine) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	used := make(map[int]bool)
+	for _, machine := range machines {
+		if slice, ok := ipv6Slice(prefix, status.IPv6CIDRBlocks[machine.Name]); ok {
+			used[slice] = true
+		} else {
+			delete(status.IPv6CIDRBlocks, machine.Name)
+		}
+		if machine.Status.MachineNetworkInfo == nil {
+			continue
+		}
+		for _, nic := range machine.Status.MachineNetworkInfo.NICInfo {
+			for _, address := range nic.IPv6Addresses {
+				if slice, ok := ipv6Slice(prefix, address); ok {
+					used[slice] = true
+				}
+			}
+		}
+	}
+
+	next := 1
+	for _, machine := range machines {
+		if machine.Spec.ProviderID != nil || status.IPv6CIDRBlocks[machine.Name] != "" {
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		if next > maxIPv6Slice {
+			return fmt.Errorf("no free /%d slice left in %s", nicIPv6PrefixBits, prefix)
+		}
+		used[next] = true
+		if status.IPv6CIDRBlocks == nil {
+			status.IPv6CIDRBlocks = make(map[string]string)
+		}
+		status.IPv6CIDRBlocks[machine.Name] = ipv6SlicePrefix(prefix, next).String()
+	}
+	return nil
+}
+
+// ipv6Slice returns the index of the /80 slice of the prefix, which contains the given IPv6 address or CIDR block.
+// It returns false, if the address is not part of the prefix.
+func ipv6Slice(prefix netip.Prefix, address string) (int, bool) {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		p, err := netip.ParsePrefix(address)
+		if err != nil {
+			return 0, false
+		}
+		addr = p.Addr()
+	}
+	if !prefix.Contains(addr) {
+		return 0, false
+	}
+	b := addr.As16()
+	return int(b[8])<<8 | int(b[9]), true
+}
+
+// ipv6SlicePrefix returns the /80 slice of the prefix with the given index.
+func ipv6SlicePrefix(prefix netip.Prefix, slice int) netip.Prefix {
+	b := prefix.Masked().Addr().As16()
+	b[8], b[9] = byte(slice>>8), byte(slice)
+	return netip.PrefixFrom(netip.AddrFrom16(b), nicIPv6PrefixBits)
+}
+
+// ReconcileIPv6CIDRBlock stores the IPv6 CIDR block, which the cluster assigned to the primary NIC of a dual-stack
+// machine, in the machine scope. The server is created, once the cluster has assigned the block.
+func (s *Service) ReconcileIPv6CIDRBlock(_ context.Context, ms *scope.Machine) (requeue bool, err error) {
+	if ms.IonosMachine.Spec.IPv6 == nil || ms.IonosMachine.Spec.ProviderID != nil {
+		return false, nil
+	}
+
+	block := ms.ClusterScope.IonosCluster.Status.IPv6CIDRBlocks[ms.IonosMachine.Name]
+	if block == "" {
+		s.logger.WithName("ReconcileIPv6CIDRBlock").Info("Waiting for the cluster to assign an IPv6 CIDR block")
+		return true, nil
+	}
+	ms.ClaimedIPv6CIDRBlock = block
+	return false, nil
+}
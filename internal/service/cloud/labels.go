@@ -0,0 +1,226 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// clusterNameLabelKey is the key of the label, which contains the name of the cluster owning a resource.
+	clusterNameLabelKey = "cluster-name"
+
+	// clusterNamespaceLabelKey is the key of the label, which contains the namespace of the cluster owning
+	// the resources of a machine. Together with the name of the cluster, it identifies the cluster.
+	clusterNamespaceLabelKey = "cluster-namespace"
+
+	// machineNameLabelKey is the key of the label, which contains the name of the machine owning a resource.
+	machineNameLabelKey = "machine-name"
+
+	// orphanedLabelKey is the key of the label, which marks volumes that were retained after the deletion
+	// of their machine according to its VolumeDeletionPolicy.
+	orphanedLabelKey = "orphaned"
+)
+
+// labelOperations provides access to the labels of a single IONOS Cloud resource.
+type labelOperations struct {
+	list   func(ctx context.Context) (*sdk.LabelResources, error)
+	create func(ctx context.Context, key, value string) error
+	update func(ctx context.Context, key, value string) error
+	delete func(ctx context.Context, key string) error
+}
+
+// ReconcileServerLabels ensures that the server of the machine and its boot and additional volumes
+// carry the labels of the cluster and the machine.
+func (s *Service) ReconcileServerLabels(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileServerLabels")
+
+	server, err := s.getServer(ctx, ms)
+	if ignoreNotFound(err) != nil {
+		return false, err
+	}
+	if server == nil {
+		return false, nil
+	}
+
+	desired := machineLabels(ms)
+	serverID := ptr.Deref(server.GetId(), "")
+	if reportsDrift(ms) {
+		return false, s.reportServerLabelDrift(ctx, ms, server, desired)
+	}
+	// The labels of an imported server and its volumes were set before the server was adopted, so they are kept.
+	prune := !keepsUnknownLabels(ms)
+	if err := s.reconcileLabels(ctx, s.serverLabelOperations(ms.DatacenterID(), serverID), desired, prune); err != nil {
+		return false, fmt.Errorf("could not reconcile labels of server %s: %w", serverID, err)
+	}
+
+	for _, volumeID := range s.machineVolumeIDs(ms, server) {
+		ops := s.volumeLabelOperations(ms.DatacenterID(), volumeID)
+		if err := s.reconcileLabels(ctx, ops, desired, prune); err != nil {
+			return false, fmt.Errorf("could not reconcile labels of volume %s: %w", volumeID, err)
+		}
+	}
+
+	log.V(4).Info("Labels of the server are up to date", "serverID", serverID)
+	return false, nil
+}
+
+// reconcileLabels ensures that the labels of a resource match the desired labels.
+// The labels of the resource are owned by the provider, which is why all other labels are removed, if prune
+// is true. Label operations are not asynchronous, so there is no request to wait for.
+func (*Service) reconcileLabels(
+	ctx context.Context, ops labelOperations, desired map[string]string, prune bool,
+) error {
+	current, err := currentLabels(ctx, ops)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range desired {
+		currentValue, exists := current[key]
+		switch {
+		case !exists:
+			err = ops.create(ctx, key, value)
+		case currentValue != value:
+			err = ops.update(ctx, key, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for key := range current {
+		if _, exists := desired[key]; !exists && prune {
+			if err := ops.delete(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ownedByOtherMachine returns whether the labels of a server, which were set by ReconcileServerLabels,
+// belong to another machine than the given one. Servers without a machine label are not owned by any machine.
+func ownedByOtherMachine(ms *scope.Machine, labels map[string]string) bool {
+	machine, ok := labels[machineNameLabelKey]
+	if !ok {
+		return false
+	}
+	namespace, hasNamespace := labels[clusterNamespaceLabelKey]
+	return machine != ms.IonosMachine.Name || labels[clusterNameLabelKey] != ms.ClusterScope.Cluster.Name ||
+		(hasNamespace && namespace != ms.ClusterScope.Cluster.Namespace)
+}
+
+// currentLabels returns the labels of a resource by their key.
+func currentLabels(ctx context.Context, ops labelOperations) (map[string]string, error) {
+	labels, err := ops.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]string)
+	for _, label := range ptr.Deref(labels.GetItems(), nil) {
+		current[ptr.Deref(label.GetProperties().GetKey(), "")] = ptr.Deref(label.GetProperties().GetValue(), "")
+	}
+	return current, nil
+}
+
+func (s *Service) datacenterLabelOperations(datacenterID string) labelOperations {
+	return labelOperations{
+		list: func(ctx context.Context) (*sdk.LabelResources, error) {
+			return s.ionosClient.ListDatacenterLabels(ctx, datacenterID)
+		},
+		create: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.CreateDatacenterLabel(ctx, datacenterID, key, value)
+		},
+		update: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.UpdateDatacenterLabel(ctx, datacenterID, key, value)
+		},
+		delete: func(ctx context.Context, key string) error {
+			return s.ionosClient.DeleteDatacenterLabel(ctx, datacenterID, key)
+		},
+	}
+}
+
+func (s *Service) serverLabelOperations(datacenterID, serverID string) labelOperations {
+	return labelOperations{
+		list: func(ctx context.Context) (*sdk.LabelResources, error) {
+			return s.ionosClient.ListServerLabels(ctx, datacenterID, serverID)
+		},
+		create: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.CreateServerLabel(ctx, datacenterID, serverID, key, value)
+		},
+		update: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.UpdateServerLabel(ctx, datacenterID, serverID, key, value)
+		},
+		delete: func(ctx context.Context, key string) error {
+			return s.ionosClient.DeleteServerLabel(ctx, datacenterID, serverID, key)
+		},
+	}
+}
+
+func (s *Service) volumeLabelOperations(datacenterID, volumeID string) labelOperations {
+	return labelOperations{
+		list: func(ctx context.Context) (*sdk.LabelResources, error) {
+			return s.ionosClient.ListVolumeLabels(ctx, datacenterID, volumeID)
+		},
+		create: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.CreateVolumeLabel(ctx, datacenterID, volumeID, key, value)
+		},
+		update: func(ctx context.Context, key, value string) error {
+			return s.ionosClient.UpdateVolumeLabel(ctx, datacenterID, volumeID, key, value)
+		},
+		delete: func(ctx context.Context, key string) error {
+			return s.ionosClient.DeleteVolumeLabel(ctx, datacenterID, volumeID, key)
+		},
+	}
+}
+
+// clusterLabels returns the labels of resources, which are owned by the cluster.
+func clusterLabels(cs *scope.Cluster) map[string]string {
+	labels := maps.Clone(cs.IonosCluster.Spec.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[clusterNameLabelKey] = cs.Cluster.Name
+	return labels
+}
+
+// machineLabels returns the labels of resources, which are owned by the machine.
+// Labels of the machine take precedence over the labels of the cluster.
+func machineLabels(ms *scope.Machine) map[string]string {
+	labels := clusterLabels(ms.ClusterScope)
+	maps.Copy(labels, ms.IonosMachine.Spec.Labels)
+	labels[clusterNameLabelKey] = ms.ClusterScope.Cluster.Name
+	labels[clusterNamespaceLabelKey] = ms.ClusterScope.Cluster.Namespace
+	labels[machineNameLabelKey] = ms.IonosMachine.Name
+	return labels
+}
+
+// keepsUnknownLabels returns true, if labels of the server of the machine, which are not set by the provider,
+// must be kept. This is the case for servers, which were imported with all their labels.
+func keepsUnknownLabels(ms *scope.Machine) bool {
+	return ms.IonosMachine.Spec.ImportExisting != ""
+}
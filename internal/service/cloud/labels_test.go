@@ -0,0 +1,192 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+type labelsSuite struct {
+	ServiceTestSuite
+}
+
+func TestLabelsSuite(t *testing.T) {
+	suite.Run(t, new(labelsSuite))
+}
+
+func (s *labelsSuite) TestReconcileServerLabels() {
+	s.infraCluster.Spec.Labels = map[string]string{"team": "platform", "cost-center": "1234"}
+	s.infraMachine.Spec.Labels = map[string]string{"cost-center": "5678"}
+	s.infraMachine.Spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data"}}
+
+	server := s.defaultServer(s.infraMachine, exampleDHCPIP)
+	server.Properties = &sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: ptr.To(exampleBootVolumeID)}}
+	server.Entities.Volumes = &sdk.AttachedVolumes{Items: &[]sdk.Volume{{
+		Id:         ptr.To(exampleBootVolumeID),
+		Properties: &sdk.VolumeProperties{Name: ptr.To(s.service.volumeName(s.infraMachine))},
+	}, {
+		Id:         ptr.To(exampleAdditionalVolumeID),
+		Properties: &sdk.VolumeProperties{Name: ptr.To(s.service.additionalVolumeName(s.infraMachine, "data"))},
+	}, {
+		Id:         ptr.To("foreign-volume"),
+		Properties: &sdk.VolumeProperties{Name: ptr.To("pvc-volume")},
+	}}}
+	s.mockGetServerCall(exampleServerID).Return(server, nil).Once()
+
+	datacenterID := s.machineScope.DatacenterID()
+	s.ionosClient.EXPECT().ListServerLabels(s.ctx, datacenterID, exampleServerID).Return(labelResources(map[string]string{
+		clusterNameLabelKey: s.capiCluster.Name,
+		"team":              "infra",
+		"stale":             "true",
+	}), nil).Once()
+	s.ionosClient.EXPECT().UpdateServerLabel(s.ctx, datacenterID, exampleServerID, "team", "platform").Return(nil).Once()
+	s.ionosClient.EXPECT().CreateServerLabel(s.ctx, datacenterID, exampleServerID, "cost-center", "5678").Return(nil).Once()
+	s.ionosClient.EXPECT().CreateServerLabel(s.ctx, datacenterID, exampleServerID,
+		clusterNamespaceLabelKey, s.capiCluster.Namespace).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateServerLabel(s.ctx, datacenterID, exampleServerID,
+		machineNameLabelKey, s.infraMachine.Name).Return(nil).Once()
+	s.ionosClient.EXPECT().DeleteServerLabel(s.ctx, datacenterID, exampleServerID, "stale").Return(nil).Once()
+
+	desired := labelResources(map[string]string{
+		clusterNameLabelKey:      s.capiCluster.Name,
+		clusterNamespaceLabelKey: s.capiCluster.Namespace,
+		machineNameLabelKey:      s.infraMachine.Name,
+		"team":                   "platform",
+		"cost-center":            "5678",
+	})
+	s.ionosClient.EXPECT().ListVolumeLabels(s.ctx, datacenterID, exampleBootVolumeID).Return(desired, nil).Once()
+	s.ionosClient.EXPECT().ListVolumeLabels(s.ctx, datacenterID, exampleAdditionalVolumeID).Return(desired, nil).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *labelsSuite) TestReconcileServerLabelsReportDrift() {
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.infraMachine.Status.Ready = true
+	s.infraCluster.Spec.Labels = map[string]string{"team": "platform"}
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+	s.ionosClient.EXPECT().ListServerLabels(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return(labelResources(map[string]string{
+			clusterNameLabelKey:      s.capiCluster.Name,
+			clusterNamespaceLabelKey: s.capiCluster.Namespace,
+			"team":                   "infra",
+			"stale":                  "true",
+		}), nil).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	server := "server " + exampleServerID
+	s.Equal([]string{
+		`label "machine-name" of ` + server + ` is missing`,
+		`label "team" of ` + server + ` is "infra" instead of "platform"`,
+		`label "stale" of ` + server + ` is unexpected`,
+	}, s.machineScope.Drift)
+}
+
+func (s *labelsSuite) TestReconcileServerLabelsImportedServer() {
+	s.infraMachine.Spec.ImportExisting = exampleServerID
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+
+	datacenterID := s.machineScope.DatacenterID()
+	s.ionosClient.EXPECT().ListServerLabels(s.ctx, datacenterID, exampleServerID).Return(labelResources(map[string]string{
+		clusterNameLabelKey:      s.capiCluster.Name,
+		clusterNamespaceLabelKey: s.capiCluster.Namespace,
+		"owner":                  "ops",
+	}), nil).Once()
+	// The existing label "owner" must not be deleted.
+	s.ionosClient.EXPECT().CreateServerLabel(s.ctx, datacenterID, exampleServerID,
+		machineNameLabelKey, s.infraMachine.Name).Return(nil).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *labelsSuite) TestReconcileServerLabelsImportedServerReportDrift() {
+	s.infraMachine.Spec.ImportExisting = exampleServerID
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.infraMachine.Status.Ready = true
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+	s.ionosClient.EXPECT().ListServerLabels(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return(labelResources(map[string]string{
+			clusterNameLabelKey:      s.capiCluster.Name,
+			clusterNamespaceLabelKey: s.capiCluster.Namespace,
+			machineNameLabelKey:      s.infraMachine.Name,
+			"owner":                  "ops",
+		}), nil).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.machineScope.Drift, "the existing labels of an imported server are expected")
+}
+
+func (s *labelsSuite) TestReconcileServerLabelsNoServer() {
+	s.infraMachine.Spec.ProviderID = nil
+	s.ionosClient.EXPECT().ListServers(s.ctx, s.machineScope.DatacenterID()).Return(&sdk.Servers{}, nil).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *labelsSuite) TestReconcileServerLabelsError() {
+	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
+	s.ionosClient.EXPECT().ListServerLabels(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return(nil, errors.New("error")).Once()
+
+	requeue, err := s.service.ReconcileServerLabels(s.ctx, s.machineScope)
+	s.ErrorContains(err, "could not reconcile labels of server")
+	s.False(requeue)
+}
+
+func (s *labelsSuite) TestMachineLabels() {
+	s.infraCluster.Spec.Labels = map[string]string{"team": "platform", "env": "dev"}
+	s.infraMachine.Spec.Labels = map[string]string{"env": "prod"}
+
+	s.Equal(map[string]string{
+		clusterNameLabelKey:      s.capiCluster.Name,
+		clusterNamespaceLabelKey: s.capiCluster.Namespace,
+		machineNameLabelKey:      s.infraMachine.Name,
+		"team":                   "platform",
+		"env":                    "prod",
+	}, machineLabels(s.machineScope))
+	s.Equal(map[string]string{"team": "platform", "env": "dev"}, s.infraCluster.Spec.Labels)
+}
+
+func labelResources(labels map[string]string) *sdk.LabelResources {
+	items := make([]sdk.LabelResource, 0, len(labels))
+	for key, value := range labels {
+		items = append(items, sdk.LabelResource{
+			Properties: &sdk.LabelResourceProperties{
+				Key:   ptr.To(key),
+				Value: ptr.To(value),
+			},
+		})
+	}
+	return &sdk.LabelResources{Items: &items}
+}
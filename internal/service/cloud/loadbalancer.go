@@ -0,0 +1,454 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// listLoadBalancersDepth is the depth needed for getting the forwarding rules of each load balancer.
+	listLoadBalancersDepth = 3
+
+	loadBalancerForwardingRuleName      = "kube-apiserver"
+	loadBalancerForwardingRuleAlgorithm = "ROUND_ROBIN"
+	loadBalancerForwardingRuleProtocol  = "TCP"
+
+	// loadBalancerTargetPort is the port the kube-apiserver is listening on at the control plane machines.
+	loadBalancerTargetPort   int32 = 6443
+	loadBalancerTargetWeight int32 = 1
+)
+
+// loadBalancerLAN describes one of the LANs, which are connected to the load balancer.
+type loadBalancerLAN struct {
+	name   string
+	public bool
+}
+
+// properties returns the properties, with which the LAN is created.
+func (l loadBalancerLAN) properties() sdk.LanPropertiesPost {
+	return sdk.LanPropertiesPost{
+		Name:   ptr.To(l.name),
+		Public: ptr.To(l.public),
+	}
+}
+
+// loadBalancerName returns the name of the control plane load balancer.
+func (*Service) loadBalancerName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("nlb-%s-%s", c.Namespace, c.Name)
+}
+
+// loadBalancerListenerLANName returns the name of the public LAN, on which the load balancer accepts traffic.
+func (*Service) loadBalancerListenerLANName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("lan-nlb-listener-%s-%s", c.Namespace, c.Name)
+}
+
+// loadBalancerTargetLANName returns the name of the private LAN, which connects the load balancer
+// with the control plane machines.
+func (*Service) loadBalancerTargetLANName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("lan-nlb-target-%s-%s", c.Namespace, c.Name)
+}
+
+func (s *Service) loadBalancerLANs(c *clusterv1.Cluster) []loadBalancerLAN {
+	return []loadBalancerLAN{
+		{name: s.loadBalancerListenerLANName(c), public: true},
+		{name: s.loadBalancerTargetLANName(c), public: false},
+	}
+}
+
+func (*Service) loadBalancersURL(datacenterID string) string {
+	return path.Join("datacenters", datacenterID, "networkloadbalancers")
+}
+
+func (*Service) loadBalancerURL(datacenterID, id string) string {
+	return path.Join("datacenters", datacenterID, "networkloadbalancers", id)
+}
+
+// isLoadBalancerTarget returns true if the machine should be registered as a target of the control plane load balancer.
+func isLoadBalancerTarget(ms *scope.Machine) bool {
+	lb := ms.ClusterScope.IonosCluster.Spec.LoadBalancer
+	return lb != nil && util.IsControlPlaneMachine(ms.Machine) && lb.DatacenterID == ms.DatacenterID()
+}
+
+// ReconcileLoadBalancerNetworks ensures the listener and target LANs of the control plane load balancer exist.
+func (s *Service) ReconcileLoadBalancerNetworks(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	if cs.IonosCluster.Spec.LoadBalancer == nil {
+		return false, nil
+	}
+
+	for _, lan := range s.loadBalancerLANs(cs.Cluster) {
+		if requeue, err := s.reconcileLoadBalancerLAN(ctx, cs, lan); err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Service) reconcileLoadBalancerLAN(
+	ctx context.Context, cs *scope.Cluster, lbLAN loadBalancerLAN,
+) (requeue bool, err error) {
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+	return s.reconcileClusterOwnedLAN(ctx, cs, datacenterID, lbLAN.properties())
+}
+
+// ReconcileLoadBalancerNetworksDeletion ensures the listener and target LANs of the control plane load balancer
+// are deleted.
+func (s *Service) ReconcileLoadBalancerNetworksDeletion(
+	ctx context.Context, cs *scope.Cluster,
+) (requeue bool, err error) {
+	if cs.IonosCluster.Spec.LoadBalancer == nil {
+		return false, nil
+	}
+
+	for _, lan := range s.loadBalancerLANs(cs.Cluster) {
+		if requeue, err := s.reconcileLoadBalancerLANDeletion(ctx, cs, lan); err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+func (s *Service) reconcileLoadBalancerLANDeletion(
+	ctx context.Context, cs *scope.Cluster, lbLAN loadBalancerLAN,
+) (requeue bool, err error) {
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+	return s.reconcileClusterOwnedLANDeletion(ctx, cs, datacenterID, lbLAN.name)
+}
+
+// ReconcileLoadBalancer ensures the control plane load balancer exists, creating one if it doesn't.
+// The load balancer listens on the control plane endpoint and forwards the traffic to the registered
+// control plane machines.
+func (s *Service) ReconcileLoadBalancer(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileLoadBalancer")
+
+	if cs.IonosCluster.Spec.LoadBalancer == nil {
+		return false, nil
+	}
+
+	nlb, request, err := scopedFindResource(ctx, cs, s.getLoadBalancer, s.getLatestLoadBalancerCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if nlb != nil {
+		cs.SetLoadBalancerID(ptr.Deref(nlb.GetId(), ""))
+		if state := getState(nlb); !isAvailable(state) {
+			log.Info("Load balancer is not available yet", "state", state)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No load balancer was found. Creating new load balancer")
+	if err := s.createLoadBalancer(ctx, cs); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReconcileLoadBalancerTargets ensures that all control plane machines, which are connected to the target LAN,
+// are registered as targets of the control plane load balancer.
+func (s *Service) ReconcileLoadBalancerTargets(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileLoadBalancerTargets")
+
+	if cs.IonosCluster.Spec.LoadBalancer == nil {
+		return false, nil
+	}
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+
+	nlb, err := s.getLoadBalancer(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+	if nlb == nil {
+		return false, errors.New("unable to find the control plane load balancer")
+	}
+
+	rule := s.findForwardingRule(nlb)
+	if rule == nil {
+		return false, fmt.Errorf("unable to find forwarding rule %s on the control plane load balancer",
+			loadBalancerForwardingRuleName)
+	}
+
+	targetLANID, err := s.getLANIDByName(ctx, datacenterID, s.loadBalancerTargetLANName(cs.Cluster))
+	if err != nil {
+		return false, err
+	}
+
+	targets, err := s.buildLoadBalancerTargets(ctx, cs, targetLANID)
+	if err != nil {
+		return false, err
+	}
+
+	currentTargets := ptr.Deref(rule.GetProperties().GetTargets(), nil)
+	if equalLoadBalancerTargets(currentTargets, targets) {
+		log.V(4).Info("Load balancer targets are up to date")
+		return false, nil
+	}
+
+	properties := *rule.GetProperties()
+	properties.Targets = &targets
+
+	requestPath, err := s.ionosClient.PatchNetworkLoadBalancerForwardingRule(
+		ctx, datacenterID, ptr.Deref(nlb.GetId(), ""), ptr.Deref(rule.GetId(), ""), properties,
+	)
+	if err != nil {
+		return false, fmt.Errorf("unable to update load balancer targets: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPatch, sdk.RequestStatusQueued, requestPath)
+	log.Info("Successfully requested for load balancer target update", "requestPath", requestPath,
+		"targets", len(targets))
+	return true, nil
+}
+
+// ReconcileLoadBalancerDeletion ensures the control plane load balancer is deleted.
+func (s *Service) ReconcileLoadBalancerDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileLoadBalancerDeletion")
+
+	if cs.IonosCluster.Spec.LoadBalancer == nil {
+		return false, nil
+	}
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+
+	nlb, request, err := scopedFindResource(ctx, cs, s.getLoadBalancer, s.getLatestLoadBalancerCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if nlb == nil {
+		cs.SetLoadBalancerID("")
+		return false, nil
+	}
+
+	nlbID := ptr.Deref(nlb.GetId(), "")
+	request, err = getMatchingRequest[sdk.NetworkLoadBalancer](
+		ctx, s, http.MethodDelete, s.loadBalancerURL(datacenterID, nlbID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	requestPath, err := s.ionosClient.DeleteNetworkLoadBalancer(ctx, datacenterID, nlbID)
+	if err != nil {
+		return false, fmt.Errorf("unable to request load balancer deletion: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, loadBalancerDeletionRequestedReason,
+		"Requested deletion of Network Load Balancer %s in data center %s", nlbID, datacenterID)
+	log.Info("Successfully requested for load balancer deletion", "requestPath", requestPath)
+	return true, nil
+}
+
+// getLoadBalancer tries to retrieve the control plane load balancer in the data center.
+func (s *Service) getLoadBalancer(ctx context.Context, cs *scope.Cluster) (*sdk.NetworkLoadBalancer, error) {
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+	nlbs, err := s.apiWithDepth(listLoadBalancersDepth).ListNetworkLoadBalancers(ctx, datacenterID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list load balancers in data center %s: %w", datacenterID, err)
+	}
+
+	var (
+		expectedName = s.loadBalancerName(cs.Cluster)
+		count        = 0
+		foundNLB     *sdk.NetworkLoadBalancer
+	)
+
+	for _, nlb := range ptr.Deref(nlbs.GetItems(), nil) {
+		if ptr.Deref(nlb.GetProperties().GetName(), "") == expectedName {
+			foundNLB = &nlb
+			count++
+		}
+
+		if count > 1 {
+			return nil, fmt.Errorf("found multiple load balancers with the name: %s", expectedName)
+		}
+	}
+
+	return foundNLB, nil
+}
+
+func (s *Service) getLatestLoadBalancerCreationRequest(
+	ctx context.Context, cs *scope.Cluster,
+) (*requestInfo, error) {
+	return getMatchingRequest(
+		ctx, s, http.MethodPost,
+		s.loadBalancersURL(cs.IonosCluster.Spec.LoadBalancer.DatacenterID),
+		matchByName[*sdk.NetworkLoadBalancer, *sdk.NetworkLoadBalancerProperties](s.loadBalancerName(cs.Cluster)),
+	)
+}
+
+func (s *Service) createLoadBalancer(ctx context.Context, cs *scope.Cluster) error {
+	log := s.logger.WithName("createLoadBalancer")
+	datacenterID := cs.IonosCluster.Spec.LoadBalancer.DatacenterID
+
+	endpointIP, err := cs.GetControlPlaneEndpointIP(ctx)
+	if err != nil {
+		return err
+	}
+	if endpointIP == "" {
+		return errors.New("control plane endpoint IP is required to create the load balancer")
+	}
+
+	listenerLANID, err := s.getLANIDByName(ctx, datacenterID, s.loadBalancerListenerLANName(cs.Cluster))
+	if err != nil {
+		return err
+	}
+
+	targetLANID, err := s.getLANIDByName(ctx, datacenterID, s.loadBalancerTargetLANName(cs.Cluster))
+	if err != nil {
+		return err
+	}
+
+	properties := sdk.NetworkLoadBalancerProperties{
+		Name:        ptr.To(s.loadBalancerName(cs.Cluster)),
+		Ips:         &[]string{endpointIP},
+		ListenerLan: &listenerLANID,
+		TargetLan:   &targetLANID,
+	}
+
+	entities := sdk.NetworkLoadBalancerEntities{
+		Forwardingrules: &sdk.NetworkLoadBalancerForwardingRules{
+			Items: &[]sdk.NetworkLoadBalancerForwardingRule{{
+				Properties: &sdk.NetworkLoadBalancerForwardingRuleProperties{
+					Name:         ptr.To(loadBalancerForwardingRuleName),
+					Algorithm:    ptr.To(loadBalancerForwardingRuleAlgorithm),
+					Protocol:     ptr.To(loadBalancerForwardingRuleProtocol),
+					ListenerIp:   &endpointIP,
+					ListenerPort: ptr.To(cs.GetControlPlaneEndpoint().Port),
+					Targets:      &[]sdk.NetworkLoadBalancerForwardingRuleTarget{},
+				},
+			}},
+		},
+	}
+
+	requestPath, err := s.ionosClient.CreateNetworkLoadBalancer(ctx, datacenterID, properties, entities)
+	if err != nil {
+		return fmt.Errorf("unable to create load balancer in data center %s: %w", datacenterID, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, loadBalancerCreationRequestedReason,
+		"Requested creation of Network Load Balancer in data center %s", datacenterID)
+	log.Info("Successfully requested for load balancer creation", "requestPath", requestPath)
+	return nil
+}
+
+func (*Service) findForwardingRule(nlb *sdk.NetworkLoadBalancer) *sdk.NetworkLoadBalancerForwardingRule {
+	rules := ptr.Deref(nlb.GetEntities().GetForwardingrules().GetItems(), nil)
+	for i := range rules {
+		if ptr.Deref(rules[i].GetProperties().GetName(), "") == loadBalancerForwardingRuleName {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// buildLoadBalancerTargets returns the targets for all control plane machines, which have an IP address
+// in the target LAN. Machines, which are about to be deleted, are not considered.
+func (*Service) buildLoadBalancerTargets(
+	ctx context.Context, cs *scope.Cluster, targetLANID int32,
+) ([]sdk.NetworkLoadBalancerForwardingRuleTarget, error) {
+	machines, err := cs.ListMachines(ctx, client.MatchingLabels{clusterv1.MachineControlPlaneLabel: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	for _, machine := range machines {
+		if !machine.DeletionTimestamp.IsZero() || machine.Status.MachineNetworkInfo == nil {
+			continue
+		}
+		for _, nic := range machine.Status.MachineNetworkInfo.NICInfo {
+			if nic.NetworkID == targetLANID && len(nic.IPv4Addresses) > 0 {
+				ips = append(ips, nic.IPv4Addresses[0])
+			}
+		}
+	}
+	slices.Sort(ips)
+
+	targets := make([]sdk.NetworkLoadBalancerForwardingRuleTarget, 0, len(ips))
+	for _, ip := range ips {
+		targets = append(targets, sdk.NetworkLoadBalancerForwardingRuleTarget{
+			Ip:     ptr.To(ip),
+			Port:   ptr.To(loadBalancerTargetPort),
+			Weight: ptr.To(loadBalancerTargetWeight),
+			HealthCheck: &sdk.NetworkLoadBalancerForwardingRuleTargetHealthCheck{
+				Check: ptr.To(true),
+			},
+		})
+	}
+
+	return targets, nil
+}
+
+// loadBalancerTarget is implemented by the targets of Network Load Balancers and target groups.
+type loadBalancerTarget[T any] interface {
+	*T
+	GetIp() *string //nolint:revive,stylecheck // method name is defined by the SDK
+	GetPort() *int32
+}
+
+// equalLoadBalancerTargets checks if both lists contain the same IP and port combinations.
+func equalLoadBalancerTargets[T any, P loadBalancerTarget[T]](a, b []T) bool {
+	toKeys := func(targets []T) []string {
+		keys := make([]string, 0, len(targets))
+		for i := range targets {
+			target := P(&targets[i])
+			keys = append(keys, fmt.Sprintf("%s:%d", ptr.Deref(target.GetIp(), ""), ptr.Deref(target.GetPort(), 0)))
+		}
+		slices.Sort(keys)
+		return keys
+	}
+
+	return slices.Equal(toKeys(a), toKeys(b))
+}
@@ -0,0 +1,458 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	exampleLoadBalancerID                = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e30"
+	exampleForwardingRuleID              = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e31"
+	exampleListenerLANID                 = "43"
+	exampleTargetLANID                   = "44"
+	exampleLoadBalancerTargetIP          = "10.0.0.2"
+	exampleSecondaryLoadBalancerTargetIP = "10.0.0.3"
+)
+
+type loadBalancerTestSuite struct {
+	ServiceTestSuite
+}
+
+func TestLoadBalancerTestSuite(t *testing.T) {
+	suite.Run(t, new(loadBalancerTestSuite))
+}
+
+func (s *loadBalancerTestSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraCluster.Spec.LoadBalancer = &infrav1.LoadBalancerSpec{
+		DatacenterID: s.machineScope.DatacenterID(),
+	}
+	s.infraCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
+		Host: exampleEndpointIP,
+		Port: defaultControlPlaneEndpointPort,
+	}
+}
+
+func (s *loadBalancerTestSuite) TestLoadBalancerNames() {
+	s.Equal("nlb-default-test-cluster", s.service.loadBalancerName(s.capiCluster))
+	s.Equal("lan-nlb-listener-default-test-cluster", s.service.loadBalancerListenerLANName(s.capiCluster))
+	s.Equal("lan-nlb-target-default-test-cluster", s.service.loadBalancerTargetLANName(s.capiCluster))
+}
+
+func (s *loadBalancerTestSuite) TestIsLoadBalancerTarget() {
+	s.False(isLoadBalancerTarget(s.machineScope), "worker machines must not be registered")
+
+	s.capiMachine.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+	s.True(isLoadBalancerTarget(s.machineScope))
+
+	s.infraCluster.Spec.LoadBalancer.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+	s.False(isLoadBalancerTarget(s.machineScope), "machines in other data centers must not be registered")
+}
+
+func (s *loadBalancerTestSuite) TestReconcileIPFailoverNotRequiredForControlPlane() {
+	s.capiMachine.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+
+	requeue, err := s.service.ReconcileIPFailover(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestBuildServerEntitiesLoadBalancerTarget() {
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec:       s.infraMachine.Spec,
+		lanID:             42,
+		loadBalancerLANID: 44,
+	})
+
+	nics := *entities.Nics.Items
+	s.Len(nics, 2)
+	s.Equal(int32(44), *nics[1].Properties.Lan)
+	s.True(*nics[1].Properties.Dhcp)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNotConfigured() {
+	s.infraCluster.Spec.LoadBalancer = nil
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileLoadBalancerTargets(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	requeue, err = s.service.ReconcileLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNetworksCreateListenerLAN() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{
+		Name:   ptr.To(s.service.loadBalancerListenerLANName(s.capiCluster)),
+		Public: ptr.To(true),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(exampleRequestPath, s.infraCluster.Status.CurrentClusterRequest.RequestPath)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNetworksCreateTargetLAN() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.listenerLAN()}}, nil).Twice()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{
+		Name:   ptr.To(s.service.loadBalancerTargetLANName(s.capiCluster)),
+		Public: ptr.To(false),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNetworksAvailable() {
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Twice()
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerCreate() {
+	s.mockListLoadBalancersCall().Return(&sdk.NetworkLoadBalancers{Items: &[]sdk.NetworkLoadBalancer{}}, nil).Once()
+	s.mockGetLoadBalancerCreationRequestsCall().Return(nil, nil).Once()
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Twice()
+
+	s.ionosClient.EXPECT().CreateNetworkLoadBalancer(
+		s.ctx, s.machineScope.DatacenterID(), sdk.NetworkLoadBalancerProperties{
+			Name:        ptr.To(s.service.loadBalancerName(s.capiCluster)),
+			Ips:         &[]string{exampleEndpointIP},
+			ListenerLan: ptr.To(int32(43)),
+			TargetLan:   ptr.To(int32(44)),
+		},
+		sdk.NetworkLoadBalancerEntities{
+			Forwardingrules: &sdk.NetworkLoadBalancerForwardingRules{
+				Items: &[]sdk.NetworkLoadBalancerForwardingRule{{
+					Properties: &sdk.NetworkLoadBalancerForwardingRuleProperties{
+						Name:         ptr.To(loadBalancerForwardingRuleName),
+						Algorithm:    ptr.To(loadBalancerForwardingRuleAlgorithm),
+						Protocol:     ptr.To(loadBalancerForwardingRuleProtocol),
+						ListenerIp:   ptr.To(exampleEndpointIP),
+						ListenerPort: ptr.To(defaultControlPlaneEndpointPort),
+						Targets:      &[]sdk.NetworkLoadBalancerForwardingRuleTarget{},
+					},
+				}},
+			},
+		},
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerCreationPending() {
+	s.mockListLoadBalancersCall().Return(&sdk.NetworkLoadBalancers{Items: &[]sdk.NetworkLoadBalancer{}}, nil).Once()
+	s.mockGetLoadBalancerCreationRequestsCall().Return([]sdk.Request{
+		s.exampleLoadBalancerPostRequest(sdk.RequestStatusRunning),
+	}, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(sdk.RequestStatusRunning, s.infraCluster.Status.CurrentClusterRequest.State)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerAvailable() {
+	s.mockListLoadBalancersCall().Return(s.exampleLoadBalancers(), nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancer(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleLoadBalancerID, s.infraCluster.Status.LoadBalancerID)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerTargetsRegisterMachines() {
+	s.createControlPlaneMachine("cp-1", exampleSecondaryLoadBalancerTargetIP)
+	s.createControlPlaneMachine("cp-0", exampleLoadBalancerTargetIP)
+
+	s.mockListLoadBalancersCall().Return(s.exampleLoadBalancers(), nil).Once()
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Once()
+
+	rule := s.exampleForwardingRule()
+	wantProps := *rule.Properties
+	wantProps.Targets = &[]sdk.NetworkLoadBalancerForwardingRuleTarget{
+		exampleLoadBalancerTarget(exampleLoadBalancerTargetIP),
+		exampleLoadBalancerTarget(exampleSecondaryLoadBalancerTargetIP),
+	}
+
+	s.ionosClient.EXPECT().PatchNetworkLoadBalancerForwardingRule(
+		s.ctx, s.machineScope.DatacenterID(), exampleLoadBalancerID, exampleForwardingRuleID, wantProps,
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerTargets(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerTargetsUpToDate() {
+	s.createControlPlaneMachine("cp-0", exampleLoadBalancerTargetIP)
+
+	nlbs := s.exampleLoadBalancers()
+	rule := (*(*nlbs.Items)[0].Entities.Forwardingrules.Items)[0]
+	rule.Properties.Targets = &[]sdk.NetworkLoadBalancerForwardingRuleTarget{
+		exampleLoadBalancerTarget(exampleLoadBalancerTargetIP),
+	}
+
+	s.mockListLoadBalancersCall().Return(nlbs, nil).Once()
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerTargets(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerTargetsIgnoreDeletedMachines() {
+	machine := s.createControlPlaneMachine("cp-0", exampleLoadBalancerTargetIP)
+	machine.SetFinalizers([]string{infrav1.MachineFinalizer})
+	s.NoError(s.k8sClient.Update(s.ctx, machine))
+	s.NoError(s.k8sClient.Delete(s.ctx, machine))
+
+	nlbs := s.exampleLoadBalancers()
+	rule := (*(*nlbs.Items)[0].Entities.Forwardingrules.Items)[0]
+	rule.Properties.Targets = &[]sdk.NetworkLoadBalancerForwardingRuleTarget{
+		exampleLoadBalancerTarget(exampleLoadBalancerTargetIP),
+	}
+
+	s.mockListLoadBalancersCall().Return(nlbs, nil).Once()
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Once()
+
+	wantProps := *rule.Properties
+	wantProps.Targets = &[]sdk.NetworkLoadBalancerForwardingRuleTarget{}
+	s.ionosClient.EXPECT().PatchNetworkLoadBalancerForwardingRule(
+		s.ctx, s.machineScope.DatacenterID(), exampleLoadBalancerID, exampleForwardingRuleID, wantProps,
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerTargets(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerDeletion() {
+	s.mockListLoadBalancersCall().Return(s.exampleLoadBalancers(), nil).Once()
+	s.mockGetLoadBalancerDeletionRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteNetworkLoadBalancer(s.ctx, s.machineScope.DatacenterID(), exampleLoadBalancerID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerDeletionPending() {
+	s.mockListLoadBalancersCall().Return(s.exampleLoadBalancers(), nil).Once()
+	s.mockGetLoadBalancerDeletionRequestsCall().Return([]sdk.Request{s.exampleRequest(requestBuildOptions{
+		status:     sdk.RequestStatusQueued,
+		method:     http.MethodDelete,
+		url:        s.service.loadBalancerURL(s.machineScope.DatacenterID(), exampleLoadBalancerID),
+		href:       exampleRequestPath,
+		targetID:   exampleLoadBalancerID,
+		targetType: sdk.NETWORKLOADBALANCER,
+	})}, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerDeletionNotFound() {
+	s.infraCluster.Status.LoadBalancerID = exampleLoadBalancerID
+	s.mockListLoadBalancersCall().Return(&sdk.NetworkLoadBalancers{Items: &[]sdk.NetworkLoadBalancer{}}, nil).Once()
+	s.mockGetLoadBalancerCreationRequestsCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.LoadBalancerID)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNetworksDeletion() {
+	s.mockListLANsCall().Return(s.loadBalancerLANs(), nil).Once()
+	s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodDelete, s.service.lanURL(s.machineScope.DatacenterID(), exampleListenerLANID)).
+		Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteLAN(s.ctx, s.machineScope.DatacenterID(), exampleListenerLANID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestReconcileLoadBalancerNetworksDeletionDone() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Twice()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Twice()
+
+	requeue, err := s.service.ReconcileLoadBalancerNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *loadBalancerTestSuite) TestEqualLoadBalancerTargets() {
+	a := []sdk.NetworkLoadBalancerForwardingRuleTarget{
+		exampleLoadBalancerTarget(exampleLoadBalancerTargetIP),
+		exampleLoadBalancerTarget(exampleSecondaryLoadBalancerTargetIP),
+	}
+	b := []sdk.NetworkLoadBalancerForwardingRuleTarget{
+		exampleLoadBalancerTarget(exampleSecondaryLoadBalancerTargetIP),
+		exampleLoadBalancerTarget(exampleLoadBalancerTargetIP),
+	}
+	s.True(equalLoadBalancerTargets(a, b))
+	s.True(equalLoadBalancerTargets(nil, []sdk.NetworkLoadBalancerForwardingRuleTarget{}))
+	s.False(equalLoadBalancerTargets(a, b[:1]))
+}
+
+func (s *loadBalancerTestSuite) createControlPlaneMachine(name, targetIP string) *infrav1.IonosCloudMachine {
+	machine := &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         s.capiCluster.Name,
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Spec: *s.infraMachine.Spec.DeepCopy(),
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, machine))
+
+	machine.Status.MachineNetworkInfo = &infrav1.MachineNetworkInfo{
+		NICInfo: []infrav1.NICInfo{
+			{IPv4Addresses: []string{exampleDHCPIP}, NetworkID: 42, Primary: true},
+			{IPv4Addresses: []string{targetIP}, NetworkID: 44},
+		},
+	}
+	s.NoError(s.k8sClient.Status().Update(s.ctx, machine))
+	return machine
+}
+
+func exampleLoadBalancerTarget(ip string) sdk.NetworkLoadBalancerForwardingRuleTarget {
+	return sdk.NetworkLoadBalancerForwardingRuleTarget{
+		Ip:     ptr.To(ip),
+		Port:   ptr.To(loadBalancerTargetPort),
+		Weight: ptr.To(loadBalancerTargetWeight),
+		HealthCheck: &sdk.NetworkLoadBalancerForwardingRuleTargetHealthCheck{
+			Check: ptr.To(true),
+		},
+	}
+}
+
+func (s *loadBalancerTestSuite) listenerLAN() sdk.Lan {
+	lan := s.exampleLAN()
+	lan.Id = ptr.To(exampleListenerLANID)
+	lan.Properties.Name = ptr.To(s.service.loadBalancerListenerLANName(s.capiCluster))
+	return lan
+}
+
+func (s *loadBalancerTestSuite) loadBalancerLANs() *sdk.Lans {
+	targetLAN := s.exampleLAN()
+	targetLAN.Id = ptr.To(exampleTargetLANID)
+	targetLAN.Properties.Name = ptr.To(s.service.loadBalancerTargetLANName(s.capiCluster))
+	return &sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN(), s.listenerLAN(), targetLAN}}
+}
+
+func (s *loadBalancerTestSuite) exampleForwardingRule() sdk.NetworkLoadBalancerForwardingRule {
+	return sdk.NetworkLoadBalancerForwardingRule{
+		Id: ptr.To(exampleForwardingRuleID),
+		Properties: &sdk.NetworkLoadBalancerForwardingRuleProperties{
+			Name:         ptr.To(loadBalancerForwardingRuleName),
+			Algorithm:    ptr.To(loadBalancerForwardingRuleAlgorithm),
+			Protocol:     ptr.To(loadBalancerForwardingRuleProtocol),
+			ListenerIp:   ptr.To(exampleEndpointIP),
+			ListenerPort: ptr.To(defaultControlPlaneEndpointPort),
+		},
+	}
+}
+
+func (s *loadBalancerTestSuite) exampleLoadBalancers() *sdk.NetworkLoadBalancers {
+	return &sdk.NetworkLoadBalancers{
+		Items: &[]sdk.NetworkLoadBalancer{{
+			Id: ptr.To(exampleLoadBalancerID),
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.NetworkLoadBalancerProperties{
+				Name: ptr.To(s.service.loadBalancerName(s.capiCluster)),
+			},
+			Entities: &sdk.NetworkLoadBalancerEntities{
+				Forwardingrules: &sdk.NetworkLoadBalancerForwardingRules{
+					Items: &[]sdk.NetworkLoadBalancerForwardingRule{s.exampleForwardingRule()},
+				},
+			},
+		}},
+	}
+}
+
+func (s *loadBalancerTestSuite) exampleLoadBalancerPostRequest(status string) sdk.Request {
+	return s.exampleRequest(requestBuildOptions{
+		status:     status,
+		method:     http.MethodPost,
+		url:        s.service.loadBalancersURL(s.machineScope.DatacenterID()),
+		body:       `{"properties": {"name": "nlb-default-test-cluster"}}`,
+		href:       exampleRequestPath,
+		targetType: sdk.NETWORKLOADBALANCER,
+	})
+}
+
+func (s *loadBalancerTestSuite) mockListLoadBalancersCall() *clienttest.MockClient_ListNetworkLoadBalancers_Call {
+	return s.ionosClient.EXPECT().ListNetworkLoadBalancers(s.ctx, s.machineScope.DatacenterID())
+}
+
+func (s *loadBalancerTestSuite) mockGetLoadBalancerCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodPost, s.service.loadBalancersURL(s.machineScope.DatacenterID()))
+}
+
+func (s *loadBalancerTestSuite) mockGetLoadBalancerDeletionRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete,
+		s.service.loadBalancerURL(s.machineScope.DatacenterID(), exampleLoadBalancerID))
+}
+
+func (s *loadBalancerTestSuite) mockGetLANCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodPost, s.service.lansURL(s.machineScope.DatacenterID()))
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	natGatewayRuleName = "snat-cluster-lan"
+
+	// defaultNATGatewaySourceSubnet is used if no source subnet was configured.
+	// It covers all subnets, which are assigned to private LANs.
+	defaultNATGatewaySourceSubnet = "10.0.0.0/8"
+)
+
+// natGatewayName returns the name of the NAT gateway of the cluster.
+func (*Service) natGatewayName(c *clusterv1.Cluster) string {
+	return fmt.Sprintf("nat-%s-%s", c.Namespace, c.Name)
+}
+
+// natGatewayIPBlockName returns the name of the IP block, which provides the public IP of the NAT gateway.
+func (*Service) natGatewayIPBlockName(cs *scope.Cluster) string {
+	return fmt.Sprintf("nat-ipb-%s-%s", cs.Cluster.Namespace, cs.Cluster.Name)
+}
+
+func (*Service) natGatewaysURL(datacenterID string) string {
+	return path.Join("datacenters", datacenterID, "natgateways")
+}
+
+func (*Service) natGatewayURL(datacenterID, id string) string {
+	return path.Join("datacenters", datacenterID, "natgateways", id)
+}
+
+// usesNATGateway returns true if the primary LAN of the machine is connected to the NAT gateway.
+func usesNATGateway(ms *scope.Machine) bool {
+	nat := ms.ClusterScope.IonosCluster.Spec.NATGateway
+	return nat != nil && nat.DatacenterID == ms.DatacenterID()
+}
+
+// ReconcileNATGatewayNetwork ensures that the cluster LAN in the data center of the NAT gateway exists.
+// The LAN is created as a private LAN, as the outbound traffic is routed through the NAT gateway.
+func (s *Service) ReconcileNATGatewayNetwork(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	nat := cs.IonosCluster.Spec.NATGateway
+	if nat == nil || len(cs.IonosCluster.Spec.Networks) > 0 {
+		// The first cluster network is connected to the NAT gateway and reconciled with the other networks.
+		return false, nil
+	}
+
+	properties := sdk.LanPropertiesPost{Name: ptr.To(s.lanName(cs.Cluster)), Public: ptr.To(false)}
+	return s.reconcileClusterOwnedLAN(ctx, cs, nat.DatacenterID, properties)
+}
+
+// ReconcileNATGatewayNetworkDeletion ensures that the cluster LAN in the data center of the NAT gateway is deleted.
+func (s *Service) ReconcileNATGatewayNetworkDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	nat := cs.IonosCluster.Spec.NATGateway
+	if nat == nil || len(cs.IonosCluster.Spec.Networks) > 0 {
+		return false, nil
+	}
+
+	return s.reconcileClusterOwnedLANDeletion(ctx, cs, nat.DatacenterID, s.lanName(cs.Cluster))
+}
+
+// ReconcileNATGatewayIPBlock ensures that the IP block for the public IP of the NAT gateway is reserved.
+func (s *Service) ReconcileNATGatewayIPBlock(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileNATGatewayIPBlock")
+
+	if cs.IonosCluster.Spec.NATGateway == nil {
+		return false, nil
+	}
+
+	ipBlock, request, err := scopedFindResource(
+		ctx, cs,
+		s.getNATGatewayIPBlock,
+		s.getLatestNATGatewayIPBlockCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if ipBlock != nil {
+		cs.SetNATGatewayIPBlockID(ptr.Deref(ipBlock.GetId(), ""))
+		if state := getState(ipBlock); !isAvailable(state) {
+			log.Info("IP block is not available yet", "state", state)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No IP block was found. Creating new IP block")
+	err = s.reserveIPBlock(
+		ctx, s.natGatewayIPBlockName(cs),
+		cs.Location(), 1, log,
+		cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest,
+	)
+	return err == nil, err
+}
+
+// ReconcileNATGatewayIPBlockDeletion ensures that the IP block of the NAT gateway is deleted.
+func (s *Service) ReconcileNATGatewayIPBlockDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileNATGatewayIPBlockDeletion")
+
+	if cs.IonosCluster.Spec.NATGateway == nil {
+		return false, nil
+	}
+
+	ipBlock, request, err := scopedFindResource(
+		ctx, cs,
+		s.getNATGatewayIPBlock,
+		s.getLatestNATGatewayIPBlockCreationRequest,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if ipBlock == nil {
+		cs.SetNATGatewayIPBlockID("")
+		return false, nil
+	}
+
+	ipBlockID := ptr.Deref(ipBlock.GetId(), "")
+	request, err = s.getLatestIPBlockDeletionRequest(ctx, ipBlockID)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	err = s.deleteIPBlock(ctx, log, ipBlockID, cs.IonosCluster, cs.IonosCluster.SetCurrentClusterRequest)
+	return err == nil, err
+}
+
+// ReconcileNATGateway ensures that the NAT gateway exists, creating one if it doesn't.
+// The NAT gateway translates the outbound traffic of the cluster LAN to the reserved public IP.
+func (s *Service) ReconcileNATGateway(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileNATGateway")
+
+	if cs.IonosCluster.Spec.NATGateway == nil {
+		return false, nil
+	}
+
+	natGateway, request, err := scopedFindResource(ctx, cs, s.getNATGateway, s.getLatestNATGatewayCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if natGateway != nil {
+		cs.SetNATGatewayID(ptr.Deref(natGateway.GetId(), ""))
+		if state := getState(natGateway); !isAvailable(state) {
+			log.Info("NAT gateway is not available yet", "state", state)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No NAT gateway was found. Creating new NAT gateway")
+	if err := s.createNATGateway(ctx, cs); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReconcileNATGatewayDeletion ensures that the NAT gateway is deleted.
+func (s *Service) ReconcileNATGatewayDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileNATGatewayDeletion")
+
+	if cs.IonosCluster.Spec.NATGateway == nil {
+		return false, nil
+	}
+	datacenterID := cs.IonosCluster.Spec.NATGateway.DatacenterID
+
+	natGateway, request, err := scopedFindResource(ctx, cs, s.getNATGateway, s.getLatestNATGatewayCreationRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if natGateway == nil {
+		cs.SetNATGatewayID("")
+		return false, nil
+	}
+
+	natGatewayID := ptr.Deref(natGateway.GetId(), "")
+	request, err = getMatchingRequest[sdk.NatGateway](
+		ctx, s, http.MethodDelete, s.natGatewayURL(datacenterID, natGatewayID),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	requestPath, err := s.ionosClient.DeleteNATGateway(ctx, datacenterID, natGatewayID)
+	if err != nil {
+		return false, fmt.Errorf("unable to request NAT gateway deletion: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, natGatewayDeletionRequestedReason,
+		"Requested deletion of NAT gateway %s in data center %s", natGatewayID, datacenterID)
+	log.Info("Successfully requested for NAT gateway deletion", "requestPath", requestPath)
+	return true, nil
+}
+
+// getNATGateway tries to retrieve the NAT gateway of the cluster in the data center.
+func (s *Service) getNATGateway(ctx context.Context, cs *scope.Cluster) (*sdk.NatGateway, error) {
+	datacenterID := cs.IonosCluster.Spec.NATGateway.DatacenterID
+	natGateways, err := s.apiWithDepth(1).ListNATGateways(ctx, datacenterID)
+	if err != nil {
+		return nil, fmt.Errorf("could not list NAT gateways in data center %s: %w", datacenterID, err)
+	}
+
+	var (
+		expectedName = s.natGatewayName(cs.Cluster)
+		count        = 0
+		foundNAT     *sdk.NatGateway
+	)
+
+	for _, natGateway := range ptr.Deref(natGateways.GetItems(), nil) {
+		if ptr.Deref(natGateway.GetProperties().GetName(), "") == expectedName {
+			foundNAT = &natGateway
+			count++
+		}
+
+		if count > 1 {
+			return nil, fmt.Errorf("found multiple NAT gateways with the name: %s", expectedName)
+		}
+	}
+
+	return foundNAT, nil
+}
+
+func (s *Service) getLatestNATGatewayCreationRequest(ctx context.Context, cs *scope.Cluster) (*requestInfo, error) {
+	return getMatchingRequest(
+		ctx, s, http.MethodPost,
+		s.natGatewaysURL(cs.IonosCluster.Spec.NATGateway.DatacenterID),
+		matchByName[*sdk.NatGateway, *sdk.NatGatewayProperties](s.natGatewayName(cs.Cluster)),
+	)
+}
+
+// getNATGatewayIPBlock finds the IP block of the NAT gateway by its name and location.
+func (s *Service) getNATGatewayIPBlock(ctx context.Context, cs *scope.Cluster) (*sdk.IpBlock, error) {
+	blocks, err := s.apiWithDepth(listIPBlocksDepth).ListIPBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list IP blocks: %w", err)
+	}
+
+	for _, block := range ptr.Deref(blocks.GetItems(), nil) {
+		props := block.GetProperties()
+		if ptr.Deref(props.GetLocation(), "") != cs.Location() {
+			continue
+		}
+		if ptr.Deref(props.GetName(), "") == s.natGatewayIPBlockName(cs) {
+			return s.cloudAPIStateInconsistencyWorkaround(ctx, &block)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Service) getLatestNATGatewayIPBlockCreationRequest(
+	ctx context.Context, cs *scope.Cluster,
+) (*requestInfo, error) {
+	return s.getLatestIPBlockRequestByNameAndLocation(
+		ctx, http.MethodPost,
+		s.natGatewayIPBlockName(cs),
+		cs.Location(),
+	)
+}
+
+func (s *Service) createNATGateway(ctx context.Context, cs *scope.Cluster) error {
+	log := s.logger.WithName("createNATGateway")
+	nat := cs.IonosCluster.Spec.NATGateway
+
+	ipBlock, err := s.getNATGatewayIPBlock(ctx, cs)
+	if err != nil {
+		return err
+	}
+	ips := ptr.Deref(ipBlock.GetProperties().GetIps(), nil)
+	if len(ips) == 0 {
+		return errors.New("a reserved IP is required to create the NAT gateway")
+	}
+	publicIP := ips[0]
+
+	// The NAT gateway is connected to the LAN of the primary NICs.
+	var lanID int32
+	if primary := cs.PrimaryNetwork(); primary != nil {
+		lanID, err = s.getNetworkLANID(ctx, nat.DatacenterID, cs.Cluster, primary)
+	} else {
+		lanID, err = s.getLANIDByName(ctx, nat.DatacenterID, s.lanName(cs.Cluster))
+	}
+	if err != nil {
+		return err
+	}
+
+	sourceSubnet := nat.SourceSubnet
+	if sourceSubnet == "" {
+		sourceSubnet = defaultNATGatewaySourceSubnet
+	}
+
+	properties := sdk.NatGatewayProperties{
+		Name:      ptr.To(s.natGatewayName(cs.Cluster)),
+		PublicIps: &[]string{publicIP},
+		Lans:      &[]sdk.NatGatewayLanProperties{{Id: &lanID}},
+	}
+	entities := sdk.NatGatewayEntities{
+		Rules: &sdk.NatGatewayRules{
+			Items: &[]sdk.NatGatewayRule{{
+				Properties: &sdk.NatGatewayRuleProperties{
+					Name:         ptr.To(natGatewayRuleName),
+					Type:         ptr.To(sdk.SNAT),
+					Protocol:     ptr.To(sdk.ALL),
+					PublicIp:     &publicIP,
+					SourceSubnet: &sourceSubnet,
+				},
+			}},
+		},
+	}
+
+	requestPath, err := s.ionosClient.CreateNATGateway(ctx, nat.DatacenterID, properties, entities)
+	if err != nil {
+		return fmt.Errorf("unable to create NAT gateway in data center %s: %w", nat.DatacenterID, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, natGatewayCreationRequestedReason,
+		"Requested creation of NAT gateway in data center %s", nat.DatacenterID)
+	log.Info("Successfully requested for NAT gateway creation", "requestPath", requestPath)
+	return nil
+}
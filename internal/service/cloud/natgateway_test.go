@@ -0,0 +1,352 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	exampleNATGatewayID   = "5c2a2ab1-7e4c-4b5d-9b0a-1b8f6d3e2a10"
+	exampleNATGatewayIPID = "5c2a2ab1-7e4c-4b5d-9b0a-1b8f6d3e2a11"
+	exampleNATGatewayIP   = "203.0.113.10"
+	exampleSourceSubnet   = "10.7.222.0/24"
+)
+
+type natGatewayTestSuite struct {
+	ServiceTestSuite
+}
+
+func TestNATGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(natGatewayTestSuite))
+}
+
+func (s *natGatewayTestSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraCluster.Spec.NATGateway = &infrav1.NATGatewaySpec{
+		DatacenterID: s.machineScope.DatacenterID(),
+		SourceSubnet: exampleSourceSubnet,
+	}
+}
+
+func (s *natGatewayTestSuite) TestNATGatewayNames() {
+	s.Equal("nat-default-test-cluster", s.service.natGatewayName(s.capiCluster))
+	s.Equal("nat-ipb-default-test-cluster", s.service.natGatewayIPBlockName(s.clusterScope))
+}
+
+func (s *natGatewayTestSuite) TestUsesNATGateway() {
+	s.True(usesNATGateway(s.machineScope))
+
+	s.infraCluster.Spec.NATGateway.DatacenterID = "a3bd2a5c-b3e1-4a9e-8d6e-d8e6c2fa0c7a"
+	s.False(usesNATGateway(s.machineScope), "machines in other data centers must not use the NAT gateway")
+
+	s.infraCluster.Spec.NATGateway = nil
+	s.False(usesNATGateway(s.machineScope))
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayNotConfigured() {
+	s.infraCluster.Spec.NATGateway = nil
+
+	steps := []func() (bool, error){
+		func() (bool, error) { return s.service.ReconcileNATGatewayNetwork(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileNATGatewayIPBlock(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileNATGateway(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileNATGatewayDeletion(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileNATGatewayIPBlockDeletion(s.ctx, s.clusterScope) },
+		func() (bool, error) { return s.service.ReconcileNATGatewayNetworkDeletion(s.ctx, s.clusterScope) },
+	}
+	for _, step := range steps {
+		requeue, err := step()
+		s.NoError(err)
+		s.False(requeue)
+	}
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayNetworkCreatePrivateLAN() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{
+		Name:   ptr.To(s.service.lanName(s.capiCluster)),
+		Public: ptr.To(false),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayNetwork(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(exampleRequestPath, s.infraCluster.Status.CurrentClusterRequest.RequestPath)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayNetworkAvailable() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayNetwork(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayIPBlockReserve() {
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{}}, nil).Once()
+	s.mockGetIPBlocksRequestsPostCall().Return(nil, nil).Once()
+	s.mockReserveIPBlockCall(s.service.natGatewayIPBlockName(s.clusterScope), exampleLocation).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayIPBlockReservationPending() {
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{}}, nil).Once()
+	s.mockGetIPBlocksRequestsPostCall().Return([]sdk.Request{
+		s.buildIPBlockRequestWithName(s.service.natGatewayIPBlockName(s.clusterScope),
+			sdk.RequestStatusRunning, http.MethodPost, ""),
+	}, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(sdk.RequestStatusRunning, s.infraCluster.Status.CurrentClusterRequest.State)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayIPBlockAvailable() {
+	s.mockNATGatewayIPBlock().Once()
+
+	requeue, err := s.service.ReconcileNATGatewayIPBlock(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleNATGatewayIPID, s.infraCluster.Status.NATGatewayIPBlockID)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayCreate() {
+	s.mockListNATGatewaysCall().Return(&sdk.NatGateways{Items: &[]sdk.NatGateway{}}, nil).Once()
+	s.mockGetNATGatewayCreationRequestsCall().Return(nil, nil).Once()
+	s.mockNATGatewayIPBlock().Once()
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+
+	s.ionosClient.EXPECT().CreateNATGateway(
+		s.ctx, s.machineScope.DatacenterID(), sdk.NatGatewayProperties{
+			Name:      ptr.To(s.service.natGatewayName(s.capiCluster)),
+			PublicIps: &[]string{exampleNATGatewayIP},
+			Lans:      &[]sdk.NatGatewayLanProperties{{Id: ptr.To(int32(42))}},
+		},
+		sdk.NatGatewayEntities{
+			Rules: &sdk.NatGatewayRules{
+				Items: &[]sdk.NatGatewayRule{{
+					Properties: &sdk.NatGatewayRuleProperties{
+						Name:         ptr.To(natGatewayRuleName),
+						Type:         ptr.To(sdk.SNAT),
+						Protocol:     ptr.To(sdk.ALL),
+						PublicIp:     ptr.To(exampleNATGatewayIP),
+						SourceSubnet: ptr.To(exampleSourceSubnet),
+					},
+				}},
+			},
+		},
+	).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGateway(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPost, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayCreateWithoutIPBlock() {
+	s.mockListNATGatewaysCall().Return(&sdk.NatGateways{Items: &[]sdk.NatGateway{}}, nil).Once()
+	s.mockGetNATGatewayCreationRequestsCall().Return(nil, nil).Once()
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{}}, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGateway(s.ctx, s.clusterScope)
+	s.Error(err)
+	s.False(requeue)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayCreationPending() {
+	s.mockListNATGatewaysCall().Return(&sdk.NatGateways{Items: &[]sdk.NatGateway{}}, nil).Once()
+	s.mockGetNATGatewayCreationRequestsCall().Return([]sdk.Request{
+		s.exampleRequest(requestBuildOptions{
+			status:     sdk.RequestStatusRunning,
+			method:     http.MethodPost,
+			url:        s.service.natGatewaysURL(s.machineScope.DatacenterID()),
+			body:       `{"properties": {"name": "nat-default-test-cluster"}}`,
+			href:       exampleRequestPath,
+			targetType: sdk.NATGATEWAY,
+		}),
+	}, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGateway(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(sdk.RequestStatusRunning, s.infraCluster.Status.CurrentClusterRequest.State)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayAvailable() {
+	s.mockListNATGatewaysCall().Return(s.exampleNATGateways(), nil).Once()
+
+	requeue, err := s.service.ReconcileNATGateway(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(exampleNATGatewayID, s.infraCluster.Status.NATGatewayID)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayDeletion() {
+	s.mockListNATGatewaysCall().Return(s.exampleNATGateways(), nil).Once()
+	s.mockGetNATGatewayDeletionRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteNATGateway(s.ctx, s.machineScope.DatacenterID(), exampleNATGatewayID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayDeletionPending() {
+	s.mockListNATGatewaysCall().Return(s.exampleNATGateways(), nil).Once()
+	s.mockGetNATGatewayDeletionRequestsCall().Return([]sdk.Request{s.exampleRequest(requestBuildOptions{
+		status:     sdk.RequestStatusQueued,
+		method:     http.MethodDelete,
+		url:        s.service.natGatewayURL(s.machineScope.DatacenterID(), exampleNATGatewayID),
+		href:       exampleRequestPath,
+		targetID:   exampleNATGatewayID,
+		targetType: sdk.NATGATEWAY,
+	})}, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayDeletionNotFound() {
+	s.infraCluster.Status.NATGatewayID = exampleNATGatewayID
+	s.mockListNATGatewaysCall().Return(&sdk.NatGateways{Items: &[]sdk.NatGateway{}}, nil).Once()
+	s.mockGetNATGatewayCreationRequestsCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.NATGatewayID)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayIPBlockDeletion() {
+	s.mockNATGatewayIPBlock().Once()
+	s.mockGetIPBlocksRequestsDeleteCall(exampleNATGatewayIPID).Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteIPBlock(s.ctx, exampleNATGatewayIPID).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayIPBlockDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodDelete, s.infraCluster.Status.CurrentClusterRequest.Method)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayIPBlockDeletionNotFound() {
+	s.infraCluster.Status.NATGatewayIPBlockID = exampleNATGatewayIPID
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{}}, nil).Once()
+	s.mockGetIPBlocksRequestsPostCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayIPBlockDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.NATGatewayIPBlockID)
+}
+
+func (s *natGatewayTestSuite) TestReconcileNATGatewayNetworkDeletion() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+	s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodDelete, s.service.lanURL(s.machineScope.DatacenterID(), exampleLANID)).
+		Return(nil, nil).Once()
+	s.ionosClient.EXPECT().DeleteLAN(s.ctx, s.machineScope.DatacenterID(), exampleLANID).
+		Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNATGatewayNetworkDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *natGatewayTestSuite) TestReconcileLANDeletionSkipsNATGatewayLAN() {
+	requeue, err := s.service.ReconcileLANDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *natGatewayTestSuite) TestReconcileLANCreatesPrivateLAN() {
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{
+		Name:          ptr.To(s.service.lanName(s.capiCluster)),
+		Public:        ptr.To(false),
+		Ipv6CidrBlock: ptr.To(infrav1.CloudResourceConfigAuto),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *natGatewayTestSuite) exampleNATGateways() *sdk.NatGateways {
+	return &sdk.NatGateways{
+		Items: &[]sdk.NatGateway{{
+			Id: ptr.To(exampleNATGatewayID),
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.NatGatewayProperties{
+				Name:      ptr.To(s.service.natGatewayName(s.capiCluster)),
+				PublicIps: &[]string{exampleNATGatewayIP},
+			},
+		}},
+	}
+}
+
+func (s *natGatewayTestSuite) exampleNATGatewayIPBlock() *sdk.IpBlock {
+	ipBlock := exampleIPBlockWithName(s.service.natGatewayIPBlockName(s.clusterScope))
+	ipBlock.Id = ptr.To(exampleNATGatewayIPID)
+	ipBlock.Properties.Ips = &[]string{exampleNATGatewayIP}
+	return ipBlock
+}
+
+func (s *natGatewayTestSuite) mockNATGatewayIPBlock() *clienttest.MockClient_GetIPBlock_Call {
+	ipBlock := s.exampleNATGatewayIPBlock()
+	s.mockListIPBlocksCall().Return(&sdk.IpBlocks{Items: &[]sdk.IpBlock{*ipBlock}}, nil).Once()
+	return s.mockGetIPBlockByIDCall(exampleNATGatewayIPID).Return(ipBlock, nil)
+}
+
+func (s *natGatewayTestSuite) mockListNATGatewaysCall() *clienttest.MockClient_ListNATGateways_Call {
+	return s.ionosClient.EXPECT().ListNATGateways(s.ctx, s.machineScope.DatacenterID())
+}
+
+func (s *natGatewayTestSuite) mockGetNATGatewayCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().
+		GetRequests(s.ctx, http.MethodPost, s.service.natGatewaysURL(s.machineScope.DatacenterID()))
+}
+
+func (s *natGatewayTestSuite) mockGetNATGatewayDeletionRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete,
+		s.service.natGatewayURL(s.machineScope.DatacenterID(), exampleNATGatewayID))
+}
+
+func (s *natGatewayTestSuite) mockGetLANCreationRequestsCall() *clienttest.MockClient_GetRequests_Call {
+	return s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodPost, s.service.lansURL(s.machineScope.DatacenterID()))
+}
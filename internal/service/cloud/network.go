@@ -23,13 +23,16 @@ import (
 	"net/http"
 	"path"
 	"slices"
+	"strconv"
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
@@ -42,6 +45,15 @@ func (*Service) lanName(c *clusterv1.Cluster) string {
 		c.Name)
 }
 
+// networkLANName returns the name of the LAN of a cluster network.
+func (*Service) networkLANName(c *clusterv1.Cluster, network string) string {
+	return fmt.Sprintf(
+		"lan-%s-%s-%s",
+		c.Namespace,
+		c.Name,
+		network)
+}
+
 func (*Service) lanURL(datacenterID, id string) string {
 	return path.Join("datacenters", datacenterID, "lans", id)
 }
@@ -51,9 +63,17 @@ func (*Service) lansURL(datacenterID string) string {
 }
 
 // ReconcileLAN ensures the cluster LAN exist, creating one if it doesn't.
+// If the cluster defines networks, their LANs are created by the cluster and the machine only waits for them.
 func (s *Service) ReconcileLAN(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
 	log := s.logger.WithName("ReconcileLAN")
 
+	if len(ms.ClusterScope.IonosCluster.Spec.Networks) > 0 {
+		return s.reconcileNetworkLANs(ctx, ms)
+	}
+	if annotations.IsExternallyManaged(ms.ClusterScope.IonosCluster) {
+		return s.reconcileExternallyManagedLAN(ctx, ms)
+	}
+
 	lan, request, err := scopedFindResource(ctx, ms, s.getLAN, s.getLatestLANCreationRequest)
 	if err != nil {
 		return false, err
@@ -61,7 +81,7 @@ func (s *Service) ReconcileLAN(ctx context.Context, ms *scope.Machine) (requeue
 
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
-		log.Info("Request is pending", "location", request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -70,6 +90,12 @@ func (s *Service) ReconcileLAN(ctx context.Context, ms *scope.Machine) (requeue
 			log.Info("LAN is not available yet", "state", state)
 			return true, nil
 		}
+		if ms.IonosMachine.Spec.IPv6 != nil && ptr.Deref(lan.GetProperties().GetIpv6CidrBlock(), "") == "" {
+			// LANs, which were created without IPv6, need to be updated for dual-stack machines.
+			log.Info("Enabling IPv6 on the cluster LAN")
+			props := sdk.LanProperties{Ipv6CidrBlock: ptr.To(infrav1.CloudResourceConfigAuto)}
+			return true, s.patchLAN(ctx, ms, ptr.Deref(lan.GetId(), ""), props)
+		}
 		return false, nil
 	}
 
@@ -82,11 +108,104 @@ func (s *Service) ReconcileLAN(ctx context.Context, ms *scope.Machine) (requeue
 	return true, nil
 }
 
+// reconcileExternallyManagedLAN ensures that the cluster LAN of an externally managed cluster is available.
+// The LAN is provided together with the rest of the infrastructure and is neither created nor updated.
+func (s *Service) reconcileExternallyManagedLAN(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("reconcileExternallyManagedLAN")
+
+	lan, err := s.getLAN(ctx, ms)
+	if err != nil {
+		return false, err
+	}
+	if lan == nil {
+		log.Info("Waiting for the LAN of the externally managed cluster", "name", s.lanName(ms.ClusterScope.Cluster))
+		return true, nil
+	}
+	if state := getState(lan); !isAvailable(state) {
+		log.Info("LAN is not available yet", "state", state)
+		return true, nil
+	}
+	if ms.IonosMachine.Spec.IPv6 != nil && ptr.Deref(lan.GetProperties().GetIpv6CidrBlock(), "") == "" {
+		return false, fmt.Errorf("IPv6 is not enabled on the LAN %s of the externally managed cluster",
+			ptr.Deref(lan.GetId(), ""))
+	}
+	return false, nil
+}
+
+// reconcileNetworkLANs ensures that the LANs of the cluster networks, to which the machine is attached,
+// are available in the data center of the machine.
+func (s *Service) reconcileNetworkLANs(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("reconcileNetworkLANs")
+
+	primary := ms.ClusterScope.PrimaryNetwork()
+	// The IPv6 setting of existing LANs is only known once they have been retrieved.
+	if ms.IonosMachine.Spec.IPv6 != nil && primary.LAN == nil && !primary.IPv6 {
+		return false, fmt.Errorf("IPv6 is not enabled on the primary network %s", primary.Name)
+	}
+
+	networks := []*infrav1.NetworkSpec{primary}
+	for _, network := range ms.IonosMachine.Spec.AdditionalNetworks {
+		if network.Name == "" {
+			continue
+		}
+		clusterNetwork := ms.ClusterScope.Network(network.Name)
+		if clusterNetwork == nil {
+			return false, fmt.Errorf("additional network %s is not defined in the cluster", network.Name)
+		}
+		networks = append(networks, clusterNetwork)
+	}
+
+	lans, err := s.listLANs(ctx, ms.DatacenterID())
+	if err != nil {
+		return false, err
+	}
+
+	for _, network := range networks {
+		lan, err := s.findNetworkLAN(lans, ms.ClusterScope.Cluster, network)
+		if err != nil {
+			return false, err
+		}
+		if lan == nil && network.LAN != nil {
+			return false, fmt.Errorf("unable to find the existing LAN of network %s in data center %s",
+				network.Name, ms.DatacenterID())
+		}
+		if lan == nil {
+			log.Info("Waiting for the cluster to create the LAN", "network", network.Name)
+			return true, nil
+		}
+		if state := getState(lan); !isAvailable(state) {
+			log.Info("LAN is not available yet", "network", network.Name, "state", state)
+			return true, nil
+		}
+		if network == primary && ms.IonosMachine.Spec.IPv6 != nil &&
+			ptr.Deref(lan.GetProperties().GetIpv6CidrBlock(), "") == "" {
+			return false, fmt.Errorf("IPv6 is not enabled on the existing LAN of the primary network %s", primary.Name)
+		}
+	}
+
+	return false, nil
+}
+
 // ReconcileLANDeletion ensures there's no cluster LAN available, requesting for deletion (if no other resource
 // uses it) otherwise.
 func (s *Service) ReconcileLANDeletion(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
 	log := s.logger.WithName("ReconcileLANDeletion")
 
+	if usesNATGateway(ms) {
+		log.V(4).Info("The cluster LAN is connected to the NAT gateway and deleted with the cluster. Skipping deletion.")
+		return false, nil
+	}
+
+	if len(ms.ClusterScope.IonosCluster.Spec.Networks) > 0 {
+		log.V(4).Info("The LANs of the cluster networks are deleted with the cluster. Skipping deletion.")
+		return false, nil
+	}
+
+	if annotations.IsExternallyManaged(ms.ClusterScope.IonosCluster) {
+		log.V(4).Info("The LAN of the externally managed cluster is not managed by the provider. Skipping deletion.")
+		return false, nil
+	}
+
 	// Try to retrieve the cluster LAN or even check if it's currently still being created.
 	lan, request, err := scopedFindResource(ctx, ms, s.getLAN, s.getLatestLANCreationRequest)
 	if err != nil {
@@ -95,7 +214,7 @@ func (s *Service) ReconcileLANDeletion(ctx context.Context, ms *scope.Machine) (
 
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
-		log.Info("Creation request is pending", "location", request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -111,7 +230,7 @@ func (s *Service) ReconcileLANDeletion(ctx context.Context, ms *scope.Machine) (
 	}
 	if request != nil && request.isPending() {
 		// We want to requeue and check again after some time
-		log.Info("Deletion request is pending", "location", request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
@@ -125,19 +244,51 @@ func (s *Service) ReconcileLANDeletion(ctx context.Context, ms *scope.Machine) (
 	return err == nil, err
 }
 
-// getLAN tries to retrieve the cluster-related LAN in the data center.
+// getLAN tries to retrieve the LAN in the data center, to which the primary NIC of the machine is attached.
+// This is the LAN of the first cluster network or the cluster LAN, if the cluster doesn't define networks.
 func (s *Service) getLAN(ctx context.Context, ms *scope.Machine) (*sdk.Lan, error) {
-	// check if the LAN exists
+	return s.getPrimaryLAN(ctx, ms.ClusterScope, ms.DatacenterID())
+}
+
+// getPrimaryLAN tries to retrieve the LAN in the data center, to which the primary NICs of the machines
+// of the cluster are attached.
+func (s *Service) getPrimaryLAN(ctx context.Context, cs *scope.Cluster, datacenterID string) (*sdk.Lan, error) {
+	primary := cs.PrimaryNetwork()
+	if primary == nil {
+		return s.getLANByName(ctx, datacenterID, s.lanName(cs.Cluster))
+	}
+
+	lans, err := s.listLANs(ctx, datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	return s.findNetworkLAN(lans, cs.Cluster, primary)
+}
+
+// getLANByName tries to retrieve the LAN with the given name in the data center.
+func (s *Service) getLANByName(ctx context.Context, datacenterID, expectedName string) (*sdk.Lan, error) {
+	lans, err := s.listLANs(ctx, datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	return findLANByName(lans, expectedName)
+}
+
+// listLANs lists the LANs in the data center.
+func (s *Service) listLANs(ctx context.Context, datacenterID string) (*sdk.Lans, error) {
 	depth := int32(2) // for listing the LANs with their number of NICs
-	lans, err := s.apiWithDepth(depth).ListLANs(ctx, ms.DatacenterID())
+	lans, err := s.apiWithDepth(depth).ListLANs(ctx, datacenterID)
 	if err != nil {
-		return nil, fmt.Errorf("could not list LANs in data center %s: %w", ms.DatacenterID(), err)
+		return nil, fmt.Errorf("could not list LANs in data center %s: %w", datacenterID, err)
 	}
+	return lans, nil
+}
 
+// findLANByName returns the LAN with the given name from the list of LANs.
+func findLANByName(lans *sdk.Lans, expectedName string) (*sdk.Lan, error) {
 	var (
-		expectedName = s.lanName(ms.ClusterScope.Cluster)
-		lanCount     = 0
-		foundLAN     *sdk.Lan
+		lanCount = 0
+		foundLAN *sdk.Lan
 	)
 
 	for _, l := range *lans.Items {
@@ -156,13 +307,80 @@ func (s *Service) getLAN(ctx context.Context, ms *scope.Machine) (*sdk.Lan, erro
 	return foundLAN, nil
 }
 
+// findLANByID returns the LAN with the given ID from the list of LANs or nil, if there is no such LAN.
+func findLANByID(lans *sdk.Lans, id int32) *sdk.Lan {
+	lanID := strconv.Itoa(int(id))
+	for _, lan := range ptr.Deref(lans.GetItems(), []sdk.Lan{}) {
+		if ptr.Deref(lan.GetId(), "") == lanID {
+			return &lan
+		}
+	}
+	return nil
+}
+
+// findNetworkLAN returns the LAN of the cluster network from the list of LANs. The LAN of a network, which
+// references an existing LAN, is found by the ID or the name of the reference. Otherwise, the LAN created
+// by the cluster is found by its name. If the LAN doesn't exist, nil is returned.
+func (s *Service) findNetworkLAN(lans *sdk.Lans, c *clusterv1.Cluster, network *infrav1.NetworkSpec) (*sdk.Lan, error) {
+	switch ref := network.LAN; {
+	case ref == nil:
+		return findLANByName(lans, s.networkLANName(c, network.Name))
+	case ref.ID != 0:
+		return findLANByID(lans, ref.ID), nil
+	default:
+		return findLANByName(lans, ref.Name)
+	}
+}
+
+// getNetworkLANID returns the numeric ID of the LAN of the cluster network in the data center.
+// An error is returned if the LAN does not exist.
+func (s *Service) getNetworkLANID(
+	ctx context.Context, datacenterID string, c *clusterv1.Cluster, network *infrav1.NetworkSpec,
+) (int32, error) {
+	lans, err := s.listLANs(ctx, datacenterID)
+	if err != nil {
+		return 0, err
+	}
+	lan, err := s.findNetworkLAN(lans, c, network)
+	if err != nil {
+		return 0, err
+	}
+	if lan == nil {
+		return 0, fmt.Errorf("unable to find the LAN of network %s in data center %s", network.Name, datacenterID)
+	}
+	return parseLANID(lan)
+}
+
+// getLANIDByName returns the numeric ID of the LAN with the given name in the data center.
+// An error is returned if the LAN does not exist.
+func (s *Service) getLANIDByName(ctx context.Context, datacenterID, name string) (int32, error) {
+	lan, err := s.getLANByName(ctx, datacenterID, name)
+	if err != nil {
+		return 0, err
+	}
+	if lan == nil {
+		return 0, fmt.Errorf("unable to find LAN %s in data center %s", name, datacenterID)
+	}
+
+	return parseLANID(lan)
+}
+
+// parseLANID returns the numeric ID of the LAN.
+func parseLANID(lan *sdk.Lan) (int32, error) {
+	lanID, err := strconv.ParseInt(ptr.Deref(lan.GetId(), "invalid"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse LAN ID: %w", err)
+	}
+	return int32(lanID), nil
+}
+
 func (s *Service) createLAN(ctx context.Context, ms *scope.Machine) error {
 	log := s.logger.WithName("createLAN")
 
 	lanProperties := sdk.LanPropertiesPost{
 		Name:          ptr.To(s.lanName(ms.ClusterScope.Cluster)),
-		Public:        ptr.To(true),
-		Ipv6CidrBlock: ptr.To("AUTO"), // IPv6 is enabled by default.
+		Public:        ptr.To(!usesNATGateway(ms)),
+		Ipv6CidrBlock: ptr.To(infrav1.CloudResourceConfigAuto), // IPv6 is enabled by default.
 	}
 
 	requestPath, err := s.ionosClient.CreateLAN(ctx, ms.DatacenterID(), lanProperties)
@@ -172,6 +390,8 @@ func (s *Service) createLAN(ctx context.Context, ms *scope.Machine) error {
 
 	ms.ClusterScope.IonosCluster.SetCurrentRequestByDatacenter(ms.DatacenterID(),
 		http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(ms.ClusterScope.IonosCluster, lanCreationRequestedReason,
+		"Requested creation of LAN %s in data center %s", *lanProperties.Name, ms.DatacenterID())
 
 	err = ms.ClusterScope.PatchObject()
 	if err != nil {
@@ -193,6 +413,8 @@ func (s *Service) deleteLAN(ctx context.Context, ms *scope.Machine, lanID string
 
 	ms.ClusterScope.IonosCluster.SetCurrentRequestByDatacenter(ms.DatacenterID(),
 		http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(ms.ClusterScope.IonosCluster, lanDeletionRequestedReason,
+		"Requested deletion of LAN %s in data center %s", lanID, ms.DatacenterID())
 
 	err = ms.ClusterScope.PatchObject()
 	if err != nil {
@@ -240,6 +462,256 @@ func (*Service) removeLANPendingRequestFromCluster(ms *scope.Machine) error {
 	return nil
 }
 
+// reconcileClusterOwnedLAN ensures that the LAN with the given properties exists in the data center, creating it
+// if it doesn't. In contrast to the cluster LAN, which is managed by the machines, these LANs belong to the cluster.
+// Existing LANs are found by their name and are not updated.
+func (s *Service) reconcileClusterOwnedLAN(
+	ctx context.Context, cs *scope.Cluster, datacenterID string, properties sdk.LanPropertiesPost,
+) (requeue bool, err error) {
+	name := ptr.Deref(properties.Name, "")
+	log := s.logger.WithName("reconcileClusterOwnedLAN").WithValues("name", name)
+
+	lan, request, err := s.findClusterOwnedLAN(ctx, datacenterID, name)
+	if err != nil {
+		return false, err
+	}
+
+	if lan != nil {
+		if state := getState(lan); !isAvailable(state) {
+			log.Info("LAN is not available yet", "state", state)
+			return true, nil
+		}
+		return false, nil
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	log.V(4).Info("No LAN was found. Creating new LAN")
+	requestPath, err := s.ionosClient.CreateLAN(ctx, datacenterID, properties)
+	if err != nil {
+		return false, fmt.Errorf("unable to create LAN in data center %s: %w", datacenterID, err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, lanCreationRequestedReason,
+		"Requested creation of LAN %s in data center %s", name, datacenterID)
+	log.Info("Successfully requested for LAN creation", "requestPath", requestPath)
+	return true, nil
+}
+
+// reconcileClusterOwnedLANDeletion ensures that the LAN with the given name is deleted from the data center.
+func (s *Service) reconcileClusterOwnedLANDeletion(
+	ctx context.Context, cs *scope.Cluster, datacenterID, name string,
+) (requeue bool, err error) {
+	log := s.logger.WithName("reconcileClusterOwnedLANDeletion").WithValues("name", name)
+
+	lan, request, err := s.findClusterOwnedLAN(ctx, datacenterID, name)
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodPost, request.status, request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	if lan == nil {
+		return false, nil
+	}
+
+	lanID := ptr.Deref(lan.GetId(), "")
+	request, err = s.getLatestLANRequestByMethod(ctx, http.MethodDelete, s.lanURL(datacenterID, lanID))
+	if err != nil {
+		return false, err
+	}
+
+	if request != nil && request.isPending() {
+		cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, request.status, request.location)
+		log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
+		return true, nil
+	}
+
+	requestPath, err := s.ionosClient.DeleteLAN(ctx, datacenterID, lanID)
+	if err != nil {
+		return false, fmt.Errorf("unable to request LAN deletion in data center: %w", err)
+	}
+
+	cs.IonosCluster.SetCurrentClusterRequest(http.MethodDelete, sdk.RequestStatusQueued, requestPath)
+	s.recordEvent(cs.IonosCluster, lanDeletionRequestedReason,
+		"Requested deletion of LAN %s in data center %s", lanID, datacenterID)
+	log.Info("Successfully requested for LAN deletion", "requestPath", requestPath)
+	return true, nil
+}
+
+// ReconcileNetworks ensures that the LANs of the cluster networks exist in every data center,
+// which contains machines of the cluster. The LANs of networks with a Cross Connect are connected
+// to it, so that machines in different data centers can reach each other. Networks, which reference
+// an existing LAN, are only checked for the LAN to be available.
+func (s *Service) ReconcileNetworks(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	if len(cs.IonosCluster.Spec.Networks) == 0 {
+		return false, nil
+	}
+
+	datacenterIDs, err := s.networkDatacenterIDs(ctx, cs)
+	if err != nil {
+		return false, err
+	}
+
+	// The LANs are connected to the Cross Connects of their networks when they are created.
+	crossConnectIDs, requeue, err := s.reconcileCrossConnects(ctx, cs)
+	if err != nil || requeue {
+		return requeue, err
+	}
+
+	for _, datacenterID := range datacenterIDs {
+		// The data center is recorded before the LANs are requested, so that they are deleted with the cluster.
+		if !slices.Contains(cs.IonosCluster.Status.NetworkDatacenterIDs, datacenterID) {
+			cs.IonosCluster.Status.NetworkDatacenterIDs = append(cs.IonosCluster.Status.NetworkDatacenterIDs, datacenterID)
+		}
+
+		for _, network := range cs.IonosCluster.Spec.Networks {
+			if network.LAN != nil {
+				continue
+			}
+			properties := s.networkLANProperties(cs.Cluster, network, crossConnectIDs[network.Name])
+			if requeue, err := s.reconcileClusterOwnedLAN(ctx, cs, datacenterID, properties); err != nil || requeue {
+				return requeue, err
+			}
+		}
+
+		if requeue, err := s.reconcileNetworkLANStatus(ctx, cs, datacenterID); err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+// reconcileNetworkLANStatus records the IDs of the LANs of the cluster networks in the data center in the status.
+// The existing LANs, which are referenced by networks, must exist and be available.
+func (s *Service) reconcileNetworkLANStatus(
+	ctx context.Context, cs *scope.Cluster, datacenterID string,
+) (requeue bool, err error) {
+	log := s.logger.WithName("reconcileNetworkLANStatus")
+
+	lans, err := s.listLANs(ctx, datacenterID)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range cs.IonosCluster.Spec.Networks {
+		network := &cs.IonosCluster.Spec.Networks[i]
+		lan, err := s.findNetworkLAN(lans, cs.Cluster, network)
+		if err != nil {
+			return false, err
+		}
+		if lan == nil && network.LAN != nil {
+			return false, fmt.Errorf("unable to find the existing LAN of network %s in data center %s",
+				network.Name, datacenterID)
+		}
+		if lan == nil {
+			return true, nil
+		}
+		if state := getState(lan); !isAvailable(state) {
+			log.Info("LAN is not available yet", "network", network.Name, "state", state)
+			return true, nil
+		}
+		cs.SetNetworkLAN(network.Name, network.LAN != nil, datacenterID, ptr.Deref(lan.GetId(), ""))
+	}
+
+	return false, nil
+}
+
+// ReconcileNetworksDeletion ensures that the LANs of the cluster networks are deleted from all data centers,
+// in which they have been created. Existing LANs, which are referenced by networks, are not deleted.
+func (s *Service) ReconcileNetworksDeletion(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	status := &cs.IonosCluster.Status
+	for len(status.NetworkDatacenterIDs) > 0 {
+		datacenterID := status.NetworkDatacenterIDs[0]
+		for _, network := range cs.IonosCluster.Spec.Networks {
+			if network.LAN != nil {
+				// Existing LANs are kept.
+				continue
+			}
+			name := s.networkLANName(cs.Cluster, network.Name)
+			if requeue, err := s.reconcileClusterOwnedLANDeletion(ctx, cs, datacenterID, name); err != nil || requeue {
+				return requeue, err
+			}
+		}
+		status.NetworkDatacenterIDs = status.NetworkDatacenterIDs[1:]
+	}
+
+	return false, nil
+}
+
+// networkDatacenterIDs returns the IDs of the data centers, in which the LANs of the cluster networks are needed.
+// Next to the data centers of the existing machines, these are the data centers, which are referenced by the
+// cluster, and the ones, in which the LANs have been created before.
+func (*Service) networkDatacenterIDs(ctx context.Context, cs *scope.Cluster) ([]string, error) {
+	ionosCluster := cs.IonosCluster
+	datacenterIDs := slices.Clone(ionosCluster.Status.NetworkDatacenterIDs)
+	add := func(datacenterID string) {
+		if datacenterID != "" && !slices.Contains(datacenterIDs, datacenterID) {
+			datacenterIDs = append(datacenterIDs, datacenterID)
+		}
+	}
+
+	add(ionosCluster.Status.DatacenterID)
+	if nat := ionosCluster.Spec.NATGateway; nat != nil {
+		add(nat.DatacenterID)
+	}
+	for _, fd := range ionosCluster.Spec.FailureDomains {
+		add(fd.DatacenterID)
+	}
+
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not list machines: %w", err)
+	}
+	for _, machine := range machines {
+		add(machine.Spec.DatacenterID)
+	}
+
+	return datacenterIDs, nil
+}
+
+// networkLANProperties returns the properties of the LAN of a cluster network.
+// If crossConnectID is set, the LAN is connected to this Cross Connect.
+func (s *Service) networkLANProperties(
+	c *clusterv1.Cluster, network infrav1.NetworkSpec, crossConnectID string,
+) sdk.LanPropertiesPost {
+	properties := sdk.LanPropertiesPost{
+		Name:   ptr.To(s.networkLANName(c, network.Name)),
+		Public: ptr.To(network.Public),
+	}
+	if network.IPv6 {
+		properties.Ipv6CidrBlock = ptr.To(infrav1.CloudResourceConfigAuto)
+	}
+	if crossConnectID != "" {
+		properties.Pcc = ptr.To(crossConnectID)
+	}
+	return properties
+}
+
+func (s *Service) findClusterOwnedLAN(
+	ctx context.Context, datacenterID, name string,
+) (*sdk.Lan, *requestInfo, error) {
+	return findResource(ctx,
+		func(ctx context.Context) (*sdk.Lan, error) {
+			return s.getLANByName(ctx, datacenterID, name)
+		},
+		func(ctx context.Context) (*requestInfo, error) {
+			return s.getLatestLANRequestByMethod(ctx, http.MethodPost, s.lansURL(datacenterID),
+				matchByName[*sdk.Lan, *sdk.LanProperties](name))
+		},
+	)
+}
+
 // ReconcileIPFailover will provide the given machine with a failover configuration. Depending on the machine role,
 // the failover IP will be either the control plane endpoint or the one provided in the machine spec.
 // The control plane nodes will attach the endpoint IP to their primary NIC and add the NIC to the Failover Group
@@ -312,7 +784,7 @@ func (s *Service) retrieveFailoverIPForMachine(
 		}
 
 		if info != nil && info.isPending() {
-			log.Info("Request is pending", "location", info.location)
+			log.Info("Request is pending", "location", info.location, "requestID", info.id())
 			return true, "", nil
 		}
 
@@ -439,7 +911,7 @@ func (s *Service) getServerNICID(ctx context.Context, ms *scope.Machine) (string
 	log := s.logger.WithName("getServerNICID")
 	server, err := s.getServer(ctx, ms)
 	if err != nil {
-		if isNotFound(err) {
+		if ionoserrors.IsNotFound(err) {
 			log.Info("Server was not found or already deleted.")
 			return "", nil
 		}
@@ -602,5 +1074,9 @@ func (s *Service) patchLAN(ctx context.Context, ms *scope.Machine, lanID string,
 }
 
 func failoverRequired(ms *scope.Machine) bool {
-	return util.IsControlPlaneMachine(ms.Machine) || ms.IonosMachine.Spec.FailoverIP != nil
+	if util.IsControlPlaneMachine(ms.Machine) {
+		// The control plane endpoint is served by the load balancer if one is configured.
+		return ms.ClusterScope.IonosCluster.Spec.LoadBalancer == nil
+	}
+	return ms.IonosMachine.Spec.FailoverIP != nil
 }
@@ -29,7 +29,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
@@ -145,6 +145,27 @@ func (s *lanSuite) TestNetworkReconcileLANExistingLAN() {
 	s.False(requeue)
 }
 
+func (s *lanSuite) TestNetworkReconcileLANExistingLANEnableIPv6() {
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(true)}
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+	s.mockPatchLANCall(sdk.LanProperties{Ipv6CidrBlock: ptr.To(infrav1.CloudResourceConfigAuto)}).
+		Return(exampleRequestPath, nil).Once()
+	s.mockWaitForRequestCall(exampleRequestPath).Return(nil).Once()
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *lanSuite) TestNetworkReconcileLANExistingLANIPv6AlreadyEnabled() {
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(true)}
+	lan := s.exampleLAN()
+	lan.Properties.Ipv6CidrBlock = ptr.To("2001:db8::/64")
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{lan}}, nil).Once()
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
 func (s *lanSuite) TestNetworkReconcileLANExistingLANUnavailable() {
 	lan := s.exampleLAN()
 	lan.Metadata.State = ptr.To("BUSY")
@@ -154,6 +175,30 @@ func (s *lanSuite) TestNetworkReconcileLANExistingLANUnavailable() {
 	s.True(requeue)
 }
 
+func (s *lanSuite) TestNetworkReconcileLANExternallyManagedNoExistingLAN() {
+	s.infraCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "terraform"}
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.NotContains(s.infraCluster.Status.CurrentRequestByDatacenter, s.machineScope.DatacenterID())
+}
+
+func (s *lanSuite) TestNetworkReconcileLANExternallyManagedIPv6NotEnabled() {
+	s.infraCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "terraform"}
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(true)}
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
+	_, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.ErrorContains(err, "IPv6 is not enabled on the LAN 42 of the externally managed cluster")
+}
+
+func (s *lanSuite) TestNetworkReconcileLANDeleteExternallyManaged() {
+	s.infraCluster.Annotations = map[string]string{clusterv1.ManagedByAnnotation: "terraform"}
+	requeue, err := s.service.ReconcileLANDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
 func (s *lanSuite) TestNetworkReconcileLANDeleteLANExistsNoPendingRequestsNoOtherUsersDelete() {
 	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil).Once()
 	s.mockGetLANDeletionRequestsCall().Return([]sdk.Request{}, nil).Once()
@@ -200,6 +245,208 @@ func (s *lanSuite) TestNetworkReconcileLANDeleteLANDoesNotExist() {
 	s.NotContains(s.infraCluster.Status.CurrentRequestByDatacenter, s.machineScope.DatacenterID())
 }
 
+func (s *lanSuite) TestNetworkLANNames() {
+	s.Equal("lan-default-test-cluster-storage", s.service.networkLANName(s.clusterScope.Cluster, "storage"))
+}
+
+func (s *lanSuite) TestGetLANPrimaryNetwork() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}, {Name: "storage"}}
+	lans := &sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("storage", "1"), s.exampleNetworkLAN("primary", "2")}}
+	s.mockListLANsCall().Return(lans, nil).Once()
+
+	lan, err := s.service.getLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal("2", *lan.Id)
+}
+
+func (s *lanSuite) TestFindNetworkLANExistingLAN() {
+	existing := s.exampleLAN()
+	existing.Id = ptr.To("7")
+	existing.Properties.Name = ptr.To("existing")
+	lans := &sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("primary", "1"), existing}}
+
+	for _, ref := range []infrav1.LANReference{{ID: 7}, {Name: "existing"}} {
+		lan, err := s.service.findNetworkLAN(lans, s.capiCluster, &infrav1.NetworkSpec{Name: "primary", LAN: &ref})
+		s.NoError(err)
+		s.Equal("7", *lan.Id)
+	}
+
+	lan, err := s.service.findNetworkLAN(lans, s.capiCluster,
+		&infrav1.NetworkSpec{Name: "primary", LAN: &infrav1.LANReference{ID: 8}})
+	s.NoError(err)
+	s.Nil(lan)
+}
+
+func (s *lanSuite) TestReconcileNetworksNotConfigured() {
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.NetworkDatacenterIDs)
+}
+
+func (s *lanSuite) TestReconcileNetworksCreateLAN() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary", IPv6: true}, {Name: "storage"}}
+	datacenterID := s.machineScope.DatacenterID()
+
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+	s.ionosClient.EXPECT().CreateLAN(s.ctx, datacenterID, sdk.LanPropertiesPost{
+		Name:          ptr.To(s.service.networkLANName(s.capiCluster, "primary")),
+		Public:        ptr.To(false),
+		Ipv6CidrBlock: ptr.To(infrav1.CloudResourceConfigAuto),
+	}).Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal([]string{datacenterID}, s.infraCluster.Status.NetworkDatacenterIDs)
+	s.Equal(exampleRequestPath, s.infraCluster.Status.CurrentClusterRequest.RequestPath)
+}
+
+func (s *lanSuite) TestReconcileNetworksAvailable() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}, {Name: "storage"}}
+	lans := &sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("primary", "1"), s.exampleNetworkLAN("storage", "2")}}
+	s.mockListLANsCall().Return(lans, nil).Times(3)
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal([]string{s.machineScope.DatacenterID()}, s.infraCluster.Status.NetworkDatacenterIDs)
+	datacenterID := s.machineScope.DatacenterID()
+	s.Equal([]infrav1.NetworkStatus{
+		{Name: "primary", LANIDs: map[string]string{datacenterID: "1"}},
+		{Name: "storage", LANIDs: map[string]string{datacenterID: "2"}},
+	}, s.infraCluster.Status.Networks)
+}
+
+func (s *lanSuite) TestReconcileNetworksExistingLAN() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{
+		{Name: "primary", LAN: &infrav1.LANReference{Name: "existing"}},
+	}
+	existing := s.exampleLAN()
+	existing.Id = ptr.To("7")
+	existing.Properties.Name = ptr.To("existing")
+	// The existing LAN is neither created nor updated.
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{existing}}, nil).Once()
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal([]infrav1.NetworkStatus{{
+		Name:      "primary",
+		Unmanaged: true,
+		LANIDs:    map[string]string{s.machineScope.DatacenterID(): "7"},
+	}}, s.infraCluster.Status.Networks)
+}
+
+func (s *lanSuite) TestReconcileNetworksExistingLANNotFound() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary", LAN: &infrav1.LANReference{ID: 7}}}
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+
+	requeue, err := s.service.ReconcileNetworks(s.ctx, s.clusterScope)
+	s.ErrorContains(err, "unable to find the existing LAN of network primary")
+	s.False(requeue)
+}
+
+func (s *lanSuite) TestReconcileNetworksDeletion() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}}
+	s.infraCluster.Status.NetworkDatacenterIDs = []string{s.machineScope.DatacenterID()}
+
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("primary", "1")}}, nil).Once()
+	s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodDelete, s.service.lanURL(s.machineScope.DatacenterID(), "1")).
+		Return(nil, nil).Once()
+	s.mockDeleteLANCall("1").Return(exampleRequestPath, nil).Once()
+
+	requeue, err := s.service.ReconcileNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal([]string{s.machineScope.DatacenterID()}, s.infraCluster.Status.NetworkDatacenterIDs)
+}
+
+func (s *lanSuite) TestReconcileNetworksDeletionExistingLAN() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary", LAN: &infrav1.LANReference{ID: 7}}}
+	s.infraCluster.Status.NetworkDatacenterIDs = []string{s.machineScope.DatacenterID()}
+
+	// The mock fails the test on any request to delete the existing LAN.
+	requeue, err := s.service.ReconcileNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.NetworkDatacenterIDs)
+}
+
+func (s *lanSuite) TestReconcileNetworksDeletionCompleted() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}}
+	s.infraCluster.Status.NetworkDatacenterIDs = []string{s.machineScope.DatacenterID()}
+
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{}}, nil).Once()
+	s.mockGetLANCreationRequestsCall().Return(nil, nil).Once()
+
+	requeue, err := s.service.ReconcileNetworksDeletion(s.ctx, s.clusterScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.infraCluster.Status.NetworkDatacenterIDs)
+}
+
+func (s *lanSuite) TestReconcileLANWaitsForNetworks() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}, {Name: "storage"}}
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{Name: "storage"}}
+	lans := &sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("primary", "1")}}
+	s.mockListLANsCall().Return(lans, nil).Once()
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *lanSuite) TestReconcileLANNetworksAvailable() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}, {Name: "storage"}}
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{Name: "storage"}, {NetworkID: 3}}
+	lans := &sdk.Lans{Items: &[]sdk.Lan{s.exampleNetworkLAN("primary", "1"), s.exampleNetworkLAN("storage", "2")}}
+	s.mockListLANsCall().Return(lans, nil).Once()
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *lanSuite) TestReconcileLANNetworksUnknownNetwork() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}}
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{Name: "storage"}}
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.ErrorContains(err, "additional network storage is not defined in the cluster")
+	s.False(requeue)
+}
+
+func (s *lanSuite) TestReconcileLANNetworksIPv6NotEnabled() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}}
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(true)}
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.ErrorContains(err, "IPv6 is not enabled on the primary network primary")
+	s.False(requeue)
+}
+
+func (s *lanSuite) TestReconcileLANNetworksExistingLANIPv6NotEnabled() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary", LAN: &infrav1.LANReference{ID: 7}}}
+	s.infraMachine.Spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(true)}
+	existing := s.exampleLAN()
+	existing.Id = ptr.To("7")
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{existing}}, nil).Once()
+
+	requeue, err := s.service.ReconcileLAN(s.ctx, s.machineScope)
+	s.ErrorContains(err, "IPv6 is not enabled on the existing LAN of the primary network primary")
+	s.False(requeue)
+}
+
+func (s *lanSuite) TestReconcileLANDeletionSkipsNetworks() {
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}}
+
+	requeue, err := s.service.ReconcileLANDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+}
+
 func (s *lanSuite) TestReconcileIPFailoverNICNotInFailoverGroup() {
 	s.machineScope.Machine.SetLabels(map[string]string{clusterv1.MachineControlPlaneLabel: ""})
 	s.machineScope.ClusterScope.IonosCluster.Spec.ControlPlaneEndpoint.Host = exampleEndpointIP
@@ -595,6 +842,13 @@ func (s *lanSuite) examplePatchRequest(status string) sdk.Request {
 	return s.exampleRequest(opts)
 }
 
+func (s *lanSuite) exampleNetworkLAN(network, id string) sdk.Lan {
+	lan := s.exampleLAN()
+	lan.Id = ptr.To(id)
+	lan.Properties.Name = ptr.To(s.service.networkLANName(s.capiCluster, network))
+	return lan
+}
+
 func (s *lanSuite) mockCreateLANCall() *clienttest.MockClient_CreateLAN_Call {
 	return s.ionosClient.EXPECT().CreateLAN(s.ctx, s.machineScope.DatacenterID(), sdk.LanPropertiesPost{
 		Name:          ptr.To(s.service.lanName(s.clusterScope.Cluster)),
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+const (
+	// resolvedConfigPath is the drop-in of systemd-resolved, which configures the nameservers of the networks.
+	resolvedConfigPath = "/etc/systemd/resolved.conf.d/90-capic-nameservers.conf"
+
+	// timesyncdConfigPath is the drop-in of systemd-timesyncd, which configures the NTP servers of the networks.
+	timesyncdConfigPath = "/etc/systemd/timesyncd.conf.d/90-capic-ntp.conf"
+)
+
+// networkOptions are the options of the networks of a machine, which the DHCP server of IONOS Cloud
+// doesn't hand out, and which are therefore configured via the bootstrap data.
+type networkOptions struct {
+	nameservers []string
+	ntpServers  []string
+}
+
+// machineNetworkOptions returns the options of the cluster networks, which the NICs of the machine are
+// attached to. The options of the primary network come first, followed by those of the additional networks
+// in the order of the NICs, so that the rendered configuration is the same for all machines.
+func machineNetworkOptions(ms *scope.Machine) networkOptions {
+	var options networkOptions
+	add := func(list []string, values []string) []string {
+		for _, value := range values {
+			if !slices.Contains(list, value) {
+				list = append(list, value)
+			}
+		}
+		return list
+	}
+
+	if network := ms.ClusterScope.PrimaryNetwork(); network != nil {
+		options.nameservers = add(options.nameservers, network.Nameservers)
+		options.ntpServers = add(options.ntpServers, network.NTPServers)
+	}
+	for _, nic := range ms.IonosMachine.Spec.AdditionalNetworks {
+		if network := ms.ClusterScope.Network(nic.Name); network != nil {
+			options.nameservers = add(options.nameservers, network.Nameservers)
+			options.ntpServers = add(options.ntpServers, network.NTPServers)
+		}
+	}
+	return options
+}
+
+// files returns the configuration files of systemd-resolved and systemd-timesyncd.
+// The nameservers are used for all domains, so that they take precedence over the ones received via DHCP.
+func (o networkOptions) files() []ignitionFile {
+	var files []ignitionFile
+	if len(o.nameservers) > 0 {
+		files = append(files, ignitionFile{
+			path:    resolvedConfigPath,
+			content: "[Resolve]\nDNS=" + strings.Join(o.nameservers, " ") + "\nDomains=~.\n",
+		})
+	}
+	if len(o.ntpServers) > 0 {
+		files = append(files, ignitionFile{
+			path:    timesyncdConfigPath,
+			content: "[Time]\nNTP=" + strings.Join(o.ntpServers, " ") + "\n",
+		})
+	}
+	return files
+}
+
+// bootCommands returns the cloud-config boot commands, which write the configuration files and restart
+// the affected services. Boot commands run before the bootstrap data is executed, so that it can already
+// resolve names, e.g. for pulling images. The nameservers and NTP servers are validated by the API, so they
+// don't need to be quoted.
+func (o networkOptions) bootCommands() []string {
+	files := o.files()
+	if len(files) == 0 {
+		return nil
+	}
+
+	commands := make([]string, 0, 2*len(files)+1)
+	for _, file := range files {
+		commands = append(commands,
+			"mkdir -p "+path.Dir(file.path),
+			"printf '"+strings.ReplaceAll(file.content, "\n", `\n`)+"' > "+file.path,
+		)
+	}
+	return append(commands, "systemctl try-restart systemd-resolved.service systemd-timesyncd.service || true")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+type networkOptionsSuite struct {
+	ServiceTestSuite
+}
+
+func TestNetworkOptionsSuite(t *testing.T) {
+	suite.Run(t, new(networkOptionsSuite))
+}
+
+func (s *networkOptionsSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{
+		Name:        "primary",
+		Nameservers: []string{"10.0.0.53"},
+		NTPServers:  []string{"ntp.example.com"},
+	}, {
+		Name:        "storage",
+		Nameservers: []string{"10.1.0.53", "10.0.0.53"},
+	}, {
+		Name:       "unused",
+		NTPServers: []string{"ntp.unused.example.com"},
+	}}
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{Name: "storage"}, {NetworkID: 3}}
+	s.infraMachine.Name = "test-machine"
+}
+
+func (s *networkOptionsSuite) TestMachineNetworkOptions() {
+	s.Equal(networkOptions{
+		nameservers: []string{"10.0.0.53", "10.1.0.53"},
+		ntpServers:  []string{"ntp.example.com"},
+	}, machineNetworkOptions(s.machineScope))
+}
+
+func (s *networkOptionsSuite) TestMachineNetworkOptionsNone() {
+	s.infraCluster.Spec.Networks = nil
+	s.Empty(machineNetworkOptions(s.machineScope).bootCommands())
+	s.Empty(machineNetworkOptions(s.machineScope).files())
+}
+
+func (s *networkOptionsSuite) TestRenderUserDataCloudConfig() {
+	userData, err := s.service.renderUserData(s.machineScope, "#cloud-config", bootstrapDataFormatCloudConfig)
+	s.NoError(err)
+
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	s.NoError(err)
+	s.Equal(fmt.Sprintf(`#cloud-config
+bootcmd:
+  - echo %[1]s > /etc/hostname
+  - hostname %[1]s
+  - mkdir -p /etc/systemd/resolved.conf.d
+  - printf '[Resolve]\nDNS=10.0.0.53 10.1.0.53\nDomains=~.\n' > %[2]s
+  - mkdir -p /etc/systemd/timesyncd.conf.d
+  - printf '[Time]\nNTP=ntp.example.com\n' > %[3]s
+  - systemctl try-restart systemd-resolved.service systemd-timesyncd.service || true
+`, s.infraMachine.Name, resolvedConfigPath, timesyncdConfigPath), string(decoded))
+}
+
+func (s *networkOptionsSuite) TestRenderUserDataIgnition() {
+	userData, err := s.service.renderUserData(s.machineScope, `{"ignition":{"version":"3.4.0"}}`,
+		bootstrapDataFormatIgnition)
+	s.NoError(err)
+
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	s.NoError(err)
+	s.JSONEq(`{"ignition":{"version":"3.4.0"},"storage":{"files":[`+
+		`{"contents":{"source":"data:,test-machine"},"mode":420,"overwrite":true,"path":"/etc/hostname"},`+
+		`{"contents":{"source":"data:,%5BResolve%5D%0ADNS=10.0.0.53%2010.1.0.53%0ADomains=~.%0A"},"mode":420,`+
+		`"overwrite":true,"path":"`+resolvedConfigPath+`"},`+
+		`{"contents":{"source":"data:,%5BTime%5D%0ANTP=ntp.example.com%0A"},"mode":420,`+
+		`"overwrite":true,"path":"`+timesyncdConfigPath+`"}]}}`, string(decoded))
+}
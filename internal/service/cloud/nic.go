@@ -25,7 +25,7 @@ import (
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
@@ -151,3 +151,8 @@ func nicHasIP(nic *sdk.Nic, expectedIP string) bool {
 func (*Service) nicName(m *infrav1.IonosCloudMachine) string {
 	return "nic-" + m.Name
 }
+
+// additionalNICName returns the name of the secondary NIC, which attaches the machine to the given LAN.
+func (*Service) additionalNICName(m *infrav1.IonosCloudMachine, networkID int32) string {
+	return fmt.Sprintf("nic-%s-%d", m.Name, networkID)
+}
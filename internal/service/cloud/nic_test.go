@@ -47,6 +47,10 @@ func (s *nicSuite) TestNICName() {
 	s.Equal(expected, nicName)
 }
 
+func (s *nicSuite) TestAdditionalNICName() {
+	s.Equal("nic-"+s.infraMachine.Name+"-3", s.service.additionalNICName(s.infraMachine, 3))
+}
+
 func (s *nicSuite) TestReconcileNICConfig() {
 	s.mockGetServerCall(exampleServerID).Return(s.defaultServer(s.infraMachine, exampleDHCPIP), nil).Once()
 
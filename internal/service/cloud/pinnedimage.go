@@ -0,0 +1,100 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// ReconcilePinnedImages pins the image, which was resolved for the first machine of an IonosCloudMachineTemplate
+// in a data center, for all machines of the template in that data center, whose image upgrade policy is Pin.
+// Pinned images are removed once their template has been deleted.
+func (s *Service) ReconcilePinnedImages(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, machine := range machines {
+		key := PinnedImageKey(&machine)
+		if key == "" || machine.Status.ResolvedImageID == "" {
+			continue
+		}
+		if _, ok := cs.IonosCluster.Status.PinnedImages[key]; ok {
+			continue
+		}
+		if cs.IonosCluster.Status.PinnedImages == nil {
+			cs.IonosCluster.Status.PinnedImages = make(map[string]string)
+		}
+		cs.IonosCluster.Status.PinnedImages[key] = machine.Status.ResolvedImageID
+		s.recordEvent(cs.IonosCluster, imagePinnedReason,
+			"Image %s is pinned for the machines of template %s in data center %s",
+			machine.Status.ResolvedImageID, machine.Annotations[clusterv1.TemplateClonedFromNameAnnotation],
+			machine.Spec.DatacenterID)
+	}
+
+	if len(cs.IonosCluster.Status.PinnedImages) == 0 {
+		return false, nil
+	}
+
+	templates, err := cs.ListMachineTemplates(ctx)
+	if err != nil {
+		return false, err
+	}
+	existing := make(map[string]bool, len(templates))
+	for _, template := range templates {
+		existing[template.Name] = true
+	}
+	for key := range cs.IonosCluster.Status.PinnedImages {
+		if templateName, _, _ := strings.Cut(key, "/"); !existing[templateName] {
+			delete(cs.IonosCluster.Status.PinnedImages, key)
+		}
+	}
+
+	return false, nil
+}
+
+// pinnedImageID returns the ID of the image, which is pinned for the template of the machine,
+// or an empty string, if no image has been pinned yet.
+func pinnedImageID(ms *scope.Machine) string {
+	key := PinnedImageKey(ms.IonosMachine)
+	if key == "" {
+		return ""
+	}
+	return ms.ClusterScope.IonosCluster.Status.PinnedImages[key]
+}
+
+// PinnedImageKey returns the key of the pinned image of the machine, which consists of the name of the template,
+// from which the machine was cloned, and the ID of its data center. It returns an empty string, if the image
+// of the machine can't be pinned.
+func PinnedImageKey(machine *infrav1.IonosCloudMachine) string {
+	disk := machine.Spec.Disk
+	if disk == nil || disk.ImageUpgradePolicy != infrav1.ImageUpgradePolicyPin || machine.Spec.DatacenterID == "" {
+		return ""
+	}
+	templateName := machine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if templateName == "" {
+		return ""
+	}
+	return templateName + "/" + machine.Spec.DatacenterID
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// ReconcileRetainedPrivateIPs reserves the private IPv4 address of the primary NIC of every machine, which
+// retains its address, for the name of the machine. Reservations are kept after the machines have been deleted.
+func (s *Service) ReconcileRetainedPrivateIPs(ctx context.Context, cs *scope.Cluster) (requeue bool, err error) {
+	machines, err := cs.ListMachines(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, machine := range machines {
+		if machine.Spec.PrivateIP == nil || !machine.Spec.PrivateIP.Retain || machine.Status.MachineNetworkInfo == nil {
+			continue
+		}
+		for _, nic := range machine.Status.MachineNetworkInfo.NICInfo {
+			if !nic.Primary || len(nic.IPv4Addresses) == 0 {
+				continue
+			}
+			ip := nic.IPv4Addresses[0]
+			if cs.IonosCluster.Status.RetainedPrivateIPs[machine.Name] == ip {
+				break
+			}
+			if cs.IonosCluster.Status.RetainedPrivateIPs == nil {
+				cs.IonosCluster.Status.RetainedPrivateIPs = make(map[string]string)
+			}
+			cs.IonosCluster.Status.RetainedPrivateIPs[machine.Name] = ip
+			s.recordEvent(cs.IonosCluster, privateIPRetainedReason,
+				"Private IP address %s is retained for machine %s", ip, machine.Name)
+			break
+		}
+	}
+
+	return false, nil
+}
+
+// ReconcilePrivateIPAddress stores the pinned or retained private IPv4 address of the machine in the machine
+// scope, so that it is assigned to the primary NIC. Without a retained address, the address is assigned by DHCP.
+func (s *Service) ReconcilePrivateIPAddress(_ context.Context, ms *scope.Machine) (requeue bool, err error) {
+	privateIP := ms.IonosMachine.Spec.PrivateIP
+	if privateIP == nil {
+		return false, nil
+	}
+
+	ip := privateIP.Address
+	if ip == "" && privateIP.Retain {
+		ip = ms.ClusterScope.IonosCluster.Status.RetainedPrivateIPs[ms.IonosMachine.Name]
+		if ip != "" {
+			s.logger.WithName("ReconcilePrivateIPAddress").V(4).Info("Using retained private IP address", "ip", ip)
+		}
+	}
+	if ip == "" {
+		return false, nil
+	}
+
+	if ms.ClaimedIPv4Addresses == nil {
+		ms.ClaimedIPv4Addresses = make(map[int]string, 1)
+	}
+	ms.ClaimedIPv4Addresses[0] = ip
+	return false, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// provisioningPhase is a phase of the provisioning of a machine, which is timed.
+type provisioningPhase string
+
+const (
+	phaseServerRequested    provisioningPhase = "server_requested"
+	phaseBootstrapDelivered provisioningPhase = "bootstrap_delivered"
+	phaseServerAvailable    provisioningPhase = "server_available"
+	phaseNodeReady          provisioningPhase = "node_ready"
+)
+
+// provisioningDuration reports the time from the creation of a machine until it reached a phase
+// of its provisioning, e.g. to monitor how long it takes until the node of a machine is ready.
+var provisioningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "capic_machine_provisioning_duration_seconds",
+	Help:    "Time from the creation of a machine until it reached a phase of its provisioning.",
+	Buckets: prometheus.ExponentialBuckets(15, 2, 10),
+}, []string{"phase"})
+
+func init() {
+	metrics.Registry.MustRegister(provisioningDuration)
+}
+
+// recordProvisioningPhase records the time, at which the machine reached the phase, in its status and
+// observes the time since the creation of the machine. It does nothing, if the phase was recorded before.
+// The phases after the server creation are only recorded for machines, whose server creation was recorded,
+// so that machines, which were provisioned before the timestamps were introduced, are not timed.
+func recordProvisioningPhase(ms *scope.Machine, phase provisioningPhase) {
+	status := &ms.IonosMachine.Status
+	if status.ProvisioningTimestamps == nil {
+		if phase != phaseServerRequested {
+			return
+		}
+		status.ProvisioningTimestamps = &infrav1.ProvisioningTimestamps{}
+	}
+
+	var timestamp **metav1.Time
+	switch timestamps := status.ProvisioningTimestamps; phase {
+	case phaseServerRequested:
+		timestamp = &timestamps.ServerRequested
+	case phaseBootstrapDelivered:
+		timestamp = &timestamps.BootstrapDelivered
+	case phaseServerAvailable:
+		timestamp = &timestamps.ServerAvailable
+	case phaseNodeReady:
+		timestamp = &timestamps.NodeReady
+	}
+	if *timestamp != nil {
+		return
+	}
+
+	now := metav1.Now()
+	*timestamp = &now
+	provisioningDuration.WithLabelValues(string(phase)).
+		Observe(now.Sub(ms.IonosMachine.CreationTimestamp.Time).Seconds())
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type provisioningPhasesSuite struct {
+	ServiceTestSuite
+}
+
+func TestProvisioningPhasesSuite(t *testing.T) {
+	suite.Run(t, new(provisioningPhasesSuite))
+}
+
+func (s *provisioningPhasesSuite) TestRecordProvisioningPhase() {
+	s.infraMachine.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+
+	recordProvisioningPhase(s.machineScope, phaseServerAvailable)
+	s.Nil(s.infraMachine.Status.ProvisioningTimestamps, "machines without a recorded server creation must not be timed")
+
+	for _, phase := range []provisioningPhase{
+		phaseServerRequested, phaseBootstrapDelivered, phaseServerAvailable, phaseNodeReady,
+	} {
+		recordProvisioningPhase(s.machineScope, phase)
+	}
+	timestamps := s.infraMachine.Status.ProvisioningTimestamps
+	s.NotNil(timestamps.ServerRequested)
+	s.NotNil(timestamps.BootstrapDelivered)
+	s.NotNil(timestamps.ServerAvailable)
+	s.NotNil(timestamps.NodeReady)
+	s.Equal(4, testutil.CollectAndCount(provisioningDuration))
+
+	// The phases are only recorded once.
+	serverRequested := timestamps.ServerRequested
+	recordProvisioningPhase(s.machineScope, phaseServerRequested)
+	s.Same(serverRequested, timestamps.ServerRequested)
+}
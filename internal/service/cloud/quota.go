@@ -0,0 +1,137 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// remainingResources reports the resources, which can still be provisioned under a contract.
+// It is updated whenever the resource limits are checked before a server is created.
+var remainingResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capic_contract_resources_remaining",
+	Help: "Resources, which can still be provisioned under the contract. Disk space is reported in MB.",
+}, []string{"contract", "resource"})
+
+func init() {
+	metrics.Registry.MustRegister(remainingResources)
+}
+
+// serverResources are the resources, which a server consumes from the resource limits of the contract.
+type serverResources struct {
+	cores int64
+	ramMB int64
+	// hddMB and ssdMB are the sizes of all volumes of the given type.
+	hddMB, ssdMB int64
+	// largestHDDMB and largestSSDMB are the sizes of the largest volume of the given type.
+	largestHDDMB, largestSSDMB int64
+}
+
+// resourcesOf returns the resources of a server created from the machine spec. The cores, the memory
+// and the boot volume of CUBE servers are defined by their template and are not taken into account.
+func resourcesOf(spec *infrav1.IonosCloudMachineSpec) serverResources {
+	var res serverResources
+	addVolume := func(diskType infrav1.VolumeDiskType, sizeGB int) {
+		sizeMB := int64(sizeGB) * 1024
+		if diskType == infrav1.VolumeDiskTypeHDD || diskType == "" {
+			res.hddMB += sizeMB
+			res.largestHDDMB = max(res.largestHDDMB, sizeMB)
+			return
+		}
+		res.ssdMB += sizeMB
+		res.largestSSDMB = max(res.largestSSDMB, sizeMB)
+	}
+
+	if spec.Type != infrav1.ServerTypeCube {
+		res.cores = int64(spec.NumCores)
+		res.ramMB = int64(spec.MemoryMB)
+		if spec.Disk != nil {
+			addVolume(spec.Disk.EffectiveDiskType(), spec.Disk.SizeGB)
+		}
+	}
+	for _, volume := range spec.AdditionalVolumes {
+		addVolume(volume.DiskType, volume.SizeGB)
+	}
+	return res
+}
+
+// checkResourceLimits compares the resources of a server created from the machine spec with the resource
+// limits of the contract. It returns a description of every exceeded limit. If the limits can't be
+// determined, the server is not checked, and it's up to the Cloud API to reject it.
+func (s *Service) checkResourceLimits(ctx context.Context, spec *infrav1.IonosCloudMachineSpec) []string {
+	log := s.logger.WithName("checkResourceLimits")
+
+	contracts, err := s.ionosClient.ListContracts(ctx)
+	if err != nil {
+		log.Info("Unable to get the resource limits of the contract", "error", err.Error())
+		return nil
+	}
+	items := ptr.Deref(contracts.GetItems(), nil)
+	if len(items) == 0 || items[0].GetProperties().GetResourceLimits() == nil {
+		return nil
+	}
+	contract := items[0].GetProperties()
+	limits := contract.GetResourceLimits()
+	contractNumber := strconv.FormatInt(ptr.Deref(contract.GetContractNumber(), 0), 10)
+
+	res := resourcesOf(spec)
+	var exceeded []string
+	checkPerResource := func(name string, requested int64, limit *int64) {
+		if limit != nil && requested > *limit {
+			exceeded = append(exceeded, fmt.Sprintf("%s: requested %d, limit %d", name, requested, *limit))
+		}
+	}
+	checkPerContract := func(name, metric string, requested int64, limit, provisioned *int64) {
+		if limit == nil {
+			return
+		}
+		remaining := *limit - ptr.Deref(provisioned, 0)
+		remainingResources.WithLabelValues(contractNumber, metric).Set(float64(remaining))
+		if requested > 0 && requested > remaining {
+			exceeded = append(exceeded, fmt.Sprintf("%s: requested %d, available %d", name, requested, remaining))
+		}
+	}
+
+	checkPerResource("cores per server", res.cores, toInt64(limits.GetCoresPerServer()))
+	checkPerContract("cores", "cores", res.cores,
+		toInt64(limits.GetCoresPerContract()), toInt64(limits.GetCoresProvisioned()))
+	checkPerResource("RAM per server (MB)", res.ramMB, toInt64(limits.GetRamPerServer()))
+	checkPerContract("RAM (MB)", "ram_mb", res.ramMB,
+		toInt64(limits.GetRamPerContract()), toInt64(limits.GetRamProvisioned()))
+	checkPerResource("HDD volume size (MB)", res.largestHDDMB, limits.GetHddLimitPerVolume())
+	checkPerContract("HDD space (MB)", "hdd_mb", res.hddMB,
+		limits.GetHddLimitPerContract(), limits.GetHddVolumeProvisioned())
+	checkPerResource("SSD volume size (MB)", res.largestSSDMB, limits.GetSsdLimitPerVolume())
+	checkPerContract("SSD space (MB)", "ssd_mb", res.ssdMB,
+		limits.GetSsdLimitPerContract(), limits.GetSsdVolumeProvisioned())
+	return exceeded
+}
+
+func toInt64(v *int32) *int64 {
+	if v == nil {
+		return nil
+	}
+	return ptr.To(int64(*v))
+}
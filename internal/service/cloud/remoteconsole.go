@@ -0,0 +1,59 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// ReconcileRemoteConsole stores the URL of the remote console of the server in a secret, if it was requested
+// with the remote console annotation. It is called for failed machines as well, whose console is needed the most.
+func (s *Service) ReconcileRemoteConsole(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	if ms.IonosMachine.Annotations[infrav1.RemoteConsoleAnnotation] != "true" {
+		return false, nil
+	}
+
+	log := s.logger.WithName("ReconcileRemoteConsole")
+	serverID := ms.ServerID()
+	if serverID == "" {
+		log.V(4).Info("Server does not exist yet. Remote console is requested once it was created")
+		return false, nil
+	}
+
+	url, err := s.ionosClient.GetServerRemoteConsoleURL(ctx, ms.DatacenterID(), serverID)
+	if err != nil {
+		if ionoserrors.IsNotFound(err) {
+			log.Info("Server was not found. Remote console is requested once it was created", "serverID", serverID)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get remote console URL of server %s: %w", serverID, err)
+	}
+	if err := ms.StoreRemoteConsoleURL(ctx, url); err != nil {
+		return false, err
+	}
+
+	log.Info("Stored remote console URL", "serverID", serverID, "secret", ms.RemoteConsoleSecretName())
+	s.recordEvent(ms.IonosMachine, remoteConsoleURLStoredReason,
+		"Stored remote console URL of server %s in secret %s", serverID, ms.RemoteConsoleSecretName())
+	delete(ms.IonosMachine.Annotations, infrav1.RemoteConsoleAnnotation)
+	return false, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+const exampleRemoteConsoleURL = "https://dcd.ionos.com/console?token=secret"
+
+type remoteConsoleSuite struct {
+	ServiceTestSuite
+}
+
+func TestRemoteConsoleSuite(t *testing.T) {
+	suite.Run(t, new(remoteConsoleSuite))
+}
+
+func (s *remoteConsoleSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.infraMachine.Annotations = map[string]string{infrav1.RemoteConsoleAnnotation: "true"}
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsole() {
+	s.ionosClient.EXPECT().GetServerRemoteConsoleURL(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return(exampleRemoteConsoleURL, nil).Once()
+
+	requeue, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.NotContains(s.infraMachine.Annotations, infrav1.RemoteConsoleAnnotation)
+	s.Require().NotNil(s.infraMachine.Status.RemoteConsole)
+	s.Equal(s.machineScope.RemoteConsoleSecretName(), s.infraMachine.Status.RemoteConsole.SecretName)
+	s.False(s.infraMachine.Status.RemoteConsole.RequestedAt.IsZero())
+
+	var secret corev1.Secret
+	key := client.ObjectKey{Namespace: s.infraMachine.Namespace, Name: s.machineScope.RemoteConsoleSecretName()}
+	s.NoError(s.k8sClient.Get(s.ctx, key, &secret))
+	s.Equal(exampleRemoteConsoleURL, string(secret.Data[infrav1.RemoteConsoleURLKey]))
+	s.Require().Len(secret.OwnerReferences, 1)
+	s.Equal(s.infraMachine.Name, secret.OwnerReferences[0].Name)
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsoleUpdatesSecret() {
+	s.ionosClient.EXPECT().GetServerRemoteConsoleURL(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("https://dcd.ionos.com/console?token=old", nil).Once()
+	s.ionosClient.EXPECT().GetServerRemoteConsoleURL(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return(exampleRemoteConsoleURL, nil).Once()
+
+	for range 2 {
+		s.infraMachine.Annotations[infrav1.RemoteConsoleAnnotation] = "true"
+		_, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+		s.NoError(err)
+	}
+
+	var secret corev1.Secret
+	key := client.ObjectKey{Namespace: s.infraMachine.Namespace, Name: s.machineScope.RemoteConsoleSecretName()}
+	s.NoError(s.k8sClient.Get(s.ctx, key, &secret))
+	s.Equal(exampleRemoteConsoleURL, string(secret.Data[infrav1.RemoteConsoleURLKey]))
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsoleNotRequested() {
+	s.infraMachine.Annotations = nil
+
+	requeue, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraMachine.Status.RemoteConsole)
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsoleNoServer() {
+	s.infraMachine.Spec.ProviderID = nil
+
+	requeue, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(s.infraMachine.Annotations, infrav1.RemoteConsoleAnnotation, "the annotation must be kept")
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsoleServerNotFound() {
+	s.ionosClient.EXPECT().GetServerRemoteConsoleURL(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("", sdk.NewGenericOpenAPIError("not found", nil, nil, 404)).Once()
+
+	requeue, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Contains(s.infraMachine.Annotations, infrav1.RemoteConsoleAnnotation, "the annotation must be kept")
+}
+
+func (s *remoteConsoleSuite) TestReconcileRemoteConsoleError() {
+	s.ionosClient.EXPECT().GetServerRemoteConsoleURL(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("", errors.New("timeout")).Once()
+
+	_, err := s.service.ReconcileRemoteConsole(s.ctx, s.machineScope)
+	s.ErrorContains(err, "failed to get remote console URL")
+	s.Contains(s.infraMachine.Annotations, infrav1.RemoteConsoleAnnotation)
+	s.Nil(s.infraMachine.Status.RemoteConsole)
+}
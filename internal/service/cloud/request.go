@@ -21,35 +21,69 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 	"sigs.k8s.io/cluster-api/util"
 
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
 
-// GetRequestStatus returns the status of a request for a given request URL.
-// If the request contains a message, it is also returned.
-func (s *Service) GetRequestStatus(
-	ctx context.Context, requestURL string,
-) (requestStatus string, statusMessage string, err error) {
-	status, err := s.ionosClient.CheckRequestStatus(ctx, requestURL)
+// PollRequest polls the state of a tracked request and updates the request accordingly.
+// It returns true as long as the request is still queued or running.
+// Once the request is done, has failed or isn't known to the API anymore, false is returned,
+// and the request doesn't need to be tracked any longer.
+func (s *Service) PollRequest(ctx context.Context, req *infrav1.ProvisioningRequest) (pending bool, err error) {
+	log := s.logger.WithValues("method", req.Method, "requestPath", req.RequestPath, "requestID", req.ID())
+
+	status, err := s.ionosClient.CheckRequestStatus(ctx, req.RequestPath)
+	if ionoserrors.IsNotFound(err) {
+		// Requests are only kept for a limited time. There is nothing left to wait for.
+		log.Info("Tracked request does not exist anymore")
+		return false, nil
+	}
 	if err != nil {
-		return "", "", fmt.Errorf("unable to retrieve the request status: %w", err)
+		return false, fmt.Errorf("unable to retrieve the request status: %w", err)
 	}
 
 	if !status.HasMetadata() || !status.Metadata.HasStatus() {
-		return "", "", errors.New("request status metadata is missing")
+		return false, errors.New("request status metadata is missing")
 	}
 
-	message := ""
-	if status.Metadata.HasMessage() {
-		message = *status.Metadata.Message
+	req.State = *status.Metadata.Status
+	req.Targets = requestTargets(status)
+
+	switch req.State {
+	case sdk.RequestStatusQueued, sdk.RequestStatusRunning:
+		return true, nil
+	case sdk.RequestStatusFailed:
+		log.Error(nil, "Request status indicates a failure",
+			"message", ptr.Deref(status.Metadata.GetMessage(), ""))
+		return false, nil
+	case sdk.RequestStatusDone:
+		return false, nil
 	}
 
-	return *status.Metadata.Status, message, nil
+	return false, fmt.Errorf("unknown request status %s", req.State)
+}
+
+func requestTargets(status *sdk.RequestStatus) []infrav1.ProvisioningRequestTarget {
+	var targets []infrav1.ProvisioningRequestTarget
+	for _, target := range ptr.Deref(status.GetMetadata().GetTargets(), nil) {
+		resource := target.GetTarget()
+		if resource == nil {
+			continue
+		}
+		targets = append(targets, infrav1.ProvisioningRequestTarget{
+			Type: string(ptr.Deref(resource.GetType(), "")),
+			ID:   ptr.Deref(resource.GetId(), ""),
+		})
+	}
+	return targets
 }
 
 // mapResourceType maps a cloud resource to its corresponding IONOS Cloud type identifier.
@@ -62,8 +96,22 @@ func mapResourceType(cloudResource any) sdk.Type {
 		return sdk.LAN
 	case sdk.Server, *sdk.Server:
 		return sdk.SERVER
+	case sdk.Volume, *sdk.Volume:
+		return sdk.VOLUME
+	case sdk.Datacenter, *sdk.Datacenter:
+		return sdk.DATACENTER
 	case sdk.IpBlock, *sdk.IpBlock:
 		return sdk.IPBLOCK
+	case sdk.NetworkLoadBalancer, *sdk.NetworkLoadBalancer:
+		return sdk.NETWORKLOADBALANCER
+	case sdk.NatGateway, *sdk.NatGateway:
+		return sdk.NATGATEWAY
+	case sdk.ApplicationLoadBalancer, *sdk.ApplicationLoadBalancer:
+		return sdk.APPLICATIONLOADBALANCER
+	case sdk.TargetGroup, *sdk.TargetGroup:
+		return sdk.TARGET_GROUP
+	case sdk.PrivateCrossConnect, *sdk.PrivateCrossConnect:
+		return sdk.PCC
 	default:
 		return ""
 	}
@@ -170,6 +218,11 @@ requestLoop:
 		}
 
 		status := ptr.Deref(req.GetMetadata().GetRequestStatus().GetMetadata().GetStatus(), "")
+		if (status == sdk.RequestStatusQueued || status == sdk.RequestStatusRunning) &&
+			slices.Contains(s.ignoredRequests, ptr.Deref(req.GetId(), "")) {
+			s.logger.V(4).Info("Ignoring abandoned request", "requestID", ptr.Deref(req.GetId(), ""))
+			continue
+		}
 		if status == sdk.RequestStatusFailed {
 			message := ptr.Deref(req.GetMetadata().GetRequestStatus().GetMetadata().GetMessage(), "")
 			s.logger.Error(nil,
@@ -294,6 +347,11 @@ func (ri *requestInfo) isPending() bool {
 	return ri.status == sdk.RequestStatusQueued || ri.status == sdk.RequestStatusRunning
 }
 
+// id returns the ID of the request, which is part of its location URL.
+func (ri *requestInfo) id() string {
+	return (&infrav1.ProvisioningRequest{RequestPath: ri.location}).ID()
+}
+
 // isDone returns true if the request was finished successfully.
 func (ri *requestInfo) isDone() bool {
 	return ri.status == sdk.RequestStatusDone
@@ -315,6 +373,21 @@ func isRunning(state string) bool {
 	return state == "RUNNING"
 }
 
+// isShutOff returns true if the VM is powered off.
+func isShutOff(state string) bool {
+	return state == "SHUTOFF"
+}
+
+// isCrashed returns true if the VM has crashed.
+func isCrashed(state string) bool {
+	return state == "CRASHED"
+}
+
+// isPoweredOn returns true if the VM is running or still in the process of shutting down.
+func isPoweredOn(state string) bool {
+	return isRunning(state) || state == "SHUTDOWN"
+}
+
 // isAvailable returns true if the resource is available. Note that not all resource types have this state.
 func isAvailable(state string) bool {
 	return state == sdk.Available
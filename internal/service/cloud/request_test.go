@@ -27,13 +27,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
 const (
-	baseTestURL      = "https://url.tld/path"
-	messageEmptyText = "message should be empty"
+	baseTestURL = "https://url.tld/path"
 )
 
 func TestMatcher_MatchByName(t *testing.T) {
@@ -53,67 +53,127 @@ func TestMatcher_MatchByName(t *testing.T) {
 	// matchByNameInvalidFunc := matchByName[*sdk.Server, *sdk.Info]("test")
 }
 
-type getRequestStatusSuite struct {
+type pollRequestSuite struct {
 	ServiceTestSuite
 }
 
-func TestGetRequestStatusTestSuite(t *testing.T) {
-	suite.Run(t, new(getRequestStatusSuite))
+func TestPollRequestTestSuite(t *testing.T) {
+	suite.Run(t, new(pollRequestSuite))
 }
 
-func (s *getRequestStatusSuite) TestGetRequestStatusMissingMetadata() {
+func (s *pollRequestSuite) exampleTrackedRequest() *infrav1.ProvisioningRequest {
+	return &infrav1.ProvisioningRequest{
+		Method:      http.MethodPost,
+		RequestPath: baseTestURL,
+		State:       sdk.RequestStatusQueued,
+	}
+}
+
+func (s *pollRequestSuite) exampleRequestStatus(status string) *sdk.RequestStatus {
+	return &sdk.RequestStatus{
+		Href: ptr.To(baseTestURL),
+		Id:   ptr.To("12345"),
+		Metadata: &sdk.RequestStatusMetadata{
+			Status: ptr.To(status),
+			Targets: &[]sdk.RequestTarget{{
+				Target: &sdk.ResourceReference{
+					Id:   ptr.To(exampleServerID),
+					Type: ptr.To(sdk.SERVER),
+				},
+			}},
+		},
+	}
+}
+
+func (s *pollRequestSuite) TestPollRequestMissingMetadata() {
 	s.mockCheckRequestStatusCall(baseTestURL).Return(&sdk.RequestStatus{
 		Href:     ptr.To(baseTestURL),
 		Id:       ptr.To("12345"),
 		Metadata: nil,
 	}, nil).Once()
 
-	status, message, err := s.service.GetRequestStatus(s.ctx, baseTestURL)
+	req := s.exampleTrackedRequest()
+	pending, err := s.service.PollRequest(s.ctx, req)
 	s.Error(err, "should return an error but didn't")
-	s.Empty(status, "status should be empty")
-	s.Empty(message, messageEmptyText)
+	s.False(pending)
+	s.Equal(s.exampleTrackedRequest(), req, "request should not be modified")
 
 	s.mockCheckRequestStatusCall(baseTestURL).Return(&sdk.RequestStatus{
 		Metadata: &sdk.RequestStatusMetadata{},
 	}, nil).Once()
 
-	status, message, err = s.service.GetRequestStatus(s.ctx, baseTestURL)
+	pending, err = s.service.PollRequest(s.ctx, req)
 	s.Error(err, "should return an error but didn't")
-	s.Empty(status, "status should be empty")
-	s.Empty(message, messageEmptyText)
+	s.False(pending)
+	s.Equal(s.exampleTrackedRequest(), req, "request should not be modified")
 }
 
-func (s *getRequestStatusSuite) TestGetRequestStatus() {
-	s.mockCheckRequestStatusCall(baseTestURL).Return(&sdk.RequestStatus{
-		Href: ptr.To(baseTestURL),
-		Id:   ptr.To("12345"),
-		Metadata: &sdk.RequestStatusMetadata{
-			Status:  ptr.To(sdk.RequestStatusFailed),
-			Message: ptr.To("Failed to do foo and bar"),
-		},
-	}, nil).Once()
+func (s *pollRequestSuite) TestPollRequestPending() {
+	for _, status := range []string{sdk.RequestStatusQueued, sdk.RequestStatusRunning} {
+		s.mockCheckRequestStatusCall(baseTestURL).Return(s.exampleRequestStatus(status), nil).Once()
 
-	status, message, err := s.service.GetRequestStatus(s.ctx, baseTestURL)
-	s.NoError(err, "should not return an error but did")
-	s.Equal(sdk.RequestStatusFailed, status, "status should be FAILED")
-	s.Equal("Failed to do foo and bar", message, "message should be 'Failed to do foo and bar'")
+		req := s.exampleTrackedRequest()
+		pending, err := s.service.PollRequest(s.ctx, req)
+		s.NoError(err)
+		s.True(pending, "request should be pending for status %s", status)
+		s.Equal(status, req.State)
+		s.Equal([]infrav1.ProvisioningRequestTarget{{Type: string(sdk.SERVER), ID: exampleServerID}}, req.Targets)
+	}
+}
 
-	s.mockCheckRequestStatusCall(baseTestURL).Return(&sdk.RequestStatus{
-		Href: ptr.To(baseTestURL),
-		Id:   ptr.To("12345"),
-		Metadata: &sdk.RequestStatusMetadata{
-			Status:  ptr.To(sdk.RequestStatusQueued),
-			Message: nil,
-		},
-	}, nil).Once()
+func (s *pollRequestSuite) TestPollRequestDone() {
+	s.mockCheckRequestStatusCall(baseTestURL).Return(s.exampleRequestStatus(sdk.RequestStatusDone), nil).Once()
+
+	req := s.exampleTrackedRequest()
+	pending, err := s.service.PollRequest(s.ctx, req)
+	s.NoError(err)
+	s.False(pending)
+	s.Equal(sdk.RequestStatusDone, req.State)
+}
+
+func (s *pollRequestSuite) TestPollRequestFailed() {
+	status := s.exampleRequestStatus(sdk.RequestStatusFailed)
+	status.Metadata.Message = ptr.To("Failed to do foo and bar")
+	s.mockCheckRequestStatusCall(baseTestURL).Return(status, nil).Once()
+
+	req := s.exampleTrackedRequest()
+	pending, err := s.service.PollRequest(s.ctx, req)
+	s.NoError(err)
+	s.False(pending)
+	s.Equal(sdk.RequestStatusFailed, req.State)
+}
+
+func (s *pollRequestSuite) TestPollRequestUnknownStatus() {
+	s.mockCheckRequestStatusCall(baseTestURL).Return(s.exampleRequestStatus("UNKNOWN"), nil).Once()
+
+	pending, err := s.service.PollRequest(s.ctx, s.exampleTrackedRequest())
+	s.ErrorContains(err, "unknown request status")
+	s.False(pending)
+}
+
+func (s *pollRequestSuite) TestPollRequestNotFound() {
+	s.mockCheckRequestStatusCall(baseTestURL).
+		Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound)).Once()
 
-	status, message, err = s.service.GetRequestStatus(s.ctx, baseTestURL)
-	s.NoError(err, "should not return an error but did")
-	s.Equal(sdk.RequestStatusQueued, status, "status should be FAILED")
-	s.Empty(message, messageEmptyText)
+	req := s.exampleTrackedRequest()
+	pending, err := s.service.PollRequest(s.ctx, req)
+	s.NoError(err)
+	s.False(pending)
+	s.Equal(s.exampleTrackedRequest(), req, "request should not be modified")
+}
+
+func (s *pollRequestSuite) TestPollRequestError() {
+	s.mockCheckRequestStatusCall(baseTestURL).
+		Return(nil, sdk.NewGenericOpenAPIError("unexpected error returned", nil, nil, http.StatusInternalServerError)).Once()
+
+	req := s.exampleTrackedRequest()
+	pending, err := s.service.PollRequest(s.ctx, req)
+	s.ErrorContains(err, "unexpected error returned")
+	s.False(pending)
+	s.Equal(s.exampleTrackedRequest(), req, "request should not be modified")
 }
 
-func (s *getRequestStatusSuite) mockCheckRequestStatusCall(
+func (s *pollRequestSuite) mockCheckRequestStatusCall(
 	requestURL string,
 ) *clienttest.MockClient_CheckRequestStatus_Call {
 	return s.ionosClient.EXPECT().CheckRequestStatus(s.ctx, requestURL)
@@ -190,6 +250,24 @@ func (s *getMatchingRequestSuite) TestMatching() {
 	s.Equal(sdk.RequestStatusFailed, request.status)
 }
 
+func (s *getMatchingRequestSuite) TestIgnoredRequests() {
+	// req1 was abandoned, but is still pending
+	req1 := s.examplePostRequest("req1", sdk.RequestStatusQueued)
+	req1.Id = ptr.To("abandoned-1")
+
+	// req2 was abandoned and has completed in the meantime
+	req2 := s.examplePostRequest("req2", sdk.RequestStatusDone)
+	req2.Id = ptr.To("abandoned-2")
+
+	s.ionosClient.EXPECT().GetRequests(s.ctx, http.MethodPost, "path").Return([]sdk.Request{req1, req2}, nil)
+
+	s.service.IgnoreRequests("abandoned-1", "abandoned-2")
+	request, err := getMatchingRequest[sdk.Lan](s.ctx, s.service, http.MethodPost, "path")
+	s.NoError(err)
+	s.NotNil(request)
+	s.Equal("req2", request.location)
+}
+
 func TestHasRequestTargetType(t *testing.T) {
 	req := sdk.Request{
 		Metadata: &sdk.RequestMetadata{
@@ -17,25 +17,53 @@ limitations under the License.
 package cloud
 
 import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util/conditions"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
 )
 
+const (
+	// defaultShutdownTimeout is used if no shutdown timeout was configured for the machine.
+	defaultShutdownTimeout = 2 * time.Minute
+
+	// cubeBootVolumeType is the volume type of the directly attached storage of CUBE servers.
+	cubeBootVolumeType = "DAS"
+
+	imageTypeHDD = "HDD"
+
+	// imageLicenceTypeUnknown is the licence type of images, which were uploaded via FTP.
+	imageLicenceTypeUnknown = "UNKNOWN"
+)
+
 // ReconcileServer ensures the cluster server exist, creating one if it doesn't.
 func (s *Service) ReconcileServer(ctx context.Context, ms *scope.Machine) (requeue bool, retErr error) {
 	log := s.logger.WithName("ReconcileServer")
@@ -48,25 +76,54 @@ func (s *Service) ReconcileServer(ctx context.Context, ms *scope.Machine) (reque
 			// Secret not available yet.
 			// Just log the error and resume reconciliation.
 			log.Info("Bootstrap secret not available yet", "error", err)
+			conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapDeliveredCondition,
+				infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
 			return false, nil
 		}
 		return true, fmt.Errorf("unexpected error when trying to get bootstrap secret: %w", err)
 	}
 
+	if ms.IonosMachine.Spec.ImportExisting != "" && ms.ServerID() == "" {
+		// The existing server is adopted instead of creating a new one.
+		return s.importServer(ctx, ms)
+	}
+
 	server, request, err := scopedFindResource(ctx, ms, s.getServer, s.getLatestServerCreationRequest)
 	if err != nil {
 		return false, err
 	}
 	if request != nil && request.isPending() {
-		log.Info("Request is pending", "location", request.location)
+		log.Info("Request is pending", "location", request.location, "requestID", request.id())
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerCreatedCondition,
+			infrav1.CreatingServerReason, clusterv1.ConditionSeverityInfo,
+			"waiting for request %s, which is in state %s", request.id(), request.status)
 		return true, nil
 	}
 
 	if server == nil {
 		// Server does not exist yet, create it
 		log.V(4).Info("No server was found. Creating new server")
+		message := ""
+		if request != nil && request.status == sdk.RequestStatusFailed {
+			message = fmt.Sprintf("retrying after request %s failed", request.id())
+		}
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerCreatedCondition,
+			infrav1.CreatingServerReason, clusterv1.ConditionSeverityInfo, "%s", message)
+		if ms.ServerID() == "" {
+			conditions.MarkFalse(ms.IonosMachine, infrav1.ProviderIDSetCondition,
+				infrav1.CreatingServerReason, clusterv1.ConditionSeverityInfo, "")
+		}
 		if err := s.createServer(ctx, secret, ms); err != nil {
-			return false, err
+			if !ionoserrors.IsTerminal(err) {
+				return false, err
+			}
+			// The Cloud API rejected the server as specified, so creating it again would fail as well.
+			log.Error(err, "Server creation was rejected")
+			message := "server creation was rejected: " + ionoserrors.Message(err)
+			conditions.MarkFalse(ms.IonosMachine, infrav1.ServerCreatedCondition,
+				infrav1.ServerCreationRejectedReason, clusterv1.ConditionSeverityError, "%s", message)
+			ms.SetFailure(capierrors.CreateMachineError, message)
+			return true, nil
 		}
 		log.V(4).Info("Successfully initiated server creation")
 		// If we reach this point, we want to requeue as the request is not processed yet,
@@ -74,24 +131,52 @@ func (s *Service) ReconcileServer(ctx context.Context, ms *scope.Machine) (reque
 		return true, nil
 	}
 
+	if ms.IonosMachine.Status.BootstrapRedelivery == nil {
+		// The bootstrap data is part of the server creation request. Imported servers were bootstrapped before.
+		conditions.MarkTrue(ms.IonosMachine, infrav1.BootstrapDeliveredCondition)
+		recordProvisioningPhase(ms, phaseBootstrapDelivered)
+	}
+	markServerCreated(ms.IonosMachine, server)
+
+	ms.IonosMachine.Status.InstanceState = getVMState(server)
+	if !s.reconcileInstanceHealth(ms, server) {
+		// The machine has failed and needs to be remediated.
+		return true, nil
+	}
+
+	requeue, err = s.reconcileBootstrapRedelivery(ctx, ms, secret, server)
+	if requeue || err != nil {
+		return requeue, err
+	}
+
 	requeue, err = s.ensureServerAvailable(ctx, ms, server)
 	if requeue || err != nil {
 		return requeue, err
 	}
+	recordProvisioningPhase(ms, phaseServerAvailable)
 
-	// Attach the IPs from all NICs of the server to the status
-	netInfo := &infrav1.MachineNetworkInfo{NICInfo: make([]infrav1.NICInfo, 0)}
+	requeue, err = s.reconcileReboot(ctx, ms, server)
+	if requeue || err != nil {
+		return requeue, err
+	}
 
-	for _, nic := range ptr.Deref(server.GetEntities().GetNics().GetItems(), []sdk.Nic{}) {
-		netInfo.NICInfo = append(netInfo.NICInfo, infrav1.NICInfo{
-			IPv4Addresses: ptr.Deref(nic.GetProperties().GetIps(), []string{}),
-			IPv6Addresses: ptr.Deref(nic.GetProperties().GetIpv6Ips(), []string{}),
-			NetworkID:     ptr.Deref(nic.GetProperties().GetLan(), 0),
-			Primary:       s.isPrimaryNIC(ms.IonosMachine, &nic),
-		})
+	requeue, err = s.reconcileServerResources(ctx, ms, server)
+	if requeue || err != nil {
+		return requeue, err
+	}
+
+	requeue, err = s.reconcileCDROMEjection(ctx, ms, server)
+	if requeue || err != nil {
+		return requeue, err
 	}
 
-	ms.IonosMachine.Status.MachineNetworkInfo = netInfo
+	// Attach the IPs from all NICs of the server to the status
+	oldStatus := ms.IonosMachine.Status.DeepCopy()
+	ms.SetMachineNetworkInfo(s.machineNetworkInfo(ms, server))
+	ms.IonosMachine.Status.Volumes = s.volumeInfo(ms.IonosMachine, server)
+	s.recordServerStatusEvents(ms, oldStatus)
+	markVolumesReady(ms.IonosMachine, server)
+	markNICsConfigured(ms.IonosMachine)
 
 	log.Info("Server is available", "serverID", ptr.Deref(server.GetId(), ""))
 	// server exists and is available.
@@ -109,12 +194,19 @@ func (s *Service) ReconcileServerDeletion(ctx context.Context, ms *scope.Machine
 
 	if request != nil && request.isPending() {
 		ms.IonosMachine.SetCurrentRequest(http.MethodPost, request.status, request.location)
-		log.Info("Creation request is pending", "location", request.location)
+		log.Info("Creation request is pending", "location", request.location, "requestID", request.id())
 		return true, nil
 	}
 
 	if server == nil {
 		ms.IonosMachine.DeleteCurrentRequest()
+		if requeue, err := s.deleteBootstrapImages(ctx, ms); err != nil || requeue {
+			return requeue, err
+		}
+		if err := s.deleteBootstrapData(ctx, ms); err != nil {
+			return false, err
+		}
+		conditions.MarkTrue(ms.IonosMachine, infrav1.ServerDeletedCondition)
 		return false, nil
 	}
 
@@ -128,7 +220,7 @@ func (s *Service) ReconcileServerDeletion(ctx context.Context, ms *scope.Machine
 			ms.IonosMachine.SetCurrentRequest(http.MethodDelete, request.status, request.location)
 
 			// We want to requeue and check again after some time
-			log.Info("Deletion request is pending", "location", request.location)
+			log.Info("Deletion request is pending", "location", request.location, "requestID", request.id())
 			return true, nil
 		}
 
@@ -138,14 +230,25 @@ func (s *Service) ReconcileServerDeletion(ctx context.Context, ms *scope.Machine
 		}
 	}
 
+	if requeue, err := s.shutdownServer(ctx, ms, server); err != nil || requeue {
+		return requeue, err
+	}
+
 	err = s.deleteServer(ctx, ms, server)
 	return err == nil, err
 }
 
 // FinalizeMachineProvisioning marks the machine as provisioned.
-func (*Service) FinalizeMachineProvisioning(_ context.Context, ms *scope.Machine) (bool, error) {
+func (s *Service) FinalizeMachineProvisioning(_ context.Context, ms *scope.Machine) (bool, error) {
+	markServerInSync(ms)
+	if !ms.IonosMachine.Status.Ready {
+		s.recordEvent(ms.IonosMachine, serverProvisionedReason, "Server %s is provisioned",
+			ms.ServerID())
+	}
 	ms.IonosMachine.Status.Ready = true
-	conditions.MarkTrue(ms.IonosMachine, infrav1.MachineProvisionedCondition)
+	if conditions.IsTrue(ms.Machine, clusterv1.MachineNodeHealthyCondition) {
+		recordProvisioningPhase(ms, phaseNodeReady)
+	}
 	return false, nil
 }
 
@@ -169,8 +272,16 @@ func (s *Service) ensureServerAvailable(ctx context.Context, ms *scope.Machine,
 		return true, nil
 	}
 
+	if ms.IonosMachine.Spec.DesiredPowerState == infrav1.PowerStateStopped {
+		return s.ensureServerStopped(ctx, ms, server)
+	}
+
 	// Check the VM state; if not running, try to start it
 	if vmState := getVMState(server); !isRunning(vmState) {
+		if conditions.GetReason(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition) == infrav1.RebootRequiredReason {
+			// The pending changes of cores and memory take effect when the server is started.
+			conditions.MarkTrue(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition)
+		}
 		err := s.startServer(ctx, ms, *server.Id)
 		if err != nil {
 			log.Error(err, "Failed to start the server")
@@ -185,6 +296,237 @@ func (s *Service) ensureServerAvailable(ctx context.Context, ms *scope.Machine,
 	return false, nil
 }
 
+// reconcileReboot reboots the server, if the machine has the reboot annotation, and removes the annotation
+// afterward. The annotation is ignored for machines, which should be stopped.
+func (s *Service) reconcileReboot(ctx context.Context, ms *scope.Machine, server *sdk.Server) (bool, error) {
+	log := s.logger.WithName("reconcileReboot")
+
+	if ms.IonosMachine.Annotations[infrav1.RebootAnnotation] != "true" {
+		return false, nil
+	}
+	if ms.IonosMachine.Spec.DesiredPowerState == infrav1.PowerStateStopped {
+		log.Info("Ignoring reboot annotation of a stopped machine")
+		delete(ms.IonosMachine.Annotations, infrav1.RebootAnnotation)
+		return false, nil
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	log.V(4).Info("Rebooting server", "serverID", serverID)
+	requestLocation, err := s.ionosClient.RebootServer(ctx, ms.DatacenterID(), serverID)
+	if err != nil {
+		return false, fmt.Errorf("failed to request server reboot: %w", err)
+	}
+
+	log.Info("Successfully requested for server reboot", "location", requestLocation)
+	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, serverRebootRequestedReason, "Requested reboot of server %s", serverID)
+	delete(ms.IonosMachine.Annotations, infrav1.RebootAnnotation)
+	return true, nil
+}
+
+// reconcileInstanceHealth updates the InstanceHealthy condition from the VM state of the server and
+// returns false if the machine has failed. A crashed server marks the machine as failed, so that
+// a MachineHealthCheck can remediate it without waiting for the node to become unhealthy.
+// A server, which was shut off although it is supposed to run, is reported and restarted.
+func (s *Service) reconcileInstanceHealth(ms *scope.Machine, server *sdk.Server) bool {
+	log := s.logger.WithName("reconcileInstanceHealth")
+
+	vmState := getVMState(server)
+	switch {
+	case isCrashed(vmState):
+		serverID := ptr.Deref(server.GetId(), "")
+		log.Info("Server has crashed", "serverID", serverID)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.InstanceHealthyCondition,
+			infrav1.InstanceCrashedReason, clusterv1.ConditionSeverityError, "server has crashed")
+		ms.SetFailure(capierrors.UpdateMachineError, fmt.Sprintf("server %s has crashed", serverID))
+		return false
+	case isShutOff(vmState) && s.isUnexpectedShutOff(ms):
+		log.Info("Server was shut off unexpectedly", "serverID", ptr.Deref(server.GetId(), ""))
+		conditions.MarkFalse(ms.IonosMachine, infrav1.InstanceHealthyCondition,
+			infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityWarning, "server was shut off unexpectedly")
+	case isRunning(vmState) || isShutOff(vmState):
+		conditions.MarkTrue(ms.IonosMachine, infrav1.InstanceHealthyCondition)
+	}
+	return true
+}
+
+// isUnexpectedShutOff returns true if the server of a provisioned machine is not supposed to be shut off.
+// Servers are expected to be shut off if they should be stopped, need to be restarted to apply
+// updated resources or while their bootstrap data is re-delivered.
+func (*Service) isUnexpectedShutOff(ms *scope.Machine) bool {
+	return ms.IonosMachine.Status.Ready &&
+		ms.IonosMachine.Spec.DesiredPowerState != infrav1.PowerStateStopped &&
+		ms.IonosMachine.Status.BootstrapRedelivery == nil &&
+		conditions.GetReason(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition) != infrav1.RebootRequiredReason
+}
+
+// reconcileServerResources updates the number of cores and the memory size of the server in place,
+// if they differ from the spec of the machine. If the changes cannot be hot-plugged into the running server,
+// the ServerResourcesUpdated condition indicates that the server needs to be restarted.
+func (s *Service) reconcileServerResources(ctx context.Context, ms *scope.Machine, server *sdk.Server) (bool, error) {
+	log := s.logger.WithName("reconcileServerResources")
+
+	spec := ms.IonosMachine.Spec
+	cores, ram := server.GetProperties().GetCores(), server.GetProperties().GetRam()
+	if spec.Type == infrav1.ServerTypeCube || cores == nil || ram == nil {
+		// The resources of CUBE servers are defined by their template and cannot be changed.
+		return false, nil
+	}
+
+	if *cores == spec.NumCores && *ram == spec.MemoryMB {
+		if conditions.GetReason(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition) != infrav1.RebootRequiredReason {
+			conditions.MarkTrue(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition)
+		}
+		return false, nil
+	}
+
+	if reportsDrift(ms) {
+		if *cores != spec.NumCores {
+			s.reportDrift(ms, "server has %d cores instead of %d", *cores, spec.NumCores)
+		}
+		if *ram != spec.MemoryMB {
+			s.reportDrift(ms, "server has %d MB of memory instead of %d MB", *ram, spec.MemoryMB)
+		}
+		return false, nil
+	}
+
+	var (
+		properties sdk.ServerProperties
+		hotPlug    = true
+		bootVolume = s.bootVolume(ms.IonosMachine, server)
+	)
+	if *cores != spec.NumCores {
+		properties.Cores = &spec.NumCores
+		hotPlug = hotPlug && spec.NumCores > *cores &&
+			ptr.Deref(bootVolume.GetProperties().GetCpuHotPlug(), false)
+	}
+	if *ram != spec.MemoryMB {
+		properties.Ram = &spec.MemoryMB
+		hotPlug = hotPlug && spec.MemoryMB > *ram &&
+			ptr.Deref(bootVolume.GetProperties().GetRamHotPlug(), false)
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	log.V(4).Info("Updating server resources", "serverID", serverID, "cores", spec.NumCores, "memoryMB", spec.MemoryMB)
+	requestLocation, err := s.ionosClient.PatchServer(ctx, ms.DatacenterID(), serverID, properties)
+	if err != nil {
+		return false, fmt.Errorf("failed to request server update: %w", err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodPatch, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, serverUpdateRequestedReason,
+		"Requested update of server %s to %d cores and %d MB of memory", serverID, spec.NumCores, spec.MemoryMB)
+	if !hotPlug && isPoweredOn(getVMState(server)) {
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition,
+			infrav1.RebootRequiredReason, clusterv1.ConditionSeverityWarning,
+			"server needs to be restarted to apply %d cores and %d MB of memory", spec.NumCores, spec.MemoryMB)
+	} else {
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerResourcesUpdatedCondition,
+			infrav1.ResizingReason, clusterv1.ConditionSeverityInfo, "")
+	}
+
+	log.Info("Successfully requested for server update", "location", requestLocation)
+	return true, nil
+}
+
+// bootVolume returns the boot volume of the server, if it is attached.
+func (s *Service) bootVolume(m *infrav1.IonosCloudMachine, server *sdk.Server) *sdk.Volume {
+	bootVolumeID := s.bootVolumeID(m, server)
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		if bootVolumeID != "" && ptr.Deref(volume.GetId(), "") == bootVolumeID {
+			return &volume
+		}
+	}
+	return nil
+}
+
+// reconcileCDROMEjection ejects the CD-ROM of the machine, once its node has joined the cluster, if the
+// CD-ROM should be ejected after the first boot. Before, the server is switched to boot from its boot volume.
+func (s *Service) reconcileCDROMEjection(ctx context.Context, ms *scope.Machine, server *sdk.Server) (bool, error) {
+	log := s.logger.WithName("reconcileCDROMEjection")
+
+	cdrom := ms.IonosMachine.Spec.CDROM
+	if cdrom == nil || !cdrom.EjectAfterFirstBoot || ms.Machine.Status.NodeRef == nil {
+		return false, nil
+	}
+
+	var imageID string
+	for _, image := range ptr.Deref(server.GetEntities().GetCdroms().GetItems(), nil) {
+		if ptr.Deref(image.GetId(), "") == cdrom.ID ||
+			(cdrom.Private != nil && matchesPrivateImageReference(&image, cdrom.Private)) {
+			imageID = ptr.Deref(image.GetId(), "")
+			break
+		}
+	}
+	if imageID == "" {
+		// The CD-ROM has already been ejected.
+		return false, nil
+	}
+
+	serverID := ptr.Deref(server.GetId(), "")
+	if ptr.Deref(server.GetProperties().GetBootCdrom().GetId(), "") == imageID {
+		bootVolumeID := s.bootVolumeID(ms.IonosMachine, server)
+		properties := sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: &bootVolumeID}}
+		requestLocation, err := s.ionosClient.PatchServer(ctx, ms.DatacenterID(), serverID, properties)
+		if err != nil {
+			return false, fmt.Errorf("failed to request boot from volume %s: %w", bootVolumeID, err)
+		}
+
+		ms.IonosMachine.SetCurrentRequest(http.MethodPatch, sdk.RequestStatusQueued, requestLocation)
+		s.recordEvent(ms.IonosMachine, serverUpdateRequestedReason,
+			"Requested boot of server %s from volume %s", serverID, bootVolumeID)
+		log.V(4).Info("Successfully requested boot from volume", "volumeID", bootVolumeID, "location", requestLocation)
+		return true, nil
+	}
+
+	requestLocation, err := s.ionosClient.DetachCDROM(ctx, ms.DatacenterID(), serverID, imageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to request CD-ROM ejection: %w", err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, cdromEjectionRequestedReason,
+		"Requested ejection of CD-ROM %s from server %s", imageID, serverID)
+	log.V(4).Info("Successfully requested CD-ROM ejection", "imageID", imageID, "location", requestLocation)
+	return true, nil
+}
+
+// bootVolumeID returns the ID of the boot volume of the server. While the server boots from a CD-ROM,
+// the boot volume is identified by its name.
+func (s *Service) bootVolumeID(m *infrav1.IonosCloudMachine, server *sdk.Server) string {
+	if id := ptr.Deref(server.GetProperties().GetBootVolume().GetId(), ""); id != "" {
+		return id
+	}
+	name := s.volumeName(m)
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		if ptr.Deref(volume.GetProperties().GetName(), "") == name {
+			return ptr.Deref(volume.GetId(), "")
+		}
+	}
+	return ""
+}
+
+// ensureServerStopped shuts down the specified server, if it is still running.
+func (s *Service) ensureServerStopped(ctx context.Context, ms *scope.Machine, server *sdk.Server) (bool, error) {
+	log := s.logger.WithName("ensureServerStopped")
+
+	vmState := getVMState(server)
+	if isRunning(vmState) {
+		if err := s.stopServer(ctx, ms, *server.Id); err != nil {
+			log.Error(err, "Failed to stop the server")
+			return true, err
+		}
+		return true, nil
+	}
+
+	if isPoweredOn(vmState) {
+		log.Info("Waiting for server to shut down", "vmState", vmState)
+		return true, nil
+	}
+
+	return false, nil
+}
+
 // getServerByServerID checks if the IonosCloudMachine has a provider ID set.
 // If it does, it will attempt to extract the server ID from the provider ID and
 // query for the server in the cloud.
@@ -208,12 +550,18 @@ func (s *Service) getServerByServerID(ctx context.Context, datacenterID, serverI
 }
 
 func (s *Service) getServer(ctx context.Context, ms *scope.Machine) (*sdk.Server, error) {
-	server, err := s.getServerByServerID(ctx, ms.DatacenterID(), ms.IonosMachine.ExtractServerID())
+	server, err := s.getServerByServerID(ctx, ms.DatacenterID(), ms.ServerID())
 	// if the server was not found, we try to find it by listing all servers.
 	if server != nil || ignoreNotFound(err) != nil {
 		return server, err
 	}
 
+	// The hostname is deterministic, so that a server, whose creation was requested before its ID
+	// could be persisted, is found by the same name again.
+	if err := renderHostname(ms); err != nil {
+		return nil, err
+	}
+
 	// listing requires one more level of depth to for instance
 	// retrieving the NIC properties.
 	const listDepth = 3
@@ -224,14 +572,9 @@ func (s *Service) getServer(ctx context.Context, ms *scope.Machine) (*sdk.Server
 		return nil, fmt.Errorf("failed to list servers in data center %s: %w", ms.DatacenterID(), listErr)
 	}
 
-	items := ptr.Deref(serverList.Items, []sdk.Server{})
-	// find servers with the expected name
-	for _, server := range items {
-		if server.HasProperties() && *server.Properties.Name == ms.IonosMachine.Name {
-			// if the server was found, we set the provider ID and return it
-			ms.SetProviderID(ptr.Deref(server.Id, ""))
-			return &server, nil
-		}
+	server, adoptErr := s.adoptServer(ctx, ms, ptr.Deref(serverList.Items, []sdk.Server{}))
+	if server != nil || adoptErr != nil {
+		return server, adoptErr
 	}
 
 	// if we still can't find a server we return the potential
@@ -239,28 +582,145 @@ func (s *Service) getServer(ctx context.Context, ms *scope.Machine) (*sdk.Server
 	return nil, err
 }
 
+// adoptServer returns the server with the hostname of the machine and sets the provider ID of the machine to it.
+// If the creation was requested multiple times, e.g. because the controller was restarted before the ID of the
+// server was persisted, the oldest server is adopted, so that the choice doesn't change between reconciliations.
+// Servers, whose labels belong to another machine, e.g. of a cluster in another namespace, are never adopted.
+// If no server has the hostname of the machine, nil is returned.
+func (s *Service) adoptServer(ctx context.Context, ms *scope.Machine, servers []sdk.Server) (*sdk.Server, error) {
+	var candidates []*sdk.Server
+	for i := range servers {
+		server := &servers[i]
+		if server.HasProperties() && ptr.Deref(server.GetProperties().GetName(), "") == ms.Hostname() {
+			candidates = append(candidates, server)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b *sdk.Server) int {
+		switch {
+		case createdBefore(a, b):
+			return -1
+		case createdBefore(b, a):
+			return 1
+		}
+		return 0
+	})
+
+	for _, server := range candidates {
+		serverID := ptr.Deref(server.GetId(), "")
+		labels, err := currentLabels(ctx, s.serverLabelOperations(ms.DatacenterID(), serverID))
+		if err != nil {
+			return nil, fmt.Errorf("could not get labels of server %s: %w", serverID, err)
+		}
+		if ownedByOtherMachine(ms, labels) {
+			s.logger.Info("Not adopting server with the hostname of the machine, which belongs to another machine",
+				"serverID", serverID, "machine", labels[machineNameLabelKey],
+				"cluster", labels[clusterNamespaceLabelKey]+"/"+labels[clusterNameLabelKey])
+			continue
+		}
+
+		if serverID != ms.ServerID() {
+			s.logger.Info("Adopting server with the hostname of the machine",
+				"serverID", serverID, "hostname", ms.Hostname())
+			s.recordEvent(ms.IonosMachine, serverAdoptedReason,
+				"Adopted server %s with hostname %s", serverID, ms.Hostname())
+		}
+		ms.SetProviderID(serverID)
+		return server, nil
+	}
+	return nil, nil
+}
+
+// createdBefore returns whether server a was created before server b. Servers without a creation date are
+// considered to be created last, with their ID as tie-breaker.
+func createdBefore(a, b *sdk.Server) bool {
+	createdA, createdB := a.GetMetadata().GetCreatedDate(), b.GetMetadata().GetCreatedDate()
+	switch {
+	case createdA != nil && createdB != nil && !createdA.Equal(*createdB):
+		return createdA.Before(*createdB)
+	case createdA != nil && createdB == nil:
+		return true
+	case createdA == nil && createdB != nil:
+		return false
+	}
+	return ptr.Deref(a.GetId(), "") < ptr.Deref(b.GetId(), "")
+}
+
 func (s *Service) deleteServer(ctx context.Context, ms *scope.Machine, server *sdk.Server) error {
 	log := s.logger.WithName("deleteServer")
 
 	serverID := ptr.Deref(server.GetId(), "")
 
+	if ms.IonosMachine.Spec.VolumeDeletionPolicy == infrav1.VolumeDeletionPolicyRetain {
+		// Retained volumes are detached one at a time before the server is deleted,
+		// so that they are not deleted together with it.
+		if volumeIDs := s.machineVolumeIDs(ms, server); len(volumeIDs) > 0 {
+			return s.retainVolume(ctx, ms, serverID, volumeIDs[0])
+		}
+	}
+
+	// Additional volumes, which are retained individually, are detached as well, so that they are kept
+	// even if the volumes are deleted together with the server.
+	if volumeID := s.findRetainedVolumeID(ms, server); volumeID != "" {
+		return s.retainVolume(ctx, ms, serverID, volumeID)
+	}
+
 	deleteVolumes := ms.ClusterScope.IsDeleted()
-	bootVolumeID := server.GetProperties().GetBootVolume().GetId()
-	if !deleteVolumes && bootVolumeID != nil {
+	bootVolumeID := s.bootVolumeID(ms.IonosMachine, server)
+	if !deleteVolumes && bootVolumeID != "" {
 		// We need to make sure to only delete volumes if the cluster is being deleted.
 		// If a node is being replaced, we only delete the boot volume and keep all other volumes.
 		// The CSI will take care of re-attaching the existing volumes to the new node.
 
-		requestLocation, err := s.ionosClient.DeleteVolume(ctx, ms.DatacenterID(), *bootVolumeID)
+		requestLocation, err := s.ionosClient.DeleteVolume(ctx, ms.DatacenterID(), bootVolumeID)
 		if err != nil {
 			return fmt.Errorf("failed to request boot volume deletion: %w", err)
 		}
 
 		ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+		s.recordEvent(ms.IonosMachine, volumeDeletionRequestedReason, "Requested deletion of boot volume %s", bootVolumeID)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+			infrav1.DeletingVolumesReason, clusterv1.ConditionSeverityInfo, "")
 		log.V(4).Info("Successfully requested for boot volume deletion", "location", requestLocation)
 		return nil
 	}
 
+	if !deleteVolumes {
+		// Additional volumes were created together with the server and belong to the machine.
+		// They are removed one at a time, as each deletion results in a separate request.
+		if volumeID := s.findAdditionalVolumeID(ms, server); volumeID != "" {
+			requestLocation, err := s.ionosClient.DeleteVolume(ctx, ms.DatacenterID(), volumeID)
+			if err != nil {
+				return fmt.Errorf("failed to request volume deletion: %w", err)
+			}
+
+			ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+			s.recordEvent(ms.IonosMachine, volumeDeletionRequestedReason, "Requested deletion of volume %s", volumeID)
+			conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+				infrav1.DeletingVolumesReason, clusterv1.ConditionSeverityInfo, "")
+			log.V(4).Info("Successfully requested for volume deletion", "volumeID", volumeID, "location", requestLocation)
+			return nil
+		}
+
+		// All remaining volumes don't belong to the machine, e.g. because they were created by the CSI driver.
+		// They are detached one at a time before deleting the server, so that the deletion of the server
+		// doesn't fail or leave them in an inconsistent state.
+		if volumes := ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}); len(volumes) > 0 {
+			volumeID := ptr.Deref(volumes[0].GetId(), "")
+			requestLocation, err := s.ionosClient.DetachVolume(ctx, ms.DatacenterID(), serverID, volumeID)
+			if err != nil {
+				return fmt.Errorf("failed to request volume detachment: %w", err)
+			}
+
+			ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+			s.recordEvent(ms.IonosMachine, volumeDetachmentRequestedReason,
+				"Requested detachment of volume %s from server %s", volumeID, serverID)
+			conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+				infrav1.DetachingVolumesReason, clusterv1.ConditionSeverityInfo, "")
+			log.V(4).Info("Successfully requested for volume detachment", "volumeID", volumeID, "location", requestLocation)
+			return nil
+		}
+	}
+
 	log.V(4).Info("Deleting server", "serverID", serverID, "deleteVolumes", deleteVolumes)
 	requestLocation, err := s.ionosClient.DeleteServer(ctx, ms.DatacenterID(), serverID, deleteVolumes)
 	if err != nil {
@@ -269,6 +729,9 @@ func (s *Service) deleteServer(ctx context.Context, ms *scope.Machine, server *s
 
 	log.Info("Successfully requested for server deletion", "location", requestLocation)
 	ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, serverDeletionRequestedReason, "Requested deletion of server %s", serverID)
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+		clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
 
 	log.V(4).Info("Done deleting server")
 	return nil
@@ -285,17 +748,79 @@ func (s *Service) startServer(ctx context.Context, ms *scope.Machine, serverID s
 
 	log.Info("Successfully requested for server start", "location", requestLocation)
 	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, serverStartRequestedReason, "Requested start of server %s", serverID)
+
+	return nil
+}
+
+func (s *Service) stopServer(ctx context.Context, ms *scope.Machine, serverID string) error {
+	log := s.logger.WithName("stopServer")
+
+	log.V(4).Info("Stopping server", "serverID", serverID)
+	requestLocation, err := s.ionosClient.StopServer(ctx, ms.DatacenterID(), serverID)
+	if err != nil {
+		return fmt.Errorf("failed to request server stop: %w", err)
+	}
+
+	log.Info("Successfully requested for server stop", "location", requestLocation)
+	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, serverStopRequestedReason, "Requested stop of server %s", serverID)
 
 	return nil
 }
 
+// shutdownServer requests the server to shut down and waits for it to power off, so that workloads and
+// the operating system can flush their data before the server is deleted. Once the shutdown timeout
+// has passed, the server is deleted regardless of its state.
+func (s *Service) shutdownServer(ctx context.Context, ms *scope.Machine, server *sdk.Server) (requeue bool, err error) {
+	log := s.logger.WithName("shutdownServer")
+
+	timeout := shutdownTimeout(ms.IonosMachine)
+	vmState := getVMState(server)
+	if timeout == 0 || !isPoweredOn(vmState) {
+		return false, nil
+	}
+
+	shutdownRequested := ms.IonosMachine.Status.ShutdownRequestedAt
+	if shutdownRequested == nil {
+		serverID := ptr.Deref(server.GetId(), "")
+		requestLocation, err := s.ionosClient.StopServer(ctx, ms.DatacenterID(), serverID)
+		if err != nil {
+			return false, fmt.Errorf("failed to request server shutdown: %w", err)
+		}
+
+		s.recordEvent(ms.IonosMachine, serverStopRequestedReason, "Requested shutdown of server %s", serverID)
+		ms.IonosMachine.Status.ShutdownRequestedAt = ptr.To(metav1.Now())
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+			infrav1.ShuttingDownReason, clusterv1.ConditionSeverityInfo, "")
+		log.Info("Successfully requested for server shutdown", "location", requestLocation)
+		return true, nil
+	}
+
+	if time.Since(shutdownRequested.Time) >= timeout {
+		log.Info("Server did not shut down in time, deleting it anyway", "timeout", timeout, "vmState", vmState)
+		return false, nil
+	}
+
+	log.Info("Waiting for server to shut down", "vmState", vmState)
+	return true, nil
+}
+
+// shutdownTimeout returns the time to wait for the server of the machine to shut down.
+func shutdownTimeout(m *infrav1.IonosCloudMachine) time.Duration {
+	if m.Spec.ShutdownTimeout == nil {
+		return defaultShutdownTimeout
+	}
+	return m.Spec.ShutdownTimeout.Duration
+}
+
 func (s *Service) getLatestServerCreationRequest(ctx context.Context, ms *scope.Machine) (*requestInfo, error) {
 	return getMatchingRequest(
 		ctx,
 		s,
 		http.MethodPost,
 		path.Join("datacenters", ms.DatacenterID(), "servers"),
-		matchByName[*sdk.Server, *sdk.ServerProperties](ms.IonosMachine.Name),
+		matchByName[*sdk.Server, *sdk.ServerProperties](ms.Hostname()),
 	)
 }
 
@@ -310,7 +835,7 @@ func (s *Service) getLatestServerDeletionRequest(
 	)
 }
 
-func (s *Service) createServer(ctx context.Context, secret *corev1.Secret, ms *scope.Machine) error {
+func (s *Service) createServer(ctx context.Context, secret *corev1.Secret, ms *scope.Machine) (retErr error) {
 	log := s.logger.WithName("createServer")
 
 	bootstrapData, exists := secret.Data["value"]
@@ -318,6 +843,10 @@ func (s *Service) createServer(ctx context.Context, secret *corev1.Secret, ms *s
 		return errors.New("unable to obtain bootstrap data from secret")
 	}
 
+	if err := renderHostname(ms); err != nil {
+		return err
+	}
+
 	lan, err := s.getLAN(ctx, ms)
 	if err != nil {
 		return err
@@ -328,18 +857,172 @@ func (s *Service) createServer(ctx context.Context, secret *corev1.Secret, ms *s
 		return fmt.Errorf("unable to parse LAN ID: %w", err)
 	}
 
-	renderedData := s.renderUserData(ms, string(bootstrapData))
 	copySpec := ms.IonosMachine.Spec.DeepCopy()
+	if err := s.resolveNetworkIDs(ctx, ms, copySpec.AdditionalNetworks); err != nil {
+		return err
+	}
+
+	for _, network := range copySpec.AdditionalNetworks {
+		if int64(network.NetworkID) == lanID {
+			return fmt.Errorf("additional network %d must not be the cluster LAN", network.NetworkID)
+		}
+	}
+
+	additionalUserData, err := s.getAdditionalUserData(ctx, ms)
+	if err != nil {
+		return err
+	}
+
+	format := getBootstrapDataFormat(secret)
+	renderedData, err := s.renderUserData(ms, string(bootstrapData), format, additionalUserData...)
+	if err != nil {
+		return err
+	}
+
+	// The bootstrap storage delivers bootstrap data of any size, so no bootstrap image is needed.
+	// Talos doesn't run cloud-init, but reads its machine config from the NoCloud data source
+	// of the bootstrap image.
+	storage := s.bootstrapStorage(ms)
+	if format == bootstrapDataFormatTalos {
+		storage = nil
+	}
+	if storage == nil && format == bootstrapDataFormatCloudConfig {
+		if renderedData, err = s.compressUserData(ms, renderedData); err != nil {
+			return err
+		}
+	}
+	bootstrapImage := format == bootstrapDataFormatTalos || (storage == nil && useBootstrapImage(renderedData))
+	if bootstrapImage && format == bootstrapDataFormatIgnition {
+		// The server is created, once the bootstrap data can be delivered.
+		message := fmt.Sprintf("user data of %d bytes exceeds the limit of %d bytes, which is only supported "+
+			"with Ignition if a bootstrap storage is configured", len(renderedData), maxUserDataSize)
+		log.Info("Postponing server creation", "reason", message)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapDeliveredCondition,
+			infrav1.UserDataTooLargeReason, clusterv1.ConditionSeverityWarning, "%s", message)
+		s.recordWarningEvent(ms.IonosMachine, infrav1.UserDataTooLargeReason, "%s", message)
+		return nil
+	}
+
+	if copySpec.Type == infrav1.ServerTypeCube {
+		templateID, err := s.getTemplateID(ctx, copySpec.Template)
+		if err != nil {
+			return err
+		}
+		copySpec.Template = &infrav1.ServerTemplate{ID: templateID}
+	}
+	resolveCPUFamily := copySpec.Type != infrav1.ServerTypeCube && ptr.Deref(copySpec.CPUFamily, "") != ""
+	var (
+		bootstrapImageID string
+		cdromImage       *sdk.Image
+	)
+	snapshot := copySpec.Disk.Image != nil && copySpec.Disk.Image.Snapshot != nil
+	privateImage := copySpec.Disk.Image != nil && copySpec.Disk.Image.Private != nil
+	if (snapshot || privateImage) && len(copySpec.SSHKeys) > 0 {
+		log.Info("Ignoring SSH keys, which can only be injected into volumes created from a public image")
+		copySpec.SSHKeys = nil
+	}
+	if resolveCPUFamily || snapshot || privateImage || bootstrapImage || copySpec.CDROM != nil {
+		datacenter, err := s.getDatacenterByID(ctx, ms.DatacenterID())
+		if err != nil {
+			return fmt.Errorf("could not get data center %s: %w", ms.DatacenterID(), err)
+		}
+		defer func() {
+			// The lookups and the server creation depend on the data center, which might have changed since
+			// it was cached, e.g. if it offers other CPU families now.
+			if retErr != nil {
+				s.datacenterCache.Invalidate(ms.DatacenterID())
+			}
+		}()
+		if resolveCPUFamily {
+			copySpec.CPUFamily = ptr.To(s.resolveCPUFamily(ms, datacenter, *copySpec.CPUFamily))
+		}
+		if snapshot {
+			if pinnedID := pinnedImageID(ms); pinnedID != "" {
+				// The snapshot, which was pinned for the template of the machine, is used instead.
+				copySpec.Disk.Image.Snapshot = &infrav1.SnapshotReference{ID: pinnedID}
+			}
+			if err := s.resolveSnapshot(ctx, datacenter, copySpec.Disk); err != nil {
+				return err
+			}
+		}
+		if privateImage {
+			ready, err := s.resolvePrivateImage(ctx, ms, datacenter, copySpec.Disk)
+			if err != nil || !ready {
+				// The server is created, once the image can be used.
+				return err
+			}
+		}
+		if copySpec.CDROM != nil {
+			if cdromImage, err = s.resolveCDROMImage(ctx, ms, datacenter, copySpec.CDROM); err != nil || cdromImage == nil {
+				// The server is created, once the image can be used.
+				return err
+			}
+		}
+		if bootstrapImage {
+			if bootstrapImageID, err = s.reconcileBootstrapImage(ctx, ms, datacenter, renderedData); err != nil {
+				return err
+			}
+			if bootstrapImageID == "" {
+				// The server is created, once the image is available.
+				conditions.MarkFalse(ms.IonosMachine, infrav1.BootstrapDeliveredCondition,
+					infrav1.BootstrapImageProcessingReason, clusterv1.ConditionSeverityInfo, "")
+				return nil
+			}
+		}
+	}
+
+	if exceeded := s.checkResourceLimits(ctx, copySpec); len(exceeded) > 0 {
+		// The server is created, once enough resources are available.
+		message := "server exceeds the resource limits of the contract: " + strings.Join(exceeded, ", ")
+		log.Info("Postponing server creation", "reason", message)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerCreatedCondition,
+			infrav1.QuotaExceededReason, clusterv1.ConditionSeverityWarning, "%s", message)
+		s.recordWarningEvent(ms.IonosMachine, infrav1.QuotaExceededReason, "%s", message)
+		return nil
+	}
+
+	if storage != nil {
+		renderedData, err = s.uploadBootstrapData(ctx, ms, storage, renderedData, format)
+		if err != nil {
+			return err
+		}
+	}
+
 	entityParams := serverEntityParams{
-		boostrapData: renderedData,
-		machineSpec:  *copySpec,
-		lanID:        int32(lanID),
+		boostrapData:     renderedData,
+		bootstrapImageID: bootstrapImageID,
+		cdromImage:       cdromImage,
+		machineSpec:      *copySpec,
+		lanID:            int32(lanID),
+	}
+
+	if isLoadBalancerTarget(ms) {
+		entityParams.loadBalancerLANID, err = s.getLANIDByName(
+			ctx, ms.DatacenterID(), s.loadBalancerTargetLANName(ms.ClusterScope.Cluster),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isApplicationLoadBalancerTarget(ms) {
+		entityParams.loadBalancerLANID, err = s.getLANIDByName(
+			ctx, ms.DatacenterID(), s.applicationLoadBalancerTargetLANName(ms.ClusterScope.Cluster),
+		)
+		if err != nil {
+			return err
+		}
 	}
 
+	properties := s.buildServerProperties(ms, copySpec)
+	if device, _ := bootConfig(copySpec); cdromImage != nil && device == infrav1.BootDeviceCDROM {
+		// The VM boots from the attached CD-ROM, e.g. to install the operating system onto the boot volume.
+		properties.BootCdrom = &sdk.ResourceReference{Id: cdromImage.GetId()}
+	}
 	server, requestLocation, err := s.ionosClient.CreateServer(
 		ctx,
 		ms.DatacenterID(),
-		s.buildServerProperties(ms, copySpec),
+		properties,
 		s.buildServerEntities(ms, entityParams),
 	)
 	if err != nil {
@@ -350,12 +1033,18 @@ func (s *Service) createServer(ctx context.Context, secret *corev1.Secret, ms *s
 	ms.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, requestLocation)
 
 	serverID := ptr.Deref(server.GetId(), "")
+	s.recordEvent(ms.IonosMachine, serverCreationRequestedReason,
+		"Requested creation of server %s in data center %s", serverID, ms.DatacenterID())
 	if serverID == "" {
 		return errors.New("server ID is empty")
 	}
 
 	// make sure to set the provider ID
 	ms.SetProviderID(serverID)
+	recordProvisioningPhase(ms, phaseServerRequested)
+	if copySpec.Disk.Image != nil {
+		ms.IonosMachine.Status.ResolvedImageID = copySpec.Disk.Image.ID
+	}
 
 	log.V(4).Info("Done creating server")
 	return nil
@@ -368,40 +1057,402 @@ func (*Service) buildServerProperties(
 	props := sdk.ServerProperties{
 		AvailabilityZone: ptr.To(machineSpec.AvailabilityZone.String()),
 		Cores:            &machineSpec.NumCores,
-		Name:             ptr.To(ms.IonosMachine.Name),
+		Name:             ptr.To(ms.Hostname()),
 		Ram:              &machineSpec.MemoryMB,
 		CpuFamily:        machineSpec.CPUFamily,
 		Type:             ptr.To(machineSpec.Type.String()),
 	}
 
+	if machineSpec.Type == infrav1.ServerTypeCube {
+		// The resources of CUBE servers are defined by their template.
+		props.Cores = nil
+		props.Ram = nil
+		props.CpuFamily = nil
+		props.TemplateUuid = ptr.To(machineSpec.Template.ID)
+	}
+
 	return props
 }
 
-type serverEntityParams struct {
-	boostrapData string
-	machineSpec  infrav1.IonosCloudMachineSpec
-	lanID        int32
+// resolveCPUFamily returns the CPU family, which the server is created with. If the requested CPU family
+// is not offered in the location of the data center, a family of the same vendor is preferred over any
+// other available one. The substitution is reported by the CPUFamilyAvailable condition.
+func (s *Service) resolveCPUFamily(ms *scope.Machine, datacenter *sdk.Datacenter, requested string) string {
+	var available []string
+	for _, architecture := range ptr.Deref(datacenter.GetProperties().GetCpuArchitecture(), nil) {
+		if family := ptr.Deref(architecture.GetCpuFamily(), ""); family != "" {
+			available = append(available, family)
+		}
+	}
+	if len(available) == 0 || slices.Contains(available, requested) {
+		// Without any known CPU families, the Cloud API decides whether the request is valid.
+		conditions.MarkTrue(ms.IonosMachine, infrav1.CPUFamilyAvailableCondition)
+		return requested
+	}
+
+	substitute := available[0]
+	vendor, _, _ := strings.Cut(requested, "_")
+	for _, family := range available {
+		if strings.HasPrefix(family, vendor+"_") {
+			substitute = family
+			break
+		}
+	}
+
+	location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+	s.logger.Info("Requested CPU family is not available, using a substitute",
+		"requested", requested, "substitute", substitute, "location", location)
+	conditions.MarkFalse(ms.IonosMachine, infrav1.CPUFamilyAvailableCondition,
+		infrav1.CPUFamilySubstitutedReason, clusterv1.ConditionSeverityWarning,
+		"CPU family %s is not offered in location %s, using %s instead", requested, location, substitute)
+	return substitute
 }
 
-// buildServerEntities returns the server entities for the expected cloud server resource.
-func (s *Service) buildServerEntities(ms *scope.Machine, params serverEntityParams) sdk.ServerEntities {
+// resolveSnapshot replaces the snapshot reference of the boot volume with the ID of the snapshot,
+// which the volume is created from. The snapshot must be located in the location of the data center.
+// If the snapshot is larger than the boot volume, the size of the volume is increased accordingly.
+func (s *Service) resolveSnapshot(ctx context.Context, datacenter *sdk.Datacenter, disk *infrav1.Volume) error {
+	ref := disk.Image.Snapshot
+	location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+
+	var snapshot *sdk.Snapshot
+	if ref.ID != "" {
+		var err error
+		snapshot, err = s.ionosClient.GetSnapshot(ctx, ref.ID)
+		if err != nil {
+			return fmt.Errorf("could not get snapshot %s: %w", ref.ID, err)
+		}
+		if snapshotLocation := ptr.Deref(snapshot.GetProperties().GetLocation(), ""); snapshotLocation != location {
+			return fmt.Errorf("snapshot %s is located in %s, but the data center is located in %s",
+				ref.ID, snapshotLocation, location)
+		}
+	} else {
+		snapshots, err := s.apiWithDepth(1).ListSnapshots(ctx)
+		if err != nil {
+			return fmt.Errorf("could not list snapshots: %w", err)
+		}
+
+		var matches []sdk.Snapshot
+		for _, snap := range ptr.Deref(snapshots.GetItems(), nil) {
+			props := snap.GetProperties()
+			if ptr.Deref(props.GetName(), "") == ref.Name && ptr.Deref(props.GetLocation(), "") == location {
+				matches = append(matches, snap)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("snapshot %q not found in location %s", ref.Name, location)
+		case 1:
+			snapshot = &matches[0]
+		default:
+			return fmt.Errorf("found multiple snapshots with the name %s in location %s", ref.Name, location)
+		}
+	}
+
+	disk.Image = &infrav1.ImageSpec{ID: ptr.Deref(snapshot.GetId(), "")}
+	disk.SizeGB = max(disk.SizeGB, int(math.Ceil(float64(ptr.Deref(snapshot.GetProperties().GetSize(), 0)))))
+	return nil
+}
+
+// resolvePrivateImage replaces the private image reference of the boot volume with the ID of the image,
+// which the volume is created from. The image must be located in the location of the data center.
+// If an image was pinned for the template of the machine, the pinned image is used instead.
+// It returns false, if the image can't be used yet.
+func (s *Service) resolvePrivateImage(
+	ctx context.Context, ms *scope.Machine, datacenter *sdk.Datacenter, disk *infrav1.Volume,
+) (bool, error) {
+	var (
+		image *sdk.Image
+		err   error
+	)
+	if pinnedID := pinnedImageID(ms); pinnedID != "" {
+		if image, err = s.ionosClient.GetImage(ctx, pinnedID); err != nil {
+			return false, fmt.Errorf("could not get pinned image %s: %w", pinnedID, err)
+		}
+	} else {
+		location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+		if image, err = s.findPrivateImage(ctx, ms, location, disk.Image.Private, imageTypeHDD); err != nil || image == nil {
+			return false, err
+		}
+	}
+
+	disk.Image = &infrav1.ImageSpec{ID: ptr.Deref(image.GetId(), "")}
+	disk.SizeGB = max(disk.SizeGB, int(math.Ceil(float64(ptr.Deref(image.GetProperties().GetSize(), 0)))))
+	return true, nil
+}
+
+// resolveCDROMImage returns the ISO image, which is attached to the VM as CD-ROM. The image must be located
+// in the location of the data center. It returns nil, if the image can't be used yet.
+func (s *Service) resolveCDROMImage(
+	ctx context.Context, ms *scope.Machine, datacenter *sdk.Datacenter, cdrom *infrav1.CDROMSpec,
+) (*sdk.Image, error) {
+	location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+	if cdrom.Private != nil {
+		return s.findPrivateImage(ctx, ms, location, cdrom.Private, imageTypeCDROM)
+	}
+
+	image, err := s.ionosClient.GetImage(ctx, cdrom.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get CD-ROM image %s: %w", cdrom.ID, err)
+	}
+	props := image.GetProperties()
+	if imageType := ptr.Deref(props.GetImageType(), ""); imageType != imageTypeCDROM {
+		return nil, fmt.Errorf("image %s is of type %s, not %s", cdrom.ID, imageType, imageTypeCDROM)
+	}
+	if imageLocation := ptr.Deref(props.GetLocation(), ""); imageLocation != location {
+		return nil, fmt.Errorf("CD-ROM image %s is located in %s, not in %s", cdrom.ID, imageLocation, location)
+	}
+	if state := getState(image); !isAvailable(state) {
+		s.logger.WithName("resolveCDROMImage").Info("CD-ROM image is not available yet", "id", cdrom.ID, "state", state)
+		return nil, nil
+	}
+	return image, nil
+}
+
+// findPrivateImage returns the private image of the given type, which matches the reference.
+// Images with an unknown licence type can't be used, which is why their licence type is set first.
+// It returns nil, if the image can't be used yet.
+func (s *Service) findPrivateImage(
+	ctx context.Context, ms *scope.Machine, location string, ref *infrav1.PrivateImageReference, imageType string,
+) (*sdk.Image, error) {
+	log := s.logger.WithName("findPrivateImage")
+
+	images, err := s.apiWithDepth(1).ListImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list images: %w", err)
+	}
+
+	var matches []sdk.Image
+	for _, image := range ptr.Deref(images.GetItems(), nil) {
+		if isPrivateImageInLocation(&image, location, imageType) && matchesPrivateImageReference(&image, ref) {
+			matches = append(matches, image)
+		}
+	}
+
+	var image *sdk.Image
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("private image %q not found in location %s", privateImageRefName(ref), location)
+	case 1:
+		image = &matches[0]
+	default:
+		return nil, fmt.Errorf("found multiple private images %q in location %s", privateImageRefName(ref), location)
+	}
+
+	imageID := ptr.Deref(image.GetId(), "")
+	if state := getState(image); !isAvailable(state) {
+		log.Info("Private image is not available yet", "id", imageID, "state", state)
+		return nil, nil
+	}
+
+	if ptr.Deref(image.GetProperties().GetLicenceType(), "") == imageLicenceTypeUnknown {
+		licenceType := cmp.Or(ref.LicenceType, infrav1.ImageLicenceTypeLinux).String()
+		requestPath, err := s.ionosClient.PatchImage(ctx, imageID, sdk.ImageProperties{LicenceType: &licenceType})
+		if err != nil {
+			return nil, fmt.Errorf("unable to set the licence type of image %s: %w", imageID, err)
+		}
+		s.recordEvent(ms.IonosMachine, imageUpdateRequestedReason,
+			"Requested licence type %s for image %s", licenceType, imageID)
+		log.Info("Successfully requested licence type of private image",
+			"id", imageID, "licenceType", licenceType, "requestPath", requestPath)
+		return nil, nil
+	}
+	return image, nil
+}
+
+// isPrivateImageInLocation returns true, if the image is a private image of the given type in the given location.
+func isPrivateImageInLocation(image *sdk.Image, location, imageType string) bool {
+	props := image.GetProperties()
+	return !ptr.Deref(props.GetPublic(), false) &&
+		ptr.Deref(props.GetImageType(), "") == imageType &&
+		ptr.Deref(props.GetLocation(), "") == location
+}
+
+// matchesPrivateImageReference returns true, if the image has the name or the alias of the reference.
+func matchesPrivateImageReference(image *sdk.Image, ref *infrav1.PrivateImageReference) bool {
+	props := image.GetProperties()
+	if ref.Name != "" {
+		return ptr.Deref(props.GetName(), "") == ref.Name
+	}
+	return slices.Contains(ptr.Deref(props.GetImageAliases(), nil), ref.Alias)
+}
+
+func privateImageRefName(ref *infrav1.PrivateImageReference) string {
+	return cmp.Or(ref.Name, ref.Alias)
+}
+
+// getTemplateID returns the ID of the CUBE template, looking it up by its name if necessary.
+func (s *Service) getTemplateID(ctx context.Context, template *infrav1.ServerTemplate) (string, error) {
+	if template == nil {
+		return "", errors.New("a template is required for CUBE servers")
+	}
+	if template.ID != "" {
+		return template.ID, nil
+	}
+
+	templates, err := s.templateCache.Templates(ctx, s.apiWithDepth(1))
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	for _, t := range templates {
+		if ptr.Deref(t.GetProperties().GetName(), "") == template.Name {
+			ids = append(ids, ptr.Deref(t.GetId(), ""))
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("template %q not found", template.Name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found multiple templates with the name: %s", template.Name)
+	}
+}
+
+// resolveNetworkIDs sets the LAN IDs of the additional networks, which reference a cluster network by name.
+func (s *Service) resolveNetworkIDs(ctx context.Context, ms *scope.Machine, networks infrav1.Networks) error {
+	for i, network := range networks {
+		if network.Name == "" {
+			continue
+		}
+		clusterNetwork := ms.ClusterScope.Network(network.Name)
+		if clusterNetwork == nil {
+			return fmt.Errorf("additional network %s is not defined in the cluster", network.Name)
+		}
+		lanID, err := s.getNetworkLANID(ctx, ms.DatacenterID(), ms.ClusterScope.Cluster, clusterNetwork)
+		if err != nil {
+			return err
+		}
+		networks[i].NetworkID = lanID
+	}
+	return nil
+}
+
+// networkDHCP returns whether NICs in the LAN of the given cluster network use DHCP.
+// NICs in LANs, which don't belong to a cluster network, always use DHCP.
+func networkDHCP(network *infrav1.NetworkSpec) bool {
+	return network == nil || ptr.Deref(network.DHCP, true)
+}
+
+type serverEntityParams struct {
+	boostrapData string
+	// bootstrapImageID is the ID of the CD-ROM image, which delivers the bootstrap data
+	// instead of the user data of the boot volume.
+	bootstrapImageID string
+	// cdromImage is the ISO image, which is attached to the VM as CD-ROM.
+	cdromImage  *sdk.Image
+	machineSpec infrav1.IonosCloudMachineSpec
+	lanID       int32
+	// loadBalancerLANID is the ID of the load balancer target LAN. It is set for control plane machines,
+	// which should be registered as targets of the control plane load balancer, and for worker machines,
+	// which should be registered as targets of the Application Load Balancer.
+	loadBalancerLANID int32
+}
+
+// buildServerVolumes returns the boot volume and the additional volumes for the expected cloud server resource.
+func (s *Service) buildServerVolumes(ms *scope.Machine, params serverEntityParams) []sdk.Volume {
 	machineSpec := params.machineSpec
 	bootVolume := sdk.Volume{
 		Properties: &sdk.VolumeProperties{
 			AvailabilityZone: ptr.To(machineSpec.Disk.AvailabilityZone.String()),
 			Name:             ptr.To(s.volumeName(ms.IonosMachine)),
 			Size:             ptr.To(float32(machineSpec.Disk.SizeGB)),
-			Type:             ptr.To(machineSpec.Disk.DiskType.String()),
+			Type:             ptr.To(machineSpec.Disk.EffectiveDiskType().String()),
 			UserData:         &params.boostrapData,
 		},
 	}
 
-	if machineSpec.Disk.Image.ID != "" {
+	if params.bootstrapImageID != "" {
+		bootVolume.Properties.UserData = nil
+	}
+
+	if machineSpec.Type == infrav1.ServerTypeCube {
+		// CUBE servers use a directly attached storage as boot volume, whose size is defined by the template.
+		bootVolume.Properties.Size = nil
+		bootVolume.Properties.Type = ptr.To(cubeBootVolumeType)
+	}
+
+	if machineSpec.Disk.Image != nil && machineSpec.Disk.Image.ID != "" {
 		bootVolume.Properties.Image = &machineSpec.Disk.Image.ID
+		if len(machineSpec.SSHKeys) > 0 {
+			// The Cloud API only injects SSH keys into volumes, which are created from a public image.
+			bootVolume.Properties.SshKeys = &machineSpec.SSHKeys
+		}
+	} else if params.cdromImage != nil {
+		// Volumes, which are not created from an image, need a licence type.
+		// The operating system on the volume is installed from the CD-ROM.
+		bootVolume.Properties.LicenceType = params.cdromImage.GetProperties().GetLicenceType()
+	} else if machineSpec.Disk.Image == nil {
+		// The operating system is installed onto the empty volume by a VM, which boots from the network.
+		bootVolume.Properties.LicenceType = ptr.To(infrav1.ImageLicenceTypeLinux.String())
+	}
+	if machineSpec.Disk.Bus != "" {
+		bootVolume.Properties.Bus = ptr.To(machineSpec.Disk.Bus.String())
+	}
+	if machineSpec.Disk.BackupUnitID != "" {
+		bootVolume.Properties.BackupunitId = &machineSpec.Disk.BackupUnitID
+	}
+
+	_, bootOrder := bootConfig(&machineSpec)
+	if bootOrder != "" {
+		bootVolume.Properties.BootOrder = ptr.To(bootOrder.String())
+	}
+
+	volumes := []sdk.Volume{bootVolume}
+	for _, volume := range machineSpec.AdditionalVolumes {
+		properties := &sdk.VolumeProperties{
+			AvailabilityZone: ptr.To(volume.AvailabilityZone.String()),
+			Name:             ptr.To(s.additionalVolumeName(ms.IonosMachine, volume.Name)),
+			Size:             ptr.To(float32(volume.SizeGB)),
+			Type:             ptr.To(volume.DiskType.String()),
+		}
+		if bootOrder == infrav1.VolumeBootOrderPrimary || bootOrder == infrav1.VolumeBootOrderNone {
+			// The Cloud API requires all other volumes to be excluded from booting.
+			properties.BootOrder = ptr.To(infrav1.VolumeBootOrderNone.String())
+		}
+		if volume.Bus != "" {
+			properties.Bus = ptr.To(volume.Bus.String())
+		}
+		volumes = append(volumes, sdk.Volume{Properties: properties})
 	}
+	return volumes
+}
+
+// bootConfig returns the device, from which the VM of the machine spec boots, and the boot order of
+// its boot volume. Without a boot config, the boot order is left to the Cloud API.
+func bootConfig(spec *infrav1.IonosCloudMachineSpec) (infrav1.BootDevice, infrav1.VolumeBootOrder) {
+	if spec.Boot == nil {
+		if spec.CDROM != nil {
+			return infrav1.BootDeviceCDROM, ""
+		}
+		return infrav1.BootDeviceVolume, ""
+	}
+
+	order := spec.Boot.VolumeBootOrder
+	if order == "" {
+		switch spec.Boot.Device {
+		case infrav1.BootDeviceVolume:
+			order = infrav1.VolumeBootOrderPrimary
+		case infrav1.BootDeviceCDROM:
+			order = infrav1.VolumeBootOrderAuto
+		case infrav1.BootDeviceNetwork:
+			order = infrav1.VolumeBootOrderNone
+		}
+	}
+	return spec.Boot.Device, order
+}
+
+// buildServerEntities returns the server entities for the expected cloud server resource.
+func (s *Service) buildServerEntities(ms *scope.Machine, params serverEntityParams) sdk.ServerEntities {
+	machineSpec := params.machineSpec
+	volumes := s.buildServerVolumes(ms, params)
 
 	serverVolumes := sdk.AttachedVolumes{
-		Items: &[]sdk.Volume{bootVolume},
+		Items: &volumes,
 	}
 
 	// As we want to retrieve a public IP from the DHCP, we need to
@@ -410,7 +1461,7 @@ func (s *Service) buildServerEntities(ms *scope.Machine, params serverEntityPara
 		Items: &[]sdk.Nic{
 			{
 				Properties: &sdk.NicProperties{
-					Dhcp: ptr.To(true),
+					Dhcp: ptr.To(networkDHCP(ms.ClusterScope.PrimaryNetwork())),
 					Lan:  &params.lanID,
 					Name: ptr.To(s.nicName(ms.IonosMachine)),
 				},
@@ -418,32 +1469,325 @@ func (s *Service) buildServerEntities(ms *scope.Machine, params serverEntityPara
 		},
 	}
 
+	if rules := machineSpec.FirewallRules; len(rules) > 0 {
+		primaryNIC := (*serverNICs.Items)[0]
+		primaryNIC.Properties.FirewallActive = ptr.To(true)
+		primaryNIC.Properties.FirewallType = ptr.To(firewallType(rules))
+		primaryNIC.Entities = &sdk.NicEntities{Firewallrules: s.buildFirewallRules(rules)}
+		(*serverNICs.Items)[0] = primaryNIC
+	}
+
+	if ipv6 := machineSpec.IPv6; ipv6 != nil {
+		(*serverNICs.Items)[0].Properties.Dhcpv6 = ptr.To(ptr.Deref(ipv6.DHCP, true))
+		// Leaving the CIDR block unset lets the cloud assign one from the cluster LAN.
+		if ms.ClaimedIPv6CIDRBlock != "" {
+			(*serverNICs.Items)[0].Properties.Ipv6CidrBlock = ptr.To(ms.ClaimedIPv6CIDRBlock)
+		}
+	}
+
+	// Addresses claimed from IPAM pools or IP blocks and private IPs are assigned to the NICs. DHCP stays enabled,
+	// so that the VM receives the assigned address from the DHCP server of IONOS Cloud.
+	if ip, ok := ms.ClaimedIPv4Addresses[0]; ok {
+		(*serverNICs.Items)[0].Properties.Ips = &[]string{ip}
+	}
+
 	// Attach server to additional LANs if any.
 	items := *serverNICs.Items
 
-	for _, nic := range ms.IonosMachine.Spec.AdditionalNetworks {
+	for i, nic := range machineSpec.AdditionalNetworks {
+		props := &sdk.NicProperties{
+			Dhcp: ptr.To(networkDHCP(ms.ClusterScope.Network(nic.Name))),
+			Lan:  ptr.To(nic.NetworkID),
+			Name: ptr.To(s.additionalNICName(ms.IonosMachine, nic.NetworkID)),
+		}
+		if ip, ok := ms.ClaimedIPv4Addresses[i+1]; ok {
+			props.Ips = &[]string{ip}
+		}
+		items = append(items, sdk.Nic{Properties: props})
+	}
+
+	// Attach the machine to the target LAN of its load balancer.
+	if params.loadBalancerLANID != 0 {
 		items = append(items, sdk.Nic{Properties: &sdk.NicProperties{
-			Lan: &nic.NetworkID,
+			Dhcp: ptr.To(true),
+			Lan:  &params.loadBalancerLANID,
 		}})
 	}
 
 	serverNICs.Items = &items
 
-	return sdk.ServerEntities{
+	entities := sdk.ServerEntities{
 		Nics:    &serverNICs,
 		Volumes: &serverVolumes,
 	}
+	var cdroms []sdk.Image
+	if params.cdromImage != nil {
+		cdroms = append(cdroms, sdk.Image{Id: params.cdromImage.GetId()})
+	}
+	if params.bootstrapImageID != "" {
+		cdroms = append(cdroms, sdk.Image{Id: ptr.To(params.bootstrapImageID)})
+	}
+	if len(cdroms) > 0 {
+		entities.Cdroms = &sdk.Cdroms{Items: &cdroms}
+	}
+	return entities
+}
+
+// bootstrapDataFormat is the format of the bootstrap data, which is stored in the "format" key
+// of the bootstrap data secret.
+type bootstrapDataFormat string
+
+const (
+	bootstrapDataFormatCloudConfig bootstrapDataFormat = "cloud-config"
+	bootstrapDataFormatIgnition    bootstrapDataFormat = "ignition"
+	bootstrapDataFormatTalos       bootstrapDataFormat = "talos"
+)
+
+// userDataPart is an additional part of the user data.
+type userDataPart struct {
+	contentType string
+	content     []byte
+}
+
+// getAdditionalUserData returns the additional user data parts of the machine.
+// Parts referencing optional secrets or keys, which don't exist, are skipped.
+func (*Service) getAdditionalUserData(ctx context.Context, ms *scope.Machine) ([]userDataPart, error) {
+	parts := make([]userDataPart, 0, len(ms.IonosMachine.Spec.AdditionalUserData))
+	for _, part := range ms.IonosMachine.Spec.AdditionalUserData {
+		content, err := ms.GetSecretKeyData(ctx, part.SecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get additional user data: %w", err)
+		}
+		if content == nil {
+			continue
+		}
+		parts = append(parts, userDataPart{
+			contentType: cmp.Or(part.ContentType, cloudConfigContentType),
+			content:     content,
+		})
+	}
+	return parts, nil
 }
 
-func (*Service) renderUserData(ms *scope.Machine, input string) string {
-	const bootCmdFormat = `bootcmd:
+// renderUserData returns the base64 encoded user data of the boot volume. The hostname of the server
+// and the nameservers and NTP servers of its networks are added to the bootstrap data, which is either
+// a cloud-config or an Ignition config.
+// If additional user data parts are given, they are combined with the cloud-config into a
+// multipart MIME document. Talos machine configs are passed on unchanged, as Talos takes the
+// hostname from the meta-data of the bootstrap image.
+func (*Service) renderUserData(
+	ms *scope.Machine, input string, format bootstrapDataFormat, additionalParts ...userDataPart,
+) (string, error) {
+	switch format {
+	case bootstrapDataFormatCloudConfig:
+		const bootCmdFormat = `bootcmd:
   - echo %[1]s > /etc/hostname
   - hostname %[1]s
 `
-	bootCmdString := fmt.Sprintf(bootCmdFormat, ms.IonosMachine.Name)
-	input = fmt.Sprintf("%s\n%s", input, bootCmdString)
+		bootCmdString := fmt.Sprintf(bootCmdFormat, ms.Hostname())
+		for _, cmd := range machineNetworkOptions(ms).bootCommands() {
+			bootCmdString += "  - " + cmd + "\n"
+		}
+		input = fmt.Sprintf("%s\n%s", input, bootCmdString)
+		if len(additionalParts) > 0 {
+			var err error
+			input, err = buildMultipartUserData(input, additionalParts)
+			if err != nil {
+				return "", err
+			}
+		}
+	case bootstrapDataFormatIgnition:
+		if len(additionalParts) > 0 {
+			return "", errors.New("additional user data is only supported with cloud-config bootstrap data")
+		}
+		files := append([]ignitionFile{{path: "/etc/hostname", content: ms.Hostname()}},
+			machineNetworkOptions(ms).files()...)
+		var err error
+		input, err = addIgnitionFiles(input, files...)
+		if err != nil {
+			return "", err
+		}
+	case bootstrapDataFormatTalos:
+		if len(additionalParts) > 0 {
+			return "", errors.New("additional user data is only supported with cloud-config bootstrap data")
+		}
+	default:
+		return "", fmt.Errorf("unsupported bootstrap data format %q", format)
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(input)), nil
+}
+
+// compressUserData compresses the base64 encoded cloud-config user data with gzip according to the user data
+// compression of the machine. cloud-init detects compressed user data by its gzip header.
+func (s *Service) compressUserData(ms *scope.Machine, renderedData string) (string, error) {
+	switch ms.IonosMachine.Spec.UserDataCompression {
+	case infrav1.UserDataCompressionNone:
+		return renderedData, nil
+	case infrav1.UserDataCompressionGzip:
+	default:
+		if !useBootstrapImage(renderedData) {
+			return renderedData, nil
+		}
+	}
+
+	userData, err := base64.StdEncoding.DecodeString(renderedData)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode user data: %w", err)
+	}
+
+	var buf bytes.Buffer
+	// The header of the output carries no modification time, which keeps the compressed user data stable.
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(userData); err != nil {
+		return "", fmt.Errorf("unable to compress user data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("unable to compress user data: %w", err)
+	}
+
+	compressed := base64.StdEncoding.EncodeToString(buf.Bytes())
+	s.logger.V(4).Info("Compressed user data", "size", len(renderedData), "compressedSize", len(compressed))
+	return compressed, nil
+}
+
+const (
+	cloudConfigContentType = "text/cloud-config"
+
+	// userDataBoundary separates the parts of multipart user data. Using a fixed boundary
+	// keeps the rendered user data stable across reconciliations.
+	userDataBoundary = "==CAPIC-USER-DATA-BOUNDARY=="
+
+	// userDataMergeType configures cloud-init to append lists and to keep existing keys
+	// when merging cloud-config parts.
+	userDataMergeType = "list(append)+dict(no_replace,recurse_list)+str()"
+)
+
+// buildMultipartUserData combines the cloud-config bootstrap data and the additional parts into
+// a multipart MIME document, which is understood by cloud-init.
+func buildMultipartUserData(cloudConfig string, additionalParts []userDataPart) (string, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n\r\n", userDataBoundary)
+
+	writer := multipart.NewWriter(&buf)
+	if err := writer.SetBoundary(userDataBoundary); err != nil {
+		return "", err
+	}
+
+	parts := append([]userDataPart{{contentType: cloudConfigContentType, content: []byte(cloudConfig)}},
+		additionalParts...)
+	for i, part := range parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType+`; charset="utf-8"`)
+		header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="part-%03d"`, i))
+		if part.contentType == cloudConfigContentType {
+			header.Set("Merge-Type", userDataMergeType)
+		}
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return "", fmt.Errorf("unable to render user data: %w", err)
+		}
+		if _, err := w.Write(part.content); err != nil {
+			return "", fmt.Errorf("unable to render user data: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("unable to render user data: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// getBootstrapDataFormat returns the format of the bootstrap data in the given secret.
+// Secrets without a format contain a cloud-config, unless they contain a Talos machine config.
+func getBootstrapDataFormat(secret *corev1.Secret) bootstrapDataFormat {
+	if format := string(secret.Data["format"]); format != "" {
+		return bootstrapDataFormat(format)
+	}
+	if isTalosMachineConfig(secret.Data["value"]) {
+		return bootstrapDataFormatTalos
+	}
+	return bootstrapDataFormatCloudConfig
+}
+
+// isTalosMachineConfig returns true if the bootstrap data is a Talos machine config, which is
+// identified by its top-level version and machine keys.
+func isTalosMachineConfig(data []byte) bool {
+	var hasVersion, hasMachine bool
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \r")
+		if line == "---" && (hasVersion || hasMachine) {
+			// Only the first document of the config needs to be checked.
+			break
+		}
+		hasVersion = hasVersion || line == "version: v1alpha1"
+		hasMachine = hasMachine || line == "machine:"
+	}
+	return hasVersion && hasMachine
+}
+
+// ignitionFile is a file, which is added to an Ignition config.
+type ignitionFile struct {
+	path    string
+	content string
+}
+
+// addIgnitionFiles adds the given files to the Ignition config, unless the config already contains them.
+// Ignition configs of spec version 2 need the file system to be set for each file.
+func addIgnitionFiles(input string, newFiles ...ignitionFile) (string, error) {
+	var config map[string]any
+	if err := json.Unmarshal([]byte(input), &config); err != nil {
+		return "", fmt.Errorf("unable to parse Ignition config: %w", err)
+	}
+
+	storage, _ := config["storage"].(map[string]any)
+	if storage == nil {
+		storage = make(map[string]any)
+	}
+	files, _ := storage["files"].([]any)
+	ignition, _ := config["ignition"].(map[string]any)
+	version, _ := ignition["version"].(string)
+
+	added := false
+	for _, newFile := range newFiles {
+		if slices.ContainsFunc(files, func(file any) bool {
+			f, ok := file.(map[string]any)
+			return ok && f["path"] == newFile.path
+		}) {
+			continue
+		}
+
+		file := map[string]any{
+			"path": newFile.path,
+			"mode": 0o644,
+			"contents": map[string]any{
+				"source": "data:," + url.PathEscape(newFile.content),
+			},
+		}
+		if strings.HasPrefix(version, "2.") {
+			file["filesystem"] = "root"
+		} else {
+			file["overwrite"] = true
+		}
+		files = append(files, file)
+		added = true
+	}
+	if !added {
+		return input, nil
+	}
+
+	storage["files"] = files
+	config["storage"] = storage
 
-	return base64.StdEncoding.EncodeToString([]byte(input))
+	output, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to render Ignition config: %w", err)
+	}
+	return string(output), nil
 }
 
 func (*Service) serversURL(datacenterID string) string {
@@ -453,3 +1797,192 @@ func (*Service) serversURL(datacenterID string) string {
 func (*Service) volumeName(m *infrav1.IonosCloudMachine) string {
 	return "vol-" + m.Name
 }
+
+// additionalVolumeName returns the name of an additional volume of the machine.
+func (*Service) additionalVolumeName(m *infrav1.IonosCloudMachine, name string) string {
+	return "vol-" + m.Name + "-" + name
+}
+
+// additionalVolumeNames returns the names of all additional volumes of the machine.
+func (s *Service) additionalVolumeNames(m *infrav1.IonosCloudMachine) map[string]struct{} {
+	names := make(map[string]struct{}, len(m.Spec.AdditionalVolumes))
+	for _, volume := range m.Spec.AdditionalVolumes {
+		names[s.additionalVolumeName(m, volume.Name)] = struct{}{}
+	}
+	return names
+}
+
+// retainVolume labels a volume of the machine as orphaned and detaches it from the server,
+// so that it is kept after the server has been deleted.
+func (s *Service) retainVolume(ctx context.Context, ms *scope.Machine, serverID, volumeID string) error {
+	log := s.logger.WithName("retainVolume")
+
+	labels := machineLabels(ms)
+	labels[orphanedLabelKey] = "true"
+	ops := s.volumeLabelOperations(ms.DatacenterID(), volumeID)
+	if err := s.reconcileLabels(ctx, ops, labels, !keepsUnknownLabels(ms)); err != nil {
+		return fmt.Errorf("could not label retained volume %s: %w", volumeID, err)
+	}
+
+	requestLocation, err := s.ionosClient.DetachVolume(ctx, ms.DatacenterID(), serverID, volumeID)
+	if err != nil {
+		return fmt.Errorf("failed to request detachment of retained volume: %w", err)
+	}
+
+	ms.IonosMachine.SetCurrentRequest(http.MethodDelete, sdk.RequestStatusQueued, requestLocation)
+	s.recordEvent(ms.IonosMachine, volumeRetainedReason,
+		"Requested detachment of volume %s from server %s, the volume is retained", volumeID, serverID)
+	conditions.MarkFalse(ms.IonosMachine, infrav1.ServerDeletedCondition,
+		infrav1.RetainingVolumesReason, clusterv1.ConditionSeverityInfo, "")
+	log.V(4).Info("Successfully requested for detachment of retained volume",
+		"volumeID", volumeID, "location", requestLocation)
+	return nil
+}
+
+// findAdditionalVolumeID returns the ID of the first additional volume of the machine,
+// which is still attached to the server. An empty string is returned if there is none.
+func (s *Service) findAdditionalVolumeID(ms *scope.Machine, server *sdk.Server) string {
+	names := s.additionalVolumeNames(ms.IonosMachine)
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		if _, ok := names[ptr.Deref(volume.GetProperties().GetName(), "")]; ok {
+			return ptr.Deref(volume.GetId(), "")
+		}
+	}
+
+	return ""
+}
+
+// findRetainedVolumeID returns the ID of the first additional volume of the machine, which should be retained
+// and is still attached to the server. An empty string is returned if there is none.
+func (s *Service) findRetainedVolumeID(ms *scope.Machine, server *sdk.Server) string {
+	names := make(map[string]struct{})
+	for _, volume := range ms.IonosMachine.Spec.AdditionalVolumes {
+		if volume.Retain {
+			names[s.additionalVolumeName(ms.IonosMachine, volume.Name)] = struct{}{}
+		}
+	}
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		if _, ok := names[ptr.Deref(volume.GetProperties().GetName(), "")]; ok {
+			return ptr.Deref(volume.GetId(), "")
+		}
+	}
+
+	return ""
+}
+
+// machineVolumeIDs returns the IDs of the boot volume and the additional volumes of the machine,
+// which are attached to the server. Volumes, which don't belong to the machine, are omitted.
+func (s *Service) machineVolumeIDs(ms *scope.Machine, server *sdk.Server) []string {
+	bootVolumeID := s.bootVolumeID(ms.IonosMachine, server)
+	names := s.additionalVolumeNames(ms.IonosMachine)
+
+	var ids []string
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		volumeID := ptr.Deref(volume.GetId(), "")
+		_, isAdditional := names[ptr.Deref(volume.GetProperties().GetName(), "")]
+		if volumeID != "" && (volumeID == bootVolumeID || isAdditional) {
+			ids = append(ids, volumeID)
+		}
+	}
+
+	return ids
+}
+
+// volumeInfo returns information about all volumes, which are attached to the server.
+func (s *Service) volumeInfo(m *infrav1.IonosCloudMachine, server *sdk.Server) []infrav1.VolumeInfo {
+	bootVolumeID := s.bootVolumeID(m, server)
+	volumes := ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{})
+
+	info := make([]infrav1.VolumeInfo, 0, len(volumes))
+	for _, volume := range volumes {
+		volumeID := ptr.Deref(volume.GetId(), "")
+		info = append(info, infrav1.VolumeInfo{
+			ID:   volumeID,
+			Name: ptr.Deref(volume.GetProperties().GetName(), ""),
+			Boot: volumeID != "" && volumeID == bootVolumeID,
+		})
+	}
+
+	return info
+}
+
+// markServerCreated marks the server of the machine as created, once it is available.
+// Later changes of its state, e.g. during updates, don't reset the condition.
+func markServerCreated(m *infrav1.IonosCloudMachine, server *sdk.Server) {
+	state := getState(server)
+	switch {
+	case isAvailable(state):
+		conditions.MarkTrue(m, infrav1.ServerCreatedCondition)
+	case !conditions.IsTrue(m, infrav1.ServerCreatedCondition):
+		conditions.MarkFalse(m, infrav1.ServerCreatedCondition, infrav1.CreatingServerReason,
+			clusterv1.ConditionSeverityInfo, "server is in state %s", state)
+	}
+}
+
+// markVolumesReady reports whether all volumes, which are attached to the server, are available.
+func markVolumesReady(m *infrav1.IonosCloudMachine, server *sdk.Server) {
+	for _, volume := range ptr.Deref(server.GetEntities().GetVolumes().GetItems(), []sdk.Volume{}) {
+		if state := getState(&volume); !isAvailable(state) {
+			conditions.MarkFalse(m, infrav1.VolumeReadyCondition, infrav1.WaitingForVolumesReason,
+				clusterv1.ConditionSeverityInfo, "volume %s is in state %s",
+				ptr.Deref(volume.GetProperties().GetName(), ""), state)
+			return
+		}
+	}
+	conditions.MarkTrue(m, infrav1.VolumeReadyCondition)
+}
+
+// markNICsConfigured reports whether all NICs in the status of the machine have an IP address.
+func markNICsConfigured(m *infrav1.IonosCloudMachine) {
+	for _, nic := range m.Status.MachineNetworkInfo.NICInfo {
+		if len(nic.IPv4Addresses) == 0 && len(nic.IPv6Addresses) == 0 {
+			conditions.MarkFalse(m, infrav1.NICConfiguredCondition, infrav1.WaitingForNICAddressesReason,
+				clusterv1.ConditionSeverityInfo, "NIC %s has no IP address yet", nic.Name)
+			return
+		}
+	}
+	conditions.MarkTrue(m, infrav1.NICConfiguredCondition)
+}
+
+// machineNetworkInfo returns information about all NICs, which are attached to the server.
+func (s *Service) machineNetworkInfo(ms *scope.Machine, server *sdk.Server) *infrav1.MachineNetworkInfo {
+	netInfo := &infrav1.MachineNetworkInfo{NICInfo: make([]infrav1.NICInfo, 0)}
+
+	for _, nic := range ptr.Deref(server.GetEntities().GetNics().GetItems(), []sdk.Nic{}) {
+		props := nic.GetProperties()
+		netInfo.NICInfo = append(netInfo.NICInfo, infrav1.NICInfo{
+			ID:             ptr.Deref(nic.GetId(), ""),
+			Name:           ptr.Deref(props.GetName(), ""),
+			MACAddress:     ptr.Deref(props.GetMac(), ""),
+			IPv4Addresses:  ptr.Deref(props.GetIps(), []string{}),
+			IPv6Addresses:  ptr.Deref(props.GetIpv6Ips(), []string{}),
+			NetworkID:      ptr.Deref(props.GetLan(), 0),
+			Primary:        s.isPrimaryNIC(ms.IonosMachine, &nic),
+			FirewallActive: ptr.Deref(props.GetFirewallActive(), false),
+			FirewallType:   ptr.Deref(props.GetFirewallType(), ""),
+		})
+	}
+
+	return netInfo
+}
+
+// recordServerStatusEvents records the IP addresses and volumes, which appeared in the status of the machine
+// since oldStatus.
+func (s *Service) recordServerStatusEvents(ms *scope.Machine, oldStatus *infrav1.IonosCloudMachineStatus) {
+	status := ms.IonosMachine.Status
+	if len(oldStatus.Addresses) == 0 && len(status.Addresses) > 0 {
+		addresses := make([]string, 0, len(status.Addresses))
+		for _, addr := range status.Addresses {
+			addresses = append(addresses, addr.Address)
+		}
+		s.recordEvent(ms.IonosMachine, ipAddressAllocatedReason,
+			"Server got IP addresses %s", strings.Join(addresses, ", "))
+	}
+
+	for _, volume := range status.Volumes {
+		if !slices.ContainsFunc(oldStatus.Volumes, func(v infrav1.VolumeInfo) bool { return v.ID == volume.ID }) {
+			s.recordEvent(ms.IonosMachine, volumeAttachedReason,
+				"Volume %s (%s) is attached to the server", volume.Name, volume.ID)
+		}
+	}
+}
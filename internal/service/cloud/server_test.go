@@ -17,18 +17,33 @@ limitations under the License.
 package cloud
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"net/mail"
 	"path"
+	"strings"
 	"testing"
+	"time"
 
 	sdk "github.com/ionos-cloud/sdk-go/v6"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
@@ -47,6 +62,278 @@ func (s *serverSuite) TestVolumeName() {
 	s.Equal(expected, volumeName)
 }
 
+func (s *serverSuite) TestAdditionalVolumeName() {
+	volumeName := s.service.additionalVolumeName(s.infraMachine, "data")
+	expected := "vol-" + s.infraMachine.Name + "-data"
+	s.Equal(expected, volumeName)
+}
+
+func (s *serverSuite) TestBuildServerEntitiesIPv6() {
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: s.infraMachine.Spec,
+		lanID:       42,
+	})
+	primaryNIC := (*entities.Nics.Items)[0]
+	s.Nil(primaryNIC.Properties.Dhcpv6, "DHCPv6 should be left to the cloud if IPv6 is not configured")
+
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(false)}
+	entities = s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	primaryNIC = (*entities.Nics.Items)[0]
+	s.Equal(ptr.To(false), primaryNIC.Properties.Dhcpv6)
+	s.Nil(primaryNIC.Properties.Ipv6CidrBlock)
+}
+
+func (s *serverSuite) TestBuildServerEntitiesClaimedAddresses() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.AdditionalNetworks = infrav1.Networks{{NetworkID: 2}, {NetworkID: 3}}
+	s.machineScope.ClaimedIPv4Addresses = map[int]string{0: "192.0.2.10", 2: "192.0.2.11"}
+
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	nics := *entities.Nics.Items
+	s.Len(nics, 3)
+	s.Equal(&[]string{"192.0.2.10"}, nics[0].Properties.Ips)
+	s.Nil(nics[1].Properties.Ips, "NICs without a claimed address should get one via DHCP")
+	s.Equal(&[]string{"192.0.2.11"}, nics[2].Properties.Ips)
+	s.Equal(ptr.To(true), nics[2].Properties.Dhcp)
+}
+
+func (s *serverSuite) TestBuildServerEntitiesCube() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.Type = infrav1.ServerTypeCube
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	bootVolume := (*entities.Volumes.Items)[0]
+	s.Equal(ptr.To(cubeBootVolumeType), bootVolume.Properties.Type)
+	s.Nil(bootVolume.Properties.Size, "the size of the boot volume is defined by the template")
+}
+
+func (s *serverSuite) TestBuildServerEntitiesVolumeProperties() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.Disk.Bus = infrav1.VolumeBusIDE
+	spec.Disk.BackupUnitID = "8a36c7a6-3aa7-4ca5-9935-7c9a0d3305a8"
+	spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data", Bus: infrav1.VolumeBusVirtIO}, {Name: "logs"}}
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	volumes := *entities.Volumes.Items
+	s.Len(volumes, 3)
+	s.Equal(ptr.To("IDE"), volumes[0].Properties.Bus)
+	s.Equal(ptr.To("8a36c7a6-3aa7-4ca5-9935-7c9a0d3305a8"), volumes[0].Properties.BackupunitId)
+	s.Equal(ptr.To("VIRTIO"), volumes[1].Properties.Bus)
+	s.Nil(volumes[2].Properties.Bus, "the bus should be left to the cloud if not configured")
+	s.Nil(volumes[2].Properties.BackupunitId)
+	s.Nil(volumes[0].Properties.BootOrder, "the boot order should be left to the cloud without a boot config")
+	s.Nil(volumes[1].Properties.BootOrder)
+}
+
+func (s *serverSuite) TestBuildServerEntitiesBootOrder() {
+	tests := []struct {
+		boot                   infrav1.BootConfig
+		bootOrder, otherOrders *string
+	}{
+		{infrav1.BootConfig{Device: infrav1.BootDeviceVolume}, ptr.To("PRIMARY"), ptr.To("NONE")},
+		{infrav1.BootConfig{Device: infrav1.BootDeviceVolume, VolumeBootOrder: infrav1.VolumeBootOrderAuto}, ptr.To("AUTO"), nil},
+		{infrav1.BootConfig{Device: infrav1.BootDeviceCDROM}, ptr.To("AUTO"), nil},
+		{infrav1.BootConfig{Device: infrav1.BootDeviceNetwork}, ptr.To("NONE"), ptr.To("NONE")},
+	}
+	for _, tt := range tests {
+		spec := s.infraMachine.Spec.DeepCopy()
+		spec.Boot = &tt.boot
+		spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data"}}
+		entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+			machineSpec: *spec,
+			lanID:       42,
+		})
+		volumes := *entities.Volumes.Items
+		s.Equal(tt.bootOrder, volumes[0].Properties.BootOrder, "device %s", tt.boot.Device)
+		s.Equal(tt.otherOrders, volumes[1].Properties.BootOrder, "device %s", tt.boot.Device)
+	}
+}
+
+func (s *serverSuite) TestBuildServerEntitiesNetworkBootEmptyVolume() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.Boot = &infrav1.BootConfig{Device: infrav1.BootDeviceNetwork}
+	spec.Disk.Image = nil
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	bootVolume := (*entities.Volumes.Items)[0]
+	s.Nil(bootVolume.Properties.Image)
+	s.Equal(ptr.To("LINUX"), bootVolume.Properties.LicenceType, "volumes without an image need a licence type")
+}
+
+func (s *serverSuite) TestBuildServerEntitiesSSHKeys() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.SSHKeys = []string{"ssh-ed25519 AAAA test@example.com"}
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	s.Equal(&spec.SSHKeys, (*entities.Volumes.Items)[0].Properties.SshKeys)
+
+	spec.Boot = &infrav1.BootConfig{Device: infrav1.BootDeviceNetwork}
+	spec.Disk.Image = nil
+	entities = s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	s.Nil((*entities.Volumes.Items)[0].Properties.SshKeys, "SSH keys can only be injected into volumes from images")
+}
+
+func (s *serverSuite) TestBuildServerPropertiesCube() {
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.Type = infrav1.ServerTypeCube
+	spec.CPUFamily = ptr.To("INTEL_SKYLAKE")
+	spec.Template = &infrav1.ServerTemplate{ID: exampleTemplateID}
+
+	props := s.service.buildServerProperties(s.machineScope, spec)
+	s.Equal(ptr.To(exampleTemplateID), props.TemplateUuid)
+	s.Equal(ptr.To(infrav1.ServerTypeCube.String()), props.Type)
+	s.Nil(props.Cores)
+	s.Nil(props.Ram)
+	s.Nil(props.CpuFamily)
+}
+
+func (s *serverSuite) TestResolveCPUFamilyAvailable() {
+	cpuFamily := s.service.resolveCPUFamily(s.machineScope, s.exampleDatacenter("AMD_OPTERON", "INTEL_SKYLAKE"), "INTEL_SKYLAKE")
+	s.Equal("INTEL_SKYLAKE", cpuFamily)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.CPUFamilyAvailableCondition))
+}
+
+func (s *serverSuite) TestResolveCPUFamilySameVendor() {
+	cpuFamily := s.service.resolveCPUFamily(s.machineScope, s.exampleDatacenter("AMD_OPTERON", "INTEL_SKYLAKE", "INTEL_ICELAKE"), "INTEL_XEON")
+	s.Equal("INTEL_SKYLAKE", cpuFamily)
+	s.True(conditions.IsFalse(s.infraMachine, infrav1.CPUFamilyAvailableCondition))
+	s.Equal(infrav1.CPUFamilySubstitutedReason, conditions.GetReason(s.infraMachine, infrav1.CPUFamilyAvailableCondition))
+}
+
+func (s *serverSuite) TestResolveCPUFamilyOtherVendor() {
+	cpuFamily := s.service.resolveCPUFamily(s.machineScope, s.exampleDatacenter("AMD_EPYC"), "INTEL_SKYLAKE")
+	s.Equal("AMD_EPYC", cpuFamily)
+	s.Equal(infrav1.CPUFamilySubstitutedReason, conditions.GetReason(s.infraMachine, infrav1.CPUFamilyAvailableCondition))
+}
+
+func (s *serverSuite) TestResolveCPUFamilyUnknown() {
+	cpuFamily := s.service.resolveCPUFamily(s.machineScope, s.exampleDatacenter(), "INTEL_SKYLAKE")
+	s.Equal("INTEL_SKYLAKE", cpuFamily)
+}
+
+func (s *serverSuite) TestResolveSnapshotByID() {
+	s.ionosClient.EXPECT().GetSnapshot(s.ctx, exampleSnapshotID).
+		Return(s.exampleSnapshot(exampleSnapshotID, "golden-image", "de/txl", 42.5), nil).Once()
+
+	disk := &infrav1.Volume{SizeGB: 20, Image: &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{ID: exampleSnapshotID}}}
+	s.NoError(s.service.resolveSnapshot(s.ctx, s.exampleDatacenter(), disk))
+	s.Equal(&infrav1.ImageSpec{ID: exampleSnapshotID}, disk.Image)
+	s.Equal(43, disk.SizeGB, "the volume must not be smaller than the snapshot")
+}
+
+func (s *serverSuite) TestResolveSnapshotByIDOtherLocation() {
+	s.ionosClient.EXPECT().GetSnapshot(s.ctx, exampleSnapshotID).
+		Return(s.exampleSnapshot(exampleSnapshotID, "golden-image", "us/las", 10), nil).Once()
+
+	disk := &infrav1.Volume{SizeGB: 20, Image: &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{ID: exampleSnapshotID}}}
+	s.ErrorContains(s.service.resolveSnapshot(s.ctx, s.exampleDatacenter(), disk), "is located in us/las")
+}
+
+func (s *serverSuite) TestResolveSnapshotByName() {
+	s.ionosClient.EXPECT().ListSnapshots(s.ctx).Return(&sdk.Snapshots{Items: &[]sdk.Snapshot{
+		*s.exampleSnapshot("snapshot-las", "golden-image", "us/las", 10),
+		*s.exampleSnapshot(exampleSnapshotID, "golden-image", "de/txl", 10),
+		*s.exampleSnapshot("snapshot-other", "other-image", "de/txl", 10),
+	}}, nil).Once()
+
+	disk := &infrav1.Volume{SizeGB: 20, Image: &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{Name: "golden-image"}}}
+	s.NoError(s.service.resolveSnapshot(s.ctx, s.exampleDatacenter(), disk))
+	s.Equal(&infrav1.ImageSpec{ID: exampleSnapshotID}, disk.Image)
+	s.Equal(20, disk.SizeGB)
+}
+
+func (s *serverSuite) TestResolveSnapshotByNameNotFound() {
+	s.ionosClient.EXPECT().ListSnapshots(s.ctx).Return(&sdk.Snapshots{Items: &[]sdk.Snapshot{
+		*s.exampleSnapshot("snapshot-las", "golden-image", "us/las", 10),
+	}}, nil).Once()
+
+	disk := &infrav1.Volume{SizeGB: 20, Image: &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{Name: "golden-image"}}}
+	s.ErrorContains(s.service.resolveSnapshot(s.ctx, s.exampleDatacenter(), disk), "not found in location de/txl")
+}
+
+func (s *serverSuite) TestResolveSnapshotByNameAmbiguous() {
+	s.ionosClient.EXPECT().ListSnapshots(s.ctx).Return(&sdk.Snapshots{Items: &[]sdk.Snapshot{
+		*s.exampleSnapshot(exampleSnapshotID, "golden-image", "de/txl", 10),
+		*s.exampleSnapshot("snapshot-2", "golden-image", "de/txl", 10),
+	}}, nil).Once()
+
+	disk := &infrav1.Volume{SizeGB: 20, Image: &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{Name: "golden-image"}}}
+	s.ErrorContains(s.service.resolveSnapshot(s.ctx, s.exampleDatacenter(), disk), "found multiple snapshots")
+}
+
+func (s *serverSuite) TestGetTemplateID() {
+	id, err := s.service.getTemplateID(s.ctx, &infrav1.ServerTemplate{ID: exampleTemplateID})
+	s.NoError(err)
+	s.Equal(exampleTemplateID, id)
+
+	s.mockListTemplatesCall().Return(s.exampleTemplates("Basic Cube XS", "Basic Cube S"), nil).Once()
+	id, err = s.service.getTemplateID(s.ctx, &infrav1.ServerTemplate{Name: "Basic Cube XS"})
+	s.NoError(err)
+	s.Equal(exampleTemplateID, id)
+}
+
+func (s *serverSuite) TestGetTemplateIDNotFound() {
+	s.mockListTemplatesCall().Return(s.exampleTemplates("Basic Cube S"), nil).Once()
+	_, err := s.service.getTemplateID(s.ctx, &infrav1.ServerTemplate{Name: "Basic Cube XS"})
+	s.ErrorContains(err, "not found")
+}
+
+func (s *serverSuite) TestGetTemplateIDMultipleFound() {
+	s.mockListTemplatesCall().Return(s.exampleTemplates("Basic Cube XS", "Basic Cube XS"), nil).Once()
+	_, err := s.service.getTemplateID(s.ctx, &infrav1.ServerTemplate{Name: "Basic Cube XS"})
+	s.ErrorContains(err, "multiple templates")
+}
+
+func (s *serverSuite) TestBuildServerEntitiesFirewallRules() {
+	entities := s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: s.infraMachine.Spec,
+		lanID:       42,
+	})
+	primaryNIC := (*entities.Nics.Items)[0]
+	s.Nil(primaryNIC.Properties.FirewallActive)
+	s.Nil(primaryNIC.Entities)
+
+	spec := s.infraMachine.Spec.DeepCopy()
+	spec.FirewallRules = []infrav1.FirewallRule{{
+		Name:           "ssh",
+		Protocol:       infrav1.FirewallRuleProtocolTCP,
+		PortRangeStart: ptr.To[int32](22),
+		PortRangeEnd:   ptr.To[int32](22),
+	}}
+	entities = s.service.buildServerEntities(s.machineScope, serverEntityParams{
+		machineSpec: *spec,
+		lanID:       42,
+	})
+	primaryNIC = (*entities.Nics.Items)[0]
+	s.Equal(ptr.To(true), primaryNIC.Properties.FirewallActive)
+	s.Equal(ptr.To("INGRESS"), primaryNIC.Properties.FirewallType)
+	s.Equal([]sdk.FirewallRule{{Properties: &sdk.FirewallruleProperties{
+		Name:           ptr.To("ssh"),
+		Protocol:       ptr.To("TCP"),
+		Type:           ptr.To("INGRESS"),
+		PortRangeStart: ptr.To[int32](22),
+		PortRangeEnd:   ptr.To[int32](22),
+	}}}, *primaryNIC.Entities.Firewallrules.Items)
+}
+
 func (s *serverSuite) TestReconcileServerNoBootstrapSecret() {
 	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
 	s.True(requeue)
@@ -61,10 +348,14 @@ func (s *serverSuite) TestReconcileServerNoBootstrapSecret() {
 func (s *serverSuite) TestReconcileServerRequestPending() {
 	s.prepareReconcileServerRequestTest()
 
-	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusQueued)}, nil)
+	req := s.examplePostRequest(sdk.RequestStatusQueued)
+	req.Metadata.RequestStatus.Href = ptr.To("https://api.ionos.com/cloudapi/v6/requests/request-id/status")
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{req}, nil)
 	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
 	s.NoError(err)
 	s.True(requeue)
+	s.Equal("waiting for request request-id, which is in state QUEUED",
+		conditions.GetMessage(s.infraMachine, infrav1.ServerCreatedCondition))
 }
 
 func (s *serverSuite) TestReconcileServerRequestDoneStateBusy() {
@@ -80,6 +371,7 @@ func (s *serverSuite) TestReconcileServerRequestDoneStateBusy() {
 			},
 		},
 	}}, nil).Once()
+	s.mockListServerLabelsCall("").Return(labelResources(nil), nil).Once()
 
 	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
 	s.NoError(err)
@@ -114,76 +406,980 @@ func (s *serverSuite) TestReconcileServerRequestDoneStateAvailable() {
 			},
 		},
 	}}, nil).Once()
+	s.mockListServerLabelsCall("").Return(labelResources(nil), nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	s.NotNil(s.machineScope.IonosMachine.Status.MachineNetworkInfo)
+	s.Equal([]string{"198.51.100.10"}, s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].IPv4Addresses)
+	s.Equal([]string{"2001:db8:2c0:301::1"},
+		s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].IPv6Addresses)
+	s.Equal(int32(1), s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].NetworkID)
+}
+
+func (s *serverSuite) TestReconcileServerRequestDoneStateAvailableAddresses() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
+		{
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.ServerProperties{
+				Name:    ptr.To(s.infraMachine.Name),
+				VmState: ptr.To("RUNNING"),
+			},
+			Entities: &sdk.ServerEntities{
+				Nics: &sdk.Nics{
+					Items: &[]sdk.Nic{
+						{
+							Id: ptr.To("additional-nic"),
+							Properties: &sdk.NicProperties{
+								Name: ptr.To("k8s-nic-additional"),
+								Mac:  ptr.To("02:01:0a:00:00:02"),
+								Lan:  ptr.To(int32(2)),
+								Ips:  ptr.To([]string{"10.0.0.2"}),
+							},
+						},
+						{
+							Id: ptr.To("primary-nic"),
+							Properties: &sdk.NicProperties{
+								Name:           ptr.To(s.service.nicName(s.infraMachine)),
+								Mac:            ptr.To("02:01:c6:33:64:0a"),
+								Lan:            ptr.To(int32(1)),
+								Ips:            ptr.To([]string{"198.51.100.10"}),
+								Ipv6Ips:        ptr.To([]string{"2001:db8:2c0:301::1"}),
+								FirewallActive: ptr.To(true),
+								FirewallType:   ptr.To("INGRESS"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}}, nil).Once()
+	s.mockListServerLabelsCall("").Return(labelResources(nil), nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	nics := s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo
+	s.Len(nics, 2)
+	s.Equal(infrav1.NICInfo{
+		ID:             "primary-nic",
+		Name:           s.service.nicName(s.infraMachine),
+		MACAddress:     "02:01:c6:33:64:0a",
+		IPv4Addresses:  []string{"198.51.100.10"},
+		IPv6Addresses:  []string{"2001:db8:2c0:301::1"},
+		NetworkID:      1,
+		Primary:        true,
+		FirewallActive: true,
+		FirewallType:   "INGRESS",
+	}, nics[1])
+	s.Equal("02:01:0a:00:00:02", nics[0].MACAddress)
+	s.False(nics[0].FirewallActive)
+
+	s.Equal(clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineExternalIP, Address: "198.51.100.10"},
+		{Type: clusterv1.MachineExternalIP, Address: "2001:db8:2c0:301::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"},
+	}, s.machineScope.IonosMachine.Status.Addresses)
+}
+
+func (s *serverSuite) TestReconcileServerRequestDoneStateAvailableVolumeInfo() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
+		{
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.ServerProperties{
+				Name:    ptr.To(s.infraMachine.Name),
+				VmState: ptr.To("RUNNING"),
+				BootVolume: &sdk.ResourceReference{
+					Id: ptr.To(exampleBootVolumeID),
+				},
+			},
+			Entities: &sdk.ServerEntities{
+				Volumes: &sdk.AttachedVolumes{
+					Items: &[]sdk.Volume{
+						{
+							Id: ptr.To(exampleBootVolumeID),
+							Properties: &sdk.VolumeProperties{
+								Name: ptr.To(s.service.volumeName(s.infraMachine)),
+							},
+						},
+						{
+							Id: ptr.To(exampleAdditionalVolumeID),
+							Properties: &sdk.VolumeProperties{
+								Name: ptr.To(s.service.additionalVolumeName(s.infraMachine, "data")),
+							},
+						},
+					},
+				},
+			},
+		},
+	}}, nil).Once()
+	s.mockListServerLabelsCall("").Return(labelResources(nil), nil).Once()
+	recorder := record.NewFakeRecorder(2)
+	s.service.recorder = recorder
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+
+	s.Len(recorder.Events, 2)
+	s.Contains(<-recorder.Events, "Normal VolumeAttached Volume "+s.service.volumeName(s.infraMachine))
+	s.Contains(<-recorder.Events, "Normal VolumeAttached Volume "+s.service.additionalVolumeName(s.infraMachine, "data"))
+	s.Equal([]infrav1.VolumeInfo{
+		{
+			ID:   exampleBootVolumeID,
+			Name: s.service.volumeName(s.infraMachine),
+			Boot: true,
+		},
+		{
+			ID:   exampleAdditionalVolumeID,
+			Name: s.service.additionalVolumeName(s.infraMachine, "data"),
+			Boot: false,
+		},
+	}, s.machineScope.IonosMachine.Status.Volumes)
+}
+
+func (s *serverSuite) TestReconcileServerRequestDoneStateAvailableTurnedOff() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
+		{
+			Id: ptr.To(exampleServerID),
+			Metadata: &sdk.DatacenterElementMetadata{
+				State: ptr.To(sdk.Available),
+			},
+			Properties: &sdk.ServerProperties{
+				Name:    ptr.To(s.infraMachine.Name),
+				VmState: ptr.To("SHUTOFF"),
+			},
+		},
+	}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+
+	s.mockStartServerCall().Return("", nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerDesiredPowerStateStopped() {
+	s.infraMachine.Spec.DesiredPowerState = infrav1.PowerStateStopped
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Name = ptr.To(s.infraMachine.Name)
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{*server}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+	s.ionosClient.EXPECT().StopServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("stop/location", nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal("RUNNING", s.infraMachine.Status.InstanceState)
+	s.Equal("stop/location", s.infraMachine.Status.CurrentRequest.RequestPath)
+}
+
+func (s *serverSuite) TestReconcileServerRebootAnnotation() {
+	s.infraMachine.Annotations = map[string]string{infrav1.RebootAnnotation: "true"}
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Name = ptr.To(s.infraMachine.Name)
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{*server}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+	s.ionosClient.EXPECT().RebootServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("reboot/location", nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.NotContains(s.infraMachine.Annotations, infrav1.RebootAnnotation)
+	s.Equal("reboot/location", s.infraMachine.Status.CurrentRequest.RequestPath)
+}
+
+func (s *serverSuite) TestReconcileServerRebootAnnotationStopped() {
+	s.infraMachine.Annotations = map[string]string{infrav1.RebootAnnotation: "true"}
+	s.infraMachine.Spec.DesiredPowerState = infrav1.PowerStateStopped
+	server := s.runningServer()
+	server.Properties.VmState = ptr.To("SHUTOFF")
+
+	requeue, err := s.service.reconcileReboot(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.False(requeue)
+	s.NotContains(s.infraMachine.Annotations, infrav1.RebootAnnotation)
+}
+
+func (s *serverSuite) TestReconcileServerDesiredPowerStateStoppedShuttingDown() {
+	s.infraMachine.Spec.DesiredPowerState = infrav1.PowerStateStopped
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Name = ptr.To(s.infraMachine.Name)
+	server.Properties.VmState = ptr.To("SHUTDOWN")
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{*server}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal("SHUTDOWN", s.infraMachine.Status.InstanceState)
+}
+
+func (s *serverSuite) TestReconcileServerDesiredPowerStateStoppedTurnedOff() {
+	s.infraMachine.Spec.DesiredPowerState = infrav1.PowerStateStopped
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Name = ptr.To(s.infraMachine.Name)
+	server.Properties.VmState = ptr.To("SHUTOFF")
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{*server}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal("SHUTOFF", s.infraMachine.Status.InstanceState)
+}
+
+func (s *serverSuite) TestReconcileServerCrashed() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Name = ptr.To(s.infraMachine.Name)
+	server.Properties.VmState = ptr.To("CRASHED")
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{*server}}, nil).Once()
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(s.machineScope.HasFailed())
+	s.Equal(capierrors.UpdateMachineError, *s.infraMachine.Status.FailureReason)
+	s.Equal(infrav1.InstanceCrashedReason, conditions.GetReason(s.infraMachine, infrav1.InstanceHealthyCondition))
+}
+
+func (s *serverSuite) TestReconcileInstanceHealthRunning() {
+	conditions.MarkFalse(s.infraMachine, infrav1.InstanceHealthyCondition,
+		infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityWarning, "")
+
+	s.True(s.service.reconcileInstanceHealth(s.machineScope, s.runningServer()))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.InstanceHealthyCondition))
+}
+
+func (s *serverSuite) TestReconcileInstanceHealthUnexpectedShutOff() {
+	s.infraMachine.Status.Ready = true
+	server := s.runningServer()
+	server.Properties.VmState = ptr.To("SHUTOFF")
+
+	s.True(s.service.reconcileInstanceHealth(s.machineScope, server))
+	s.False(s.machineScope.HasFailed())
+	s.Equal(infrav1.InstanceStoppedReason, conditions.GetReason(s.infraMachine, infrav1.InstanceHealthyCondition))
+}
+
+func (s *serverSuite) TestReconcileInstanceHealthExpectedShutOff() {
+	server := s.runningServer()
+	server.Properties.VmState = ptr.To("SHUTOFF")
+
+	// The server is not provisioned yet.
+	s.True(s.service.reconcileInstanceHealth(s.machineScope, server))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.InstanceHealthyCondition))
+
+	// The server needs to be restarted to apply updated resources.
+	s.infraMachine.Status.Ready = true
+	conditions.MarkFalse(s.infraMachine, infrav1.ServerResourcesUpdatedCondition,
+		infrav1.RebootRequiredReason, clusterv1.ConditionSeverityWarning, "")
+	s.True(s.service.reconcileInstanceHealth(s.machineScope, server))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.InstanceHealthyCondition))
+
+	// The server is supposed to be stopped.
+	conditions.MarkTrue(s.infraMachine, infrav1.ServerResourcesUpdatedCondition)
+	s.infraMachine.Spec.DesiredPowerState = infrav1.PowerStateStopped
+	s.True(s.service.reconcileInstanceHealth(s.machineScope, server))
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.InstanceHealthyCondition))
+}
+
+func (s *serverSuite) TestReconcileServerResourcesUpToDate() {
+	server := s.resizableServer(2, 4096, true)
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.False(requeue)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerResourcesReportDrift() {
+	s.infraMachine.Spec.DriftPolicy = infrav1.DriftPolicyReport
+	s.infraMachine.Status.Ready = true
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, s.resizableServer(1, 4096, true))
+	s.NoError(err)
+	s.False(requeue)
+	s.Nil(s.infraMachine.Status.CurrentRequest)
+	s.Equal([]string{"server has 1 cores instead of 2"}, s.machineScope.Drift)
+}
+
+func (s *serverSuite) TestReconcileServerResourcesHotPlug() {
+	server := s.resizableServer(1, 2048, true)
+	s.ionosClient.EXPECT().PatchServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID, sdk.ServerProperties{
+		Cores: ptr.To(int32(2)),
+		Ram:   ptr.To(int32(4096)),
+	}).Return("patch/location", nil).Once()
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(http.MethodPatch, s.infraMachine.Status.CurrentRequest.Method)
+	s.Equal("patch/location", s.infraMachine.Status.CurrentRequest.RequestPath)
+	s.Equal(infrav1.ResizingReason, conditions.GetReason(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerResourcesWithoutHotPlugSupport() {
+	server := s.resizableServer(1, 4096, false)
+	s.ionosClient.EXPECT().PatchServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID, sdk.ServerProperties{
+		Cores: ptr.To(int32(2)),
+	}).Return("patch/location", nil).Once()
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.RebootRequiredReason,
+		conditions.GetReason(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerResourcesShrinkRequiresReboot() {
+	server := s.resizableServer(2, 8192, true)
+	s.ionosClient.EXPECT().PatchServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID, sdk.ServerProperties{
+		Ram: ptr.To(int32(4096)),
+	}).Return("patch/location", nil).Once()
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.RebootRequiredReason,
+		conditions.GetReason(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+
+	// The condition is kept until the server has been restarted.
+	requeue, err = s.service.reconcileServerResources(s.ctx, s.machineScope, s.resizableServer(2, 4096, true))
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(infrav1.RebootRequiredReason,
+		conditions.GetReason(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerResourcesStoppedServer() {
+	server := s.resizableServer(4, 8192, false)
+	server.Properties.VmState = ptr.To("SHUTOFF")
+	s.ionosClient.EXPECT().PatchServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID, sdk.ServerProperties{
+		Cores: ptr.To(int32(2)),
+		Ram:   ptr.To(int32(4096)),
+	}).Return("patch/location", nil).Once()
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.ResizingReason, conditions.GetReason(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestEnsureServerAvailableClearsRebootRequired() {
+	conditions.MarkFalse(s.infraMachine, infrav1.ServerResourcesUpdatedCondition,
+		infrav1.RebootRequiredReason, clusterv1.ConditionSeverityWarning, "")
+	server := s.resizableServer(2, 4096, true)
+	server.Properties.VmState = ptr.To("SHUTOFF")
+	s.mockStartServerCall().Return("start/location", nil).Once()
+
+	requeue, err := s.service.ensureServerAvailable(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerResourcesUpdatedCondition))
+}
+
+func (s *serverSuite) TestReconcileEnterpriseServerNoRequest() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockCreateServerCall(infrav1.ServerTypeEnterprise).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.Equal("ionos://12345", ptr.Deref(s.machineScope.IonosMachine.Spec.ProviderID, ""))
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerCreationRejected() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	rejected := sdk.NewGenericOpenAPIError("422 Unprocessable Entity", nil, sdk.Error{Messages: &[]sdk.ErrorMessage{{
+		Message: ptr.To("[VDC-5-6] The requested image does not support the CPU family"),
+	}}}, http.StatusUnprocessableEntity)
+	s.mockCreateServerCall(infrav1.ServerTypeEnterprise).Return(nil, "", rejected).Once()
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(s.machineScope.HasFailed())
+	s.Equal(capierrors.CreateMachineError, *s.infraMachine.Status.FailureReason)
+	s.Contains(*s.infraMachine.Status.FailureMessage, "does not support the CPU family")
+	s.Equal(infrav1.ServerCreationRejectedReason,
+		conditions.GetReason(s.infraMachine, infrav1.ServerCreatedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerCreationThrottled() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockCreateServerCall(infrav1.ServerTypeEnterprise).
+		Return(nil, "", sdk.NewGenericOpenAPIError("", nil, nil, http.StatusTooManyRequests)).Once()
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	_, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.Error(err)
+	s.False(s.machineScope.HasFailed())
+}
+
+func (s *serverSuite) TestReconcileServerCreationFailureInvalidatesDatacenter() {
+	cache := &DatacenterCache{}
+	WithDatacenterCache(cache)(s.service)
+	s.infraMachine.Spec.CPUFamily = ptr.To("AMD_OPTERON")
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockCreateServerCall(infrav1.ServerTypeEnterprise).
+		Return(nil, "", sdk.NewGenericOpenAPIError("", nil, nil, http.StatusTooManyRequests)).Once()
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	_, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.Error(err)
+	s.Nil(cache.get(s.machineScope.DatacenterID()), "the data center must be requested again")
+}
+
+func (s *serverSuite) TestReconcileServerSnapshotIgnoresSSHKeys() {
+	s.infraMachine.Spec.Disk.Image = &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{ID: exampleSnapshotID}}
+	s.infraMachine.Spec.SSHKeys = []string{"ssh-ed25519 AAAA test@example.com"}
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.ionosClient.EXPECT().GetSnapshot(s.ctx, exampleSnapshotID).
+		Return(s.exampleSnapshot(exampleSnapshotID, "golden-image", "de/txl", 10), nil).Once()
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.Anything,
+		mock.MatchedBy(func(entities sdk.ServerEntities) bool {
+			bootVolume := (*entities.Volumes.Items)[0]
+			return ptr.Deref(bootVolume.Properties.Image, "") == exampleSnapshotID && bootVolume.Properties.SshKeys == nil
+		}),
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil).Once()
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileVCPUServerNoRequest() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockCreateServerCall(infrav1.ServerTypeVCPU).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	s.infraMachine.Spec.Type = infrav1.ServerTypeVCPU
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.Equal("ionos://12345", ptr.Deref(s.machineScope.IonosMachine.Spec.ProviderID, ""))
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileCubeServerNoRequest() {
+	s.prepareReconcileServerRequestTest()
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockListTemplatesCall().Return(s.exampleTemplates("Basic Cube XS"), nil).Once()
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.MatchedBy(func(properties sdk.ServerProperties) bool {
+			return ptr.Deref(properties.Type, "") == infrav1.ServerTypeCube.String() &&
+				ptr.Deref(properties.TemplateUuid, "") == exampleTemplateID
+		}),
+		mock.Anything,
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	s.infraMachine.Spec.Type = infrav1.ServerTypeCube
+	s.infraMachine.Spec.Template = &infrav1.ServerTemplate{Name: "Basic Cube XS"}
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.Equal("ionos://12345", ptr.Deref(s.machineScope.IonosMachine.Spec.ProviderID, ""))
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(&infrav1.ServerTemplate{Name: "Basic Cube XS"}, s.infraMachine.Spec.Template,
+		"the template of the spec must not be changed")
+}
+
+func (s *serverSuite) TestReconcileServerResourcesCube() {
+	s.infraMachine.Spec.Type = infrav1.ServerTypeCube
+	server := s.resizableServer(1, 1024, true)
+
+	requeue, err := s.service.reconcileServerResources(s.ctx, s.machineScope, server)
+	s.NoError(err)
+	s.False(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestAdditionalVolumes() {
+	s.prepareReconcileServerRequestTest()
+	s.infraMachine.Spec.AdditionalVolumes = []infrav1.VolumeSpec{{
+		Name:             "data",
+		DiskType:         infrav1.VolumeDiskTypeSSDStandard,
+		SizeGB:           50,
+		AvailabilityZone: infrav1.AvailabilityZoneOne,
+	}}
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.Anything,
+		mock.MatchedBy(func(entities sdk.ServerEntities) bool {
+			volumes := ptr.Deref(entities.GetVolumes().GetItems(), []sdk.Volume{})
+			if len(volumes) != 2 {
+				return false
+			}
+			props := volumes[1].GetProperties()
+			return ptr.Deref(props.GetName(), "") == s.service.additionalVolumeName(s.infraMachine, "data") &&
+				ptr.Deref(props.GetSize(), 0) == 50 &&
+				ptr.Deref(props.GetType(), "") == infrav1.VolumeDiskTypeSSDStandard.String() &&
+				ptr.Deref(props.GetAvailabilityZone(), "") == infrav1.AvailabilityZoneOne.String() &&
+				props.GetUserData() == nil
+		}),
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
 
 	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
 	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestDefaultDiskType() {
+	s.prepareReconcileServerRequestTest()
+	s.infraMachine.Spec.Disk.DiskType = ""
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.Anything,
+		mock.MatchedBy(func(entities sdk.ServerEntities) bool {
+			volumes := ptr.Deref(entities.GetVolumes().GetItems(), []sdk.Volume{})
+			return len(volumes) == 1 &&
+				ptr.Deref(volumes[0].GetProperties().GetType(), "") == infrav1.DefaultBootVolumeDiskType.String()
+		}),
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
+		Id: ptr.To("1"),
+		Properties: &sdk.LanProperties{
+			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
+			Public: ptr.To(true),
+		},
+	}}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestAdditionalNetworks() {
+	s.prepareReconcileServerRequestTest()
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{NetworkID: 3}}
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.Anything,
+		mock.MatchedBy(func(entities sdk.ServerEntities) bool {
+			nics := ptr.Deref(entities.GetNics().GetItems(), []sdk.Nic{})
+			if len(nics) != 2 {
+				return false
+			}
+			props := nics[1].GetProperties()
+			return ptr.Deref(props.GetName(), "") == s.service.additionalNICName(s.infraMachine, 3) &&
+				ptr.Deref(props.GetLan(), 0) == 3 &&
+				ptr.Deref(props.GetDhcp(), false)
+		}),
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestClusterNetworks() {
+	s.prepareReconcileServerRequestTest()
+	s.infraCluster.Spec.Networks = []infrav1.NetworkSpec{{Name: "primary"}, {Name: "storage", DHCP: ptr.To(false)}}
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{Name: "storage"}}
+
+	primaryLAN := s.exampleLAN()
+	primaryLAN.Properties.Name = ptr.To(s.service.networkLANName(s.capiCluster, "primary"))
+	storageLAN := s.exampleLAN()
+	storageLAN.Id = ptr.To("3")
+	storageLAN.Properties.Name = ptr.To(s.service.networkLANName(s.capiCluster, "storage"))
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.ionosClient.EXPECT().CreateServer(
+		s.ctx,
+		s.machineScope.DatacenterID(),
+		mock.Anything,
+		mock.MatchedBy(func(entities sdk.ServerEntities) bool {
+			nics := ptr.Deref(entities.GetNics().GetItems(), []sdk.Nic{})
+			if len(nics) != 2 {
+				return false
+			}
+			primary, storage := nics[0].GetProperties(), nics[1].GetProperties()
+			return ptr.Deref(primary.GetLan(), 0) == 42 && ptr.Deref(primary.GetDhcp(), false) &&
+				ptr.Deref(storage.GetName(), "") == s.service.additionalNICName(s.infraMachine, 3) &&
+				ptr.Deref(storage.GetLan(), 0) == 3 &&
+				!ptr.Deref(storage.GetDhcp(), true)
+		}),
+	).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{primaryLAN, storageLAN}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestAdditionalNetworkIsClusterLAN() {
+	s.prepareReconcileServerRequestTest()
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{{NetworkID: 42}}
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.ErrorContains(err, "must not be the cluster LAN")
 	s.False(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerNoRequestUnsupportedBootstrapFormat() {
+	s.prepareReconcileServerRequestTest()
+	secret := &corev1.Secret{}
+	s.NoError(s.k8sClient.Get(s.ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test"}, secret))
+	secret.Data["format"] = []byte("unknown")
+	s.NoError(s.k8sClient.Update(s.ctx, secret))
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.ErrorContains(err, `unsupported bootstrap data format "unknown"`)
+	s.False(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerIgnitionUserDataTooLarge() {
+	s.prepareReconcileServerRequestTest()
+	secret := &corev1.Secret{}
+	s.NoError(s.k8sClient.Get(s.ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test"}, secret))
+	secret.Data["format"] = []byte(bootstrapDataFormatIgnition)
+	secret.Data["value"] = []byte(`{"ignition":{"version":"3.4.0"},"padding":"` + strings.Repeat("a", maxUserDataSize) + `"}`)
+	s.NoError(s.k8sClient.Update(s.ctx, secret))
+
+	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
+	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{s.exampleLAN()}}, nil)
+
+	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(infrav1.UserDataTooLargeReason,
+		conditions.GetReason(s.infraMachine, infrav1.BootstrapDeliveredCondition))
+	s.Contains(conditions.GetMessage(s.infraMachine, infrav1.BootstrapDeliveredCondition),
+		fmt.Sprintf("exceeds the limit of %d bytes", maxUserDataSize))
+}
+
+func (s *serverSuite) TestCompressUserData() {
+	small := base64.StdEncoding.EncodeToString([]byte("#cloud-config\n"))
+	large := base64.StdEncoding.EncodeToString([]byte("#cloud-config\n" + strings.Repeat("#", maxUserDataSize)))
+
+	tests := []struct {
+		name        string
+		compression infrav1.UserDataCompression
+		input       string
+		compressed  bool
+	}{
+		{name: "auto small", input: small},
+		{name: "auto large", input: large, compressed: true},
+		{name: "gzip", compression: infrav1.UserDataCompressionGzip, input: small, compressed: true},
+		{name: "none", compression: infrav1.UserDataCompressionNone, input: large},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.infraMachine.Spec.UserDataCompression = test.compression
+
+			output, err := s.service.compressUserData(s.machineScope, test.input)
+			s.NoError(err)
+			if !test.compressed {
+				s.Equal(test.input, output)
+				return
+			}
+
+			s.False(useBootstrapImage(output))
+			decoded, err := base64.StdEncoding.DecodeString(output)
+			s.NoError(err)
+			r, err := gzip.NewReader(bytes.NewReader(decoded))
+			s.NoError(err)
+			userData, err := io.ReadAll(r)
+			s.NoError(err)
+			expected, err := base64.StdEncoding.DecodeString(test.input)
+			s.NoError(err)
+			s.Equal(expected, userData)
+		})
+	}
+}
+
+func (s *serverSuite) TestRenderUserDataCloudConfig() {
+	userData, err := s.service.renderUserData(s.machineScope, "#cloud-config", bootstrapDataFormatCloudConfig)
+	s.NoError(err)
+
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	s.NoError(err)
+	s.Equal(fmt.Sprintf(`#cloud-config
+bootcmd:
+  - echo %[1]s > /etc/hostname
+  - hostname %[1]s
+`, s.infraMachine.Name), string(decoded))
+}
+
+func (s *serverSuite) TestRenderUserDataIgnition() {
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{{
+		name:  "spec version 3",
+		input: `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/kubernetes/kubeadm.yml"}]}}`,
+		expect: `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/kubernetes/kubeadm.yml"},` +
+			`{"contents":{"source":"data:,test-machine"},"mode":420,"overwrite":true,"path":"/etc/hostname"}]}}`,
+	}, {
+		name:  "spec version 2",
+		input: `{"ignition":{"version":"2.3.0"}}`,
+		expect: `{"ignition":{"version":"2.3.0"},"storage":{"files":[` +
+			`{"contents":{"source":"data:,test-machine"},"filesystem":"root","mode":420,"path":"/etc/hostname"}]}}`,
+	}, {
+		name:   "hostname is already set",
+		input:  `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/hostname"}]}}`,
+		expect: `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/hostname"}]}}`,
+	}}
+
+	s.infraMachine.Name = "test-machine"
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			userData, err := s.service.renderUserData(s.machineScope, test.input, bootstrapDataFormatIgnition)
+			s.NoError(err)
+
+			decoded, err := base64.StdEncoding.DecodeString(userData)
+			s.NoError(err)
+			s.JSONEq(test.expect, string(decoded))
+		})
+	}
+}
+
+func (s *serverSuite) TestRenderUserDataInvalidIgnition() {
+	_, err := s.service.renderUserData(s.machineScope, "#cloud-config", bootstrapDataFormatIgnition)
+	s.ErrorContains(err, "unable to parse Ignition config")
+}
+
+func (s *serverSuite) TestRenderUserDataMultipart() {
+	userData, err := s.service.renderUserData(s.machineScope, "#cloud-config", bootstrapDataFormatCloudConfig,
+		userDataPart{contentType: "text/cloud-config", content: []byte("#cloud-config\nwrite_files: []\n")},
+		userDataPart{contentType: "text/x-shellscript", content: []byte("#!/bin/sh\necho test\n")},
+	)
+	s.NoError(err)
+
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	s.NoError(err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(decoded))
+	s.NoError(err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	s.NoError(err)
+	s.Equal("multipart/mixed", mediaType)
+
+	type part struct {
+		contentType string
+		mergeType   string
+		content     string
+	}
+	var parts []part
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		s.NoError(err)
+		content, err := io.ReadAll(p)
+		s.NoError(err)
+		parts = append(parts, part{
+			contentType: p.Header.Get("Content-Type"),
+			mergeType:   p.Header.Get("Merge-Type"),
+			content:     string(content),
+		})
+	}
 
-	s.NotNil(s.machineScope.IonosMachine.Status.MachineNetworkInfo)
-	s.Equal([]string{"198.51.100.10"}, s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].IPv4Addresses)
-	s.Equal([]string{"2001:db8:2c0:301::1"},
-		s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].IPv6Addresses)
-	s.Equal(int32(1), s.machineScope.IonosMachine.Status.MachineNetworkInfo.NICInfo[0].NetworkID)
+	s.Equal([]part{{
+		contentType: `text/cloud-config; charset="utf-8"`,
+		mergeType:   userDataMergeType,
+		content: fmt.Sprintf(`#cloud-config
+bootcmd:
+  - echo %[1]s > /etc/hostname
+  - hostname %[1]s
+`, s.infraMachine.Name),
+	}, {
+		contentType: `text/cloud-config; charset="utf-8"`,
+		mergeType:   userDataMergeType,
+		content:     "#cloud-config\nwrite_files: []\n",
+	}, {
+		contentType: `text/x-shellscript; charset="utf-8"`,
+		content:     "#!/bin/sh\necho test\n",
+	}}, parts)
 }
 
-func (s *serverSuite) TestReconcileServerRequestDoneStateAvailableTurnedOff() {
-	s.prepareReconcileServerRequestTest()
-	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
-	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
-		{
-			Id: ptr.To(exampleServerID),
-			Metadata: &sdk.DatacenterElementMetadata{
-				State: ptr.To(sdk.Available),
-			},
-			Properties: &sdk.ServerProperties{
-				Name:    ptr.To(s.infraMachine.Name),
-				VmState: ptr.To("SHUTOFF"),
-			},
+func (s *serverSuite) TestRenderUserDataIgnitionWithAdditionalParts() {
+	_, err := s.service.renderUserData(s.machineScope, `{"ignition":{"version":"3.4.0"}}`, bootstrapDataFormatIgnition,
+		userDataPart{contentType: "text/cloud-config", content: []byte("#cloud-config")},
+	)
+	s.ErrorContains(err, "additional user data is only supported with cloud-config bootstrap data")
+}
+
+func (s *serverSuite) TestGetAdditionalUserData() {
+	s.NoError(s.k8sClient.Create(s.ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "user-data",
+			Namespace: metav1.NamespaceDefault,
 		},
-	}}, nil).Once()
+		Data: map[string][]byte{
+			"mirrors": []byte("#cloud-config"),
+			"script":  []byte("#!/bin/sh"),
+		},
+	}))
 
-	s.mockStartServerCall().Return("", nil)
+	s.infraMachine.Spec.AdditionalUserData = []infrav1.UserDataPart{{
+		SecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+			Key:                  "mirrors",
+		},
+	}, {
+		SecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+			Key:                  "missing",
+			Optional:             ptr.To(true),
+		},
+	}, {
+		SecretRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+			Key:                  "script",
+		},
+		ContentType: "text/x-shellscript",
+	}}
 
-	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
+	parts, err := s.service.getAdditionalUserData(s.ctx, s.machineScope)
 	s.NoError(err)
-	s.True(requeue)
+	s.Equal([]userDataPart{
+		{contentType: "text/cloud-config", content: []byte("#cloud-config")},
+		{contentType: "text/x-shellscript", content: []byte("#!/bin/sh")},
+	}, parts)
+
+	s.infraMachine.Spec.AdditionalUserData[1].SecretRef.Optional = nil
+	_, err = s.service.getAdditionalUserData(s.ctx, s.machineScope)
+	s.ErrorContains(err, `does not contain key "missing"`)
 }
 
-func (s *serverSuite) TestReconcileEnterpriseServerNoRequest() {
-	s.prepareReconcileServerRequestTest()
-	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
-	s.mockCreateServerCall(infrav1.ServerTypeEnterprise).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
-	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
-		Id: ptr.To("1"),
-		Properties: &sdk.LanProperties{
-			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
-			Public: ptr.To(true),
-		},
-	}}}, nil)
+func (s *serverSuite) TestGetBootstrapDataFormat() {
+	secret := &corev1.Secret{Data: map[string][]byte{"value": []byte("test")}}
+	s.Equal(bootstrapDataFormatCloudConfig, getBootstrapDataFormat(secret))
 
-	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
-	s.Equal("ionos://12345", ptr.Deref(s.machineScope.IonosMachine.Spec.ProviderID, ""))
-	s.NoError(err)
-	s.True(requeue)
+	secret.Data["format"] = []byte("ignition")
+	s.Equal(bootstrapDataFormatIgnition, getBootstrapDataFormat(secret))
+
+	secret = &corev1.Secret{Data: map[string][]byte{"value": []byte(exampleTalosConfig)}}
+	s.Equal(bootstrapDataFormatTalos, getBootstrapDataFormat(secret))
+
+	secret.Data["value"] = []byte("#cloud-config\nwrite_files:\n- path: /machine.yaml\n  content: |\n    machine:\n")
+	s.Equal(bootstrapDataFormatCloudConfig, getBootstrapDataFormat(secret))
 }
 
-func (s *serverSuite) TestReconcileVCPUServerNoRequest() {
-	s.prepareReconcileServerRequestTest()
-	s.mockGetServerCreationRequestCall().Return([]sdk.Request{}, nil)
-	s.mockCreateServerCall(infrav1.ServerTypeVCPU).Return(&sdk.Server{Id: ptr.To("12345")}, "location/to/server", nil)
-	s.mockListLANsCall().Return(&sdk.Lans{Items: &[]sdk.Lan{{
-		Id: ptr.To("1"),
-		Properties: &sdk.LanProperties{
-			Name:   ptr.To(s.service.lanName(s.clusterScope.Cluster)),
-			Public: ptr.To(true),
-		},
-	}}}, nil)
+func (s *serverSuite) TestRenderUserDataTalos() {
+	userData, err := s.service.renderUserData(s.machineScope, exampleTalosConfig, bootstrapDataFormatTalos)
+	s.NoError(err)
 
-	s.infraMachine.Spec.Type = infrav1.ServerTypeVCPU
-	requeue, err := s.service.ReconcileServer(s.ctx, s.machineScope)
-	s.Equal("ionos://12345", ptr.Deref(s.machineScope.IonosMachine.Spec.ProviderID, ""))
+	decoded, err := base64.StdEncoding.DecodeString(userData)
 	s.NoError(err)
-	s.True(requeue)
+	s.Equal(exampleTalosConfig, string(decoded), "the Talos machine config must not be modified")
+
+	_, err = s.service.renderUserData(s.machineScope, exampleTalosConfig, bootstrapDataFormatTalos,
+		userDataPart{contentType: "text/x-shellscript", content: []byte("#!/bin/sh")})
+	s.ErrorContains(err, "only supported with cloud-config")
 }
 
 func (s *serverSuite) prepareReconcileServerRequestTest() {
@@ -203,6 +1399,8 @@ func (s *serverSuite) prepareReconcileServerRequestTest() {
 	s.machineScope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("test")
 	s.machineScope.IonosMachine.Spec.ProviderID = nil
 	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{}}, nil).Once()
+	// Servers of type CUBE don't have a CPU family.
+	s.mockGetDatacenterCall().Return(s.exampleDatacenter("AMD_OPTERON"), nil).Maybe()
 }
 
 func (s *serverSuite) TestReconcileServerDeletion() {
@@ -243,6 +1441,177 @@ func (s *serverSuite) TestReconcileServerDeletionDeleteBootVolume() {
 
 	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
 	s.validateSuccessfulDeletionResponse(res, err, reqLocationVolume)
+	s.Equal(infrav1.DeletingVolumesReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
+
+	res, err = s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationServer)
+	s.Equal(clusterv1.DeletingReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
+}
+
+func (s *serverSuite) TestReconcileServerDeletionDeleteAdditionalVolumes() {
+	s.infraMachine.Spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data"}}
+	additionalVolumes := &sdk.AttachedVolumes{Items: &[]sdk.Volume{{
+		Id: ptr.To(exampleAdditionalVolumeID),
+		Properties: &sdk.VolumeProperties{
+			Name: ptr.To(s.service.additionalVolumeName(s.infraMachine, "data")),
+		},
+	}, {
+		Id: ptr.To("foreign-volume"),
+		Properties: &sdk.VolumeProperties{
+			Name: ptr.To("pvc-volume"),
+		},
+	}}}
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id:       ptr.To(exampleServerID),
+		Entities: &sdk.ServerEntities{Volumes: additionalVolumes},
+	}, nil).Once()
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id: ptr.To(exampleServerID),
+	}, nil).Once()
+
+	reqLocationVolume := "delete/location/volume"
+	reqLocationServer := "delete/location/server"
+
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+	s.mockDeleteVolumeCall(exampleAdditionalVolumeID).Return(reqLocationVolume, nil).Once()
+	s.mockDeleteServerCall(exampleServerID, false).Return(reqLocationServer, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationVolume)
+
+	res, err = s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationServer)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionDetachVolumes() {
+	foreignVolumes := &sdk.AttachedVolumes{Items: &[]sdk.Volume{{
+		Id: ptr.To("foreign-volume"),
+		Properties: &sdk.VolumeProperties{
+			Name: ptr.To("pvc-volume"),
+		},
+	}}}
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id:       ptr.To(exampleServerID),
+		Entities: &sdk.ServerEntities{Volumes: foreignVolumes},
+	}, nil).Once()
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id: ptr.To(exampleServerID),
+	}, nil).Once()
+
+	reqLocationVolume := "delete/location/volume"
+	reqLocationServer := "delete/location/server"
+
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+	s.ionosClient.EXPECT().DetachVolume(s.ctx, s.machineScope.DatacenterID(), exampleServerID, "foreign-volume").
+		Return(reqLocationVolume, nil).Once()
+	s.mockDeleteServerCall(exampleServerID, false).Return(reqLocationServer, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationVolume)
+	s.Equal(infrav1.DetachingVolumesReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
+
+	res, err = s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationServer)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionRetainVolumes() {
+	s.clusterScope.Cluster.DeletionTimestamp = ptr.To(metav1.Now())
+	s.infraMachine.Spec.VolumeDeletionPolicy = infrav1.VolumeDeletionPolicyRetain
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id: ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{
+			BootVolume: &sdk.ResourceReference{
+				Id: ptr.To(exampleBootVolumeID),
+			},
+		},
+		Entities: &sdk.ServerEntities{Volumes: &sdk.AttachedVolumes{Items: &[]sdk.Volume{{
+			Id: ptr.To(exampleBootVolumeID),
+		}}}},
+	}, nil).Once()
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id: ptr.To(exampleServerID),
+	}, nil).Once()
+
+	reqLocationVolume := "delete/location/volume"
+	reqLocationServer := "delete/location/server"
+
+	datacenterID := s.machineScope.DatacenterID()
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+	s.ionosClient.EXPECT().ListVolumeLabels(s.ctx, datacenterID, exampleBootVolumeID).
+		Return(&sdk.LabelResources{}, nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, exampleBootVolumeID,
+		clusterNameLabelKey, s.capiCluster.Name).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, exampleBootVolumeID,
+		clusterNamespaceLabelKey, s.capiCluster.Namespace).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, exampleBootVolumeID,
+		machineNameLabelKey, s.infraMachine.Name).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, exampleBootVolumeID,
+		orphanedLabelKey, "true").Return(nil).Once()
+	s.ionosClient.EXPECT().DetachVolume(s.ctx, datacenterID, exampleServerID, exampleBootVolumeID).
+		Return(reqLocationVolume, nil).Once()
+	s.mockDeleteServerCall(exampleServerID, true).Return(reqLocationServer, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationVolume)
+	s.Equal(infrav1.RetainingVolumesReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
+
+	res, err = s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationServer)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionRetainAdditionalVolume() {
+	s.clusterScope.Cluster.DeletionTimestamp = ptr.To(metav1.Now())
+	s.infraMachine.Spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data", Retain: true}, {Name: "cache"}}
+	volumes := []sdk.Volume{{
+		Id:         ptr.To(exampleBootVolumeID),
+		Properties: &sdk.VolumeProperties{Name: ptr.To(s.service.volumeName(s.infraMachine))},
+	}, {
+		Id:         ptr.To("cache-volume"),
+		Properties: &sdk.VolumeProperties{Name: ptr.To(s.service.additionalVolumeName(s.infraMachine, "cache"))},
+	}, {
+		Id:         ptr.To("data-volume"),
+		Properties: &sdk.VolumeProperties{Name: ptr.To(s.service.additionalVolumeName(s.infraMachine, "data"))},
+	}}
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: ptr.To(exampleBootVolumeID)}},
+		Entities:   &sdk.ServerEntities{Volumes: &sdk.AttachedVolumes{Items: &volumes}},
+	}, nil).Once()
+
+	s.mockGetServerCall(exampleServerID).Return(&sdk.Server{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{BootVolume: &sdk.ResourceReference{Id: ptr.To(exampleBootVolumeID)}},
+		Entities:   &sdk.ServerEntities{Volumes: &sdk.AttachedVolumes{Items: ptr.To(volumes[:2])}},
+	}, nil).Once()
+
+	reqLocationVolume := "delete/location/volume"
+	reqLocationServer := "delete/location/server"
+
+	// Only the retained volume is detached, while the other volumes are deleted together with the server.
+	datacenterID := s.machineScope.DatacenterID()
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+	s.ionosClient.EXPECT().ListVolumeLabels(s.ctx, datacenterID, "data-volume").
+		Return(&sdk.LabelResources{}, nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, "data-volume",
+		clusterNameLabelKey, s.capiCluster.Name).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, "data-volume",
+		clusterNamespaceLabelKey, s.capiCluster.Namespace).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, "data-volume",
+		machineNameLabelKey, s.infraMachine.Name).Return(nil).Once()
+	s.ionosClient.EXPECT().CreateVolumeLabel(s.ctx, datacenterID, "data-volume",
+		orphanedLabelKey, "true").Return(nil).Once()
+	s.ionosClient.EXPECT().DetachVolume(s.ctx, datacenterID, exampleServerID, "data-volume").
+		Return(reqLocationVolume, nil).Once()
+	s.mockDeleteServerCall(exampleServerID, true).Return(reqLocationServer, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocationVolume)
+	s.Equal(infrav1.RetainingVolumesReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
 
 	res, err = s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
 	s.validateSuccessfulDeletionResponse(res, err, reqLocationServer)
@@ -277,6 +1646,104 @@ func (s *serverSuite) validateSuccessfulDeletionResponse(success bool, err error
 	s.Equal(s.machineScope.IonosMachine.Status.CurrentRequest.RequestPath, requestLocation)
 }
 
+func (s *serverSuite) TestReconcileServerDeletionShutdownServer() {
+	s.mockGetServerCall(exampleServerID).Return(s.runningServer(), nil)
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+	s.ionosClient.EXPECT().StopServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID).
+		Return("stop/location", nil).Once()
+
+	requeue, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.NotNil(s.infraMachine.Status.ShutdownRequestedAt)
+	s.Equal(infrav1.ShuttingDownReason, conditions.GetReason(s.infraMachine, infrav1.ServerDeletedCondition))
+	s.False(conditions.Has(s.infraMachine, infrav1.ServerCreatedCondition), "the server condition must be kept")
+}
+
+func (s *serverSuite) TestReconcileServerDeletionWaitForShutdown() {
+	// The shutdown is timed independently of the transition time of a condition, which was already false.
+	conditions.Set(s.infraMachine, &clusterv1.Condition{
+		Type:               infrav1.ServerCreatedCondition,
+		Status:             corev1.ConditionFalse,
+		Severity:           clusterv1.ConditionSeverityInfo,
+		Reason:             infrav1.ShuttingDownReason,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+	})
+	s.infraMachine.Status.ShutdownRequestedAt = ptr.To(metav1.Now())
+	s.mockGetServerCall(exampleServerID).Return(s.runningServer(), nil)
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+
+	requeue, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionShutdownTimeout() {
+	s.infraMachine.Spec.ShutdownTimeout = &metav1.Duration{Duration: time.Minute}
+	s.infraMachine.Status.ShutdownRequestedAt = ptr.To(metav1.NewTime(time.Now().Add(-2 * time.Minute)))
+	s.mockGetServerCall(exampleServerID).Return(s.runningServer(), nil)
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+
+	reqLocation := "delete/location"
+	s.mockDeleteServerCall(exampleServerID, false).Return(reqLocation, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocation)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionShutdownDisabled() {
+	s.infraMachine.Spec.ShutdownTimeout = &metav1.Duration{}
+	s.mockGetServerCall(exampleServerID).Return(s.runningServer(), nil)
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+
+	reqLocation := "delete/location"
+	s.mockDeleteServerCall(exampleServerID, false).Return(reqLocation, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocation)
+}
+
+func (s *serverSuite) TestReconcileServerDeletionAfterShutdown() {
+	s.infraMachine.Status.ShutdownRequestedAt = ptr.To(metav1.Now())
+	server := s.runningServer()
+	server.Properties.VmState = ptr.To("SHUTOFF")
+	s.mockGetServerCall(exampleServerID).Return(server, nil)
+	s.mockGetServerDeletionRequestCall(exampleServerID).Return(nil, nil)
+
+	reqLocation := "delete/location"
+	s.mockDeleteServerCall(exampleServerID, false).Return(reqLocation, nil)
+
+	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
+	s.validateSuccessfulDeletionResponse(res, err, reqLocation)
+}
+
+// resizableServer returns an available and running server with the given resources, whose boot volume
+// supports hot-plugging cores and memory if hotPlug is true.
+func (s *serverSuite) resizableServer(cores, ram int32, hotPlug bool) *sdk.Server {
+	server := s.runningServer()
+	server.Metadata = &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+	server.Properties.Cores = &cores
+	server.Properties.Ram = &ram
+	server.Properties.BootVolume = &sdk.ResourceReference{Id: ptr.To(exampleBootVolumeID)}
+	server.Entities = &sdk.ServerEntities{Volumes: &sdk.AttachedVolumes{Items: &[]sdk.Volume{{
+		Id: ptr.To(exampleBootVolumeID),
+		Properties: &sdk.VolumeProperties{
+			CpuHotPlug: &hotPlug,
+			RamHotPlug: &hotPlug,
+		},
+	}}}}
+	return server
+}
+
+func (s *serverSuite) runningServer() *sdk.Server {
+	return &sdk.Server{
+		Id: ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{
+			VmState: ptr.To("RUNNING"),
+		},
+	}
+}
+
 func (s *serverSuite) TestReconcileServerDeletionServerNotFound() {
 	s.mockGetServerCall(exampleServerID).Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, 404))
 	s.mockGetServerCreationRequestCall().Return([]sdk.Request{s.examplePostRequest(sdk.RequestStatusDone)}, nil)
@@ -285,6 +1752,7 @@ func (s *serverSuite) TestReconcileServerDeletionServerNotFound() {
 	res, err := s.service.ReconcileServerDeletion(s.ctx, s.machineScope)
 	s.NoError(err)
 	s.False(res)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.ServerDeletedCondition))
 }
 
 func (s *serverSuite) TestReconcileServerDeletionUnexpectedError() {
@@ -397,10 +1865,104 @@ func (s *serverSuite) TestGetServerWithoutProviderIDFoundInList() {
 			},
 		},
 	}}, nil)
+	s.mockListServerLabelsCall("").Return(labelResources(nil), nil).Once()
+
+	server, err := s.service.getServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.NotNil(server)
+}
+
+func (s *serverSuite) TestGetServerAdoptsOldestDuplicate() {
+	s.machineScope.IonosMachine.Spec.ProviderID = nil
+	created := time.Now()
+	duplicate := func(id string, createdDate time.Time) sdk.Server {
+		return sdk.Server{
+			Id:         ptr.To(id),
+			Metadata:   &sdk.DatacenterElementMetadata{CreatedDate: &sdk.IonosTime{Time: createdDate}},
+			Properties: &sdk.ServerProperties{Name: ptr.To(s.infraMachine.Name)},
+		}
+	}
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
+		duplicate("newer", created.Add(time.Minute)),
+		{Id: ptr.To("other"), Properties: &sdk.ServerProperties{Name: ptr.To("other")}},
+		duplicate("older", created),
+	}}, nil)
+	s.mockListServerLabelsCall("older").Return(labelResources(nil), nil).Once()
+
+	server, err := s.service.getServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal("older", ptr.Deref(server.GetId(), ""))
+	s.Equal("older", s.machineScope.ServerID())
+}
+
+func (s *serverSuite) TestGetServerSkipsServerOfOtherMachine() {
+	s.machineScope.IonosMachine.Spec.ProviderID = nil
+	created := time.Now()
+	duplicate := func(id string, createdDate time.Time) sdk.Server {
+		return sdk.Server{
+			Id:         ptr.To(id),
+			Metadata:   &sdk.DatacenterElementMetadata{CreatedDate: &sdk.IonosTime{Time: createdDate}},
+			Properties: &sdk.ServerProperties{Name: ptr.To(s.infraMachine.Name)},
+		}
+	}
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{
+		duplicate("own", created.Add(time.Minute)),
+		duplicate("other-namespace", created),
+	}}, nil)
+	// The machine of a cluster with the same name in another namespace has the same hostname.
+	s.mockListServerLabelsCall("other-namespace").Return(labelResources(map[string]string{
+		clusterNameLabelKey:      s.clusterScope.Cluster.Name,
+		clusterNamespaceLabelKey: "other",
+		machineNameLabelKey:      s.infraMachine.Name,
+	}), nil).Once()
+	s.mockListServerLabelsCall("own").Return(labelResources(map[string]string{
+		clusterNameLabelKey:      s.clusterScope.Cluster.Name,
+		clusterNamespaceLabelKey: s.clusterScope.Cluster.Namespace,
+		machineNameLabelKey:      s.infraMachine.Name,
+	}), nil).Once()
+
+	server, err := s.service.getServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Equal("own", ptr.Deref(server.GetId(), ""))
+	s.Equal("own", s.machineScope.ServerID())
+}
+
+func (s *serverSuite) TestGetServerDoesNotAdoptServerOfOtherMachine() {
+	s.machineScope.IonosMachine.Spec.ProviderID = nil
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{Name: ptr.To(s.infraMachine.Name)},
+	}}}, nil)
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(map[string]string{
+		clusterNameLabelKey: s.clusterScope.Cluster.Name,
+		machineNameLabelKey: "other-machine",
+	}), nil).Once()
+
+	server, err := s.service.getServer(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.Nil(server)
+	s.Empty(s.machineScope.ServerID())
+}
+
+func (s *serverSuite) TestGetServerWithHostnameFormatAfterRestart() {
+	// The status with the hostname was lost after the creation of the server was requested.
+	s.machineScope.IonosMachine.Spec.ProviderID = nil
+	s.infraMachine.Spec.HostnameFormat = "{{ .ClusterName }}-{{ .Random }}"
+	s.NoError(renderHostname(s.machineScope))
+	hostname := s.infraMachine.Status.Hostname
+	s.infraMachine.Status.Hostname = ""
+
+	s.mockListServersCall().Return(&sdk.Servers{Items: &[]sdk.Server{{
+		Id:         ptr.To(exampleServerID),
+		Properties: &sdk.ServerProperties{Name: ptr.To(hostname)},
+	}}}, nil)
+	s.mockListServerLabelsCall(exampleServerID).Return(labelResources(nil), nil).Once()
 
 	server, err := s.service.getServer(s.ctx, s.machineScope)
 	s.NoError(err)
 	s.NotNil(server)
+	s.Equal(hostname, s.infraMachine.Status.Hostname)
+	s.Equal(exampleServerID, s.machineScope.ServerID())
 }
 
 //nolint:unused
@@ -426,6 +1988,10 @@ func (s *serverSuite) mockListServersCall() *clienttest.MockClient_ListServers_C
 	return s.ionosClient.EXPECT().ListServers(s.ctx, s.machineScope.DatacenterID())
 }
 
+func (s *serverSuite) mockListServerLabelsCall(serverID string) *clienttest.MockClient_ListServerLabels_Call {
+	return s.ionosClient.EXPECT().ListServerLabels(s.ctx, s.machineScope.DatacenterID(), serverID)
+}
+
 func (s *serverSuite) mockDeleteVolumeCall(volumeID string) *clienttest.MockClient_DeleteVolume_Call {
 	return s.ionosClient.EXPECT().DeleteVolume(s.ctx, s.machineScope.DatacenterID(), volumeID)
 }
@@ -453,6 +2019,56 @@ func (s *serverSuite) mockCreateServerCall(serverType infrav1.ServerType) *clien
 	)
 }
 
+func (s *serverSuite) mockGetDatacenterCall() *clienttest.MockClient_GetDatacenter_Call {
+	return s.ionosClient.EXPECT().GetDatacenter(s.ctx, s.machineScope.DatacenterID())
+}
+
+// exampleDatacenter returns a data center in de/txl, which offers the given CPU families.
+func (s *serverSuite) exampleDatacenter(cpuFamilies ...string) *sdk.Datacenter {
+	architectures := make([]sdk.CpuArchitectureProperties, 0, len(cpuFamilies))
+	for _, family := range cpuFamilies {
+		architectures = append(architectures, sdk.CpuArchitectureProperties{CpuFamily: ptr.To(family)})
+	}
+	return &sdk.Datacenter{
+		Id: ptr.To(s.machineScope.DatacenterID()),
+		Properties: &sdk.DatacenterProperties{
+			Location:        ptr.To("de/txl"),
+			CpuArchitecture: &architectures,
+		},
+	}
+}
+
+func (*serverSuite) exampleSnapshot(id, name, location string, sizeGB float32) *sdk.Snapshot {
+	return &sdk.Snapshot{
+		Id: ptr.To(id),
+		Properties: &sdk.SnapshotProperties{
+			Name:     ptr.To(name),
+			Location: ptr.To(location),
+			Size:     ptr.To(sizeGB),
+		},
+	}
+}
+
+func (s *serverSuite) mockListTemplatesCall() *clienttest.MockClient_ListTemplates_Call {
+	return s.ionosClient.EXPECT().ListTemplates(s.ctx)
+}
+
+// exampleTemplates returns CUBE templates with the given names. The first one has the ID exampleTemplateID.
+func (*serverSuite) exampleTemplates(names ...string) *sdk.Templates {
+	items := make([]sdk.Template, 0, len(names))
+	for i, name := range names {
+		id := exampleTemplateID
+		if i > 0 {
+			id = fmt.Sprintf("template-%d", i)
+		}
+		items = append(items, sdk.Template{
+			Id:         &id,
+			Properties: &sdk.TemplateProperties{Name: ptr.To(name)},
+		})
+	}
+	return &sdk.Templates{Items: &items}
+}
+
 func (s *serverSuite) mockStartServerCall() *clienttest.MockClient_StartServer_Call {
 	return s.ionosClient.EXPECT().StartServer(s.ctx, s.machineScope.DatacenterID(), exampleServerID)
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// importServer adopts the existing server, which is referenced by the machine, by setting the provider ID
+// of the machine to it, once the server has been verified to match the machine. The server is labeled and
+// reconciled like any other server afterward. As long as the server doesn't match, the import is retried.
+func (s *Service) importServer(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("importServer")
+
+	serverID := ms.IonosMachine.Spec.ImportExisting
+	server, err := s.getServerByServerID(ctx, ms.DatacenterID(), serverID)
+	if ignoreNotFound(err) != nil {
+		return false, err
+	}
+
+	var mismatches []string
+	if server == nil {
+		mismatches = []string{"server doesn't exist in data center " + ms.DatacenterID()}
+	} else if mismatches, err = s.verifyImportedServer(ctx, ms, server); err != nil {
+		return false, err
+	}
+	if len(mismatches) > 0 {
+		message := fmt.Sprintf("server %s cannot be imported: %s", serverID, strings.Join(mismatches, ", "))
+		log.Info("Postponing server import", "reason", message)
+		conditions.MarkFalse(ms.IonosMachine, infrav1.ServerCreatedCondition,
+			infrav1.ServerImportRejectedReason, clusterv1.ConditionSeverityWarning, "%s", message)
+		s.recordWarningEvent(ms.IonosMachine, infrav1.ServerImportRejectedReason, "%s", message)
+		return true, nil
+	}
+
+	log.Info("Importing existing server", "serverID", serverID)
+	s.recordEvent(ms.IonosMachine, serverAdoptedReason, "Imported existing server %s", serverID)
+	ms.SetProviderID(serverID)
+	return true, nil
+}
+
+// verifyImportedServer returns the properties of the server, which don't match the machine. Cores and memory
+// are verified, so that the server is not resized unexpectedly once it has been imported. A server, which
+// carries the labels of another machine, is not imported, as it is already managed by Cluster API.
+func (s *Service) verifyImportedServer(
+	ctx context.Context, ms *scope.Machine, server *sdk.Server,
+) ([]string, error) {
+	var mismatches []string
+	spec := ms.IonosMachine.Spec
+	properties := server.GetProperties()
+	if serverType := ptr.Deref(properties.GetType(), ""); spec.Type != "" && serverType != spec.Type.String() {
+		mismatches = append(mismatches, fmt.Sprintf("type %s doesn't match %s", serverType, spec.Type))
+	}
+	if spec.Type != infrav1.ServerTypeCube {
+		// The cores and memory of CUBE servers are defined by their template.
+		if cores := ptr.Deref(properties.GetCores(), 0); cores != spec.NumCores {
+			mismatches = append(mismatches, fmt.Sprintf("%d cores don't match %d", cores, spec.NumCores))
+		}
+		if ram := ptr.Deref(properties.GetRam(), 0); ram != spec.MemoryMB {
+			mismatches = append(mismatches, fmt.Sprintf("%d MB of memory don't match %d MB", ram, spec.MemoryMB))
+		}
+	}
+
+	lan, err := s.getLAN(ctx, ms)
+	if err != nil {
+		return nil, err
+	}
+	connected := false
+	for _, nic := range ptr.Deref(server.GetEntities().GetNics().GetItems(), nil) {
+		if lan != nil && fmt.Sprint(ptr.Deref(nic.GetProperties().GetLan(), 0)) == ptr.Deref(lan.GetId(), "") {
+			connected = true
+		}
+	}
+	if !connected {
+		mismatches = append(mismatches, "server is not connected to the LAN of the cluster")
+	}
+
+	labels, err := currentLabels(ctx, s.serverLabelOperations(ms.DatacenterID(), ptr.Deref(server.GetId(), "")))
+	if err != nil {
+		return nil, fmt.Errorf("could not get labels of server %s: %w", ptr.Deref(server.GetId(), ""), err)
+	}
+	if ownedByOtherMachine(ms, labels) {
+		mismatches = append(mismatches, fmt.Sprintf("server belongs to machine %s of cluster %s",
+			labels[machineNameLabelKey], labels[clusterNameLabelKey]))
+	}
+	return mismatches, nil
+}
@@ -19,13 +19,13 @@ package cloud
 
 import (
 	"errors"
-	"net/http"
 
 	"github.com/go-logr/logr"
-	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
 )
 
 const (
@@ -35,19 +35,38 @@ const (
 
 // Service offers infra resources services for IONOS Cloud machine reconciliation.
 type Service struct {
-	logger      logr.Logger
-	ionosClient ionoscloud.Client
+	logger        logr.Logger
+	ionosClient   ionoscloud.Client
+	objectStorage ionoscloud.ObjectStorage
+	dns           ionoscloud.DNS
+	recorder      record.EventRecorder
+
+	datacenterCache *DatacenterCache
+	templateCache   *TemplateCache
+
+	// ignoredRequests are the IDs of abandoned requests, which are ignored by request lookups while they are pending.
+	ignoredRequests []string
 }
 
 // NewService returns a new Service.
-func NewService(ionosClient ionoscloud.Client, log logr.Logger) (*Service, error) {
+func NewService(ionosClient ionoscloud.Client, log logr.Logger, opts ...Option) (*Service, error) {
 	if ionosClient == nil {
 		return nil, errors.New("IONOS Cloud client is required")
 	}
-	return &Service{
+	s := &Service{
 		logger:      log,
 		ionosClient: ionosClient,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
+}
+
+// IgnoreRequests makes the Service ignore the pending requests with the given IDs, when it looks up requests
+// for a resource. This allows issuing the operation of a stuck request again.
+func (s *Service) IgnoreRequests(ids ...string) {
+	s.ignoredRequests = append(s.ignoredRequests, ids...)
 }
 
 // apiWithDepth is a shortcut for the IONOS Cloud Client with a specific depth.
@@ -56,23 +75,9 @@ func (s *Service) apiWithDepth(depth int32) ionoscloud.Client {
 	return client.WithDepth(s.ionosClient, depth)
 }
 
-// isNotFound is a shortcut for checking if an error is a not found error.
-func isNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	var target sdk.GenericOpenAPIError
-	if errors.As(err, &target) {
-		return target.StatusCode() == http.StatusNotFound
-	}
-
-	return false
-}
-
 // ignoreNotFound is a shortcut for ignoring not found errors.
 func ignoreNotFound(err error) error {
-	if isNotFound(err) {
+	if ionoserrors.IsNotFound(err) {
 		return nil
 	}
 	return err
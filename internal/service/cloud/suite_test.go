@@ -25,6 +25,7 @@ import (
 
 	"github.com/go-logr/logr"
 	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,7 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
@@ -62,17 +63,32 @@ const (
 )
 
 const (
-	exampleLANID             = "42"
-	exampleNICID             = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e3e"
-	exampleSecondaryNICID    = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e3d"
-	exampleIPBlockID         = "f882d597-4ee2-4b89-b01a-cbecd0f513d8"
-	exampleServerID          = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebf"
-	exampleBootVolumeID      = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebf"
-	exampleSecondaryServerID = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebd"
-	exampleRequestPath       = "/test"
-	exampleLocation          = "de/txl"
+	exampleLANID              = "42"
+	exampleNICID              = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e3e"
+	exampleSecondaryNICID     = "f3b3f8e4-3b6d-4b6d-8f1d-3e3e6e3e3e3d"
+	exampleIPBlockID          = "f882d597-4ee2-4b89-b01a-cbecd0f513d8"
+	exampleServerID           = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebf"
+	exampleBootVolumeID       = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebf"
+	exampleAdditionalVolumeID = "dd426c63-cd1d-4c02-aca3-13b4a27c2eb0"
+	exampleSecondaryServerID  = "dd426c63-cd1d-4c02-aca3-13b4a27c2ebd"
+	exampleTemplateID         = "15c6dd2f-02d2-4987-b439-9a58dd59ecc3"
+	exampleSnapshotID         = "7d3a8e2b-1f4c-4b6a-9c2d-5e8f0a1b2c3d"
+	exampleRequestPath        = "/test"
+	exampleLocation           = "de/txl"
 )
 
+// exampleTalosConfig is the beginning of a Talos machine config, as rendered by the Talos bootstrap provider.
+const exampleTalosConfig = `version: v1alpha1
+debug: false
+persist: true
+machine:
+  type: worker
+  token: 7f1a2b.3c4d5e6f7a8b9c0d
+cluster:
+  controlPlane:
+    endpoint: https://203.0.113.1:6443
+`
+
 type ServiceTestSuite struct {
 	*require.Assertions
 	suite.Suite
@@ -102,6 +118,8 @@ func TestServiceTestSuite(t *testing.T) {
 func (s *ServiceTestSuite) SetupTest() {
 	var err error
 	s.ionosClient = clienttest.NewMockClient(s.T())
+	// Without resource limits, the contract doesn't restrict the creation of servers.
+	s.ionosClient.EXPECT().ListContracts(mock.Anything).Return(&sdk.Contracts{}, nil).Maybe()
 
 	s.capiCluster = &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// DefaultTemplateCacheTTL is the time, for which the templates are cached, if the TemplateCache has no TTL.
+const DefaultTemplateCacheTTL = time.Hour
+
+// TemplateCache caches the catalog of the templates of CUBE servers, which rarely changes and is the same for
+// all contracts. It is shared by the services of all reconciliations. The zero value is ready to use.
+type TemplateCache struct {
+	// TTL is the time, after which the templates are listed again. It defaults to DefaultTemplateCacheTTL.
+	TTL time.Duration
+
+	mu        sync.Mutex
+	templates []sdk.Template
+	expiresAt time.Time
+
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+// WithTemplateCache makes the Service cache the templates of CUBE servers.
+func WithTemplateCache(cache *TemplateCache) Option {
+	return func(s *Service) {
+		s.templateCache = cache
+	}
+}
+
+// Templates returns the templates of CUBE servers. They are only listed with the given client, if they are
+// not cached. If the cache is nil, they are always listed. The returned templates must not be modified.
+func (c *TemplateCache) Templates(ctx context.Context, ionosClient ionoscloud.Client) ([]sdk.Template, error) {
+	if c == nil {
+		return listTemplates(ctx, ionosClient)
+	}
+
+	// Concurrent reconciliations wait for the first one to list the templates.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if c.now != nil {
+		now = c.now()
+	}
+	if c.templates != nil && now.Before(c.expiresAt) {
+		return c.templates, nil
+	}
+
+	templates, err := listTemplates(ctx, ionosClient)
+	if err != nil {
+		return nil, err
+	}
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultTemplateCacheTTL
+	}
+	c.templates, c.expiresAt = templates, now.Add(ttl)
+	return templates, nil
+}
+
+func listTemplates(ctx context.Context, ionosClient ionoscloud.Client) ([]sdk.Template, error) {
+	templates, err := ionosClient.ListTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list templates: %w", err)
+	}
+	return ptr.Deref(templates.GetItems(), []sdk.Template{}), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloud
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/suite"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+type templateCacheSuite struct {
+	ServiceTestSuite
+
+	cache *TemplateCache
+	now   time.Time
+}
+
+func TestTemplateCacheSuite(t *testing.T) {
+	suite.Run(t, new(templateCacheSuite))
+}
+
+func (s *templateCacheSuite) SetupTest() {
+	s.ServiceTestSuite.SetupTest()
+	s.now = time.Now()
+	s.cache = &TemplateCache{TTL: time.Hour, now: func() time.Time { return s.now }}
+	WithTemplateCache(s.cache)(s.service)
+}
+
+func (s *templateCacheSuite) templates() *sdk.Templates {
+	return &sdk.Templates{Items: &[]sdk.Template{{
+		Id:         ptr.To(exampleTemplateID),
+		Properties: &sdk.TemplateProperties{Name: ptr.To("Basic Cube XS")},
+	}}}
+}
+
+func (s *templateCacheSuite) TestCachedTemplates() {
+	s.ionosClient.EXPECT().ListTemplates(s.ctx).Return(s.templates(), nil).Once()
+
+	for range 2 {
+		id, err := s.service.getTemplateID(s.ctx, &infrav1.ServerTemplate{Name: "Basic Cube XS"})
+		s.NoError(err)
+		s.Equal(exampleTemplateID, id)
+	}
+}
+
+func (s *templateCacheSuite) TestExpiredTemplates() {
+	s.ionosClient.EXPECT().ListTemplates(s.ctx).Return(s.templates(), nil).Twice()
+
+	_, err := s.cache.Templates(s.ctx, s.ionosClient)
+	s.NoError(err)
+	s.now = s.now.Add(time.Hour)
+	_, err = s.cache.Templates(s.ctx, s.ionosClient)
+	s.NoError(err)
+}
+
+func (s *templateCacheSuite) TestFailedListIsNotCached() {
+	s.ionosClient.EXPECT().ListTemplates(s.ctx).Return(nil, errors.New("timeout")).Once()
+	s.ionosClient.EXPECT().ListTemplates(s.ctx).Return(s.templates(), nil).Once()
+
+	_, err := s.cache.Templates(s.ctx, s.ionosClient)
+	s.Error(err)
+	templates, err := s.cache.Templates(s.ctx, s.ionosClient)
+	s.NoError(err)
+	s.Len(templates, 1)
+	_, err = s.cache.Templates(s.ctx, s.ionosClient)
+	s.NoError(err)
+}
+
+func (s *templateCacheSuite) TestNilCache() {
+	var cache *TemplateCache
+	s.ionosClient.EXPECT().ListTemplates(s.ctx).Return(s.templates(), nil).Twice()
+
+	for range 2 {
+		templates, err := cache.Templates(s.ctx, s.ionosClient)
+		s.NoError(err)
+		s.Len(templates, 1)
+	}
+}
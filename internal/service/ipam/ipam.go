@@ -0,0 +1,177 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ipam offers services for claiming IP addresses of IONOS Cloud machines from Cluster API IPAM providers.
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+// Service claims IP addresses for the NICs of IonosCloudMachines.
+type Service struct {
+	logger logr.Logger
+	client client.Client
+}
+
+// NewService returns a new Service.
+func NewService(c client.Client, log logr.Logger) (*Service, error) {
+	if c == nil {
+		return nil, errors.New("kubernetes client is required")
+	}
+	return &Service{
+		logger: log,
+		client: c,
+	}, nil
+}
+
+// ReconcileIPAddresses ensures that an IPAddressClaim exists for each NIC of the machine, which references
+// an IPAM pool, and waits for the IPAM provider to allocate the addresses. The claimed addresses are stored
+// in the machine scope, so that they can be assigned to the NICs.
+// The claims are owned by the IonosCloudMachine, which means that the addresses are released once the machine
+// has been deleted.
+func (s *Service) ReconcileIPAddresses(ctx context.Context, ms *scope.Machine) (requeue bool, err error) {
+	log := s.logger.WithName("ReconcileIPAddresses")
+
+	poolRefs := nicPoolRefs(ms.IonosMachine)
+	if len(poolRefs) == 0 {
+		return false, nil
+	}
+
+	addresses := make(map[int]string, len(poolRefs))
+	for _, ref := range poolRefs {
+		claim, err := s.ensureClaim(ctx, ms, ref.index, ref.poolRef)
+		if err != nil {
+			return false, err
+		}
+
+		address, err := s.getClaimedAddress(ctx, claim)
+		if err != nil {
+			return false, err
+		}
+		if address == "" {
+			log.Info("Waiting for IP address to be allocated", "claim", claim.Name)
+			conditions.MarkFalse(ms.IonosMachine, infrav1.IPAddressClaimedCondition,
+				infrav1.WaitingForIPAddressReason, clusterv1.ConditionSeverityInfo,
+				"Waiting for IPAddressClaim %s to be bound", claim.Name)
+			return true, nil
+		}
+		addresses[ref.index] = address
+	}
+
+	ms.ClaimedIPv4Addresses = addresses
+	conditions.MarkTrue(ms.IonosMachine, infrav1.IPAddressClaimedCondition)
+	return false, nil
+}
+
+// ensureClaim returns the IPAddressClaim of the NIC with the given index, creating it if it doesn't exist.
+func (s *Service) ensureClaim(
+	ctx context.Context, ms *scope.Machine, index int, poolRef *corev1.TypedLocalObjectReference,
+) (*ipamv1.IPAddressClaim, error) {
+	m := ms.IonosMachine
+	claim := &ipamv1.IPAddressClaim{}
+	key := client.ObjectKey{Namespace: m.Namespace, Name: claimName(m, index)}
+	err := s.client.Get(ctx, key, claim)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return claim, err
+	}
+
+	claim = &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: ms.ClusterScope.Cluster.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(m, infrav1.GroupVersion.WithKind(infrav1.IonosCloudMachineType)),
+			},
+		},
+		Spec: ipamv1.IPAddressClaimSpec{
+			PoolRef: *poolRef,
+		},
+	}
+	if err := s.client.Create(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to create IPAddressClaim %s: %w", key, err)
+	}
+
+	s.logger.V(4).Info("Created IPAddressClaim", "claim", key.String())
+	return claim, nil
+}
+
+// getClaimedAddress returns the IPv4 address, which was allocated for the claim.
+// An empty string is returned if the claim has not been bound yet.
+func (s *Service) getClaimedAddress(ctx context.Context, claim *ipamv1.IPAddressClaim) (string, error) {
+	if claim.Status.AddressRef.Name == "" {
+		return "", nil
+	}
+
+	address := &ipamv1.IPAddress{}
+	key := client.ObjectKey{Namespace: claim.Namespace, Name: claim.Status.AddressRef.Name}
+	if err := s.client.Get(ctx, key, address); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get IPAddress %s: %w", key, err)
+	}
+
+	addr, err := netip.ParseAddr(address.Spec.Address)
+	if err != nil || !addr.Is4() {
+		return "", fmt.Errorf("IPAddress %s does not contain a valid IPv4 address: %q", key, address.Spec.Address)
+	}
+	return addr.String(), nil
+}
+
+// claimName returns the name of the IPAddressClaim of the NIC with the given index.
+func claimName(m *infrav1.IonosCloudMachine, index int) string {
+	return fmt.Sprintf("%s-nic-%d", m.Name, index)
+}
+
+// nicPoolRef is the IPAM pool reference of the NIC with the given index.
+type nicPoolRef struct {
+	index   int
+	poolRef *corev1.TypedLocalObjectReference
+}
+
+// nicPoolRefs returns the IPAM pool references of the NICs of the machine.
+// The primary NIC has the index 0, followed by the NICs of the additional networks.
+func nicPoolRefs(m *infrav1.IonosCloudMachine) []nicPoolRef {
+	var refs []nicPoolRef
+	if m.Spec.IPv4PoolRef != nil {
+		refs = append(refs, nicPoolRef{index: 0, poolRef: m.Spec.IPv4PoolRef})
+	}
+	for i, network := range m.Spec.AdditionalNetworks {
+		if network.IPv4PoolRef != nil {
+			refs = append(refs, nicPoolRef{index: i + 1, poolRef: network.IPv4PoolRef})
+		}
+	}
+	return refs
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/scope"
+)
+
+type ipamSuite struct {
+	*require.Assertions
+	suite.Suite
+	ctx          context.Context
+	k8sClient    client.Client
+	machineScope *scope.Machine
+	infraMachine *infrav1.IonosCloudMachine
+	service      *Service
+}
+
+func TestIPAMSuite(t *testing.T) {
+	suite.Run(t, new(ipamSuite))
+}
+
+func (s *ipamSuite) SetupTest() {
+	s.Assertions = s.Require()
+	s.ctx = context.Background()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+	}
+	s.infraMachine = &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-machine",
+			UID:       "6a9d2c4e-0b2f-4b4a-8e49-4a4d0b3f7c11",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	s.NoError(clusterv1.AddToScheme(scheme))
+	s.NoError(ipamv1.AddToScheme(scheme))
+	s.NoError(infrav1.AddToScheme(scheme))
+	s.k8sClient = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(s.infraMachine).
+		WithStatusSubresource(&ipamv1.IPAddressClaim{}).
+		Build()
+
+	clusterScope, err := scope.NewCluster(scope.ClusterParams{
+		Client:       s.k8sClient,
+		Cluster:      cluster,
+		IonosCluster: &infrav1.IonosCloudCluster{},
+	})
+	s.NoError(err)
+
+	s.machineScope, err = scope.NewMachine(scope.MachineParams{
+		Client:       s.k8sClient,
+		Machine:      &clusterv1.Machine{},
+		ClusterScope: clusterScope,
+		IonosMachine: s.infraMachine,
+	})
+	s.NoError(err)
+
+	s.service, err = NewService(s.k8sClient, logr.Discard())
+	s.NoError(err)
+}
+
+func (s *ipamSuite) TestReconcileIPAddressesNoPools() {
+	requeue, err := s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Empty(s.machineScope.ClaimedIPv4Addresses)
+	s.Nil(conditions.Get(s.infraMachine, infrav1.IPAddressClaimedCondition))
+}
+
+func (s *ipamSuite) TestReconcileIPAddressesCreatesClaims() {
+	s.infraMachine.Spec.IPv4PoolRef = examplePoolRef("primary")
+	s.infraMachine.Spec.AdditionalNetworks = infrav1.Networks{
+		{NetworkID: 2},
+		{NetworkID: 3, IPAMConfig: infrav1.IPAMConfig{IPv4PoolRef: examplePoolRef("secondary")}},
+	}
+
+	requeue, err := s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.True(conditions.IsFalse(s.infraMachine, infrav1.IPAddressClaimedCondition))
+	s.Equal(infrav1.WaitingForIPAddressReason, conditions.GetReason(s.infraMachine, infrav1.IPAddressClaimedCondition))
+
+	claim := s.getClaim("test-machine-nic-0")
+	s.Equal(*examplePoolRef("primary"), claim.Spec.PoolRef)
+	s.Equal("test-cluster", claim.Labels[clusterv1.ClusterNameLabel])
+	s.Len(claim.OwnerReferences, 1)
+	s.Equal(infrav1.IonosCloudMachineType, claim.OwnerReferences[0].Kind)
+	s.True(ptr.Deref(claim.OwnerReferences[0].Controller, false))
+
+	// The claim of the next NIC is only created once the first one has been bound.
+	s.bindClaim("test-machine-nic-0", "192.0.2.10")
+	requeue, err = s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.True(requeue)
+	s.Equal(*examplePoolRef("secondary"), s.getClaim("test-machine-nic-2").Spec.PoolRef)
+
+	s.bindClaim("test-machine-nic-2", "192.0.2.11")
+	requeue, err = s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.False(requeue)
+	s.Equal(map[int]string{0: "192.0.2.10", 2: "192.0.2.11"}, s.machineScope.ClaimedIPv4Addresses)
+	s.True(conditions.IsTrue(s.infraMachine, infrav1.IPAddressClaimedCondition))
+}
+
+func (s *ipamSuite) TestReconcileIPAddressesInvalidAddress() {
+	s.infraMachine.Spec.IPv4PoolRef = examplePoolRef("primary")
+
+	_, err := s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.NoError(err)
+	s.bindClaim("test-machine-nic-0", "2001:db8::1")
+
+	_, err = s.service.ReconcileIPAddresses(s.ctx, s.machineScope)
+	s.ErrorContains(err, "does not contain a valid IPv4 address")
+}
+
+func (s *ipamSuite) getClaim(name string) *ipamv1.IPAddressClaim {
+	s.T().Helper()
+	claim := &ipamv1.IPAddressClaim{}
+	s.NoError(s.k8sClient.Get(s.ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, claim))
+	return claim
+}
+
+// bindClaim simulates an IPAM provider, which allocates an address for the claim.
+func (s *ipamSuite) bindClaim(name, address string) {
+	s.T().Helper()
+	claim := s.getClaim(name)
+	ipAddress := &ipamv1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: claim.Namespace, Name: name},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  address,
+			Prefix:   24,
+		},
+	}
+	s.NoError(s.k8sClient.Create(s.ctx, ipAddress))
+
+	claim.Status.AddressRef = corev1.LocalObjectReference{Name: ipAddress.Name}
+	s.NoError(s.k8sClient.Status().Update(s.ctx, claim))
+}
+
+func examplePoolRef(name string) *corev1.TypedLocalObjectReference {
+	return &corev1.TypedLocalObjectReference{
+		APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+		Kind:     "InClusterIPPool",
+		Name:     name,
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package locker offers locks, which serialize operations on the same key, e.g. a data center.
+package locker
+
+import "sync"
+
+// Locker holds one lock per key. The zero value is ready to use.
+type Locker struct {
+	mu     sync.Mutex
+	locked map[string]struct{}
+}
+
+// TryLock acquires the lock for the key and reports whether it succeeded.
+// It doesn't block, so that reconcilers can requeue instead of occupying a worker.
+func (l *Locker) TryLock(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.locked[key]; ok {
+		return false
+	}
+	if l.locked == nil {
+		l.locked = make(map[string]struct{})
+	}
+	l.locked[key] = struct{}{}
+	return true
+}
+
+// Unlock releases the lock for the key.
+func (l *Locker) Unlock(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.locked, key)
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package locker
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryLock(t *testing.T) {
+	var l Locker
+	require.True(t, l.TryLock("a"))
+	require.False(t, l.TryLock("a"), "a held lock must not be acquired twice")
+	require.True(t, l.TryLock("b"), "locks of different keys must be independent")
+
+	l.Unlock("a")
+	require.True(t, l.TryLock("a"))
+}
+
+func TestTryLockConcurrent(t *testing.T) {
+	var (
+		l        Locker
+		acquired atomic.Int32
+		wg       sync.WaitGroup
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.TryLock("key") {
+				acquired.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), acquired.Load())
+}
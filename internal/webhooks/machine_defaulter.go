@@ -0,0 +1,165 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachine,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachines,verbs=create,versions=v1beta1,name=default.ionoscloudmachine.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachinetemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinetemplates,verbs=create,versions=v1beta1,name=default.ionoscloudmachinetemplate.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachinepool,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinepools,verbs=create,versions=v1beta1,name=default.ionoscloudmachinepool.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+
+// MachineDefaulter defaults the machine specs of IonosCloudMachines, IonosCloudMachineTemplates and
+// IonosCloudMachinePools. Objects, which belong to an IonosCloudCluster, inherit the CPU family, the disk type
+// of the boot volume, the SSH keys and the labels of its machine defaults. Optionally, the CPU family defaults
+// to the first one offered by the data center. The static defaults, e.g. the availability zones, are declared
+// in the CRDs, while a boot volume without disk type is created as infrav1.DefaultBootVolumeDiskType.
+//
+// The webhook is only called when an object is created. Most of the defaulted fields are immutable, so applying
+// defaults to existing objects would be rejected by the validation rules of the CRDs.
+type MachineDefaulter struct {
+	Client client.Client
+
+	// RateLimiter limits the requests to the Cloud API. It is shared with the reconcilers.
+	RateLimiter *icc.RateLimiter
+
+	// APIEndpoint is the default endpoint of the Cloud API, which can be overridden in the credentials secret.
+	APIEndpoint icc.Endpoint
+
+	// DefaultCPUFamily activates defaulting the CPU family from the data center, which requires a request
+	// to the Cloud API. If the Cloud API cannot be reached, the CPU family is left unset.
+	DefaultCPUFamily bool
+
+	// newIonosClient creates the client for the Cloud API from the credentials secret.
+	// It can be replaced in tests.
+	newIonosClient func(secret *corev1.Secret) (ionoscloud.Client, error)
+}
+
+var _ admission.CustomDefaulter = &MachineDefaulter{}
+
+// SetupWebhookWithManager registers the defaulter for all kinds, which contain a machine spec.
+func (d *MachineDefaulter) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []runtime.Object{
+		&infrav1.IonosCloudMachine{},
+		&infrav1.IonosCloudMachineTemplate{},
+		&infrav1.IonosCloudMachinePool{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).WithDefaulter(d).Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Default applies the defaults to the machine spec of a new object.
+func (d *MachineDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	o, err := machineSpecOf(obj)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+
+	d.applyMachineDefaults(ctx, o)
+	if d.DefaultCPUFamily {
+		d.defaultCPUFamily(ctx, o)
+	}
+	return nil
+}
+
+// applyMachineDefaults merges the machine defaults of the IonosCloudCluster, which the object belongs to,
+// into the fields of the machine spec, which are not set. The availability zone is applied by the machine
+// controller, as it depends on the failure domain of the machine. Errors are only logged, as the object
+// might be created before its cluster.
+func (d *MachineDefaulter) applyMachineDefaults(ctx context.Context, o *machineSpecObject) {
+	ionosCluster, err := ionosClusterFor(ctx, d.Client, o.obj)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Info("Unable to apply the machine defaults of the cluster", "error", err.Error())
+		return
+	}
+	if ionosCluster == nil || ionosCluster.Spec.MachineDefaults == nil {
+		return
+	}
+
+	defaults := ionosCluster.Spec.MachineDefaults
+	spec := o.spec
+	if spec.CPUFamily == nil && defaults.CPUFamily != nil &&
+		spec.Type != infrav1.ServerTypeVCPU && spec.Type != infrav1.ServerTypeCube {
+		spec.CPUFamily = ptr.To(*defaults.CPUFamily)
+	}
+	if spec.Disk != nil && spec.Disk.DiskType == "" {
+		spec.Disk.DiskType = defaults.DiskType
+	}
+	if len(spec.SSHKeys) == 0 && len(defaults.SSHKeys) > 0 {
+		spec.SSHKeys = slices.Clone(defaults.SSHKeys)
+	}
+	for key, value := range defaults.Labels {
+		if _, ok := spec.Labels[key]; ok {
+			continue
+		}
+		if spec.Labels == nil {
+			spec.Labels = make(map[string]string, len(defaults.Labels))
+		}
+		spec.Labels[key] = value
+	}
+}
+
+// defaultCPUFamily sets the CPU family to the first one offered by the data center of the machine spec.
+// VCPU and CUBE servers don't support choosing a CPU family, and machines without a data center ID get
+// theirs assigned later, so none of them are defaulted. Errors are only logged, as the Cloud API selects a CPU family
+// for servers without one.
+func (d *MachineDefaulter) defaultCPUFamily(ctx context.Context, o *machineSpecObject) {
+	spec := o.spec
+	if spec.CPUFamily != nil || spec.Type == infrav1.ServerTypeVCPU || spec.Type == infrav1.ServerTypeCube ||
+		spec.DatacenterID == "" {
+		return
+	}
+
+	log := ctrl.LoggerFrom(ctx).WithValues("datacenterID", spec.DatacenterID)
+	ionosClient, err := ionosClientFor(ctx, d.Client, d.RateLimiter, d.APIEndpoint, d.newIonosClient, o.obj)
+	if err != nil {
+		log.Info("Unable to default the CPU family", "error", err.Error())
+		return
+	}
+	if ionosClient == nil {
+		return
+	}
+
+	datacenter, err := ionosClient.GetDatacenter(ctx, spec.DatacenterID)
+	if err != nil {
+		log.Info("Unable to default the CPU family", "error", err.Error())
+		return
+	}
+	for _, architecture := range ptr.Deref(datacenter.GetProperties().GetCpuArchitecture(), nil) {
+		if cpuFamily := ptr.Deref(architecture.GetCpuFamily(), ""); cpuFamily != "" {
+			spec.CPUFamily = &cpuFamily
+			return
+		}
+	}
+}
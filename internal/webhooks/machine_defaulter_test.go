@@ -0,0 +1,209 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name       string
+		mutateSpec func(spec *infrav1.IonosCloudMachineSpec)
+		mockCalls  func(m *clienttest.MockClient)
+		want       func(spec *infrav1.IonosCloudMachineSpec)
+	}{{
+		name: "minimal spec",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+		},
+		want: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.CPUFamily = ptr.To("AMD_OPTERON")
+		},
+	}, {
+		name: "explicit values are kept",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.AvailabilityZone = infrav1.AvailabilityZoneTwo
+			spec.CPUFamily = ptr.To("INTEL_SKYLAKE")
+			spec.Disk.DiskType = infrav1.VolumeDiskTypeHDD
+			spec.Disk.AvailabilityZone = infrav1.AvailabilityZoneTwo
+			spec.AdditionalVolumes = []infrav1.VolumeSpec{{Name: "data", AvailabilityZone: infrav1.AvailabilityZoneOne}}
+			spec.IPv6 = &infrav1.IPv6Config{DHCP: ptr.To(false)}
+		},
+		want: func(*infrav1.IonosCloudMachineSpec) {},
+	}, {
+		// A CPU family must not be set for CUBE servers.
+		name: "CUBE server",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeCube
+			spec.Template = &infrav1.ServerTemplate{Name: "Basic Cube XS"}
+		},
+		want: func(*infrav1.IonosCloudMachineSpec) {},
+	}, {
+		// A CPU family must not be set for VCPU servers.
+		name: "VCPU server",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeVCPU
+		},
+		want: func(*infrav1.IonosCloudMachineSpec) {},
+	}, {
+		name: "no data center ID",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.DatacenterID = ""
+		},
+		want: func(*infrav1.IonosCloudMachineSpec) {},
+	}, {
+		name: "API error",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(nil, errors.New("timeout")).Once()
+		},
+		want: func(*infrav1.IonosCloudMachineSpec) {},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := exampleMinimalMachine()
+			if tt.mutateSpec != nil {
+				tt.mutateSpec(&machine.Spec)
+			}
+			want := machine.Spec.DeepCopy()
+			tt.want(want)
+
+			ionosClient := clienttest.NewMockClient(t)
+			if tt.mockCalls != nil {
+				tt.mockCalls(ionosClient)
+			}
+
+			require.NoError(t, newTestDefaulter(t, ionosClient, true).Default(context.Background(), machine))
+			require.Equal(t, want, &machine.Spec)
+		})
+	}
+}
+
+func TestDefaultCPUFamilyDisabled(t *testing.T) {
+	machine := exampleMinimalMachine()
+	require.NoError(t, newTestDefaulter(t, clienttest.NewMockClient(t), false).Default(context.Background(), machine))
+	require.Nil(t, machine.Spec.CPUFamily)
+	// The disk type of the boot volume is defaulted by the machine controller.
+	require.Empty(t, machine.Spec.Disk.DiskType)
+}
+
+func TestDefaultMachineWithoutCluster(t *testing.T) {
+	machine := exampleMinimalMachine()
+	machine.Labels = nil
+	require.NoError(t, newTestDefaulter(t, clienttest.NewMockClient(t), true).Default(context.Background(), machine))
+	require.Nil(t, machine.Spec.CPUFamily)
+}
+
+func TestDefaultMachineDefaults(t *testing.T) {
+	defaulter := newTestDefaulter(t, clienttest.NewMockClient(t), false)
+	ionosCluster := &infrav1.IonosCloudCluster{}
+	key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}
+	require.NoError(t, defaulter.Client.Get(context.Background(), key, ionosCluster))
+	ionosCluster.Spec.MachineDefaults = &infrav1.MachineDefaults{
+		CPUFamily:        ptr.To("INTEL_SKYLAKE"),
+		DiskType:         infrav1.VolumeDiskTypeSSDPremium,
+		AvailabilityZone: infrav1.AvailabilityZoneTwo,
+		SSHKeys:          []string{"ssh-ed25519 AAAA cluster"},
+		Labels:           map[string]string{"team": "platform", "env": "prod"},
+	}
+	require.NoError(t, defaulter.Client.Update(context.Background(), ionosCluster))
+
+	machine := exampleMinimalMachine()
+	machine.Spec.Labels = map[string]string{"env": "dev"}
+	require.NoError(t, defaulter.Default(context.Background(), machine))
+	require.Equal(t, ptr.To("INTEL_SKYLAKE"), machine.Spec.CPUFamily)
+	require.Equal(t, infrav1.VolumeDiskTypeSSDPremium, machine.Spec.Disk.DiskType)
+	require.Equal(t, map[string]string{"team": "platform", "env": "dev"}, machine.Spec.Labels)
+	require.Equal(t, []string{"ssh-ed25519 AAAA cluster"}, machine.Spec.SSHKeys)
+	// The availability zone is applied by the machine controller.
+	require.Empty(t, machine.Spec.AvailabilityZone)
+
+	machine = exampleMinimalMachine()
+	machine.Spec.Type = infrav1.ServerTypeVCPU
+	machine.Spec.Disk.DiskType = infrav1.VolumeDiskTypeHDD
+	machine.Spec.SSHKeys = []string{"ssh-ed25519 AAAA machine"}
+	require.NoError(t, defaulter.Default(context.Background(), machine))
+	require.Nil(t, machine.Spec.CPUFamily)
+	require.Equal(t, infrav1.VolumeDiskTypeHDD, machine.Spec.Disk.DiskType)
+	require.Equal(t, []string{"ssh-ed25519 AAAA machine"}, machine.Spec.SSHKeys)
+}
+
+func TestDefaultTemplate(t *testing.T) {
+	machine := exampleMinimalMachine()
+	template := &infrav1.IonosCloudMachineTemplate{
+		ObjectMeta: machine.ObjectMeta,
+		Spec: infrav1.IonosCloudMachineTemplateSpec{
+			Template: infrav1.IonosCloudMachineTemplateResource{Spec: machine.Spec},
+		},
+	}
+	ionosClient := clienttest.NewMockClient(t)
+	ionosClient.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+
+	require.NoError(t, newTestDefaulter(t, ionosClient, true).Default(context.Background(), template))
+	require.Equal(t, ptr.To("AMD_OPTERON"), template.Spec.Template.Spec.CPUFamily)
+}
+
+func TestDefaultInvalidObject(t *testing.T) {
+	err := newTestDefaulter(t, clienttest.NewMockClient(t), true).Default(context.Background(), &corev1.Secret{})
+	require.True(t, apierrors.IsBadRequest(err))
+}
+
+// TestDefaultThenValidate checks that the defaults pass the validation against the Cloud API,
+// which runs after the defaulting webhook.
+func TestDefaultThenValidate(t *testing.T) {
+	machine := exampleMinimalMachine()
+	ionosClient := clienttest.NewMockClient(t)
+	ionosClient.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Twice()
+	ionosClient.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+
+	require.NoError(t, newTestDefaulter(t, ionosClient, true).Default(context.Background(), machine))
+	warnings, err := newTestValidator(t, ionosClient, true).ValidateCreate(context.Background(), machine)
+	require.NoError(t, err)
+	require.Empty(t, warnings, "the defaulted CPU family must be offered by the data center")
+}
+
+func newTestDefaulter(t *testing.T, ionosClient ionoscloud.Client, defaultCPUFamily bool) *MachineDefaulter {
+	t.Helper()
+
+	validator := newTestValidator(t, ionosClient, true)
+	return &MachineDefaulter{
+		Client:           validator.Client,
+		DefaultCPUFamily: defaultCPUFamily,
+		newIonosClient:   validator.newIonosClient,
+	}
+}
+
+// exampleMinimalMachine returns a machine, which doesn't set any of the defaulted fields.
+func exampleMinimalMachine() *infrav1.IonosCloudMachine {
+	machine := exampleMachine()
+	machine.Spec.CPUFamily = nil
+	return machine
+}
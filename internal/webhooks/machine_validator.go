@@ -0,0 +1,628 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhooks contains the admission webhooks, which default machine specs and validate them
+// against the Cloud API.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/topology"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/credentials"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoserrors"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// Defaults of the CRD for the resources of a machine, which are ignored for CUBE servers.
+const (
+	defaultNumCores   = 1
+	defaultMemoryMB   = 3072
+	defaultDiskSizeGB = 20
+)
+
+// Image types of the Cloud API.
+const (
+	imageTypeHDD   = "HDD"
+	imageTypeCDROM = "CDROM"
+)
+
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachine,mutating=false,failurePolicy=ignore,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachines,verbs=create;update,versions=v1beta1,name=validation.ionoscloudmachine.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachinetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinetemplates,verbs=create;update,versions=v1beta1,name=validation.ionoscloudmachinetemplate.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-ionoscloudmachinepool,mutating=false,failurePolicy=ignore,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=ionoscloudmachinepools,verbs=create;update,versions=v1beta1,name=validation.ionoscloudmachinepool.infrastructure.cluster.x-k8s.io,admissionReviewVersions=v1
+
+// MachineValidator validates the machine specs of IonosCloudMachines, IonosCloudMachineTemplates and
+// IonosCloudMachinePools against the Cloud API. It checks that the data center exists and that the image,
+// snapshot or ISO image of the CD-ROM is available in the location of the data center. It warns if the data center doesn't offer
+// the CPU family, or if it is in another location than the cluster or the IP block of the machine.
+//
+// Independent of Enabled, the spec of an IonosCloudMachineTemplate is immutable, as Cluster API expects
+// machine templates to be replaced instead of updated. Otherwise, changes would silently only apply to new machines.
+//
+// The credentials are read from the IonosCloudCluster of the cluster, which the object belongs to.
+// Objects without a cluster are not validated. If the credentials cannot be read or the Cloud API
+// cannot be reached, the object is admitted with a warning, as the controller reports the same problems
+// during reconciliation.
+type MachineValidator struct {
+	Client client.Client
+
+	// RateLimiter limits the requests to the Cloud API. It is shared with the reconcilers.
+	RateLimiter *icc.RateLimiter
+
+	// APIEndpoint is the default endpoint of the Cloud API, which can be overridden in the credentials secret.
+	APIEndpoint icc.Endpoint
+
+	// Enabled activates the validation. If it is false, all objects are admitted without contacting the Cloud API.
+	Enabled bool
+
+	// newIonosClient creates the client for the Cloud API from the credentials secret.
+	// It can be replaced in tests.
+	newIonosClient func(secret *corev1.Secret) (ionoscloud.Client, error)
+}
+
+var _ admission.CustomValidator = &MachineValidator{}
+
+// SetupWebhookWithManager registers the validator for all kinds, which contain a machine spec.
+func (v *MachineValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	for _, obj := range []runtime.Object{
+		&infrav1.IonosCloudMachine{},
+		&infrav1.IonosCloudMachineTemplate{},
+		&infrav1.IonosCloudMachinePool{},
+	} {
+		if err := ctrl.NewWebhookManagedBy(mgr).For(obj).WithValidator(v).Complete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateCreate validates the machine spec of a new object.
+func (v *MachineValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	o, err := machineSpecOf(obj)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if errs := validateSSHKeys(o.spec.SSHKeys, o.path.Child("sshKeys")); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(infrav1.GroupVersion.WithKind(o.kind).GroupKind(), o.obj.GetName(), errs)
+	}
+	return v.validate(ctx, obj, nil)
+}
+
+// ValidateUpdate validates the machine spec of an updated object, if one of the validated fields has changed.
+// Updates of the spec of an IonosCloudMachineTemplate are rejected.
+func (v *MachineValidator) ValidateUpdate(
+	ctx context.Context, oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	old, err := machineSpecOf(oldObj)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if template, ok := newObj.(*infrav1.IonosCloudMachineTemplate); ok {
+		if err := validateTemplateImmutable(ctx, old, template); err != nil {
+			return nil, err
+		}
+	}
+	return v.validate(ctx, newObj, old.spec)
+}
+
+// ValidateDelete admits all deletions.
+func (*MachineValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// machineSpecObject is an object, which contains a machine spec.
+type machineSpecObject struct {
+	obj  client.Object
+	kind string
+	spec *infrav1.IonosCloudMachineSpec
+	path *field.Path
+}
+
+func machineSpecOf(obj runtime.Object) (*machineSpecObject, error) {
+	switch o := obj.(type) {
+	case *infrav1.IonosCloudMachine:
+		return &machineSpecObject{o, "IonosCloudMachine", &o.Spec, field.NewPath("spec")}, nil
+	case *infrav1.IonosCloudMachineTemplate:
+		return &machineSpecObject{
+			o, "IonosCloudMachineTemplate", &o.Spec.Template.Spec, field.NewPath("spec", "template", "spec"),
+		}, nil
+	case *infrav1.IonosCloudMachinePool:
+		return &machineSpecObject{
+			o, infrav1.IonosCloudMachinePoolKind, &o.Spec.Template.Spec, field.NewPath("spec", "template", "spec"),
+		}, nil
+	default:
+		return nil, fmt.Errorf("expected an object with a machine spec, but got %T", obj)
+	}
+}
+
+// validateSSHKeys checks that the SSH keys are public keys in the authorized_keys format, as the Cloud API
+// only rejects invalid keys when the server is created. The SSH keys are immutable, so they are only validated
+// when an object is created.
+func validateSSHKeys(keys []string, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for i, key := range keys {
+		if _, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(key)); err != nil {
+			errs = append(errs, field.Invalid(path.Index(i), key, "must be a public SSH key in the authorized_keys format"))
+		} else if len(bytes.TrimSpace(rest)) > 0 {
+			errs = append(errs, field.Invalid(path.Index(i), key, "must contain a single public SSH key"))
+		}
+	}
+	return errs
+}
+
+// validateTemplateImmutable rejects changes to the spec of an IonosCloudMachineTemplate. Dry-run requests of
+// the topology controller of Cluster API, which are marked with the topology dry-run annotation, are allowed
+// to change the spec, as the controller uses them to detect whether a template needs to be rotated.
+func validateTemplateImmutable(
+	ctx context.Context, old *machineSpecObject, template *infrav1.IonosCloudMachineTemplate,
+) error {
+	changed := changedFields(old.spec, &template.Spec.Template.Spec)
+	if len(changed) == 0 {
+		return nil
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && topology.ShouldSkipImmutabilityChecks(req, template) {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, name := range changed {
+		errs = append(errs, field.Forbidden(old.path.Child(name),
+			"the spec of an IonosCloudMachineTemplate is immutable, create a new template instead"))
+	}
+	return apierrors.NewInvalid(infrav1.GroupVersion.WithKind(old.kind).GroupKind(), template.GetName(), errs)
+}
+
+// changedFields returns the JSON names of the top-level fields of the machine spec, which differ.
+func changedFields(oldSpec, newSpec *infrav1.IonosCloudMachineSpec) []string {
+	oldValue, newValue := reflect.ValueOf(oldSpec).Elem(), reflect.ValueOf(newSpec).Elem()
+	var changed []string
+	for i := range oldValue.NumField() {
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(oldValue.Type().Field(i).Tag.Get("json"), ",")
+		changed = append(changed, name)
+	}
+	return changed
+}
+
+func (v *MachineValidator) validate(
+	ctx context.Context, obj runtime.Object, oldSpec *infrav1.IonosCloudMachineSpec,
+) (admission.Warnings, error) {
+	if !v.Enabled {
+		return nil, nil
+	}
+
+	o, err := machineSpecOf(obj)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	// Machines in failure domains or in the data center of the cluster get their data center ID assigned later.
+	if o.spec.DatacenterID == "" || (oldSpec != nil && !validatedFieldsChanged(oldSpec, o.spec)) {
+		return nil, nil
+	}
+
+	ionosCluster, err := ionosClusterFor(ctx, v.Client, o.obj)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Skipped validation against the Cloud API: %v", err)}, nil
+	}
+	if ionosCluster == nil {
+		return nil, nil
+	}
+
+	ionosClient, err := ionosClientForCluster(ctx, v.Client, v.RateLimiter, v.APIEndpoint, v.newIonosClient, ionosCluster)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Skipped validation against the Cloud API: %v", err)}, nil
+	}
+
+	errs, warnings, err := validateMachineSpec(ctx, ionosClient, ionosCluster, o.spec, o.path)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Skipped validation against the Cloud API: %v", err)}, nil
+	}
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(infrav1.GroupVersion.WithKind(o.kind).GroupKind(), o.obj.GetName(), errs)
+	}
+	return warnings, nil
+}
+
+// ionosClientFor returns a client for the Cloud API, which uses the credentials of the cluster the object
+// belongs to. If the object doesn't belong to an IonosCloudCluster, nil is returned.
+// If newIonosClient is set, it is used to create the client instead.
+func ionosClientFor(
+	ctx context.Context, c client.Client, rateLimiter *icc.RateLimiter, endpoint icc.Endpoint,
+	newIonosClient func(secret *corev1.Secret) (ionoscloud.Client, error), obj client.Object,
+) (ionoscloud.Client, error) {
+	ionosCluster, err := ionosClusterFor(ctx, c, obj)
+	if err != nil || ionosCluster == nil {
+		return nil, err
+	}
+	return ionosClientForCluster(ctx, c, rateLimiter, endpoint, newIonosClient, ionosCluster)
+}
+
+// ionosClientForCluster returns a client for the Cloud API, which uses the credentials of the IonosCloudCluster.
+func ionosClientForCluster(
+	ctx context.Context, c client.Client, rateLimiter *icc.RateLimiter, endpoint icc.Endpoint,
+	newIonosClient func(secret *corev1.Secret) (ionoscloud.Client, error), ionosCluster *infrav1.IonosCloudCluster,
+) (ionoscloud.Client, error) {
+	secret, err := credentials.GetSecret(ctx, c, ionosCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if newIonosClient != nil {
+		return newIonosClient(secret)
+	}
+	var opts []icc.Option
+	if rateLimiter != nil {
+		opts = append(opts, icc.WithRateLimiter(rateLimiter))
+	}
+	opts = append(opts, icc.WithTracing())
+	return icc.NewClientFromSecret(secret, endpoint, opts...)
+}
+
+// ionosClusterFor returns the IonosCloudCluster of the cluster, which the object belongs to according to its
+// cluster name label. If the object doesn't belong to an IonosCloudCluster, nil is returned.
+func ionosClusterFor(ctx context.Context, c client.Client, obj client.Object) (*infrav1.IonosCloudCluster, error) {
+	clusterName := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	cluster, err := util.GetClusterByName(ctx, c, obj.GetNamespace(), clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("could not get cluster %s: %w", clusterName, err)
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref == nil || ref.Kind != infrav1.IonosCloudClusterKind {
+		return nil, nil
+	}
+
+	ionosCluster := &infrav1.IonosCloudCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, ionosCluster); err != nil {
+		return nil, fmt.Errorf("could not get IonosCloudCluster %s: %w", ref.Name, err)
+	}
+	return ionosCluster, nil
+}
+
+// validateMachineSpec validates the data center, the image, snapshot or private image and the CPU family of the machine spec
+// against the Cloud API. An unavailable CPU family only results in a warning, as the controller falls
+// back to a compatible one. A data center outside the location of the cluster or of the IP block of the machine
+// also only results in a warning. An error is only returned, if the Cloud API could not be queried.
+func validateMachineSpec(
+	ctx context.Context, ionosClient ionoscloud.Client, ionosCluster *infrav1.IonosCloudCluster,
+	spec *infrav1.IonosCloudMachineSpec, fldPath *field.Path,
+) (field.ErrorList, admission.Warnings, error) {
+	datacenter, err := ionosClient.GetDatacenter(ctx, spec.DatacenterID)
+	if ionoserrors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(fldPath.Child("datacenterID"), spec.DatacenterID)}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var errs field.ErrorList
+	location := ptr.Deref(datacenter.GetProperties().GetLocation(), "")
+
+	if imageID := imageIDOf(spec); imageID != "" {
+		imagePath := fldPath.Child("disk", "image", "id")
+		image, err := ionosClient.GetImage(ctx, imageID)
+		switch {
+		case ionoserrors.IsNotFound(err):
+			errs = append(errs, field.NotFound(imagePath, imageID))
+		case err != nil:
+			return nil, nil, err
+		case ptr.Deref(image.GetProperties().GetLocation(), "") != location:
+			errs = append(errs, field.Invalid(imagePath, imageID,
+				fmt.Sprintf("image is not available in location %s of data center %s", location, spec.DatacenterID)))
+		}
+	}
+
+	if snapshot := snapshotOf(spec); snapshot != (infrav1.SnapshotReference{}) {
+		snapshotErrs, err := validateSnapshot(ctx, ionosClient, snapshot, location, fldPath.Child("disk", "image", "snapshot"))
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, snapshotErrs...)
+	}
+
+	if ref := privateImageOf(spec); ref != (infrav1.PrivateImageReference{}) {
+		imageErrs, err := validatePrivateImage(ctx, ionosClient, ref, location, imageTypeHDD,
+			fldPath.Child("disk", "image", "private"))
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, imageErrs...)
+	}
+
+	if cdrom := spec.CDROM; cdrom != nil {
+		cdromErrs, err := validateCDROM(ctx, ionosClient, cdrom, location, fldPath.Child("cdrom"))
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, cdromErrs...)
+	}
+
+	warnings := clusterLocationWarnings(ionosCluster, spec, location, fldPath)
+	if spec.Type == infrav1.ServerTypeCube && spec.Template != nil {
+		templateErrs, templateWarnings, err := validateServerTemplate(ctx, ionosClient, spec, fldPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, templateErrs...)
+		warnings = append(warnings, templateWarnings...)
+	}
+
+	if cpuFamily := ptr.Deref(spec.CPUFamily, ""); cpuFamily != "" {
+		var cpuFamilies []string
+		for _, architecture := range ptr.Deref(datacenter.GetProperties().GetCpuArchitecture(), nil) {
+			cpuFamilies = append(cpuFamilies, ptr.Deref(architecture.GetCpuFamily(), ""))
+		}
+		if len(cpuFamilies) > 0 && !slices.Contains(cpuFamilies, cpuFamily) {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: CPU family %s is not offered in location %s, a compatible one of %s is used instead",
+				fldPath.Child("cpuFamily"), cpuFamily, location, strings.Join(cpuFamilies, ", ")))
+		}
+	}
+
+	return errs, warnings, nil
+}
+
+// clusterLocationWarnings warns if the data center of the machine is in another location than the resources of
+// the cluster. The machine is still admitted, as it can be reachable through a Cross Connect or the internet,
+// but the load balancer, the IP blocks and the failover IPs of the cluster cannot be attached to it.
+// Like the other checks against the Cloud API, it only runs with --enable-api-validation, and machines, whose data
+// center is set by their failure domain, are not checked, as the failure domain is only known once it is placed.
+func clusterLocationWarnings(
+	ionosCluster *infrav1.IonosCloudCluster, spec *infrav1.IonosCloudMachineSpec, location string, fldPath *field.Path,
+) admission.Warnings {
+	if location == "" {
+		return nil
+	}
+
+	var warnings admission.Warnings
+	if clusterLocation := ionosCluster.Spec.Location; clusterLocation != "" && clusterLocation != location {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: data center %s is in location %s, but cluster %s is in location %s. "+
+				"The load balancer, IP blocks and failover IPs of the cluster cannot be used by the machine",
+			fldPath.Child("datacenterID"), spec.DatacenterID, location, ionosCluster.Name, clusterLocation))
+	}
+
+	if spec.IPBlock == "" {
+		return warnings
+	}
+	for _, ipBlock := range ionosCluster.Spec.IPBlocks {
+		if ipBlock.Name != spec.IPBlock {
+			continue
+		}
+		ipBlockLocation := ipBlock.Location
+		if ipBlockLocation == "" {
+			ipBlockLocation = ionosCluster.Spec.Location
+		}
+		if ipBlockLocation != "" && ipBlockLocation != location {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s: IP block %s is reserved in location %s, but data center %s is in location %s. "+
+					"No IP of the IP block can be assigned to the machine",
+				fldPath.Child("ipBlock"), spec.IPBlock, ipBlockLocation, spec.DatacenterID, location))
+		}
+	}
+	return warnings
+}
+
+// validateSnapshot validates that the referenced snapshot exists in the location of the data center.
+func validateSnapshot(
+	ctx context.Context, ionosClient ionoscloud.Client, ref infrav1.SnapshotReference, location string, fldPath *field.Path,
+) (field.ErrorList, error) {
+	if ref.ID != "" {
+		snapshot, err := ionosClient.GetSnapshot(ctx, ref.ID)
+		if ionoserrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(fldPath.Child("id"), ref.ID)}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ptr.Deref(snapshot.GetProperties().GetLocation(), "") != location {
+			return field.ErrorList{field.Invalid(fldPath.Child("id"), ref.ID,
+				fmt.Sprintf("snapshot is not located in location %s of the data center", location))}, nil
+		}
+		return nil, nil
+	}
+
+	snapshots, err := icc.WithDepth(ionosClient, 1).ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matches := 0
+	for _, snapshot := range ptr.Deref(snapshots.GetItems(), nil) {
+		props := snapshot.GetProperties()
+		if ptr.Deref(props.GetName(), "") == ref.Name && ptr.Deref(props.GetLocation(), "") == location {
+			matches++
+		}
+	}
+	switch matches {
+	case 0:
+		return field.ErrorList{field.Invalid(fldPath.Child("name"), ref.Name,
+			fmt.Sprintf("no snapshot with this name exists in location %s of the data center", location))}, nil
+	case 1:
+		return nil, nil
+	default:
+		return field.ErrorList{field.Invalid(fldPath.Child("name"), ref.Name,
+			fmt.Sprintf("multiple snapshots with this name exist in location %s of the data center", location))}, nil
+	}
+}
+
+// validateCDROM validates that the ISO image of the CD-ROM exists in the location of the data center.
+func validateCDROM(
+	ctx context.Context, ionosClient ionoscloud.Client, cdrom *infrav1.CDROMSpec, location string, fldPath *field.Path,
+) (field.ErrorList, error) {
+	if cdrom.Private != nil {
+		return validatePrivateImage(ctx, ionosClient, *cdrom.Private, location, imageTypeCDROM, fldPath.Child("private"))
+	}
+
+	imagePath := fldPath.Child("id")
+	image, err := ionosClient.GetImage(ctx, cdrom.ID)
+	switch {
+	case ionoserrors.IsNotFound(err):
+		return field.ErrorList{field.NotFound(imagePath, cdrom.ID)}, nil
+	case err != nil:
+		return nil, err
+	case ptr.Deref(image.GetProperties().GetImageType(), "") != imageTypeCDROM:
+		return field.ErrorList{field.Invalid(imagePath, cdrom.ID, "image is not an ISO image")}, nil
+	case ptr.Deref(image.GetProperties().GetLocation(), "") != location:
+		return field.ErrorList{field.Invalid(imagePath, cdrom.ID,
+			fmt.Sprintf("image is not available in location %s of the data center", location))}, nil
+	}
+	return nil, nil
+}
+
+// validatePrivateImage validates that exactly one private image of the given type with the referenced name
+// or alias exists in the location of the data center.
+func validatePrivateImage(
+	ctx context.Context, ionosClient ionoscloud.Client, ref infrav1.PrivateImageReference, location, imageType string,
+	fldPath *field.Path,
+) (field.ErrorList, error) {
+	images, err := icc.WithDepth(ionosClient, 1).ListImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refPath, refValue := fldPath.Child("name"), ref.Name
+	if ref.Alias != "" {
+		refPath, refValue = fldPath.Child("alias"), ref.Alias
+	}
+	matches := 0
+	for _, image := range ptr.Deref(images.GetItems(), nil) {
+		props := image.GetProperties()
+		if ptr.Deref(props.GetPublic(), false) || ptr.Deref(props.GetImageType(), "") != imageType ||
+			ptr.Deref(props.GetLocation(), "") != location {
+			continue
+		}
+		if ref.Name != "" && ptr.Deref(props.GetName(), "") == ref.Name ||
+			ref.Alias != "" && slices.Contains(ptr.Deref(props.GetImageAliases(), nil), ref.Alias) {
+			matches++
+		}
+	}
+	switch matches {
+	case 0:
+		return field.ErrorList{field.Invalid(refPath, refValue,
+			fmt.Sprintf("no private image with this value exists in location %s of the data center", location))}, nil
+	case 1:
+		return nil, nil
+	default:
+		return field.ErrorList{field.Invalid(refPath, refValue,
+			fmt.Sprintf("multiple private images with this value exist in location %s of the data center", location))}, nil
+	}
+}
+
+// validateServerTemplate checks that the template of a CUBE server exists and is unique. The cores, the memory size
+// and the size of the boot volume of CUBE servers are defined by their template, so values of the spec, which differ
+// from the template, are reported as warnings, as they are ignored. The defaults of the CRD are not reported.
+func validateServerTemplate(
+	ctx context.Context, ionosClient ionoscloud.Client, spec *infrav1.IonosCloudMachineSpec, fldPath *field.Path,
+) (field.ErrorList, admission.Warnings, error) {
+	templates, err := icc.WithDepth(ionosClient, 1).ListTemplates(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ref := *spec.Template
+	refPath, refValue := fldPath.Child("template", "name"), ref.Name
+	if ref.ID != "" {
+		refPath, refValue = fldPath.Child("template", "id"), ref.ID
+	}
+	var matches []sdk.Template
+	for _, template := range ptr.Deref(templates.GetItems(), nil) {
+		if ref.ID != "" && ptr.Deref(template.GetId(), "") == ref.ID ||
+			ref.ID == "" && ptr.Deref(template.GetProperties().GetName(), "") == ref.Name {
+			matches = append(matches, template)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return field.ErrorList{field.NotFound(refPath, refValue)}, nil, nil
+	case 1:
+	default:
+		return field.ErrorList{field.Invalid(refPath, refValue, "multiple templates with this name exist")}, nil, nil
+	}
+
+	props := matches[0].GetProperties()
+	name := ptr.Deref(props.GetName(), refValue)
+	var warnings admission.Warnings
+	warn := func(path *field.Path, value, defaultValue int, resource string, templateValue *float32) {
+		if templateValue == nil || value == 0 || value == defaultValue || value == int(*templateValue) {
+			return
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: is ignored for CUBE servers, template %s provides %d %s",
+			path, name, int(*templateValue), resource))
+	}
+	warn(fldPath.Child("numCores"), int(spec.NumCores), defaultNumCores, "cores", props.GetCores())
+	warn(fldPath.Child("memoryMB"), int(spec.MemoryMB), defaultMemoryMB, "MB of memory", props.GetRam())
+	if spec.Disk != nil {
+		warn(fldPath.Child("disk", "sizeGB"), spec.Disk.SizeGB, defaultDiskSizeGB, "GB of storage", props.GetStorageSize())
+	}
+	return nil, warnings, nil
+}
+
+// validatedFieldsChanged returns true if one of the fields, which are validated against the Cloud API, has changed.
+func validatedFieldsChanged(oldSpec, newSpec *infrav1.IonosCloudMachineSpec) bool {
+	return oldSpec.DatacenterID != newSpec.DatacenterID ||
+		imageIDOf(oldSpec) != imageIDOf(newSpec) ||
+		snapshotOf(oldSpec) != snapshotOf(newSpec) ||
+		privateImageOf(oldSpec) != privateImageOf(newSpec) ||
+		ptr.Deref(oldSpec.CPUFamily, "") != ptr.Deref(newSpec.CPUFamily, "") ||
+		ptr.Deref(oldSpec.Template, infrav1.ServerTemplate{}) != ptr.Deref(newSpec.Template, infrav1.ServerTemplate{})
+}
+
+func imageIDOf(spec *infrav1.IonosCloudMachineSpec) string {
+	if spec.Disk == nil || spec.Disk.Image == nil {
+		return ""
+	}
+	return spec.Disk.Image.ID
+}
+
+func snapshotOf(spec *infrav1.IonosCloudMachineSpec) infrav1.SnapshotReference {
+	if spec.Disk == nil || spec.Disk.Image == nil || spec.Disk.Image.Snapshot == nil {
+		return infrav1.SnapshotReference{}
+	}
+	return *spec.Disk.Image.Snapshot
+}
+
+func privateImageOf(spec *infrav1.IonosCloudMachineSpec) infrav1.PrivateImageReference {
+	if spec.Disk == nil || spec.Disk.Image == nil || spec.Disk.Image.Private == nil {
+		return infrav1.PrivateImageReference{}
+	}
+	return *spec.Disk.Image.Private
+}
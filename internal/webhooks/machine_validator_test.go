@@ -0,0 +1,556 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/clienttest"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	exampleDatacenterID = "ccf27092-34e8-499e-a2f5-2bdee9d34a12"
+	exampleImageID      = "3e3e3003-55a5-11ee-b0ed-4a0bb8f8f2a6"
+	exampleSnapshotID   = "7d3a8e2b-1f4c-4b6a-9c2d-5e8f0a1b2c3d"
+	exampleISOImageID   = "b4f1c6a2-8d3e-4f5a-9b7c-0e2d4f6a8b1c"
+)
+
+func TestValidateCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		mutateSpec  func(spec *infrav1.IonosCloudMachineSpec)
+		mockCalls   func(m *clienttest.MockClient)
+		wantInvalid bool
+		wantWarning bool
+	}{{
+		name: "valid spec",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+		},
+	}, {
+		name: "data center not found",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).
+				Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound)).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "image not found",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).
+				Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound)).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "image in other location",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("us/las"), nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "CPU family not offered",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.CPUFamily = ptr.To("INTEL_ICELAKE")
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+		},
+		wantWarning: true,
+	}, {
+		name: "snapshot by ID in other location",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{ID: exampleSnapshotID}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetSnapshot(context.Background(), exampleSnapshotID).Return(exampleSnapshot("us/las"), nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "snapshot by name",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{Name: "golden-image"}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().ListSnapshots(context.Background()).
+				Return(&sdk.Snapshots{Items: &[]sdk.Snapshot{*exampleSnapshot("de/txl")}}, nil).Once()
+		},
+	}, {
+		name: "snapshot by name not found",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = &infrav1.ImageSpec{Snapshot: &infrav1.SnapshotReference{Name: "golden-image"}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().ListSnapshots(context.Background()).
+				Return(&sdk.Snapshots{Items: &[]sdk.Snapshot{*exampleSnapshot("us/las")}}, nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "private image by alias",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = &infrav1.ImageSpec{Private: &infrav1.PrivateImageReference{Alias: "kube:v1.29.4"}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().ListImages(context.Background()).
+				Return(&sdk.Images{Items: &[]sdk.Image{*examplePrivateImage("de/txl", false)}}, nil).Once()
+		},
+	}, {
+		name: "private image by name is public",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = &infrav1.ImageSpec{Private: &infrav1.PrivateImageReference{Name: "ubuntu-2204-kube-v1.29.4"}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().ListImages(context.Background()).
+				Return(&sdk.Images{Items: &[]sdk.Image{*examplePrivateImage("de/txl", true)}}, nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "CD-ROM without boot image",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Disk.Image = nil
+			spec.CDROM = &infrav1.CDROMSpec{ID: exampleISOImageID}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleISOImageID).Return(exampleISOImage("de/txl"), nil).Once()
+		},
+	}, {
+		name: "CD-ROM is no ISO image",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.CDROM = &infrav1.CDROMSpec{ID: exampleImageID}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Twice()
+		},
+		wantInvalid: true,
+	}, {
+		name: "private CD-ROM in other location",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.CDROM = &infrav1.CDROMSpec{Private: &infrav1.PrivateImageReference{Name: "talos-amd64.iso"}}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			m.EXPECT().ListImages(context.Background()).
+				Return(&sdk.Images{Items: &[]sdk.Image{*exampleISOImage("us/las")}}, nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "CUBE template by name",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeCube
+			spec.Template = &infrav1.ServerTemplate{Name: "Basic Cube S"}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			m.EXPECT().ListTemplates(context.Background()).Return(exampleTemplates("Basic Cube S"), nil).Once()
+		},
+	}, {
+		name: "CUBE template with differing resources",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeCube
+			spec.Template = &infrav1.ServerTemplate{Name: "Basic Cube S"}
+			spec.NumCores = 4
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			m.EXPECT().ListTemplates(context.Background()).Return(exampleTemplates("Basic Cube S"), nil).Once()
+		},
+		wantWarning: true,
+	}, {
+		name: "CUBE template not found",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeCube
+			spec.Template = &infrav1.ServerTemplate{ID: "6f1e2d3c-4b5a-4978-8a9b-0c1d2e3f4a5b"}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			m.EXPECT().ListTemplates(context.Background()).Return(exampleTemplates("Basic Cube S"), nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "CUBE template name is ambiguous",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.Type = infrav1.ServerTypeCube
+			spec.Template = &infrav1.ServerTemplate{Name: "Basic Cube S"}
+		},
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			m.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			m.EXPECT().ListTemplates(context.Background()).
+				Return(exampleTemplates("Basic Cube S", "Basic Cube S"), nil).Once()
+		},
+		wantInvalid: true,
+	}, {
+		name: "Cloud API unavailable",
+		mockCalls: func(m *clienttest.MockClient) {
+			m.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).
+				Return(nil, errors.New("connection refused")).Once()
+		},
+		wantWarning: true,
+	}, {
+		name: "no data center ID",
+		mutateSpec: func(spec *infrav1.IonosCloudMachineSpec) {
+			spec.DatacenterID = ""
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ionosClient := clienttest.NewMockClient(t)
+			if test.mockCalls != nil {
+				test.mockCalls(ionosClient)
+			}
+			machine := exampleMachine()
+			if test.mutateSpec != nil {
+				test.mutateSpec(&machine.Spec)
+			}
+
+			warnings, err := newTestValidator(t, ionosClient, true).ValidateCreate(context.Background(), machine)
+			require.Equal(t, test.wantInvalid, apierrors.IsInvalid(err), "unexpected error: %v", err)
+			if !test.wantInvalid {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.wantWarning, len(warnings) > 0)
+		})
+	}
+}
+
+func TestValidateCreateDisabled(t *testing.T) {
+	warnings, err := newTestValidator(t, clienttest.NewMockClient(t), false).
+		ValidateCreate(context.Background(), exampleMachine())
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
+
+func TestValidateCreateSSHKeys(t *testing.T) {
+	validator := newTestValidator(t, clienttest.NewMockClient(t), false)
+
+	machine := exampleMachine()
+	machine.Spec.SSHKeys = []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIABqtVlCJJWyL3w3oBhEBj7IObIP+7JCIosT5A1psusS test@example.com"}
+	_, err := validator.ValidateCreate(context.Background(), machine)
+	require.NoError(t, err)
+
+	machine.Spec.SSHKeys = append(machine.Spec.SSHKeys, "ssh-rsa not-a-key")
+	_, err = validator.ValidateCreate(context.Background(), machine)
+	require.True(t, apierrors.IsInvalid(err))
+	require.ErrorContains(t, err, "spec.sshKeys[1]")
+}
+
+func TestValidateCreateWithoutCluster(t *testing.T) {
+	machine := exampleMachine()
+	machine.Labels = nil
+
+	warnings, err := newTestValidator(t, clienttest.NewMockClient(t), true).
+		ValidateCreate(context.Background(), machine)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
+
+func TestValidateCreateClusterLocation(t *testing.T) {
+	tests := []struct {
+		name            string
+		clusterLocation string
+		ipBlocks        []infrav1.IPBlockSpec
+		ipBlock         string
+		wantWarnings    int
+	}{{
+		name:            "same location",
+		clusterLocation: "de/txl",
+	}, {
+		name:            "cluster in other location",
+		clusterLocation: "de/fra",
+		wantWarnings:    1,
+	}, {
+		name:            "IP block in location of cluster",
+		clusterLocation: "de/txl",
+		ipBlocks:        []infrav1.IPBlockSpec{{Name: "egress"}},
+		ipBlock:         "egress",
+	}, {
+		name:            "IP block in other location",
+		clusterLocation: "de/txl",
+		ipBlocks:        []infrav1.IPBlockSpec{{Name: "egress", Location: "us/las"}},
+		ipBlock:         "egress",
+		wantWarnings:    1,
+	}, {
+		name:            "cluster and IP block in other location",
+		clusterLocation: "de/fra",
+		ipBlocks:        []infrav1.IPBlockSpec{{Name: "egress"}},
+		ipBlock:         "egress",
+		wantWarnings:    2,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ionosClient := clienttest.NewMockClient(t)
+			ionosClient.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+			ionosClient.EXPECT().GetImage(context.Background(), exampleImageID).Return(exampleImage("de/txl"), nil).Once()
+			validator := newTestValidator(t, ionosClient, true)
+
+			ionosCluster := &infrav1.IonosCloudCluster{}
+			require.NoError(t, validator.Client.Get(context.Background(),
+				client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test-cluster"}, ionosCluster))
+			ionosCluster.Spec.Location = test.clusterLocation
+			ionosCluster.Spec.IPBlocks = test.ipBlocks
+			require.NoError(t, validator.Client.Update(context.Background(), ionosCluster))
+
+			machine := exampleMachine()
+			machine.Spec.IPBlock = test.ipBlock
+
+			warnings, err := validator.ValidateCreate(context.Background(), machine)
+			require.NoError(t, err)
+			require.Len(t, warnings, test.wantWarnings)
+		})
+	}
+}
+
+func TestValidateCreateTemplate(t *testing.T) {
+	ionosClient := clienttest.NewMockClient(t)
+	ionosClient.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).
+		Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound)).Once()
+
+	machine := exampleMachine()
+	template := &infrav1.IonosCloudMachineTemplate{
+		ObjectMeta: machine.ObjectMeta,
+		Spec: infrav1.IonosCloudMachineTemplateSpec{
+			Template: infrav1.IonosCloudMachineTemplateResource{Spec: machine.Spec},
+		},
+	}
+
+	_, err := newTestValidator(t, ionosClient, true).ValidateCreate(context.Background(), template)
+	require.True(t, apierrors.IsInvalid(err))
+	require.ErrorContains(t, err, "spec.template.spec.datacenterID")
+}
+
+func TestValidateUpdate(t *testing.T) {
+	oldMachine := exampleMachine()
+	newMachine := exampleMachine()
+	newMachine.Spec.NumCores = 4
+
+	// No validated field has changed, the Cloud API must not be contacted.
+	warnings, err := newTestValidator(t, clienttest.NewMockClient(t), true).
+		ValidateUpdate(context.Background(), oldMachine, newMachine)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+
+	ionosClient := clienttest.NewMockClient(t)
+	ionosClient.EXPECT().GetDatacenter(context.Background(), exampleDatacenterID).Return(exampleDatacenter(), nil).Once()
+	ionosClient.EXPECT().GetImage(context.Background(), "other-image").
+		Return(nil, sdk.NewGenericOpenAPIError("not found", nil, nil, http.StatusNotFound)).Once()
+	newMachine.Spec.Disk.Image.ID = "other-image"
+
+	_, err = newTestValidator(t, ionosClient, true).ValidateUpdate(context.Background(), oldMachine, newMachine)
+	require.True(t, apierrors.IsInvalid(err))
+}
+
+func TestValidateUpdateTemplate(t *testing.T) {
+	machine := exampleMachine()
+	oldTemplate := &infrav1.IonosCloudMachineTemplate{
+		ObjectMeta: machine.ObjectMeta,
+		Spec: infrav1.IonosCloudMachineTemplateSpec{
+			Template: infrav1.IonosCloudMachineTemplateResource{Spec: machine.Spec},
+		},
+	}
+
+	// Changes outside of the spec are allowed.
+	newTemplate := oldTemplate.DeepCopy()
+	newTemplate.Labels["foo"] = "bar"
+	_, err := newTestValidator(t, clienttest.NewMockClient(t), false).
+		ValidateUpdate(context.Background(), oldTemplate, newTemplate)
+	require.NoError(t, err)
+
+	newTemplate.Spec.Template.Spec.NumCores = 4
+	newTemplate.Spec.Template.Spec.MemoryMB = 4096
+	_, err = newTestValidator(t, clienttest.NewMockClient(t), false).
+		ValidateUpdate(context.Background(), oldTemplate, newTemplate)
+	require.True(t, apierrors.IsInvalid(err))
+	require.ErrorContains(t, err, "spec.template.spec.numCores")
+	require.ErrorContains(t, err, "spec.template.spec.memoryMB")
+
+	// The topology controller of Cluster API is allowed to change the spec in dry-run requests.
+	newTemplate.Annotations = map[string]string{clusterv1.TopologyDryRunAnnotation: ""}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{DryRun: ptr.To(true)},
+	})
+	_, err = newTestValidator(t, clienttest.NewMockClient(t), false).ValidateUpdate(ctx, oldTemplate, newTemplate)
+	require.NoError(t, err)
+
+	// Without dry-run, the annotation is ignored.
+	ctx = admission.NewContextWithRequest(context.Background(), admission.Request{})
+	_, err = newTestValidator(t, clienttest.NewMockClient(t), false).ValidateUpdate(ctx, oldTemplate, newTemplate)
+	require.True(t, apierrors.IsInvalid(err))
+}
+
+func newTestValidator(t *testing.T, ionosClient ionoscloud.Client, enabled bool) *MachineValidator {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, clusterv1.AddToScheme(scheme))
+	require.NoError(t, infrav1.AddToScheme(scheme))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       infrav1.IonosCloudClusterKind,
+					Name:       "test-cluster",
+				},
+			},
+		},
+		&infrav1.IonosCloudCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+			Spec: infrav1.IonosCloudClusterSpec{
+				CredentialsRef: &corev1.LocalObjectReference{Name: "test-credentials"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-credentials"},
+			Data:       map[string][]byte{"token": []byte("token")},
+		},
+	).Build()
+
+	return &MachineValidator{
+		Client:  cl,
+		Enabled: enabled,
+		newIonosClient: func(*corev1.Secret) (ionoscloud.Client, error) {
+			return ionosClient, nil
+		},
+	}
+}
+
+func exampleMachine() *infrav1.IonosCloudMachine {
+	return &infrav1.IonosCloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-machine",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+		Spec: infrav1.IonosCloudMachineSpec{
+			DatacenterID: exampleDatacenterID,
+			NumCores:     2,
+			CPUFamily:    ptr.To("AMD_OPTERON"),
+			Disk: &infrav1.Volume{
+				Image: &infrav1.ImageSpec{ID: exampleImageID},
+			},
+		},
+	}
+}
+
+func exampleDatacenter() *sdk.Datacenter {
+	return &sdk.Datacenter{
+		Id: ptr.To(exampleDatacenterID),
+		Properties: &sdk.DatacenterProperties{
+			Location: ptr.To("de/txl"),
+			CpuArchitecture: &[]sdk.CpuArchitectureProperties{
+				{CpuFamily: ptr.To("AMD_OPTERON")},
+				{CpuFamily: ptr.To("INTEL_SKYLAKE")},
+			},
+		},
+	}
+}
+
+func exampleImage(location string) *sdk.Image {
+	return &sdk.Image{
+		Id:         ptr.To(exampleImageID),
+		Properties: &sdk.ImageProperties{Location: ptr.To(location)},
+	}
+}
+
+func examplePrivateImage(location string, public bool) *sdk.Image {
+	return &sdk.Image{
+		Id: ptr.To(exampleImageID),
+		Properties: &sdk.ImageProperties{
+			Name:         ptr.To("ubuntu-2204-kube-v1.29.4"),
+			ImageAliases: &[]string{"kube:v1.29.4"},
+			ImageType:    ptr.To("HDD"),
+			Location:     ptr.To(location),
+			Public:       ptr.To(public),
+		},
+	}
+}
+
+func exampleISOImage(location string) *sdk.Image {
+	return &sdk.Image{
+		Id: ptr.To(exampleISOImageID),
+		Properties: &sdk.ImageProperties{
+			Name:      ptr.To("talos-amd64.iso"),
+			ImageType: ptr.To("CDROM"),
+			Location:  ptr.To(location),
+			Public:    ptr.To(false),
+		},
+	}
+}
+
+func exampleSnapshot(location string) *sdk.Snapshot {
+	return &sdk.Snapshot{
+		Id:         ptr.To(exampleSnapshotID),
+		Properties: &sdk.SnapshotProperties{Name: ptr.To("golden-image"), Location: ptr.To(location)},
+	}
+}
+
+// exampleTemplates returns CUBE templates with the given names, which provide 2 cores, 2048 MB of memory
+// and 50 GB of storage.
+func exampleTemplates(names ...string) *sdk.Templates {
+	items := make([]sdk.Template, 0, len(names))
+	for i, name := range names {
+		items = append(items, sdk.Template{
+			Id: ptr.To(fmt.Sprintf("template-%d", i)),
+			Properties: &sdk.TemplateProperties{
+				Name:        ptr.To(name),
+				Cores:       ptr.To[float32](2),
+				Ram:         ptr.To[float32](2048),
+				StorageSize: ptr.To[float32](50),
+			},
+		})
+	}
+	return &sdk.Templates{Items: &items}
+}
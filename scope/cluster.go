@@ -24,6 +24,7 @@ import (
 	"net"
 	"net/netip"
 	"slices"
+	"strings"
 	"time"
 
 	"k8s.io/client-go/util/retry"
@@ -32,7 +33,8 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
 // resolver is able to look up IP addresses from a given host name.
@@ -96,12 +98,21 @@ func (c *Cluster) GetControlPlaneEndpoint() clusterv1.APIEndpoint {
 
 // GetControlPlaneEndpointIP returns the endpoint IP for the IonosCloudCluster.
 // If the endpoint host is unset (neither an IP nor an FQDN), it will return an empty string.
+// If the host is the name of the DNS record, which is managed for the control plane endpoint,
+// the address of the record is returned instead of resolving the name.
 func (c *Cluster) GetControlPlaneEndpointIP(ctx context.Context) (string, error) {
 	host := c.GetControlPlaneEndpoint().Host
 	if host == "" {
 		return "", nil
 	}
 
+	if name := c.ControlPlaneEndpointDNSName(); name != "" && strings.EqualFold(host, name) {
+		if dns := c.IonosCluster.Status.ControlPlaneEndpointDNS; dns != nil {
+			return dns.Address, nil
+		}
+		return "", nil
+	}
+
 	if ip, err := netip.ParseAddr(host); err == nil {
 		return ip.String(), nil
 	}
@@ -118,11 +129,164 @@ func (c *Cluster) GetControlPlaneEndpointIP(ctx context.Context) (string, error)
 	return ips[0].String(), nil
 }
 
+// ControlPlaneEndpointDNSName returns the fully qualified name of the DNS record, which is managed for the
+// control plane endpoint, or an empty string if no DNS record is configured.
+func (c *Cluster) ControlPlaneEndpointDNSName() string {
+	dns := c.IonosCluster.Spec.DNS
+	if dns == nil {
+		return ""
+	}
+	return c.ControlPlaneEndpointDNSRecordName() + "." + strings.TrimSuffix(dns.ZoneName, ".")
+}
+
+// ControlPlaneEndpointDNSRecordName returns the name of the DNS record of the control plane endpoint
+// relative to its zone. It defaults to the name and the namespace of the cluster.
+func (c *Cluster) ControlPlaneEndpointDNSRecordName() string {
+	if dns := c.IonosCluster.Spec.DNS; dns != nil && dns.RecordName != "" {
+		return dns.RecordName
+	}
+	return c.Cluster.Name + "." + c.Cluster.Namespace
+}
+
 // SetControlPlaneEndpointIPBlockID sets the IP block ID in the IonosCloudCluster status.
 func (c *Cluster) SetControlPlaneEndpointIPBlockID(id string) {
 	c.IonosCluster.Status.ControlPlaneEndpointIPBlockID = id
 }
 
+// SetDatacenterID sets the ID of the data center, which is owned by the cluster, in the IonosCloudCluster status.
+func (c *Cluster) SetDatacenterID(id string) {
+	c.IonosCluster.Status.DatacenterID = id
+}
+
+// SetLoadBalancerID sets the Network Load Balancer ID in the IonosCloudCluster status.
+func (c *Cluster) SetLoadBalancerID(id string) {
+	c.IonosCluster.Status.LoadBalancerID = id
+}
+
+// SetNATGatewayID sets the NAT Gateway ID in the IonosCloudCluster status.
+func (c *Cluster) SetNATGatewayID(id string) {
+	c.IonosCluster.Status.NATGatewayID = id
+}
+
+// SetNATGatewayIPBlockID sets the ID of the NAT Gateway IP block in the IonosCloudCluster status.
+func (c *Cluster) SetNATGatewayIPBlockID(id string) {
+	c.IonosCluster.Status.NATGatewayIPBlockID = id
+}
+
+// SetApplicationLoadBalancerID sets the Application Load Balancer ID in the IonosCloudCluster status.
+func (c *Cluster) SetApplicationLoadBalancerID(id string) {
+	c.IonosCluster.Status.ApplicationLoadBalancerID = id
+}
+
+// SetApplicationLoadBalancerIPBlock sets the ID and the public IP of the Application Load Balancer IP block
+// in the IonosCloudCluster status.
+func (c *Cluster) SetApplicationLoadBalancerIPBlock(id, ip string) {
+	c.IonosCluster.Status.ApplicationLoadBalancerIPBlockID = id
+	c.IonosCluster.Status.ApplicationLoadBalancerIP = ip
+}
+
+// IPBlock returns the status of the IP block of the cluster with the given name.
+// If the IP block has not been reserved yet, nil is returned.
+func (c *Cluster) IPBlock(name string) *infrav1.IPBlockStatus {
+	for i, block := range c.IonosCluster.Status.IPBlocks {
+		if block.Name == name {
+			return &c.IonosCluster.Status.IPBlocks[i]
+		}
+	}
+	return nil
+}
+
+// SetIPBlock sets the ID and the IPs of the IP block with the given name in the IonosCloudCluster status.
+// The assignments of its IPs are kept.
+func (c *Cluster) SetIPBlock(name, id string, ips []string) {
+	if block := c.IPBlock(name); block != nil {
+		block.ID = id
+		block.IPs = ips
+		return
+	}
+	c.IonosCluster.Status.IPBlocks = append(c.IonosCluster.Status.IPBlocks,
+		infrav1.IPBlockStatus{Name: name, ID: id, IPs: ips})
+}
+
+// RemoveIPBlock removes the IP block with the given name from the IonosCloudCluster status.
+func (c *Cluster) RemoveIPBlock(name string) {
+	c.IonosCluster.Status.IPBlocks = slices.DeleteFunc(c.IonosCluster.Status.IPBlocks,
+		func(block infrav1.IPBlockStatus) bool { return block.Name == name })
+}
+
+// FailureDomain returns the failure domain with the given name.
+// If the failure domain is not declared in the IonosCloudCluster, nil is returned.
+func (c *Cluster) FailureDomain(name string) *infrav1.FailureDomainSpec {
+	for i, fd := range c.IonosCluster.Spec.FailureDomains {
+		if fd.Name == name {
+			return &c.IonosCluster.Spec.FailureDomains[i]
+		}
+	}
+	return nil
+}
+
+// Network returns the cluster network with the given name.
+// If the network is not declared in the IonosCloudCluster, nil is returned.
+func (c *Cluster) Network(name string) *infrav1.NetworkSpec {
+	for i, network := range c.IonosCluster.Spec.Networks {
+		if network.Name == name {
+			return &c.IonosCluster.Spec.Networks[i]
+		}
+	}
+	return nil
+}
+
+// SetNetworkLAN records the ID of the LAN of the cluster network with the given name in the data center
+// in the IonosCloudCluster status. Unmanaged marks networks, which use an existing LAN.
+func (c *Cluster) SetNetworkLAN(name string, unmanaged bool, datacenterID, lanID string) {
+	status := &c.IonosCluster.Status
+	i := slices.IndexFunc(status.Networks, func(network infrav1.NetworkStatus) bool { return network.Name == name })
+	if i < 0 {
+		status.Networks = append(status.Networks, infrav1.NetworkStatus{Name: name})
+		i = len(status.Networks) - 1
+	}
+	network := &status.Networks[i]
+	network.Unmanaged = unmanaged
+	if network.LANIDs == nil {
+		network.LANIDs = map[string]string{}
+	}
+	network.LANIDs[datacenterID] = lanID
+}
+
+// PrimaryNetwork returns the network, to which the primary NICs of the machines are attached.
+// If the IonosCloudCluster doesn't declare any networks, nil is returned.
+func (c *Cluster) PrimaryNetwork() *infrav1.NetworkSpec {
+	if len(c.IonosCluster.Spec.Networks) == 0 {
+		return nil
+	}
+	return &c.IonosCluster.Spec.Networks[0]
+}
+
+// SetFailureDomains publishes the failure domains of the IonosCloudCluster spec in its status.
+// The data center ID and availability zone of a failure domain are exposed as attributes.
+func (c *Cluster) SetFailureDomains() {
+	if len(c.IonosCluster.Spec.FailureDomains) == 0 {
+		c.IonosCluster.Status.FailureDomains = nil
+		return
+	}
+
+	failureDomains := make(clusterv1.FailureDomains, len(c.IonosCluster.Spec.FailureDomains))
+	for _, fd := range c.IonosCluster.Spec.FailureDomains {
+		attributes := map[string]string{}
+		if fd.DatacenterID != "" {
+			attributes["datacenterID"] = fd.DatacenterID
+		}
+		if fd.AvailabilityZone != "" {
+			attributes["availabilityZone"] = fd.AvailabilityZone.String()
+		}
+		failureDomains[fd.Name] = clusterv1.FailureDomainSpec{
+			ControlPlane: ptr.Deref(fd.ControlPlane, true),
+			Attributes:   attributes,
+		}
+	}
+	c.IonosCluster.Status.FailureDomains = failureDomains
+}
+
 // ListMachines returns a list of IonosCloudMachines in the same namespace and with the same cluster label.
 // With machineLabels, additional search labels can be provided.
 func (c *Cluster) ListMachines(
@@ -143,6 +307,15 @@ func (c *Cluster) ListMachines(
 	return machineList.Items, nil
 }
 
+// ListMachineTemplates returns the IonosCloudMachineTemplates in the namespace of the cluster.
+func (c *Cluster) ListMachineTemplates(ctx context.Context) ([]infrav1.IonosCloudMachineTemplate, error) {
+	templateList := &infrav1.IonosCloudMachineTemplateList{}
+	if err := c.client.List(ctx, templateList, client.InNamespace(c.Cluster.Namespace)); err != nil {
+		return nil, err
+	}
+	return templateList.Items, nil
+}
+
 // Location is a shortcut for getting the location used by the IONOS Cloud cluster IP block.
 func (c *Cluster) Location() string {
 	return c.IonosCluster.Spec.Location
@@ -159,6 +332,7 @@ func (c *Cluster) PatchObject() error {
 	// always set the ready condition
 	conditions.SetSummary(c.IonosCluster,
 		conditions.WithConditions(infrav1.IonosCloudClusterReady))
+	setBlockMove(c.IonosCluster, c.IonosCluster.HasPendingRequests())
 
 	// NOTE(piepmatz): We don't accept and forward a context here. This is on purpose: Even if a reconciliation is
 	//  aborted, we want to make sure that the final patch is applied. Reusing the context from the reconciliation
@@ -169,6 +343,10 @@ func (c *Cluster) PatchObject() error {
 	return c.patchHelper.Patch(timeoutCtx, c.IonosCluster, patch.WithOwnedConditions{
 		Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
+			infrav1.DryRunInSyncCondition,
+			infrav1.ControlPlaneEndpointReachableCondition,
+			infrav1.ResourcesDeletedCondition,
+			infrav1.CredentialsValidCondition,
 		},
 	})
 }
@@ -30,7 +30,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
 func TestNewClusterMissingParams(t *testing.T) {
@@ -111,10 +112,12 @@ func resolvesTo(ips ...string) []netip.Addr {
 
 func TestCluster_GetControlPlaneEndpointIP(t *testing.T) {
 	tests := []struct {
-		name     string
-		host     string
-		resolver resolver
-		want     string
+		name      string
+		host      string
+		dns       *infrav1.DNSSpec
+		dnsStatus *infrav1.DNSStatus
+		resolver  resolver
+		want      string
 	}{
 		{
 			name: "host empty",
@@ -146,6 +149,19 @@ func TestCluster_GetControlPlaneEndpointIP(t *testing.T) {
 			},
 			want: "1.2.3.4",
 		},
+		{
+			name:      "host is managed DNS record",
+			host:      "cluster.example.com",
+			dns:       &infrav1.DNSSpec{ZoneName: "example.com.", RecordName: "cluster"},
+			dnsStatus: &infrav1.DNSStatus{Address: "198.51.100.1"},
+			want:      "198.51.100.1",
+		},
+		{
+			name: "host is managed DNS record without address",
+			host: "cluster.example.com",
+			dns:  &infrav1.DNSSpec{ZoneName: "example.com", RecordName: "cluster"},
+			want: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -156,6 +172,10 @@ func TestCluster_GetControlPlaneEndpointIP(t *testing.T) {
 						ControlPlaneEndpoint: clusterv1.APIEndpoint{
 							Host: tt.host,
 						},
+						DNS: tt.dns,
+					},
+					Status: infrav1.IonosCloudClusterStatus{
+						ControlPlaneEndpointDNS: tt.dnsStatus,
 					},
 				},
 			}
@@ -317,6 +337,100 @@ func TestClusterIsDeleted(t *testing.T) {
 	}
 }
 
+func TestClusterFailureDomain(t *testing.T) {
+	c := &Cluster{
+		IonosCluster: &infrav1.IonosCloudCluster{
+			Spec: infrav1.IonosCloudClusterSpec{
+				FailureDomains: []infrav1.FailureDomainSpec{
+					{Name: "zone-1", AvailabilityZone: infrav1.AvailabilityZoneOne},
+					{Name: "zone-2", AvailabilityZone: infrav1.AvailabilityZoneTwo},
+				},
+			},
+		},
+	}
+
+	fd := c.FailureDomain("zone-2")
+	require.NotNil(t, fd)
+	require.Equal(t, infrav1.AvailabilityZoneTwo, fd.AvailabilityZone)
+	require.Nil(t, c.FailureDomain("zone-3"))
+}
+
+func TestClusterNetworks(t *testing.T) {
+	c := &Cluster{IonosCluster: &infrav1.IonosCloudCluster{}}
+	require.Nil(t, c.PrimaryNetwork())
+	require.Nil(t, c.Network("primary"))
+
+	c.IonosCluster.Spec.Networks = []infrav1.NetworkSpec{
+		{Name: "primary"},
+		{Name: "storage", DHCP: ptr.To(false)},
+	}
+	require.Equal(t, "primary", c.PrimaryNetwork().Name)
+
+	network := c.Network("storage")
+	require.NotNil(t, network)
+	require.False(t, *network.DHCP)
+	require.Nil(t, c.Network("other"))
+}
+
+func TestClusterIPBlocks(t *testing.T) {
+	c := &Cluster{IonosCluster: &infrav1.IonosCloudCluster{}}
+	require.Nil(t, c.IPBlock("egress"))
+
+	c.SetIPBlock("egress", "ipb-1", []string{"203.0.113.1"})
+	c.IPBlock("egress").Assignments = map[string]string{"IonosCloudMachine/m1": "203.0.113.1"}
+
+	c.SetIPBlock("egress", "ipb-1", []string{"203.0.113.1", "203.0.113.2"})
+	block := c.IPBlock("egress")
+	require.NotNil(t, block)
+	require.Equal(t, []string{"203.0.113.1", "203.0.113.2"}, block.IPs)
+	require.Equal(t, map[string]string{"IonosCloudMachine/m1": "203.0.113.1"}, block.Assignments,
+		"the assignments must be kept")
+
+	c.SetIPBlock("ingress", "ipb-2", nil)
+	require.Len(t, c.IonosCluster.Status.IPBlocks, 2)
+
+	c.RemoveIPBlock("egress")
+	require.Nil(t, c.IPBlock("egress"))
+	require.NotNil(t, c.IPBlock("ingress"))
+}
+
+func TestClusterSetFailureDomains(t *testing.T) {
+	c := &Cluster{
+		IonosCluster: &infrav1.IonosCloudCluster{
+			Spec: infrav1.IonosCloudClusterSpec{
+				FailureDomains: []infrav1.FailureDomainSpec{
+					{
+						Name:         "dc",
+						DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a",
+						ControlPlane: ptr.To(true),
+					},
+					{
+						Name:             "zone",
+						AvailabilityZone: infrav1.AvailabilityZoneOne,
+						ControlPlane:     ptr.To(false),
+					},
+				},
+			},
+		},
+	}
+
+	c.SetFailureDomains()
+	require.Equal(t, clusterv1.FailureDomains{
+		"dc": {
+			ControlPlane: true,
+			Attributes:   map[string]string{"datacenterID": "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"},
+		},
+		"zone": {
+			ControlPlane: false,
+			Attributes:   map[string]string{"availabilityZone": "ZONE_1"},
+		},
+	}, c.IonosCluster.Status.FailureDomains)
+
+	c.IonosCluster.Spec.FailureDomains = nil
+	c.SetFailureDomains()
+	require.Nil(t, c.IonosCluster.Status.FailureDomains)
+}
+
 func buildMachineWithLabel(name string, labels map[string]string) *infrav1.IonosCloudMachine {
 	return &infrav1.IonosCloudMachine{
 		ObjectMeta: metav1.ObjectMeta{
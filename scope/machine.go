@@ -20,17 +20,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
@@ -42,6 +49,24 @@ type Machine struct {
 	Machine      *clusterv1.Machine
 	IonosMachine *infrav1.IonosCloudMachine
 
+	// MachinePool is the Cluster API machine pool, which the machine belongs to.
+	// It is nil if the machine is not part of a machine pool.
+	MachinePool *expv1.MachinePool
+
+	// ClaimedIPv4Addresses contains the IPv4 addresses, which were claimed from IPAM pools, assigned from
+	// an IP block of the cluster or configured as private IP for the NICs of the machine. The primary NIC has the index 0, followed by
+	// the NICs of the additional networks in their order. It is populated while reconciling the IP addresses
+	// of the machine.
+	ClaimedIPv4Addresses map[int]string
+
+	// ClaimedIPv6CIDRBlock is the IPv6 CIDR block, which the cluster assigned to the primary NIC of the machine.
+	// It is populated while reconciling the IPv6 CIDR block of a dual-stack machine.
+	ClaimedIPv6CIDRBlock string
+
+	// Drift contains the differences between the VM and the spec of the IonosCloudMachine, which were detected
+	// while reconciling a machine with the Report drift policy. They are reported in the ServerInSync condition.
+	Drift []string
+
 	ClusterScope *Cluster
 }
 
@@ -51,6 +76,7 @@ type MachineParams struct {
 	Machine      *clusterv1.Machine
 	ClusterScope *Cluster
 	IonosMachine *infrav1.IonosCloudMachine
+	MachinePool  *expv1.MachinePool
 }
 
 // NewMachine creates a new Machine using the provided params.
@@ -78,13 +104,14 @@ func NewMachine(params MachineParams) (*Machine, error) {
 		Machine:      params.Machine,
 		ClusterScope: params.ClusterScope,
 		IonosMachine: params.IonosMachine,
+		MachinePool:  params.MachinePool,
 	}, nil
 }
 
 // GetBootstrapDataSecret returns the bootstrap data secret, which has been created by the
 // Kubeadm provider.
 func (m *Machine) GetBootstrapDataSecret(ctx context.Context, log logr.Logger) (*corev1.Secret, error) {
-	name := ptr.Deref(m.Machine.Spec.Bootstrap.DataSecretName, "")
+	name := m.BootstrapDataSecretName()
 	if name == "" {
 		return nil, errors.New("machine has no bootstrap data yet")
 	}
@@ -105,14 +132,315 @@ func (m *Machine) GetBootstrapDataSecret(ctx context.Context, log logr.Logger) (
 	return &lookupSecret, nil
 }
 
+// GetSecretKeyData returns the data of a key of a secret in the namespace of the IonosCloudMachine.
+// If the selector is optional and the secret or the key doesn't exist, nil is returned.
+func (m *Machine) GetSecretKeyData(ctx context.Context, selector corev1.SecretKeySelector) ([]byte, error) {
+	optional := ptr.Deref(selector.Optional, false)
+	key := client.ObjectKey{
+		Name:      selector.Name,
+		Namespace: m.IonosMachine.Namespace,
+	}
+
+	var secret corev1.Secret
+	if err := m.client.Get(ctx, key, &secret); err != nil {
+		if optional && apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data, exists := secret.Data[selector.Key]
+	if !exists && !optional {
+		return nil, fmt.Errorf("secret %s does not contain key %q", key, selector.Key)
+	}
+	return data, nil
+}
+
+// RemoteConsoleSecretName returns the name of the secret, which contains the URL of the remote console of the VM.
+func (m *Machine) RemoteConsoleSecretName() string {
+	return m.IonosMachine.Name + "-remote-console"
+}
+
+// StoreRemoteConsoleURL stores the URL of the remote console of the VM in a secret, which is owned by the
+// IonosCloudMachine, and references the secret in its status. The URL is not stored in the status itself,
+// as it grants access to the console.
+func (m *Machine) StoreRemoteConsoleURL(ctx context.Context, url string) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      m.RemoteConsoleSecretName(),
+		Namespace: m.IonosMachine.Namespace,
+	}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, m.client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string)
+		}
+		secret.Labels[clusterv1.ClusterNameLabel] = m.ClusterScope.Cluster.Name
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{infrav1.RemoteConsoleURLKey: []byte(url)}
+		return controllerutil.SetOwnerReference(m.IonosMachine, secret, m.client.Scheme())
+	}); err != nil {
+		return fmt.Errorf("could not store the remote console URL in secret %s: %w", secret.Name, err)
+	}
+
+	m.IonosMachine.Status.RemoteConsole = &infrav1.RemoteConsoleStatus{
+		SecretName:  secret.Name,
+		RequestedAt: metav1.Now(),
+	}
+	return nil
+}
+
+// BootstrapDataSecretName returns the name of the secret containing the bootstrap data.
+// Machines of a machine pool don't carry any bootstrap configuration themselves,
+// which is why the name is taken from the template of the machine pool instead.
+// An empty string is returned if the bootstrap data is not available yet.
+func (m *Machine) BootstrapDataSecretName() string {
+	if name := ptr.Deref(m.Machine.Spec.Bootstrap.DataSecretName, ""); name != "" || m.MachinePool == nil {
+		return name
+	}
+	return ptr.Deref(m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName, "")
+}
+
 // DatacenterID returns the data center ID used by the IonosCloudMachine.
 func (m *Machine) DatacenterID() string {
 	return m.IonosMachine.Spec.DatacenterID
 }
 
+// ApplyFailureDomain places the IonosCloudMachine in the failure domain of the Cluster API machine.
+// The data center and availability zone of the failure domain are written to the IonosCloudMachine spec.
+// Availability zones, which were explicitly set for the server or its volumes, are kept.
+// Machines without a data center ID are placed in the data center owned by the cluster, if there is one.
+//
+// Once the server has been created, the placement of the machine is not changed anymore.
+func (m *Machine) ApplyFailureDomain() error {
+	spec := &m.IonosMachine.Spec
+	if spec.ProviderID != nil {
+		return nil
+	}
+
+	name := ptr.Deref(m.Machine.Spec.FailureDomain, "")
+	if name == "" {
+		if spec.DatacenterID == "" {
+			spec.DatacenterID = m.ClusterScope.IonosCluster.Status.DatacenterID
+		}
+		if spec.DatacenterID == "" {
+			return errors.New("machine has neither a data center ID nor a failure domain")
+		}
+		return nil
+	}
+
+	fd := m.ClusterScope.FailureDomain(name)
+	if fd == nil {
+		return fmt.Errorf("failure domain %q is not declared in the IonosCloudCluster", name)
+	}
+
+	if fd.DatacenterID != "" {
+		if spec.DatacenterID != "" && spec.DatacenterID != fd.DatacenterID {
+			return fmt.Errorf("data center %s of the machine conflicts with data center %s of failure domain %q",
+				spec.DatacenterID, fd.DatacenterID, name)
+		}
+		spec.DatacenterID = fd.DatacenterID
+	}
+	if spec.DatacenterID == "" {
+		spec.DatacenterID = m.ClusterScope.IonosCluster.Status.DatacenterID
+	}
+	if spec.DatacenterID == "" {
+		return fmt.Errorf("failure domain %q does not define a data center and the machine has no data center ID", name)
+	}
+
+	if fd.AvailabilityZone == "" {
+		return nil
+	}
+	if zone := m.AvailabilityZone(); zone != infrav1.AvailabilityZoneAuto && zone != fd.AvailabilityZone {
+		return fmt.Errorf("availability zone %s of the machine conflicts with availability zone %s of failure domain %q",
+			zone, fd.AvailabilityZone, name)
+	}
+	m.setAvailabilityZone(fd.AvailabilityZone)
+	return nil
+}
+
+// ApplyDefaultAvailabilityZone places a machine in the AUTO availability zone in the availability zone of the
+// machine defaults of the cluster. It must be called after ApplyFailureDomain, so that the availability zone
+// of the failure domain takes precedence. Once the server has been created, the zone is not changed anymore.
+func (m *Machine) ApplyDefaultAvailabilityZone() {
+	defaults := m.ClusterScope.IonosCluster.Spec.MachineDefaults
+	if m.IonosMachine.Spec.ProviderID != nil || defaults == nil || isAutoZone(defaults.AvailabilityZone) ||
+		m.AvailabilityZone() != infrav1.AvailabilityZoneAuto {
+		return
+	}
+	m.setAvailabilityZone(defaults.AvailabilityZone)
+}
+
+// spreadZones are the availability zones, which machines are distributed across by the spread strategy.
+var spreadZones = []infrav1.AvailabilityZone{infrav1.AvailabilityZoneOne, infrav1.AvailabilityZoneTwo}
+
+// spreadGroupLabels are the labels, which identify the group of machines, that are spread across availability zones.
+// The first label, which is set on the machine, is used.
+var spreadGroupLabels = []string{
+	clusterv1.MachineDeploymentNameLabel,
+	clusterv1.MachinePoolNameLabel,
+	clusterv1.MachineControlPlaneNameLabel,
+}
+
+// ApplySpreadStrategy places a machine without an availability zone in the availability zone with the fewest
+// machines of the same MachineDeployment, MachinePool or control plane in its data center, if the spread
+// strategy is ZoneRoundRobin. It must be called after ApplyFailureDomain and ApplyDefaultAvailabilityZone.
+//
+// Machines, which are created at the same time, might see an outdated view of each other,
+// so the distribution is best effort. Once the server has been created, the zone is not changed anymore.
+func (m *Machine) ApplySpreadStrategy(ctx context.Context) error {
+	spec := &m.IonosMachine.Spec
+	if spec.ProviderID != nil || m.AvailabilityZone() != infrav1.AvailabilityZoneAuto ||
+		m.spreadStrategy() != infrav1.SpreadStrategyZoneRoundRobin {
+		return nil
+	}
+
+	matchLabels := client.MatchingLabels{}
+	for _, key := range spreadGroupLabels {
+		if value, ok := m.IonosMachine.Labels[key]; ok {
+			matchLabels[key] = value
+			break
+		}
+	}
+	machines, err := m.ListMachines(ctx, matchLabels)
+	if err != nil {
+		return err
+	}
+
+	machinesPerZone := make(map[infrav1.AvailabilityZone]int, len(spreadZones))
+	for _, machine := range machines {
+		if machine.Name == m.IonosMachine.Name || !machine.DeletionTimestamp.IsZero() ||
+			machine.Spec.DatacenterID != spec.DatacenterID {
+			continue
+		}
+		machinesPerZone[machine.Spec.AvailabilityZone]++
+	}
+
+	zone := spreadZones[0]
+	for _, z := range spreadZones[1:] {
+		if machinesPerZone[z] < machinesPerZone[zone] {
+			zone = z
+		}
+	}
+	m.setAvailabilityZone(zone)
+	return nil
+}
+
+// spreadStrategy returns the spread strategy of the machine, which defaults to the one of the cluster.
+func (m *Machine) spreadStrategy() infrav1.SpreadStrategy {
+	if strategy := m.IonosMachine.Spec.SpreadStrategy; strategy != "" {
+		return strategy
+	}
+	return m.ClusterScope.IonosCluster.Spec.SpreadStrategy
+}
+
+// setAvailabilityZone places the server in the given availability zone. Volumes, which don't have
+// an explicit availability zone, are placed in the same zone.
+func (m *Machine) setAvailabilityZone(zone infrav1.AvailabilityZone) {
+	spec := &m.IonosMachine.Spec
+	spec.AvailabilityZone = zone
+
+	if spec.Disk != nil && isAutoZone(spec.Disk.AvailabilityZone) {
+		spec.Disk.AvailabilityZone = zone
+	}
+	for i := range spec.AdditionalVolumes {
+		if isAutoZone(spec.AdditionalVolumes[i].AvailabilityZone) {
+			spec.AdditionalVolumes[i].AvailabilityZone = zone
+		}
+	}
+}
+
+func isAutoZone(zone infrav1.AvailabilityZone) bool {
+	return zone == "" || zone == infrav1.AvailabilityZoneAuto
+}
+
 // SetProviderID sets the provider ID for the IonosCloudMachine.
 func (m *Machine) SetProviderID(id string) {
 	m.IonosMachine.Spec.ProviderID = ptr.To("ionos://" + id)
+	conditions.MarkTrue(m.IonosMachine, infrav1.ProviderIDSetCondition)
+}
+
+// ServerID returns the ID of the server of the IonosCloudMachine, which is derived from its provider ID.
+// It is empty, if the provider ID has not been set yet.
+func (m *Machine) ServerID() string {
+	return m.IonosMachine.ExtractServerID()
+}
+
+// Hostname returns the hostname of the server of the IonosCloudMachine. It is the hostname rendered from
+// the hostname format, or the name of the IonosCloudMachine, if no hostname has been rendered.
+func (m *Machine) Hostname() string {
+	if m.IonosMachine.Status.Hostname != "" {
+		return m.IonosMachine.Status.Hostname
+	}
+	return m.IonosMachine.Name
+}
+
+// AvailabilityZone returns the availability zone, in which the server of the IonosCloudMachine is created.
+// An unset availability zone is reported as AUTO.
+func (m *Machine) AvailabilityZone() infrav1.AvailabilityZone {
+	if zone := m.IonosMachine.Spec.AvailabilityZone; !isAutoZone(zone) {
+		return zone
+	}
+	return infrav1.AvailabilityZoneAuto
+}
+
+// CurrentRequest returns the request, which the IonosCloudMachine is waiting for, or nil.
+func (m *Machine) CurrentRequest() *infrav1.ProvisioningRequest {
+	return m.IonosMachine.Status.CurrentRequest
+}
+
+// HasPendingRequest returns true, if the IonosCloudMachine is waiting for a request to complete.
+func (m *Machine) HasPendingRequest() bool {
+	return m.IonosMachine.HasPendingRequest()
+}
+
+// SetMachineNetworkInfo sets the information about the NICs of the server in the status of the
+// IonosCloudMachine, together with the addresses, which Cluster API copies to the machine.
+func (m *Machine) SetMachineNetworkInfo(netInfo *infrav1.MachineNetworkInfo) {
+	m.IonosMachine.Status.MachineNetworkInfo = netInfo
+	if netInfo == nil {
+		m.IonosMachine.Status.Addresses = nil
+		return
+	}
+	m.IonosMachine.Status.Addresses = machineAddresses(netInfo)
+}
+
+// machineAddresses returns the addresses of all NICs in the format Cluster API expects.
+// The addresses of the primary NIC are listed first. Private and unique local addresses are
+// reported as InternalIP, all other addresses as ExternalIP.
+func machineAddresses(netInfo *infrav1.MachineNetworkInfo) clusterv1.MachineAddresses {
+	nics := slices.Clone(netInfo.NICInfo)
+	slices.SortStableFunc(nics, func(a, b infrav1.NICInfo) int {
+		switch {
+		case a.Primary == b.Primary:
+			return 0
+		case a.Primary:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	addresses := make(clusterv1.MachineAddresses, 0)
+	seen := make(map[string]struct{})
+	for _, nic := range nics {
+		for _, ip := range slices.Concat(nic.IPv4Addresses, nic.IPv6Addresses) {
+			addr, err := netip.ParseAddr(ip)
+			if err != nil {
+				continue
+			}
+			if _, ok := seen[ip]; ok {
+				continue
+			}
+			seen[ip] = struct{}{}
+
+			addrType := clusterv1.MachineExternalIP
+			if addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+				addrType = clusterv1.MachineInternalIP
+			}
+			addresses = append(addresses, clusterv1.MachineAddress{Type: addrType, Address: ip})
+		}
+	}
+
+	return addresses
 }
 
 // CountMachines returns the number of existing IonosCloudMachines in the same namespace
@@ -167,12 +495,30 @@ func (m *Machine) HasFailed() bool {
 	return status.FailureReason != nil || status.FailureMessage != nil
 }
 
+// SetFailure marks the IonosCloudMachine as failed. Cluster API propagates the failure to the machine,
+// which allows a MachineHealthCheck to remediate it.
+func (m *Machine) SetFailure(reason capierrors.MachineStatusError, message string) {
+	m.IonosMachine.Status.FailureReason = &reason
+	m.IonosMachine.Status.FailureMessage = &message
+}
+
 // PatchObject will apply all changes from the IonosMachine.
 // It will also make sure to patch the status subresource.
 func (m *Machine) PatchObject() error {
+	// The provisioning phases replaced the MachineProvisioned condition.
+	conditions.Delete(m.IonosMachine, infrav1.MachineProvisionedCondition) //nolint:staticcheck // Cleans up old machines.
 	conditions.SetSummary(m.IonosMachine,
 		conditions.WithConditions(
-			infrav1.MachineProvisionedCondition))
+			infrav1.ServerCreatedCondition,
+			infrav1.VolumeReadyCondition,
+			infrav1.NICConfiguredCondition,
+			infrav1.BootstrapDeliveredCondition,
+			infrav1.ProviderIDSetCondition,
+			infrav1.BootstrapExecutedSuccessfullyCondition,
+			infrav1.IPAddressClaimedCondition,
+			infrav1.InstanceHealthyCondition,
+			infrav1.ServerDeletedCondition))
+	setBlockMove(m.IonosMachine, m.HasPendingRequest())
 
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
@@ -185,7 +531,20 @@ func (m *Machine) PatchObject() error {
 		m.IonosMachine,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
-			infrav1.MachineProvisionedCondition,
+			infrav1.ServerCreatedCondition,
+			infrav1.VolumeReadyCondition,
+			infrav1.NICConfiguredCondition,
+			infrav1.BootstrapDeliveredCondition,
+			infrav1.ProviderIDSetCondition,
+			infrav1.BootstrapExecutedSuccessfullyCondition,
+			infrav1.ServerResourcesUpdatedCondition,
+			infrav1.CPUFamilyAvailableCondition,
+			infrav1.BootstrapImageAvailableCondition,
+			infrav1.ServerDeletedCondition,
+			infrav1.IPAddressClaimedCondition,
+			infrav1.InstanceHealthyCondition,
+			infrav1.DryRunInSyncCondition,
+			infrav1.ServerInSyncCondition,
 		}})
 }
 
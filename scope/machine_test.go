@@ -18,18 +18,24 @@ package scope
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
+	sdk "github.com/ionos-cloud/sdk-go/v6"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
-	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1alpha1"
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
 	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
 )
 
@@ -102,6 +108,84 @@ func TestMachineHasFailedFailureReason(t *testing.T) {
 	require.True(t, scope.HasFailed())
 }
 
+func TestMachineSetFailure(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	require.False(t, scope.HasFailed())
+	scope.SetFailure(capierrors.UpdateMachineError, "server crashed")
+	require.True(t, scope.HasFailed())
+	require.Equal(t, capierrors.UpdateMachineError, *scope.IonosMachine.Status.FailureReason)
+	require.Equal(t, "server crashed", *scope.IonosMachine.Status.FailureMessage)
+}
+
+func TestMachineServerID(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	scope.IonosMachine.Spec.ProviderID = nil
+	require.Empty(t, scope.ServerID())
+	require.False(t, conditions.Has(scope.IonosMachine, infrav1.ProviderIDSetCondition))
+
+	scope.SetProviderID("server-id")
+	require.Equal(t, "server-id", scope.ServerID())
+	require.Equal(t, "ionos://server-id", *scope.IonosMachine.Spec.ProviderID)
+	require.True(t, conditions.IsTrue(scope.IonosMachine, infrav1.ProviderIDSetCondition))
+}
+
+func TestMachineHostname(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	require.Equal(t, scope.IonosMachine.Name, scope.Hostname())
+
+	scope.IonosMachine.Status.Hostname = "test-cluster-x7k2p"
+	require.Equal(t, "test-cluster-x7k2p", scope.Hostname())
+}
+
+func TestMachineAvailabilityZone(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+
+	scope.IonosMachine.Spec.AvailabilityZone = ""
+	require.Equal(t, infrav1.AvailabilityZoneAuto, scope.AvailabilityZone())
+	scope.IonosMachine.Spec.AvailabilityZone = infrav1.AvailabilityZoneTwo
+	require.Equal(t, infrav1.AvailabilityZoneTwo, scope.AvailabilityZone())
+}
+
+func TestMachineCurrentRequest(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	require.Nil(t, scope.CurrentRequest())
+	require.False(t, scope.HasPendingRequest())
+
+	scope.IonosMachine.SetCurrentRequest(http.MethodPost, sdk.RequestStatusQueued, "/requests/1")
+	require.Equal(t, "/requests/1", scope.CurrentRequest().RequestPath)
+	require.True(t, scope.HasPendingRequest())
+
+	scope.IonosMachine.DeleteCurrentRequest()
+	require.Nil(t, scope.CurrentRequest())
+	require.False(t, scope.HasPendingRequest())
+}
+
+func TestMachineSetMachineNetworkInfo(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+
+	scope.SetMachineNetworkInfo(&infrav1.MachineNetworkInfo{NICInfo: []infrav1.NICInfo{
+		{IPv4Addresses: []string{"203.0.113.10"}},
+		{IPv4Addresses: []string{"10.0.0.10", "invalid"}, IPv6Addresses: []string{"fd00::10"}, Primary: true},
+		{IPv4Addresses: []string{"10.0.0.10"}},
+	}})
+	require.Len(t, scope.IonosMachine.Status.MachineNetworkInfo.NICInfo, 3)
+	require.Equal(t, clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.10"},
+		{Type: clusterv1.MachineInternalIP, Address: "fd00::10"},
+		{Type: clusterv1.MachineExternalIP, Address: "203.0.113.10"},
+	}, scope.IonosMachine.Status.Addresses)
+
+	scope.SetMachineNetworkInfo(nil)
+	require.Nil(t, scope.IonosMachine.Status.MachineNetworkInfo)
+	require.Empty(t, scope.IonosMachine.Status.Addresses)
+}
+
 func TestCountMachinesWithDifferentLabels(t *testing.T) {
 	scope, err := NewMachine(exampleParams(t))
 	require.NoError(t, err)
@@ -224,3 +308,374 @@ func createMachineWithLabels(name string, labels map[string]string, offset time.
 		},
 	}
 }
+
+func TestMachineBootstrapDataSecretName(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	require.Empty(t, scope.BootstrapDataSecretName())
+
+	scope.Machine.Spec.Bootstrap.DataSecretName = ptr.To("machine-bootstrap")
+	require.Equal(t, "machine-bootstrap", scope.BootstrapDataSecretName())
+}
+
+func TestMachineBootstrapDataSecretNameFromMachinePool(t *testing.T) {
+	params := exampleParams(t)
+	params.MachinePool = &expv1.MachinePool{}
+	params.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName = ptr.To("pool-bootstrap")
+
+	scope, err := NewMachine(params)
+	require.NoError(t, err)
+	require.Equal(t, "pool-bootstrap", scope.BootstrapDataSecretName())
+}
+
+func TestMachineGetSecretKeyData(t *testing.T) {
+	scope, err := NewMachine(exampleParams(t))
+	require.NoError(t, err)
+	scope.IonosMachine.Namespace = metav1.NamespaceDefault
+
+	ctx := context.Background()
+	require.NoError(t, scope.client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-data", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"mirrors": []byte("#cloud-config")},
+	}))
+
+	selector := func(name, key string, optional bool) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+			Optional:             ptr.To(optional),
+		}
+	}
+
+	data, err := scope.GetSecretKeyData(ctx, selector("user-data", "mirrors", false))
+	require.NoError(t, err)
+	require.Equal(t, []byte("#cloud-config"), data)
+
+	_, err = scope.GetSecretKeyData(ctx, selector("user-data", "proxy", false))
+	require.ErrorContains(t, err, `does not contain key "proxy"`)
+
+	_, err = scope.GetSecretKeyData(ctx, selector("missing", "mirrors", false))
+	require.True(t, apierrors.IsNotFound(err))
+
+	data, err = scope.GetSecretKeyData(ctx, selector("user-data", "proxy", true))
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	data, err = scope.GetSecretKeyData(ctx, selector("missing", "mirrors", true))
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestMachineApplyFailureDomain(t *testing.T) {
+	const (
+		machineDatacenterID = "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"
+		domainDatacenterID  = "6ded8c5f-8df2-46ef-b4ce-61833daf0961"
+		clusterDatacenterID = "0ab6d5c4-9b0e-4c47-9a4d-4b5d0c1b5f37"
+	)
+
+	failureDomains := []infrav1.FailureDomainSpec{
+		{Name: "dc", DatacenterID: domainDatacenterID},
+		{Name: "zone", AvailabilityZone: infrav1.AvailabilityZoneTwo},
+		{Name: "dc-zone", DatacenterID: domainDatacenterID, AvailabilityZone: infrav1.AvailabilityZoneOne},
+	}
+
+	tests := []struct {
+		name           string
+		failureDomain  *string
+		datacenterID   string
+		zone           infrav1.AvailabilityZone
+		providerID     *string
+		clusterDC      string
+		wantErr        bool
+		wantDatacenter string
+		wantZone       infrav1.AvailabilityZone
+		wantDiskZone   infrav1.AvailabilityZone
+	}{
+		{
+			name:           "no failure domain",
+			datacenterID:   machineDatacenterID,
+			zone:           infrav1.AvailabilityZoneAuto,
+			wantDatacenter: machineDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+		{
+			name:    "no failure domain and no data center",
+			zone:    infrav1.AvailabilityZoneAuto,
+			wantErr: true,
+		},
+		{
+			name:           "no failure domain and cluster data center",
+			zone:           infrav1.AvailabilityZoneAuto,
+			clusterDC:      clusterDatacenterID,
+			wantDatacenter: clusterDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+		{
+			name:           "machine data center takes precedence over cluster data center",
+			datacenterID:   machineDatacenterID,
+			zone:           infrav1.AvailabilityZoneAuto,
+			clusterDC:      clusterDatacenterID,
+			wantDatacenter: machineDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+		{
+			name:          "unknown failure domain",
+			failureDomain: ptr.To("unknown"),
+			datacenterID:  machineDatacenterID,
+			wantErr:       true,
+		},
+		{
+			name:           "data center from failure domain",
+			failureDomain:  ptr.To("dc"),
+			zone:           infrav1.AvailabilityZoneAuto,
+			wantDatacenter: domainDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+		{
+			name:          "conflicting data center",
+			failureDomain: ptr.To("dc"),
+			datacenterID:  machineDatacenterID,
+			wantErr:       true,
+		},
+		{
+			name:           "zone from failure domain",
+			failureDomain:  ptr.To("zone"),
+			datacenterID:   machineDatacenterID,
+			zone:           infrav1.AvailabilityZoneAuto,
+			wantDatacenter: machineDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneTwo,
+			wantDiskZone:   infrav1.AvailabilityZoneTwo,
+		},
+		{
+			name:          "zone from failure domain without data center",
+			failureDomain: ptr.To("zone"),
+			zone:          infrav1.AvailabilityZoneAuto,
+			wantErr:       true,
+		},
+		{
+			name:           "zone from failure domain with cluster data center",
+			failureDomain:  ptr.To("zone"),
+			zone:           infrav1.AvailabilityZoneAuto,
+			clusterDC:      clusterDatacenterID,
+			wantDatacenter: clusterDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneTwo,
+			wantDiskZone:   infrav1.AvailabilityZoneTwo,
+		},
+		{
+			name:           "failure domain data center takes precedence over cluster data center",
+			failureDomain:  ptr.To("dc"),
+			zone:           infrav1.AvailabilityZoneAuto,
+			clusterDC:      clusterDatacenterID,
+			wantDatacenter: domainDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+		{
+			name:          "conflicting zone",
+			failureDomain: ptr.To("dc-zone"),
+			zone:          infrav1.AvailabilityZoneTwo,
+			wantErr:       true,
+		},
+		{
+			name:           "data center and zone from failure domain",
+			failureDomain:  ptr.To("dc-zone"),
+			zone:           infrav1.AvailabilityZoneOne,
+			wantDatacenter: domainDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneOne,
+			wantDiskZone:   infrav1.AvailabilityZoneOne,
+		},
+		{
+			name:           "server already created",
+			failureDomain:  ptr.To("dc-zone"),
+			datacenterID:   machineDatacenterID,
+			zone:           infrav1.AvailabilityZoneAuto,
+			providerID:     ptr.To("ionos://12345"),
+			wantDatacenter: machineDatacenterID,
+			wantZone:       infrav1.AvailabilityZoneAuto,
+			wantDiskZone:   infrav1.AvailabilityZoneAuto,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := exampleParams(t)
+			params.ClusterScope.IonosCluster = &infrav1.IonosCloudCluster{
+				Spec:   infrav1.IonosCloudClusterSpec{FailureDomains: failureDomains},
+				Status: infrav1.IonosCloudClusterStatus{DatacenterID: test.clusterDC},
+			}
+			params.Machine.Spec.FailureDomain = test.failureDomain
+			params.IonosMachine.Spec = infrav1.IonosCloudMachineSpec{
+				ProviderID:       test.providerID,
+				DatacenterID:     test.datacenterID,
+				AvailabilityZone: test.zone,
+				Disk:             &infrav1.Volume{AvailabilityZone: infrav1.AvailabilityZoneAuto},
+			}
+
+			scope, err := NewMachine(params)
+			require.NoError(t, err)
+
+			err = scope.ApplyFailureDomain()
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.wantDatacenter, scope.DatacenterID())
+			require.Equal(t, test.wantZone, scope.IonosMachine.Spec.AvailabilityZone)
+			require.Equal(t, test.wantDiskZone, scope.IonosMachine.Spec.Disk.AvailabilityZone)
+		})
+	}
+}
+
+func TestMachineApplyFailureDomainKeepsExplicitVolumeZones(t *testing.T) {
+	params := exampleParams(t)
+	params.ClusterScope.IonosCluster = &infrav1.IonosCloudCluster{
+		Spec: infrav1.IonosCloudClusterSpec{
+			FailureDomains: []infrav1.FailureDomainSpec{
+				{Name: "zone", AvailabilityZone: infrav1.AvailabilityZoneOne},
+			},
+		},
+	}
+	params.Machine.Spec.FailureDomain = ptr.To("zone")
+	params.IonosMachine.Spec = infrav1.IonosCloudMachineSpec{
+		DatacenterID: "ee090ff2-1eef-48ec-a246-a51a33aa4f3a",
+		Disk:         &infrav1.Volume{AvailabilityZone: infrav1.AvailabilityZoneThree},
+		AdditionalVolumes: []infrav1.VolumeSpec{
+			{Name: "auto", AvailabilityZone: infrav1.AvailabilityZoneAuto},
+			{Name: "explicit", AvailabilityZone: infrav1.AvailabilityZoneTwo},
+		},
+	}
+
+	scope, err := NewMachine(params)
+	require.NoError(t, err)
+	require.NoError(t, scope.ApplyFailureDomain())
+
+	spec := scope.IonosMachine.Spec
+	require.Equal(t, infrav1.AvailabilityZoneOne, spec.AvailabilityZone)
+	require.Equal(t, infrav1.AvailabilityZoneThree, spec.Disk.AvailabilityZone)
+	require.Equal(t, infrav1.AvailabilityZoneOne, spec.AdditionalVolumes[0].AvailabilityZone)
+	require.Equal(t, infrav1.AvailabilityZoneTwo, spec.AdditionalVolumes[1].AvailabilityZone)
+}
+
+func TestMachineApplyDefaultAvailabilityZone(t *testing.T) {
+	tests := []struct {
+		name         string
+		zone         infrav1.AvailabilityZone
+		providerID   *string
+		expectedZone infrav1.AvailabilityZone
+	}{
+		{"AUTO zone", infrav1.AvailabilityZoneAuto, nil, infrav1.AvailabilityZoneTwo},
+		{"unset zone", "", nil, infrav1.AvailabilityZoneTwo},
+		{"explicit zone", infrav1.AvailabilityZoneOne, nil, infrav1.AvailabilityZoneOne},
+		{"server exists", infrav1.AvailabilityZoneAuto, ptr.To("ionos://server"), infrav1.AvailabilityZoneAuto},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := exampleParams(t)
+			params.ClusterScope.IonosCluster = &infrav1.IonosCloudCluster{
+				Spec: infrav1.IonosCloudClusterSpec{
+					MachineDefaults: &infrav1.MachineDefaults{AvailabilityZone: infrav1.AvailabilityZoneTwo},
+				},
+			}
+			params.IonosMachine.Spec = infrav1.IonosCloudMachineSpec{
+				ProviderID:       test.providerID,
+				AvailabilityZone: test.zone,
+				Disk:             &infrav1.Volume{AvailabilityZone: infrav1.AvailabilityZoneAuto},
+			}
+
+			scope, err := NewMachine(params)
+			require.NoError(t, err)
+			scope.ApplyDefaultAvailabilityZone()
+			require.Equal(t, test.expectedZone, scope.IonosMachine.Spec.AvailabilityZone)
+			if test.expectedZone == infrav1.AvailabilityZoneTwo {
+				require.Equal(t, infrav1.AvailabilityZoneTwo, scope.IonosMachine.Spec.Disk.AvailabilityZone)
+			}
+		})
+	}
+}
+
+func TestMachineApplySpreadStrategy(t *testing.T) {
+	const datacenterID = "ee090ff2-1eef-48ec-a246-a51a33aa4f3a"
+
+	params := exampleParams(t)
+	params.ClusterScope.Cluster.SetName("test-cluster")
+	params.ClusterScope.IonosCluster = &infrav1.IonosCloudCluster{
+		Spec: infrav1.IonosCloudClusterSpec{SpreadStrategy: infrav1.SpreadStrategyZoneRoundRobin},
+	}
+	labels := map[string]string{
+		clusterv1.ClusterNameLabel:           "test-cluster",
+		clusterv1.MachineDeploymentNameLabel: "workers",
+	}
+	params.IonosMachine = createMachineWithLabels("worker-new", labels, 0)
+	params.IonosMachine.Spec = infrav1.IonosCloudMachineSpec{
+		DatacenterID: datacenterID,
+		Disk:         &infrav1.Volume{AvailabilityZone: infrav1.AvailabilityZoneAuto},
+	}
+
+	scope, err := NewMachine(params)
+	require.NoError(t, err)
+
+	otherLabels := map[string]string{
+		clusterv1.ClusterNameLabel:           "test-cluster",
+		clusterv1.MachineDeploymentNameLabel: "other",
+	}
+	for _, m := range []struct {
+		name         string
+		labels       map[string]string
+		datacenterID string
+		zone         infrav1.AvailabilityZone
+	}{
+		{"worker-1", labels, datacenterID, infrav1.AvailabilityZoneOne},
+		{"worker-2", labels, datacenterID, infrav1.AvailabilityZoneTwo},
+		{"worker-3", labels, datacenterID, infrav1.AvailabilityZoneOne},
+		// Machines in other data centers and of other deployments are not taken into account.
+		{"worker-4", labels, "a5d0d8f6-3c42-4f3e-8c1c-2a4e3b6f1d2e", infrav1.AvailabilityZoneTwo},
+		{"other-1", otherLabels, datacenterID, infrav1.AvailabilityZoneTwo},
+		{"other-2", otherLabels, datacenterID, infrav1.AvailabilityZoneTwo},
+	} {
+		machine := createMachineWithLabels(m.name, m.labels, 0)
+		machine.Spec.DatacenterID = m.datacenterID
+		machine.Spec.AvailabilityZone = m.zone
+		require.NoError(t, scope.client.Create(context.Background(), machine))
+	}
+
+	require.NoError(t, scope.ApplySpreadStrategy(context.Background()))
+	require.Equal(t, infrav1.AvailabilityZoneTwo, scope.IonosMachine.Spec.AvailabilityZone)
+	require.Equal(t, infrav1.AvailabilityZoneTwo, scope.IonosMachine.Spec.Disk.AvailabilityZone)
+}
+
+func TestMachineApplySpreadStrategySkipped(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     infrav1.SpreadStrategy
+		zone         infrav1.AvailabilityZone
+		providerID   *string
+		expectedZone infrav1.AvailabilityZone
+	}{
+		{"machine opts out", infrav1.SpreadStrategyNone, infrav1.AvailabilityZoneAuto, nil, infrav1.AvailabilityZoneAuto},
+		{"explicit zone", "", infrav1.AvailabilityZoneTwo, nil, infrav1.AvailabilityZoneTwo},
+		{"server exists", "", infrav1.AvailabilityZoneAuto, ptr.To("ionos://server"), infrav1.AvailabilityZoneAuto},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := exampleParams(t)
+			params.ClusterScope.IonosCluster = &infrav1.IonosCloudCluster{
+				Spec: infrav1.IonosCloudClusterSpec{SpreadStrategy: infrav1.SpreadStrategyZoneRoundRobin},
+			}
+			params.IonosMachine.Spec = infrav1.IonosCloudMachineSpec{
+				ProviderID:       test.providerID,
+				SpreadStrategy:   test.strategy,
+				AvailabilityZone: test.zone,
+			}
+
+			scope, err := NewMachine(params)
+			require.NoError(t, err)
+			require.NoError(t, scope.ApplySpreadStrategy(context.Background()))
+			require.Equal(t, test.expectedZone, scope.IonosMachine.Spec.AvailabilityZone)
+		})
+	}
+}
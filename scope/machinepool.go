@@ -0,0 +1,133 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/util/retry"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// MachinePool defines a basic machine pool context for primary use in IonosCloudMachinePoolReconciler.
+type MachinePool struct {
+	client      client.Client
+	patchHelper *patch.Helper
+
+	MachinePool      *expv1.MachinePool
+	IonosMachinePool *infrav1.IonosCloudMachinePool
+
+	ClusterScope *Cluster
+}
+
+// MachinePoolParams is a struct that contains the params used to create a new MachinePool through NewMachinePool.
+type MachinePoolParams struct {
+	Client           client.Client
+	MachinePool      *expv1.MachinePool
+	ClusterScope     *Cluster
+	IonosMachinePool *infrav1.IonosCloudMachinePool
+}
+
+// NewMachinePool creates a new MachinePool using the provided params.
+func NewMachinePool(params MachinePoolParams) (*MachinePool, error) {
+	if params.Client == nil {
+		return nil, errors.New("machine pool scope params lack a client")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("machine pool scope params lack a Cluster API machine pool")
+	}
+	if params.IonosMachinePool == nil {
+		return nil, errors.New("machine pool scope params lack a IONOS Cloud machine pool")
+	}
+	if params.ClusterScope == nil {
+		return nil, errors.New("machine pool scope params need a IONOS Cloud cluster scope")
+	}
+
+	helper, err := patch.NewHelper(params.IonosMachinePool, params.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init patch helper: %w", err)
+	}
+	return &MachinePool{
+		client:           params.Client,
+		patchHelper:      helper,
+		MachinePool:      params.MachinePool,
+		ClusterScope:     params.ClusterScope,
+		IonosMachinePool: params.IonosMachinePool,
+	}, nil
+}
+
+// DesiredReplicas returns the number of replicas, which was requested for the machine pool.
+// If the number of replicas is not set, the pool is expected to have exactly one replica.
+func (m *MachinePool) DesiredReplicas() int32 {
+	return ptr.Deref(m.MachinePool.Spec.Replicas, 1)
+}
+
+// MachineLabels returns the labels, which identify the IonosCloudMachines belonging to the machine pool.
+func (m *MachinePool) MachineLabels() client.MatchingLabels {
+	return client.MatchingLabels{
+		clusterv1.ClusterNameLabel:     m.ClusterScope.Cluster.Name,
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(m.MachinePool.Name),
+	}
+}
+
+// ListMachines returns all IonosCloudMachines, which belong to the machine pool.
+func (m *MachinePool) ListMachines(ctx context.Context) ([]infrav1.IonosCloudMachine, error) {
+	return m.ClusterScope.ListMachines(ctx, m.MachineLabels())
+}
+
+// PatchObject will apply all changes from the IonosMachinePool.
+// It will also make sure to patch the status subresource.
+func (m *MachinePool) PatchObject() error {
+	conditions.SetSummary(m.IonosMachinePool,
+		conditions.WithConditions(
+			infrav1.ReplicasReadyCondition))
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// We don't accept and forward a context here. This is on purpose: Even if a reconciliation is
+	// aborted, we want to make sure that the final patch is applied.
+	return m.patchHelper.Patch(
+		timeoutCtx,
+		m.IonosMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.ReplicasReadyCondition,
+		}})
+}
+
+// Finalize will make sure to apply a patch to the current IonosCloudMachinePool.
+// It also implements a retry mechanism to increase the chance of success
+// in case the patch operation was not successful.
+func (m *MachinePool) Finalize() error {
+	shouldRetry := func(error) bool { return true }
+	return retry.OnError(
+		retry.DefaultBackoff,
+		shouldRetry,
+		m.PatchObject)
+}
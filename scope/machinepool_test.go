@@ -0,0 +1,133 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func exampleMachinePoolParams(t *testing.T) MachinePoolParams {
+	if err := infrav1.AddToScheme(scheme.Scheme); err != nil {
+		require.NoError(t, err, "could not construct params")
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	return MachinePoolParams{
+		Client: cl,
+		MachinePool: &expv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-pool",
+				Namespace: metav1.NamespaceDefault,
+			},
+		},
+		ClusterScope: &Cluster{
+			client: cl,
+			Cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: metav1.NamespaceDefault,
+				},
+			},
+		},
+		IonosMachinePool: &infrav1.IonosCloudMachinePool{},
+	}
+}
+
+func TestNewMachinePoolOK(t *testing.T) {
+	scope, err := NewMachinePool(exampleMachinePoolParams(t))
+	require.NotNil(t, scope, "returned machine pool scope should not be nil")
+	require.NoError(t, err)
+	require.NotNil(t, scope.patchHelper, "returned scope should have a non-nil patchHelper")
+}
+
+func TestMachinePoolParamsNilClientShouldFail(t *testing.T) {
+	params := exampleMachinePoolParams(t)
+	params.Client = nil
+	scope, err := NewMachinePool(params)
+	require.Nil(t, scope, "returned machine pool scope should be nil")
+	require.Error(t, err)
+}
+
+func TestMachinePoolParamsNilMachinePoolShouldFail(t *testing.T) {
+	params := exampleMachinePoolParams(t)
+	params.MachinePool = nil
+	scope, err := NewMachinePool(params)
+	require.Nil(t, scope, "returned machine pool scope should be nil")
+	require.Error(t, err)
+}
+
+func TestMachinePoolParamsNilIonosMachinePoolShouldFail(t *testing.T) {
+	params := exampleMachinePoolParams(t)
+	params.IonosMachinePool = nil
+	scope, err := NewMachinePool(params)
+	require.Nil(t, scope, "returned machine pool scope should be nil")
+	require.Error(t, err)
+}
+
+func TestMachinePoolParamsNilClusterScopeShouldFail(t *testing.T) {
+	params := exampleMachinePoolParams(t)
+	params.ClusterScope = nil
+	scope, err := NewMachinePool(params)
+	require.Nil(t, scope, "returned machine pool scope should be nil")
+	require.Error(t, err)
+}
+
+func TestMachinePoolDesiredReplicas(t *testing.T) {
+	scope, err := NewMachinePool(exampleMachinePoolParams(t))
+	require.NoError(t, err)
+	require.Equal(t, int32(1), scope.DesiredReplicas(), "replicas should default to 1")
+
+	scope.MachinePool.Spec.Replicas = ptr.To(int32(3))
+	require.Equal(t, int32(3), scope.DesiredReplicas())
+}
+
+func TestMachinePoolListMachines(t *testing.T) {
+	params := exampleMachinePoolParams(t)
+	scope, err := NewMachinePool(params)
+	require.NoError(t, err)
+
+	machineWithLabels := func(name string, labels map[string]string) *infrav1.IonosCloudMachine {
+		return &infrav1.IonosCloudMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    labels,
+			},
+		}
+	}
+
+	ctx := context.Background()
+	require.NoError(t, params.Client.Create(ctx, machineWithLabels("pool-machine", scope.MachineLabels())))
+	require.NoError(t, params.Client.Create(ctx, machineWithLabels("other-machine", map[string]string{
+		clusterv1.ClusterNameLabel: "test-cluster",
+	})))
+
+	machines, err := scope.ListMachines(ctx)
+	require.NoError(t, err)
+	require.Len(t, machines, 1)
+	require.Equal(t, "pool-machine", machines[0].Name)
+}
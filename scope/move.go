@@ -0,0 +1,40 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+// setBlockMove adds or removes the annotation, which prevents clusterctl move from moving the object.
+//
+// Requests to the Cloud API are processed asynchronously and cannot be paused. If an object was moved
+// while one of its requests is pending, the target management cluster would lose track of the request.
+// Moving is therefore blocked until all requests of the object have completed.
+func setBlockMove(obj metav1.Object, blocked bool) {
+	annotations := obj.GetAnnotations()
+	if blocked {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[clusterctlv1.BlockMoveAnnotation] = "true"
+	} else {
+		delete(annotations, clusterctlv1.BlockMoveAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+}
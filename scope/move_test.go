@@ -0,0 +1,58 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+
+	infrav1 "github.com/ionos-cloud/cluster-api-provider-ionoscloud/api/v1beta1"
+)
+
+func TestSetBlockMove(t *testing.T) {
+	machine := &infrav1.IonosCloudMachine{}
+
+	setBlockMove(machine, false)
+	require.NotContains(t, machine.Annotations, clusterctlv1.BlockMoveAnnotation)
+
+	setBlockMove(machine, true)
+	require.Contains(t, machine.Annotations, clusterctlv1.BlockMoveAnnotation)
+
+	setBlockMove(machine, false)
+	require.NotContains(t, machine.Annotations, clusterctlv1.BlockMoveAnnotation)
+}
+
+func TestPendingRequestsBlockMove(t *testing.T) {
+	cluster := &infrav1.IonosCloudCluster{}
+	require.False(t, cluster.HasPendingRequests())
+
+	cluster.SetCurrentRequestByDatacenter("dc", http.MethodPost, "QUEUED", "/requests/1")
+	require.True(t, cluster.HasPendingRequests())
+	cluster.DeleteCurrentRequestByDatacenter("dc")
+	require.False(t, cluster.HasPendingRequests())
+
+	cluster.SetCurrentClusterRequest(http.MethodDelete, "QUEUED", "/requests/2")
+	require.True(t, cluster.HasPendingRequests())
+
+	machine := &infrav1.IonosCloudMachine{}
+	require.False(t, machine.HasPendingRequest())
+	machine.SetCurrentRequest(http.MethodPost, "QUEUED", "/requests/3")
+	require.True(t, machine.HasPendingRequest())
+}
@@ -0,0 +1,122 @@
+//go:build e2e
+
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+
+	icc "github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud/client"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// Variables of the e2e config, which are used by the test suite.
+const (
+	kubernetesVersionManagementVariable = "KUBERNETES_VERSION_MANAGEMENT"
+	cniPathVariable                     = "CNI"
+	cniResourcesVariable                = "CNI_RESOURCES"
+	locationVariable                    = "CONTROL_PLANE_ENDPOINT_LOCATION"
+	datacenterIDVariable                = "IONOSCLOUD_DATACENTER_ID"
+	apiInsecureVariable                 = "IONOS_API_INSECURE"
+)
+
+// ionosCloudEnv manages the resources in the Cloud API, which the clusters of the tests are created in.
+// The Cloud API is either the production API or a simulated environment, whose URL is set with IONOS_API_URL.
+type ionosCloudEnv struct {
+	client *icc.IonosCloudClient
+
+	// datacenterID is the ID of the data center, which is used by all tests.
+	datacenterID string
+	// ownsDatacenter is true if the data center was created by the test suite and must be deleted after the tests.
+	ownsDatacenter bool
+}
+
+// newIonosCloudEnv creates the client for the Cloud API. The token is taken from the environment variable
+// IONOS_TOKEN, while the endpoint is configured with the variables IONOS_API_URL and IONOS_API_INSECURE.
+func newIonosCloudEnv(config *clusterctl.E2EConfig) *ionosCloudEnv {
+	token := os.Getenv(sdk.IonosTokenEnvVar)
+	Expect(token).ToNot(BeEmpty(), "The %s environment variable must be set", sdk.IonosTokenEnvVar)
+
+	endpoint := icc.Endpoint{URL: config.GetVariable(sdk.IonosApiUrlEnvVar)}
+	insecure, err := strconv.ParseBool(config.GetVariable(apiInsecureVariable))
+	Expect(err).ToNot(HaveOccurred(), "Invalid value of %s", apiInsecureVariable)
+	endpoint.InsecureSkipVerify = insecure
+
+	client, err := icc.NewClient(icc.Credentials{Token: token}, endpoint)
+	Expect(err).ToNot(HaveOccurred(), "Failed to create the client for the Cloud API")
+	return &ionosCloudEnv{client: client}
+}
+
+// setup makes sure that a data center exists for the tests. If the config doesn't reference an existing
+// data center, a new one is created in the location of the control plane endpoint.
+func (e *ionosCloudEnv) setup(ctx context.Context, config *clusterctl.E2EConfig) {
+	if config.HasVariable(datacenterIDVariable) {
+		e.datacenterID = config.GetVariable(datacenterIDVariable)
+	}
+	if e.datacenterID != "" {
+		Byf("Using the existing data center %s", e.datacenterID)
+		return
+	}
+
+	location := config.GetVariable(locationVariable)
+	Byf("Creating a data center in %s", location)
+	datacenter, res, err := e.client.API.DataCentersApi.DatacentersPost(ctx).Datacenter(sdk.Datacenter{
+		Properties: &sdk.DatacenterProperties{
+			Name:        ptr.To("capic-e2e-" + util.RandomString(6)),
+			Location:    &location,
+			Description: ptr.To("Data center of the end-to-end tests of Cluster API Provider IONOS Cloud"),
+		},
+	}).Execute()
+	Expect(err).ToNot(HaveOccurred(), "Failed to create the data center")
+	Expect(e.client.WaitForRequest(ctx, res.Header.Get("Location"))).To(Succeed(),
+		"Failed to wait for the creation of the data center")
+
+	e.datacenterID = *datacenter.GetId()
+	e.ownsDatacenter = true
+
+	// Environment variables take precedence over the variables of the config, so both need to be set.
+	config.Variables[datacenterIDVariable] = e.datacenterID
+	Expect(os.Setenv(datacenterIDVariable, e.datacenterID)).To(Succeed())
+}
+
+// teardown deletes the data center, if it was created by the test suite.
+func (e *ionosCloudEnv) teardown(ctx context.Context) {
+	if !e.ownsDatacenter {
+		return
+	}
+
+	Byf("Deleting the data center %s", e.datacenterID)
+	requestLocation, err := e.client.DeleteDatacenter(ctx, e.datacenterID)
+	Expect(err).ToNot(HaveOccurred(), "Failed to delete the data center")
+	Expect(e.client.WaitForRequest(ctx, requestLocation)).To(Succeed(),
+		"Failed to wait for the deletion of the data center")
+}
+
+// Byf is a wrapper around By, which formats the description.
+func Byf(format string, a ...any) {
+	By(fmt.Sprintf(format, a...))
+}
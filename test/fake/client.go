@@ -0,0 +1,384 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake offers an in-memory implementation of the IONOS Cloud client, which can be used in tests
+// instead of mocking every single call.
+//
+// Like the Cloud API, the fake processes mutating requests asynchronously. Every mutating call returns
+// the location of a request, which is queued until it is completed with CompleteRequests. Created resources
+// are visible right away, but stay BUSY until their request has been completed. Changes and deletions take
+// effect once the request has been completed. WithAutoComplete completes every request immediately.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// baseURL is the prefix of the URLs of all resources and requests.
+const baseURL = "https://api.ionos.com/cloudapi/v6/"
+
+var _ ionoscloud.Client = (*Client)(nil)
+
+// Client is an in-memory implementation of ionoscloud.Client. It is safe for concurrent use.
+type Client struct {
+	mu           sync.Mutex
+	autoComplete bool
+
+	datacenters   map[string]*datacenter
+	ipBlocks      map[string]*sdk.IpBlock
+	targetGroups  map[string]*sdk.TargetGroup
+	crossConnects map[string]*sdk.PrivateCrossConnect
+	images        map[string]*sdk.Image
+	snapshots     map[string]*sdk.Snapshot
+	templates     []sdk.Template
+	labels        map[string]*resourceLabels
+	limits        *sdk.ResourceLimits
+
+	requests []*request
+	// now returns the creation time of requests. Requests created within the same nanosecond need to be
+	// ordered as well, so the time is increased monotonically.
+	now      func() time.Time
+	lastTime time.Time
+	ipSeq    int
+}
+
+// datacenter holds the resources of a data center.
+type datacenter struct {
+	datacenter  sdk.Datacenter
+	servers     map[string]*sdk.Server
+	volumes     map[string]*sdk.Volume
+	lans        map[string]*sdk.Lan
+	nlbs        map[string]*sdk.NetworkLoadBalancer
+	natGateways map[string]*sdk.NatGateway
+	albs        map[string]*sdk.ApplicationLoadBalancer
+	lanSeq      int
+}
+
+// request is a request to the Cloud API, which is applied once it is completed.
+type request struct {
+	id         string
+	method     string
+	url        string
+	body       string
+	created    time.Time
+	status     string
+	message    string
+	targetType sdk.Type
+	targetID   string
+	apply      func()
+}
+
+// Option configures the fake client.
+type Option func(*Client)
+
+// WithAutoComplete completes every request as soon as it has been created.
+func WithAutoComplete() Option {
+	return func(c *Client) {
+		c.autoComplete = true
+	}
+}
+
+// NewClient creates an empty fake client.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		datacenters:   make(map[string]*datacenter),
+		ipBlocks:      make(map[string]*sdk.IpBlock),
+		targetGroups:  make(map[string]*sdk.TargetGroup),
+		crossConnects: make(map[string]*sdk.PrivateCrossConnect),
+		images:        make(map[string]*sdk.Image),
+		snapshots:     make(map[string]*sdk.Snapshot),
+		labels:        make(map[string]*resourceLabels),
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CompleteRequests completes all queued requests in the order in which they were created
+// and returns their number.
+func (c *Client) CompleteRequests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	completed := 0
+	for _, req := range c.requests {
+		if c.complete(req) {
+			completed++
+		}
+	}
+	return completed
+}
+
+// FailRequest marks the queued request with the given location as failed. Its changes are not applied.
+func (c *Client) FailRequest(location, message string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := c.findRequest(location)
+	if req == nil {
+		return fmt.Errorf("request %s not found", location)
+	}
+	if req.status != sdk.RequestStatusQueued {
+		return fmt.Errorf("request %s is already %s", location, req.status)
+	}
+	req.status = sdk.RequestStatusFailed
+	req.message = message
+	return nil
+}
+
+// PendingRequests returns the number of requests, which have not been completed yet.
+func (c *Client) PendingRequests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := 0
+	for _, req := range c.requests {
+		if req.status == sdk.RequestStatusQueued {
+			pending++
+		}
+	}
+	return pending
+}
+
+// CheckRequestStatus checks the status of a provided request identified by requestID.
+func (c *Client) CheckRequestStatus(_ context.Context, requestURL string) (*sdk.RequestStatus, error) {
+	if requestURL == "" {
+		return nil, errors.New("request URL is empty")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := c.findRequest(requestURL)
+	if req == nil {
+		return nil, notFound("request", requestURL)
+	}
+	return req.requestStatus(), nil
+}
+
+// WaitForRequest waits for the completion of the provided request.
+// As there is nothing to wait for, a queued request is completed right away.
+func (c *Client) WaitForRequest(_ context.Context, requestURL string) error {
+	if requestURL == "" {
+		return errors.New("request URL is empty")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := c.findRequest(requestURL)
+	if req == nil {
+		return notFound("request", requestURL)
+	}
+	c.complete(req)
+	if req.status == sdk.RequestStatusFailed {
+		return fmt.Errorf("request %s has failed: %s", requestURL, req.message)
+	}
+	return nil
+}
+
+// GetRequests returns the requests that match the provided method and path, the latest request first.
+func (c *Client) GetRequests(_ context.Context, method, path string) ([]sdk.Request, error) {
+	if path == "" {
+		return nil, errors.New("path needs to be provided")
+	}
+	if method == "" {
+		return nil, errors.New("method needs to be provided")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var requests []sdk.Request
+	for i := len(c.requests) - 1; i >= 0; i-- {
+		if req := c.requests[i]; req.method == method && strings.Contains(req.url, path) {
+			requests = append(requests, req.sdkRequest())
+		}
+	}
+	return requests, nil
+}
+
+// enqueue creates a request for the resource at urlPath, which is completed asynchronously unless auto-completion
+// is enabled. The apply function is called, once the request is completed. It returns the location of the request.
+func (c *Client) enqueue(
+	method, urlPath string, body any, targetType sdk.Type, targetID string, apply func(),
+) (string, error) {
+	var encodedBody string
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return "", fmt.Errorf("could not encode request body: %w", err)
+		}
+		encodedBody = string(data)
+	}
+
+	created := c.now()
+	if !created.After(c.lastTime) {
+		created = c.lastTime.Add(time.Nanosecond)
+	}
+	c.lastTime = created
+
+	req := &request{
+		id:         uuid.NewString(),
+		method:     method,
+		url:        baseURL + urlPath,
+		body:       encodedBody,
+		created:    created,
+		status:     sdk.RequestStatusQueued,
+		targetType: targetType,
+		targetID:   targetID,
+		apply:      apply,
+	}
+	c.requests = append(c.requests, req)
+	if c.autoComplete {
+		c.complete(req)
+	}
+	return req.location(), nil
+}
+
+// complete applies a queued request and returns true if it was completed.
+func (c *Client) complete(req *request) bool {
+	if req.status != sdk.RequestStatusQueued {
+		return false
+	}
+	if req.apply != nil {
+		req.apply()
+	}
+	req.status = sdk.RequestStatusDone
+	return true
+}
+
+func (c *Client) findRequest(location string) *request {
+	for _, req := range c.requests {
+		if req.location() == location {
+			return req
+		}
+	}
+	return nil
+}
+
+func (r *request) location() string {
+	return baseURL + path.Join("requests", r.id, "status")
+}
+
+func (r *request) requestStatus() *sdk.RequestStatus {
+	metadata := &sdk.RequestStatusMetadata{Status: ptr.To(r.status)}
+	if r.message != "" {
+		metadata.Message = ptr.To(r.message)
+	}
+	if r.targetType != "" {
+		metadata.Targets = &[]sdk.RequestTarget{{
+			Status: ptr.To(r.status),
+			Target: &sdk.ResourceReference{Id: ptr.To(r.targetID), Type: ptr.To(r.targetType)},
+		}}
+	}
+	return &sdk.RequestStatus{
+		Id:       ptr.To(r.id),
+		Href:     ptr.To(r.location()),
+		Metadata: metadata,
+	}
+}
+
+func (r *request) sdkRequest() sdk.Request {
+	props := &sdk.RequestProperties{
+		Method: ptr.To(r.method),
+		Url:    ptr.To(r.url),
+	}
+	if r.body != "" {
+		props.Body = ptr.To(r.body)
+	}
+	return sdk.Request{
+		Id: ptr.To(r.id),
+		Metadata: &sdk.RequestMetadata{
+			CreatedDate:   &sdk.IonosTime{Time: r.created},
+			RequestStatus: r.requestStatus(),
+		},
+		Properties: props,
+	}
+}
+
+// notFound returns the error of the Cloud API for a resource, which doesn't exist.
+func notFound(kind, id string) error {
+	return sdk.NewGenericOpenAPIError(fmt.Sprintf("%s %s not found", kind, id), nil, nil, http.StatusNotFound)
+}
+
+// badRequest returns the error of the Cloud API for an invalid request.
+func badRequest(format string, args ...any) error {
+	return sdk.NewGenericOpenAPIError(fmt.Sprintf(format, args...), nil, nil, http.StatusBadRequest)
+}
+
+// emptyID returns an error for a missing ID of a resource.
+func emptyID(kind string) error {
+	return fmt.Errorf("%s ID is empty", kind)
+}
+
+// clone returns a deep copy of v, so that callers can't modify the state of the fake.
+func clone[T any](v *T) *T {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("could not encode %T: %v", v, err))
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		panic(fmt.Sprintf("could not decode %T: %v", v, err))
+	}
+	return &out
+}
+
+// merge applies the fields, which are set in patch, to dst.
+func merge(dst, patch any) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		panic(fmt.Sprintf("could not encode %T: %v", patch, err))
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		panic(fmt.Sprintf("could not decode %T into %T: %v", patch, dst, err))
+	}
+}
+
+// available returns the metadata of a resource, which is available.
+func available() *sdk.DatacenterElementMetadata {
+	return &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Available)}
+}
+
+// busy returns the metadata of a resource, which is being provisioned.
+func busy() *sdk.DatacenterElementMetadata {
+	return &sdk.DatacenterElementMetadata{State: ptr.To(sdk.Busy)}
+}
+
+// values returns copies of the resources of the map, sorted by their ID.
+func values[T any](resources map[string]*T, id func(*T) string) []T {
+	items := make([]T, 0, len(resources))
+	for _, resource := range resources {
+		items = append(items, *clone(resource))
+	}
+	slices.SortFunc(items, func(a, b T) int { return strings.Compare(id(&a), id(&b)) })
+	return items
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"testing"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func TestCreateServerCompletesAsynchronously(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	server, location, err := c.CreateServer(ctx, dcID,
+		sdk.ServerProperties{Name: ptr.To("test-server")},
+		sdk.ServerEntities{
+			Volumes: &sdk.AttachedVolumes{Items: &[]sdk.Volume{{Properties: &sdk.VolumeProperties{Name: ptr.To("disk")}}}},
+			Nics:    &sdk.Nics{Items: &[]sdk.Nic{{Properties: &sdk.NicProperties{Lan: ptr.To(int32(1))}}}},
+		})
+	require.NoError(t, err)
+	require.NotEmpty(t, *server.Id)
+	require.Equal(t, 1, c.PendingRequests())
+
+	status, err := c.CheckRequestStatus(ctx, location)
+	require.NoError(t, err)
+	require.Equal(t, sdk.RequestStatusQueued, *status.Metadata.Status)
+
+	got, err := c.GetServer(ctx, dcID, *server.Id)
+	require.NoError(t, err)
+	require.Equal(t, sdk.Busy, *got.Metadata.State)
+	require.Equal(t, *(*got.Entities.Volumes.Items)[0].Id, *got.Properties.BootVolume.Id)
+	nic := (*got.Entities.Nics.Items)[0]
+	require.NotEmpty(t, *nic.Properties.Mac)
+	require.Len(t, *nic.Properties.Ips, 1)
+
+	require.Equal(t, 1, c.CompleteRequests())
+	require.Zero(t, c.PendingRequests())
+
+	got, err = c.GetServer(ctx, dcID, *server.Id)
+	require.NoError(t, err)
+	require.Equal(t, sdk.Available, *got.Metadata.State)
+	require.Equal(t, sdk.Available, *(*got.Entities.Volumes.Items)[0].Metadata.State)
+}
+
+func TestGetRequests(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithAutoComplete())
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	_, err := c.CreateLAN(ctx, dcID, sdk.LanPropertiesPost{Name: ptr.To("first")})
+	require.NoError(t, err)
+	location, err := c.CreateLAN(ctx, dcID, sdk.LanPropertiesPost{Name: ptr.To("second")})
+	require.NoError(t, err)
+	_, err = c.DeleteLAN(ctx, dcID, "1")
+	require.NoError(t, err)
+
+	requests, err := c.GetRequests(ctx, http.MethodPost, path.Join("datacenters", dcID, "lans"))
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	// The latest request comes first.
+	latest := requests[0]
+	require.Equal(t, location, *latest.Metadata.RequestStatus.Href)
+	require.Equal(t, sdk.RequestStatusDone, *latest.Metadata.RequestStatus.Metadata.Status)
+	require.Equal(t, sdk.LAN, *(*latest.Metadata.RequestStatus.Metadata.Targets)[0].Target.Type)
+	require.True(t, requests[1].Metadata.CreatedDate.Before(latest.Metadata.CreatedDate.Time))
+
+	var lan sdk.Lan
+	require.NoError(t, json.Unmarshal([]byte(*latest.Properties.Body), &lan))
+	require.Equal(t, "second", *lan.Properties.Name)
+
+	lans, err := c.ListLANs(ctx, dcID)
+	require.NoError(t, err)
+	require.Len(t, *lans.Items, 1)
+	require.Equal(t, "2", *(*lans.Items)[0].Id)
+}
+
+func TestFailRequest(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	location, err := c.DeleteDatacenter(ctx, dcID)
+	require.NoError(t, err)
+	require.NoError(t, c.FailRequest(location, "something went wrong"))
+	require.Zero(t, c.CompleteRequests())
+
+	require.Error(t, c.WaitForRequest(ctx, location))
+	_, err = c.GetDatacenter(ctx, dcID)
+	require.NoError(t, err, "the data center must not be deleted by a failed request")
+	require.Error(t, c.FailRequest(location, "again"))
+}
+
+func TestNotFound(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	_, err := c.GetServer(ctx, dcID, "missing")
+	var apiErr sdk.GenericOpenAPIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+	_, err = c.GetServer(ctx, dcID, "")
+	require.Error(t, err)
+}
+
+func TestDeleteServerWithVolumes(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithAutoComplete())
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	server, _, err := c.CreateServer(ctx, dcID, sdk.ServerProperties{Name: ptr.To("test-server")},
+		sdk.ServerEntities{Volumes: &sdk.AttachedVolumes{Items: &[]sdk.Volume{{}}}})
+	require.NoError(t, err)
+	volumeID := *(*server.Entities.Volumes.Items)[0].Id
+	require.NoError(t, c.CreateVolumeLabel(ctx, dcID, volumeID, "key", "value"))
+
+	_, err = c.DeleteServer(ctx, dcID, *server.Id, true)
+	require.NoError(t, err)
+
+	_, err = c.ListVolumeLabels(ctx, dcID, volumeID)
+	require.Error(t, err)
+	labels, err := c.ListLabels(ctx)
+	require.NoError(t, err)
+	require.Empty(t, *labels.Items)
+}
+
+func TestLabels(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithAutoComplete())
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	server, _, err := c.CreateServer(ctx, dcID, sdk.ServerProperties{}, sdk.ServerEntities{})
+	require.NoError(t, err)
+	serverID := *server.Id
+
+	require.NoError(t, c.CreateServerLabel(ctx, dcID, serverID, "key", "value"))
+	require.Error(t, c.CreateServerLabel(ctx, dcID, serverID, "key", "value"))
+	require.Error(t, c.UpdateServerLabel(ctx, dcID, serverID, "missing", "value"))
+	require.NoError(t, c.UpdateServerLabel(ctx, dcID, serverID, "key", "updated"))
+
+	labels, err := c.ListLabels(ctx)
+	require.NoError(t, err)
+	require.Len(t, *labels.Items, 1)
+	props := (*labels.Items)[0].Properties
+	require.Equal(t, "updated", *props.Value)
+	require.Equal(t, serverID, *props.ResourceId)
+	require.Equal(t, "server", *props.ResourceType)
+	require.Contains(t, *props.ResourceHref, path.Join("datacenters", dcID))
+
+	require.NoError(t, c.DeleteServerLabel(ctx, dcID, serverID, "key"))
+	serverLabels, err := c.ListServerLabels(ctx, dcID, serverID)
+	require.NoError(t, err)
+	require.Empty(t, *serverLabels.Items)
+}
+
+func TestServerPowerState(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient()
+	dcID := c.AddDatacenter("test", "de/txl")
+
+	server, _, err := c.CreateServer(ctx, dcID, sdk.ServerProperties{}, sdk.ServerEntities{})
+	require.NoError(t, err)
+	serverID := *server.Id
+
+	location, err := c.StopServer(ctx, dcID, serverID)
+	require.NoError(t, err)
+	got, err := c.GetServer(ctx, dcID, serverID)
+	require.NoError(t, err)
+	require.Equal(t, "RUNNING", *got.Properties.VmState, "the server must not be stopped before the request is done")
+
+	require.NoError(t, c.WaitForRequest(ctx, location))
+	got, err = c.GetServer(ctx, dcID, serverID)
+	require.NoError(t, err)
+	require.Equal(t, "SHUTOFF", *got.Properties.VmState)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// SetResourceLimits sets the resource limits of the contract. The provisioned resources are reported
+// as given and are not derived from the resources of the fake.
+func (c *Client) SetResourceLimits(limits sdk.ResourceLimits) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.limits = clone(&limits)
+}
+
+// ListContracts returns the contract of the fake. It has no resource limits, unless they were set
+// with SetResourceLimits.
+func (c *Client) ListContracts(_ context.Context) (*sdk.Contracts, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	contract := sdk.Contract{
+		Type: ptr.To(sdk.CONTRACT),
+		Properties: &sdk.ContractProperties{
+			ContractNumber: ptr.To(int64(31721234)),
+			Status:         ptr.To("BILLABLE"),
+		},
+	}
+	if c.limits != nil {
+		contract.Properties.ResourceLimits = clone(c.limits)
+	}
+	return &sdk.Contracts{Items: &[]sdk.Contract{contract}}, nil
+}
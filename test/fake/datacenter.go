@@ -0,0 +1,252 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// resourceLabels are the labels of a single resource.
+type resourceLabels struct {
+	resourceType sdk.Type
+	href         string
+	labels       map[string]string
+}
+
+// AddDatacenter adds an available data center in the given location and returns its ID.
+func (c *Client) AddDatacenter(name, location string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	c.datacenters[id] = newDatacenter(id, sdk.DatacenterProperties{Name: &name, Location: &location}, available())
+	return id
+}
+
+func newDatacenter(id string, properties sdk.DatacenterProperties, metadata *sdk.DatacenterElementMetadata) *datacenter {
+	return &datacenter{
+		datacenter: sdk.Datacenter{
+			Id:         ptr.To(id),
+			Href:       ptr.To(baseURL + path.Join("datacenters", id)),
+			Metadata:   metadata,
+			Properties: &properties,
+		},
+		servers:     make(map[string]*sdk.Server),
+		volumes:     make(map[string]*sdk.Volume),
+		lans:        make(map[string]*sdk.Lan),
+		nlbs:        make(map[string]*sdk.NetworkLoadBalancer),
+		natGateways: make(map[string]*sdk.NatGateway),
+		albs:        make(map[string]*sdk.ApplicationLoadBalancer),
+	}
+}
+
+// getDatacenter returns the data center with the given ID. The caller must hold the lock.
+func (c *Client) getDatacenter(datacenterID string) (*datacenter, error) {
+	if datacenterID == "" {
+		return nil, emptyID("data center")
+	}
+	dc, ok := c.datacenters[datacenterID]
+	if !ok {
+		return nil, notFound("data center", datacenterID)
+	}
+	return dc, nil
+}
+
+// CreateDatacenter creates a new data center with the provided properties, returning the request location.
+func (c *Client) CreateDatacenter(_ context.Context, properties sdk.DatacenterProperties) (string, error) {
+	if location := properties.GetLocation(); location == nil || *location == "" {
+		return "", errors.New("location must be set")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	dc := newDatacenter(id, properties, busy())
+	c.datacenters[id] = dc
+	return c.enqueue(http.MethodPost, "datacenters", sdk.Datacenter{Properties: &properties}, sdk.DATACENTER, id,
+		func() { dc.datacenter.Metadata = available() })
+}
+
+// ListDatacenters returns a list of data centers.
+func (c *Client) ListDatacenters(_ context.Context) (*sdk.Datacenters, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := make([]sdk.Datacenter, 0, len(c.datacenters))
+	for _, dc := range c.datacenters {
+		items = append(items, *clone(&dc.datacenter))
+	}
+	slices.SortFunc(items, func(a, b sdk.Datacenter) int { return strings.Compare(*a.Id, *b.Id) })
+	return &sdk.Datacenters{Items: &items}, nil
+}
+
+// GetDatacenter returns the data center that matches the provided datacenterID.
+func (c *Client) GetDatacenter(_ context.Context, datacenterID string) (*sdk.Datacenter, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	return clone(&dc.datacenter), nil
+}
+
+// DeleteDatacenter deletes the data center that matches the provided datacenterID, returning the request location.
+func (c *Client) DeleteDatacenter(_ context.Context, datacenterID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getDatacenter(datacenterID); err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, path.Join("datacenters", datacenterID), nil, sdk.DATACENTER, datacenterID,
+		func() {
+			delete(c.datacenters, datacenterID)
+			delete(c.labels, datacenterID)
+		})
+}
+
+// ListLabels returns a list of the labels of all resources.
+func (c *Client) ListLabels(_ context.Context) (*sdk.Labels, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var items []sdk.Label
+	for id, res := range c.labels {
+		for key, value := range res.labels {
+			items = append(items, sdk.Label{
+				Id: ptr.To("urn:label:" + string(res.resourceType) + ":" + id + ":" + key),
+				Properties: &sdk.LabelProperties{
+					Key:          ptr.To(key),
+					Value:        ptr.To(value),
+					ResourceId:   ptr.To(id),
+					ResourceType: ptr.To(string(res.resourceType)),
+					ResourceHref: ptr.To(res.href),
+				},
+			})
+		}
+	}
+	slices.SortFunc(items, func(a, b sdk.Label) int { return strings.Compare(*a.Id, *b.Id) })
+	return &sdk.Labels{Items: &items}, nil
+}
+
+// ListDatacenterLabels returns a list of labels of the specified data center.
+func (c *Client) ListDatacenterLabels(_ context.Context, datacenterID string) (*sdk.LabelResources, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getDatacenter(datacenterID); err != nil {
+		return nil, err
+	}
+	return c.listLabels(datacenterID), nil
+}
+
+// CreateDatacenterLabel adds a label with the provided key and value to the specified data center.
+func (c *Client) CreateDatacenterLabel(_ context.Context, datacenterID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getDatacenter(datacenterID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.DATACENTER, path.Join("datacenters", datacenterID), datacenterID, key, value, false)
+}
+
+// UpdateDatacenterLabel updates the value of the label with the provided key of the specified data center.
+func (c *Client) UpdateDatacenterLabel(_ context.Context, datacenterID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getDatacenter(datacenterID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.DATACENTER, path.Join("datacenters", datacenterID), datacenterID, key, value, true)
+}
+
+// DeleteDatacenterLabel removes the label with the provided key from the specified data center.
+func (c *Client) DeleteDatacenterLabel(_ context.Context, datacenterID, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getDatacenter(datacenterID); err != nil {
+		return err
+	}
+	return c.deleteLabel(datacenterID, key)
+}
+
+// listLabels returns the labels of the resource with the given ID. The caller must hold the lock.
+func (c *Client) listLabels(resourceID string) *sdk.LabelResources {
+	items := []sdk.LabelResource{}
+	if res, ok := c.labels[resourceID]; ok {
+		for key, value := range res.labels {
+			items = append(items, sdk.LabelResource{
+				Id:         ptr.To(key),
+				Properties: &sdk.LabelResourceProperties{Key: ptr.To(key), Value: ptr.To(value)},
+			})
+		}
+	}
+	slices.SortFunc(items, func(a, b sdk.LabelResource) int { return strings.Compare(*a.Id, *b.Id) })
+	return &sdk.LabelResources{Items: &items}
+}
+
+// setLabel creates or updates a label of a resource. Like the Cloud API, creating an existing label
+// or updating a missing one fails. The caller must hold the lock.
+func (c *Client) setLabel(resourceType sdk.Type, resourcePath, resourceID, key, value string, update bool) error {
+	if key == "" {
+		return errors.New("label key is empty")
+	}
+	res, ok := c.labels[resourceID]
+	if !ok {
+		res = &resourceLabels{resourceType: resourceType, href: baseURL + resourcePath, labels: map[string]string{}}
+		c.labels[resourceID] = res
+	}
+	if _, exists := res.labels[key]; exists != update {
+		if update {
+			return notFound("label", key)
+		}
+		return badRequest("label %s already exists", key)
+	}
+	res.labels[key] = value
+	return nil
+}
+
+// deleteLabel removes a label of a resource. The caller must hold the lock.
+func (c *Client) deleteLabel(resourceID, key string) error {
+	if key == "" {
+		return errors.New("label key is empty")
+	}
+	res, ok := c.labels[resourceID]
+	if !ok {
+		return notFound("label", key)
+	}
+	if _, exists := res.labels[key]; !exists {
+		return notFound("label", key)
+	}
+	delete(res.labels, key)
+	return nil
+}
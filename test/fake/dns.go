@@ -0,0 +1,153 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+var _ ionoscloud.DNS = (*DNS)(nil)
+
+// DNS is an in-memory implementation of ionoscloud.DNS. Zones must be added with AddZone.
+// It is safe for concurrent use.
+type DNS struct {
+	mu      sync.Mutex
+	zoneIDs map[string]string
+	records map[string]map[string]ionoscloud.DNSRecord
+}
+
+// NewDNS creates a fake DNS without any zones.
+func NewDNS() *DNS {
+	return &DNS{zoneIDs: make(map[string]string), records: make(map[string]map[string]ionoscloud.DNSRecord)}
+}
+
+// AddZone adds an empty zone with the given name and returns its ID.
+func (d *DNS) AddZone(zoneName string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id := uuid.New().String()
+	d.zoneIDs[zoneName] = id
+	d.records[id] = make(map[string]ionoscloud.DNSRecord)
+	return id
+}
+
+// Records returns all records of the zone with the given ID, sorted by name and type.
+func (d *DNS) Records(zoneID string) []ionoscloud.DNSRecord {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	records := make([]ionoscloud.DNSRecord, 0, len(d.records[zoneID]))
+	for _, record := range d.records[zoneID] {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Type < records[j].Type
+	})
+	return records
+}
+
+// GetZoneID returns the ID of the zone with the given name.
+func (d *DNS) GetZoneID(_ context.Context, zoneName string) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	id, ok := d.zoneIDs[zoneName]
+	if !ok {
+		return "", fmt.Errorf("zone %s not found", zoneName)
+	}
+	return id, nil
+}
+
+// ListRecords returns the records of the zone with the given name.
+func (d *DNS) ListRecords(_ context.Context, zoneID, name string) ([]ionoscloud.DNSRecord, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	zone, err := d.zone(zoneID)
+	if err != nil {
+		return nil, err
+	}
+	var records []ionoscloud.DNSRecord
+	for _, record := range zone {
+		if record.Name == name {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// CreateRecord stores the record with a new ID.
+func (d *DNS) CreateRecord(_ context.Context, zoneID string, record ionoscloud.DNSRecord) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	zone, err := d.zone(zoneID)
+	if err != nil {
+		return "", err
+	}
+	record.ID = uuid.New().String()
+	zone[record.ID] = record
+	return record.ID, nil
+}
+
+// UpdateRecord replaces the stored record with the ID of the given record.
+func (d *DNS) UpdateRecord(_ context.Context, zoneID string, record ionoscloud.DNSRecord) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	zone, err := d.zone(zoneID)
+	if err != nil {
+		return err
+	}
+	if _, ok := zone[record.ID]; !ok {
+		return fmt.Errorf("record %s not found", record.ID)
+	}
+	zone[record.ID] = record
+	return nil
+}
+
+// DeleteRecord deletes the record with the given ID.
+func (d *DNS) DeleteRecord(_ context.Context, zoneID, recordID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	zone, err := d.zone(zoneID)
+	if err != nil {
+		return err
+	}
+	delete(zone, recordID)
+	return nil
+}
+
+func (d *DNS) zone(id string) (map[string]ionoscloud.DNSRecord, error) {
+	zone, ok := d.records[id]
+	if !ok {
+		return nil, fmt.Errorf("zone %s not found", id)
+	}
+	return zone, nil
+}
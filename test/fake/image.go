@@ -0,0 +1,207 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+// AddImage adds an image with the given properties and returns its ID.
+func (c *Client) AddImage(properties sdk.ImageProperties) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.addImage(properties)
+}
+
+func (c *Client) addImage(properties sdk.ImageProperties) string {
+	id := uuid.NewString()
+	c.images[id] = &sdk.Image{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.IMAGE),
+		Href:       ptr.To(baseURL + path.Join("images", id)),
+		Metadata:   available(),
+		Properties: clone(&properties),
+	}
+	return id
+}
+
+// AddSnapshot adds a snapshot with the given properties and returns its ID.
+func (c *Client) AddSnapshot(properties sdk.SnapshotProperties) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	c.snapshots[id] = &sdk.Snapshot{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.SNAPSHOT),
+		Href:       ptr.To(baseURL + path.Join("snapshots", id)),
+		Metadata:   available(),
+		Properties: clone(&properties),
+	}
+	return id
+}
+
+// AddTemplate adds a template for CUBE servers with the given properties and returns its ID.
+func (c *Client) AddTemplate(properties sdk.TemplateProperties) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	c.templates = append(c.templates, sdk.Template{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.TEMPLATE),
+		Href:       ptr.To(baseURL + path.Join("templates", id)),
+		Metadata:   available(),
+		Properties: clone(&properties),
+	})
+	return id
+}
+
+// ListTemplates returns a list of templates, which are used to create CUBE servers.
+func (c *Client) ListTemplates(_ context.Context) (*sdk.Templates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := make([]sdk.Template, 0, len(c.templates))
+	for i := range c.templates {
+		items = append(items, *clone(&c.templates[i]))
+	}
+	return &sdk.Templates{Items: &items}, nil
+}
+
+// GetImage returns the image that matches the provided imageID.
+func (c *Client) GetImage(_ context.Context, imageID string) (*sdk.Image, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if imageID == "" {
+		return nil, emptyID("image")
+	}
+	image, ok := c.images[imageID]
+	if !ok {
+		return nil, notFound("image", imageID)
+	}
+	return clone(image), nil
+}
+
+// ListImages returns a list of all images, which are accessible with the credentials.
+func (c *Client) ListImages(_ context.Context) (*sdk.Images, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := values(c.images, func(i *sdk.Image) string { return *i.Id })
+	return &sdk.Images{Items: &items}, nil
+}
+
+// PatchImage updates the private image that matches the provided imageID with the provided properties,
+// returning the request location.
+func (c *Client) PatchImage(_ context.Context, imageID string, properties sdk.ImageProperties) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if imageID == "" {
+		return "", emptyID("image")
+	}
+	image, ok := c.images[imageID]
+	if !ok {
+		return "", notFound("image", imageID)
+	}
+	if ptr.Deref(image.Properties.Public, false) {
+		return "", badRequest("public image %s can't be updated", imageID)
+	}
+	patch := clone(&properties)
+	image.Metadata = busy()
+	return c.enqueue(http.MethodPatch, path.Join("images", imageID), patch, sdk.IMAGE, imageID,
+		func() {
+			merge(image.Properties, patch)
+			image.Metadata = available()
+		})
+}
+
+// DeleteImage deletes the private image that matches the provided imageID, returning the request location.
+func (c *Client) DeleteImage(_ context.Context, imageID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if imageID == "" {
+		return "", emptyID("image")
+	}
+	image, ok := c.images[imageID]
+	if !ok {
+		return "", notFound("image", imageID)
+	}
+	if ptr.Deref(image.Properties.Public, false) {
+		return "", badRequest("public image %s can't be deleted", imageID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join("images", imageID), nil, sdk.IMAGE, imageID,
+		func() { delete(c.images, imageID) })
+}
+
+// UploadImage creates a private CD-ROM image with the provided name in the given location.
+// Unlike the Cloud API, the image is available right away.
+func (c *Client) UploadImage(_ context.Context, location, name string, data []byte) error {
+	if location == "" {
+		return errors.New("location must be set")
+	}
+	if name == "" {
+		return errors.New("name must be set")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.addImage(sdk.ImageProperties{
+		Name:      ptr.To(name),
+		Location:  ptr.To(location),
+		ImageType: ptr.To("CDROM"),
+		Size:      ptr.To(float32(len(data)) / (1 << 30)),
+		Public:    ptr.To(false),
+	})
+	return nil
+}
+
+// ListSnapshots returns a list of all snapshots, which are accessible with the credentials.
+func (c *Client) ListSnapshots(_ context.Context) (*sdk.Snapshots, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := values(c.snapshots, func(s *sdk.Snapshot) string { return *s.Id })
+	return &sdk.Snapshots{Items: &items}, nil
+}
+
+// GetSnapshot returns the snapshot that matches the provided snapshotID.
+func (c *Client) GetSnapshot(_ context.Context, snapshotID string) (*sdk.Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if snapshotID == "" {
+		return nil, emptyID("snapshot")
+	}
+	snapshot, ok := c.snapshots[snapshotID]
+	if !ok {
+		return nil, notFound("snapshot", snapshotID)
+	}
+	return clone(snapshot), nil
+}
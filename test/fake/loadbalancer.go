@@ -0,0 +1,430 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func nlbPath(datacenterID, loadBalancerID string) string {
+	return path.Join("datacenters", datacenterID, "networkloadbalancers", loadBalancerID)
+}
+
+func natGatewayPath(datacenterID, natGatewayID string) string {
+	return path.Join("datacenters", datacenterID, "natgateways", natGatewayID)
+}
+
+func albPath(datacenterID, loadBalancerID string) string {
+	return path.Join("datacenters", datacenterID, "applicationloadbalancers", loadBalancerID)
+}
+
+// CreateNetworkLoadBalancer creates a new Network Load Balancer with the provided properties and entities in the
+// specified data center, returning the request location.
+func (c *Client) CreateNetworkLoadBalancer(
+	_ context.Context, datacenterID string,
+	properties sdk.NetworkLoadBalancerProperties, entities sdk.NetworkLoadBalancerEntities,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.NewString()
+	nlb := &sdk.NetworkLoadBalancer{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.NETWORKLOADBALANCER),
+		Href:       ptr.To(baseURL + nlbPath(datacenterID, id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+		Entities:   clone(&entities),
+	}
+	if rules := nlb.Entities.Forwardingrules; rules != nil && rules.Items != nil {
+		for i := range *rules.Items {
+			rule := &(*rules.Items)[i]
+			ruleID := uuid.NewString()
+			rule.Id = ptr.To(ruleID)
+			rule.Type = ptr.To(sdk.FORWARDING_RULE)
+			rule.Href = ptr.To(baseURL + path.Join(nlbPath(datacenterID, id), "forwardingrules", ruleID))
+			rule.Metadata = available()
+		}
+	}
+	dc.nlbs[id] = nlb
+
+	body := sdk.NetworkLoadBalancer{Properties: &properties, Entities: &entities}
+	return c.enqueue(http.MethodPost, path.Join("datacenters", datacenterID, "networkloadbalancers"), body,
+		sdk.NETWORKLOADBALANCER, id, func() { nlb.Metadata = available() })
+}
+
+// ListNetworkLoadBalancers returns a list of Network Load Balancers in the specified data center.
+func (c *Client) ListNetworkLoadBalancers(_ context.Context, datacenterID string) (*sdk.NetworkLoadBalancers, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	items := values(dc.nlbs, func(l *sdk.NetworkLoadBalancer) string { return *l.Id })
+	return &sdk.NetworkLoadBalancers{Items: &items}, nil
+}
+
+// getNetworkLoadBalancer returns the Network Load Balancer with the given ID and its data center.
+// The caller must hold the lock.
+func (c *Client) getNetworkLoadBalancer(
+	datacenterID, loadBalancerID string,
+) (*datacenter, *sdk.NetworkLoadBalancer, error) {
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if loadBalancerID == "" {
+		return nil, nil, emptyID("Network Load Balancer")
+	}
+	nlb, ok := dc.nlbs[loadBalancerID]
+	if !ok {
+		return nil, nil, notFound("Network Load Balancer", loadBalancerID)
+	}
+	return dc, nlb, nil
+}
+
+// GetNetworkLoadBalancer returns the Network Load Balancer that matches the provided loadBalancerID
+// in the specified data center.
+func (c *Client) GetNetworkLoadBalancer(
+	_ context.Context, datacenterID, loadBalancerID string,
+) (*sdk.NetworkLoadBalancer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, nlb, err := c.getNetworkLoadBalancer(datacenterID, loadBalancerID)
+	if err != nil {
+		return nil, err
+	}
+	return clone(nlb), nil
+}
+
+// DeleteNetworkLoadBalancer deletes the Network Load Balancer that matches the provided loadBalancerID
+// in the specified data center, returning the request location.
+func (c *Client) DeleteNetworkLoadBalancer(_ context.Context, datacenterID, loadBalancerID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, _, err := c.getNetworkLoadBalancer(datacenterID, loadBalancerID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, nlbPath(datacenterID, loadBalancerID), nil,
+		sdk.NETWORKLOADBALANCER, loadBalancerID, func() { delete(dc.nlbs, loadBalancerID) })
+}
+
+// PatchNetworkLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+// Network Load Balancer with the provided properties, returning the request location.
+func (c *Client) PatchNetworkLoadBalancerForwardingRule(
+	_ context.Context, datacenterID, loadBalancerID, ruleID string,
+	properties sdk.NetworkLoadBalancerForwardingRuleProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, nlb, err := c.getNetworkLoadBalancer(datacenterID, loadBalancerID)
+	if err != nil {
+		return "", err
+	}
+	var rule *sdk.NetworkLoadBalancerForwardingRule
+	if rules := nlb.Entities.GetForwardingrules(); rules != nil && rules.Items != nil {
+		for i := range *rules.Items {
+			if r := &(*rules.Items)[i]; *r.Id == ruleID {
+				rule = r
+			}
+		}
+	}
+	if rule == nil {
+		return "", notFound("forwarding rule", ruleID)
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, path.Join(nlbPath(datacenterID, loadBalancerID), "forwardingrules", ruleID),
+		patch, sdk.NETWORKLOADBALANCER, loadBalancerID, func() { merge(rule.Properties, patch) })
+}
+
+// CreateNATGateway creates a new NAT Gateway with the provided properties and entities in the
+// specified data center, returning the request location.
+func (c *Client) CreateNATGateway(
+	_ context.Context, datacenterID string, properties sdk.NatGatewayProperties, entities sdk.NatGatewayEntities,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.NewString()
+	natGateway := &sdk.NatGateway{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.NATGATEWAY),
+		Href:       ptr.To(baseURL + natGatewayPath(datacenterID, id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+		Entities:   clone(&entities),
+	}
+	if rules := natGateway.Entities.Rules; rules != nil && rules.Items != nil {
+		for i := range *rules.Items {
+			rule := &(*rules.Items)[i]
+			ruleID := uuid.NewString()
+			rule.Id = ptr.To(ruleID)
+			rule.Type = ptr.To(sdk.NATGATEWAY_RULE)
+			rule.Href = ptr.To(baseURL + path.Join(natGatewayPath(datacenterID, id), "rules", ruleID))
+			rule.Metadata = available()
+		}
+	}
+	dc.natGateways[id] = natGateway
+
+	body := sdk.NatGateway{Properties: &properties, Entities: &entities}
+	return c.enqueue(http.MethodPost, path.Join("datacenters", datacenterID, "natgateways"), body,
+		sdk.NATGATEWAY, id, func() { natGateway.Metadata = available() })
+}
+
+// ListNATGateways returns a list of NAT Gateways in the specified data center.
+func (c *Client) ListNATGateways(_ context.Context, datacenterID string) (*sdk.NatGateways, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	items := values(dc.natGateways, func(g *sdk.NatGateway) string { return *g.Id })
+	return &sdk.NatGateways{Items: &items}, nil
+}
+
+// DeleteNATGateway deletes the NAT Gateway that matches the provided natGatewayID
+// in the specified data center, returning the request location.
+func (c *Client) DeleteNATGateway(_ context.Context, datacenterID, natGatewayID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return "", err
+	}
+	if natGatewayID == "" {
+		return "", emptyID("NAT Gateway")
+	}
+	if _, ok := dc.natGateways[natGatewayID]; !ok {
+		return "", notFound("NAT Gateway", natGatewayID)
+	}
+	return c.enqueue(http.MethodDelete, natGatewayPath(datacenterID, natGatewayID), nil,
+		sdk.NATGATEWAY, natGatewayID, func() { delete(dc.natGateways, natGatewayID) })
+}
+
+// CreateApplicationLoadBalancer creates a new Application Load Balancer with the provided properties and
+// entities in the specified data center, returning the request location.
+func (c *Client) CreateApplicationLoadBalancer(
+	_ context.Context, datacenterID string,
+	properties sdk.ApplicationLoadBalancerProperties, entities sdk.ApplicationLoadBalancerEntities,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return "", err
+	}
+
+	id := uuid.NewString()
+	alb := &sdk.ApplicationLoadBalancer{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.APPLICATIONLOADBALANCER),
+		Href:       ptr.To(baseURL + albPath(datacenterID, id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+		Entities:   clone(&entities),
+	}
+	if rules := alb.Entities.Forwardingrules; rules != nil && rules.Items != nil {
+		for i := range *rules.Items {
+			rule := &(*rules.Items)[i]
+			ruleID := uuid.NewString()
+			rule.Id = ptr.To(ruleID)
+			rule.Type = ptr.To(sdk.FORWARDING_RULE)
+			rule.Href = ptr.To(baseURL + path.Join(albPath(datacenterID, id), "forwardingrules", ruleID))
+			rule.Metadata = available()
+		}
+	}
+	dc.albs[id] = alb
+
+	body := sdk.ApplicationLoadBalancer{Properties: &properties, Entities: &entities}
+	return c.enqueue(http.MethodPost, path.Join("datacenters", datacenterID, "applicationloadbalancers"), body,
+		sdk.APPLICATIONLOADBALANCER, id, func() { alb.Metadata = available() })
+}
+
+// ListApplicationLoadBalancers returns a list of Application Load Balancers in the specified data center.
+func (c *Client) ListApplicationLoadBalancers(
+	_ context.Context, datacenterID string,
+) (*sdk.ApplicationLoadBalancers, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	items := values(dc.albs, func(l *sdk.ApplicationLoadBalancer) string { return *l.Id })
+	return &sdk.ApplicationLoadBalancers{Items: &items}, nil
+}
+
+// getApplicationLoadBalancer returns the Application Load Balancer with the given ID and its data center.
+// The caller must hold the lock.
+func (c *Client) getApplicationLoadBalancer(
+	datacenterID, loadBalancerID string,
+) (*datacenter, *sdk.ApplicationLoadBalancer, error) {
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if loadBalancerID == "" {
+		return nil, nil, emptyID("Application Load Balancer")
+	}
+	alb, ok := dc.albs[loadBalancerID]
+	if !ok {
+		return nil, nil, notFound("Application Load Balancer", loadBalancerID)
+	}
+	return dc, alb, nil
+}
+
+// DeleteApplicationLoadBalancer deletes the Application Load Balancer that matches the provided loadBalancerID
+// in the specified data center, returning the request location.
+func (c *Client) DeleteApplicationLoadBalancer(
+	_ context.Context, datacenterID, loadBalancerID string,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, _, err := c.getApplicationLoadBalancer(datacenterID, loadBalancerID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, albPath(datacenterID, loadBalancerID), nil,
+		sdk.APPLICATIONLOADBALANCER, loadBalancerID, func() { delete(dc.albs, loadBalancerID) })
+}
+
+// PatchApplicationLoadBalancerForwardingRule patches the forwarding rule that matches ruleID of the specified
+// Application Load Balancer with the provided properties, returning the request location.
+func (c *Client) PatchApplicationLoadBalancerForwardingRule(
+	_ context.Context, datacenterID, loadBalancerID, ruleID string,
+	properties sdk.ApplicationLoadBalancerForwardingRuleProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, alb, err := c.getApplicationLoadBalancer(datacenterID, loadBalancerID)
+	if err != nil {
+		return "", err
+	}
+	var rule *sdk.ApplicationLoadBalancerForwardingRule
+	if rules := alb.Entities.GetForwardingrules(); rules != nil && rules.Items != nil {
+		for i := range *rules.Items {
+			if r := &(*rules.Items)[i]; *r.Id == ruleID {
+				rule = r
+			}
+		}
+	}
+	if rule == nil {
+		return "", notFound("forwarding rule", ruleID)
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, path.Join(albPath(datacenterID, loadBalancerID), "forwardingrules", ruleID),
+		patch, sdk.APPLICATIONLOADBALANCER, loadBalancerID, func() { merge(rule.Properties, patch) })
+}
+
+// CreateTargetGroup creates a new target group with the provided properties, returning the request location.
+func (c *Client) CreateTargetGroup(_ context.Context, properties sdk.TargetGroupProperties) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	targetGroup := &sdk.TargetGroup{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.TARGET_GROUP),
+		Href:       ptr.To(baseURL + path.Join("targetgroups", id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+	}
+	c.targetGroups[id] = targetGroup
+	return c.enqueue(http.MethodPost, "targetgroups", sdk.TargetGroup{Properties: &properties},
+		sdk.TARGET_GROUP, id, func() { targetGroup.Metadata = available() })
+}
+
+// ListTargetGroups returns a list of target groups.
+func (c *Client) ListTargetGroups(_ context.Context) (*sdk.TargetGroups, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := values(c.targetGroups, func(g *sdk.TargetGroup) string { return *g.Id })
+	return &sdk.TargetGroups{Items: &items}, nil
+}
+
+// PatchTargetGroup patches the target group that matches the provided targetGroupID with the provided
+// properties, returning the request location.
+func (c *Client) PatchTargetGroup(
+	_ context.Context, targetGroupID string, properties sdk.TargetGroupProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	targetGroup, err := c.getTargetGroup(targetGroupID)
+	if err != nil {
+		return "", err
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, path.Join("targetgroups", targetGroupID), patch,
+		sdk.TARGET_GROUP, targetGroupID, func() { merge(targetGroup.Properties, patch) })
+}
+
+// DeleteTargetGroup deletes the target group that matches the provided targetGroupID,
+// returning the request location.
+func (c *Client) DeleteTargetGroup(_ context.Context, targetGroupID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getTargetGroup(targetGroupID); err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, path.Join("targetgroups", targetGroupID), nil,
+		sdk.TARGET_GROUP, targetGroupID, func() { delete(c.targetGroups, targetGroupID) })
+}
+
+// getTargetGroup returns the target group with the given ID. The caller must hold the lock.
+func (c *Client) getTargetGroup(targetGroupID string) (*sdk.TargetGroup, error) {
+	if targetGroupID == "" {
+		return nil, emptyID("target group")
+	}
+	targetGroup, ok := c.targetGroups[targetGroupID]
+	if !ok {
+		return nil, notFound("target group", targetGroupID)
+	}
+	return targetGroup, nil
+}
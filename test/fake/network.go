@@ -0,0 +1,303 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+func lanPath(datacenterID, lanID string) string {
+	return path.Join("datacenters", datacenterID, "lans", lanID)
+}
+
+// getLAN returns the LAN with the given ID and its data center. The caller must hold the lock.
+func (c *Client) getLAN(datacenterID, lanID string) (*datacenter, *sdk.Lan, error) {
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lanID == "" {
+		return nil, nil, emptyID("LAN")
+	}
+	lan, ok := dc.lans[lanID]
+	if !ok {
+		return nil, nil, notFound("LAN", lanID)
+	}
+	return dc, lan, nil
+}
+
+// CreateLAN creates a new LAN with the provided properties in the specified data center.
+// LANs are numbered consecutively per data center, like in the Cloud API.
+func (c *Client) CreateLAN(_ context.Context, datacenterID string, properties sdk.LanPropertiesPost) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return "", err
+	}
+
+	dc.lanSeq++
+	id := strconv.Itoa(dc.lanSeq)
+	props := clone(&properties)
+	lan := &sdk.Lan{
+		Id:       ptr.To(id),
+		Type:     ptr.To(sdk.LAN),
+		Href:     ptr.To(baseURL + lanPath(datacenterID, id)),
+		Metadata: busy(),
+		Properties: &sdk.LanProperties{
+			Name:          props.Name,
+			Public:        props.Public,
+			IpFailover:    props.IpFailover,
+			Ipv6CidrBlock: lanIPv6CIDRBlock(id, props.Ipv6CidrBlock),
+			Pcc:           props.Pcc,
+		},
+	}
+	dc.lans[id] = lan
+	return c.enqueue(http.MethodPost, path.Join("datacenters", datacenterID, "lans"),
+		sdk.LanPost{Properties: &properties}, sdk.LAN, id, func() { lan.Metadata = available() })
+}
+
+// PatchLAN patches the LAN that matches lanID in the specified data center with the provided properties,
+// returning the request location.
+func (c *Client) PatchLAN(_ context.Context, datacenterID, lanID string, properties sdk.LanProperties) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, lan, err := c.getLAN(datacenterID, lanID)
+	if err != nil {
+		return "", err
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, lanPath(datacenterID, lanID), patch, sdk.LAN, lanID,
+		func() {
+			merge(lan.Properties, patch)
+			lan.Properties.Ipv6CidrBlock = lanIPv6CIDRBlock(lanID, lan.Properties.Ipv6CidrBlock)
+		})
+}
+
+// lanIPv6CIDRBlock returns the IPv6 CIDR block of the LAN. Like the Cloud API, a /64 block is delegated
+// to the LAN, if the block is requested automatically.
+func lanIPv6CIDRBlock(lanID string, requested *string) *string {
+	if ptr.Deref(requested, "") != "AUTO" {
+		return requested
+	}
+	return ptr.To(fmt.Sprintf("2001:db8:0:%s::/64", lanID))
+}
+
+// ListLANs returns a list of LANs in the specified data center.
+func (c *Client) ListLANs(_ context.Context, datacenterID string) (*sdk.Lans, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	items := values(dc.lans, func(l *sdk.Lan) string { return fmt.Sprintf("%08s", *l.Id) })
+	for i := range items {
+		items[i].Entities = &sdk.LanEntities{Nics: &sdk.LanNics{Items: ptr.To(dc.lanNICs(*items[i].Id))}}
+	}
+	return &sdk.Lans{Items: &items}, nil
+}
+
+// lanNICs returns copies of the NICs of all servers, which are connected to the LAN.
+func (dc *datacenter) lanNICs(lanID string) []sdk.Nic {
+	servers := values(dc.servers, func(s *sdk.Server) string { return *s.Id })
+	nics := []sdk.Nic{}
+	for _, server := range servers {
+		for _, nic := range *server.Entities.Nics.Items {
+			if lan := nic.Properties.GetLan(); lan != nil && strconv.Itoa(int(*lan)) == lanID {
+				nics = append(nics, nic)
+			}
+		}
+	}
+	return nics
+}
+
+// DeleteLAN deletes the LAN that matches the provided lanID in the specified data center,
+// returning the request location.
+func (c *Client) DeleteLAN(_ context.Context, datacenterID, lanID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, _, err := c.getLAN(datacenterID, lanID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, lanPath(datacenterID, lanID), nil, sdk.LAN, lanID,
+		func() { delete(dc.lans, lanID) })
+}
+
+// ReserveIPBlock reserves an IP block with the provided properties in the specified location.
+// The IPs of the block are taken from 203.0.113.0/24, which is reserved for documentation.
+func (c *Client) ReserveIPBlock(_ context.Context, name, location string, size int32) (string, error) {
+	if location == "" {
+		return "", errors.New("location must be set")
+	}
+	if size <= 0 {
+		return "", errors.New("size must be greater than 0")
+	}
+	if name == "" {
+		return "", errors.New("name must be set")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ips := make([]string, 0, size)
+	for range size {
+		c.ipSeq++
+		ips = append(ips, fmt.Sprintf("203.0.113.%d", c.ipSeq%254+1))
+	}
+
+	id := uuid.NewString()
+	ipBlock := &sdk.IpBlock{
+		Id:       ptr.To(id),
+		Type:     ptr.To(sdk.IPBLOCK),
+		Href:     ptr.To(baseURL + path.Join("ipblocks", id)),
+		Metadata: busy(),
+		Properties: &sdk.IpBlockProperties{
+			Name:     ptr.To(name),
+			Location: ptr.To(location),
+			Size:     ptr.To(size),
+			Ips:      &ips,
+		},
+	}
+	c.ipBlocks[id] = ipBlock
+	body := sdk.IpBlock{Properties: &sdk.IpBlockProperties{Name: &name, Location: &location, Size: &size}}
+	return c.enqueue(http.MethodPost, "ipblocks", body, sdk.IPBLOCK, id, func() { ipBlock.Metadata = available() })
+}
+
+// GetIPBlock returns the IP block that matches the provided ipBlockID.
+func (c *Client) GetIPBlock(_ context.Context, ipBlockID string) (*sdk.IpBlock, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ipBlockID == "" {
+		return nil, emptyID("IP block")
+	}
+	ipBlock, ok := c.ipBlocks[ipBlockID]
+	if !ok {
+		return nil, notFound("IP block", ipBlockID)
+	}
+	return clone(ipBlock), nil
+}
+
+// ListIPBlocks returns a list of IP blocks.
+func (c *Client) ListIPBlocks(_ context.Context) (*sdk.IpBlocks, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := values(c.ipBlocks, func(b *sdk.IpBlock) string { return *b.Id })
+	return &sdk.IpBlocks{Items: &items}, nil
+}
+
+// DeleteIPBlock deletes the IP block that matches the provided ipBlockID.
+func (c *Client) DeleteIPBlock(_ context.Context, ipBlockID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ipBlockID == "" {
+		return "", emptyID("IP block")
+	}
+	if _, ok := c.ipBlocks[ipBlockID]; !ok {
+		return "", notFound("IP block", ipBlockID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join("ipblocks", ipBlockID), nil, sdk.IPBLOCK, ipBlockID,
+		func() { delete(c.ipBlocks, ipBlockID) })
+}
+
+// CreateCrossConnect creates a new Cross Connect with the provided properties, returning the request location.
+// LANs are connected to it by setting their PCC property.
+func (c *Client) CreateCrossConnect(_ context.Context, properties sdk.PrivateCrossConnectProperties) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := uuid.NewString()
+	crossConnect := &sdk.PrivateCrossConnect{
+		Id:       ptr.To(id),
+		Type:     ptr.To(sdk.PCC),
+		Href:     ptr.To(baseURL + path.Join("pccs", id)),
+		Metadata: busy(),
+		Properties: &sdk.PrivateCrossConnectProperties{
+			Name:        properties.Name,
+			Description: properties.Description,
+		},
+	}
+	c.crossConnects[id] = crossConnect
+	return c.enqueue(http.MethodPost, "pccs", sdk.PrivateCrossConnect{Properties: &properties},
+		sdk.PCC, id, func() { crossConnect.Metadata = available() })
+}
+
+// ListCrossConnects returns a list of Cross Connects. The peers of each Cross Connect are the LANs,
+// which are connected to it.
+func (c *Client) ListCrossConnects(_ context.Context) (*sdk.PrivateCrossConnects, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items := values(c.crossConnects, func(p *sdk.PrivateCrossConnect) string { return *p.Id })
+	for i := range items {
+		peers := []sdk.Peer{}
+		for _, dc := range c.datacenters {
+			for _, lan := range dc.lans {
+				if ptr.Deref(lan.Properties.Pcc, "") != *items[i].Id {
+					continue
+				}
+				peers = append(peers, sdk.Peer{
+					Id:             lan.Id,
+					Name:           lan.Properties.Name,
+					DatacenterId:   dc.datacenter.Id,
+					DatacenterName: dc.datacenter.Properties.Name,
+					Location:       dc.datacenter.Properties.Location,
+				})
+			}
+		}
+		slices.SortFunc(peers, func(a, b sdk.Peer) int {
+			return strings.Compare(*a.DatacenterId+"/"+*a.Id, *b.DatacenterId+"/"+*b.Id)
+		})
+		items[i].Properties.Peers = &peers
+	}
+	return &sdk.PrivateCrossConnects{Items: &items}, nil
+}
+
+// DeleteCrossConnect deletes the Cross Connect that matches the provided crossConnectID,
+// returning the request location.
+func (c *Client) DeleteCrossConnect(_ context.Context, crossConnectID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if crossConnectID == "" {
+		return "", emptyID("Cross Connect")
+	}
+	if _, ok := c.crossConnects[crossConnectID]; !ok {
+		return "", notFound("Cross Connect", crossConnectID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join("pccs", crossConnectID), nil, sdk.PCC, crossConnectID,
+		func() { delete(c.crossConnects, crossConnectID) })
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/ionoscloud"
+)
+
+var _ ionoscloud.ObjectStorage = (*ObjectStorage)(nil)
+
+// ObjectStorage is an in-memory implementation of ionoscloud.ObjectStorage. Buckets are created on first use.
+// It is safe for concurrent use.
+type ObjectStorage struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket holds the objects and the lifecycle rules of a bucket.
+type bucket struct {
+	objects map[string][]byte
+	rules   map[string]ExpirationRule
+}
+
+// ExpirationRule is a lifecycle rule, which deletes objects with the prefix after the given number of days.
+type ExpirationRule struct {
+	Prefix string
+	Days   int32
+}
+
+// NewObjectStorage creates an empty fake Object Storage.
+func NewObjectStorage() *ObjectStorage {
+	return &ObjectStorage{buckets: make(map[string]*bucket)}
+}
+
+// Object returns the data of the object with the given key and whether it exists.
+func (o *ObjectStorage) Object(bucketName, key string) ([]byte, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	data, ok := o.bucket(bucketName).objects[key]
+	return slices.Clone(data), ok
+}
+
+// ExpirationRule returns the lifecycle rule with the given ID and whether it exists.
+func (o *ObjectStorage) ExpirationRule(bucketName, ruleID string) (ExpirationRule, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	rule, ok := o.bucket(bucketName).rules[ruleID]
+	return rule, ok
+}
+
+// PutObject stores the data as the object with the given key.
+func (o *ObjectStorage) PutObject(_ context.Context, bucketName, key string, data []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.bucket(bucketName).objects[key] = slices.Clone(data)
+	return nil
+}
+
+// DeleteObject deletes the object with the given key.
+func (o *ObjectStorage) DeleteObject(_ context.Context, bucketName, key string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	delete(o.bucket(bucketName).objects, key)
+	return nil
+}
+
+// PresignGetObject returns a URL of the object, which contains the expiry but no signature.
+func (*ObjectStorage) PresignGetObject(bucketName, key string, expiry time.Duration) (string, error) {
+	u := url.URL{
+		Scheme:   "https",
+		Host:     bucketName + ".s3.example.com",
+		Path:     "/" + key,
+		RawQuery: url.Values{"X-Amz-Expires": {fmt.Sprint(int64(expiry.Seconds()))}}.Encode(),
+	}
+	return u.String(), nil
+}
+
+// EnsureExpirationRule stores the lifecycle rule with the given ID.
+func (o *ObjectStorage) EnsureExpirationRule(_ context.Context, bucketName, ruleID, prefix string, days int32) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.bucket(bucketName).rules[ruleID] = ExpirationRule{Prefix: prefix, Days: days}
+	return nil
+}
+
+func (o *ObjectStorage) bucket(name string) *bucket {
+	b, ok := o.buckets[name]
+	if !ok {
+		b = &bucket{objects: make(map[string][]byte), rules: make(map[string]ExpirationRule)}
+		o.buckets[name] = b
+	}
+	return b
+}
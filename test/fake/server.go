@@ -0,0 +1,647 @@
+/*
+Copyright 2024 IONOS Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/google/uuid"
+	sdk "github.com/ionos-cloud/sdk-go/v6"
+
+	"github.com/ionos-cloud/cluster-api-provider-ionoscloud/internal/util/ptr"
+)
+
+const (
+	vmStateRunning = "RUNNING"
+	vmStateShutoff = "SHUTOFF"
+)
+
+func serverPath(datacenterID, serverID string) string {
+	return path.Join("datacenters", datacenterID, "servers", serverID)
+}
+
+func volumePath(datacenterID, volumeID string) string {
+	return path.Join("datacenters", datacenterID, "volumes", volumeID)
+}
+
+func nicPath(datacenterID, serverID, nicID string) string {
+	return path.Join(serverPath(datacenterID, serverID), "nics", nicID)
+}
+
+// getServer returns the server with the given ID and its data center. The caller must hold the lock.
+func (c *Client) getServer(datacenterID, serverID string) (*datacenter, *sdk.Server, error) {
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if serverID == "" {
+		return nil, nil, emptyID("server")
+	}
+	server, ok := dc.servers[serverID]
+	if !ok {
+		return nil, nil, notFound("server", serverID)
+	}
+	return dc, server, nil
+}
+
+// getNIC returns the NIC with the given ID of a server. The caller must hold the lock.
+func (c *Client) getNIC(datacenterID, serverID, nicID string) (*sdk.Nic, error) {
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if nicID == "" {
+		return nil, emptyID("NIC")
+	}
+	nics := server.Entities.Nics.Items
+	for i := range *nics {
+		if nic := &(*nics)[i]; *nic.Id == nicID {
+			return nic, nil
+		}
+	}
+	return nil, notFound("NIC", nicID)
+}
+
+// CreateServer creates a new server with provided properties in the specified data center.
+// The volumes and NICs of the server are created along with it.
+func (c *Client) CreateServer(
+	_ context.Context, datacenterID string, properties sdk.ServerProperties, entities sdk.ServerEntities,
+) (*sdk.Server, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body := sdk.Server{Properties: clone(&properties), Entities: clone(&entities)}
+
+	id := uuid.NewString()
+	server := &sdk.Server{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.SERVER),
+		Href:       ptr.To(baseURL + serverPath(datacenterID, id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+		Entities:   clone(&entities),
+	}
+	server.Properties.VmState = ptr.To(vmStateRunning)
+
+	if server.Entities.Volumes == nil {
+		server.Entities.Volumes = &sdk.AttachedVolumes{}
+	}
+	if server.Entities.Volumes.Items == nil {
+		server.Entities.Volumes.Items = &[]sdk.Volume{}
+	}
+	volumes := *server.Entities.Volumes.Items
+	for i := range volumes {
+		volume := &volumes[i]
+		volumeID := uuid.NewString()
+		volume.Id = ptr.To(volumeID)
+		volume.Type = ptr.To(sdk.VOLUME)
+		volume.Href = ptr.To(baseURL + volumePath(datacenterID, volumeID))
+		volume.Metadata = busy()
+		dc.volumes[volumeID] = clone(volume)
+	}
+	if len(volumes) > 0 && server.Properties.BootVolume == nil && server.Properties.BootCdrom == nil &&
+		ptr.Deref(volumes[0].Properties.GetBootOrder(), "") != "NONE" {
+		server.Properties.BootVolume = &sdk.ResourceReference{Id: ptr.To(*volumes[0].Id), Type: ptr.To(sdk.VOLUME)}
+	}
+
+	// Attached CD-ROMs are returned with the properties of their images.
+	for i, cdrom := range ptr.Deref(server.Entities.GetCdroms().GetItems(), nil) {
+		if image, ok := c.images[ptr.Deref(cdrom.Id, "")]; ok {
+			(*server.Entities.Cdroms.Items)[i] = *clone(image)
+		}
+	}
+
+	if server.Entities.Nics == nil {
+		server.Entities.Nics = &sdk.Nics{}
+	}
+	if server.Entities.Nics.Items == nil {
+		server.Entities.Nics.Items = &[]sdk.Nic{}
+	}
+	nics := *server.Entities.Nics.Items
+	for i := range nics {
+		nic := &nics[i]
+		nicID := uuid.NewString()
+		nic.Id = ptr.To(nicID)
+		nic.Type = ptr.To(sdk.NIC)
+		nic.Href = ptr.To(baseURL + nicPath(datacenterID, id, nicID))
+		nic.Metadata = busy()
+		if nic.Properties == nil {
+			nic.Properties = &sdk.NicProperties{}
+		}
+		c.ipSeq++
+		nic.Properties.Mac = ptr.To(fmt.Sprintf("02:01:%02x:%02x:%02x:%02x",
+			byte(c.ipSeq>>24), byte(c.ipSeq>>16), byte(c.ipSeq>>8), byte(c.ipSeq)))
+		if len(ptr.Deref(nic.Properties.Ips, nil)) == 0 && ptr.Deref(nic.Properties.Dhcp, true) {
+			nic.Properties.Ips = &[]string{fmt.Sprintf("192.0.2.%d", c.ipSeq%254+1)}
+		}
+		if rules := nic.Entities.GetFirewallrules(); rules != nil && rules.Items != nil {
+			for j := range *rules.Items {
+				assignFirewallRule(&(*rules.Items)[j], datacenterID, id, nicID)
+			}
+		}
+	}
+
+	dc.servers[id] = server
+	location, err := c.enqueue(http.MethodPost, path.Join("datacenters", datacenterID, "servers"), body,
+		sdk.SERVER, id, func() { markAvailable(dc, server) })
+	if err != nil {
+		return nil, "", err
+	}
+	return clone(server), location, nil
+}
+
+// markAvailable marks a server and all of its entities as available.
+func markAvailable(dc *datacenter, server *sdk.Server) {
+	server.Metadata = available()
+	for i := range *server.Entities.Volumes.Items {
+		volume := &(*server.Entities.Volumes.Items)[i]
+		volume.Metadata = available()
+		if v, ok := dc.volumes[*volume.Id]; ok {
+			v.Metadata = available()
+		}
+	}
+	for i := range *server.Entities.Nics.Items {
+		nic := &(*server.Entities.Nics.Items)[i]
+		nic.Metadata = available()
+		if rules := nic.Entities.GetFirewallrules(); rules != nil && rules.Items != nil {
+			for j := range *rules.Items {
+				(*rules.Items)[j].Metadata = available()
+			}
+		}
+	}
+}
+
+// ListServers returns a list with the servers in the specified data center.
+func (c *Client) ListServers(_ context.Context, datacenterID string) (*sdk.Servers, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	items := values(dc.servers, func(s *sdk.Server) string { return *s.Id })
+	return &sdk.Servers{Items: &items}, nil
+}
+
+// GetServer returns the server that matches the provided serverID in the specified data center.
+func (c *Client) GetServer(_ context.Context, datacenterID, serverID string) (*sdk.Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return clone(server), nil
+}
+
+// DeleteServer deletes the server that matches the provided serverID in the specified data center.
+// If deleteVolumes is true, the attached volumes are deleted as well.
+func (c *Client) DeleteServer(_ context.Context, datacenterID, serverID string, deleteVolumes bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, serverPath(datacenterID, serverID), nil, sdk.SERVER, serverID, func() {
+		if deleteVolumes {
+			for _, volume := range *server.Entities.Volumes.Items {
+				delete(dc.volumes, *volume.Id)
+				delete(c.labels, *volume.Id)
+			}
+		}
+		delete(dc.servers, serverID)
+		delete(c.labels, serverID)
+	})
+}
+
+// ListServerLabels returns a list of labels of the specified server.
+func (c *Client) ListServerLabels(_ context.Context, datacenterID, serverID string) (*sdk.LabelResources, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, err := c.getServer(datacenterID, serverID); err != nil {
+		return nil, err
+	}
+	return c.listLabels(serverID), nil
+}
+
+// CreateServerLabel adds a label with the provided key and value to the specified server.
+func (c *Client) CreateServerLabel(_ context.Context, datacenterID, serverID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, err := c.getServer(datacenterID, serverID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.SERVER, serverPath(datacenterID, serverID), serverID, key, value, false)
+}
+
+// UpdateServerLabel updates the value of the label with the provided key of the specified server.
+func (c *Client) UpdateServerLabel(_ context.Context, datacenterID, serverID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, err := c.getServer(datacenterID, serverID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.SERVER, serverPath(datacenterID, serverID), serverID, key, value, true)
+}
+
+// DeleteServerLabel removes the label with the provided key from the specified server.
+func (c *Client) DeleteServerLabel(_ context.Context, datacenterID, serverID, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, err := c.getServer(datacenterID, serverID); err != nil {
+		return err
+	}
+	return c.deleteLabel(serverID, key)
+}
+
+// StartServer starts the server that matches the provided serverID in the specified data center.
+func (c *Client) StartServer(_ context.Context, datacenterID, serverID string) (string, error) {
+	return c.serverAction(datacenterID, serverID, "start", vmStateRunning)
+}
+
+// StopServer stops the server that matches the provided serverID in the specified data center.
+func (c *Client) StopServer(_ context.Context, datacenterID, serverID string) (string, error) {
+	return c.serverAction(datacenterID, serverID, "stop", vmStateShutoff)
+}
+
+// RebootServer reboots the server that matches the provided serverID in the specified data center.
+func (c *Client) RebootServer(_ context.Context, datacenterID, serverID string) (string, error) {
+	return c.serverAction(datacenterID, serverID, "reboot", vmStateRunning)
+}
+
+// GetServerRemoteConsoleURL returns the URL of the remote console of the server that matches the provided
+// serverID in the specified data center.
+func (c *Client) GetServerRemoteConsoleURL(_ context.Context, datacenterID, serverID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, _, err := c.getServer(datacenterID, serverID); err != nil {
+		return "", err
+	}
+	return "https://dcd.ionos.com/console?token=" + serverID, nil
+}
+
+// serverAction requests an action for a server, which sets the VM state of the server once it is completed.
+func (c *Client) serverAction(datacenterID, serverID, action, vmState string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodPost, path.Join(serverPath(datacenterID, serverID), action), nil,
+		sdk.SERVER, serverID, func() { server.Properties.VmState = ptr.To(vmState) })
+}
+
+// PatchServer updates the server that matches the provided serverID in the specified data center
+// with the provided properties, returning the request location.
+func (c *Client) PatchServer(
+	_ context.Context, datacenterID, serverID string, properties sdk.ServerProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return "", err
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, serverPath(datacenterID, serverID), patch, sdk.SERVER, serverID,
+		func() {
+			merge(server.Properties, patch)
+			// The server boots either from a volume or from a CD-ROM.
+			if patch.BootVolume != nil {
+				server.Properties.BootCdrom = nil
+			}
+			if patch.BootCdrom != nil {
+				server.Properties.BootVolume = nil
+			}
+		})
+}
+
+// getVolume returns the volume with the given ID and its data center. The caller must hold the lock.
+func (c *Client) getVolume(datacenterID, volumeID string) (*datacenter, error) {
+	dc, err := c.getDatacenter(datacenterID)
+	if err != nil {
+		return nil, err
+	}
+	if volumeID == "" {
+		return nil, emptyID("volume")
+	}
+	if _, ok := dc.volumes[volumeID]; !ok {
+		return nil, notFound("volume", volumeID)
+	}
+	return dc, nil
+}
+
+// DeleteVolume deletes the volume that matches the provided volumeID in the specified data center.
+func (c *Client) DeleteVolume(_ context.Context, datacenterID, volumeID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, err := c.getVolume(datacenterID, volumeID)
+	if err != nil {
+		return "", err
+	}
+	return c.enqueue(http.MethodDelete, volumePath(datacenterID, volumeID), nil, sdk.VOLUME, volumeID, func() {
+		for _, server := range dc.servers {
+			detachVolume(server, volumeID)
+		}
+		delete(dc.volumes, volumeID)
+		delete(c.labels, volumeID)
+	})
+}
+
+// ListVolumeLabels returns a list of labels of the specified volume.
+func (c *Client) ListVolumeLabels(_ context.Context, datacenterID, volumeID string) (*sdk.LabelResources, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getVolume(datacenterID, volumeID); err != nil {
+		return nil, err
+	}
+	return c.listLabels(volumeID), nil
+}
+
+// CreateVolumeLabel adds a label with the provided key and value to the specified volume.
+func (c *Client) CreateVolumeLabel(_ context.Context, datacenterID, volumeID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getVolume(datacenterID, volumeID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.VOLUME, volumePath(datacenterID, volumeID), volumeID, key, value, false)
+}
+
+// UpdateVolumeLabel updates the value of the label with the provided key of the specified volume.
+func (c *Client) UpdateVolumeLabel(_ context.Context, datacenterID, volumeID, key, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getVolume(datacenterID, volumeID); err != nil {
+		return err
+	}
+	return c.setLabel(sdk.VOLUME, volumePath(datacenterID, volumeID), volumeID, key, value, true)
+}
+
+// DeleteVolumeLabel removes the label with the provided key from the specified volume.
+func (c *Client) DeleteVolumeLabel(_ context.Context, datacenterID, volumeID, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.getVolume(datacenterID, volumeID); err != nil {
+		return err
+	}
+	return c.deleteLabel(volumeID, key)
+}
+
+// CreateServerVolume creates a volume with the provided properties and attaches it to the server in the
+// specified data center, returning the volume and the request location.
+func (c *Client) CreateServerVolume(
+	_ context.Context, datacenterID, serverID string, properties sdk.VolumeProperties,
+) (*sdk.Volume, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dc, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	id := uuid.NewString()
+	volume := &sdk.Volume{
+		Id:         ptr.To(id),
+		Type:       ptr.To(sdk.VOLUME),
+		Href:       ptr.To(baseURL + volumePath(datacenterID, id)),
+		Metadata:   busy(),
+		Properties: clone(&properties),
+	}
+	dc.volumes[id] = volume
+	location, err := c.enqueue(http.MethodPost, path.Join(serverPath(datacenterID, serverID), "volumes"),
+		sdk.Volume{Properties: &properties}, sdk.VOLUME, id, func() {
+			volume.Metadata = available()
+			server.Entities.Volumes.Items = ptr.To(append(*server.Entities.Volumes.Items, *clone(volume)))
+		})
+	return clone(volume), location, err
+}
+
+// DetachVolume detaches the volume that matches the provided volumeID from the server in the specified
+// data center, returning the request location.
+func (c *Client) DetachVolume(_ context.Context, datacenterID, serverID, volumeID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return "", err
+	}
+	if !slices.ContainsFunc(*server.Entities.Volumes.Items, func(v sdk.Volume) bool { return *v.Id == volumeID }) {
+		return "", notFound("volume", volumeID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join(serverPath(datacenterID, serverID), "volumes", volumeID), nil,
+		sdk.VOLUME, volumeID, func() { detachVolume(server, volumeID) })
+}
+
+// detachVolume removes the volume from the entities of the server.
+func detachVolume(server *sdk.Server, volumeID string) {
+	volumes := slices.DeleteFunc(*server.Entities.Volumes.Items, func(v sdk.Volume) bool { return *v.Id == volumeID })
+	server.Entities.Volumes.Items = &volumes
+	if boot := server.Properties.BootVolume; boot != nil && ptr.Deref(boot.Id, "") == volumeID {
+		server.Properties.BootVolume = nil
+	}
+}
+
+// DetachCDROM ejects the CD-ROM image that matches the provided imageID from the server in the specified
+// data center, returning the request location.
+func (c *Client) DetachCDROM(_ context.Context, datacenterID, serverID, imageID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, server, err := c.getServer(datacenterID, serverID)
+	if err != nil {
+		return "", err
+	}
+	isImage := func(image sdk.Image) bool { return ptr.Deref(image.Id, "") == imageID }
+	cdroms := ptr.Deref(server.Entities.GetCdroms().GetItems(), nil)
+	if !slices.ContainsFunc(cdroms, isImage) {
+		return "", notFound("CD-ROM", imageID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join(serverPath(datacenterID, serverID), "cdroms", imageID), nil,
+		sdk.IMAGE, imageID, func() {
+			cdroms := slices.DeleteFunc(*server.Entities.Cdroms.Items, isImage)
+			server.Entities.Cdroms.Items = &cdroms
+			if boot := server.Properties.BootCdrom; boot != nil && ptr.Deref(boot.Id, "") == imageID {
+				server.Properties.BootCdrom = nil
+			}
+		})
+}
+
+// PatchNIC updates the NIC identified by nicID with the provided properties, returning the request location.
+func (c *Client) PatchNIC(
+	_ context.Context, datacenterID, serverID, nicID string, properties sdk.NicProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, err := c.getNIC(datacenterID, serverID, nicID)
+	if err != nil {
+		return "", err
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, nicPath(datacenterID, serverID, nicID), patch, sdk.NIC, nicID,
+		func() { merge(nic.Properties, patch) })
+}
+
+// ListFirewallRules returns a list of firewall rules of the NIC identified by nicID.
+func (c *Client) ListFirewallRules(
+	_ context.Context, datacenterID, serverID, nicID string,
+) (*sdk.FirewallRules, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, err := c.getNIC(datacenterID, serverID, nicID)
+	if err != nil {
+		return nil, err
+	}
+	if rules := nic.Entities.GetFirewallrules(); rules != nil {
+		return clone(rules), nil
+	}
+	return &sdk.FirewallRules{Items: &[]sdk.FirewallRule{}}, nil
+}
+
+// CreateFirewallRule creates a firewall rule with the provided properties on the NIC identified by nicID,
+// returning the request location.
+func (c *Client) CreateFirewallRule(
+	_ context.Context, datacenterID, serverID, nicID string, properties sdk.FirewallruleProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, err := c.getNIC(datacenterID, serverID, nicID)
+	if err != nil {
+		return "", err
+	}
+	rule := sdk.FirewallRule{Metadata: busy(), Properties: clone(&properties)}
+	assignFirewallRule(&rule, datacenterID, serverID, nicID)
+	ruleID := *rule.Id
+
+	if nic.Entities == nil {
+		nic.Entities = &sdk.NicEntities{}
+	}
+	if nic.Entities.Firewallrules == nil {
+		nic.Entities.Firewallrules = &sdk.FirewallRules{}
+	}
+	rules := append(ptr.Deref(nic.Entities.Firewallrules.Items, nil), rule)
+	nic.Entities.Firewallrules.Items = &rules
+
+	return c.enqueue(http.MethodPost, path.Join(nicPath(datacenterID, serverID, nicID), "firewallrules"),
+		sdk.FirewallRule{Properties: &properties}, sdk.FIREWALL_RULE, ruleID, func() {
+			if r := findFirewallRule(nic, ruleID); r != nil {
+				r.Metadata = available()
+			}
+		})
+}
+
+// PatchFirewallRule patches the firewall rule that matches ruleID of the NIC identified by nicID
+// with the provided properties, returning the request location.
+func (c *Client) PatchFirewallRule(
+	_ context.Context, datacenterID, serverID, nicID, ruleID string, properties sdk.FirewallruleProperties,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, err := c.getNIC(datacenterID, serverID, nicID)
+	if err != nil {
+		return "", err
+	}
+	if findFirewallRule(nic, ruleID) == nil {
+		return "", notFound("firewall rule", ruleID)
+	}
+	patch := clone(&properties)
+	return c.enqueue(http.MethodPatch, path.Join(nicPath(datacenterID, serverID, nicID), "firewallrules", ruleID),
+		patch, sdk.FIREWALL_RULE, ruleID, func() {
+			if r := findFirewallRule(nic, ruleID); r != nil {
+				merge(r.Properties, patch)
+			}
+		})
+}
+
+// DeleteFirewallRule deletes the firewall rule that matches ruleID of the NIC identified by nicID,
+// returning the request location.
+func (c *Client) DeleteFirewallRule(
+	_ context.Context, datacenterID, serverID, nicID, ruleID string,
+) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nic, err := c.getNIC(datacenterID, serverID, nicID)
+	if err != nil {
+		return "", err
+	}
+	if findFirewallRule(nic, ruleID) == nil {
+		return "", notFound("firewall rule", ruleID)
+	}
+	return c.enqueue(http.MethodDelete, path.Join(nicPath(datacenterID, serverID, nicID), "firewallrules", ruleID),
+		nil, sdk.FIREWALL_RULE, ruleID, func() {
+			rules := slices.DeleteFunc(*nic.Entities.Firewallrules.Items,
+				func(r sdk.FirewallRule) bool { return *r.Id == ruleID })
+			nic.Entities.Firewallrules.Items = &rules
+		})
+}
+
+// assignFirewallRule sets the ID and the references of a new firewall rule.
+func assignFirewallRule(rule *sdk.FirewallRule, datacenterID, serverID, nicID string) {
+	ruleID := uuid.NewString()
+	rule.Id = ptr.To(ruleID)
+	rule.Type = ptr.To(sdk.FIREWALL_RULE)
+	rule.Href = ptr.To(baseURL + path.Join(nicPath(datacenterID, serverID, nicID), "firewallrules", ruleID))
+	if rule.Metadata == nil {
+		rule.Metadata = busy()
+	}
+}
+
+func findFirewallRule(nic *sdk.Nic, ruleID string) *sdk.FirewallRule {
+	rules := nic.Entities.GetFirewallrules()
+	if rules == nil || rules.Items == nil {
+		return nil
+	}
+	for i := range *rules.Items {
+		if rule := &(*rules.Items)[i]; *rule.Id == ruleID {
+			return rule
+		}
+	}
+	return nil
+}